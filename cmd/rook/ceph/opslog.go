@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceph
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/cmd/rook/rook"
+	"github.com/rook/rook/pkg/daemon/ceph/opslog"
+	"github.com/spf13/cobra"
+)
+
+var opsLogCmd = &cobra.Command{
+	Use:   "ops-log",
+	Short: "Ships the RGW ops log to external sinks",
+	Long: `Print the records of the RGW ops log and ship them to the sinks configured in the
+ROOK_OPS_LOG_CONFIG env var. This runs in the ops-log sidecar of the RGW pods.`,
+}
+
+var (
+	opsLogFile      string
+	opsLogStatsPort int
+)
+
+func init() {
+	opsLogCmd.Flags().StringVar(&opsLogFile, "log-file", "", "path to the ops log file")
+	opsLogCmd.Flags().IntVar(&opsLogStatsPort, "stats-port", opslog.StatsPort, "port the delivery stats are served on")
+	if err := opsLogCmd.MarkFlagRequired("log-file"); err != nil {
+		panic(err)
+	}
+
+	opsLogCmd.RunE = runOpsLog
+}
+
+func runOpsLog(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(opsLogCmd.Flags())
+
+	config, err := opslog.ConfigFromEnv()
+	if err != nil {
+		rook.TerminateFatal(err)
+	}
+
+	statsListener, err := net.Listen("tcp", fmt.Sprintf(":%d", opsLogStatsPort))
+	if err != nil {
+		rook.TerminateFatal(errors.Wrap(err, "failed to listen on the stats port"))
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, os.Interrupt)
+	defer cancel()
+	if err := opslog.Run(ctx, opsLogFile, config, os.Stdout, statsListener); err != nil {
+		rook.TerminateFatal(errors.Wrap(err, "failed to ship the ops log"))
+	}
+	return nil
+}
@@ -379,14 +379,22 @@ func commonOSDInit(cmd *cobra.Command) {
 	clusterInfo.InternalMonitors = opcontroller.ParseMonEndpoints(cfg.monEndpoints)
 }
 
-// use zone/region/hostname labels in the crushmap
+// use zone/region/hostname labels, or the labels of the user-defined hierarchy, in the crushmap
 func getLocation(ctx context.Context, clientset kubernetes.Interface) (string, string, error) {
 	// get the value the operator instructed to use as the host name in the CRUSH map
 	hostNameLabel := os.Getenv("ROOK_CRUSHMAP_HOSTNAME")
 
 	rootLabel := os.Getenv(oposd.CrushRootVarName)
 
-	loc, topologyAffinity, err := oposd.GetLocationWithNode(ctx, clientset, os.Getenv(k8sutil.NodeNameEnvVar), rootLabel, hostNameLabel)
+	var crushTopology *cephv1.CrushTopologySpec
+	if value := os.Getenv(oposd.CrushTopologyVarName); value != "" {
+		crushTopology = &cephv1.CrushTopologySpec{}
+		if err := json.Unmarshal([]byte(value), crushTopology); err != nil {
+			return "", "", errors.Wrapf(err, "failed to parse crush topology %q", value)
+		}
+	}
+
+	loc, topologyAffinity, err := oposd.GetLocationWithNode(ctx, clientset, os.Getenv(k8sutil.NodeNameEnvVar), rootLabel, hostNameLabel, crushTopology)
 	if err != nil {
 		return "", "", err
 	}
@@ -66,6 +66,7 @@ rules:
       - cephfilesystems
       - cephnfses
       - cephnvmeofgateways
+      - cephnvmeofsubsystems
       - cephobjectstores
       - cephobjectstoreusers
       - cephobjectrealms
@@ -76,6 +77,7 @@ rules:
       - cephrbdmirrors
       - cephfilesystemmirrors
       - cephfilesystemsubvolumegroups
+      - cephfilesystemsubvolumes
       - cephblockpoolradosnamespaces
       - cephcosidrivers
     verbs:
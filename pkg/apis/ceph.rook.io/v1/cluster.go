@@ -16,6 +16,16 @@ limitations under the License.
 
 package v1
 
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultCanaryUpgradeSoakTime is the default soak period of a canary upgrade
+	DefaultCanaryUpgradeSoakTime = time.Hour
+)
+
 // RequireMsgr2 checks if the network settings require the msgr2 protocol
 func (c *ClusterSpec) RequireMsgr2() bool {
 	if c.Network.Connections == nil {
@@ -52,6 +62,57 @@ func (c *ClusterSpec) ZonesRequired() bool {
 	return c.IsStretchCluster() || len(c.Mon.Zones) > 0
 }
 
+// CanaryUpgrade returns the canary upgrade settings, or nil if the daemons are upgraded without canary
+func (c *ClusterSpec) CanaryUpgrade() *CanaryUpgradeSpec {
+	if c.UpgradeStrategy == nil {
+		return nil
+	}
+	return c.UpgradeStrategy.Canary
+}
+
+// MonCount returns the number of mons upgraded in the canary stage
+func (s *CanaryUpgradeSpec) MonCount() int {
+	return intOrDefault(s.Mons, 1)
+}
+
+// OSDHostCount returns the number of hosts per device class whose OSDs are upgraded in the canary stage
+func (s *CanaryUpgradeSpec) OSDHostCount() int {
+	return intOrDefault(s.OSDHostsPerDeviceClass, 1)
+}
+
+// RGWCount returns the number of object stores whose RGW daemons are upgraded in the canary stage
+func (s *CanaryUpgradeSpec) RGWCount() int {
+	return intOrDefault(s.RGWs, 1)
+}
+
+// SoakDuration returns the time to watch the cluster after the canary daemons are upgraded
+func (s *CanaryUpgradeSpec) SoakDuration() time.Duration {
+	if s.SoakTime == nil {
+		return DefaultCanaryUpgradeSoakTime
+	}
+	return s.SoakTime.Duration
+}
+
+func intOrDefault(value *int, defaultValue int) int {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// HoldsDaemons returns whether the daemons that are not canaries must keep their current version
+func (s *UpgradeStatus) HoldsDaemons() bool {
+	if s == nil {
+		return false
+	}
+	return s.Phase == UpgradePhaseCanary || s.Phase == UpgradePhaseSoaking || s.Phase == UpgradePhaseHalted
+}
+
+// IsCanary returns whether the daemon, e.g. "osd.3", is upgraded in the canary stage
+func (s *UpgradeStatus) IsCanary(daemon string) bool {
+	return s != nil && slices.Contains(s.CanaryDaemons, daemon)
+}
+
 func (c *CephCluster) GetStatusConditions() *[]Condition {
 	return &c.Status.Conditions
 }
@@ -19,3 +19,33 @@ package v1
 func (c *CephFilesystem) GetStatusConditions() *[]Condition {
 	return &c.Status.Conditions
 }
+
+// SetUsage sets the capacity used by the subvolume group in its status
+func (c *CephFilesystemSubVolumeGroup) SetUsage(usage *UsageStatus) {
+	if c.Status == nil {
+		c.Status = &CephFilesystemSubVolumeGroupStatus{}
+	}
+	c.Status.Usage = usage
+}
+
+// ActiveMDSCount returns the number of active MDS ranks the filesystem should run. With autoscaling,
+// this is the count last chosen by the operator, kept within the autoscale limits.
+func (c *CephFilesystem) ActiveMDSCount() int32 {
+	count := c.Spec.MetadataServer.ActiveCount
+	autoscale := c.Spec.MetadataServer.Autoscale
+	if autoscale == nil {
+		return count
+	}
+	if c.Status != nil && c.Status.MDSAutoscale != nil && c.Status.MDSAutoscale.ActiveCount > 0 {
+		count = c.Status.MDSAutoscale.ActiveCount
+	}
+	return min(max(count, autoscale.MinActiveCount), autoscale.MaxActiveCount)
+}
+
+// MaxActiveMDSCount returns the highest number of active MDS ranks the filesystem may run
+func (c *CephFilesystem) MaxActiveMDSCount() int32 {
+	if c.Spec.MetadataServer.Autoscale != nil {
+		return max(c.Spec.MetadataServer.ActiveCount, c.Spec.MetadataServer.Autoscale.MaxActiveCount)
+	}
+	return c.Spec.MetadataServer.ActiveCount
+}
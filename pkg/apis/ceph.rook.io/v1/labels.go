@@ -45,6 +45,28 @@ const (
 	// ReadyForSwapOSDAnnotationKey is set by Rook on the OSD Deployment once the OSD is destroyed and
 	// the disk may be physically swapped. E.g. "osd.rook.io/replace-ready-for-swap": "true".
 	ReadyForSwapOSDAnnotationKey = "osd.rook.io/replace-ready-for-swap"
+
+	// NodeMaintenanceAnnotationKey is set by Rook on the OSD and mon Deployments it stopped for a
+	// CephNodeMaintenance, with the name of the maintenance as value. Like ReplaceInProgressOSDAnnotationKey
+	// it marks the ownership of the SkipReconcileLabelKey fence, so that only the daemons stopped by the
+	// maintenance are restarted when it ends.
+	NodeMaintenanceAnnotationKey = "ceph.rook.io/node-maintenance"
+
+	// CanaryUpgradeHoldLabelKey is set by Rook on the daemon Deployments that keep their current Ceph
+	// version while a canary upgrade is in its canary stage or is halted. It is removed when the upgrade
+	// continues with the daemons that are not canaries.
+	CanaryUpgradeHoldLabelKey = "ceph.rook.io/canary-upgrade-hold"
+
+	// PauseOSDUpdatesAnnotationKey is set by a user on a CephCluster to pause the rolling updates of the
+	// OSDs, e.g. "ceph.rook.io/pause-osd-updates": "true". The updates resume
+	// when it is removed.
+	PauseOSDUpdatesAnnotationKey = "ceph.rook.io/pause-osd-updates"
+
+	// ReaddressMonsAnnotationKey is set on a CephCluster to re-address the mons after the IPs of their
+	// nodes or their service CIDR changed, e.g. "ceph.rook.io/readdress-mons": "true". Rook also sets it
+	// when the mons lost quorum after their address changed, sets it to "restarting-daemons" while the
+	// other daemons are restarted on the new addresses, and removes it once they are restarted.
+	ReaddressMonsAnnotationKey = "ceph.rook.io/readdress-mons"
 )
 
 // LabelsSpec is the main spec label for all daemons
@@ -48,6 +48,15 @@ func (n *NetworkSpec) IsHost() bool {
 	return enforceHostNetwork || (n.HostNetwork && n.Provider == NetworkProviderDefault) || n.Provider == NetworkProviderHost
 }
 
+// EffectiveProvider returns the network provider the daemons are deployed with, taking the legacy
+// HostNetwork setting into account
+func (n *NetworkSpec) EffectiveProvider() NetworkProviderType {
+	if n.IsHost() {
+		return NetworkProviderHost
+	}
+	return n.Provider
+}
+
 func ValidateNetworkSpec(clusterNamespace string, spec NetworkSpec) error {
 	if spec.HostNetwork && (spec.Provider != NetworkProviderDefault) {
 		return errors.Errorf(`the legacy hostNetwork setting is only valid with the default network provider ("") and not with '%q'`, spec.Provider)
@@ -88,12 +97,14 @@ func ValidateNetworkSpec(clusterNamespace string, spec NetworkSpec) error {
 	return nil
 }
 
+// ValidateNetworkSpecUpdate validates an update of the network spec of a running cluster. Like the
+// CRD validation rule on the provider, the provider can only be changed to or from the default provider
+// (""). The legacy hostNetwork setting is not a provider, so the provider can be set while it is removed.
 func ValidateNetworkSpecUpdate(clusterNamespace string, oldSpec, newSpec NetworkSpec) error {
-	// Allow an attempt to enable or disable host networking, but not other provider changes
 	oldProvider := oldSpec.Provider
 	newProvider := newSpec.Provider
-	if oldProvider != newProvider && oldProvider != "host" && newProvider != "host" {
-		return errors.Errorf("invalid update: network provider change from %q to %q is not allowed", oldProvider, newProvider)
+	if oldProvider != newProvider && oldProvider != NetworkProviderDefault && newProvider != NetworkProviderDefault {
+		return errors.Errorf("invalid update: network provider change from %q to %q is not allowed, the network provider must be disabled (reverted to empty string) before a new provider is enabled", oldProvider, newProvider)
 	}
 
 	return ValidateNetworkSpec(clusterNamespace, newSpec)
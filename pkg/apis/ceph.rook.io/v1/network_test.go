@@ -72,6 +72,29 @@ func TestValidateNetworkSpec(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateNetworkSpecUpdate(t *testing.T) {
+	multus := NetworkSpec{Provider: NetworkProviderMultus, Selectors: map[CephNetworkType]string{CephNetworkPublic: "public-net"}}
+	host := NetworkSpec{Provider: NetworkProviderHost}
+
+	assert.NoError(t, ValidateNetworkSpecUpdate("ns", NetworkSpec{}, multus))
+	assert.NoError(t, ValidateNetworkSpecUpdate("ns", multus, NetworkSpec{}))
+	assert.NoError(t, ValidateNetworkSpecUpdate("ns", NetworkSpec{}, host))
+	assert.NoError(t, ValidateNetworkSpecUpdate("ns", host, NetworkSpec{}))
+	// the provider is changed from the default provider as in the CRD validation rule
+	assert.NoError(t, ValidateNetworkSpecUpdate("ns", NetworkSpec{HostNetwork: true}, multus))
+	assert.Error(t, ValidateNetworkSpecUpdate("ns", host, multus))
+	assert.Error(t, ValidateNetworkSpecUpdate("ns", multus, host))
+	// the new spec is validated
+	assert.Error(t, ValidateNetworkSpecUpdate("ns", NetworkSpec{}, NetworkSpec{Provider: NetworkProviderMultus}))
+}
+
+func TestNetworkEffectiveProvider(t *testing.T) {
+	assert.Equal(t, NetworkProviderDefault, (&NetworkSpec{}).EffectiveProvider())
+	assert.Equal(t, NetworkProviderHost, (&NetworkSpec{HostNetwork: true}).EffectiveProvider())
+	assert.Equal(t, NetworkProviderHost, (&NetworkSpec{Provider: NetworkProviderHost}).EffectiveProvider())
+	assert.Equal(t, NetworkProviderMultus, (&NetworkSpec{Provider: NetworkProviderMultus}).EffectiveProvider())
+}
+
 // test the NetworkSpec.IsHost method with different network providers
 // Also test it in combination with the legacy
 // "HostNetwork" setting.
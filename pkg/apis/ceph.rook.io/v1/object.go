@@ -124,6 +124,65 @@ func ValidateObjectSpec(gs *CephObjectStore) error {
 		return err
 	}
 
+	if err := validateObjectStoreLuaScripts(&gs.Spec); err != nil {
+		return err
+	}
+
+	if err := validateObjectStoreRateLimits(&gs.Spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateObjectStoreLuaScripts validates that each Lua script is stored in a ConfigMap and that
+// only one script is set for each context and tenant.
+func validateObjectStoreLuaScripts(spec *ObjectStoreSpec) error {
+	if spec.LuaScripts == nil {
+		return nil
+	}
+	if spec.IsExternal() {
+		return errors.New("luaScripts are not supported for external object stores")
+	}
+
+	seen := map[string]bool{}
+	for _, script := range spec.LuaScripts.Scripts {
+		if script.ConfigMapKeyRef.Name == "" || script.ConfigMapKeyRef.Key == "" {
+			return errors.Errorf("lua script for context %q must reference a ConfigMap name and key", script.Context)
+		}
+		id := string(script.Context) + "/" + script.Tenant
+		if seen[id] {
+			return errors.Errorf("only one lua script may be set for context %q and tenant %q", script.Context, script.Tenant)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// validateObjectStoreRateLimits validates that the byte rate limits are not negative
+func validateObjectStoreRateLimits(spec *ObjectStoreSpec) error {
+	if spec.RateLimits == nil {
+		return nil
+	}
+	if spec.IsExternal() {
+		return errors.New("rateLimits are not supported for external object stores")
+	}
+
+	for scope, limit := range map[string]*RateLimitSpec{
+		"user":      spec.RateLimits.User,
+		"bucket":    spec.RateLimits.Bucket,
+		"anonymous": spec.RateLimits.Anonymous,
+	} {
+		if limit == nil {
+			continue
+		}
+		if limit.MaxReadBytes != nil && limit.MaxReadBytes.Sign() < 0 {
+			return errors.Errorf("rateLimits.%s.maxReadBytes must not be negative", scope)
+		}
+		if limit.MaxWriteBytes != nil && limit.MaxWriteBytes.Sign() < 0 {
+			return errors.Errorf("rateLimits.%s.maxWriteBytes must not be negative", scope)
+		}
+	}
 	return nil
 }
 
@@ -226,6 +285,22 @@ func (z *CephObjectZone) GetStatusConditions() *[]Condition {
 	return &z.Status.Conditions
 }
 
+// SetUsage sets the capacity used by the user in its status
+func (u *CephObjectStoreUser) SetUsage(usage *UsageStatus) {
+	if u.Status == nil {
+		u.Status = &ObjectStoreUserStatus{}
+	}
+	u.Status.Usage = usage
+}
+
+// SetUsage sets the capacity used by the account in its status
+func (a *CephObjectStoreAccount) SetUsage(usage *UsageStatus) {
+	if a.Status == nil {
+		a.Status = &ObjectStoreAccountStatus{}
+	}
+	a.Status.Usage = usage
+}
+
 // String returns an addressable string representation of the EndpointAddress.
 func (e *EndpointAddress) String() string {
 	// hostname is easier to read, and it is probably less likely to change, so prefer it over IP
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -127,6 +129,51 @@ func TestValidateObjectStoreSpec(t *testing.T) {
 		assert.ErrorContains(t, err, `"-invalid.dns.name"`)
 		assert.ErrorContains(t, err, `"*.invalid.dns.name"`)
 	})
+
+	t.Run("luaScripts", func(t *testing.T) {
+		scriptRef := func(key string) corev1.ConfigMapKeySelector {
+			return corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "lua"}, Key: key}
+		}
+		o := &CephObjectStore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-store",
+				Namespace: "rook-ceph",
+			},
+			Spec: ObjectStoreSpec{
+				Gateway: GatewaySpec{
+					Port: 1,
+				},
+				LuaScripts: &ObjectStoreLuaScriptsSpec{
+					Scripts: []ObjectStoreLuaScript{
+						{Context: LuaScriptContextPreRequest, ConfigMapKeyRef: scriptRef("pre.lua")},
+						{Context: LuaScriptContextPreRequest, Tenant: "tenant1", ConfigMapKeyRef: scriptRef("pre-tenant1.lua")},
+						{Context: LuaScriptContextBackground, ConfigMapKeyRef: scriptRef("background.lua")},
+					},
+					AllowedPackages: []string{"luasocket"},
+				},
+			},
+		}
+		err := ValidateObjectSpec(o)
+		assert.NoError(t, err)
+
+		// duplicate context and tenant
+		s := o.DeepCopy()
+		s.Spec.LuaScripts.Scripts = append(s.Spec.LuaScripts.Scripts, ObjectStoreLuaScript{Context: LuaScriptContextPreRequest, Tenant: "tenant1", ConfigMapKeyRef: scriptRef("other.lua")})
+		err = ValidateObjectSpec(s)
+		assert.ErrorContains(t, err, `"tenant1"`)
+
+		// missing configmap key
+		s = o.DeepCopy()
+		s.Spec.LuaScripts.Scripts[0].ConfigMapKeyRef.Key = ""
+		err = ValidateObjectSpec(s)
+		assert.ErrorContains(t, err, "ConfigMap")
+
+		// external object store
+		s = o.DeepCopy()
+		s.Spec.Gateway.ExternalRgwEndpoints = []EndpointAddress{{IP: "192.168.0.1"}}
+		err = ValidateObjectSpec(s)
+		assert.ErrorContains(t, err, "external")
+	})
 }
 
 func TestValidateObjectStoreSecurity(t *testing.T) {
@@ -168,6 +215,23 @@ func TestValidateObjectStoreSecurity(t *testing.T) {
 	})
 }
 
+func TestValidateObjectStoreRateLimits(t *testing.T) {
+	spec := &ObjectStoreSpec{}
+	assert.NoError(t, validateObjectStoreRateLimits(spec))
+
+	readBytes := resource.MustParse("1Gi")
+	spec.RateLimits = &ObjectStoreRateLimitsSpec{User: &RateLimitSpec{MaxReadBytes: &readBytes}}
+	assert.NoError(t, validateObjectStoreRateLimits(spec))
+
+	writeBytes := resource.MustParse("-1")
+	spec.RateLimits.Anonymous = &RateLimitSpec{MaxWriteBytes: &writeBytes}
+	assert.ErrorContains(t, validateObjectStoreRateLimits(spec), "rateLimits.anonymous.maxWriteBytes")
+
+	spec.RateLimits.Anonymous = nil
+	spec.Gateway.ExternalRgwEndpoints = []EndpointAddress{{IP: "192.168.0.1"}}
+	assert.ErrorContains(t, validateObjectStoreRateLimits(spec), "not supported for external object stores")
+}
+
 func boolPtr(b bool) *bool { return &b }
 
 func TestIsTLSEnabled(t *testing.T) {
@@ -91,6 +91,14 @@ func (p *CephBlockPoolRadosNamespace) GetStatusConditions() *[]Condition {
 	return &p.Status.Conditions
 }
 
+// SetUsage sets the capacity used by the rados namespace in its status
+func (p *CephBlockPoolRadosNamespace) SetUsage(usage *UsageStatus) {
+	if p.Status == nil {
+		p.Status = &CephBlockPoolRadosNamespaceStatus{}
+	}
+	p.Status.Usage = usage
+}
+
 // SnapshotSchedulesEnabled returns whether snapshot schedules are desired
 func (p *MirroringSpec) SnapshotSchedulesEnabled() bool {
 	return len(p.SnapshotSchedules) > 0
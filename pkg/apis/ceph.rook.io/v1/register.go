@@ -67,6 +67,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CephNFSList{},
 		&CephNVMeOFGateway{},
 		&CephNVMeOFGatewayList{},
+		&CephNVMeOFSubsystem{},
+		&CephNVMeOFSubsystemList{},
+		&CephNodeMaintenance{},
+		&CephNodeMaintenanceList{},
 		&CephObjectStore{},
 		&CephObjectStoreList{},
 		&CephObjectStoreUser{},
@@ -89,6 +93,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CephFilesystemMirrorList{},
 		&CephFilesystemSubVolumeGroup{},
 		&CephFilesystemSubVolumeGroupList{},
+		&CephFilesystemSubVolume{},
+		&CephFilesystemSubVolumeList{},
 		&CephBlockPoolRadosNamespace{},
 		&CephBlockPoolRadosNamespaceList{},
 		&CephCOSIDriver{},
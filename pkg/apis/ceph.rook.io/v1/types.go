@@ -186,6 +186,11 @@ type ClusterSpec struct {
 	// +optional
 	UpgradeOSDRequiresHealthyPGs bool `json:"upgradeOSDRequiresHealthyPGs,omitempty"`
 
+	// UpgradeStrategy defines how the Ceph daemons are upgraded when the Ceph image changes
+	// +optional
+	// +nullable
+	UpgradeStrategy *UpgradeStrategySpec `json:"upgradeStrategy,omitempty"`
+
 	// A spec for configuring disruption management.
 	// +nullable
 	// +optional
@@ -217,6 +222,12 @@ type ClusterSpec struct {
 	// +nullable
 	External ExternalSpec `json:"external,omitempty"`
 
+	// ConnectionBundle publishes a signed connection bundle with the mon endpoints, CephX keys and RGW
+	// and dashboard endpoints of the cluster, to be synced by the consumer clusters in external mode.
+	// +optional
+	// +nullable
+	ConnectionBundle *ConnectionBundleSpec `json:"connectionBundle,omitempty"`
+
 	// A spec for mgr related options
 	// +optional
 	// +nullable
@@ -369,6 +380,27 @@ type ClusterCephxConfig struct {
 	// CSI key rotation can affect existing PV connections, so take care when exercising this option.
 	// +optional
 	CSI CephXConfigWithPriorCount `json:"csi,omitempty"`
+
+	// Inventory reports the key type, generation and age of every CephX entity of the cluster in the
+	// CephCluster status, and rotates the keys older than a maximum age across all the components.
+	// +optional
+	// +nullable
+	Inventory *CephxInventorySpec `json:"inventory,omitempty"`
+}
+
+// CephxInventorySpec represents the inventory of the CephX keys of the cluster
+type CephxInventorySpec struct {
+	// Interval of the inventory. The default is 1h.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MaxKeyAgeDays is the maximum age of the CephX keys in days. The older keys are rotated at the
+	// next reconcile of the resource managing them, regardless of the key rotation policy of the
+	// component. The age of a key is counted from the first inventory of its current generation.
+	// If 0 or unset, the age of the keys is only reported.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxKeyAgeDays uint32 `json:"maxKeyAgeDays,omitempty"`
 }
 
 type CephXConfigWithPriorCount struct {
@@ -649,11 +681,148 @@ type ClusterStatus struct {
 	Cephx       ClusterCephxStatus `json:"cephx,omitempty"`
 	CephStorage *CephStorage       `json:"storage,omitempty"`
 	CephVersion *ClusterVersion    `json:"version,omitempty"`
+	// Upgrade reports the progress of a canary upgrade of the Ceph daemons
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+	// Crashes summarizes the crash reports of the Ceph daemons
+	// +optional
+	Crashes *CrashesStatus `json:"crashes,omitempty"`
+	// Network reports the network provider of the Ceph daemons and the progress of a migration to
+	// another network provider
+	// +optional
+	Network *NetworkStatus `json:"network,omitempty"`
+	// ConnectionBundle reports the connection bundle published by the cluster, or synced from the
+	// provider cluster in external mode
+	// +optional
+	ConnectionBundle *ConnectionBundleStatus `json:"connectionBundle,omitempty"`
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// UpgradePhase is the phase of a canary upgrade
+type UpgradePhase string
+
+const (
+	// UpgradePhaseCanary means the canary daemons are being upgraded
+	UpgradePhaseCanary UpgradePhase = "Canary"
+	// UpgradePhaseSoaking means the canary daemons are upgraded and the cluster is watched for regressions
+	UpgradePhaseSoaking UpgradePhase = "Soaking"
+	// UpgradePhaseContinuing means the soak period passed and the other daemons are being upgraded
+	UpgradePhaseContinuing UpgradePhase = "Continuing"
+	// UpgradePhaseCompleted means all the daemons managed by the cluster are upgraded
+	UpgradePhaseCompleted UpgradePhase = "Completed"
+	// UpgradePhaseHalted means a regression was detected and the upgrade of the other daemons is stopped
+	UpgradePhaseHalted UpgradePhase = "Halted"
+)
+
+// UpgradeStatus reports the progress of a canary upgrade
+type UpgradeStatus struct {
+	// Image is the Ceph image the cluster is upgraded to
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Phase is the phase of the upgrade
+	// +optional
+	Phase UpgradePhase `json:"phase,omitempty"`
+	// CanaryDaemons are the daemons upgraded before the others, e.g. "mon.a", "osd.3" or "rgw.my-store"
+	// +optional
+	CanaryDaemons []string `json:"canaryDaemons,omitempty"`
+	// StartTime is the time the upgrade started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// SoakStartTime is the time the canary daemons were upgraded and the soak period started
+	// +optional
+	SoakStartTime *metav1.Time `json:"soakStartTime,omitempty"`
+	// Reason is the regression that halted the upgrade
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message about the progress of the upgrade
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NetworkStatus reports the network provider of the Ceph daemons
+type NetworkStatus struct {
+	// Provider is the network provider the Ceph daemons are deployed with. During a migration, it is
+	// the provider the daemons are migrated from.
+	// +optional
+	Provider NetworkProviderType `json:"provider,omitempty"`
+	// Migration reports the progress of the last migration to another network provider
+	// +optional
+	Migration *NetworkMigrationStatus `json:"migration,omitempty"`
+}
+
+// NetworkMigrationPhase is the phase of a network provider migration
+type NetworkMigrationPhase string
+
+const (
+	// NetworkMigrationPhaseMons means the mons are failed over one at a time to the new network
+	NetworkMigrationPhaseMons NetworkMigrationPhase = "Mons"
+	// NetworkMigrationPhaseDaemons means the mgrs, OSDs and other daemons are restarted on the new network
+	NetworkMigrationPhaseDaemons NetworkMigrationPhase = "Daemons"
+	// NetworkMigrationPhaseCompleted means the mons, mgrs and OSDs run on the new network
+	NetworkMigrationPhaseCompleted NetworkMigrationPhase = "Completed"
+)
+
+// NetworkMigrationStatus reports the progress of a network provider migration
+type NetworkMigrationStatus struct {
+	// From is the network provider the daemons are migrated from
+	// +optional
+	From NetworkProviderType `json:"from,omitempty"`
+	// To is the network provider the daemons are migrated to
+	// +optional
+	To NetworkProviderType `json:"to,omitempty"`
+	// Phase is the phase of the migration
+	// +optional
+	Phase NetworkMigrationPhase `json:"phase,omitempty"`
+	// PendingMons are the mons not yet failed over to the new network
+	// +optional
+	PendingMons []string `json:"pendingMons,omitempty"`
+	// StartTime is the time the migration started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the migration completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Message is a human-readable message about the progress of the migration
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CrashesStatus summarizes the crash reports of the Ceph daemons
+type CrashesStatus struct {
+	// Count is the number of crash reports in the cluster, archived or not
+	// +optional
+	Count int `json:"count,omitempty"`
+	// Unarchived is the number of crash reports not archived yet, which raise the RECENT_CRASH health warning
+	// +optional
+	Unarchived int `json:"unarchived,omitempty"`
+	// LastReported is the timestamp of the last crash reported by the operator. Only the crashes
+	// after it are reported. When the reporting is enabled, it is set to the newest existing crash.
+	// +optional
+	LastReported string `json:"lastReported,omitempty"`
+	// Recent are the most recent crash reports, the latest first
+	// +optional
+	Recent []CrashSummary `json:"recent,omitempty"`
+	// Unforwarded are the IDs of the reported crashes that failed to be forwarded to the endpoint.
+	// They are forwarded again at the next checks.
+	// +optional
+	Unforwarded []string `json:"unforwarded,omitempty"`
+}
+
+// CrashSummary represents a crash report of a Ceph daemon
+type CrashSummary struct {
+	// ID of the crash report
+	ID string `json:"id"`
+	// Entity is the Ceph daemon that crashed, e.g. "osd.3"
+	Entity string `json:"entity"`
+	// Timestamp is the time of the crash
+	Timestamp string `json:"timestamp"`
+	// Node is the host name of the crashed daemon
+	// +optional
+	Node string `json:"node,omitempty"`
+}
+
 // CephDaemonsVersions show the current ceph version for different ceph daemons
 type CephDaemonsVersions struct {
 	// Mon shows Mon Ceph version
@@ -720,6 +889,62 @@ type OSDStatus struct {
 	// StoreType is a mapping between the OSD backend stores and number of OSDs using these stores
 	StoreType       map[string]int  `json:"storeType,omitempty"`
 	MigrationStatus MigrationStatus `json:"migrationStatus,omitempty"`
+	// CrushWeights are the OSDs whose CRUSH weight is ramping up to their full weight
+	// +optional
+	CrushWeights []OSDCrushWeightStatus `json:"crushWeights,omitempty"`
+	// CrushInitialWeightSet is true if Rook set osd_crush_initial_weight to ramp up the new OSDs. Rook
+	// only removes the option when the ramping is disabled, if it set it.
+	// +optional
+	CrushInitialWeightSet bool `json:"crushInitialWeightSet,omitempty"`
+	// TuningProfiles are the tuning profiles applied to the OSDs
+	// +optional
+	TuningProfiles []OSDTuningProfileStatus `json:"tuningProfiles,omitempty"`
+}
+
+// OSDTuningProfileStatus represents the OSDs a tuning profile is applied to
+type OSDTuningProfileStatus struct {
+	// Name of the profile
+	Name string `json:"name"`
+	// OSDs are the IDs of the OSDs the profile is applied to
+	// +optional
+	OSDs []int `json:"osds,omitempty"`
+	// OSDOptions are the names of the Ceph options the profile set on each of its OSDs. They are
+	// removed from an OSD when the profile no longer sets them or is no longer applied to the OSD.
+	// +optional
+	OSDOptions []OSDTuningProfileOptions `json:"osdOptions,omitempty"`
+	// LastDriftCorrected is the time a setting of the profile was last found changed on an OSD and
+	// set again
+	// +optional
+	// +nullable
+	LastDriftCorrected *metav1.Time `json:"lastDriftCorrected,omitempty"`
+}
+
+// OSDTuningProfileOptions represents the Ceph options a tuning profile set on an OSD
+type OSDTuningProfileOptions struct {
+	// ID of the OSD
+	ID int `json:"id"`
+	// Options are the names of the Ceph options
+	// +optional
+	Options []string `json:"options,omitempty"`
+}
+
+// OSDCrushWeightStatus represents the CRUSH weight of an OSD ramping up to its full weight
+type OSDCrushWeightStatus struct {
+	// ID of the OSD
+	ID int `json:"id"`
+	// Weight is the current CRUSH weight of the OSD
+	Weight float64 `json:"weight"`
+	// FullWeight is the CRUSH weight of the OSD matching the size of its device
+	FullWeight float64 `json:"fullWeight"`
+	// LastUpdated is the time the weight of the OSD was last raised
+	// +optional
+	// +nullable
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+	// Created is the time Rook created the OSD. Only the OSDs created by Rook are ramped up from
+	// a zero weight.
+	// +optional
+	// +nullable
+	Created *metav1.Time `json:"created,omitempty"`
 }
 
 // MigrationStatus status represents the current status of any OSD migration.
@@ -792,6 +1017,23 @@ const (
 	// RadosNamespaceEmptyReason represents when a rados namespace does not contain images or snapshots that are blocking
 	// deletion.
 	RadosNamespaceEmptyReason ConditionReason = "RadosNamespaceEmpty"
+	// MultisiteSyncCaughtUpReason represents when a zone is caught up with the multisite replication.
+	MultisiteSyncCaughtUpReason ConditionReason = "SyncCaughtUp"
+	// MultisiteSyncBehindReason represents when a zone has metadata or data shards that are behind
+	// the multisite replication.
+	MultisiteSyncBehindReason ConditionReason = "SyncBehind"
+	// MultisiteSyncCheckFailedReason represents when the multisite replication status of a zone
+	// could not be checked.
+	MultisiteSyncCheckFailedReason ConditionReason = "SyncCheckFailed"
+	// OSDUpdatesPausedReason represents the OSD updates being paused with the pause annotation
+	OSDUpdatesPausedReason ConditionReason = "OSDUpdatesPaused"
+	// OSDUpdatesInProgressReason represents the OSDs of a failure domain being updated
+	OSDUpdatesInProgressReason ConditionReason = "OSDUpdatesInProgress"
+	// OSDUpdatesWaitingForCleanPGsReason represents the OSD updates waiting for the PGs to be clean
+	// before updating the OSDs of the next failure domain
+	OSDUpdatesWaitingForCleanPGsReason ConditionReason = "WaitingForCleanPGs"
+	// OSDUpdatesCompleteReason represents all the OSDs being updated
+	OSDUpdatesCompleteReason ConditionReason = "OSDUpdatesComplete"
 )
 
 // ConditionType represent a resource's status
@@ -817,6 +1059,13 @@ const (
 	ConditionPoolDeletionIsBlocked ConditionType = "PoolDeletionIsBlocked"
 	// ConditionRadosNSDeletionIsBlocked represents when deletion of the object is blocked.
 	ConditionRadosNSDeletionIsBlocked ConditionType = "RadosNamespaceDeletionIsBlocked"
+
+	// ConditionMultisiteSyncHealthy represents whether the multisite replication of a zone is caught up.
+	ConditionMultisiteSyncHealthy ConditionType = "MultisiteSyncHealthy"
+
+	// ConditionOSDUpdatesPending represents whether some OSDs of the cluster are not updated yet,
+	// either because the updates are paused or because they are rolled one failure domain at a time.
+	ConditionOSDUpdatesPending ConditionType = "OSDUpdatesPending"
 )
 
 // ClusterState represents the state of a Ceph Cluster
@@ -894,6 +1143,55 @@ type ClusterCephxStatus struct {
 	CrashCollector CephxStatus `json:"crashCollector,omitempty"`
 	// Ceph Exporter represents the cephx key rotation status of the ceph exporter daemon
 	CephExporter CephxStatus `json:"cephExporter,omitempty"`
+	// Inventory reports every CephX entity of the cluster, if the inventory is enabled
+	// +optional
+	Inventory *CephxInventoryStatus `json:"inventory,omitempty"`
+}
+
+// CephxInventoryStatus reports the CephX entities of the cluster
+type CephxInventoryStatus struct {
+	// LastChecked is the time of the last inventory
+	// +optional
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+	// ConfigMap is the name of the configmap holding the inventory of every CephX key of the cluster
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// Keys is the number of CephX keys of the cluster
+	// +optional
+	Keys int `json:"keys,omitempty"`
+	// OverdueKeys is the number of keys older than the maximum key age, waiting to be rotated
+	// +optional
+	OverdueKeys int `json:"overdueKeys,omitempty"`
+	// Overdue are the CephX entities whose key is older than the maximum key age, sorted by name. At most
+	// 100 entities are listed, the configmap lists all of them.
+	// +optional
+	Overdue []CephxEntityStatus `json:"overdue,omitempty"`
+}
+
+// CephxEntityStatus represents the key of a CephX entity
+type CephxEntityStatus struct {
+	// Entity is the name of the CephX entity, e.g. "osd.3" or "client.csi-rbd-node"
+	Entity string `json:"entity"`
+	// Owner is the resource managing the key, e.g. "CephFilesystem/myfs". Empty if the key is not
+	// managed by Rook.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// Component is the component of the CephCluster managing the key, e.g. "mgr" or "csi"
+	// +optional
+	Component string `json:"component,omitempty"`
+	// KeyType is the cipher type of the key reported by Ceph
+	// +optional
+	KeyType CephxKeyType `json:"keyType,omitempty"`
+	// KeyGeneration is the key generation in the status of the owner
+	// +optional
+	KeyGeneration uint32 `json:"keyGeneration,omitempty"`
+	// KeySince is the time the current generation of the key was first inventoried. It is not set
+	// for the keys not managed by Rook, whose rotation cannot be tracked.
+	// +optional
+	KeySince *metav1.Time `json:"keySince,omitempty"`
+	// Overdue is true if the key is older than the maximum key age and waits to be rotated
+	// +optional
+	Overdue bool `json:"overdue,omitempty"`
 }
 
 // MonSpec represents the specification of the monitor
@@ -916,6 +1214,11 @@ type MonSpec struct {
 	// StretchCluster is the stretch cluster specification
 	// +optional
 	StretchCluster *StretchClusterSpec `json:"stretchCluster,omitempty"`
+	// Rebalance moves the mons one at a time to spread them evenly across the failure domains
+	// of the nodes. Ignored if the mon zones are specified.
+	// +optional
+	// +nullable
+	Rebalance *MonRebalanceSpec `json:"rebalance,omitempty"`
 	// VolumeClaimTemplate is the PVC definition
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
@@ -935,6 +1238,13 @@ type MonSpec struct {
 	FloatingMon FloatingMonSpec `json:"floatingMon,omitempty,omitzero"`
 }
 
+// MonRebalanceSpec represents the settings for spreading the mons across the failure domains
+type MonRebalanceSpec struct {
+	// MinInterval is the minimum time between moving two mons. Defaults to 1h.
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
+}
+
 // +kubebuilder:validation:MinProperties=2
 type FloatingMonSpec struct {
 	// Name is the identifier for the floating monitor (recommended "c")
@@ -1037,6 +1347,116 @@ type ExternalSpec struct {
 	// Enable determines whether external mode is enabled or not
 	// +optional
 	Enable bool `json:"enable,omitempty"`
+	// ConnectionBundle is the signed connection bundle published by the provider cluster. The mon
+	// endpoints, CephX keys and RGW and dashboard endpoints of the provider are synced from it.
+	// +optional
+	// +nullable
+	ConnectionBundle *ExternalConnectionBundleSpec `json:"connectionBundle,omitempty"`
+}
+
+// ExternalConnectionBundleSpec represents the connection bundle of the provider cluster synced by a
+// consumer cluster in external mode. Exactly one of Secret, URL and Path must be set.
+type ExternalConnectionBundleSpec struct {
+	// Secret is the key of a secret in the namespace of the CephCluster holding the bundle
+	// +optional
+	// +nullable
+	Secret *v1.SecretKeySelector `json:"secret,omitempty"`
+	// URL the bundle is downloaded from. Only https URLs are allowed.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// CABundle is the key of a secret in the namespace of the CephCluster holding the PEM-encoded CA
+	// certificates the server of the URL is verified with. If not set, the system CAs are used.
+	// +optional
+	// +nullable
+	CABundle *v1.SecretKeySelector `json:"caBundle,omitempty"`
+	// Path of a file holding the bundle in the operator container, e.g. mounted from a secret
+	// +optional
+	Path string `json:"path,omitempty"`
+	// PublicKey is the base64-encoded Ed25519 public key the bundle is signed with, as reported in
+	// the status of the provider CephCluster
+	// +kubebuilder:validation:MinLength=1
+	PublicKey string `json:"publicKey"`
+	// Interval between the syncs of the bundle. The default is 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ConnectionBundleSpec represents the connection bundle published by a provider cluster
+type ConnectionBundleSpec struct {
+	// Interval between the updates of the bundle. The default is 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// DashboardURL is the URL of the dashboard published in the bundle. If not set, the URL reported
+	// by the mgr is published.
+	// +optional
+	DashboardURL string `json:"dashboardURL,omitempty"`
+	// Consumers are the consumer clusters a bundle with dedicated CSI users is published for, in the
+	// secret rook-ceph-connection-bundle-<name>. The CSI users of a consumer are removed with the
+	// consumer. The bundle in the secret rook-ceph-connection-bundle has no CSI users.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Consumers []ConnectionBundleConsumerSpec `json:"consumers,omitempty"`
+}
+
+// ConnectionBundleConsumerSpec represents a consumer cluster of the connection bundle. The caps of
+// its CSI users are restricted to its pool and filesystem.
+type ConnectionBundleConsumerSpec struct {
+	// Name of the consumer, part of the names of its CephX users and of the secret of its bundle
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=32
+	Name string `json:"name"`
+	// RBDPool is the pool the RBD CSI users of the consumer have access to. If not set, no RBD CSI
+	// users are created for the consumer.
+	// +optional
+	RBDPool string `json:"rbdPool,omitempty"`
+	// RadosNamespace is the rados namespace of the RBDPool the RBD CSI users are restricted to
+	// +optional
+	RadosNamespace string `json:"radosNamespace,omitempty"`
+	// Filesystem is the name of the CephFilesystem the CephFS CSI users of the consumer have access
+	// to. If not set, no CephFS CSI users are created for the consumer.
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+}
+
+// ConnectionBundleStatus reports the connection bundle published by a provider cluster, or synced
+// by a consumer cluster in external mode
+type ConnectionBundleStatus struct {
+	// PublicKey is the base64-encoded Ed25519 public key the bundle is signed with
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+	// Digest is the SHA-256 digest of the bundle
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// LastUpdated is the time the bundle was last published or applied
+	// +optional
+	// +nullable
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+	// DashboardURL is the URL of the dashboard of the provider cluster
+	// +optional
+	DashboardURL string `json:"dashboardURL,omitempty"`
+	// RGWEndpoints are the endpoints of the object stores of the provider cluster
+	// +optional
+	RGWEndpoints []ConnectionBundleObjectStore `json:"rgwEndpoints,omitempty"`
+	// Consumers are the bundles published for the consumer clusters
+	// +optional
+	Consumers []ConnectionBundleConsumerStatus `json:"consumers,omitempty"`
+}
+
+// ConnectionBundleConsumerStatus reports the bundle published for a consumer cluster
+type ConnectionBundleConsumerStatus struct {
+	// Name of the consumer
+	Name string `json:"name"`
+	// Digest is the SHA-256 digest of the bundle of the consumer
+	Digest string `json:"digest"`
+}
+
+// ConnectionBundleObjectStore represents the endpoints of an object store of the provider cluster
+type ConnectionBundleObjectStore struct {
+	// Name of the CephObjectStore
+	Name string `json:"name"`
+	// Endpoints of the object store
+	Endpoints []string `json:"endpoints"`
 }
 
 // CrashCollectorSpec represents options to configure the crash controller
@@ -1048,6 +1468,58 @@ type CrashCollectorSpec struct {
 	// DaysToRetain represents the number of days to retain crash until they get pruned
 	// +optional
 	DaysToRetain uint `json:"daysToRetain,omitempty"`
+
+	// Reporting emits an event for each new crash on the resource of the crashed daemon and
+	// summarizes the crashes in the CephCluster status
+	// +optional
+	// +nullable
+	Reporting *CrashReportingSpec `json:"reporting,omitempty"`
+}
+
+// CrashReportingSpec represents the reporting of the new crashes of the Ceph daemons
+type CrashReportingSpec struct {
+	// Interval of the checks for new crashes. The default is 60s.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Forward sends the metadata of each new crash to an external endpoint
+	// +optional
+	// +nullable
+	Forward *CrashForwardSpec `json:"forward,omitempty"`
+
+	// Archive the crashes once they are reported, and forwarded if Forward is set, so they no
+	// longer raise the RECENT_CRASH health warning
+	// +optional
+	Archive bool `json:"archive,omitempty"`
+}
+
+// CrashForwardType is the type of endpoint the crashes are forwarded to
+type CrashForwardType string
+
+const (
+	// CrashForwardWebhook posts the crash metadata as JSON to the URL
+	CrashForwardWebhook CrashForwardType = "webhook"
+	// CrashForwardSentry sends the crash as an event to a Sentry-compatible store API, with the
+	// URL being the DSN of the project
+	CrashForwardSentry CrashForwardType = "sentry"
+)
+
+// CrashForwardSpec represents an external endpoint the crashes are forwarded to
+type CrashForwardSpec struct {
+	// Type of the endpoint, "webhook" or "sentry"
+	// +kubebuilder:validation:Enum=webhook;sentry
+	// +kubebuilder:default=webhook
+	// +optional
+	Type CrashForwardType `json:"type,omitempty"`
+
+	// URL of the webhook, or the DSN of the Sentry project
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// The value of the Authorization header sent to the webhook, e.g. "Bearer <token>"
+	// +optional
+	// +nullable
+	AuthorizationSecretRef *v1.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
 }
 
 // +genclient
@@ -1641,6 +2113,49 @@ type MetadataServerSpec struct {
 	// +kubebuilder:validation:Maximum=1.0
 	// +optional
 	CacheMemoryRequestFactor *float64 `json:"cacheMemoryRequestFactor,omitempty"`
+
+	// Autoscale adjusts the number of active MDS ranks to the metadata load. When set, activeCount is the
+	// initial number of active ranks and is kept within the autoscale limits.
+	// +optional
+	Autoscale *MDSAutoscaleSpec `json:"autoscale,omitempty"`
+}
+
+// MDSAutoscaleSpec is the policy to scale the number of active MDS ranks with the metadata load
+// +kubebuilder:validation:XValidation:message="minActiveCount must not be greater than maxActiveCount",rule="self.minActiveCount <= self.maxActiveCount"
+// +kubebuilder:validation:XValidation:message="scaleDownRequestRate must be lower than scaleUpRequestRate",rule="!has(self.scaleDownRequestRate) || !has(self.scaleUpRequestRate) || self.scaleDownRequestRate < self.scaleUpRequestRate"
+type MDSAutoscaleSpec struct {
+	// MinActiveCount is the lowest number of active MDS ranks
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	MinActiveCount int32 `json:"minActiveCount"`
+
+	// MaxActiveCount is the highest number of active MDS ranks
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	MaxActiveCount int32 `json:"maxActiveCount"`
+
+	// ScaleUpRequestRate is the average client request rate per active rank, in requests per second,
+	// above which a rank is added. Defaults to 1000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ScaleUpRequestRate int32 `json:"scaleUpRequestRate,omitempty"`
+
+	// ScaleDownRequestRate is the average client request rate per active rank, in requests per second,
+	// below which a rank is removed. Defaults to half of scaleUpRequestRate.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ScaleDownRequestRate *int32 `json:"scaleDownRequestRate,omitempty"`
+
+	// CacheUsagePercent is the share of mds_cache_memory_limit used by any active rank above which a
+	// rank is added. Ranks are only removed while all active ranks are below it. Defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CacheUsagePercent int32 `json:"cacheUsagePercent,omitempty"`
+
+	// CooldownPeriod is the minimum time between two scaling operations. Defaults to 10m.
+	// +optional
+	CooldownPeriod *metav1.Duration `json:"cooldownPeriod,omitempty"`
 }
 
 // FSMirroringSpec represents the setting for a mirrored filesystem
@@ -1690,12 +2205,35 @@ type CephFilesystemStatus struct {
 	// MirroringStatus is the filesystem mirroring status
 	// +optional
 	MirroringStatus *FilesystemMirroringInfoSpec `json:"mirroringStatus,omitempty"`
-	Conditions      []Condition                  `json:"conditions,omitempty"`
+	// MDSAutoscale is the status of the MDS autoscaler
+	// +optional
+	MDSAutoscale *MDSAutoscaleStatus `json:"mdsAutoscale,omitempty"`
+	Conditions   []Condition         `json:"conditions,omitempty"`
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// MDSAutoscaleStatus is the status of the MDS autoscaler
+type MDSAutoscaleStatus struct {
+	// ActiveCount is the number of active MDS ranks chosen by the autoscaler
+	// +optional
+	ActiveCount int32 `json:"activeCount,omitempty"`
+	// RequestRate is the average client request rate per active rank, in requests per second
+	// +optional
+	RequestRate int64 `json:"requestRate,omitempty"`
+	// CacheUsagePercent is the highest cache usage of an active rank, in percent of mds_cache_memory_limit
+	// +optional
+	CacheUsagePercent int32 `json:"cacheUsagePercent,omitempty"`
+	// LastScaleTime is the last time the number of active ranks was changed
+	// +optional
+	// +nullable
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// LastScaleReason is why the number of active ranks was last changed
+	// +optional
+	LastScaleReason string `json:"lastScaleReason,omitempty"`
+}
+
 // FilesystemMirroringInfoSpec is the status of the pool mirroring
 type FilesystemMirroringInfoSpec struct {
 	// PoolMirroringStatus is the mirroring status of a filesystem
@@ -1939,6 +2477,23 @@ type ObjectStoreSpec struct {
 	// referenced by the zone's zonegroup should configure defaulting behavior.
 	// +optional
 	DefaultRealm bool `json:"defaultRealm,omitempty"`
+
+	// LuaScripts are the RGW Lua scripts and packages to install on the object store
+	// +optional
+	// +nullable
+	LuaScripts *ObjectStoreLuaScriptsSpec `json:"luaScripts,omitempty"`
+
+	// RateLimits are the global RGW rate limits of the object store. They apply to every user,
+	// bucket and anonymous client that does not have its own rate limit.
+	// +optional
+	// +nullable
+	RateLimits *ObjectStoreRateLimitsSpec `json:"rateLimits,omitempty"`
+
+	// BucketIndex are the settings of the monitoring and resharding of the bucket indexes. The bucket
+	// indexes are only checked if set.
+	// +optional
+	// +nullable
+	BucketIndex *ObjectStoreBucketIndexSpec `json:"bucketIndex,omitempty"`
 }
 
 // ObjectSharedPoolsSpec represents object store pool info when configuring RADOS namespaces in existing pools.
@@ -2192,66 +2747,201 @@ type OpsLogSidecar struct {
 	// Resources represents the way to specify resource requirements for the ops-log sidecar
 	// +optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
-}
 
-// EndpointAddress is a tuple that describes a single IP address or host name. This is a subset of
-// Kubernetes's v1.EndpointAddress.
-// +structType=atomic
-type EndpointAddress struct {
-	// The IP of this endpoint. As a legacy behavior, this supports being given a DNS-addressable hostname as well.
+	// Sinks are the external destinations the ops log records are shipped to. The records are
+	// still printed by the sidecar.
 	// +optional
-	IP string `json:"ip" protobuf:"bytes,1,opt,name=ip"`
+	// +nullable
+	// +listType=map
+	// +listMapKey=name
+	Sinks []OpsLogSinkSpec `json:"sinks,omitempty"`
 
-	// The DNS-addressable Hostname of this endpoint. This field will be preferred over IP if both are given.
+	// Delivery configures the batching and the retries of the delivery of the records to the sinks
 	// +optional
-	Hostname string `json:"hostname,omitempty" protobuf:"bytes,3,opt,name=hostname"`
+	Delivery OpsLogDeliverySpec `json:"delivery,omitempty"`
 }
 
-// ProtocolSpec represents a Ceph Object Store protocol specification
-type ProtocolSpec struct {
-	// Represents RGW 'rgw_enable_apis' config option. See: https://docs.ceph.com/en/reef/radosgw/config-ref/#confval-rgw_enable_apis
-	// If no value provided then all APIs will be enabled: s3, s3website, swift, swift_auth, admin, sts, iam, notifications
-	// If enabled APIs are set, all remaining APIs will be disabled.
-	// This option overrides S3.Enabled value.
+// OpsLogSinkSpec represents an external destination of the ops log. Exactly one of the endpoints
+// must be set.
+type OpsLogSinkSpec struct {
+	// Name of the sink, reported in the object store status
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// HTTP endpoint the records are posted to as newline-delimited JSON
 	// +optional
 	// +nullable
-	EnableAPIs []ObjectStoreAPI `json:"enableAPIs,omitempty"`
-
-	// The spec for S3
+	HTTP *OpsLogHTTPSinkSpec `json:"http,omitempty"`
+	// Kafka endpoint the records are produced to, one message per record
 	// +optional
 	// +nullable
-	S3 *S3Spec `json:"s3,omitempty"`
-
-	// The spec for Swift
+	Kafka *OpsLogKafkaSinkSpec `json:"kafka,omitempty"`
+	// S3 bucket the records are written to, one object per batch
 	// +optional
 	// +nullable
-	Swift *SwiftSpec `json:"swift"`
+	S3 *OpsLogS3SinkSpec `json:"s3,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=s3;s3website;swift;swift_auth;admin;sts;iam;notifications
-type ObjectStoreAPI string
-
-// S3Spec represents Ceph Object Store specification for the S3 API
-type S3Spec struct {
-	// Deprecated: use protocol.enableAPIs instead.
-	// Whether to enable S3. This defaults to true (even if protocols.s3 is not present in the CRD). This maintains backwards compatibility – by default S3 is enabled.
-	// +nullable
+// OpsLogHTTPSinkSpec represents an HTTP endpoint of the ops log
+type OpsLogHTTPSinkSpec struct {
+	HTTPEndpointSpec `json:",inline"`
+	// The value of the Authorization header sent to the endpoint, e.g. "Bearer <token>"
 	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-	// Whether to use Keystone for authentication. This option maps directly to the rgw_s3_auth_use_keystone option. Enabling it allows generating S3 credentials via an OpenStack API call, see the docs. If not given, the defaults of the corresponding RGW option apply.
 	// +nullable
-	// +optional
-	AuthUseKeystone *bool `json:"authUseKeystone,omitempty"`
+	AuthorizationSecretRef *v1.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
 }
 
-// SwiftSpec represents Ceph Object Store specification for the Swift API
-type SwiftSpec struct {
-	// Whether or not the Swift account name should be included in the Swift API URL. If set to false (the default), then the Swift API will listen on a URL formed like http://host:port/<rgw_swift_url_prefix>/v1. If set to true, the Swift API URL will be http://host:port/<rgw_swift_url_prefix>/v1/AUTH_<account_name>. You must set this option to true (and update the Keystone service catalog) if you want radosgw to support publicly-readable containers and temporary URLs.
-	// +nullable
-	// +optional
-	AccountInUrl *bool `json:"accountInUrl,omitempty"`
-	// The URL prefix for the Swift API, to distinguish it from the S3 API endpoint. The default is swift, which makes the Swift API available at the URL http://host:port/swift/v1 (or http://host:port/swift/v1/AUTH_%(tenant_id)s if rgw swift account in url is enabled).
-	// +nullable
+// OpsLogKafkaSinkSpec represents a Kafka endpoint of the ops log
+type OpsLogKafkaSinkSpec struct {
+	KafkaEndpointSpec `json:",inline"`
+	// The Kafka topic the records are produced to
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+}
+
+// OpsLogS3SinkSpec represents an S3 bucket of the ops log
+type OpsLogS3SinkSpec struct {
+	// The URL of the S3 endpoint, e.g. https://s3.example.com
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+	// The name of the bucket the records are written to
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+	// The region of the bucket. The default is "us-east-1".
+	// +optional
+	Region string `json:"region,omitempty"`
+	// The prefix of the names of the objects written to the bucket
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// The access key used to write to the bucket
+	AccessKeySecretRef v1.SecretKeySelector `json:"accessKeySecretRef"`
+	// The secret key used to write to the bucket
+	SecretKeySecretRef v1.SecretKeySelector `json:"secretKeySecretRef"`
+	// Indicate whether the server certificate is validated by the client or not
+	// +optional
+	DisableVerifySSL bool `json:"disableVerifySSL,omitempty"`
+}
+
+// OpsLogDeliverySpec represents the batching and the retries of the delivery of the ops log.
+// While the records buffered for a sink are at the limit, the sidecar stops reading the ops log
+// so that no record is lost.
+type OpsLogDeliverySpec struct {
+	// BatchSize is the maximum number of records sent to a sink at once. The default is 500.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +nullable
+	BatchSize *int32 `json:"batchSize,omitempty"`
+	// BatchTimeout is the maximum time a record waits for its batch to be full. The default is 5s.
+	// +optional
+	// +nullable
+	BatchTimeout *metav1.Duration `json:"batchTimeout,omitempty"`
+	// BufferSize is the maximum number of records buffered for each sink. The default is 10000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +nullable
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+	// MaxRetries is the number of retries of a batch before it is dropped. If not set, a batch is
+	// retried until it is delivered.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	// +nullable
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// OpsLogStatus represents the delivery of the ops log of the object store gateways to the sinks
+type OpsLogStatus struct {
+	// LastChecked is the time the delivery status was last collected from the gateways
+	// +optional
+	LastChecked string `json:"lastChecked,omitempty"`
+	// Details are the errors to collect the delivery status, if any
+	// +optional
+	Details string `json:"details,omitempty"`
+	// Sinks is the delivery status of each sink, summed over the gateways
+	// +optional
+	// +nullable
+	Sinks []OpsLogSinkStatus `json:"sinks,omitempty"`
+}
+
+// OpsLogSinkStatus represents the delivery of the ops log to a sink
+type OpsLogSinkStatus struct {
+	// Name of the sink
+	Name string `json:"name"`
+	// Delivered is the number of records delivered since the sidecars started
+	// +optional
+	Delivered int64 `json:"delivered,omitempty"`
+	// Dropped is the number of records dropped after their retries since the sidecars started
+	// +optional
+	Dropped int64 `json:"dropped,omitempty"`
+	// Buffered is the number of records read from the ops log and not delivered yet
+	// +optional
+	Buffered int64 `json:"buffered,omitempty"`
+	// LagSeconds is the age of the oldest record not delivered yet, in seconds
+	// +optional
+	LagSeconds int64 `json:"lagSeconds,omitempty"`
+	// LastError is the last delivery error, if the last delivery failed
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// EndpointAddress is a tuple that describes a single IP address or host name. This is a subset of
+// Kubernetes's v1.EndpointAddress.
+// +structType=atomic
+type EndpointAddress struct {
+	// The IP of this endpoint. As a legacy behavior, this supports being given a DNS-addressable hostname as well.
+	// +optional
+	IP string `json:"ip" protobuf:"bytes,1,opt,name=ip"`
+
+	// The DNS-addressable Hostname of this endpoint. This field will be preferred over IP if both are given.
+	// +optional
+	Hostname string `json:"hostname,omitempty" protobuf:"bytes,3,opt,name=hostname"`
+}
+
+// ProtocolSpec represents a Ceph Object Store protocol specification
+type ProtocolSpec struct {
+	// Represents RGW 'rgw_enable_apis' config option. See: https://docs.ceph.com/en/reef/radosgw/config-ref/#confval-rgw_enable_apis
+	// If no value provided then all APIs will be enabled: s3, s3website, swift, swift_auth, admin, sts, iam, notifications
+	// If enabled APIs are set, all remaining APIs will be disabled.
+	// This option overrides S3.Enabled value.
+	// +optional
+	// +nullable
+	EnableAPIs []ObjectStoreAPI `json:"enableAPIs,omitempty"`
+
+	// The spec for S3
+	// +optional
+	// +nullable
+	S3 *S3Spec `json:"s3,omitempty"`
+
+	// The spec for Swift
+	// +optional
+	// +nullable
+	Swift *SwiftSpec `json:"swift"`
+}
+
+// +kubebuilder:validation:Enum=s3;s3website;swift;swift_auth;admin;sts;iam;notifications
+type ObjectStoreAPI string
+
+// S3Spec represents Ceph Object Store specification for the S3 API
+type S3Spec struct {
+	// Deprecated: use protocol.enableAPIs instead.
+	// Whether to enable S3. This defaults to true (even if protocols.s3 is not present in the CRD). This maintains backwards compatibility – by default S3 is enabled.
+	// +nullable
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Whether to use Keystone for authentication. This option maps directly to the rgw_s3_auth_use_keystone option. Enabling it allows generating S3 credentials via an OpenStack API call, see the docs. If not given, the defaults of the corresponding RGW option apply.
+	// +nullable
+	// +optional
+	AuthUseKeystone *bool `json:"authUseKeystone,omitempty"`
+}
+
+// SwiftSpec represents Ceph Object Store specification for the Swift API
+type SwiftSpec struct {
+	// Whether or not the Swift account name should be included in the Swift API URL. If set to false (the default), then the Swift API will listen on a URL formed like http://host:port/<rgw_swift_url_prefix>/v1. If set to true, the Swift API URL will be http://host:port/<rgw_swift_url_prefix>/v1/AUTH_<account_name>. You must set this option to true (and update the Keystone service catalog) if you want radosgw to support publicly-readable containers and temporary URLs.
+	// +nullable
+	// +optional
+	AccountInUrl *bool `json:"accountInUrl,omitempty"`
+	// The URL prefix for the Swift API, to distinguish it from the S3 API endpoint. The default is swift, which makes the Swift API available at the URL http://host:port/swift/v1 (or http://host:port/swift/v1/AUTH_%(tenant_id)s if rgw swift account in url is enabled).
+	// +nullable
 	// +optional
 	UrlPrefix *string `json:"urlPrefix,omitempty"`
 	// Enables the Object Versioning of OpenStack Object Storage API. This allows clients to put the X-Versions-Location attribute on containers that should be versioned.
@@ -2325,6 +3015,18 @@ type ObjectStoreStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LuaScripts are the Lua scripts and packages currently installed by Rook on the object store
+	// +optional
+	// +nullable
+	LuaScripts *ObjectStoreLuaScriptsStatus `json:"luaScripts,omitempty"`
+	// BucketIndex is the status of the bucket indexes and of their resharding
+	// +optional
+	// +nullable
+	BucketIndex *ObjectStoreBucketIndexStatus `json:"bucketIndex,omitempty"`
+	// OpsLog is the delivery status of the ops log to the sinks of the ops log sidecar
+	// +optional
+	// +nullable
+	OpsLog *OpsLogStatus `json:"opsLog,omitempty"`
 }
 
 type ObjectEndpoints struct {
@@ -2359,6 +3061,220 @@ type ObjectStoreHostingSpec struct {
 	DNSNames []string `json:"dnsNames,omitempty"`
 }
 
+// ObjectStoreLuaScriptsSpec represents the RGW Lua scripting settings for the object store.
+// When set, Rook manages all Lua scripts and packages of the object store: scripts and packages
+// that are not listed here are removed.
+type ObjectStoreLuaScriptsSpec struct {
+	// Scripts is the list of Lua scripts to install. Only one script may be set per context and tenant.
+	// +optional
+	// +nullable
+	Scripts []ObjectStoreLuaScript `json:"scripts,omitempty"`
+	// AllowedPackages is the list of Lua packages (from luarocks) that scripts are allowed to use.
+	// A version may be given with the package name, e.g. "luasocket 3.1.0-1".
+	// The RGWs must be able to reach the luarocks server to install the packages.
+	// +optional
+	// +nullable
+	AllowedPackages []string `json:"allowedPackages,omitempty"`
+}
+
+// ObjectStoreBucketIndexSpec represents the monitoring and resharding of the bucket indexes of the
+// object store
+type ObjectStoreBucketIndexSpec struct {
+	// Check is the periodic check of the bucket indexes and of the reshard queue. The check runs
+	// every 6 hours by default.
+	// +optional
+	Check HealthCheckSpec `json:"check,omitempty"`
+	// ObjectsPerShardThreshold is the number of objects per index shard above which a bucket is
+	// reported. If not set, the buckets that RGW reports over its warning threshold are reported.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +nullable
+	ObjectsPerShardThreshold *int64 `json:"objectsPerShardThreshold,omitempty"`
+	// Reshard queues manual reshards of the reported buckets that match the policy. If not set, the
+	// buckets are only reported.
+	// +optional
+	// +nullable
+	Reshard *BucketReshardPolicySpec `json:"reshard,omitempty"`
+}
+
+// BucketReshardPolicySpec represents the buckets to reshard manually and their number of shards
+type BucketReshardPolicySpec struct {
+	// Buckets are glob patterns of the names of the buckets to reshard. The name of a bucket of a
+	// tenant is "tenant/bucket". If empty, all reported buckets are resharded.
+	// +optional
+	// +nullable
+	Buckets []string `json:"buckets,omitempty"`
+	// VersionedOnly only reshards versioned buckets, which dynamic resharding does not reshard
+	// in some configurations.
+	// +optional
+	VersionedOnly bool `json:"versionedOnly,omitempty"`
+	// ObjectsPerShard is the number of objects per shard the number of shards of a resharded bucket
+	// is computed from. The default is 50000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +nullable
+	ObjectsPerShard *int64 `json:"objectsPerShard,omitempty"`
+	// MaxShards is the maximum number of shards of a resharded bucket. The default is 1999.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +nullable
+	MaxShards *int64 `json:"maxShards,omitempty"`
+}
+
+// ObjectStoreBucketIndexStatus represents the status of the bucket indexes of the object store
+type ObjectStoreBucketIndexStatus struct {
+	// LastChecked is the time of the last check of the bucket indexes
+	// +optional
+	LastChecked string `json:"lastChecked,omitempty"`
+	// Details is the error of the last check, if it failed
+	// +optional
+	Details string `json:"details,omitempty"`
+	// LargeBucketCount is the number of buckets over the objects per shard threshold
+	// +optional
+	LargeBucketCount int `json:"largeBucketCount,omitempty"`
+	// LargeBuckets are the buckets with the most objects per shard over the threshold. At most 20
+	// buckets are listed.
+	// +optional
+	// +nullable
+	LargeBuckets []BucketIndexStatus `json:"largeBuckets,omitempty"`
+	// Reshards are the buckets in the reshard queue
+	// +optional
+	// +nullable
+	Reshards []BucketReshardStatus `json:"reshards,omitempty"`
+}
+
+// BucketIndexStatus represents the index of a bucket
+type BucketIndexStatus struct {
+	// Bucket is the name of the bucket, prefixed with its tenant if any
+	Bucket string `json:"bucket"`
+	// Owner is the user owning the bucket
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// NumObjects is the number of objects in the bucket
+	NumObjects int64 `json:"numObjects"`
+	// NumShards is the number of shards of the bucket index
+	NumShards int64 `json:"numShards"`
+	// ObjectsPerShard is the number of objects per shard of the bucket index
+	ObjectsPerShard int64 `json:"objectsPerShard"`
+	// FillStatus is the fill status of the bucket index reported by RGW
+	// +optional
+	FillStatus string `json:"fillStatus,omitempty"`
+}
+
+// BucketReshardStatus represents a bucket in the reshard queue
+type BucketReshardStatus struct {
+	// Bucket is the name of the bucket, prefixed with its tenant if any
+	Bucket string `json:"bucket"`
+	// OldNumShards is the number of shards of the bucket index before the reshard
+	// +optional
+	OldNumShards int64 `json:"oldNumShards,omitempty"`
+	// NewNumShards is the number of shards of the bucket index after the reshard
+	// +optional
+	NewNumShards int64 `json:"newNumShards,omitempty"`
+	// Status is the reshard status of the bucket, "queued" or "in-progress"
+	// +optional
+	Status string `json:"status,omitempty"`
+	// QueuedTime is the time the bucket was queued for resharding
+	// +optional
+	QueuedTime string `json:"queuedTime,omitempty"`
+}
+
+// ObjectStoreRateLimitsSpec represents the global RGW rate limits of the object store. A scope that
+// is not set has no global rate limit.
+type ObjectStoreRateLimitsSpec struct {
+	// User is the rate limit applied to each user
+	// +optional
+	// +nullable
+	User *RateLimitSpec `json:"user,omitempty"`
+	// Bucket is the rate limit applied to each bucket
+	// +optional
+	// +nullable
+	Bucket *RateLimitSpec `json:"bucket,omitempty"`
+	// Anonymous is the rate limit applied to unauthenticated requests
+	// +optional
+	// +nullable
+	Anonymous *RateLimitSpec `json:"anonymous,omitempty"`
+}
+
+// RateLimitSpec represents an RGW rate limit. The limits are enforced per minute by each RGW
+// daemon. A limit that is not set or is zero is unlimited.
+type RateLimitSpec struct {
+	// MaxReadOps is the maximum number of read operations per minute
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	// +nullable
+	MaxReadOps *int64 `json:"maxReadOps,omitempty"`
+	// MaxWriteOps is the maximum number of write operations per minute
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	// +nullable
+	MaxWriteOps *int64 `json:"maxWriteOps,omitempty"`
+	// MaxReadBytes is the maximum number of bytes read per minute
+	// See https://pkg.go.dev/k8s.io/apimachinery/pkg/api/resource#Quantity for more info.
+	// +optional
+	// +nullable
+	MaxReadBytes *resource.Quantity `json:"maxReadBytes,omitempty"`
+	// MaxWriteBytes is the maximum number of bytes written per minute
+	// See https://pkg.go.dev/k8s.io/apimachinery/pkg/api/resource#Quantity for more info.
+	// +optional
+	// +nullable
+	MaxWriteBytes *resource.Quantity `json:"maxWriteBytes,omitempty"`
+}
+
+// ObjectStoreLuaScript represents an RGW Lua script stored in a ConfigMap
+type ObjectStoreLuaScript struct {
+	// Context is the RGW context in which the script runs
+	// +kubebuilder:validation:Enum=preRequest;postRequest;background;getData;putData
+	Context LuaScriptContext `json:"context"`
+	// Tenant the script applies to. If not set, the script applies to all tenants that do not have
+	// their own script in the same context.
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+	// ConfigMapKeyRef is the reference to the ConfigMap key holding the Lua script.
+	// The ConfigMap must be in the object store namespace.
+	ConfigMapKeyRef v1.ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// LuaScriptContext is the RGW context in which a Lua script runs
+type LuaScriptContext string
+
+const (
+	// LuaScriptContextPreRequest runs the script before each request is executed
+	LuaScriptContextPreRequest LuaScriptContext = "preRequest"
+	// LuaScriptContextPostRequest runs the script after each request is executed
+	LuaScriptContextPostRequest LuaScriptContext = "postRequest"
+	// LuaScriptContextBackground runs the script periodically in the background
+	LuaScriptContextBackground LuaScriptContext = "background"
+	// LuaScriptContextGetData runs the script on the data of each object read
+	LuaScriptContextGetData LuaScriptContext = "getData"
+	// LuaScriptContextPutData runs the script on the data of each object written
+	LuaScriptContextPutData LuaScriptContext = "putData"
+)
+
+// ObjectStoreLuaScriptsStatus represents the Lua scripts and packages installed by Rook on the object store
+type ObjectStoreLuaScriptsStatus struct {
+	// Scripts are the Lua scripts installed on the object store
+	// +optional
+	// +nullable
+	Scripts []ObjectStoreLuaScriptStatus `json:"scripts,omitempty"`
+	// AllowedPackages are the Lua packages allowed on the object store
+	// +optional
+	// +nullable
+	AllowedPackages []string `json:"allowedPackages,omitempty"`
+}
+
+// ObjectStoreLuaScriptStatus represents a Lua script installed by Rook on the object store
+type ObjectStoreLuaScriptStatus struct {
+	// Context is the RGW context in which the script runs
+	Context LuaScriptContext `json:"context"`
+	// Tenant the script applies to
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+	// Hash is the hash of the installed script content
+	// +optional
+	Hash string `json:"hash,omitempty"`
+}
+
 // ObjectEndpointSpec represents an object store endpoint
 type ObjectEndpointSpec struct {
 	// DnsName is the DNS name (in RFC-1123 format) of the endpoint.
@@ -2409,6 +3325,31 @@ type ObjectStoreUserStatus struct {
 	// +optional
 	// +nullable
 	Keys []SecretReference `json:"keys,omitempty"`
+	// Usage is the capacity used by the buckets of the user
+	// +optional
+	Usage *UsageStatus `json:"usage,omitempty"`
+	// KeyRotation is the status of the rotation of the operator-managed key pair
+	// +optional
+	KeyRotation *ObjectKeyRotationStatus `json:"keyRotation,omitempty"`
+}
+
+// ObjectKeyRotationStatus is the status of the rotation of an S3 key pair
+type ObjectKeyRotationStatus struct {
+	// KeyGeneration is incremented each time a new key pair is published
+	// +optional
+	KeyGeneration int64 `json:"keyGeneration,omitempty"`
+	// LastRotationTime is the time the current key pair was published
+	// +optional
+	// +nullable
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// PreviousAccessKeyID is the access key ID of the previous key pair, which remains valid
+	// until PreviousKeyRevokeTime
+	// +optional
+	PreviousAccessKeyID string `json:"previousAccessKeyID,omitempty"`
+	// PreviousKeyRevokeTime is the time after which the previous key pair is revoked
+	// +optional
+	// +nullable
+	PreviousKeyRevokeTime *metav1.Time `json:"previousKeyRevokeTime,omitempty"`
 }
 
 type SecretReference struct {
@@ -2460,6 +3401,22 @@ type ObjectStoreUserSpec struct {
 	// +optional
 	// +kubebuilder:validation:XValidation:message="accountRef is immutable",rule="self == oldSelf"
 	AccountRef ObjectStoreUserAccountRef `json:"accountRef,omitzero"`
+	// KeyRotation periodically replaces the key pair generated by the operator. The new key pair
+	// is published to the user secret and the previous key pair remains valid for the grace period.
+	// Key rotation cannot be used together with keys.
+	// +optional
+	// +nullable
+	KeyRotation *ObjectKeyRotationSpec `json:"keyRotation,omitempty"`
+}
+
+// ObjectKeyRotationSpec is the rotation policy of an S3 key pair
+type ObjectKeyRotationSpec struct {
+	// Interval is the time between two rotations, for example "720h"
+	Interval *metav1.Duration `json:"interval"`
+	// GracePeriod is the time the previous key pair remains valid after a new key pair is
+	// published. Defaults to 24h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 // ObjectStoreUserAccountRef is a reference to a CephObjectStoreAccount
@@ -2671,7 +3628,91 @@ type CephObjectZone struct {
 	Spec              ObjectZoneSpec `json:"spec"`
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
-	Status *Status `json:"status,omitempty"`
+	Status *ObjectZoneStatus `json:"status,omitempty"`
+}
+
+// ObjectZoneStatus represents the status of a Ceph Object Store Gateway Zone
+type ObjectZoneStatus struct {
+	Status `json:",inline"` // inline core Status
+	// SyncStatus is the multisite replication status of the zone
+	// +optional
+	// +nullable
+	SyncStatus *ObjectZoneSyncStatus `json:"syncStatus,omitempty"`
+}
+
+// ObjectZoneSyncStatus represents the multisite replication status of a zone as reported by
+// `radosgw-admin sync status` and `radosgw-admin sync error list`
+type ObjectZoneSyncStatus struct {
+	// Metadata is the status of the metadata sync from the master zone
+	// +optional
+	// +nullable
+	Metadata *ObjectSyncStatus `json:"metadata,omitempty"`
+	// Data is the status of the data sync from each source zone
+	// +optional
+	// +nullable
+	Data []ObjectDataSyncStatus `json:"data,omitempty"`
+	// Buckets is the status of the sync of the buckets listed in syncStatusBuckets from each source zone
+	// +optional
+	// +nullable
+	Buckets []ObjectBucketSyncStatus `json:"buckets,omitempty"`
+	// ErrorCount is the number of entries in the sync error log
+	// +optional
+	ErrorCount int `json:"errorCount,omitempty"`
+	// LastChecked is the last time the status was checked
+	// +optional
+	LastChecked string `json:"lastChecked,omitempty"`
+	// Details contains potential status errors
+	// +optional
+	Details string `json:"details,omitempty"`
+}
+
+// ObjectSyncStatus represents the multisite sync status of the metadata or data of a zone
+type ObjectSyncStatus struct {
+	// State is the sync state reported by RGW, e.g. "syncing" or "no sync (zone is master)"
+	// +optional
+	State string `json:"state,omitempty"`
+	// CaughtUp is true when no shard is behind
+	// +optional
+	CaughtUp bool `json:"caughtUp,omitempty"`
+	// ShardsBehind is the number of shards that are behind
+	// +optional
+	ShardsBehind int `json:"shardsBehind,omitempty"`
+	// RecoveringShards is the number of shards that are recovering from sync errors
+	// +optional
+	RecoveringShards int `json:"recoveringShards,omitempty"`
+	// OldestChangeNotApplied is the time of the oldest incremental change that is not applied yet
+	// +optional
+	OldestChangeNotApplied string `json:"oldestChangeNotApplied,omitempty"`
+	// LagSeconds is the age in seconds of the oldest incremental change that is not applied yet
+	// +optional
+	LagSeconds int64 `json:"lagSeconds,omitempty"`
+}
+
+// ObjectBucketSyncStatus represents the multisite sync status of a bucket from a source zone as
+// reported by `radosgw-admin bucket sync status`
+type ObjectBucketSyncStatus struct {
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// SourceZone is the name of the zone the bucket is synced from
+	// +optional
+	SourceZone string `json:"sourceZone,omitempty"`
+	// CaughtUp is true when no shard of the bucket is behind
+	// +optional
+	CaughtUp bool `json:"caughtUp,omitempty"`
+	// ShardsBehind is the number of shards of the bucket that are behind
+	// +optional
+	ShardsBehind int `json:"shardsBehind,omitempty"`
+	// Details contains potential status errors
+	// +optional
+	Details string `json:"details,omitempty"`
+}
+
+// ObjectDataSyncStatus represents the data sync status of a zone from a source zone
+type ObjectDataSyncStatus struct {
+	// SourceZone is the name of the zone the data is synced from
+	SourceZone string `json:"sourceZone"`
+	// +optional
+	ObjectSyncStatus `json:",inline"`
 }
 
 // CephObjectZoneList represents a list Ceph Object Store Gateway Zones
@@ -2720,6 +3761,18 @@ type ObjectZoneSpec struct {
 	// +optional
 	// +kubebuilder:default=true
 	PreservePoolsOnDelete bool `json:"preservePoolsOnDelete"`
+
+	// SyncStatusCheck configures the periodic check of the multisite replication status of the zone
+	// +optional
+	// +nullable
+	SyncStatusCheck HealthCheckSpec `json:"syncStatusCheck,omitempty"`
+
+	// SyncStatusBuckets are the buckets whose multisite replication status is checked along with
+	// the status of the zone. Checking a bucket is expensive on zones with many buckets, so only the
+	// listed buckets are checked. The bucket of a tenant is named "tenant/bucket".
+	// +optional
+	// +nullable
+	SyncStatusBuckets []string `json:"syncStatusBuckets,omitempty"`
 }
 
 // +genclient
@@ -3018,6 +4071,9 @@ type ObjectStoreAccountStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// Usage is the capacity used by the buckets of the account
+	// +optional
+	Usage *UsageStatus `json:"usage,omitempty"`
 }
 
 // CephObjectStoreAccountList represents the Ceph object store accounts
@@ -3320,7 +4376,9 @@ type AdditionalVolumeMounts []AdditionalVolumeMount
 // +kubebuilder:validation:XValidation:message=`the legacy hostNetwork setting can only be set if the network.provider is set to the empty string`,rule=`!has(self.hostNetwork) || self.hostNetwork == false || !has(self.provider) || self.provider == ""`
 type NetworkSpec struct {
 	// Provider is what provides network connectivity to the cluster e.g. "host" or "multus".
-	// If the Provider is updated from being empty to "host" on a running cluster, then the operator will automatically fail over all the mons to apply the "host" network settings.
+	// If the Provider is updated on a running cluster, then the operator migrates the cluster to the new provider:
+	// it fails over the mons one at a time to the new network, then restarts the other daemons on it.
+	// The progress of the migration is reported in the network status of the cluster.
 	// +kubebuilder:validation:XValidation:message="network provider must be disabled (reverted to empty string) before a new provider is enabled",rule="self == '' || oldSelf == '' || self == oldSelf"
 	// +nullable
 	// +optional
@@ -3503,10 +4561,39 @@ type DisruptionManagementSpec struct {
 	MachineDisruptionBudgetNamespace string `json:"machineDisruptionBudgetNamespace,omitempty"`
 }
 
-// +genclient
-// +genclient:noStatus
-// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
-
+// UpgradeStrategySpec defines how the Ceph daemons are upgraded
+type UpgradeStrategySpec struct {
+	// Canary upgrades a small set of daemons first and watches the cluster during a soak period
+	// before upgrading the other daemons. The upgrade is halted if a regression is detected.
+	// +optional
+	// +nullable
+	Canary *CanaryUpgradeSpec `json:"canary,omitempty"`
+}
+
+// CanaryUpgradeSpec defines the canary daemons and the soak period of a canary upgrade
+type CanaryUpgradeSpec struct {
+	// Mons is the number of mons upgraded in the canary stage. The default is 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Mons *int `json:"mons,omitempty"`
+	// OSDHostsPerDeviceClass is the number of hosts per OSD device class whose OSDs are upgraded in
+	// the canary stage. The default is 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OSDHostsPerDeviceClass *int `json:"osdHostsPerDeviceClass,omitempty"`
+	// RGWs is the number of object stores whose RGW daemons are upgraded in the canary stage. The default is 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RGWs *int `json:"rgws,omitempty"`
+	// SoakTime is the time to watch the cluster after the canary daemons are upgraded. The default is 1h.
+	// +optional
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // CephClient represents a Ceph Client
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
@@ -3829,6 +4916,171 @@ type StorageScopeSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +optional
 	OSDMaxUpdatesInParallel uint32 `json:"osdMaxUpdatesInParallel,omitempty"`
+	// CrushTopology declares the levels of the CRUSH hierarchy above the OSD hosts and the node labels
+	// the names of their buckets are read from. If not set, the topology.rook.io labels and the
+	// Kubernetes zone and region labels are used.
+	// +optional
+	// +nullable
+	CrushTopology *CrushTopologySpec `json:"crushTopology,omitempty"`
+	// OSDUpdateStrategy orders the rolling updates of the existing OSDs by CRUSH failure domain
+	// +optional
+	// +nullable
+	OSDUpdateStrategy *OSDUpdateStrategySpec `json:"osdUpdateStrategy,omitempty"`
+	// CrushWeight manages the CRUSH weights of the OSDs. The new OSDs can be ramped up to their full
+	// weight in steps, and the weights of the OSDs can be raised when their devices grow.
+	// If set, AllowOsdCrushWeightUpdate is ignored.
+	// +optional
+	// +nullable
+	CrushWeight *OSDCrushWeightSpec `json:"crushWeight,omitempty"`
+	// TuningProfiles are named sets of BlueStore, op queue, compression and recovery settings of the
+	// OSDs. They are referenced by name with the "tuningProfile" config of the nodes and devices, or
+	// the tuningProfile of the device sets. The built-in profiles "hdd-archive", "nvme-latency" and
+	// "qlc-capacity" can be referenced without declaring them, and are replaced by a profile of the
+	// same name.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	TuningProfiles []OSDTuningProfile `json:"tuningProfiles,omitempty"`
+}
+
+// OSDTuningProfile represents a named set of settings applied to the OSDs referencing it
+type OSDTuningProfile struct {
+	// Name of the profile
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// BlueStore settings of the OSDs
+	// +optional
+	// +nullable
+	BlueStore *OSDBlueStoreTuning `json:"bluestore,omitempty"`
+	// MClockProfile is the mclock profile of the op queue of the OSDs
+	// +kubebuilder:validation:Enum=high_client_ops;balanced;high_recovery_ops;custom
+	// +optional
+	MClockProfile string `json:"mclockProfile,omitempty"`
+	// Recovery settings of the OSDs
+	// +optional
+	// +nullable
+	Recovery *OSDRecoveryTuning `json:"recovery,omitempty"`
+	// Config are other Ceph settings of the OSDs, applied after the settings above. The keys are the
+	// names of Ceph options, with the words separated by underscores, spaces or dashes.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// OSDBlueStoreTuning represents the BlueStore settings of a tuning profile
+type OSDBlueStoreTuning struct {
+	// MinAllocSize is the allocation unit of BlueStore in bytes. It is only applied when an OSD is
+	// created.
+	// +kubebuilder:validation:Minimum=4096
+	// +optional
+	MinAllocSize *int64 `json:"minAllocSize,omitempty"`
+	// CacheMetaRatio is the ratio of the cache used for metadata
+	// +kubebuilder:validation:Minimum=0.0
+	// +kubebuilder:validation:Maximum=1.0
+	// +optional
+	CacheMetaRatio *float64 `json:"cacheMetaRatio,omitempty"`
+	// CacheKVRatio is the ratio of the cache used for the key/value store
+	// +kubebuilder:validation:Minimum=0.0
+	// +kubebuilder:validation:Maximum=1.0
+	// +optional
+	CacheKVRatio *float64 `json:"cacheKVRatio,omitempty"`
+	// CacheKVOnodeRatio is the ratio of the cache used for the onodes of the key/value store
+	// +kubebuilder:validation:Minimum=0.0
+	// +kubebuilder:validation:Maximum=1.0
+	// +optional
+	CacheKVOnodeRatio *float64 `json:"cacheKVOnodeRatio,omitempty"`
+	// CompressionMode is the inline compression mode of BlueStore
+	// +kubebuilder:validation:Enum=none;passive;aggressive;force
+	// +optional
+	CompressionMode string `json:"compressionMode,omitempty"`
+	// CompressionAlgorithm is the inline compression algorithm of BlueStore
+	// +kubebuilder:validation:Enum=snappy;zlib;zstd;lz4
+	// +optional
+	CompressionAlgorithm string `json:"compressionAlgorithm,omitempty"`
+}
+
+// OSDRecoveryTuning represents the recovery settings of a tuning profile
+type OSDRecoveryTuning struct {
+	// MaxBackfills is the maximum number of backfills to or from an OSD at a time
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBackfills *int32 `json:"maxBackfills,omitempty"`
+	// MaxActive is the maximum number of active recovery requests of an OSD at a time
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxActive *int32 `json:"maxActive,omitempty"`
+	// Sleep is the time in seconds an OSD sleeps between recovery requests. It is ignored by the
+	// mclock op queue.
+	// +kubebuilder:validation:Minimum=0.0
+	// +optional
+	Sleep *float64 `json:"sleep,omitempty"`
+}
+
+// OSDCrushWeightSpec represents the management of the CRUSH weights of the OSDs
+type OSDCrushWeightSpec struct {
+	// InitialWeight is the fraction of its full CRUSH weight a new OSD starts with, from 0 to 1.
+	// The weight of the new OSDs is then raised by a step each time the PGs are clean, until the
+	// OSDs reach their full weight. If not set, the new OSDs start at their full weight.
+	// +kubebuilder:validation:Minimum=0.0
+	// +kubebuilder:validation:Maximum=1.0
+	// +optional
+	// +nullable
+	InitialWeight *float64 `json:"initialWeight,omitempty"`
+	// Step is the fraction of its full CRUSH weight the weight of a ramping OSD is raised by at a
+	// time. The default is 0.1.
+	// +kubebuilder:validation:Minimum=0.0
+	// +kubebuilder:validation:Maximum=1.0
+	// +optional
+	Step float64 `json:"step,omitempty"`
+	// Interval between the checks of the CRUSH weights. At most one step is taken per interval.
+	// The default is 1m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// ResizeOnGrowth raises the CRUSH weight of the OSDs when their devices grow, e.g. after the PVC
+	// of an OSD is expanded. If InitialWeight is set, the weight of the grown OSDs is ramped up in steps.
+	// +optional
+	ResizeOnGrowth bool `json:"resizeOnGrowth,omitempty"`
+}
+
+// OSDUpdateStrategySpec represents the order of the rolling updates of the OSDs
+type OSDUpdateStrategySpec struct {
+	// FailureDomain is the CRUSH bucket type the OSD updates are grouped by, e.g. "host", "rack" or "zone".
+	// All the OSDs of a failure domain are updated, and the PGs must be clean, before the OSDs of the next
+	// failure domain are updated.
+	// +kubebuilder:validation:MinLength=1
+	FailureDomain string `json:"failureDomain"`
+	// Order lists the names of the failure domains to update first, in this order. The other failure
+	// domains are updated afterwards in the order of their names.
+	// +optional
+	Order []string `json:"order,omitempty"`
+}
+
+// CrushTopologySpec represents a user-defined CRUSH hierarchy of the OSDs
+type CrushTopologySpec struct {
+	// Levels are the CRUSH bucket types above the host, from the lowest to the highest, with the node
+	// label of each type. They replace the default topology labels. The bucket types missing from the
+	// CRUSH map are added, and the types are ordered in the CRUSH map as declared.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=type
+	Levels []CrushTopologyLevelSpec `json:"levels"`
+	// MigrateOSDs moves the hosts of the existing OSDs in the CRUSH map when their location in the
+	// hierarchy changes, e.g. after a level is added or a node is relabeled. The data of the moved
+	// hosts is rebalanced. If false, only the new OSDs are placed in the hierarchy.
+	// +optional
+	MigrateOSDs bool `json:"migrateOSDs,omitempty"`
+}
+
+// CrushTopologyLevelSpec represents a level of the CRUSH hierarchy
+type CrushTopologyLevelSpec struct {
+	// Type is the CRUSH bucket type of the level, e.g. "rack" or a custom type like "power-domain"
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Type string `json:"type"`
+	// Label is the node label the names of the buckets of the level are read from, e.g.
+	// "infra.example.com/power-domain". The nodes without the label are not placed in a bucket of the level.
+	// +kubebuilder:validation:MinLength=1
+	Label string `json:"label"`
 }
 
 // Migration handles the OSD migration
@@ -3977,6 +5229,9 @@ type StorageClassDeviceSet struct {
 	// TuneFastDeviceClass Tune the OSD when running on a fast Device Class
 	// +optional
 	TuneFastDeviceClass bool `json:"tuneFastDeviceClass,omitempty"`
+	// TuningProfile is the name of the tuning profile of the OSDs of the set
+	// +optional
+	TuningProfile string `json:"tuningProfile,omitempty"`
 	// Scheduler name for OSD pod placement
 	// +optional
 	SchedulerName string `json:"schedulerName,omitempty"`
@@ -4088,6 +5343,106 @@ type CephFilesystemSubVolumeGroupStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Usage is the capacity used by the subvolumes of the group
+	// +optional
+	Usage *UsageStatus `json:"usage,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephFilesystemSubVolume represents a Ceph Filesystem SubVolume
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Filesystem",type=string,JSONPath=`.spec.filesystemName`,description="Name of the CephFileSystem"
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.subVolumeGroupName`
+// +kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.spec.size`
+// +kubebuilder:printcolumn:name="Path",type=string,JSONPath=`.status.path`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cephfssv;cephsv
+type CephFilesystemSubVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec represents the specification of a Ceph Filesystem SubVolume
+	Spec CephFilesystemSubVolumeSpec `json:"spec"`
+	// Status represents the status of a Ceph Filesystem SubVolume
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Status *CephFilesystemSubVolumeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephFilesystemSubVolumeList represents a list of Ceph filesystem subvolumes
+type CephFilesystemSubVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephFilesystemSubVolume `json:"items"`
+}
+
+// CephFilesystemSubVolumeSpec represents the specification of a Ceph Filesystem SubVolume
+type CephFilesystemSubVolumeSpec struct {
+	// The name of the subvolume. If not set, the default is the name of the CR.
+	// +kubebuilder:validation:XValidation:message="name is immutable",rule="self == oldSelf"
+	// +optional
+	Name string `json:"name,omitempty"`
+	// FilesystemName is the name of the Ceph Filesystem volume of the subvolume. Typically it's the name of
+	// the CephFilesystem CR.
+	// +kubebuilder:validation:XValidation:message="filesystemName is immutable",rule="self == oldSelf"
+	FilesystemName string `json:"filesystemName"`
+	// SubVolumeGroupName is the name of the subvolume group of the subvolume. If not set, the subvolume
+	// is created in the default subvolume group of the filesystem.
+	// +kubebuilder:validation:XValidation:message="subVolumeGroupName is immutable",rule="self == oldSelf"
+	// +optional
+	SubVolumeGroupName string `json:"subVolumeGroupName,omitempty"`
+	// Size is the quota of the subvolume. If not set, the subvolume has no quota.
+	// The subvolume is not shrunk below its used size.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// The data pool name for the subvolume layout, if the data pool of the subvolume group is not desired.
+	// +kubebuilder:validation:XValidation:message="dataPoolName is immutable",rule="self == oldSelf"
+	// +optional
+	DataPoolName string `json:"dataPoolName,omitempty"`
+	// Mode is the octal permission mode of the subvolume directory, e.g. "755"
+	// +kubebuilder:validation:Pattern=`^[0-7]{3,4}$`
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// UID is the owner user ID of the subvolume directory
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UID *int64 `json:"uid,omitempty"`
+	// GID is the owner group ID of the subvolume directory
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GID *int64 `json:"gid,omitempty"`
+	// NamespaceIsolated stores the data of the subvolume in its own RADOS namespace of the data pool.
+	// The credential of the subvolume is then limited to that namespace.
+	// +kubebuilder:validation:XValidation:message="namespaceIsolated is immutable",rule="self == oldSelf"
+	// +optional
+	NamespaceIsolated bool `json:"namespaceIsolated,omitempty"`
+	// Earmark tags the subvolume with its intended use, e.g. "nfs" or "smb.cluster.<cluster id>",
+	// so that it is not exported by another protocol. Requires Ceph Squid or newer.
+	// +optional
+	Earmark string `json:"earmark,omitempty"`
+	// Preserve the subvolume and its data in the filesystem on CephFilesystemSubVolume CR deletion
+	// +optional
+	PreserveSubVolumeOnDelete bool `json:"preserveSubVolumeOnDelete,omitempty"`
+}
+
+// CephFilesystemSubVolumeStatus represents the Status of Ceph Filesystem SubVolume
+type CephFilesystemSubVolumeStatus struct {
+	// +optional
+	Phase ConditionType `json:"phase,omitempty"`
+	// Path is the path of the subvolume in the filesystem
+	// +optional
+	Path string `json:"path,omitempty"`
+	// SecretName is the name of the Secret with the cephx credential limited to the subvolume
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +genclient
@@ -4182,6 +5537,43 @@ type CephBlockPoolRadosNamespaceStatus struct {
 	// +optional
 	SnapshotScheduleStatus *SnapshotScheduleStatusSpec `json:"snapshotScheduleStatus,omitempty"`
 	Conditions             []Condition                 `json:"conditions,omitempty"`
+	// Usage is the capacity used by the RBD images in the rados namespace
+	// +optional
+	Usage *UsageStatus `json:"usage,omitempty"`
+}
+
+// UsageStatus is the capacity used by a tenant of the cluster, collected periodically by the operator
+type UsageStatus struct {
+	// UsedBytes is the number of bytes stored by the tenant
+	UsedBytes int64 `json:"usedBytes"`
+	// ProvisionedBytes is the total size of the RBD images in a rados namespace
+	// +optional
+	ProvisionedBytes int64 `json:"provisionedBytes,omitempty"`
+	// Images is the number of RBD images in a rados namespace
+	// +optional
+	Images int64 `json:"images,omitempty"`
+	// Objects is the number of RADOS objects of an object store user or account
+	// +optional
+	Objects int64 `json:"objects,omitempty"`
+	// QuotaBytes is the quota of the tenant. It is not set if the tenant has no quota.
+	// +optional
+	QuotaBytes int64 `json:"quotaBytes,omitempty"`
+	// BytesSent is the number of bytes sent by the RGW to an object store user or account, from the
+	// RGW usage log
+	// +optional
+	BytesSent int64 `json:"bytesSent,omitempty"`
+	// BytesReceived is the number of bytes received by the RGW from an object store user or account,
+	// from the RGW usage log
+	// +optional
+	BytesReceived int64 `json:"bytesReceived,omitempty"`
+	// Operations is the number of RGW operations of an object store user or account, from the RGW
+	// usage log
+	// +optional
+	Operations int64 `json:"operations,omitempty"`
+	// LastUpdateTime is the time the usage was collected
+	// +optional
+	// +nullable
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 // Represents the source of a volume to mount.
@@ -4363,6 +5755,44 @@ type NVMeOFGatewaySpec struct {
 	// If LivenessProbe.Disabled is false and LivenessProbe.Probe is nil uses default probe.
 	// +optional
 	LivenessProbe *ProbeSpec `json:"livenessProbe,omitempty"`
+
+	// Security configures mutual TLS for the gRPC control plane of the gateway and the key used to
+	// encrypt the DH-HMAC-CHAP and PSK keys of hosts. It is required to use host keys on subsystems.
+	// +optional
+	Security *NVMeOFSecuritySpec `json:"security,omitempty"`
+}
+
+// NVMeOFSecuritySpec represents the security settings of an NVMe-oF gateway
+type NVMeOFSecuritySpec struct {
+	// MTLS enables mutual TLS on the gRPC control plane of the gateway. Clients of the gRPC API,
+	// including the operator, must present a certificate signed by the client CA.
+	// +optional
+	MTLS *NVMeOFMTLSSpec `json:"mtls,omitempty"`
+
+	// EncryptionKeySecretName is the name of a Secret with the key "encryption.key" that the gateway
+	// uses to encrypt the DH-HMAC-CHAP and PSK keys of hosts before storing them in Ceph. If not
+	// specified, the operator generates the key. The key must not change once hosts have keys.
+	// +optional
+	EncryptionKeySecretName string `json:"encryptionKeySecretName,omitempty"`
+}
+
+// NVMeOFMTLSSpec represents the certificates used for mutual TLS on the gRPC control plane of an
+// NVMe-oF gateway. Both secrets must be specified, or neither to have the operator generate a CA and
+// the certificates, which it renews before they expire.
+// +kubebuilder:validation:XValidation:message="serverSecretName and clientSecretName must be specified together",rule="has(self.serverSecretName) == has(self.clientSecretName)"
+type NVMeOFMTLSSpec struct {
+	// ServerSecretName is the name of a Secret with the certificate ("tls.crt") and key ("tls.key")
+	// served by the gateway and the CA ("ca.crt") that signs client certificates. The certificate must
+	// be valid for the service name of each gateway instance, e.g. "rook-ceph-nvmeof-<gateway>-a.<namespace>.svc".
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ServerSecretName string `json:"serverSecretName,omitempty"`
+
+	// ClientSecretName is the name of a Secret with the client certificate ("tls.crt") and key
+	// ("tls.key") presented by the operator and the CA ("ca.crt") that signs the server certificate
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ClientSecretName string `json:"clientSecretName,omitempty"`
 }
 
 // NVMeOFGatewayPorts represents the port configuration for NVMe-oF gateway
@@ -4391,3 +5821,284 @@ type NVMeOFGatewayPorts struct {
 	// +optional
 	DiscoveryPort int32 `json:"discoveryPort,omitempty"`
 }
+
+// +genclient
+// +genclient:noStatus
+// +kubebuilder:resource:shortName=nvmeofss,path=cephnvmeofsubsystems
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.spec.gatewayName`
+// +kubebuilder:printcolumn:name="NQN",type=string,JSONPath=`.status.nqn`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+//
+// CephNVMeOFSubsystem represents an NVMe-oF subsystem exported by a CephNVMeOFGateway
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CephNVMeOFSubsystem struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              NVMeOFSubsystemSpec `json:"spec"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Status *NVMeOFSubsystemStatus `json:"status,omitempty"`
+}
+
+// CephNVMeOFSubsystemList represents a list of Ceph NVMe-oF subsystems
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CephNVMeOFSubsystemList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephNVMeOFSubsystem `json:"items"`
+}
+
+// NVMeOFSubsystemSpec represents the spec of an NVMe-oF subsystem
+type NVMeOFSubsystemSpec struct {
+	// GatewayName is the name of the CephNVMeOFGateway in the same namespace that exports the subsystem
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:message="gatewayName is immutable",rule="self == oldSelf"
+	GatewayName string `json:"gatewayName"`
+
+	// NQN is the NVMe qualified name of the subsystem.
+	// If not specified, "nqn.2016-06.io.rook:<namespace>.<name>" is used.
+	// +optional
+	// +kubebuilder:validation:MaxLength=223
+	// +kubebuilder:validation:Pattern=`^nqn\.`
+	// +kubebuilder:validation:XValidation:message="nqn is immutable",rule="self == oldSelf"
+	NQN string `json:"nqn,omitempty"`
+
+	// SerialNumber of the subsystem. If not specified, the gateway generates one.
+	// It can only be set when the subsystem is created.
+	// +optional
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:message="serialNumber is immutable",rule="self == oldSelf"
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// MaxNamespaces is the maximum number of namespaces of the subsystem. If not specified, the
+	// gateway default is used. It can only be set when the subsystem is created.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:message="maxNamespaces is immutable",rule="self == oldSelf"
+	MaxNamespaces int32 `json:"maxNamespaces,omitempty"`
+
+	// Namespaces are the RBD images exported by the subsystem. Namespaces of the subsystem that are
+	// not in the list are removed from the subsystem, the RBD images are not deleted.
+	// +optional
+	// +listType=map
+	// +listMapKey=pool
+	// +listMapKey=image
+	Namespaces []NVMeOFNamespaceSpec `json:"namespaces,omitempty"`
+
+	// Hosts are the NVMe-oF hosts allowed to connect to the subsystem
+	// +optional
+	// +listType=map
+	// +listMapKey=nqn
+	Hosts []NVMeOFHostSpec `json:"hosts,omitempty"`
+
+	// AllowAnyHost allows any host to connect to the subsystem. Hosts is ignored when set.
+	// +optional
+	AllowAnyHost bool `json:"allowAnyHost,omitempty"`
+
+	// DHCHAPKey references the DH-HMAC-CHAP key of the subsystem in the same namespace. When set,
+	// hosts with a DH-HMAC-CHAP key also authenticate the subsystem (bidirectional authentication).
+	// The gateway must have security settings to use keys.
+	// +optional
+	DHCHAPKey *v1.SecretKeySelector `json:"dhchapKey,omitempty"`
+}
+
+// NVMeOFNamespaceSpec represents an RBD image exported as a namespace of an NVMe-oF subsystem
+type NVMeOFNamespaceSpec struct {
+	// Pool is the name of the RBD pool of the image
+	// +kubebuilder:validation:MinLength=1
+	Pool string `json:"pool"`
+
+	// Image is the name of the RBD image
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// NSID is the namespace ID. If not specified, the gateway assigns the next free ID.
+	// It can only be set when the namespace is added.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	NSID int32 `json:"nsid,omitempty"`
+
+	// Size of the RBD image. If set, the image is created by the gateway when it does not exist.
+	// Otherwise the image must already exist.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// LoadBalancingGroup is the ANA group of the gateway instance that serves the namespace.
+	// If not specified, the gateway places the namespace on the instance with the fewest namespaces.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	LoadBalancingGroup int32 `json:"loadBalancingGroup,omitempty"`
+}
+
+// NVMeOFHostSpec represents an NVMe-oF host allowed to connect to a subsystem
+type NVMeOFHostSpec struct {
+	// NQN is the NVMe qualified name of the host
+	// +kubebuilder:validation:Pattern=`^nqn\.`
+	NQN string `json:"nqn"`
+
+	// DHCHAPKey references the DH-HMAC-CHAP key of the host in the same namespace, in the format
+	// generated by "nvme gen-dhchap-key" (DHHC-1:...). The host must authenticate with this key.
+	// +optional
+	DHCHAPKey *v1.SecretKeySelector `json:"dhchapKey,omitempty"`
+
+	// PSK references the TLS pre-shared key of the host in the same namespace, in the format
+	// generated by "nvme gen-tls-key" (NVMeTLSkey-1:...). When any host has a PSK, the listeners of
+	// the subsystem require TLS and all hosts must have a PSK.
+	// +optional
+	PSK *v1.SecretKeySelector `json:"psk,omitempty"`
+}
+
+// NVMeOFSubsystemStatus represents the status of an NVMe-oF subsystem
+type NVMeOFSubsystemStatus struct {
+	// +optional
+	Phase ConditionType `json:"phase,omitempty"`
+	// Message explains the phase, e.g. why the subsystem failed to reconcile
+	// +optional
+	Message string `json:"message,omitempty"`
+	// NQN is the NVMe qualified name of the subsystem
+	// +optional
+	NQN string `json:"nqn,omitempty"`
+	// Namespaces are the namespaces of the subsystem and the ANA group serving them
+	// +optional
+	Namespaces []NVMeOFNamespaceStatus `json:"namespaces,omitempty"`
+	// Listeners are the addresses on which the gateway instances accept connections to the subsystem
+	// +optional
+	Listeners []NVMeOFListenerStatus `json:"listeners,omitempty"`
+	// Gateways reports the ANA group of each gateway instance and how many namespaces it serves
+	// +optional
+	Gateways []NVMeOFGatewayInstanceStatus `json:"gateways,omitempty"`
+	// Hosts reports the hosts allowed to connect to the subsystem and the keys they authenticate with
+	// +optional
+	Hosts []NVMeOFHostStatus `json:"hosts,omitempty"`
+	// DHCHAPKeyHash is the hash of the DH-HMAC-CHAP key of the subsystem last set on the gateway
+	// +optional
+	DHCHAPKeyHash string `json:"dhchapKeyHash,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// NVMeOFNamespaceStatus represents the status of a namespace of an NVMe-oF subsystem
+type NVMeOFNamespaceStatus struct {
+	NSID  int32  `json:"nsid"`
+	Pool  string `json:"pool"`
+	Image string `json:"image"`
+	// ANAGroup is the load balancing group of the namespace
+	// +optional
+	ANAGroup int32 `json:"anaGroup,omitempty"`
+	// Gateway is the gateway instance that owns the ANA group of the namespace
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// NVMeOFListenerStatus represents a listener of an NVMe-oF subsystem
+type NVMeOFListenerStatus struct {
+	Gateway string `json:"gateway"`
+	Address string `json:"address"`
+	Port    int32  `json:"port"`
+}
+
+// NVMeOFHostStatus represents a host allowed to connect to an NVMe-oF subsystem. The hashes of the
+// keys last set on the gateway are used to detect when the keys in the referenced secrets change.
+type NVMeOFHostStatus struct {
+	NQN string `json:"nqn"`
+	// +optional
+	DHCHAPKeyHash string `json:"dhchapKeyHash,omitempty"`
+	// +optional
+	PSKHash string `json:"pskHash,omitempty"`
+}
+
+// NVMeOFGatewayInstanceStatus represents the load of a gateway instance for an NVMe-oF subsystem
+type NVMeOFGatewayInstanceStatus struct {
+	Name string `json:"name"`
+	// ANAGroup is the load balancing group owned by the gateway instance
+	// +optional
+	ANAGroup int32 `json:"anaGroup,omitempty"`
+	// Namespaces is the number of namespaces of the subsystem in the ANA group of the instance
+	Namespaces int `json:"namespaces"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephNodeMaintenance represents a planned maintenance of a node running Ceph daemons
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cephnm
+type CephNodeMaintenance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec represents the specification of a Ceph node maintenance
+	Spec NodeMaintenanceSpec `json:"spec"`
+	// Status represents the status of a Ceph node maintenance
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Status *NodeMaintenanceStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephNodeMaintenanceList represents a list of Ceph node maintenances
+type CephNodeMaintenanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephNodeMaintenance `json:"items"`
+}
+
+// NodeMaintenanceSpec represents the specification of a Ceph node maintenance
+type NodeMaintenanceSpec struct {
+	// NodeName is the name of the Kubernetes node to put in maintenance
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:message="nodeName is immutable",rule="self == oldSelf"
+	NodeName string `json:"nodeName"`
+	// Timeout is the maximum duration of the maintenance. Once expired, the daemons of the node are
+	// restarted and the OSD flags are cleared even if the CR still exists. Defaults to 4h.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// NodeMaintenancePhase represents the phase of a Ceph node maintenance
+type NodeMaintenancePhase string
+
+const (
+	// NodeMaintenancePhaseInProgress is set while the daemons of the node are being stopped
+	NodeMaintenancePhaseInProgress NodeMaintenancePhase = "InProgress"
+	// NodeMaintenancePhaseBlocked is set when stopping a daemon of the node would make data
+	// unavailable or break the mon quorum
+	NodeMaintenancePhaseBlocked NodeMaintenancePhase = "Blocked"
+	// NodeMaintenancePhaseReady is set when all the daemons of the node are stopped
+	NodeMaintenancePhaseReady NodeMaintenancePhase = "Ready"
+	// NodeMaintenancePhaseExpired is set when the daemons of the node were restored after the
+	// maintenance timed out
+	NodeMaintenancePhaseExpired NodeMaintenancePhase = "Expired"
+)
+
+// NodeMaintenanceStatus represents the status of a Ceph node maintenance
+type NodeMaintenanceStatus struct {
+	// +optional
+	Phase NodeMaintenancePhase `json:"phase,omitempty"`
+	// Message explains the phase, e.g. why the maintenance is blocked
+	// +optional
+	Message string `json:"message,omitempty"`
+	// CrushHost is the name of the CRUSH host bucket of the node
+	// +optional
+	CrushHost string `json:"crushHost,omitempty"`
+	// StartTime is the time the maintenance started
+	// +optional
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// StoppedOSDs are the OSDs of the node that are stopped for the maintenance
+	// +optional
+	StoppedOSDs []int `json:"stoppedOSDs,omitempty"`
+	// StoppedMons are the mons of the node that are stopped for the maintenance
+	// +optional
+	StoppedMons []string `json:"stoppedMons,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
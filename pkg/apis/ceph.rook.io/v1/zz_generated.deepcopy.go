@@ -208,6 +208,22 @@ func (in *AuthSpec) DeepCopy() *AuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketIndexStatus) DeepCopyInto(out *BucketIndexStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketIndexStatus.
+func (in *BucketIndexStatus) DeepCopy() *BucketIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketNotificationSpec) DeepCopyInto(out *BucketNotificationSpec) {
 	*out = *in
@@ -234,6 +250,53 @@ func (in *BucketNotificationSpec) DeepCopy() *BucketNotificationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketReshardPolicySpec) DeepCopyInto(out *BucketReshardPolicySpec) {
+	*out = *in
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObjectsPerShard != nil {
+		in, out := &in.ObjectsPerShard, &out.ObjectsPerShard
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxShards != nil {
+		in, out := &in.MaxShards, &out.MaxShards
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketReshardPolicySpec.
+func (in *BucketReshardPolicySpec) DeepCopy() *BucketReshardPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketReshardPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketReshardStatus) DeepCopyInto(out *BucketReshardStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketReshardStatus.
+func (in *BucketReshardStatus) DeepCopy() *BucketReshardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketReshardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketTopicSpec) DeepCopyInto(out *BucketTopicSpec) {
 	*out = *in
@@ -331,6 +394,42 @@ func (in *CSIDriverSpec) DeepCopy() *CSIDriverSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgradeSpec) DeepCopyInto(out *CanaryUpgradeSpec) {
+	*out = *in
+	if in.Mons != nil {
+		in, out := &in.Mons, &out.Mons
+		*out = new(int)
+		**out = **in
+	}
+	if in.OSDHostsPerDeviceClass != nil {
+		in, out := &in.OSDHostsPerDeviceClass, &out.OSDHostsPerDeviceClass
+		*out = new(int)
+		**out = **in
+	}
+	if in.RGWs != nil {
+		in, out := &in.RGWs, &out.RGWs
+		*out = new(int)
+		**out = **in
+	}
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgradeSpec.
+func (in *CanaryUpgradeSpec) DeepCopy() *CanaryUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Capacity) DeepCopyInto(out *Capacity) {
 	*out = *in
@@ -530,6 +629,11 @@ func (in *CephBlockPoolRadosNamespaceStatus) DeepCopyInto(out *CephBlockPoolRado
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(UsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1245,6 +1349,11 @@ func (in *CephFilesystemStatus) DeepCopyInto(out *CephFilesystemStatus) {
 		*out = new(FilesystemMirroringInfoSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MDSAutoscale != nil {
+		in, out := &in.MDSAutoscale, &out.MDSAutoscale
+		*out = new(MDSAutoscaleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]Condition, len(*in))
@@ -1265,6 +1374,38 @@ func (in *CephFilesystemStatus) DeepCopy() *CephFilesystemStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephFilesystemSubVolume) DeepCopyInto(out *CephFilesystemSubVolume) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(CephFilesystemSubVolumeStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephFilesystemSubVolume.
+func (in *CephFilesystemSubVolume) DeepCopy() *CephFilesystemSubVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(CephFilesystemSubVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephFilesystemSubVolume) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephFilesystemSubVolumeGroup) DeepCopyInto(out *CephFilesystemSubVolumeGroup) {
 	*out = *in
@@ -1393,6 +1534,11 @@ func (in *CephFilesystemSubVolumeGroupStatus) DeepCopyInto(out *CephFilesystemSu
 			(*out)[key] = val
 		}
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(UsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1406,6 +1552,86 @@ func (in *CephFilesystemSubVolumeGroupStatus) DeepCopy() *CephFilesystemSubVolum
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephFilesystemSubVolumeList) DeepCopyInto(out *CephFilesystemSubVolumeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephFilesystemSubVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephFilesystemSubVolumeList.
+func (in *CephFilesystemSubVolumeList) DeepCopy() *CephFilesystemSubVolumeList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephFilesystemSubVolumeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephFilesystemSubVolumeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephFilesystemSubVolumeSpec) DeepCopyInto(out *CephFilesystemSubVolumeSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GID != nil {
+		in, out := &in.GID, &out.GID
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephFilesystemSubVolumeSpec.
+func (in *CephFilesystemSubVolumeSpec) DeepCopy() *CephFilesystemSubVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CephFilesystemSubVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephFilesystemSubVolumeStatus) DeepCopyInto(out *CephFilesystemSubVolumeStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephFilesystemSubVolumeStatus.
+func (in *CephFilesystemSubVolumeStatus) DeepCopy() *CephFilesystemSubVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CephFilesystemSubVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephHealthMessage) DeepCopyInto(out *CephHealthMessage) {
 	*out = *in
@@ -1553,31 +1779,31 @@ func (in *CephNVMeOFGatewayList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectRealm) DeepCopyInto(out *CephObjectRealm) {
+func (in *CephNVMeOFSubsystem) DeepCopyInto(out *CephNVMeOFSubsystem) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(Status)
+		*out = new(NVMeOFSubsystemStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealm.
-func (in *CephObjectRealm) DeepCopy() *CephObjectRealm {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephNVMeOFSubsystem.
+func (in *CephNVMeOFSubsystem) DeepCopy() *CephNVMeOFSubsystem {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectRealm)
+	out := new(CephNVMeOFSubsystem)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectRealm) DeepCopyObject() runtime.Object {
+func (in *CephNVMeOFSubsystem) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1585,13 +1811,13 @@ func (in *CephObjectRealm) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectRealmList) DeepCopyInto(out *CephObjectRealmList) {
+func (in *CephNVMeOFSubsystemList) DeepCopyInto(out *CephNVMeOFSubsystemList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CephObjectRealm, len(*in))
+		*out = make([]CephNVMeOFSubsystem, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1599,18 +1825,18 @@ func (in *CephObjectRealmList) DeepCopyInto(out *CephObjectRealmList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealmList.
-func (in *CephObjectRealmList) DeepCopy() *CephObjectRealmList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephNVMeOFSubsystemList.
+func (in *CephNVMeOFSubsystemList) DeepCopy() *CephNVMeOFSubsystemList {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectRealmList)
+	out := new(CephNVMeOFSubsystemList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectRealmList) DeepCopyObject() runtime.Object {
+func (in *CephNVMeOFSubsystemList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1618,31 +1844,31 @@ func (in *CephObjectRealmList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectStore) DeepCopyInto(out *CephObjectStore) {
+func (in *CephNodeMaintenance) DeepCopyInto(out *CephNodeMaintenance) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(ObjectStoreStatus)
+		*out = new(NodeMaintenanceStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStore.
-func (in *CephObjectStore) DeepCopy() *CephObjectStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephNodeMaintenance.
+func (in *CephNodeMaintenance) DeepCopy() *CephNodeMaintenance {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectStore)
+	out := new(CephNodeMaintenance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectStore) DeepCopyObject() runtime.Object {
+func (in *CephNodeMaintenance) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1650,31 +1876,32 @@ func (in *CephObjectStore) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectStoreAccount) DeepCopyInto(out *CephObjectStoreAccount) {
+func (in *CephNodeMaintenanceList) DeepCopyInto(out *CephNodeMaintenanceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(ObjectStoreAccountStatus)
-		(*in).DeepCopyInto(*out)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephNodeMaintenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreAccount.
-func (in *CephObjectStoreAccount) DeepCopy() *CephObjectStoreAccount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephNodeMaintenanceList.
+func (in *CephNodeMaintenanceList) DeepCopy() *CephNodeMaintenanceList {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectStoreAccount)
+	out := new(CephNodeMaintenanceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectStoreAccount) DeepCopyObject() runtime.Object {
+func (in *CephNodeMaintenanceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1682,32 +1909,31 @@ func (in *CephObjectStoreAccount) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectStoreAccountList) DeepCopyInto(out *CephObjectStoreAccountList) {
+func (in *CephObjectRealm) DeepCopyInto(out *CephObjectRealm) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CephObjectStoreAccount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(Status)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreAccountList.
-func (in *CephObjectStoreAccountList) DeepCopy() *CephObjectStoreAccountList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealm.
+func (in *CephObjectRealm) DeepCopy() *CephObjectRealm {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectStoreAccountList)
+	out := new(CephObjectRealm)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectStoreAccountList) DeepCopyObject() runtime.Object {
+func (in *CephObjectRealm) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1715,13 +1941,13 @@ func (in *CephObjectStoreAccountList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectStoreList) DeepCopyInto(out *CephObjectStoreList) {
+func (in *CephObjectRealmList) DeepCopyInto(out *CephObjectRealmList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CephObjectStore, len(*in))
+		*out = make([]CephObjectRealm, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1729,18 +1955,18 @@ func (in *CephObjectStoreList) DeepCopyInto(out *CephObjectStoreList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreList.
-func (in *CephObjectStoreList) DeepCopy() *CephObjectStoreList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectRealmList.
+func (in *CephObjectRealmList) DeepCopy() *CephObjectRealmList {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectStoreList)
+	out := new(CephObjectRealmList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectStoreList) DeepCopyObject() runtime.Object {
+func (in *CephObjectRealmList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1748,31 +1974,31 @@ func (in *CephObjectStoreList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectStoreUser) DeepCopyInto(out *CephObjectStoreUser) {
+func (in *CephObjectStore) DeepCopyInto(out *CephObjectStore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(ObjectStoreUserStatus)
+		*out = new(ObjectStoreStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreUser.
-func (in *CephObjectStoreUser) DeepCopy() *CephObjectStoreUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStore.
+func (in *CephObjectStore) DeepCopy() *CephObjectStore {
 	if in == nil {
 		return nil
 	}
-	out := new(CephObjectStoreUser)
+	out := new(CephObjectStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CephObjectStoreUser) DeepCopyObject() runtime.Object {
+func (in *CephObjectStore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1780,18 +2006,148 @@ func (in *CephObjectStoreUser) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CephObjectStoreUserList) DeepCopyInto(out *CephObjectStoreUserList) {
+func (in *CephObjectStoreAccount) DeepCopyInto(out *CephObjectStoreAccount) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CephObjectStoreUser, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	return
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ObjectStoreAccountStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreAccount.
+func (in *CephObjectStoreAccount) DeepCopy() *CephObjectStoreAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreAccountList) DeepCopyInto(out *CephObjectStoreAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephObjectStoreAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreAccountList.
+func (in *CephObjectStoreAccountList) DeepCopy() *CephObjectStoreAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreList) DeepCopyInto(out *CephObjectStoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephObjectStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreList.
+func (in *CephObjectStoreList) DeepCopy() *CephObjectStoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreUser) DeepCopyInto(out *CephObjectStoreUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ObjectStoreUserStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreUser.
+func (in *CephObjectStoreUser) DeepCopy() *CephObjectStoreUser {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreUserList) DeepCopyInto(out *CephObjectStoreUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephObjectStoreUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreUserList.
@@ -1820,7 +2176,7 @@ func (in *CephObjectZone) DeepCopyInto(out *CephObjectZone) {
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(Status)
+		*out = new(ObjectZoneStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -2122,6 +2478,74 @@ func (in *CephxConfig) DeepCopy() *CephxConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephxEntityStatus) DeepCopyInto(out *CephxEntityStatus) {
+	*out = *in
+	if in.KeySince != nil {
+		in, out := &in.KeySince, &out.KeySince
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephxEntityStatus.
+func (in *CephxEntityStatus) DeepCopy() *CephxEntityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CephxEntityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephxInventorySpec) DeepCopyInto(out *CephxInventorySpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephxInventorySpec.
+func (in *CephxInventorySpec) DeepCopy() *CephxInventorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CephxInventorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephxInventoryStatus) DeepCopyInto(out *CephxInventoryStatus) {
+	*out = *in
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	if in.Overdue != nil {
+		in, out := &in.Overdue, &out.Overdue
+		*out = make([]CephxEntityStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephxInventoryStatus.
+func (in *CephxInventoryStatus) DeepCopy() *CephxInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CephxInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephxStatus) DeepCopyInto(out *CephxStatus) {
 	*out = *in
@@ -2224,6 +2648,11 @@ func (in *ClusterCephxConfig) DeepCopyInto(out *ClusterCephxConfig) {
 	out.Daemon = in.Daemon
 	out.RBDMirrorPeer = in.RBDMirrorPeer
 	out.CSI = in.CSI
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(CephxInventorySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2248,6 +2677,11 @@ func (in *ClusterCephxStatus) DeepCopyInto(out *ClusterCephxStatus) {
 	out.RBDMirrorPeer = in.RBDMirrorPeer
 	out.CrashCollector = in.CrashCollector
 	out.CephExporter = in.CephExporter
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(CephxInventoryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2341,12 +2775,22 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.DisruptionManagement = in.DisruptionManagement
 	in.Mon.DeepCopyInto(&out.Mon)
-	out.CrashCollector = in.CrashCollector
+	in.CrashCollector.DeepCopyInto(&out.CrashCollector)
 	out.Dashboard = in.Dashboard
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
-	out.External = in.External
+	in.External.DeepCopyInto(&out.External)
+	if in.ConnectionBundle != nil {
+		in, out := &in.ConnectionBundle, &out.ConnectionBundle
+		*out = new(ConnectionBundleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Mgr.DeepCopyInto(&out.Mgr)
 	out.CleanupPolicy = in.CleanupPolicy
 	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
@@ -2415,7 +2859,7 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(CephStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Cephx = in.Cephx
+	in.Cephx.DeepCopyInto(&out.Cephx)
 	if in.CephStorage != nil {
 		in, out := &in.CephStorage, &out.CephStorage
 		*out = new(CephStorage)
@@ -2426,6 +2870,26 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(ClusterVersion)
 		**out = **in
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Crashes != nil {
+		in, out := &in.Crashes, &out.Crashes
+		*out = new(CrashesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NetworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionBundle != nil {
+		in, out := &in.ConnectionBundle, &out.ConnectionBundle
+		*out = new(ConnectionBundleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2536,96 +3000,338 @@ func (in *ConfigFileVolumeSource) DeepCopy() *ConfigFileVolumeSource {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConnectionsSpec) DeepCopyInto(out *ConnectionsSpec) {
+func (in *ConnectionBundleConsumerSpec) DeepCopyInto(out *ConnectionBundleConsumerSpec) {
 	*out = *in
-	if in.Encryption != nil {
-		in, out := &in.Encryption, &out.Encryption
-		*out = new(EncryptionSpec)
-		**out = **in
-	}
-	if in.Compression != nil {
-		in, out := &in.Compression, &out.Compression
-		*out = new(CompressionSpec)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionsSpec.
-func (in *ConnectionsSpec) DeepCopy() *ConnectionsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionBundleConsumerSpec.
+func (in *ConnectionBundleConsumerSpec) DeepCopy() *ConnectionBundleConsumerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConnectionsSpec)
+	out := new(ConnectionBundleConsumerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CrashCollectorSpec) DeepCopyInto(out *CrashCollectorSpec) {
+func (in *ConnectionBundleConsumerStatus) DeepCopyInto(out *ConnectionBundleConsumerStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashCollectorSpec.
-func (in *CrashCollectorSpec) DeepCopy() *CrashCollectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionBundleConsumerStatus.
+func (in *ConnectionBundleConsumerStatus) DeepCopy() *ConnectionBundleConsumerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CrashCollectorSpec)
+	out := new(ConnectionBundleConsumerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DaemonHealthSpec) DeepCopyInto(out *DaemonHealthSpec) {
+func (in *ConnectionBundleObjectStore) DeepCopyInto(out *ConnectionBundleObjectStore) {
 	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
-	in.Monitor.DeepCopyInto(&out.Monitor)
-	in.ObjectStorageDaemon.DeepCopyInto(&out.ObjectStorageDaemon)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonHealthSpec.
-func (in *DaemonHealthSpec) DeepCopy() *DaemonHealthSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionBundleObjectStore.
+func (in *ConnectionBundleObjectStore) DeepCopy() *ConnectionBundleObjectStore {
 	if in == nil {
 		return nil
 	}
-	out := new(DaemonHealthSpec)
+	out := new(ConnectionBundleObjectStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
+func (in *ConnectionBundleSpec) DeepCopyInto(out *ConnectionBundleSpec) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]ConnectionBundleConsumerSpec, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
-func (in *DashboardSpec) DeepCopy() *DashboardSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionBundleSpec.
+func (in *ConnectionBundleSpec) DeepCopy() *ConnectionBundleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardSpec)
+	out := new(ConnectionBundleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Device) DeepCopyInto(out *Device) {
+func (in *ConnectionBundleStatus) DeepCopyInto(out *ConnectionBundleStatus) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.RGWEndpoints != nil {
+		in, out := &in.RGWEndpoints, &out.RGWEndpoints
+		*out = make([]ConnectionBundleObjectStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]ConnectionBundleConsumerStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Device.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionBundleStatus.
+func (in *ConnectionBundleStatus) DeepCopy() *ConnectionBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionsSpec) DeepCopyInto(out *ConnectionsSpec) {
+	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(EncryptionSpec)
+		**out = **in
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(CompressionSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionsSpec.
+func (in *ConnectionsSpec) DeepCopy() *ConnectionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashCollectorSpec) DeepCopyInto(out *CrashCollectorSpec) {
+	*out = *in
+	if in.Reporting != nil {
+		in, out := &in.Reporting, &out.Reporting
+		*out = new(CrashReportingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashCollectorSpec.
+func (in *CrashCollectorSpec) DeepCopy() *CrashCollectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashCollectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashForwardSpec) DeepCopyInto(out *CrashForwardSpec) {
+	*out = *in
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashForwardSpec.
+func (in *CrashForwardSpec) DeepCopy() *CrashForwardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashForwardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashReportingSpec) DeepCopyInto(out *CrashReportingSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Forward != nil {
+		in, out := &in.Forward, &out.Forward
+		*out = new(CrashForwardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashReportingSpec.
+func (in *CrashReportingSpec) DeepCopy() *CrashReportingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashReportingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashSummary) DeepCopyInto(out *CrashSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashSummary.
+func (in *CrashSummary) DeepCopy() *CrashSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashesStatus) DeepCopyInto(out *CrashesStatus) {
+	*out = *in
+	if in.Recent != nil {
+		in, out := &in.Recent, &out.Recent
+		*out = make([]CrashSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unforwarded != nil {
+		in, out := &in.Unforwarded, &out.Unforwarded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashesStatus.
+func (in *CrashesStatus) DeepCopy() *CrashesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrushTopologyLevelSpec) DeepCopyInto(out *CrushTopologyLevelSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrushTopologyLevelSpec.
+func (in *CrushTopologyLevelSpec) DeepCopy() *CrushTopologyLevelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrushTopologyLevelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrushTopologySpec) DeepCopyInto(out *CrushTopologySpec) {
+	*out = *in
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = make([]CrushTopologyLevelSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrushTopologySpec.
+func (in *CrushTopologySpec) DeepCopy() *CrushTopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrushTopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonHealthSpec) DeepCopyInto(out *DaemonHealthSpec) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.Monitor.DeepCopyInto(&out.Monitor)
+	in.ObjectStorageDaemon.DeepCopyInto(&out.ObjectStorageDaemon)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonHealthSpec.
+func (in *DaemonHealthSpec) DeepCopy() *DaemonHealthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonHealthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
+func (in *DashboardSpec) DeepCopy() *DashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Device) DeepCopyInto(out *Device) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Device.
 func (in *Device) DeepCopy() *Device {
 	if in == nil {
 		return nil
@@ -2720,9 +3426,45 @@ func (in *ErasureCodedSpec) DeepCopy() *ErasureCodedSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalConnectionBundleSpec) DeepCopyInto(out *ExternalConnectionBundleSpec) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalConnectionBundleSpec.
+func (in *ExternalConnectionBundleSpec) DeepCopy() *ExternalConnectionBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalConnectionBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSpec) DeepCopyInto(out *ExternalSpec) {
 	*out = *in
+	if in.ConnectionBundle != nil {
+		in, out := &in.ConnectionBundle, &out.ConnectionBundle
+		*out = new(ExternalConnectionBundleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3462,6 +4204,52 @@ func (in *LogCollectorSpec) DeepCopy() *LogCollectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MDSAutoscaleSpec) DeepCopyInto(out *MDSAutoscaleSpec) {
+	*out = *in
+	if in.ScaleDownRequestRate != nil {
+		in, out := &in.ScaleDownRequestRate, &out.ScaleDownRequestRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MDSAutoscaleSpec.
+func (in *MDSAutoscaleSpec) DeepCopy() *MDSAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MDSAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MDSAutoscaleStatus) DeepCopyInto(out *MDSAutoscaleStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MDSAutoscaleStatus.
+func (in *MDSAutoscaleStatus) DeepCopy() *MDSAutoscaleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MDSAutoscaleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataServerSpec) DeepCopyInto(out *MetadataServerSpec) {
 	*out = *in
@@ -3501,6 +4289,11 @@ func (in *MetadataServerSpec) DeepCopyInto(out *MetadataServerSpec) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.Autoscale != nil {
+		in, out := &in.Autoscale, &out.Autoscale
+		*out = new(MDSAutoscaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3773,20 +4566,46 @@ func (in *ModuleSettings) DeepCopy() *ModuleSettings {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonSpec) DeepCopyInto(out *MonSpec) {
+func (in *MonRebalanceSpec) DeepCopyInto(out *MonRebalanceSpec) {
 	*out = *in
-	if in.Zones != nil {
-		in, out := &in.Zones, &out.Zones
-		*out = make([]MonZoneSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonRebalanceSpec.
+func (in *MonRebalanceSpec) DeepCopy() *MonRebalanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonRebalanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonSpec) DeepCopyInto(out *MonSpec) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]MonZoneSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.StretchCluster != nil {
 		in, out := &in.StretchCluster, &out.StretchCluster
 		*out = new(StretchClusterSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rebalance != nil {
+		in, out := &in.Rebalance, &out.Rebalance
+		*out = new(MonRebalanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VolumeClaimTemplate != nil {
 		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
 		*out = new(VolumeClaimTemplate)
@@ -3964,6 +4783,22 @@ func (in *NFSStatus) DeepCopy() *NFSStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NVMeOFGatewayInstanceStatus) DeepCopyInto(out *NVMeOFGatewayInstanceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFGatewayInstanceStatus.
+func (in *NVMeOFGatewayInstanceStatus) DeepCopy() *NVMeOFGatewayInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NVMeOFGatewayInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NVMeOFGatewayPorts) DeepCopyInto(out *NVMeOFGatewayPorts) {
 	*out = *in
@@ -4031,6 +4866,11 @@ func (in *NVMeOFGatewaySpec) DeepCopyInto(out *NVMeOFGatewaySpec) {
 		*out = new(ProbeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(NVMeOFSecuritySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4063,338 +4903,963 @@ func (in *NVMeOFGatewayStatus) DeepCopy() *NVMeOFGatewayStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamedBlockPoolSpec) DeepCopyInto(out *NamedBlockPoolSpec) {
+func (in *NVMeOFHostSpec) DeepCopyInto(out *NVMeOFHostSpec) {
 	*out = *in
-	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
+	if in.DHCHAPKey != nil {
+		in, out := &in.DHCHAPKey, &out.DHCHAPKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PSK != nil {
+		in, out := &in.PSK, &out.PSK
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedBlockPoolSpec.
-func (in *NamedBlockPoolSpec) DeepCopy() *NamedBlockPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFHostSpec.
+func (in *NVMeOFHostSpec) DeepCopy() *NVMeOFHostSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NamedBlockPoolSpec)
+	out := new(NVMeOFHostSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamedPoolSpec) DeepCopyInto(out *NamedPoolSpec) {
+func (in *NVMeOFHostStatus) DeepCopyInto(out *NVMeOFHostStatus) {
 	*out = *in
-	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedPoolSpec.
-func (in *NamedPoolSpec) DeepCopy() *NamedPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFHostStatus.
+func (in *NVMeOFHostStatus) DeepCopy() *NVMeOFHostStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NamedPoolSpec)
+	out := new(NVMeOFHostStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+func (in *NVMeOFListenerStatus) DeepCopyInto(out *NVMeOFListenerStatus) {
 	*out = *in
-	if in.Selectors != nil {
-		in, out := &in.Selectors, &out.Selectors
-		*out = make(map[CephNetworkType]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.AddressRanges != nil {
-		in, out := &in.AddressRanges, &out.AddressRanges
-		*out = new(AddressRangesSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Connections != nil {
-		in, out := &in.Connections, &out.Connections
-		*out = new(ConnectionsSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	out.MultiClusterService = in.MultiClusterService
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
-func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFListenerStatus.
+func (in *NVMeOFListenerStatus) DeepCopy() *NVMeOFListenerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkSpec)
+	out := new(NVMeOFListenerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Node) DeepCopyInto(out *Node) {
+func (in *NVMeOFMTLSSpec) DeepCopyInto(out *NVMeOFMTLSSpec) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Selection.DeepCopyInto(&out.Selection)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
-func (in *Node) DeepCopy() *Node {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFMTLSSpec.
+func (in *NVMeOFMTLSSpec) DeepCopy() *NVMeOFMTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Node)
+	out := new(NVMeOFMTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in NodesByName) DeepCopyInto(out *NodesByName) {
-	{
-		in := &in
-		*out = make(NodesByName, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-		return
+func (in *NVMeOFNamespaceSpec) DeepCopyInto(out *NVMeOFNamespaceSpec) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesByName.
-func (in NodesByName) DeepCopy() NodesByName {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFNamespaceSpec.
+func (in *NVMeOFNamespaceSpec) DeepCopy() *NVMeOFNamespaceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodesByName)
+	out := new(NVMeOFNamespaceSpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationFilterRule) DeepCopyInto(out *NotificationFilterRule) {
+func (in *NVMeOFNamespaceStatus) DeepCopyInto(out *NVMeOFNamespaceStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterRule.
-func (in *NotificationFilterRule) DeepCopy() *NotificationFilterRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFNamespaceStatus.
+func (in *NVMeOFNamespaceStatus) DeepCopy() *NVMeOFNamespaceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationFilterRule)
+	out := new(NVMeOFNamespaceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationFilterSpec) DeepCopyInto(out *NotificationFilterSpec) {
+func (in *NVMeOFSecuritySpec) DeepCopyInto(out *NVMeOFSecuritySpec) {
 	*out = *in
-	if in.KeyFilters != nil {
-		in, out := &in.KeyFilters, &out.KeyFilters
-		*out = make([]NotificationKeyFilterRule, len(*in))
-		copy(*out, *in)
-	}
-	if in.MetadataFilters != nil {
-		in, out := &in.MetadataFilters, &out.MetadataFilters
-		*out = make([]NotificationFilterRule, len(*in))
-		copy(*out, *in)
-	}
-	if in.TagFilters != nil {
-		in, out := &in.TagFilters, &out.TagFilters
-		*out = make([]NotificationFilterRule, len(*in))
-		copy(*out, *in)
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(NVMeOFMTLSSpec)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterSpec.
-func (in *NotificationFilterSpec) DeepCopy() *NotificationFilterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFSecuritySpec.
+func (in *NVMeOFSecuritySpec) DeepCopy() *NVMeOFSecuritySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationFilterSpec)
+	out := new(NVMeOFSecuritySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotificationKeyFilterRule) DeepCopyInto(out *NotificationKeyFilterRule) {
+func (in *NVMeOFSubsystemSpec) DeepCopyInto(out *NVMeOFSubsystemSpec) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NVMeOFNamespaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]NVMeOFHostSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DHCHAPKey != nil {
+		in, out := &in.DHCHAPKey, &out.DHCHAPKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationKeyFilterRule.
-func (in *NotificationKeyFilterRule) DeepCopy() *NotificationKeyFilterRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFSubsystemSpec.
+func (in *NVMeOFSubsystemSpec) DeepCopy() *NVMeOFSubsystemSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NotificationKeyFilterRule)
+	out := new(NVMeOFSubsystemSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OSDStatus) DeepCopyInto(out *OSDStatus) {
+func (in *NVMeOFSubsystemStatus) DeepCopyInto(out *NVMeOFSubsystemStatus) {
 	*out = *in
-	if in.StoreType != nil {
-		in, out := &in.StoreType, &out.StoreType
-		*out = make(map[string]int, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NVMeOFNamespaceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]NVMeOFListenerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]NVMeOFGatewayInstanceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]NVMeOFHostStatus, len(*in))
+		copy(*out, *in)
 	}
-	out.MigrationStatus = in.MigrationStatus
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStatus.
-func (in *OSDStatus) DeepCopy() *OSDStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NVMeOFSubsystemStatus.
+func (in *NVMeOFSubsystemStatus) DeepCopy() *NVMeOFSubsystemStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OSDStatus)
+	out := new(NVMeOFSubsystemStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OSDStore) DeepCopyInto(out *OSDStore) {
+func (in *NamedBlockPoolSpec) DeepCopyInto(out *NamedBlockPoolSpec) {
 	*out = *in
+	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStore.
-func (in *OSDStore) DeepCopy() *OSDStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedBlockPoolSpec.
+func (in *NamedBlockPoolSpec) DeepCopy() *NamedBlockPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OSDStore)
+	out := new(NamedBlockPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectEndpointSpec) DeepCopyInto(out *ObjectEndpointSpec) {
+func (in *NamedPoolSpec) DeepCopyInto(out *NamedPoolSpec) {
 	*out = *in
+	in.PoolSpec.DeepCopyInto(&out.PoolSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpointSpec.
-func (in *ObjectEndpointSpec) DeepCopy() *ObjectEndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedPoolSpec.
+func (in *NamedPoolSpec) DeepCopy() *NamedPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectEndpointSpec)
+	out := new(NamedPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectEndpoints) DeepCopyInto(out *ObjectEndpoints) {
+func (in *NetworkMigrationStatus) DeepCopyInto(out *NetworkMigrationStatus) {
 	*out = *in
-	if in.Insecure != nil {
-		in, out := &in.Insecure, &out.Insecure
+	if in.PendingMons != nil {
+		in, out := &in.PendingMons, &out.PendingMons
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Secure != nil {
-		in, out := &in.Secure, &out.Secure
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpoints.
-func (in *ObjectEndpoints) DeepCopy() *ObjectEndpoints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkMigrationStatus.
+func (in *NetworkMigrationStatus) DeepCopy() *NetworkMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectEndpoints)
+	out := new(NetworkMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectHealthCheckSpec) DeepCopyInto(out *ObjectHealthCheckSpec) {
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
-	if in.ReadinessProbe != nil {
-		in, out := &in.ReadinessProbe, &out.ReadinessProbe
-		*out = new(ProbeSpec)
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make(map[CephNetworkType]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AddressRanges != nil {
+		in, out := &in.AddressRanges, &out.AddressRanges
+		*out = new(AddressRangesSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.StartupProbe != nil {
-		in, out := &in.StartupProbe, &out.StartupProbe
-		*out = new(ProbeSpec)
+	if in.Connections != nil {
+		in, out := &in.Connections, &out.Connections
+		*out = new(ConnectionsSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	out.MultiClusterService = in.MultiClusterService
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectHealthCheckSpec.
-func (in *ObjectHealthCheckSpec) DeepCopy() *ObjectHealthCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectHealthCheckSpec)
+	out := new(NetworkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectRealmSpec) DeepCopyInto(out *ObjectRealmSpec) {
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
 	*out = *in
-	out.Pull = in.Pull
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(NetworkMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRealmSpec.
-func (in *ObjectRealmSpec) DeepCopy() *ObjectRealmSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectRealmSpec)
+	out := new(NetworkStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectSharedPoolsSpec) DeepCopyInto(out *ObjectSharedPoolsSpec) {
+func (in *Node) DeepCopyInto(out *Node) {
 	*out = *in
-	if in.PoolPlacements != nil {
-		in, out := &in.PoolPlacements, &out.PoolPlacements
-		*out = make([]PoolPlacementSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	in.Selection.DeepCopyInto(&out.Selection)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSharedPoolsSpec.
-func (in *ObjectSharedPoolsSpec) DeepCopy() *ObjectSharedPoolsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
+func (in *Node) DeepCopy() *Node {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectSharedPoolsSpec)
+	out := new(Node)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStoreAccountSpec) DeepCopyInto(out *ObjectStoreAccountSpec) {
+func (in *NodeMaintenanceSpec) DeepCopyInto(out *NodeMaintenanceSpec) {
 	*out = *in
-	if in.RootUser != nil {
-		in, out := &in.RootUser, &out.RootUser
-		*out = new(AccountRootUserSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceSpec.
+func (in *NodeMaintenanceSpec) DeepCopy() *NodeMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMaintenanceStatus) DeepCopyInto(out *NodeMaintenanceStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StoppedOSDs != nil {
+		in, out := &in.StoppedOSDs, &out.StoppedOSDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.StoppedMons != nil {
+		in, out := &in.StoppedMons, &out.StoppedMons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceStatus.
+func (in *NodeMaintenanceStatus) DeepCopy() *NodeMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in NodesByName) DeepCopyInto(out *NodesByName) {
+	{
+		in := &in
+		*out = make(NodesByName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesByName.
+func (in NodesByName) DeepCopy() NodesByName {
+	if in == nil {
+		return nil
+	}
+	out := new(NodesByName)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationFilterRule) DeepCopyInto(out *NotificationFilterRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterRule.
+func (in *NotificationFilterRule) DeepCopy() *NotificationFilterRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationFilterRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationFilterSpec) DeepCopyInto(out *NotificationFilterSpec) {
+	*out = *in
+	if in.KeyFilters != nil {
+		in, out := &in.KeyFilters, &out.KeyFilters
+		*out = make([]NotificationKeyFilterRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetadataFilters != nil {
+		in, out := &in.MetadataFilters, &out.MetadataFilters
+		*out = make([]NotificationFilterRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagFilters != nil {
+		in, out := &in.TagFilters, &out.TagFilters
+		*out = make([]NotificationFilterRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilterSpec.
+func (in *NotificationFilterSpec) DeepCopy() *NotificationFilterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationFilterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationKeyFilterRule) DeepCopyInto(out *NotificationKeyFilterRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationKeyFilterRule.
+func (in *NotificationKeyFilterRule) DeepCopy() *NotificationKeyFilterRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationKeyFilterRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDBlueStoreTuning) DeepCopyInto(out *OSDBlueStoreTuning) {
+	*out = *in
+	if in.MinAllocSize != nil {
+		in, out := &in.MinAllocSize, &out.MinAllocSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CacheMetaRatio != nil {
+		in, out := &in.CacheMetaRatio, &out.CacheMetaRatio
+		*out = new(float64)
+		**out = **in
+	}
+	if in.CacheKVRatio != nil {
+		in, out := &in.CacheKVRatio, &out.CacheKVRatio
+		*out = new(float64)
+		**out = **in
+	}
+	if in.CacheKVOnodeRatio != nil {
+		in, out := &in.CacheKVOnodeRatio, &out.CacheKVOnodeRatio
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDBlueStoreTuning.
+func (in *OSDBlueStoreTuning) DeepCopy() *OSDBlueStoreTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDBlueStoreTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDCrushWeightSpec) DeepCopyInto(out *OSDCrushWeightSpec) {
+	*out = *in
+	if in.InitialWeight != nil {
+		in, out := &in.InitialWeight, &out.InitialWeight
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDCrushWeightSpec.
+func (in *OSDCrushWeightSpec) DeepCopy() *OSDCrushWeightSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDCrushWeightSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDCrushWeightStatus) DeepCopyInto(out *OSDCrushWeightStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDCrushWeightStatus.
+func (in *OSDCrushWeightStatus) DeepCopy() *OSDCrushWeightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDCrushWeightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDRecoveryTuning) DeepCopyInto(out *OSDRecoveryTuning) {
+	*out = *in
+	if in.MaxBackfills != nil {
+		in, out := &in.MaxBackfills, &out.MaxBackfills
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxActive != nil {
+		in, out := &in.MaxActive, &out.MaxActive
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sleep != nil {
+		in, out := &in.Sleep, &out.Sleep
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDRecoveryTuning.
+func (in *OSDRecoveryTuning) DeepCopy() *OSDRecoveryTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDRecoveryTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDStatus) DeepCopyInto(out *OSDStatus) {
+	*out = *in
+	if in.StoreType != nil {
+		in, out := &in.StoreType, &out.StoreType
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.MigrationStatus = in.MigrationStatus
+	if in.CrushWeights != nil {
+		in, out := &in.CrushWeights, &out.CrushWeights
+		*out = make([]OSDCrushWeightStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TuningProfiles != nil {
+		in, out := &in.TuningProfiles, &out.TuningProfiles
+		*out = make([]OSDTuningProfileStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStatus.
+func (in *OSDStatus) DeepCopy() *OSDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDStore) DeepCopyInto(out *OSDStore) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStore.
+func (in *OSDStore) DeepCopy() *OSDStore {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDTuningProfile) DeepCopyInto(out *OSDTuningProfile) {
+	*out = *in
+	if in.BlueStore != nil {
+		in, out := &in.BlueStore, &out.BlueStore
+		*out = new(OSDBlueStoreTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recovery != nil {
+		in, out := &in.Recovery, &out.Recovery
+		*out = new(OSDRecoveryTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDTuningProfile.
+func (in *OSDTuningProfile) DeepCopy() *OSDTuningProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDTuningProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDTuningProfileOptions) DeepCopyInto(out *OSDTuningProfileOptions) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDTuningProfileOptions.
+func (in *OSDTuningProfileOptions) DeepCopy() *OSDTuningProfileOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDTuningProfileOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDTuningProfileStatus) DeepCopyInto(out *OSDTuningProfileStatus) {
+	*out = *in
+	if in.OSDs != nil {
+		in, out := &in.OSDs, &out.OSDs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.OSDOptions != nil {
+		in, out := &in.OSDOptions, &out.OSDOptions
+		*out = make([]OSDTuningProfileOptions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastDriftCorrected != nil {
+		in, out := &in.LastDriftCorrected, &out.LastDriftCorrected
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDTuningProfileStatus.
+func (in *OSDTuningProfileStatus) DeepCopy() *OSDTuningProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDTuningProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDUpdateStrategySpec) DeepCopyInto(out *OSDUpdateStrategySpec) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDUpdateStrategySpec.
+func (in *OSDUpdateStrategySpec) DeepCopy() *OSDUpdateStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDUpdateStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectBucketSyncStatus) DeepCopyInto(out *ObjectBucketSyncStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectBucketSyncStatus.
+func (in *ObjectBucketSyncStatus) DeepCopy() *ObjectBucketSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectBucketSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectDataSyncStatus) DeepCopyInto(out *ObjectDataSyncStatus) {
+	*out = *in
+	out.ObjectSyncStatus = in.ObjectSyncStatus
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectDataSyncStatus.
+func (in *ObjectDataSyncStatus) DeepCopy() *ObjectDataSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectDataSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectEndpointSpec) DeepCopyInto(out *ObjectEndpointSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpointSpec.
+func (in *ObjectEndpointSpec) DeepCopy() *ObjectEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectEndpoints) DeepCopyInto(out *ObjectEndpoints) {
+	*out = *in
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secure != nil {
+		in, out := &in.Secure, &out.Secure
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpoints.
+func (in *ObjectEndpoints) DeepCopy() *ObjectEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectHealthCheckSpec) DeepCopyInto(out *ObjectHealthCheckSpec) {
+	*out = *in
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectHealthCheckSpec.
+func (in *ObjectHealthCheckSpec) DeepCopy() *ObjectHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectKeyRotationSpec) DeepCopyInto(out *ObjectKeyRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectKeyRotationSpec.
+func (in *ObjectKeyRotationSpec) DeepCopy() *ObjectKeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectKeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectKeyRotationStatus) DeepCopyInto(out *ObjectKeyRotationStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousKeyRevokeTime != nil {
+		in, out := &in.PreviousKeyRevokeTime, &out.PreviousKeyRevokeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectKeyRotationStatus.
+func (in *ObjectKeyRotationStatus) DeepCopy() *ObjectKeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRealmSpec) DeepCopyInto(out *ObjectRealmSpec) {
+	*out = *in
+	out.Pull = in.Pull
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRealmSpec.
+func (in *ObjectRealmSpec) DeepCopy() *ObjectRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSharedPoolsSpec) DeepCopyInto(out *ObjectSharedPoolsSpec) {
+	*out = *in
+	if in.PoolPlacements != nil {
+		in, out := &in.PoolPlacements, &out.PoolPlacements
+		*out = make([]PoolPlacementSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSharedPoolsSpec.
+func (in *ObjectSharedPoolsSpec) DeepCopy() *ObjectSharedPoolsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSharedPoolsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreAccountSpec) DeepCopyInto(out *ObjectStoreAccountSpec) {
+	*out = *in
+	if in.RootUser != nil {
+		in, out := &in.RootUser, &out.RootUser
+		*out = new(AccountRootUserSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -4417,6 +5882,11 @@ func (in *ObjectStoreAccountStatus) DeepCopyInto(out *ObjectStoreAccountStatus)
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(UsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4430,6 +5900,59 @@ func (in *ObjectStoreAccountStatus) DeepCopy() *ObjectStoreAccountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreBucketIndexSpec) DeepCopyInto(out *ObjectStoreBucketIndexSpec) {
+	*out = *in
+	in.Check.DeepCopyInto(&out.Check)
+	if in.ObjectsPerShardThreshold != nil {
+		in, out := &in.ObjectsPerShardThreshold, &out.ObjectsPerShardThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Reshard != nil {
+		in, out := &in.Reshard, &out.Reshard
+		*out = new(BucketReshardPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreBucketIndexSpec.
+func (in *ObjectStoreBucketIndexSpec) DeepCopy() *ObjectStoreBucketIndexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreBucketIndexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreBucketIndexStatus) DeepCopyInto(out *ObjectStoreBucketIndexStatus) {
+	*out = *in
+	if in.LargeBuckets != nil {
+		in, out := &in.LargeBuckets, &out.LargeBuckets
+		*out = make([]BucketIndexStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reshards != nil {
+		in, out := &in.Reshards, &out.Reshards
+		*out = make([]BucketReshardStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreBucketIndexStatus.
+func (in *ObjectStoreBucketIndexStatus) DeepCopy() *ObjectStoreBucketIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreBucketIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStoreHostingSpec) DeepCopyInto(out *ObjectStoreHostingSpec) {
 	*out = *in
@@ -4456,6 +5979,124 @@ func (in *ObjectStoreHostingSpec) DeepCopy() *ObjectStoreHostingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreLuaScript) DeepCopyInto(out *ObjectStoreLuaScript) {
+	*out = *in
+	in.ConfigMapKeyRef.DeepCopyInto(&out.ConfigMapKeyRef)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreLuaScript.
+func (in *ObjectStoreLuaScript) DeepCopy() *ObjectStoreLuaScript {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreLuaScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreLuaScriptStatus) DeepCopyInto(out *ObjectStoreLuaScriptStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreLuaScriptStatus.
+func (in *ObjectStoreLuaScriptStatus) DeepCopy() *ObjectStoreLuaScriptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreLuaScriptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreLuaScriptsSpec) DeepCopyInto(out *ObjectStoreLuaScriptsSpec) {
+	*out = *in
+	if in.Scripts != nil {
+		in, out := &in.Scripts, &out.Scripts
+		*out = make([]ObjectStoreLuaScript, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedPackages != nil {
+		in, out := &in.AllowedPackages, &out.AllowedPackages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreLuaScriptsSpec.
+func (in *ObjectStoreLuaScriptsSpec) DeepCopy() *ObjectStoreLuaScriptsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreLuaScriptsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreLuaScriptsStatus) DeepCopyInto(out *ObjectStoreLuaScriptsStatus) {
+	*out = *in
+	if in.Scripts != nil {
+		in, out := &in.Scripts, &out.Scripts
+		*out = make([]ObjectStoreLuaScriptStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedPackages != nil {
+		in, out := &in.AllowedPackages, &out.AllowedPackages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreLuaScriptsStatus.
+func (in *ObjectStoreLuaScriptsStatus) DeepCopy() *ObjectStoreLuaScriptsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreLuaScriptsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreRateLimitsSpec) DeepCopyInto(out *ObjectStoreRateLimitsSpec) {
+	*out = *in
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Anonymous != nil {
+		in, out := &in.Anonymous, &out.Anonymous
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreRateLimitsSpec.
+func (in *ObjectStoreRateLimitsSpec) DeepCopy() *ObjectStoreRateLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreRateLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStoreSecuritySpec) DeepCopyInto(out *ObjectStoreSecuritySpec) {
 	*out = *in
@@ -4515,9 +6156,24 @@ func (in *ObjectStoreSpec) DeepCopyInto(out *ObjectStoreSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Hosting != nil {
-		in, out := &in.Hosting, &out.Hosting
-		*out = new(ObjectStoreHostingSpec)
+	if in.Hosting != nil {
+		in, out := &in.Hosting, &out.Hosting
+		*out = new(ObjectStoreHostingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LuaScripts != nil {
+		in, out := &in.LuaScripts, &out.LuaScripts
+		*out = new(ObjectStoreLuaScriptsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(ObjectStoreRateLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BucketIndex != nil {
+		in, out := &in.BucketIndex, &out.BucketIndex
+		*out = new(ObjectStoreBucketIndexSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -4552,6 +6208,21 @@ func (in *ObjectStoreStatus) DeepCopyInto(out *ObjectStoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LuaScripts != nil {
+		in, out := &in.LuaScripts, &out.LuaScripts
+		*out = new(ObjectStoreLuaScriptsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BucketIndex != nil {
+		in, out := &in.BucketIndex, &out.BucketIndex
+		*out = new(ObjectStoreBucketIndexStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpsLog != nil {
+		in, out := &in.OpsLog, &out.OpsLog
+		*out = new(OpsLogStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4611,6 +6282,11 @@ func (in *ObjectStoreUserSpec) DeepCopyInto(out *ObjectStoreUserSpec) {
 		}
 	}
 	out.AccountRef = in.AccountRef
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(ObjectKeyRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4639,6 +6315,16 @@ func (in *ObjectStoreUserStatus) DeepCopyInto(out *ObjectStoreUserStatus) {
 		*out = make([]SecretReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(UsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(ObjectKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4652,6 +6338,22 @@ func (in *ObjectStoreUserStatus) DeepCopy() *ObjectStoreUserStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSyncStatus) DeepCopyInto(out *ObjectSyncStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSyncStatus.
+func (in *ObjectSyncStatus) DeepCopy() *ObjectSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectUserCapSpec) DeepCopyInto(out *ObjectUserCapSpec) {
 	*out = *in
@@ -4752,6 +6454,12 @@ func (in *ObjectZoneSpec) DeepCopyInto(out *ObjectZoneSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.SyncStatusCheck.DeepCopyInto(&out.SyncStatusCheck)
+	if in.SyncStatusBuckets != nil {
+		in, out := &in.SyncStatusBuckets, &out.SyncStatusBuckets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4765,10 +6473,164 @@ func (in *ObjectZoneSpec) DeepCopy() *ObjectZoneSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneStatus) DeepCopyInto(out *ObjectZoneStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(ObjectZoneSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneStatus.
+func (in *ObjectZoneStatus) DeepCopy() *ObjectZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneSyncStatus) DeepCopyInto(out *ObjectZoneSyncStatus) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(ObjectSyncStatus)
+		**out = **in
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]ObjectDataSyncStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]ObjectBucketSyncStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneSyncStatus.
+func (in *ObjectZoneSyncStatus) DeepCopy() *ObjectZoneSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsLogDeliverySpec) DeepCopyInto(out *OpsLogDeliverySpec) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchTimeout != nil {
+		in, out := &in.BatchTimeout, &out.BatchTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogDeliverySpec.
+func (in *OpsLogDeliverySpec) DeepCopy() *OpsLogDeliverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsLogDeliverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsLogHTTPSinkSpec) DeepCopyInto(out *OpsLogHTTPSinkSpec) {
+	*out = *in
+	out.HTTPEndpointSpec = in.HTTPEndpointSpec
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogHTTPSinkSpec.
+func (in *OpsLogHTTPSinkSpec) DeepCopy() *OpsLogHTTPSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsLogHTTPSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsLogKafkaSinkSpec) DeepCopyInto(out *OpsLogKafkaSinkSpec) {
+	*out = *in
+	in.KafkaEndpointSpec.DeepCopyInto(&out.KafkaEndpointSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogKafkaSinkSpec.
+func (in *OpsLogKafkaSinkSpec) DeepCopy() *OpsLogKafkaSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsLogKafkaSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsLogS3SinkSpec) DeepCopyInto(out *OpsLogS3SinkSpec) {
+	*out = *in
+	in.AccessKeySecretRef.DeepCopyInto(&out.AccessKeySecretRef)
+	in.SecretKeySecretRef.DeepCopyInto(&out.SecretKeySecretRef)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogS3SinkSpec.
+func (in *OpsLogS3SinkSpec) DeepCopy() *OpsLogS3SinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsLogS3SinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsLogSidecar) DeepCopyInto(out *OpsLogSidecar) {
 	*out = *in
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]OpsLogSinkSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Delivery.DeepCopyInto(&out.Delivery)
 	return
 }
 
@@ -4782,6 +6644,74 @@ func (in *OpsLogSidecar) DeepCopy() *OpsLogSidecar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsLogSinkSpec) DeepCopyInto(out *OpsLogSinkSpec) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(OpsLogHTTPSinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(OpsLogKafkaSinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(OpsLogS3SinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogSinkSpec.
+func (in *OpsLogSinkSpec) DeepCopy() *OpsLogSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsLogSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsLogSinkStatus) DeepCopyInto(out *OpsLogSinkStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogSinkStatus.
+func (in *OpsLogSinkStatus) DeepCopy() *OpsLogSinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsLogSinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsLogStatus) DeepCopyInto(out *OpsLogStatus) {
+	*out = *in
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]OpsLogSinkStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogStatus.
+func (in *OpsLogStatus) DeepCopy() *OpsLogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsLogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeerRemoteSpec) DeepCopyInto(out *PeerRemoteSpec) {
 	*out = *in
@@ -5212,6 +7142,42 @@ func (in *RadosNamespaceMirroring) DeepCopy() *RadosNamespaceMirroring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+	if in.MaxReadOps != nil {
+		in, out := &in.MaxReadOps, &out.MaxReadOps
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxWriteOps != nil {
+		in, out := &in.MaxWriteOps, &out.MaxWriteOps
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxReadBytes != nil {
+		in, out := &in.MaxReadBytes, &out.MaxReadBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxWriteBytes != nil {
+		in, out := &in.MaxWriteBytes, &out.MaxWriteBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadAffinitySpec) DeepCopyInto(out *ReadAffinitySpec) {
 	*out = *in
@@ -5737,6 +7703,28 @@ func (in *StorageScopeSpec) DeepCopyInto(out *StorageScopeSpec) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.CrushTopology != nil {
+		in, out := &in.CrushTopology, &out.CrushTopology
+		*out = new(CrushTopologySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSDUpdateStrategy != nil {
+		in, out := &in.OSDUpdateStrategy, &out.OSDUpdateStrategy
+		*out = new(OSDUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CrushWeight != nil {
+		in, out := &in.CrushWeight, &out.CrushWeight
+		*out = new(OSDCrushWeightSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TuningProfiles != nil {
+		in, out := &in.TuningProfiles, &out.TuningProfiles
+		*out = make([]OSDTuningProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -5835,6 +7823,76 @@ func (in *TopicEndpointSpec) DeepCopy() *TopicEndpointSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.CanaryDaemons != nil {
+		in, out := &in.CanaryDaemons, &out.CanaryDaemons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SoakStartTime != nil {
+		in, out := &in.SoakStartTime, &out.SoakStartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStrategySpec) DeepCopyInto(out *UpgradeStrategySpec) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategySpec.
+func (in *UpgradeStrategySpec) DeepCopy() *UpgradeStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageStatus) DeepCopyInto(out *UsageStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageStatus.
+func (in *UsageStatus) DeepCopy() *UsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeClaimTemplate) DeepCopyInto(out *VolumeClaimTemplate) {
 	*out = *in
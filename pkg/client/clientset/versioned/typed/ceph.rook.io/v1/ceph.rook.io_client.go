@@ -38,8 +38,11 @@ type CephV1Interface interface {
 	CephFilesystemsGetter
 	CephFilesystemMirrorsGetter
 	CephFilesystemSubVolumeGroupsGetter
+	CephFilesystemSubVolumesGetter
 	CephNFSesGetter
 	CephNVMeOFGatewaysGetter
+	CephNVMeOFSubsystemsGetter
+	CephNodeMaintenancesGetter
 	CephObjectRealmsGetter
 	CephObjectStoresGetter
 	CephObjectStoreAccountsGetter
@@ -94,6 +97,10 @@ func (c *CephV1Client) CephFilesystemSubVolumeGroups(namespace string) CephFiles
 	return newCephFilesystemSubVolumeGroups(c, namespace)
 }
 
+func (c *CephV1Client) CephFilesystemSubVolumes(namespace string) CephFilesystemSubVolumeInterface {
+	return newCephFilesystemSubVolumes(c, namespace)
+}
+
 func (c *CephV1Client) CephNFSes(namespace string) CephNFSInterface {
 	return newCephNFSes(c, namespace)
 }
@@ -102,6 +109,14 @@ func (c *CephV1Client) CephNVMeOFGateways(namespace string) CephNVMeOFGatewayInt
 	return newCephNVMeOFGateways(c, namespace)
 }
 
+func (c *CephV1Client) CephNVMeOFSubsystems(namespace string) CephNVMeOFSubsystemInterface {
+	return newCephNVMeOFSubsystems(c, namespace)
+}
+
+func (c *CephV1Client) CephNodeMaintenances(namespace string) CephNodeMaintenanceInterface {
+	return newCephNodeMaintenances(c, namespace)
+}
+
 func (c *CephV1Client) CephObjectRealms(namespace string) CephObjectRealmInterface {
 	return newCephObjectRealms(c, namespace)
 }
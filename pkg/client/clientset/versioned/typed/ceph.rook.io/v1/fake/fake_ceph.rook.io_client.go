@@ -68,6 +68,10 @@ func (c *FakeCephV1) CephFilesystemSubVolumeGroups(namespace string) v1.CephFile
 	return newFakeCephFilesystemSubVolumeGroups(c, namespace)
 }
 
+func (c *FakeCephV1) CephFilesystemSubVolumes(namespace string) v1.CephFilesystemSubVolumeInterface {
+	return newFakeCephFilesystemSubVolumes(c, namespace)
+}
+
 func (c *FakeCephV1) CephNFSes(namespace string) v1.CephNFSInterface {
 	return newFakeCephNFSes(c, namespace)
 }
@@ -76,6 +80,14 @@ func (c *FakeCephV1) CephNVMeOFGateways(namespace string) v1.CephNVMeOFGatewayIn
 	return newFakeCephNVMeOFGateways(c, namespace)
 }
 
+func (c *FakeCephV1) CephNVMeOFSubsystems(namespace string) v1.CephNVMeOFSubsystemInterface {
+	return newFakeCephNVMeOFSubsystems(c, namespace)
+}
+
+func (c *FakeCephV1) CephNodeMaintenances(namespace string) v1.CephNodeMaintenanceInterface {
+	return newFakeCephNodeMaintenances(c, namespace)
+}
+
 func (c *FakeCephV1) CephObjectRealms(namespace string) v1.CephObjectRealmInterface {
 	return newFakeCephObjectRealms(c, namespace)
 }
@@ -38,10 +38,16 @@ type CephFilesystemMirrorExpansion interface{}
 
 type CephFilesystemSubVolumeGroupExpansion interface{}
 
+type CephFilesystemSubVolumeExpansion interface{}
+
 type CephNFSExpansion interface{}
 
 type CephNVMeOFGatewayExpansion interface{}
 
+type CephNVMeOFSubsystemExpansion interface{}
+
+type CephNodeMaintenanceExpansion interface{}
+
 type CephObjectRealmExpansion interface{}
 
 type CephObjectStoreExpansion interface{}
@@ -44,10 +44,16 @@ type Interface interface {
 	CephFilesystemMirrors() CephFilesystemMirrorInformer
 	// CephFilesystemSubVolumeGroups returns a CephFilesystemSubVolumeGroupInformer.
 	CephFilesystemSubVolumeGroups() CephFilesystemSubVolumeGroupInformer
+	// CephFilesystemSubVolumes returns a CephFilesystemSubVolumeInformer.
+	CephFilesystemSubVolumes() CephFilesystemSubVolumeInformer
 	// CephNFSes returns a CephNFSInformer.
 	CephNFSes() CephNFSInformer
 	// CephNVMeOFGateways returns a CephNVMeOFGatewayInformer.
 	CephNVMeOFGateways() CephNVMeOFGatewayInformer
+	// CephNVMeOFSubsystems returns a CephNVMeOFSubsystemInformer.
+	CephNVMeOFSubsystems() CephNVMeOFSubsystemInformer
+	// CephNodeMaintenances returns a CephNodeMaintenanceInformer.
+	CephNodeMaintenances() CephNodeMaintenanceInformer
 	// CephObjectRealms returns a CephObjectRealmInformer.
 	CephObjectRealms() CephObjectRealmInformer
 	// CephObjectStores returns a CephObjectStoreInformer.
@@ -125,6 +131,11 @@ func (v *version) CephFilesystemSubVolumeGroups() CephFilesystemSubVolumeGroupIn
 	return &cephFilesystemSubVolumeGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CephFilesystemSubVolumes returns a CephFilesystemSubVolumeInformer.
+func (v *version) CephFilesystemSubVolumes() CephFilesystemSubVolumeInformer {
+	return &cephFilesystemSubVolumeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CephNFSes returns a CephNFSInformer.
 func (v *version) CephNFSes() CephNFSInformer {
 	return &cephNFSInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -135,6 +146,16 @@ func (v *version) CephNVMeOFGateways() CephNVMeOFGatewayInformer {
 	return &cephNVMeOFGatewayInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CephNVMeOFSubsystems returns a CephNVMeOFSubsystemInformer.
+func (v *version) CephNVMeOFSubsystems() CephNVMeOFSubsystemInformer {
+	return &cephNVMeOFSubsystemInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CephNodeMaintenances returns a CephNodeMaintenanceInformer.
+func (v *version) CephNodeMaintenances() CephNodeMaintenanceInformer {
+	return &cephNodeMaintenanceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CephObjectRealms returns a CephObjectRealmInformer.
 func (v *version) CephObjectRealms() CephObjectRealmInformer {
 	return &cephObjectRealmInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
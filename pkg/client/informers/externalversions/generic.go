@@ -73,10 +73,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephFilesystemMirrors().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cephfilesystemsubvolumegroups"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephFilesystemSubVolumeGroups().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("cephfilesystemsubvolumes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephFilesystemSubVolumes().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cephnfses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephNFSes().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cephnvmeofgateways"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephNVMeOFGateways().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("cephnvmeofsubsystems"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephNVMeOFSubsystems().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("cephnodemaintenances"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephNodeMaintenances().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cephobjectrealms"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ceph().V1().CephObjectRealms().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("cephobjectstores"):
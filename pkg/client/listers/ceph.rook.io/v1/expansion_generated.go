@@ -98,6 +98,14 @@ type CephFilesystemSubVolumeGroupListerExpansion interface{}
 // CephFilesystemSubVolumeGroupNamespaceLister.
 type CephFilesystemSubVolumeGroupNamespaceListerExpansion interface{}
 
+// CephFilesystemSubVolumeListerExpansion allows custom methods to be added to
+// CephFilesystemSubVolumeLister.
+type CephFilesystemSubVolumeListerExpansion interface{}
+
+// CephFilesystemSubVolumeNamespaceListerExpansion allows custom methods to be added to
+// CephFilesystemSubVolumeNamespaceLister.
+type CephFilesystemSubVolumeNamespaceListerExpansion interface{}
+
 // CephNFSListerExpansion allows custom methods to be added to
 // CephNFSLister.
 type CephNFSListerExpansion interface{}
@@ -114,6 +122,22 @@ type CephNVMeOFGatewayListerExpansion interface{}
 // CephNVMeOFGatewayNamespaceLister.
 type CephNVMeOFGatewayNamespaceListerExpansion interface{}
 
+// CephNVMeOFSubsystemListerExpansion allows custom methods to be added to
+// CephNVMeOFSubsystemLister.
+type CephNVMeOFSubsystemListerExpansion interface{}
+
+// CephNVMeOFSubsystemNamespaceListerExpansion allows custom methods to be added to
+// CephNVMeOFSubsystemNamespaceLister.
+type CephNVMeOFSubsystemNamespaceListerExpansion interface{}
+
+// CephNodeMaintenanceListerExpansion allows custom methods to be added to
+// CephNodeMaintenanceLister.
+type CephNodeMaintenanceListerExpansion interface{}
+
+// CephNodeMaintenanceNamespaceListerExpansion allows custom methods to be added to
+// CephNodeMaintenanceNamespaceLister.
+type CephNodeMaintenanceNamespaceListerExpansion interface{}
+
 // CephObjectRealmListerExpansion allows custom methods to be added to
 // CephObjectRealmLister.
 type CephObjectRealmListerExpansion interface{}
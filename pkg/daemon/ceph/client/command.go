@@ -17,14 +17,17 @@ limitations under the License.
 package client
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/util/exec"
+	"github.com/rook/rook/pkg/util/tracing"
 )
 
 // RunAllCephCommandsInToolboxPod - when running the e2e tests, all ceph commands need to be run in the toolbox.
@@ -178,6 +181,17 @@ func (c *CephToolCommand) run() ([]byte, error) {
 		return nil, c.clusterInfo.Context.Err()
 	}
 
+	command := tracing.CommandName(c.args)
+	_, span := tracing.StartChildSpan(c.clusterInfo.Context, strings.TrimSpace(c.tool+" "+command),
+		tracing.ToolKey.String(c.tool),
+		tracing.CommandKey.String(command),
+		tracing.NamespaceKey.String(c.clusterInfo.Namespace))
+	output, err := c.execute()
+	tracing.EndSpan(span, err)
+	return output, err
+}
+
+func (c *CephToolCommand) execute() ([]byte, error) {
 	// Initialize the command and args
 	command := c.tool
 	args := c.args
@@ -250,12 +264,17 @@ func (c *CephToolCommand) RunWithTimeout(timeout time.Duration) ([]byte, error)
 }
 
 func ExecuteCephCommandWithRetry(
+	ctx context.Context,
 	cmd func() (string, []byte, error),
 	retries int,
 	waitTime time.Duration,
-) ([]byte, error) {
+) (output []byte, err error) {
+	_, span := tracing.StartChildSpan(ctx, "ExecuteCephCommandWithRetry")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	for i := 0; i < retries; i++ {
 		action, data, err := cmd()
+		span.SetAttributes(tracing.ActionKey.String(action), tracing.AttemptsKey.Int(i+1))
 		if err != nil {
 			logger.Infof("command failed for %s. trying again...", action)
 			time.Sleep(waitTime)
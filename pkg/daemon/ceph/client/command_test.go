@@ -29,7 +29,14 @@ import (
 	"github.com/rook/rook/pkg/operator/test"
 	"github.com/rook/rook/pkg/util/exec"
 	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/rook/rook/pkg/util/tracing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestFinalizeCephCommandArgs(t *testing.T) {
@@ -236,3 +243,43 @@ func TestNewGaneshaRadosGraceCommand(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestCephCommandSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, arg ...string) (string, error) {
+			if arg[0] == "status" {
+				return "", errors.New("induced error")
+			}
+			return "", nil
+		},
+	}
+	clusterInfo := AdminTestClusterInfo("rook-ceph")
+
+	// the commands run outside of a reconcile are not recorded
+	_, err := NewCephCommand(&clusterd.Context{Executor: executor}, clusterInfo, []string{"status"}).RunWithTimeout(time.Second)
+	assert.Error(t, err)
+	assert.Empty(t, exporter.GetSpans())
+
+	reconcileCtx, reconcile := tracing.StartSpan(context.TODO(), "reconcile")
+	reconcileInfo := clusterInfo.WithContext(reconcileCtx)
+	_, err = NewCephCommand(&clusterd.Context{Executor: executor}, reconcileInfo, []string{"osd", "pool", "create", "replicapool", "8"}).RunWithTimeout(time.Second)
+	assert.NoError(t, err)
+	_, err = NewCephCommand(&clusterd.Context{Executor: executor}, reconcileInfo, []string{"status"}).RunWithTimeout(time.Second)
+	assert.Error(t, err)
+	tracing.EndSpan(reconcile, nil)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, reconcile.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, "ceph osd pool create", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, tracing.ToolKey.String("ceph"))
+	assert.Contains(t, spans[0].Attributes, tracing.CommandKey.String("osd pool create"))
+	assert.Contains(t, spans[0].Attributes, tracing.NamespaceKey.String("rook-ceph"))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "ceph status", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
@@ -18,6 +18,7 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rook/rook/pkg/clusterd"
@@ -53,6 +54,20 @@ type CrashList struct {
 	IoErrorOffset    int      `json:"io_error_offset,omitempty"`
 	IoErrorLength    int      `json:"iio_error_length,omitempty"`
 	Backtrace        []string `json:"backtrace,omitempty"`
+	Archived         string   `json:"archived,omitempty"`
+}
+
+// crash timestamps are reported with a "T" or a space between the date and the time depending on the Ceph version
+var crashTimestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999Z"}
+
+// ParseCrashTimestamp parses the timestamp of a crash report
+func ParseCrashTimestamp(timestamp string) (time.Time, bool) {
+	for _, layout := range crashTimestampLayouts {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
 }
 
 // GetCrashList gets the list of Crashes.
@@ -19,7 +19,12 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,6 +37,9 @@ const (
 	CrushRootConfigKey = "crushRoot"
 )
 
+// crushTypeLine matches the declaration of a bucket type in a decompiled CRUSH map
+var crushTypeLine = regexp.MustCompile(`^type\s+\d+\s+(\S+)\s*$`)
+
 // CrushMap is the go representation of a CRUSH map
 type CrushMap struct {
 	Devices []struct {
@@ -150,6 +158,186 @@ func GetCrushHostName(context *clusterd.Context, clusterInfo *ClusterInfo, osdID
 	return result.Location["host"], nil
 }
 
+// typeIDs returns the IDs of the bucket types of the CRUSH map by their name
+func (m *CrushMap) typeIDs() map[string]int {
+	ids := make(map[string]int, len(m.Types))
+	for _, t := range m.Types {
+		ids[t.Name] = t.ID
+	}
+	return ids
+}
+
+// insertCrushTypes returns the IDs of the bucket types of a CRUSH map by their name with the missing
+// types of the given levels inserted, or nil if no type is missing. The levels are ordered from the
+// lowest to the highest above the host. CRUSH links a new bucket to the bucket of the next type of
+// its location in the order of the type IDs, so the levels must be ordered by their IDs between the
+// host and the root. A missing type is inserted right above the level below it, and the IDs of the
+// higher types are shifted up when no ID is free, which keeps the order of the existing types.
+// The existing types are never reordered, so a level order that would require it is rejected.
+func insertCrushTypes(current map[string]int, levels []string) (map[string]int, error) {
+	hostID, ok := current["host"]
+	if !ok {
+		return nil, errors.New("crush map has no host type")
+	}
+	rootID, ok := current["root"]
+	if !ok {
+		rootID = math.MaxInt
+	}
+
+	ordered := slices.Collect(maps.Keys(current))
+	sort.Slice(ordered, func(i, j int) bool { return current[ordered[i]] < current[ordered[j]] })
+
+	inserted := false
+	previous, previousName := hostID, "host"
+	for _, level := range levels {
+		if id, ok := current[level]; ok {
+			if id <= previous || id >= rootID {
+				return nil, errors.Errorf("crush type %q (id %d) is not ordered above %q (id %d) and below the root, and reordering the types of a crush map is not supported", level, id, previousName, previous)
+			}
+			previous, previousName = id, level
+			continue
+		}
+		if slices.Contains(ordered, level) {
+			return nil, errors.Errorf("crush type %q is declared twice in the levels", level)
+		}
+		ordered = slices.Insert(ordered, slices.Index(ordered, previousName)+1, level)
+		previousName = level
+		inserted = true
+	}
+	if !inserted {
+		return nil, nil
+	}
+
+	types := make(map[string]int, len(ordered))
+	id := -1
+	for _, name := range ordered {
+		if currentID, ok := current[name]; ok && currentID > id {
+			id = currentID
+		} else {
+			id++
+		}
+		types[name] = id
+	}
+	return types, nil
+}
+
+// setCrushTypes replaces the bucket types of a decompiled CRUSH map. The buckets and the rules of a
+// decompiled map refer to the types by name, so the map is compiled with the new IDs of the types.
+func setCrushTypes(crushMap string, types map[string]int) (string, error) {
+	lines := strings.Split(crushMap, "\n")
+	first, last := -1, -1
+	for i, line := range lines {
+		match := crushTypeLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if first == -1 {
+			first = i
+		} else if last != i-1 {
+			return "", errors.New("the types of the crush map are not declared together")
+		}
+		last = i
+		if _, ok := types[match[1]]; !ok {
+			return "", errors.Errorf("crush type %q is missing from the new types", match[1])
+		}
+	}
+	if first == -1 {
+		return "", errors.New("failed to find the types of the crush map")
+	}
+
+	names := slices.Collect(maps.Keys(types))
+	sort.Slice(names, func(i, j int) bool { return types[names[i]] < types[names[j]] })
+	typeLines := make([]string, 0, len(names))
+	for _, name := range names {
+		typeLines = append(typeLines, fmt.Sprintf("type %d %s", types[name], name))
+	}
+	lines = slices.Replace(lines, first, last+1, typeLines...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// EnsureCrushTypes adds the bucket types of the levels of a CRUSH hierarchy to the CRUSH map if
+// they are missing. The CRUSH map is decompiled, the missing types are inserted and the higher
+// types renumbered if needed, then the map is recompiled and injected. The levels must be ordered
+// from the lowest to the highest above the host by the IDs of their existing types.
+func EnsureCrushTypes(context *clusterd.Context, clusterInfo *ClusterInfo, levels []string) error {
+	crushMap, err := GetCrushMap(context, clusterInfo)
+	if err != nil {
+		return err
+	}
+	current := crushMap.typeIDs()
+	types, err := insertCrushTypes(current, levels)
+	if err != nil {
+		return err
+	}
+	if types == nil {
+		logger.Debugf("crush map types are already ordered for levels %v", levels)
+		return nil
+	}
+	logger.Infof("updating the crush map types from %v to %v", current, types)
+
+	compiledCRUSHMapFilePath, err := GetCompiledCrushMap(context, clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get crush map")
+	}
+	defer func() {
+		err := os.Remove(compiledCRUSHMapFilePath)
+		if err != nil {
+			logger.Errorf("failed to remove file %q. %v", compiledCRUSHMapFilePath, err)
+		}
+	}()
+
+	err = decompileCRUSHMap(context, compiledCRUSHMapFilePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to decompile crush map")
+	}
+	decompiledCRUSHMapFilePath := buildDecompileCRUSHFileName(compiledCRUSHMapFilePath)
+	defer func() {
+		err := os.Remove(decompiledCRUSHMapFilePath)
+		if err != nil {
+			logger.Errorf("failed to remove file %q. %v", decompiledCRUSHMapFilePath, err)
+		}
+	}()
+
+	decompiled, err := os.ReadFile(decompiledCRUSHMapFilePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read decompiled crush map %q", decompiledCRUSHMapFilePath)
+	}
+	updated, err := setCrushTypes(string(decompiled), types)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(decompiledCRUSHMapFilePath, []byte(updated), 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write decompiled crush map %q", decompiledCRUSHMapFilePath)
+	}
+
+	err = compileCRUSHMap(context, decompiledCRUSHMapFilePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to compile crush map")
+	}
+	defer func() {
+		err := os.Remove(buildCompileCRUSHFileName(decompiledCRUSHMapFilePath))
+		if err != nil {
+			logger.Errorf("failed to remove file %q. %v", buildCompileCRUSHFileName(decompiledCRUSHMapFilePath), err)
+		}
+	}()
+
+	err = injectCRUSHMap(context, clusterInfo, buildCompileCRUSHFileName(decompiledCRUSHMapFilePath))
+	if err != nil {
+		return errors.Wrap(err, "failed to inject crush map")
+	}
+	return nil
+}
+
+// MoveCrushBucket moves a bucket and its children to a location in the CRUSH map
+func MoveCrushBucket(context *clusterd.Context, clusterInfo *ClusterInfo, name string, location []string) error {
+	args := append([]string{"osd", "crush", "move", name}, location...)
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to move crush bucket %q to %v. %s", name, location, string(buf))
+	}
+	return nil
+}
+
 // NormalizeCrushName replaces . with -
 func NormalizeCrushName(name string) string {
 	return strings.ReplaceAll(name, ".", "-")
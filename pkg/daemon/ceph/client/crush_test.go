@@ -17,6 +17,8 @@ package client
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -336,3 +338,144 @@ func TestBuildCompiledDecompileCRUSHFileName(t *testing.T) {
 	assert.Equal(t, "/tmp/06399022.decompiled", buildDecompileCRUSHFileName("/tmp/06399022"))
 	assert.Equal(t, "/tmp/06399022.compiled", buildCompileCRUSHFileName("/tmp/06399022"))
 }
+
+func TestInsertCrushTypes(t *testing.T) {
+	defaultTypes := map[string]int{"osd": 0, "host": 1, "chassis": 2, "rack": 3, "row": 4, "pdu": 5, "pod": 6, "room": 7, "datacenter": 8, "zone": 9, "region": 10, "root": 11}
+
+	// the default types are already ordered for the default levels
+	types, err := insertCrushTypes(defaultTypes, []string{"chassis", "rack", "zone"})
+	assert.NoError(t, err)
+	assert.Nil(t, types)
+
+	// a new type is inserted above the host and the higher types are shifted up
+	types, err = insertCrushTypes(defaultTypes, []string{"power-domain", "rack"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"osd": 0, "host": 1, "power-domain": 2, "chassis": 3, "rack": 4, "row": 5, "pdu": 6, "pod": 7, "room": 8, "datacenter": 9, "zone": 10, "region": 11, "root": 12}, types)
+
+	// new types are inserted in the order of the levels
+	types, err = insertCrushTypes(defaultTypes, []string{"rack", "power-domain", "cage", "zone"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"osd": 0, "host": 1, "chassis": 2, "rack": 3, "power-domain": 4, "cage": 5, "row": 6, "pdu": 7, "pod": 8, "room": 9, "datacenter": 10, "zone": 11, "region": 12, "root": 13}, types)
+
+	// existing types are not reordered
+	_, err = insertCrushTypes(defaultTypes, []string{"zone", "rack"})
+	assert.Error(t, err)
+	_, err = insertCrushTypes(defaultTypes, []string{"root"})
+	assert.Error(t, err)
+	_, err = insertCrushTypes(defaultTypes, []string{"cage", "cage"})
+	assert.Error(t, err)
+
+	// the free ids are used before the higher types are shifted
+	sparseTypes := map[string]int{"osd": 0, "host": 1, "rack": 3, "zone": 9, "root": 11}
+	types, err = insertCrushTypes(sparseTypes, []string{"power-domain", "chassis", "rack", "room", "zone", "region"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"osd": 0, "host": 1, "power-domain": 2, "chassis": 3, "rack": 4, "room": 5, "zone": 9, "region": 10, "root": 11}, types)
+
+	// without a root the new types are added above the highest level
+	types, err = insertCrushTypes(map[string]int{"osd": 0, "host": 1}, []string{"rack"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"osd": 0, "host": 1, "rack": 2}, types)
+
+	_, err = insertCrushTypes(map[string]int{"osd": 0, "root": 11}, []string{"rack"})
+	assert.Error(t, err)
+}
+
+func TestSetCrushTypes(t *testing.T) {
+	crushMap := `# begin crush map
+tunable choose_total_tries 50
+
+# types
+type 0 osd
+type 1 host
+type 3 rack
+type 11 root
+
+# buckets
+host node-a {
+	id -3		# do not change unnecessarily
+	alg straw2
+	item osd.0 weight 1.000
+}
+
+# rules
+rule replicated_rule {
+	id 0
+	type replicated
+	step take default
+	step chooseleaf firstn 0 type host
+	step emit
+}
+`
+	updated, err := setCrushTypes(crushMap, map[string]int{"osd": 0, "host": 1, "power-domain": 2, "rack": 4, "region": 10, "root": 12})
+	assert.NoError(t, err)
+	assert.Contains(t, updated, "# types\ntype 0 osd\ntype 1 host\ntype 2 power-domain\ntype 4 rack\ntype 10 region\ntype 12 root\n\n# buckets")
+	assert.Contains(t, updated, "\ttype replicated\n")
+
+	// an existing type cannot be removed
+	_, err = setCrushTypes(crushMap, map[string]int{"osd": 0, "host": 1, "root": 2})
+	assert.Error(t, err)
+
+	_, err = setCrushTypes("# begin crush map\n", map[string]int{"rack": 2})
+	assert.Error(t, err)
+}
+
+func TestEnsureCrushTypes(t *testing.T) {
+	decompiled := `# begin crush map
+
+# types
+type 0 osd
+type 1 host
+type 2 chassis
+type 3 rack
+type 4 row
+type 5 pdu
+type 6 pod
+type 7 room
+type 8 datacenter
+type 9 region
+type 10 root
+
+# rules
+rule replicated_rule {
+	id 0
+	type replicated
+	step take default
+	step chooseleaf firstn 0 type host
+	step emit
+}
+`
+	var compiled, injected string
+	executor := &exectest.MockExecutor{}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("Command: %s %v", command, args)
+		switch {
+		case command == "crushtool" && args[0] == "--decompile":
+			return "", os.WriteFile(args[3], []byte(decompiled), 0o600)
+		case command == "crushtool" && args[0] == "--compile":
+			data, err := os.ReadFile(args[1])
+			compiled = string(data)
+			return "", err
+		case args[0] == "osd" && args[1] == "crush" && args[2] == "dump":
+			return testCrushMap, nil
+		case args[0] == "osd" && args[1] == "getcrushmap":
+			return "", nil
+		case args[0] == "osd" && args[1] == "setcrushmap":
+			injected = args[3]
+			return "", nil
+		}
+		return "", errors.Errorf("unexpected command %q %v", command, args)
+	}
+	context := &clusterd.Context{Executor: executor}
+
+	// the default levels exist in the crush map
+	err := EnsureCrushTypes(context, AdminTestClusterInfo("mycluster"), []string{"chassis", "rack"})
+	assert.NoError(t, err)
+	assert.Empty(t, compiled)
+
+	// a new level is inserted between the host and the chassis
+	err = EnsureCrushTypes(context, AdminTestClusterInfo("mycluster"), []string{"power-domain", "rack"})
+	assert.NoError(t, err)
+	assert.Contains(t, compiled, "# types\ntype 0 osd\ntype 1 host\ntype 2 power-domain\ntype 3 chassis\ntype 4 rack\ntype 5 row\ntype 6 pdu\ntype 7 pod\ntype 8 room\ntype 9 datacenter\ntype 10 region\ntype 11 root\n\n# rules")
+	assert.Contains(t, compiled, "step chooseleaf firstn 0 type host\n")
+	assert.True(t, strings.HasSuffix(injected, ".compiled"))
+}
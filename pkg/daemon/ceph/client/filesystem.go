@@ -401,6 +401,72 @@ func GetMDSDump(context *clusterd.Context, clusterInfo *ClusterInfo) (*MDSDump,
 	return &dump, nil
 }
 
+// FilesystemStatus is a representation of the json structure returned by 'ceph fs status <fs>'
+type FilesystemStatus struct {
+	MDSMap []MDSStatus `json:"mdsmap"`
+}
+
+// MDSStatus is the status of an mds daemon as reported by 'ceph fs status <fs>'
+type MDSStatus struct {
+	Name  string `json:"name"`
+	Rank  int    `json:"rank"`
+	State string `json:"state"`
+	// Rate is the client request rate in requests per second
+	Rate float64 `json:"rate"`
+}
+
+// GetFilesystemStatus returns the status of the mds daemons of a filesystem, including the client
+// request rate of each active rank computed by the mgr from the mds perf counters.
+var GetFilesystemStatus = getFilesystemStatus
+
+func getFilesystemStatus(context *clusterd.Context, clusterInfo *ClusterInfo, fsName string) (*FilesystemStatus, error) {
+	args := []string{"fs", "status", fsName}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get status of filesystem %q", fsName)
+	}
+	var status FilesystemStatus
+	if err := json.Unmarshal(buf, &status); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal status of filesystem %q. %s", fsName, buf)
+	}
+	return &status, nil
+}
+
+type mdsCacheStatus struct {
+	Pool struct {
+		Bytes uint64 `json:"bytes"`
+	} `json:"pool"`
+}
+
+// GetMDSCacheUsage returns the memory used by the cache of an mds daemon and its mds_cache_memory_limit, in bytes
+var GetMDSCacheUsage = getMDSCacheUsage
+
+func getMDSCacheUsage(context *clusterd.Context, clusterInfo *ClusterInfo, mdsName string) (uint64, uint64, error) {
+	daemon := fmt.Sprintf("mds.%s", mdsName)
+	buf, err := NewCephCommand(context, clusterInfo, []string{"tell", daemon, "cache", "status"}).Run()
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to get cache status of %q", daemon)
+	}
+	var cache mdsCacheStatus
+	if err := json.Unmarshal(buf, &cache); err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to unmarshal cache status of %q. %s", daemon, buf)
+	}
+
+	buf, err = NewCephCommand(context, clusterInfo, []string{"tell", daemon, "config", "get", "mds_cache_memory_limit"}).Run()
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to get mds_cache_memory_limit of %q", daemon)
+	}
+	var config map[string]string
+	if err := json.Unmarshal(buf, &config); err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to unmarshal mds_cache_memory_limit of %q. %s", daemon, buf)
+	}
+	limit, err := strconv.ParseUint(config["mds_cache_memory_limit"], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to parse mds_cache_memory_limit of %q", daemon)
+	}
+	return cache.Pool.Bytes, limit, nil
+}
+
 // SubvolumeGroup is a representation of a Ceph filesystem subvolume group.
 type SubvolumeGroup struct {
 	Name string `json:"name"`
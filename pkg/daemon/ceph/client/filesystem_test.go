@@ -519,6 +519,55 @@ func TestGetMDSDump(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetFilesystemStatus(t *testing.T) {
+	executor := &exectest.MockExecutor{}
+	context := &clusterd.Context{Executor: executor}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("Command: %s %v", command, args)
+		if args[0] == "fs" && args[1] == "status" && args[2] == "myfs" {
+			return `{"clients":[{"clients":2,"fs":"myfs"}],"mds_version":[],"mdsmap":[
+				{"caps":12,"dirs":10,"dns":20,"inos":20,"name":"myfs-a","rank":0,"rate":1250.5,"state":"active"},
+				{"caps":0,"dirs":10,"dns":20,"inos":20,"name":"myfs-b","rank":0,"events":3,"state":"standby-replay"}],"pools":[]}`, nil
+		}
+		return "", errors.Errorf("unexpected ceph command %q", args)
+	}
+
+	status, err := GetFilesystemStatus(context, AdminTestClusterInfo("mycluster"), "myfs")
+	assert.NoError(t, err)
+	assert.Equal(t, []MDSStatus{
+		{Name: "myfs-a", Rank: 0, State: "active", Rate: 1250.5},
+		{Name: "myfs-b", Rank: 0, State: "standby-replay"},
+	}, status.MDSMap)
+
+	_, err = GetFilesystemStatus(context, AdminTestClusterInfo("mycluster"), "otherfs")
+	assert.Error(t, err)
+}
+
+func TestGetMDSCacheUsage(t *testing.T) {
+	executor := &exectest.MockExecutor{}
+	context := &clusterd.Context{Executor: executor}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		logger.Infof("Command: %s %v", command, args)
+		if args[0] == "tell" && args[1] == "mds.myfs-a" {
+			if args[2] == "cache" && args[3] == "status" {
+				return `{"pool":{"items":1234,"bytes":3221225472}}`, nil
+			}
+			if args[2] == "config" && args[3] == "get" && args[4] == "mds_cache_memory_limit" {
+				return `{"mds_cache_memory_limit":"4294967296"}`, nil
+			}
+		}
+		return "", errors.Errorf("unexpected ceph command %q", args)
+	}
+
+	used, limit, err := GetMDSCacheUsage(context, AdminTestClusterInfo("mycluster"), "myfs-a")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3221225472), used)
+	assert.Equal(t, uint64(4294967296), limit)
+
+	_, _, err = GetMDSCacheUsage(context, AdminTestClusterInfo("mycluster"), "myfs-b")
+	assert.Error(t, err)
+}
+
 func TestFSHasStandby(t *testing.T) {
 	// Not found in an empty list
 	fsName := "foo"
@@ -104,6 +104,15 @@ func (c *ClusterInfo) SetName(name string) {
 	c.name = name
 }
 
+// WithContext returns a copy of the cluster info that runs the Ceph commands with the given context,
+// e.g. to record them as part of a reconcile without affecting the background health checks that
+// share the cluster info
+func (c *ClusterInfo) WithContext(ctx context.Context) *ClusterInfo {
+	clusterInfo := *c
+	clusterInfo.Context = ctx
+	return &clusterInfo
+}
+
 func (c *ClusterInfo) NamespacedName() types.NamespacedName {
 	if c.name == "" {
 		panic("name is not set on the clusterInfo")
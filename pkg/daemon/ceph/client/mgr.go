@@ -68,6 +68,25 @@ func CephMgrStat(context *clusterd.Context, clusterInfo *ClusterInfo) (*MgrStat,
 	return &mgrStat, nil
 }
 
+// CephMgrServices returns the URLs of the services of the mgr modules, e.g. the dashboard or prometheus
+func CephMgrServices(context *clusterd.Context, clusterInfo *ClusterInfo) (map[string]string, error) {
+	args := []string{"mgr", "services"}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		if len(buf) > 0 {
+			return nil, errors.Wrapf(err, "failed to get mgr services. %s", string(buf))
+		}
+		return nil, errors.Wrap(err, "failed to get mgr services")
+	}
+
+	services := map[string]string{}
+	if err := json.Unmarshal([]byte(buf), &services); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal mgr services")
+	}
+
+	return services, nil
+}
+
 // MgrEnableModule enables a mgr module
 func MgrEnableModule(context *clusterd.Context, clusterInfo *ClusterInfo, name string, force bool) error {
 	retryCount := 5
@@ -21,6 +21,7 @@ import (
 	"math"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/rook/rook/pkg/clusterd"
@@ -119,6 +120,28 @@ func UnsetFlagOnCrushUnit(context *clusterd.Context, clusterInfo *ClusterInfo, c
 	return nil
 }
 
+// IsFlagSet checks if a cluster-wide OSD flag is set
+func (dump *OSDDump) IsFlagSet(checkFlag string) bool {
+	return slices.Contains(strings.Split(dump.Flags, ","), checkFlag)
+}
+
+// UpdateFlag checks if the cluster-wide flag is in the desired state and sets/unsets if it isn't.
+// It returns true if the value was changed
+func (dump *OSDDump) UpdateFlag(context *clusterd.Context, clusterInfo *ClusterInfo, set bool, flag string) (bool, error) {
+	if dump.IsFlagSet(flag) == set {
+		return false, nil
+	}
+	action := "unset"
+	if set {
+		action = "set"
+	}
+	cmd := NewCephCommand(context, clusterInfo, []string{"osd", action, flag})
+	if _, err := cmd.Run(); err != nil {
+		return true, errors.Wrapf(err, "failed to %s flag %s", action, flag)
+	}
+	return true, nil
+}
+
 type SafeToDestroyStatus struct {
 	SafeToDestroy []int `json:"safe_to_destroy"`
 }
@@ -130,6 +153,7 @@ type OsdTree struct {
 		Name            string   `json:"name"`
 		Type            string   `json:"type"`
 		TypeID          int      `json:"type_id"`
+		DeviceClass     string   `json:"device_class,omitempty"`
 		Children        []int    `json:"children,omitempty"`
 		PoolWeights     struct{} `json:"pool_weights,omitempty"`
 		CrushWeight     float64  `json:"crush_weight,omitempty"`
@@ -253,6 +277,27 @@ func ResizeOsdCrushWeight(actualOSD OSDNodeUsage, ctx *clusterd.Context, cluster
 	return true, nil
 }
 
+// OsdFullCrushWeight returns the CRUSH weight of an OSD matching the size of its device, in TiB
+func OsdFullCrushWeight(actualOSD OSDNodeUsage) (float64, error) {
+	// actualOSD.KB is in KiB units
+	weight, err := convertKibibytesToTebibytes(actualOSD.KB.String())
+	if err != nil {
+		return float64(0), errors.Wrapf(err, "failed to convert KiB to TiB for osd.%d crush weight %q", actualOSD.ID, actualOSD.KB.String())
+	}
+	return weight, nil
+}
+
+// SetOsdCrushWeight sets the CRUSH weight of an OSD
+func SetOsdCrushWeight(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int, weight float64) error {
+	logger.Infof("updating osd.%d crush weight to %f for cluster in namespace %q", osdID, weight, clusterInfo.Namespace)
+	args := []string{"osd", "crush", "reweight", fmt.Sprintf("osd.%d", osdID), fmt.Sprintf("%f", weight)}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set the crush weight of osd.%d to %f. %s", osdID, weight, string(buf))
+	}
+	return nil
+}
+
 func SetDeviceClass(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int, deviceClass string) error {
 	// First remove the existing device class
 	args := []string{"osd", "crush", "rm-device-class", fmt.Sprintf("osd.%d", osdID)}
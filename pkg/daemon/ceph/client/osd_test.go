@@ -171,6 +171,37 @@ func TestOSDLifecycleHelpers(t *testing.T) {
 	assert.Error(t, OSDDestroy(ctx, info, 5))
 }
 
+func TestUpdateFlag(t *testing.T) {
+	var gotArgs []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			gotArgs = args
+			return "", nil
+		},
+	}
+	ctx := &clusterd.Context{Executor: executor}
+	info := AdminTestClusterInfo("mycluster")
+	dump := &OSDDump{Flags: "sortbitwise,norebalance,recovery_deletes"}
+
+	assert.True(t, dump.IsFlagSet("norebalance"))
+	assert.False(t, dump.IsFlagSet("noout"))
+
+	changed, err := dump.UpdateFlag(ctx, info, true, "norebalance")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Nil(t, gotArgs)
+
+	changed, err = dump.UpdateFlag(ctx, info, true, "noout")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"osd", "set", "noout"}, gotArgs[:3])
+
+	changed, err = dump.UpdateFlag(ctx, info, false, "norebalance")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"osd", "unset", "norebalance"}, gotArgs[:3])
+}
+
 func TestOsdListNum(t *testing.T) {
 	executor := &exectest.MockExecutor{}
 	emptyOsdListNumResult := false
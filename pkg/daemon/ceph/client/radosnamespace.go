@@ -140,3 +140,44 @@ func ListRadosNamespacesInPool(context *clusterd.Context, clusterInfo *ClusterIn
 	}
 	return namespacesList, nil
 }
+
+// RadosNamespaceUsage is the capacity used by the RBD images in a rados namespace
+type RadosNamespaceUsage struct {
+	Images           int64
+	ProvisionedBytes int64
+	UsedBytes        int64
+}
+
+// GetRadosNamespaceUsage returns the capacity used by the RBD images in a rados namespace.
+// The used size of images without the fast-diff feature is computed by scanning their objects.
+func GetRadosNamespaceUsage(context *clusterd.Context, clusterInfo *ClusterInfo, poolName, namespaceName string) (*RadosNamespaceUsage, error) {
+	// sample output: {"images":[{"name":"img1","id":"1234","provisioned_size":1073741824,"used_size":4194304}],"total_provisioned_size":1073741824,"total_used_size":4194304}
+	args := []string{"du", "--pool", poolName, "--namespace", namespaceName}
+	cmd := NewRBDCommand(context, clusterInfo, args)
+	cmd.JsonOutput = true
+	output, err := cmd.Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get disk usage of rados namespace %s/%s. %s", poolName, namespaceName, string(output))
+	}
+
+	var du struct {
+		Images []struct {
+			Name     string `json:"name"`
+			Snapshot string `json:"snapshot"`
+		} `json:"images"`
+		TotalProvisionedSize int64 `json:"total_provisioned_size"`
+		TotalUsedSize        int64 `json:"total_used_size"`
+	}
+	if err := json.Unmarshal(output, &du); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal rbd du response")
+	}
+
+	usage := &RadosNamespaceUsage{ProvisionedBytes: du.TotalProvisionedSize, UsedBytes: du.TotalUsedSize}
+	for _, image := range du.Images {
+		// snapshots are listed as separate entries
+		if image.Snapshot == "" {
+			usage.Images++
+		}
+	}
+	return usage, nil
+}
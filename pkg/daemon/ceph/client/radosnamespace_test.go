@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const rbdDuOutput = `{"images":[{"name":"img1","id":"1234","snapshot":"snap1","snapshot_id":4,"provisioned_size":1073741824,"used_size":4194304},
+{"name":"img1","id":"1234","provisioned_size":1073741824,"used_size":8388608},
+{"name":"img2","id":"5678","provisioned_size":2147483648,"used_size":0}],
+"total_provisioned_size":3221225472,"total_used_size":12582912}`
+
+func TestGetRadosNamespaceUsage(t *testing.T) {
+	var duErr error
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if command == "rbd" && args[0] == "du" {
+				assert.Equal(t, []string{"du", "--pool", "replicapool", "--namespace", "tenant-a"}, args[:5])
+				return rbdDuOutput, duErr
+			}
+			return "", errors.Errorf("unexpected command %q %v", command, args)
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+
+	usage, err := GetRadosNamespaceUsage(context, AdminTestClusterInfo("mycluster"), "replicapool", "tenant-a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), usage.Images)
+	assert.Equal(t, int64(3221225472), usage.ProvisionedBytes)
+	assert.Equal(t, int64(12582912), usage.UsedBytes)
+
+	duErr = errors.New("failed to connect")
+	_, err = GetRadosNamespaceUsage(context, AdminTestClusterInfo("mycluster"), "replicapool", "tenant-a")
+	assert.ErrorContains(t, err, "failed to get disk usage")
+}
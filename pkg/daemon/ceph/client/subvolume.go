@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+)
+
+// SubVolumeInfo is a representation of the json structure returned by 'ceph fs subvolume info'
+type SubVolumeInfo struct {
+	Path string `json:"path"`
+	// BytesQuota is 0 if the subvolume has no quota
+	BytesQuota    int64  `json:"bytes_quota"`
+	BytesUsed     int64  `json:"bytes_used"`
+	DataPool      string `json:"data_pool"`
+	PoolNamespace string `json:"pool_namespace"`
+	Earmark       string `json:"earmark"`
+	// Mode is the mode of the subvolume directory, including its file type bits
+	Mode uint32 `json:"mode"`
+	UID  int64  `json:"uid"`
+	GID  int64  `json:"gid"`
+}
+
+// UnmarshalJSON handles the bytes_quota field, which Ceph reports as "infinite" when no quota is set
+func (s *SubVolumeInfo) UnmarshalJSON(data []byte) error {
+	type subVolumeInfoAlias SubVolumeInfo
+	aux := &struct {
+		BytesQuota json.RawMessage `json:"bytes_quota"`
+		*subVolumeInfoAlias
+	}{
+		subVolumeInfoAlias: (*subVolumeInfoAlias)(s),
+	}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	var err error
+	s.BytesQuota, err = parseBytesQuota(aux.BytesQuota)
+	return err
+}
+
+func subVolumeArgs(args []string, groupName string) []string {
+	if groupName != "" {
+		args = append(args, "--group_name", groupName)
+	}
+	return args
+}
+
+// GetCephFSSubVolumeInfo gets the info of a CephFS subvolume. If the subvolume group name is empty,
+// the default subvolume group is used.
+func GetCephFSSubVolumeInfo(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName, subVolName string) (*SubVolumeInfo, error) {
+	args := subVolumeArgs([]string{"fs", "subvolume", "info", volName, subVolName}, groupName)
+	output, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		// Intentionally don't wrap the error so the caller can inspect the return code
+		return nil, err
+	}
+
+	info := SubVolumeInfo{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal info of subvolume %q", subVolName)
+	}
+	return &info, nil
+}
+
+// CreateCephFSSubVolume creates a CephFS subvolume
+func CreateCephFSSubVolume(context *clusterd.Context, clusterInfo *ClusterInfo, volName, subVolName string, spec *cephv1.CephFilesystemSubVolumeSpec) error {
+	logger.Infof("creating cephfs %q subvolume %q", volName, subVolName)
+	// [<size:int>] [--group_name <group_name>] [--pool_layout <data_pool_name>] [--uid <uid>] [--gid <gid>] [--mode <octal_mode>] [--namespace-isolated]
+	args := subVolumeArgs([]string{"fs", "subvolume", "create", volName, subVolName}, spec.SubVolumeGroupName)
+	if spec.Size != nil {
+		args = append(args, fmt.Sprintf("--size=%d", spec.Size.Value()))
+	}
+	if spec.DataPoolName != "" {
+		args = append(args, fmt.Sprintf("--pool_layout=%s", spec.DataPoolName))
+	}
+	if spec.UID != nil {
+		args = append(args, fmt.Sprintf("--uid=%d", *spec.UID))
+	}
+	if spec.GID != nil {
+		args = append(args, fmt.Sprintf("--gid=%d", *spec.GID))
+	}
+	if spec.Mode != "" {
+		args = append(args, fmt.Sprintf("--mode=%s", spec.Mode))
+	}
+	if spec.NamespaceIsolated {
+		args = append(args, "--namespace-isolated")
+	}
+
+	cmd := NewCephCommand(context, clusterInfo, args)
+	cmd.JsonOutput = false
+	output, err := cmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create subvolume %q in filesystem %q. %s", subVolName, volName, output)
+	}
+
+	logger.Infof("successfully created subvolume %q in filesystem %q", subVolName, volName)
+	return nil
+}
+
+// SetCephFSSubVolumeAttrs sets the mode and owner of an existing CephFS subvolume. Only the attributes
+// that are set are changed.
+func SetCephFSSubVolumeAttrs(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName, subVolName, mode string, uid, gid *int64) error {
+	// creating an existing subvolume only updates the attributes passed
+	args := subVolumeArgs([]string{"fs", "subvolume", "create", volName, subVolName}, groupName)
+	if uid != nil {
+		args = append(args, fmt.Sprintf("--uid=%d", *uid))
+	}
+	if gid != nil {
+		args = append(args, fmt.Sprintf("--gid=%d", *gid))
+	}
+	if mode != "" {
+		args = append(args, fmt.Sprintf("--mode=%s", mode))
+	}
+	logger.Infof("setting the attributes of cephfs %q subvolume %q", volName, subVolName)
+	cmd := NewCephCommand(context, clusterInfo, args)
+	cmd.JsonOutput = false
+	output, err := cmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set the attributes of subvolume %q in filesystem %q. %s", subVolName, volName, output)
+	}
+	return nil
+}
+
+// ResizeCephFSSubVolume sets the quota of a CephFS subvolume, or removes it if the size is 0.
+// The subvolume is not shrunk below its used size.
+func ResizeCephFSSubVolume(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName, subVolName string, size int64) error {
+	newSize := "inf"
+	if size > 0 {
+		newSize = fmt.Sprintf("%d", size)
+	}
+	logger.Infof("resizing cephfs %q subvolume %q to %s", volName, subVolName, newSize)
+	args := subVolumeArgs([]string{"fs", "subvolume", "resize", volName, subVolName, newSize, "--no_shrink"}, groupName)
+	cmd := NewCephCommand(context, clusterInfo, args)
+	cmd.JsonOutput = false
+	output, err := cmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to resize subvolume %q in filesystem %q. %s", subVolName, volName, output)
+	}
+	return nil
+}
+
+// SetCephFSSubVolumeEarmark sets the earmark of a CephFS subvolume, or removes it if the earmark is empty
+func SetCephFSSubVolumeEarmark(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName, subVolName, earmark string) error {
+	args := subVolumeArgs([]string{"fs", "subvolume", "earmark", "rm", volName, subVolName}, groupName)
+	if earmark != "" {
+		args = subVolumeArgs([]string{"fs", "subvolume", "earmark", "set", volName, subVolName}, groupName)
+		args = append(args, "--earmark", earmark)
+	}
+	cmd := NewCephCommand(context, clusterInfo, args)
+	cmd.JsonOutput = false
+	output, err := cmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set earmark of subvolume %q in filesystem %q to %q. %s", subVolName, volName, earmark, output)
+	}
+	return nil
+}
+
+// DeleteCephFSSubVolume deletes a CephFS subvolume and its data
+func DeleteCephFSSubVolume(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName, subVolName string) error {
+	logger.Infof("deleting cephfs %q subvolume %q", volName, subVolName)
+	args := subVolumeArgs([]string{"fs", "subvolume", "rm", volName, subVolName}, groupName)
+	cmd := NewCephCommand(context, clusterInfo, args)
+	cmd.JsonOutput = false
+	output, err := cmd.Run()
+	if err != nil {
+		logger.Debugf("failed to delete subvolume %q. %s. %v", subVolName, output, err)
+		// Intentionally don't wrap the error so the caller can inspect the return code
+		return err
+	}
+
+	logger.Infof("successfully deleted cephfs %q subvolume %q", volName, subVolName)
+	return nil
+}
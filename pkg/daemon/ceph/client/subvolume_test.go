@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetCephFSSubVolumeInfo(t *testing.T) {
+	output := `{"path":"/volumes/csi/vol1/abc","bytes_quota":"infinite","bytes_used":100,"data_pool":"myfs-replicated","pool_namespace":"","earmark":"nfs","mode":16877,"uid":1000,"gid":2000}`
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("Command: %s %v", command, args)
+			if args[0] == "fs" && args[1] == "subvolume" && args[2] == "info" {
+				assert.Equal(t, []string{"myfs", "vol1", "--group_name", "csi"}, args[3:7])
+				return output, nil
+			}
+			return "", errors.New("unknown command")
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+
+	info, err := GetCephFSSubVolumeInfo(context, AdminTestClusterInfo("mycluster"), "myfs", "csi", "vol1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/volumes/csi/vol1/abc", info.Path)
+	assert.Equal(t, int64(0), info.BytesQuota)
+	assert.Equal(t, int64(100), info.BytesUsed)
+	assert.Equal(t, "myfs-replicated", info.DataPool)
+	assert.Equal(t, "nfs", info.Earmark)
+	assert.Equal(t, uint32(0o40755), info.Mode)
+	assert.Equal(t, int64(1000), info.UID)
+	assert.Equal(t, int64(2000), info.GID)
+
+	output = `{"path":"/volumes/_nogroup/vol1/abc","bytes_quota":1073741824,"data_pool":"myfs-replicated","pool_namespace":"fsvolumens_vol1"}`
+	info, err = GetCephFSSubVolumeInfo(context, AdminTestClusterInfo("mycluster"), "myfs", "csi", "vol1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1073741824), info.BytesQuota)
+	assert.Equal(t, "fsvolumens_vol1", info.PoolNamespace)
+}
+
+func TestCreateCephFSSubVolume(t *testing.T) {
+	var createArgs []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("Command: %s %v", command, args)
+			if args[0] == "fs" && args[1] == "subvolume" && args[2] == "create" {
+				createArgs = args
+				return "", nil
+			}
+			return "", errors.New("unknown command")
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+
+	t.Run("defaults", func(t *testing.T) {
+		err := CreateCephFSSubVolume(context, AdminTestClusterInfo("mycluster"), "myfs", "vol1", &cephv1.CephFilesystemSubVolumeSpec{})
+		assert.NoError(t, err)
+		assert.NotContains(t, createArgs, "--group_name")
+		assert.NotContains(t, createArgs, "--namespace-isolated")
+	})
+
+	t.Run("all settings", func(t *testing.T) {
+		spec := &cephv1.CephFilesystemSubVolumeSpec{
+			SubVolumeGroupName: "group1",
+			Size:               ptr.To(resource.MustParse("1Gi")),
+			DataPoolName:       "myfs-ec",
+			Mode:               "750",
+			UID:                ptr.To(int64(1000)),
+			GID:                ptr.To(int64(2000)),
+			NamespaceIsolated:  true,
+		}
+		err := CreateCephFSSubVolume(context, AdminTestClusterInfo("mycluster"), "myfs", "vol1", spec)
+		assert.NoError(t, err)
+		assert.Subset(t, createArgs, []string{"--group_name", "group1", "--size=1073741824", "--pool_layout=myfs-ec", "--mode=750", "--uid=1000", "--gid=2000", "--namespace-isolated"})
+	})
+}
+
+func TestSetCephFSSubVolumeAttrs(t *testing.T) {
+	var createArgs []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "fs" && args[1] == "subvolume" && args[2] == "create" {
+				createArgs = args
+				return "", nil
+			}
+			return "", errors.New("unknown command")
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+
+	err := SetCephFSSubVolumeAttrs(context, AdminTestClusterInfo("mycluster"), "myfs", "group1", "vol1", "750", nil, ptr.To(int64(2000)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fs", "subvolume", "create", "myfs", "vol1", "--group_name", "group1", "--gid=2000", "--mode=750"}, createArgs[:9])
+	for _, arg := range createArgs {
+		assert.NotContains(t, arg, "--size")
+		assert.NotContains(t, arg, "--uid")
+	}
+}
+
+func TestResizeCephFSSubVolume(t *testing.T) {
+	var resizeArgs []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("Command: %s %v", command, args)
+			if args[0] == "fs" && args[1] == "subvolume" && args[2] == "resize" {
+				resizeArgs = args
+				return "", nil
+			}
+			return "", errors.New("unknown command")
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+
+	err := ResizeCephFSSubVolume(context, AdminTestClusterInfo("mycluster"), "myfs", "", "vol1", 1024)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"myfs", "vol1", "1024", "--no_shrink"}, resizeArgs[3:7])
+
+	err = ResizeCephFSSubVolume(context, AdminTestClusterInfo("mycluster"), "myfs", "", "vol1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "inf", resizeArgs[5])
+}
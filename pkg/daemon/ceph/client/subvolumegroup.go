@@ -111,21 +111,28 @@ func (s *subvolumeGroupInfo) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if len(aux.BytesQuota) == 0 || string(aux.BytesQuota) == "null" {
-		s.BytesQuota = 0
-		return nil
+	var err error
+	s.BytesQuota, err = parseBytesQuota(aux.BytesQuota)
+	return err
+}
+
+// parseBytesQuota parses a bytes_quota field of a subvolume group or subvolume, mapping "infinite" to 0
+func parseBytesQuota(raw json.RawMessage) (int64, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
 	}
 
 	var quotaStr string
-	if err := json.Unmarshal(aux.BytesQuota, &quotaStr); err == nil {
+	if err := json.Unmarshal(raw, &quotaStr); err == nil {
 		if quotaStr == "infinite" {
-			s.BytesQuota = 0
-			return nil
+			return 0, nil
 		}
-		return errors.Errorf("unexpected bytes_quota value %q", quotaStr)
+		return 0, errors.Errorf("unexpected bytes_quota value %q", quotaStr)
 	}
 
-	return json.Unmarshal(aux.BytesQuota, &s.BytesQuota)
+	var quota int64
+	err := json.Unmarshal(raw, &quota)
+	return quota, err
 }
 
 // getCephFSSubVolumeGroupInfo gets subvolumegroup info of the group name.
@@ -147,6 +154,16 @@ func getCephFSSubVolumeGroupInfo(context *clusterd.Context, clusterInfo *Cluster
 	return &svgInfo, nil
 }
 
+// GetCephFSSubVolumeGroupUsage returns the bytes used by the subvolumes of a group and its quota,
+// which is 0 if the group has no quota
+func GetCephFSSubVolumeGroupUsage(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName string) (int64, int64, error) {
+	svgInfo, err := getCephFSSubVolumeGroupInfo(context, clusterInfo, volName, groupName)
+	if err != nil {
+		return 0, 0, err
+	}
+	return svgInfo.BytesUsed, svgInfo.BytesQuota, nil
+}
+
 // DeleteCephFSSubVolumeGroup deletes a CephFS subvolume group.
 func DeleteCephFSSubVolumeGroup(context *clusterd.Context, clusterInfo *ClusterInfo, volName, groupName string) error {
 	logger.Infof("deleting cephfs %q subvolume group %q", volName, groupName)
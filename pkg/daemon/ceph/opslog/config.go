@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package opslog ships the RGW ops log to external sinks from the ops-log sidecar of the RGW pods
+package opslog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", "opslog")
+
+const (
+	// ConfigEnvVar is the env var of the sidecar with the sinks and the delivery settings
+	ConfigEnvVar = "ROOK_OPS_LOG_CONFIG"
+
+	// the keys of the secrets of the sinks passed to the sidecar in env vars
+	SecretAuthorization = "AUTHORIZATION"
+	SecretUser          = "USER"
+	SecretPassword      = "PASSWORD"
+	SecretAccessKey     = "ACCESS_KEY"
+	SecretSecretKey     = "SECRET_KEY"
+
+	defaultBatchSize    = 500
+	defaultBatchTimeout = 5 * time.Second
+	defaultBufferSize   = 10000
+)
+
+// Config is the configuration of the sidecar, passed by the operator in the ConfigEnvVar env var
+type Config struct {
+	Sinks    []cephv1.OpsLogSinkSpec   `json:"sinks"`
+	Delivery cephv1.OpsLogDeliverySpec `json:"delivery"`
+}
+
+// ConfigFromEnv reads the configuration of the sidecar from its env
+func ConfigFromEnv() (*Config, error) {
+	raw := os.Getenv(ConfigEnvVar)
+	if raw == "" {
+		return nil, errors.Errorf("%s is not set", ConfigEnvVar)
+	}
+	config := &Config{}
+	if err := json.Unmarshal([]byte(raw), config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", ConfigEnvVar)
+	}
+	return config, nil
+}
+
+// SecretEnvVarName returns the name of the env var of the sidecar with a secret of a sink
+func SecretEnvVarName(sink, key string) string {
+	return fmt.Sprintf("ROOK_OPS_LOG_SINK_%s_%s", strings.ToUpper(strings.ReplaceAll(sink, "-", "_")), key)
+}
+
+// SecretRefs returns the secrets of a sink by their key
+func SecretRefs(sink *cephv1.OpsLogSinkSpec) map[string]*v1.SecretKeySelector {
+	refs := map[string]*v1.SecretKeySelector{}
+	switch {
+	case sink.HTTP != nil:
+		if sink.HTTP.AuthorizationSecretRef != nil {
+			refs[SecretAuthorization] = sink.HTTP.AuthorizationSecretRef
+		}
+	case sink.Kafka != nil:
+		if sink.Kafka.UserSecretRef != nil {
+			refs[SecretUser] = sink.Kafka.UserSecretRef
+		}
+		if sink.Kafka.PasswordSecretRef != nil {
+			refs[SecretPassword] = sink.Kafka.PasswordSecretRef
+		}
+	case sink.S3 != nil:
+		refs[SecretAccessKey] = &sink.S3.AccessKeySecretRef
+		refs[SecretSecretKey] = &sink.S3.SecretKeySecretRef
+	}
+	return refs
+}
+
+// ValidateSink checks that exactly one supported endpoint of a sink is set
+func ValidateSink(sink *cephv1.OpsLogSinkSpec) error {
+	endpoints := 0
+	for _, set := range []bool{sink.HTTP != nil, sink.Kafka != nil, sink.S3 != nil} {
+		if set {
+			endpoints++
+		}
+	}
+	if endpoints != 1 {
+		return errors.Errorf("ops log sink %q must have exactly one of http, kafka or s3", sink.Name)
+	}
+	if sink.HTTP != nil && sink.HTTP.SendCloudEvents {
+		return errors.Errorf("ops log sink %q: sendCloudEvents is not supported", sink.Name)
+	}
+	if sink.Kafka != nil {
+		switch sink.Kafka.Mechanism {
+		case "", kafkaMechanismPlain, kafkaMechanismScramSHA256, kafkaMechanismScramSHA512:
+		default:
+			return errors.Errorf("ops log sink %q: kafka mechanism %q is not supported", sink.Name, sink.Kafka.Mechanism)
+		}
+		if (sink.Kafka.UserSecretRef == nil) != (sink.Kafka.PasswordSecretRef == nil) {
+			return errors.Errorf("ops log sink %q: kafka userSecretRef and passwordSecretRef must be set together", sink.Name)
+		}
+	}
+	return nil
+}
+
+func batchSize(delivery *cephv1.OpsLogDeliverySpec) int {
+	if delivery.BatchSize != nil {
+		return int(*delivery.BatchSize)
+	}
+	return defaultBatchSize
+}
+
+func batchTimeout(delivery *cephv1.OpsLogDeliverySpec) time.Duration {
+	if delivery.BatchTimeout != nil {
+		return delivery.BatchTimeout.Duration
+	}
+	return defaultBatchTimeout
+}
+
+func bufferSize(delivery *cephv1.OpsLogDeliverySpec) int {
+	if delivery.BufferSize != nil {
+		return int(*delivery.BufferSize)
+	}
+	return defaultBufferSize
+}
+
+// maxRetries returns -1 if the batches are retried until they are delivered
+func maxRetries(delivery *cephv1.OpsLogDeliverySpec) int {
+	if delivery.MaxRetries != nil {
+		return int(*delivery.MaxRetries)
+	}
+	return -1
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+const (
+	kafkaMechanismPlain       = "PLAIN"
+	kafkaMechanismScramSHA256 = "SCRAM-SHA-256"
+	kafkaMechanismScramSHA512 = "SCRAM-SHA-512"
+
+	kafkaDefaultPort = "9092"
+	kafkaClientID    = "rook-ops-log"
+	// below the default message.max.bytes of the brokers
+	kafkaMaxBatchBytes = 900 * 1024
+)
+
+type kafkaSink struct {
+	topic  string
+	client *kgo.Client
+}
+
+func newKafkaSink(spec *cephv1.OpsLogKafkaSinkSpec, user, password string) (*kafkaSink, error) {
+	brokers, err := kafkaBootstrapBrokers(spec.URI)
+	if err != nil {
+		return nil, err
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(brokers...),
+		kgo.ClientID(kafkaClientID),
+		kgo.DefaultProduceTopic(spec.Topic),
+		kgo.ProducerBatchMaxBytes(kafkaMaxBatchBytes),
+		// the records of a batch are spread over the partitions of the topic
+		kgo.RecordPartitioner(kgo.RoundRobinPartitioner()),
+		kgo.RecordDeliveryTimeout(sendTimeout),
+	}
+	if spec.AckLevel == "none" {
+		// idempotent writes need the broker ack
+		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	} else {
+		// the broker ack waits for all the in-sync replicas
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	}
+	if spec.UseSSL {
+		// #nosec G402 InsecureSkipVerify is enabled by the user
+		opts = append(opts, kgo.DialTLSConfig(&tls.Config{InsecureSkipVerify: spec.DisableVerifySSL, MinVersion: tls.VersionTLS12}))
+	}
+	if user != "" {
+		opts = append(opts, kgo.SASL(kafkaMechanism(spec.Mechanism, user, password)))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create kafka client for %q", spec.URI)
+	}
+	return &kafkaSink{topic: spec.Topic, client: client}, nil
+}
+
+// kafkaMechanism returns the SASL mechanism of the sink. The mechanisms are validated with the sink.
+func kafkaMechanism(mechanism, user, password string) sasl.Mechanism {
+	switch mechanism {
+	case kafkaMechanismScramSHA256:
+		return scram.Auth{User: user, Pass: password}.AsSha256Mechanism()
+	case kafkaMechanismScramSHA512:
+		return scram.Auth{User: user, Pass: password}.AsSha512Mechanism()
+	default:
+		return plain.Auth{User: user, Pass: password}.AsMechanism()
+	}
+}
+
+// kafkaBootstrapBrokers returns the brokers of a URI like "kafka://host1:9092,host2:9092"
+func kafkaBootstrapBrokers(uri string) ([]string, error) {
+	// url.Parse rejects the list of hosts in the authority
+	hosts := uri
+	if _, rest, ok := strings.Cut(uri, "://"); ok {
+		hosts, _, _ = strings.Cut(rest, "/")
+	}
+	if i := strings.LastIndex(hosts, "@"); i >= 0 {
+		hosts = hosts[i+1:]
+	}
+	var brokers []string
+	for _, host := range strings.Split(hosts, ",") {
+		if host == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, kafkaDefaultPort)
+		}
+		brokers = append(brokers, host)
+	}
+	if len(brokers) == 0 {
+		return nil, errors.Errorf("invalid kafka uri %q: no broker", uri)
+	}
+	return brokers, nil
+}
+
+func (s *kafkaSink) send(ctx context.Context, records [][]byte) error {
+	kafkaRecords := make([]*kgo.Record, 0, len(records))
+	for _, r := range records {
+		kafkaRecords = append(kafkaRecords, &kgo.Record{Value: r})
+	}
+	if err := s.client.ProduceSync(ctx, kafkaRecords...).FirstErr(); err != nil {
+		return errors.Wrapf(err, "failed to produce records to kafka topic %q", s.topic)
+	}
+	return nil
+}
+
+func (s *kafkaSink) close() {
+	s.client.Close()
+}
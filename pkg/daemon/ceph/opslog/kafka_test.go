@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestKafkaSink(t *testing.T) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(2, "ops"))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	sink, err := newKafkaSink(&cephv1.OpsLogKafkaSinkSpec{
+		KafkaEndpointSpec: cephv1.KafkaEndpointSpec{URI: "kafka://" + strings.Join(cluster.ListenAddrs(), ",")},
+		Topic:             "ops",
+	}, "", "")
+	require.NoError(t, err)
+	defer sink.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, sink.send(ctx, [][]byte{[]byte(`{"op":"a"}`), []byte(`{"op":"b"}`)}))
+	require.NoError(t, sink.send(ctx, [][]byte{[]byte(`{"op":"c"}`)}))
+
+	consumer, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...), kgo.ConsumeTopics("ops"))
+	require.NoError(t, err)
+	defer consumer.Close()
+	var values []string
+	partitions := map[int32]bool{}
+	for len(values) < 3 {
+		fetches := consumer.PollFetches(ctx)
+		require.NoError(t, fetches.Err())
+		fetches.EachRecord(func(r *kgo.Record) {
+			values = append(values, string(r.Value))
+			partitions[r.Partition] = true
+		})
+	}
+	assert.ElementsMatch(t, []string{`{"op":"a"}`, `{"op":"b"}`, `{"op":"c"}`}, values)
+	assert.Len(t, partitions, 2)
+}
+
+func TestKafkaSinkUnreachable(t *testing.T) {
+	sink, err := newKafkaSink(&cephv1.OpsLogKafkaSinkSpec{
+		KafkaEndpointSpec: cephv1.KafkaEndpointSpec{URI: "kafka://127.0.0.1:1"},
+		Topic:             "ops",
+	}, "user", "password")
+	require.NoError(t, err)
+	defer sink.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Error(t, sink.send(ctx, [][]byte{[]byte(`{"op":"a"}`)}))
+}
+
+func TestKafkaBootstrapBrokers(t *testing.T) {
+	brokers, err := kafkaBootstrapBrokers("kafka://broker-0:9093,broker-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"broker-0:9093", "broker-1:9092"}, brokers)
+
+	brokers, err = kafkaBootstrapBrokers("my-cluster-kafka-bootstrap")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"my-cluster-kafka-bootstrap:9092"}, brokers)
+
+	_, err = kafkaBootstrapBrokers("kafka://")
+	assert.Error(t, err)
+}
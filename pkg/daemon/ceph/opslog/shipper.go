@@ -0,0 +1,376 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// StatsPort is the port the sidecar serves its stats on
+	StatsPort = 9286
+	// StatsPath is the HTTP path of the stats of the sidecar
+	StatsPath = "/stats"
+
+	checkpointInterval = 5 * time.Second
+	minBackoff         = time.Second
+	maxBackoff         = time.Minute
+)
+
+// Stats is the delivery status of the ops log, served by the sidecar on the stats port
+type Stats struct {
+	Updated      time.Time   `json:"updated"`
+	BacklogBytes int64       `json:"backlogBytes"`
+	Sinks        []SinkStats `json:"sinks"`
+}
+
+// SinkStats is the delivery status of the ops log to a sink since the sidecar started
+type SinkStats struct {
+	Name       string `json:"name"`
+	Delivered  int64  `json:"delivered"`
+	Dropped    int64  `json:"dropped"`
+	Buffered   int64  `json:"buffered"`
+	LagSeconds int64  `json:"lagSeconds"`
+	LastError  string `json:"lastError,omitempty"`
+}
+
+func checkpointFilePath(logFile string) string {
+	return logFile + ".checkpoint"
+}
+
+// worker delivers the records of the ops log to a sink in batches
+type worker struct {
+	name         string
+	sink         sink
+	queue        chan record
+	batchSize    int
+	batchTimeout time.Duration
+	maxRetries   int
+
+	mu        sync.Mutex
+	delivered int64
+	dropped   int64
+	pending   []record
+	lastError string
+	acked     position
+}
+
+func (w *worker) run(ctx context.Context) {
+	for {
+		batch := w.nextBatch(ctx)
+		if batch == nil {
+			return
+		}
+		w.deliver(ctx, batch)
+	}
+}
+
+// nextBatch waits for the first record of a batch, then for the batch to be full or its timeout.
+// It returns nil when the context is canceled.
+func (w *worker) nextBatch(ctx context.Context) []record {
+	var batch []record
+	select {
+	case <-ctx.Done():
+		return nil
+	case r := <-w.queue:
+		batch = append(batch, r)
+	}
+	w.setPending(batch)
+
+	timeout := time.NewTimer(w.batchTimeout)
+	defer timeout.Stop()
+	for len(batch) < w.batchSize {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timeout.C:
+			return batch
+		case r := <-w.queue:
+			batch = append(batch, r)
+			w.setPending(batch)
+		}
+	}
+	return batch
+}
+
+// deliver sends a batch to the sink, retrying with a backoff until it is delivered or the retries
+// are exhausted
+func (w *worker) deliver(ctx context.Context, batch []record) {
+	data := make([][]byte, len(batch))
+	for i := range batch {
+		data[i] = batch[i].data
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := w.sink.send(ctx, data)
+		if err == nil {
+			w.done(batch, "", false)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if w.maxRetries >= 0 && attempt >= w.maxRetries {
+			logger.Errorf("dropping %d ops log records for sink %q after %d retries. %v", len(batch), w.name, attempt, err)
+			w.done(batch, err.Error(), true)
+			return
+		}
+		logger.Warningf("failed to deliver %d ops log records to sink %q, retrying. %v", len(batch), w.name, err)
+		w.setError(err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff(attempt)):
+		}
+	}
+}
+
+func backoff(attempt int) time.Duration {
+	delay := minBackoff
+	for i := 0; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+func (w *worker) setPending(batch []record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = batch
+}
+
+func (w *worker) setError(lastError string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastError = lastError
+}
+
+func (w *worker) done(batch []record, lastError string, dropped bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if dropped {
+		w.dropped += int64(len(batch))
+	} else {
+		w.delivered += int64(len(batch))
+	}
+	w.lastError = lastError
+	w.acked = batch[len(batch)-1].pos
+	w.pending = nil
+}
+
+func (w *worker) stats(now time.Time) SinkStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	stats := SinkStats{
+		Name:      w.name,
+		Delivered: w.delivered,
+		Dropped:   w.dropped,
+		Buffered:  int64(len(w.pending) + len(w.queue)),
+		LastError: w.lastError,
+	}
+	if len(w.pending) > 0 {
+		stats.LagSeconds = int64(now.Sub(w.pending[0].readTime).Seconds())
+	}
+	return stats
+}
+
+func (w *worker) ackedPosition() position {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.acked
+}
+
+type shipper struct {
+	logFile string
+	out     io.Writer
+	tail    *tailer
+	workers []*worker
+}
+
+// Run ships the records of the ops log to the sinks of the config until the context is canceled.
+// The records are also written to out, and the stats are served on the stats listener. The position
+// of the records delivered to every sink is saved so that the sidecar resumes from it when it restarts.
+func Run(ctx context.Context, logFile string, config *Config, out io.Writer, statsListener net.Listener) error {
+	s := &shipper{logFile: logFile, out: out}
+
+	start := s.readCheckpoint()
+	s.tail = newTailer(logFile, start)
+	defer s.tail.close()
+
+	for i := range config.Sinks {
+		spec := &config.Sinks[i]
+		sink, err := newSink(spec, func(key string) string {
+			return os.Getenv(SecretEnvVarName(spec.Name, key))
+		})
+		if err != nil {
+			return err
+		}
+		defer sink.close()
+		w := &worker{
+			name:         spec.Name,
+			sink:         sink,
+			queue:        make(chan record, bufferSize(&config.Delivery)),
+			batchSize:    batchSize(&config.Delivery),
+			batchTimeout: batchTimeout(&config.Delivery),
+			maxRetries:   maxRetries(&config.Delivery),
+		}
+		if start != nil {
+			w.acked = *start
+		}
+		s.workers = append(s.workers, w)
+	}
+
+	var wg sync.WaitGroup
+	for _, w := range s.workers {
+		wg.Go(func() { w.run(ctx) })
+	}
+	wg.Go(func() { s.saveCheckpoints(ctx) })
+	wg.Go(func() { s.serveStats(ctx, statsListener) })
+
+	err := s.read(ctx)
+	wg.Wait()
+	s.writeCheckpoint()
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// read passes the records of the ops log to the workers. While the buffer of a worker is full,
+// the ops log is not read anymore.
+func (s *shipper) read(ctx context.Context) error {
+	for {
+		r, err := s.tail.next(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(s.out, "%s\n", r.data); err != nil {
+			logger.Warningf("failed to print ops log record. %v", err)
+		}
+		for _, w := range s.workers {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case w.queue <- r:
+			}
+		}
+	}
+}
+
+func (s *shipper) saveCheckpoints(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(checkpointInterval):
+			s.writeCheckpoint()
+		}
+	}
+}
+
+// serveStats serves the stats to the operator until the context is canceled
+func (s *shipper) serveStats(ctx context.Context, listener net.Listener) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(StatsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.stats()); err != nil {
+			logger.Warningf("failed to write ops log stats. %v", err)
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			logger.Warningf("failed to stop ops log stats server. %v", err)
+		}
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Errorf("failed to serve ops log stats. %v", err)
+	}
+}
+
+func (s *shipper) stats() Stats {
+	now := time.Now()
+	stats := Stats{Updated: now.UTC(), BacklogBytes: s.tail.backlog()}
+	for _, w := range s.workers {
+		stats.Sinks = append(stats.Sinks, w.stats(now))
+	}
+	return stats
+}
+
+// writeCheckpoint saves the position of the oldest record delivered to every sink
+func (s *shipper) writeCheckpoint() {
+	if len(s.workers) == 0 {
+		return
+	}
+	checkpoint := s.workers[0].ackedPosition()
+	for _, w := range s.workers[1:] {
+		if acked := w.ackedPosition(); acked.before(checkpoint) {
+			checkpoint = acked
+		}
+	}
+	if checkpoint.Inode == 0 {
+		// nothing was delivered yet
+		return
+	}
+	if err := writeJSONFile(checkpointFilePath(s.logFile), checkpoint); err != nil {
+		logger.Warningf("failed to write ops log checkpoint. %v", err)
+	}
+}
+
+func (s *shipper) readCheckpoint() *position {
+	data, err := os.ReadFile(checkpointFilePath(s.logFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warningf("failed to read ops log checkpoint. %v", err)
+		}
+		return nil
+	}
+	checkpoint := &position{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		logger.Warningf("failed to parse ops log checkpoint. %v", err)
+		return nil
+	}
+	return checkpoint
+}
+
+// writeJSONFile replaces a file atomically so that a reader never sees a partial file
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %q", path)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(err, "failed to rename %q", tmp)
+	}
+	return nil
+}
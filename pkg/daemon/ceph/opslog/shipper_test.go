@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func appendLines(t *testing.T, path string, lines ...string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	defer f.Close()
+	for _, line := range lines {
+		_, err := f.WriteString(line + "\n")
+		require.NoError(t, err)
+	}
+}
+
+func nextData(t *testing.T, tail *tailer) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	r, err := tail.next(ctx)
+	require.NoError(t, err)
+	return string(r.data)
+}
+
+func TestTailer(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "ops.log")
+	tail := newTailer(logFile, nil)
+	tail.pollInterval = 10 * time.Millisecond
+	defer tail.close()
+
+	t.Run("wait for the log to be created", func(t *testing.T) {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			appendLines(t, logFile, `{"op":"get_obj"}`, "", `{"op":"put_obj"}`)
+		}()
+		assert.Equal(t, `{"op":"get_obj"}`, nextData(t, tail))
+		assert.Equal(t, `{"op":"put_obj"}`, nextData(t, tail))
+		assert.Equal(t, int64(0), tail.backlog())
+	})
+
+	t.Run("partial record", func(t *testing.T) {
+		f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0o600)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"op":`)
+		require.NoError(t, err)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			_, _ = f.WriteString(`"list_bucket"}` + "\n")
+			f.Close()
+		}()
+		assert.Equal(t, `{"op":"list_bucket"}`, nextData(t, tail))
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		appendLines(t, logFile, `{"op":"before"}`)
+		require.NoError(t, os.Rename(logFile, logFile+".1"))
+		appendLines(t, logFile+".1", `{"op":"late"}`)
+		appendLines(t, logFile, `{"op":"after"}`)
+		assert.Equal(t, `{"op":"before"}`, nextData(t, tail))
+		assert.Equal(t, `{"op":"late"}`, nextData(t, tail))
+		assert.Equal(t, `{"op":"after"}`, nextData(t, tail))
+		assert.Equal(t, 1, tail.generation)
+	})
+
+	t.Run("truncation", func(t *testing.T) {
+		require.NoError(t, os.Truncate(logFile, 0))
+		appendLines(t, logFile, `{"op":"new"}`)
+		assert.Equal(t, `{"op":"new"}`, nextData(t, tail))
+		assert.Equal(t, 2, tail.generation)
+	})
+}
+
+func TestTailerResume(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "ops.log")
+	appendLines(t, logFile, `{"op":"a"}`, `{"op":"b"}`)
+	info, err := os.Stat(logFile)
+	require.NoError(t, err)
+
+	tail := newTailer(logFile, &position{Inode: fileInode(info), Offset: int64(len(`{"op":"a"}` + "\n"))})
+	defer tail.close()
+	assert.Equal(t, `{"op":"b"}`, nextData(t, tail))
+
+	// a checkpoint of a rotated log is ignored
+	other := newTailer(logFile, &position{Inode: fileInode(info) + 1, Offset: 3})
+	defer other.close()
+	assert.Equal(t, `{"op":"a"}`, nextData(t, other))
+}
+
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]string
+}
+
+func (s *fakeSink) send(ctx context.Context, records [][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	var batch []string
+	for _, r := range records {
+		batch = append(batch, string(r))
+	}
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *fakeSink) close() {}
+
+func testRecords(data ...string) []record {
+	var records []record
+	for i, d := range data {
+		records = append(records, record{data: []byte(d), pos: position{Inode: 1, Offset: int64(i + 1)}, readTime: time.Now()})
+	}
+	return records
+}
+
+func TestWorker(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("batches are full or timed out", func(t *testing.T) {
+		sink := &fakeSink{}
+		w := &worker{name: "test", sink: sink, queue: make(chan record, 10), batchSize: 2, batchTimeout: 20 * time.Millisecond, maxRetries: -1}
+		for _, r := range testRecords("a", "b", "c") {
+			w.queue <- r
+		}
+		w.deliver(ctx, w.nextBatch(ctx))
+		w.deliver(ctx, w.nextBatch(ctx))
+		assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, sink.batches)
+		stats := w.stats(time.Now())
+		assert.Equal(t, SinkStats{Name: "test", Delivered: 3}, stats)
+		assert.Equal(t, int64(3), w.ackedPosition().Offset)
+	})
+
+	t.Run("dropped after the retries", func(t *testing.T) {
+		sink := &fakeSink{failures: 2}
+		w := &worker{name: "test", sink: sink, queue: make(chan record, 10), batchSize: 10, maxRetries: 1}
+		batch := testRecords("a", "b")
+		w.setPending(batch)
+		w.deliver(ctx, batch)
+		stats := w.stats(time.Now())
+		assert.Equal(t, int64(2), stats.Dropped)
+		assert.Equal(t, int64(0), stats.Buffered)
+		assert.Equal(t, "sink unavailable", stats.LastError)
+		assert.Equal(t, int64(2), w.ackedPosition().Offset)
+	})
+
+	t.Run("lag of the pending records", func(t *testing.T) {
+		w := &worker{name: "test", queue: make(chan record, 10)}
+		batch := testRecords("a")
+		batch[0].readTime = time.Now().Add(-time.Minute)
+		w.setPending(batch)
+		w.queue <- testRecords("b")[0]
+		stats := w.stats(time.Now())
+		assert.Equal(t, int64(60), stats.LagSeconds)
+		assert.Equal(t, int64(2), stats.Buffered)
+	})
+
+	assert.Equal(t, time.Second, backoff(0))
+	assert.Equal(t, 4*time.Second, backoff(2))
+	assert.Equal(t, time.Minute, backoff(20))
+}
+
+func TestRun(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		authorization = r.Header.Get("Authorization")
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			received = append(received, scanner.Text())
+		}
+	}))
+	defer server.Close()
+
+	logFile := filepath.Join(t.TempDir(), "ops.log")
+	appendLines(t, logFile, `{"op":"a"}`, `{"op":"b"}`)
+	t.Setenv(SecretEnvVarName("audit-http", SecretAuthorization), "Bearer token")
+	batchTimeout := metav1.Duration{Duration: 10 * time.Millisecond}
+	config := &Config{
+		Sinks: []cephv1.OpsLogSinkSpec{{
+			Name: "audit-http",
+			HTTP: &cephv1.OpsLogHTTPSinkSpec{
+				HTTPEndpointSpec:       cephv1.HTTPEndpointSpec{URI: server.URL},
+				AuthorizationSecretRef: &v1.SecretKeySelector{Key: "token"},
+			},
+		}},
+		Delivery: cephv1.OpsLogDeliverySpec{BatchTimeout: &batchTimeout},
+	}
+
+	var stats Stats
+	run := func() string {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := &bytes.Buffer{}
+		done := make(chan error)
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go func() { done <- Run(ctx, logFile, config, out, listener) }()
+		assert.Eventually(t, func() bool {
+			resp, err := http.Get("http://" + listener.Addr().String() + StatsPath)
+			if err != nil {
+				return false
+			}
+			defer resp.Body.Close()
+			stats = Stats{}
+			return json.NewDecoder(resp.Body).Decode(&stats) == nil && len(stats.Sinks) == 1 && stats.Sinks[0].Delivered == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		cancel()
+		assert.NoError(t, <-done)
+		return out.String()
+	}
+
+	out := run()
+	assert.Equal(t, `{"op":"a"}`+"\n"+`{"op":"b"}`+"\n", out)
+	assert.Equal(t, []string{`{"op":"a"}`, `{"op":"b"}`}, received)
+	assert.Equal(t, "Bearer token", authorization)
+	assert.Equal(t, []SinkStats{{Name: "audit-http", Delivered: 2}}, stats.Sinks)
+
+	// the delivered records are not sent again after a restart
+	received = nil
+	appendLines(t, logFile, `{"op":"c"}`, `{"op":"d"}`)
+	out = run()
+	assert.Equal(t, []string{`{"op":"c"}`, `{"op":"d"}`}, received)
+	assert.False(t, strings.Contains(out, `"a"`))
+}
+
+func TestHTTPSinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink := newHTTPSink(&cephv1.OpsLogHTTPSinkSpec{HTTPEndpointSpec: cephv1.HTTPEndpointSpec{URI: server.URL}}, "")
+	err := sink.send(context.TODO(), [][]byte{[]byte(`{}`)})
+	assert.ErrorContains(t, err, "503")
+}
+
+func TestValidateSink(t *testing.T) {
+	http := &cephv1.OpsLogHTTPSinkSpec{HTTPEndpointSpec: cephv1.HTTPEndpointSpec{URI: "http://logs"}}
+	kafka := &cephv1.OpsLogKafkaSinkSpec{KafkaEndpointSpec: cephv1.KafkaEndpointSpec{URI: "kafka://broker"}, Topic: "ops"}
+
+	assert.NoError(t, ValidateSink(&cephv1.OpsLogSinkSpec{Name: "a", HTTP: http}))
+	assert.Error(t, ValidateSink(&cephv1.OpsLogSinkSpec{Name: "a"}))
+	assert.Error(t, ValidateSink(&cephv1.OpsLogSinkSpec{Name: "a", HTTP: http, Kafka: kafka}))
+
+	cloudEvents := http.DeepCopy()
+	cloudEvents.SendCloudEvents = true
+	assert.Error(t, ValidateSink(&cephv1.OpsLogSinkSpec{Name: "a", HTTP: cloudEvents}))
+
+	gssapi := kafka.DeepCopy()
+	gssapi.Mechanism = "GSSAPI"
+	assert.Error(t, ValidateSink(&cephv1.OpsLogSinkSpec{Name: "a", Kafka: gssapi}))
+
+	userOnly := kafka.DeepCopy()
+	userOnly.UserSecretRef = &v1.SecretKeySelector{Key: "user"}
+	assert.Error(t, ValidateSink(&cephv1.OpsLogSinkSpec{Name: "a", Kafka: userOnly}))
+}
+
+func TestSecretEnvVarName(t *testing.T) {
+	assert.Equal(t, "ROOK_OPS_LOG_SINK_AUDIT_HTTP_AUTHORIZATION", SecretEnvVarName("audit-http", SecretAuthorization))
+}
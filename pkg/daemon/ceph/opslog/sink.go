@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+)
+
+const (
+	sendTimeout     = 30 * time.Second
+	defaultS3Region = "us-east-1"
+)
+
+// sink is an external destination of the ops log
+type sink interface {
+	// send delivers a batch of records, either completely or not at all
+	send(ctx context.Context, records [][]byte) error
+	close()
+}
+
+// newSink creates the sink of a spec. The secrets of the sink are returned by their key.
+func newSink(spec *cephv1.OpsLogSinkSpec, secret func(key string) string) (sink, error) {
+	if err := ValidateSink(spec); err != nil {
+		return nil, err
+	}
+	switch {
+	case spec.HTTP != nil:
+		return newHTTPSink(spec.HTTP, secret(SecretAuthorization)), nil
+	case spec.Kafka != nil:
+		return newKafkaSink(spec.Kafka, secret(SecretUser), secret(SecretPassword))
+	default:
+		return newS3Sink(spec.S3, secret(SecretAccessKey), secret(SecretSecretKey)), nil
+	}
+}
+
+func newHTTPClient(disableVerifySSL bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if disableVerifySSL {
+		// #nosec G402 is enabled by the user
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &http.Client{Timeout: sendTimeout, Transport: transport}
+}
+
+// joinRecords returns the records as newline-delimited JSON
+func joinRecords(records [][]byte) []byte {
+	var buf bytes.Buffer
+	for _, r := range records {
+		buf.Write(r)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+type httpSink struct {
+	uri           string
+	authorization string
+	client        *http.Client
+}
+
+func newHTTPSink(spec *cephv1.OpsLogHTTPSinkSpec, authorization string) *httpSink {
+	return &httpSink{
+		uri:           spec.URI,
+		authorization: authorization,
+		client:        newHTTPClient(spec.DisableVerifySSL),
+	}
+}
+
+func (s *httpSink) send(ctx context.Context, records [][]byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.uri, bytes.NewReader(joinRecords(records)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create request to %q", s.uri)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post records to %q", s.uri)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("failed to post records to %q: %s %s", s.uri, resp.Status, string(body))
+	}
+	return nil
+}
+
+func (s *httpSink) close() {
+	s.client.CloseIdleConnections()
+}
+
+type s3Sink struct {
+	bucket string
+	prefix string
+	// the objects are named after the pod so that the gateways do not overwrite each other
+	podName string
+	client  *s3.Client
+	http    *http.Client
+	seq     atomic.Int64
+}
+
+func newS3Sink(spec *cephv1.OpsLogS3SinkSpec, accessKey, secretKey string) *s3Sink {
+	region := spec.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	httpClient := newHTTPClient(spec.DisableVerifySSL)
+	endpoint := spec.Endpoint
+	client := s3.NewFromConfig(aws.Config{
+		Region:       region,
+		Credentials:  aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
+		HTTPClient:   httpClient,
+		BaseEndpoint: &endpoint,
+		// the retries are done by the worker
+		RetryMaxAttempts: 1,
+	}, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	podName, _ := os.Hostname()
+	return &s3Sink{bucket: spec.Bucket, prefix: spec.Prefix, podName: podName, client: client, http: httpClient}
+}
+
+func (s *s3Sink) objectKey(now time.Time) string {
+	name := fmt.Sprintf("%s-%06d.log", now.UTC().Format("20060102T150405Z"), s.seq.Add(1))
+	return path.Join(s.prefix, s.podName, now.UTC().Format("2006/01/02"), name)
+}
+
+func (s *s3Sink) send(ctx context.Context, records [][]byte) error {
+	key := s.objectKey(time.Now())
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &s.bucket,
+		Key:         &key,
+		Body:        bytes.NewReader(joinRecords(records)),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to write object %q in bucket %q", key, s.bucket)
+	}
+	return nil
+}
+
+func (s *s3Sink) close() {
+	s.http.CloseIdleConnections()
+}
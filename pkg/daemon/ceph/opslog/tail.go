@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// position is the position in the ops log after a record. The generation is incremented each time
+// the tailer switches to a new file so that the positions of different files can be ordered.
+type position struct {
+	Inode      uint64 `json:"inode"`
+	Offset     int64  `json:"offset"`
+	generation int
+}
+
+func (p position) before(other position) bool {
+	if p.generation != other.generation {
+		return p.generation < other.generation
+	}
+	return p.Offset < other.Offset
+}
+
+type record struct {
+	data     []byte
+	pos      position
+	readTime time.Time
+}
+
+// tailer reads the records of the ops log like 'tail -F'. It follows the ops log when it is
+// rotated by logrotate and restarts from the beginning of the file when it is truncated.
+type tailer struct {
+	path         string
+	pollInterval time.Duration
+	// the position to resume from when the file is first opened
+	start *position
+
+	file       *os.File
+	reader     *bufio.Reader
+	inode      uint64
+	generation int
+	partial    []byte
+	// the offset after the last complete record, read by the stats concurrently
+	offset atomic.Int64
+}
+
+func newTailer(path string, start *position) *tailer {
+	return &tailer{path: path, pollInterval: time.Second, start: start}
+}
+
+// next returns the next record of the ops log, waiting for it to be written if needed
+func (t *tailer) next(ctx context.Context) (record, error) {
+	for {
+		if t.file == nil {
+			opened, err := t.open()
+			if err != nil {
+				return record{}, err
+			}
+			if !opened {
+				if err := t.wait(ctx); err != nil {
+					return record{}, err
+				}
+				continue
+			}
+		}
+
+		line, err := t.reader.ReadBytes('\n')
+		t.partial = append(t.partial, line...)
+		if err == nil {
+			offset := t.offset.Add(int64(len(t.partial)))
+			data := bytes.TrimSpace(t.partial)
+			t.partial = nil
+			if len(data) == 0 {
+				continue
+			}
+			return record{data: data, pos: position{Inode: t.inode, Offset: offset, generation: t.generation}, readTime: time.Now()}, nil
+		}
+		if err != io.EOF {
+			return record{}, errors.Wrapf(err, "failed to read %q", t.path)
+		}
+
+		switched, err := t.checkRotation()
+		if err != nil {
+			return record{}, err
+		}
+		if !switched {
+			if err := t.wait(ctx); err != nil {
+				return record{}, err
+			}
+		}
+	}
+}
+
+// open opens the ops log, returning false if it does not exist yet
+func (t *tailer) open() (bool, error) {
+	file, err := os.Open(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to open %q", t.path)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return false, errors.Wrapf(err, "failed to stat %q", t.path)
+	}
+
+	t.file = file
+	t.inode = fileInode(info)
+	t.offset.Store(0)
+	if t.start != nil {
+		if t.start.Inode == t.inode && t.start.Offset <= info.Size() {
+			if _, err := file.Seek(t.start.Offset, io.SeekStart); err != nil {
+				return false, errors.Wrapf(err, "failed to seek %q", t.path)
+			}
+			t.offset.Store(t.start.Offset)
+			logger.Infof("resuming ops log %q at offset %d", t.path, t.start.Offset)
+		} else {
+			logger.Warningf("ops log %q was rotated since the last checkpoint, reading it from the start", t.path)
+		}
+		t.start = nil
+	}
+	t.reader = bufio.NewReader(file)
+	return true, nil
+}
+
+// checkRotation switches to the new ops log if it was rotated, or restarts from the beginning of
+// the file if it was truncated. It returns whether there may be more records to read right away.
+func (t *tailer) checkRotation() (bool, error) {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the log was renamed and the new one is not created yet
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to stat %q", t.path)
+	}
+
+	if fileInode(info) != t.inode {
+		logger.Infof("ops log %q was rotated", t.path)
+		if len(t.partial) > 0 {
+			logger.Warningf("discarding incomplete record at the end of the rotated ops log")
+		}
+		t.file.Close()
+		t.file = nil
+		t.partial = nil
+		t.generation++
+		return true, nil
+	}
+
+	if info.Size() < t.offset.Load()+int64(len(t.partial)) {
+		logger.Warningf("ops log %q was truncated, reading it from the start", t.path)
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return false, errors.Wrapf(err, "failed to seek %q", t.path)
+		}
+		t.reader.Reset(t.file)
+		t.offset.Store(0)
+		t.partial = nil
+		t.generation++
+		return true, nil
+	}
+	return false, nil
+}
+
+func (t *tailer) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(t.pollInterval):
+		return nil
+	}
+}
+
+// backlog returns the number of bytes of the ops log not read yet
+func (t *tailer) backlog() int64 {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return 0
+	}
+	if backlog := info.Size() - t.offset.Load(); backlog > 0 {
+		return backlog
+	}
+	return 0
+}
+
+func (t *tailer) close() {
+	if t.file != nil {
+		t.file.Close()
+	}
+}
+
+func fileInode(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Ino
+	}
+	return 0
+}
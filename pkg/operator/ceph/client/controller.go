@@ -93,7 +93,7 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -112,6 +112,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Reconcile the clients whose cephx keys are overdue for rotation in the cephx key inventory
+	err = c.Watch(opcontroller.ReconcileTriggerSource(controllerTypeMeta.Kind))
+	if err != nil {
+		return err
+	}
+
 	// Watch secrets
 	err = c.Watch(
 		source.Kind(
@@ -251,7 +257,8 @@ func (r *ReconcileCephClient) reconcile(request reconcile.Request) (reconcile.Re
 
 	// do not ignore key type for non-daemon cephclient key
 	shouldRotateCephxKeys, err := keyring.ShouldRotateCephxKeys(
-		cephClient.Spec.Security.CephX, runningCephVersion, runningCephVersion, cephClient.Status.Cephx, false, r.clusterInfo.Namespace)
+		cephClient.Spec.Security.CephX, runningCephVersion, runningCephVersion, cephClient.Status.Cephx, false, r.clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephClient", cephClient.Name, ""))
 	if err != nil {
 		return reconcile.Result{}, *cephClient, errors.Wrap(err, "failed to determine if cephx keys should be rotated")
 	}
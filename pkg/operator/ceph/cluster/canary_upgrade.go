@@ -0,0 +1,411 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mgr"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/file/mds"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// upgradeHaltHealthError is the reason of a halted upgrade when the cluster health is HEALTH_ERR
+	upgradeHaltHealthError = "HealthError"
+	// upgradeHaltSlowOps is the reason of a halted upgrade when slow ops are reported
+	upgradeHaltSlowOps = "SlowOps"
+	// upgradeHaltDaemonCrash is the reason of a halted upgrade when a crash is reported
+	upgradeHaltDaemonCrash = "DaemonCrashed"
+
+	slowOpsHealthCheck = "SLOW_OPS"
+)
+
+// canaryUpgradeApps are the daemons held by a canary upgrade, with the label of their daemon ID
+var canaryUpgradeApps = []struct {
+	appName, daemonType, daemonLabel string
+}{
+	{mon.AppName, config.MonType, config.MonType},
+	{mgr.AppName, config.MgrType, config.MgrType},
+	{osd.AppName, config.OsdType, osd.OsdIdLabelKey},
+	{object.AppName, config.RgwType, config.RgwType},
+	{mds.AppName, config.MdsType, config.MdsType},
+}
+
+// reconcileCanaryUpgrade runs before the daemons are reconciled. It starts a canary upgrade when the
+// Ceph image changes, and continues the upgrade once the soak period is over without regression.
+func (c *cluster) reconcileCanaryUpgrade() error {
+	c.canaryUpgradeRequeue = 0
+
+	upgrade, err := c.getUpgradeStatus()
+	if err != nil {
+		return err
+	}
+
+	canary := c.Spec.CanaryUpgrade()
+	image := c.Spec.CephVersion.Image
+	switch {
+	case canary == nil:
+		if !upgrade.HoldsDaemons() {
+			return nil
+		}
+		log.NamespacedInfo(c.Namespace, logger, "canary upgrade is disabled, upgrading all the daemons")
+		upgrade, err = updateUpgradeStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.UpgradeStatus) {
+			s.Phase = cephv1.UpgradePhaseContinuing
+			s.Message = "canary upgrade disabled, upgrading all the daemons"
+		})
+	case c.isUpgrade && (upgrade == nil || upgrade.Image != image):
+		upgrade, err = c.startCanaryUpgrade(canary, image)
+	case upgrade == nil || upgrade.Image != image:
+		// the daemons already run the version of the image, release the daemons held for another image
+		if !upgrade.HoldsDaemons() {
+			return nil
+		}
+		upgrade = nil
+	case upgrade.Phase == cephv1.UpgradePhaseSoaking:
+		upgrade, err = c.checkCanarySoak(canary, upgrade)
+	case upgrade.Phase == cephv1.UpgradePhaseHalted:
+		log.NamespacedWarning(c.Namespace, logger, "canary upgrade to image %q is halted (%s): %s. the daemons that are not canaries keep their current version",
+			image, upgrade.Reason, upgrade.Message)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.applyCanaryUpgradeHold(upgrade)
+}
+
+// completeCanaryStage runs after the mons, mgrs and OSDs are reconciled. It starts the soak period once
+// the canary daemons are upgraded, and completes the upgrade once all the daemons are upgraded, also
+// when the canary upgrade was disabled during the upgrade.
+func (c *cluster) completeCanaryStage() error {
+	upgrade, err := c.getUpgradeStatus()
+	if err != nil {
+		return err
+	}
+	if upgrade == nil || upgrade.Image != c.Spec.CephVersion.Image {
+		return nil
+	}
+
+	canary := c.Spec.CanaryUpgrade()
+	switch upgrade.Phase {
+	case cephv1.UpgradePhaseCanary:
+		if canary == nil {
+			return nil
+		}
+		log.NamespacedInfo(c.Namespace, logger, "canary daemons %v are upgraded, watching the cluster for %s", upgrade.CanaryDaemons, canary.SoakDuration())
+		c.canaryUpgradeRequeue = canary.SoakDuration()
+		_, err = updateUpgradeStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.UpgradeStatus) {
+			now := metav1.Now()
+			s.Phase = cephv1.UpgradePhaseSoaking
+			s.SoakStartTime = &now
+			s.Message = fmt.Sprintf("canary daemons upgraded, watching the cluster for %s", canary.SoakDuration())
+		})
+		return err
+	case cephv1.UpgradePhaseContinuing:
+		log.NamespacedInfo(c.Namespace, logger, "canary upgrade to image %q completed", upgrade.Image)
+		_, err = updateUpgradeStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.UpgradeStatus) {
+			s.Phase = cephv1.UpgradePhaseCompleted
+			s.Message = "mons, mgrs and OSDs upgraded, the other daemons are upgraded by their controllers"
+		})
+		return err
+	}
+	return nil
+}
+
+func (c *cluster) startCanaryUpgrade(canary *cephv1.CanaryUpgradeSpec, image string) (*cephv1.UpgradeStatus, error) {
+	canaries, err := c.selectCanaryDaemons(canary)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select the canary daemons")
+	}
+
+	now := metav1.Now()
+	upgrade := cephv1.UpgradeStatus{
+		Image:         image,
+		Phase:         cephv1.UpgradePhaseCanary,
+		CanaryDaemons: canaries,
+		StartTime:     &now,
+		Message:       fmt.Sprintf("upgrading %d canary daemons", len(canaries)),
+	}
+	if len(canaries) == 0 {
+		upgrade.Phase = cephv1.UpgradePhaseContinuing
+		upgrade.Message = "no canary daemons to upgrade, upgrading all the daemons"
+	}
+
+	log.NamespacedInfo(c.Namespace, logger, "starting canary upgrade to image %q with canary daemons %v", image, canaries)
+	return updateUpgradeStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.UpgradeStatus) {
+		*s = upgrade
+	})
+}
+
+func (c *cluster) checkCanarySoak(canary *cephv1.CanaryUpgradeSpec, upgrade *cephv1.UpgradeStatus) (*cephv1.UpgradeStatus, error) {
+	status, err := cephclient.Status(c.context, c.ClusterInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ceph status to check the canary upgrade")
+	}
+	reason, message, err := checkUpgradeRegression(c.context, c.ClusterInfo, status, upgrade.StartTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check the cluster for regressions during the canary upgrade")
+	}
+	if reason != "" {
+		return haltCanaryUpgrade(c.ClusterInfo.Context, c.context, c.namespacedName, reason, message)
+	}
+
+	soakStart := time.Now()
+	if upgrade.SoakStartTime != nil {
+		soakStart = upgrade.SoakStartTime.Time
+	}
+	if elapsed := time.Since(soakStart); elapsed < canary.SoakDuration() {
+		c.canaryUpgradeRequeue = canary.SoakDuration() - elapsed
+		log.NamespacedInfo(c.Namespace, logger, "canary upgrade soaking, the other daemons are upgraded in %s", c.canaryUpgradeRequeue.Round(time.Second))
+		return upgrade, nil
+	}
+
+	log.NamespacedInfo(c.Namespace, logger, "canary upgrade soak period passed without regression, upgrading the other daemons")
+	return updateUpgradeStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.UpgradeStatus) {
+		s.Phase = cephv1.UpgradePhaseContinuing
+		s.Message = "soak period passed without regression, upgrading the other daemons"
+	})
+}
+
+// selectCanaryDaemons returns the first mons, the OSDs of the first hosts of each device class, and the
+// RGWs of the first object stores, in alphabetical order
+func (c *cluster) selectCanaryDaemons(canary *cephv1.CanaryUpgradeSpec) ([]string, error) {
+	canaries := []string{}
+
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, mon.AppName)}
+	monDeployments, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).List(c.ClusterInfo.Context, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list mon deployments")
+	}
+	monIDs := []string{}
+	for _, d := range monDeployments.Items {
+		if monID, ok := d.Labels[config.MonType]; ok {
+			monIDs = append(monIDs, monID)
+		}
+	}
+	slices.Sort(monIDs)
+	for _, monID := range monIDs[:min(canary.MonCount(), len(monIDs))] {
+		canaries = append(canaries, canaryDaemonName(config.MonType, monID))
+	}
+
+	if canary.OSDHostCount() > 0 {
+		tree, err := cephclient.HostTree(c.context, c.ClusterInfo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the osd tree")
+		}
+		for _, osdID := range selectCanaryOSDs(tree, canary.OSDHostCount()) {
+			canaries = append(canaries, canaryDaemonName(config.OsdType, strconv.Itoa(osdID)))
+		}
+	}
+
+	if canary.RGWCount() > 0 {
+		objectStores, err := c.context.RookClientset.CephV1().CephObjectStores(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list object stores")
+		}
+		storeNames := []string{}
+		for _, store := range objectStores.Items {
+			if store.Spec.IsExternal() || !store.DeletionTimestamp.IsZero() {
+				continue
+			}
+			storeNames = append(storeNames, store.Name)
+		}
+		slices.Sort(storeNames)
+		for _, storeName := range storeNames[:min(canary.RGWCount(), len(storeNames))] {
+			canaries = append(canaries, canaryDaemonName(config.RgwType, storeName))
+		}
+	}
+
+	return canaries, nil
+}
+
+// selectCanaryOSDs returns the OSDs of the first hosts in alphabetical order that hold OSDs of a device
+// class, until the given number of hosts is selected for every device class
+func selectCanaryOSDs(tree cephclient.OsdTree, hostsPerDeviceClass int) []int {
+	deviceClasses := map[int]string{}
+	hosts := map[string][]int{}
+	for _, node := range tree.Nodes {
+		switch node.Type {
+		case "osd":
+			deviceClasses[node.ID] = node.DeviceClass
+		case "host":
+			hosts[node.Name] = node.Children
+		}
+	}
+
+	hostNames := make([]string, 0, len(hosts))
+	for hostName := range hosts {
+		hostNames = append(hostNames, hostName)
+	}
+	slices.Sort(hostNames)
+
+	selectedHosts := map[string]int{}
+	osds := []int{}
+	for _, hostName := range hostNames {
+		hostClasses := map[string]bool{}
+		for _, osdID := range hosts[hostName] {
+			hostClasses[deviceClasses[osdID]] = true
+		}
+		selected := false
+		for deviceClass := range hostClasses {
+			if selectedHosts[deviceClass] < hostsPerDeviceClass {
+				selected = true
+			}
+		}
+		if !selected {
+			continue
+		}
+		for deviceClass := range hostClasses {
+			selectedHosts[deviceClass]++
+		}
+		osds = append(osds, hosts[hostName]...)
+	}
+
+	slices.Sort(osds)
+	return osds
+}
+
+// checkUpgradeRegression returns the reason and a message when the cluster health is in error, when
+// slow ops are reported, or when a daemon crashed since the upgrade started
+func checkUpgradeRegression(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, status cephclient.CephStatus, since *metav1.Time) (string, string, error) {
+	if status.Health.Status == "HEALTH_ERR" {
+		checks := []string{}
+		for code, check := range status.Health.Checks {
+			if check.Severity == "HEALTH_ERR" {
+				checks = append(checks, fmt.Sprintf("%s: %s", code, check.Summary.Message))
+			}
+		}
+		slices.Sort(checks)
+		return upgradeHaltHealthError, fmt.Sprintf("cluster health is HEALTH_ERR. %s", strings.Join(checks, "; ")), nil
+	}
+
+	if check, ok := status.Health.Checks[slowOpsHealthCheck]; ok {
+		return upgradeHaltSlowOps, check.Summary.Message, nil
+	}
+
+	crashes, err := cephclient.GetCrashList(context, clusterInfo)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to list crash reports")
+	}
+	for _, crash := range crashes {
+		crashTime, ok := cephclient.ParseCrashTimestamp(crash.Timestamp)
+		if !ok || since == nil || crashTime.Before(since.Time) {
+			continue
+		}
+		return upgradeHaltDaemonCrash, fmt.Sprintf("%s crashed at %s (crash %q)", crash.Entity, crash.Timestamp, crash.ID), nil
+	}
+
+	return "", "", nil
+}
+
+func haltCanaryUpgrade(ctx context.Context, clusterdContext *clusterd.Context, nsName types.NamespacedName, reason, message string) (*cephv1.UpgradeStatus, error) {
+	log.NamedError(nsName, logger, "halting canary upgrade (%s): %s", reason, message)
+	return updateUpgradeStatus(ctx, clusterdContext, nsName, func(s *cephv1.UpgradeStatus) {
+		s.Phase = cephv1.UpgradePhaseHalted
+		s.Reason = reason
+		s.Message = message
+	})
+}
+
+func (c *cluster) getUpgradeStatus() (*cephv1.UpgradeStatus, error) {
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.namespacedName, cephCluster); err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster to check the canary upgrade")
+	}
+	return cephCluster.Status.Upgrade, nil
+}
+
+// updateUpgradeStatus updates the canary upgrade status of the CephCluster and returns the updated status
+func updateUpgradeStatus(ctx context.Context, clusterdContext *clusterd.Context, nsName types.NamespacedName, update func(*cephv1.UpgradeStatus)) (*cephv1.UpgradeStatus, error) {
+	var upgrade *cephv1.UpgradeStatus
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := clusterdContext.Client.Get(ctx, nsName, cephCluster); err != nil {
+			return errors.Wrap(err, "failed to get cluster to update the upgrade status")
+		}
+		if cephCluster.Status.Upgrade == nil {
+			cephCluster.Status.Upgrade = &cephv1.UpgradeStatus{}
+		}
+		update(cephCluster.Status.Upgrade)
+		upgrade = cephCluster.Status.Upgrade
+		return reporting.UpdateStatus(clusterdContext.Client, cephCluster)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update the upgrade status")
+	}
+	return upgrade, nil
+}
+
+// applyCanaryUpgradeHold labels the deployments of the daemons that are not canaries while the upgrade
+// holds their current version, and removes the label from all the deployments otherwise
+func (c *cluster) applyCanaryUpgradeHold(upgrade *cephv1.UpgradeStatus) error {
+	for _, app := range canaryUpgradeApps {
+		listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, app.appName)}
+		deployments, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).List(c.ClusterInfo.Context, listOpts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list %q deployments", app.daemonType)
+		}
+
+		for i := range deployments.Items {
+			d := &deployments.Items[i]
+			daemonID, ok := d.Labels[app.daemonLabel]
+			if !ok {
+				continue
+			}
+			_, held := d.Labels[cephv1.CanaryUpgradeHoldLabelKey]
+			hold := upgrade.HoldsDaemons() && !upgrade.IsCanary(canaryDaemonName(app.daemonType, daemonID))
+			if held == hold {
+				continue
+			}
+
+			if hold {
+				k8sutil.AddLabelToDeployment(cephv1.CanaryUpgradeHoldLabelKey, "true", d)
+			} else {
+				delete(d.Labels, cephv1.CanaryUpgradeHoldLabelKey)
+			}
+			if _, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Update(c.ClusterInfo.Context, d, metav1.UpdateOptions{}); err != nil {
+				return errors.Wrapf(err, "failed to update the canary upgrade label of deployment %q", d.Name)
+			}
+			log.NamespacedDebug(c.Namespace, logger, "set canary upgrade hold of deployment %q to %t", d.Name, hold)
+		}
+	}
+	return nil
+}
+
+// canaryDaemonName returns the name of a daemon in the canary upgrade status, e.g. "osd.3"
+func canaryDaemonName(daemonType, daemonID string) string {
+	return fmt.Sprintf("%s.%s", daemonType, daemonID)
+}
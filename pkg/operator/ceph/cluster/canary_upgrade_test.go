@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	rookclient "github.com/rook/rook/pkg/client/clientset/versioned/fake"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const canaryTestOSDTree = `{"nodes":[
+	{"id":-1,"name":"default","type":"root","children":[-3,-5,-7]},
+	{"id":-3,"name":"node-b","type":"host","children":[2,3]},
+	{"id":-5,"name":"node-a","type":"host","children":[0,1]},
+	{"id":-7,"name":"node-c","type":"host","children":[4]},
+	{"id":0,"name":"osd.0","type":"osd","device_class":"hdd"},
+	{"id":1,"name":"osd.1","type":"osd","device_class":"hdd"},
+	{"id":2,"name":"osd.2","type":"osd","device_class":"ssd"},
+	{"id":3,"name":"osd.3","type":"osd","device_class":"hdd"},
+	{"id":4,"name":"osd.4","type":"osd","device_class":"ssd"}],"stray":[]}`
+
+func TestSelectCanaryOSDs(t *testing.T) {
+	var tree cephclient.OsdTree
+	require.NoError(t, json.Unmarshal([]byte(canaryTestOSDTree), &tree))
+
+	// node-a is the first hdd host, node-b the first ssd host
+	assert.Equal(t, []int{0, 1, 2, 3}, selectCanaryOSDs(tree, 1))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, selectCanaryOSDs(tree, 2))
+	assert.Empty(t, selectCanaryOSDs(tree, 0))
+}
+
+func TestCheckUpgradeRegression(t *testing.T) {
+	since := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	crashes := "[]"
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "crash" && args[1] == "ls" {
+				return crashes, nil
+			}
+			return "", nil
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := cephclient.AdminTestClusterInfo("ns")
+
+	t.Run("healthy", func(t *testing.T) {
+		status := cephclient.CephStatus{Health: cephclient.HealthStatus{Status: "HEALTH_WARN"}}
+		reason, _, err := checkUpgradeRegression(context, clusterInfo, status, &since)
+		assert.NoError(t, err)
+		assert.Empty(t, reason)
+	})
+
+	t.Run("health error", func(t *testing.T) {
+		status := cephclient.CephStatus{Health: cephclient.HealthStatus{Status: "HEALTH_ERR", Checks: map[string]cephclient.CheckMessage{
+			"MON_DOWN": {Severity: "HEALTH_ERR", Summary: cephclient.Summary{Message: "1/3 mons down"}},
+		}}}
+		reason, message, err := checkUpgradeRegression(context, clusterInfo, status, &since)
+		assert.NoError(t, err)
+		assert.Equal(t, upgradeHaltHealthError, reason)
+		assert.Contains(t, message, "MON_DOWN: 1/3 mons down")
+	})
+
+	t.Run("slow ops", func(t *testing.T) {
+		status := cephclient.CephStatus{Health: cephclient.HealthStatus{Status: "HEALTH_WARN", Checks: map[string]cephclient.CheckMessage{
+			slowOpsHealthCheck: {Severity: "HEALTH_WARN", Summary: cephclient.Summary{Message: "3 slow ops"}},
+		}}}
+		reason, message, err := checkUpgradeRegression(context, clusterInfo, status, &since)
+		assert.NoError(t, err)
+		assert.Equal(t, upgradeHaltSlowOps, reason)
+		assert.Equal(t, "3 slow ops", message)
+	})
+
+	t.Run("crash before the upgrade", func(t *testing.T) {
+		crashes = `[{"crash_id":"old","entity_name":"osd.1","timestamp":"2025-12-31T23:00:00.000000Z"}]`
+		status := cephclient.CephStatus{Health: cephclient.HealthStatus{Status: "HEALTH_OK"}}
+		reason, _, err := checkUpgradeRegression(context, clusterInfo, status, &since)
+		assert.NoError(t, err)
+		assert.Empty(t, reason)
+	})
+
+	t.Run("crash during the upgrade", func(t *testing.T) {
+		crashes = `[{"crash_id":"new","entity_name":"osd.2","timestamp":"2026-01-01 01:00:00.000000Z"}]`
+		status := cephclient.CephStatus{Health: cephclient.HealthStatus{Status: "HEALTH_OK"}}
+		reason, message, err := checkUpgradeRegression(context, clusterInfo, status, &since)
+		assert.NoError(t, err)
+		assert.Equal(t, upgradeHaltDaemonCrash, reason)
+		assert.Contains(t, message, "osd.2 crashed")
+	})
+}
+
+func TestReconcileCanaryUpgrade(t *testing.T) {
+	ns := "rook-ceph"
+	nsName := types.NamespacedName{Namespace: ns, Name: "my-cluster"}
+	ctx := context.TODO()
+
+	newDeployment := func(name, app, daemonLabel, daemonID string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels:    map[string]string{k8sutil.AppAttr: app, daemonLabel: daemonID},
+		}}
+	}
+
+	healthStatus := `{"health":{"status":"HEALTH_OK","checks":{}}}`
+	newTestCluster := func(t *testing.T) *cluster {
+		cephCluster := &cephv1.CephCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: ns},
+			Spec: cephv1.ClusterSpec{
+				CephVersion: cephv1.CephVersionSpec{Image: "quay.io/ceph/ceph:v20.2.1"},
+				UpgradeStrategy: &cephv1.UpgradeStrategySpec{Canary: &cephv1.CanaryUpgradeSpec{
+					SoakTime: &metav1.Duration{Duration: time.Hour},
+				}},
+			},
+		}
+		s := scheme.Scheme
+		s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephClusterList{})
+		cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(cephCluster).Build()
+
+		clientset := k8sfake.NewClientset(
+			newDeployment("rook-ceph-mon-a", "rook-ceph-mon", "mon", "a"),
+			newDeployment("rook-ceph-mon-b", "rook-ceph-mon", "mon", "b"),
+			newDeployment("rook-ceph-mgr-a", "rook-ceph-mgr", "mgr", "a"),
+			newDeployment("rook-ceph-osd-0", "rook-ceph-osd", "ceph-osd-id", "0"),
+			newDeployment("rook-ceph-osd-4", "rook-ceph-osd", "ceph-osd-id", "4"),
+			newDeployment("rook-ceph-rgw-store-a-a", "rook-ceph-rgw", "rgw", "store-a"),
+			newDeployment("rook-ceph-rgw-store-b-a", "rook-ceph-rgw", "rgw", "store-b"),
+		)
+		rookClientset := rookclient.NewSimpleClientset(
+			&cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "store-b", Namespace: ns}},
+			&cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "store-a", Namespace: ns}},
+		)
+
+		run := func(args ...string) (string, error) {
+			switch args[0] {
+			case "osd":
+				return canaryTestOSDTree, nil
+			case "status":
+				return healthStatus, nil
+			case "crash":
+				return "[]", nil
+			}
+			return "", nil
+		}
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+				return run(args...)
+			},
+			MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+				return run(args...)
+			},
+		}
+
+		clusterInfo := cephclient.AdminTestClusterInfo(ns)
+		clusterInfo.Context = ctx
+		return &cluster{
+			ClusterInfo:    clusterInfo,
+			Namespace:      ns,
+			Spec:           &cephCluster.Spec,
+			namespacedName: nsName,
+			isUpgrade:      true,
+			context: &clusterd.Context{
+				Client:        cl,
+				Clientset:     clientset,
+				RookClientset: rookClientset,
+				Executor:      executor,
+			},
+		}
+	}
+
+	heldDeployments := func(t *testing.T, c *cluster) []string {
+		deployments, err := c.context.Clientset.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{LabelSelector: cephv1.CanaryUpgradeHoldLabelKey})
+		require.NoError(t, err)
+		held := []string{}
+		for _, d := range deployments.Items {
+			held = append(held, d.Name)
+		}
+		return held
+	}
+
+	upgradeStatus := func(t *testing.T, c *cluster) *cephv1.UpgradeStatus {
+		upgrade, err := c.getUpgradeStatus()
+		require.NoError(t, err)
+		return upgrade
+	}
+
+	t.Run("canary upgrade continues after the soak period", func(t *testing.T) {
+		c := newTestCluster(t)
+
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		upgrade := upgradeStatus(t, c)
+		assert.Equal(t, cephv1.UpgradePhaseCanary, upgrade.Phase)
+		assert.Equal(t, "quay.io/ceph/ceph:v20.2.1", upgrade.Image)
+		assert.Equal(t, []string{"mon.a", "osd.0", "osd.1", "osd.2", "osd.3", "rgw.store-a"}, upgrade.CanaryDaemons)
+		assert.ElementsMatch(t, []string{"rook-ceph-mon-b", "rook-ceph-mgr-a", "rook-ceph-osd-4", "rook-ceph-rgw-store-b-a"}, heldDeployments(t, c))
+
+		// the canary daemons are upgraded, the soak period starts
+		require.NoError(t, c.completeCanaryStage())
+		upgrade = upgradeStatus(t, c)
+		assert.Equal(t, cephv1.UpgradePhaseSoaking, upgrade.Phase)
+		assert.NotNil(t, upgrade.SoakStartTime)
+		assert.Equal(t, time.Hour, c.canaryUpgradeRequeue)
+
+		// the soak period is not over
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		assert.Equal(t, cephv1.UpgradePhaseSoaking, upgradeStatus(t, c).Phase)
+		assert.Positive(t, c.canaryUpgradeRequeue)
+		assert.Len(t, heldDeployments(t, c), 4)
+
+		// the soak period is over without regression
+		c.Spec.UpgradeStrategy.Canary.SoakTime = &metav1.Duration{}
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		assert.Equal(t, cephv1.UpgradePhaseContinuing, upgradeStatus(t, c).Phase)
+		assert.Zero(t, c.canaryUpgradeRequeue)
+		assert.Empty(t, heldDeployments(t, c))
+
+		require.NoError(t, c.completeCanaryStage())
+		assert.Equal(t, cephv1.UpgradePhaseCompleted, upgradeStatus(t, c).Phase)
+
+		// the upgrade is not started again for the same image
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		assert.Equal(t, cephv1.UpgradePhaseCompleted, upgradeStatus(t, c).Phase)
+	})
+
+	t.Run("canary upgrade halts on slow ops", func(t *testing.T) {
+		c := newTestCluster(t)
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		require.NoError(t, c.completeCanaryStage())
+
+		healthStatus = `{"health":{"status":"HEALTH_WARN","checks":{"SLOW_OPS":{"severity":"HEALTH_WARN","summary":{"message":"2 slow ops, oldest one blocked for 40 sec"}}}}}`
+		defer func() { healthStatus = `{"health":{"status":"HEALTH_OK","checks":{}}}` }()
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		upgrade := upgradeStatus(t, c)
+		assert.Equal(t, cephv1.UpgradePhaseHalted, upgrade.Phase)
+		assert.Equal(t, upgradeHaltSlowOps, upgrade.Reason)
+		assert.Equal(t, "2 slow ops, oldest one blocked for 40 sec", upgrade.Message)
+		assert.Len(t, heldDeployments(t, c), 4)
+
+		// the halted upgrade keeps the daemons held
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		assert.Equal(t, cephv1.UpgradePhaseHalted, upgradeStatus(t, c).Phase)
+		assert.Len(t, heldDeployments(t, c), 4)
+
+		// disabling the canary upgrade releases the daemons
+		c.Spec.UpgradeStrategy = nil
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		assert.Equal(t, cephv1.UpgradePhaseContinuing, upgradeStatus(t, c).Phase)
+		assert.Empty(t, heldDeployments(t, c))
+
+		// the upgrade completes once all the daemons are upgraded
+		require.NoError(t, c.completeCanaryStage())
+		assert.Equal(t, cephv1.UpgradePhaseCompleted, upgradeStatus(t, c).Phase)
+	})
+
+	t.Run("no canary daemons", func(t *testing.T) {
+		c := newTestCluster(t)
+		c.Spec.UpgradeStrategy.Canary = &cephv1.CanaryUpgradeSpec{Mons: new(0), OSDHostsPerDeviceClass: new(0), RGWs: new(0)}
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		assert.Equal(t, cephv1.UpgradePhaseContinuing, upgradeStatus(t, c).Phase)
+		assert.Empty(t, heldDeployments(t, c))
+	})
+
+	t.Run("no upgrade", func(t *testing.T) {
+		c := newTestCluster(t)
+		c.isUpgrade = false
+		require.NoError(t, c.reconcileCanaryUpgrade())
+		assert.Nil(t, upgradeStatus(t, c))
+		assert.Empty(t, heldDeployments(t, c))
+	})
+}
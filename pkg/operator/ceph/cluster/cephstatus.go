@@ -144,6 +144,38 @@ func (c *cephStatusChecker) checkStatus(ctx context.Context) {
 	}
 
 	c.configureHealthSettings(status)
+	c.checkCanaryUpgrade(status)
+}
+
+// checkCanaryUpgrade halts a canary upgrade that is in its soak period if the cluster shows a regression
+func (c *cephStatusChecker) checkCanaryUpgrade(status cephclient.CephStatus) {
+	clusterName := c.clusterInfo.NamespacedName()
+	cephCluster, err := c.context.RookClientset.CephV1().CephClusters(clusterName.Namespace).Get(c.clusterInfo.Context, clusterName.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamespacedDebug(c.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		log.NamespacedError(c.clusterInfo.Namespace, logger, "failed to retrieve ceph cluster %q to check the canary upgrade. %v", clusterName.Name, err)
+		return
+	}
+
+	upgrade := cephCluster.Status.Upgrade
+	if cephCluster.Spec.CanaryUpgrade() == nil || upgrade == nil || upgrade.Phase != cephv1.UpgradePhaseSoaking {
+		return
+	}
+
+	reason, message, err := checkUpgradeRegression(c.context, c.clusterInfo, status, upgrade.StartTime)
+	if err != nil {
+		log.NamespacedError(c.clusterInfo.Namespace, logger, "failed to check the cluster for regressions during the canary upgrade. %v", err)
+		return
+	}
+	if reason == "" {
+		return
+	}
+	if _, err := haltCanaryUpgrade(c.clusterInfo.Context, c.context, clusterName, reason, message); err != nil {
+		log.NamespacedError(c.clusterInfo.Namespace, logger, "failed to halt the canary upgrade. %v", err)
+	}
 }
 
 func (c *cephStatusChecker) configureHealthSettings(status cephclient.CephStatus) {
@@ -0,0 +1,415 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// cephxInventoryConfigMapName is the name of the configmap holding the inventory of every CephX key
+	cephxInventoryConfigMapName = "rook-ceph-cephx-inventory"
+	// cephxInventoryConfigMapKey is the key of the inventory in the configmap, as a JSON list of entities
+	cephxInventoryConfigMapKey = "entities.json"
+	// maxOverdueEntitiesInStatus caps the overdue keys listed in the CephCluster status, which must
+	// remain small enough to be updated frequently
+	maxOverdueEntitiesInStatus = 100
+)
+
+// defaultCephxInventoryInterval is the interval of the inventories of the CephX keys
+var defaultCephxInventoryInterval = time.Hour
+
+// cephxInventory reports the CephX keys of the cluster in a configmap, with a summary in the CephCluster
+// status, and flags the keys older than the maximum key age to be rotated by the reconciles of the
+// resources managing them
+type cephxInventory struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	interval    time.Duration
+}
+
+// cephxKeyOwner is the resource managing a CephX key, with the key status of the resource
+type cephxKeyOwner struct {
+	kind      string
+	name      string
+	component string
+	status    cephv1.CephxStatus
+}
+
+// cephxKeyOwners are the resources that may manage the CephX keys of the cluster
+type cephxKeyOwners struct {
+	cephCluster       *cephv1.CephCluster
+	filesystems       cephv1.CephFilesystemList
+	objectStores      cephv1.CephObjectStoreList
+	rbdMirrors        cephv1.CephRBDMirrorList
+	filesystemMirrors cephv1.CephFilesystemMirrorList
+	nfses             cephv1.CephNFSList
+	clients           cephv1.CephClientList
+	nvmeofGateways    cephv1.CephNVMeOFGatewayList
+	subVolumes        cephv1.CephFilesystemSubVolumeList
+}
+
+func newCephxInventory(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, spec *cephv1.CephxInventorySpec) *cephxInventory {
+	i := &cephxInventory{
+		context:     context,
+		clusterInfo: clusterInfo,
+		interval:    defaultCephxInventoryInterval,
+	}
+	if spec != nil && spec.Interval != nil && spec.Interval.Duration > 0 {
+		i.interval = spec.Interval.Duration
+	}
+	return i
+}
+
+// Start inventories the CephX keys when it starts and at set intervals after that, until the monitoring
+// is cancelled or restarted with other settings
+func (i *cephxInventory) Start(monitoringRoutines *sync.Map, daemon string) {
+	v, ok := monitoringRoutines.Load(daemon)
+	if !ok {
+		log.NamespacedInfo(i.clusterInfo.Namespace, logger, "ceph cluster %q has been deleted. stopping the cephx key inventory", i.clusterInfo.Namespace)
+		return
+	}
+	health := v.(*opcontroller.ClusterHealth)
+
+	// the operator may have restarted since the last inventory, which can be up to an interval ago
+	i.inventoryKeys()
+	for {
+		select {
+		case <-time.After(i.interval):
+			i.inventoryKeys()
+
+		case <-health.InternalCtx.Done():
+			log.NamespacedInfo(i.clusterInfo.Namespace, logger, "stopping the cephx key inventory in namespace %q", i.clusterInfo.Namespace)
+			// the monitoring may already be restarted with other settings, in which case the keys to
+			// rotate are updated by the new inventory
+			if monitoringRoutines.CompareAndDelete(daemon, health) {
+				// the keys are only rotated while the inventory is running
+				keyring.SetOverdueCephxKeysForCluster(i.clusterInfo.Namespace, nil)
+			}
+			return
+		}
+	}
+}
+
+func (i *cephxInventory) inventoryKeys() {
+	log.NamespacedDebug(i.clusterInfo.Namespace, logger, "taking the inventory of the cephx keys")
+	if err := i.checkKeys(); err != nil {
+		log.NamespacedError(i.clusterInfo.Namespace, logger, "failed to take the inventory of the cephx keys. %v", err)
+	}
+}
+
+// checkKeys updates the inventory and the keys to rotate, and requests the reconciles of the resources
+// whose keys are overdue
+func (i *cephxInventory) checkKeys() error {
+	owners, err := i.listKeyOwners()
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamespacedDebug(i.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return err
+	}
+	spec := owners.cephCluster.Spec.Security.CephX.Inventory
+	if spec == nil {
+		return nil
+	}
+
+	keys, err := cephclient.AuthDumpKeys(i.context, i.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to list the cephx keys")
+	}
+
+	previous, err := i.loadInventory()
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	maxKeyAge := time.Duration(spec.MaxKeyAgeDays) * 24 * time.Hour
+
+	// the mon key is not listed with the other keys
+	entities := []cephv1.CephxEntityStatus{cephxEntityStatus(owners, "mon.", owners.cephCluster.Status.Cephx.Mon.KeyType, previous, now, maxKeyAge)}
+	for _, key := range keys.Data.Secrets {
+		entity := key.Entity.TypeStr + "." + key.Entity.Id
+		entities = append(entities, cephxEntityStatus(owners, entity, cephv1.CephxKeyType(key.Auth.Key.TypeStr), previous, now, maxKeyAge))
+	}
+	slices.SortFunc(entities, func(a, b cephv1.CephxEntityStatus) int { return strings.Compare(a.Entity, b.Entity) })
+
+	// the keys of an owner are rotated until the key generation in its status is past the newest overdue generation
+	overdue := map[string]uint32{}
+	var overdueEntities []cephv1.CephxEntityStatus
+	reconciles := map[string][]string{}
+	for _, entity := range entities {
+		if !entity.Overdue {
+			continue
+		}
+		overdueEntities = append(overdueEntities, entity)
+		kind, name, _ := strings.Cut(entity.Owner, "/")
+		if kind == "CephFilesystemSubVolume" {
+			// the keys of the subvolumes are reported, but their controller does not rotate them
+			continue
+		}
+		owner := keyring.CephxKeyOwner(kind, name, entity.Component)
+		overdue[owner] = max(overdue[owner], entity.KeyGeneration)
+		if !slices.Contains(reconciles[kind], name) {
+			reconciles[kind] = append(reconciles[kind], name)
+		}
+	}
+	if len(overdue) > 0 {
+		log.NamespacedInfo(i.clusterInfo.Namespace, logger, "cephx keys older than %d days will be rotated at the next reconcile of %v", spec.MaxKeyAgeDays, slices.Sorted(maps.Keys(overdue)))
+	}
+
+	if err := i.saveInventory(entities); err != nil {
+		return err
+	}
+	status := &cephv1.CephxInventoryStatus{
+		LastChecked: &now,
+		ConfigMap:   cephxInventoryConfigMapName,
+		Keys:        len(entities),
+		OverdueKeys: len(overdueEntities),
+		Overdue:     overdueEntities[:min(len(overdueEntities), maxOverdueEntitiesInStatus)],
+	}
+	if err := i.updateInventoryStatus(status); err != nil {
+		return err
+	}
+	if maxKeyAge == 0 {
+		keyring.SetOverdueCephxKeysForCluster(i.clusterInfo.Namespace, nil)
+		return nil
+	}
+	keyring.SetOverdueCephxKeysForCluster(i.clusterInfo.Namespace, overdue)
+
+	// the keys are rotated by the reconciles of their owners, which may otherwise not happen for a long time
+	for _, kind := range slices.Sorted(maps.Keys(reconciles)) {
+		for _, name := range reconciles[kind] {
+			if !opcontroller.TriggerReconcile(kind, types.NamespacedName{Namespace: i.clusterInfo.Namespace, Name: name}) {
+				log.NamespacedWarning(i.clusterInfo.Namespace, logger, "failed to request the reconcile of %s %q to rotate its overdue cephx keys, its keys will be rotated at its next reconcile", kind, name)
+			}
+		}
+	}
+	return nil
+}
+
+// loadInventory returns the entities of the last inventory, which hold when the current key generations
+// were first inventoried
+func (i *cephxInventory) loadInventory() ([]cephv1.CephxEntityStatus, error) {
+	cm, err := i.context.Clientset.CoreV1().ConfigMaps(i.clusterInfo.Namespace).Get(i.clusterInfo.Context, cephxInventoryConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get the cephx key inventory configmap %q", cephxInventoryConfigMapName)
+	}
+
+	var entities []cephv1.CephxEntityStatus
+	if err := json.Unmarshal([]byte(cm.Data[cephxInventoryConfigMapKey]), &entities); err != nil {
+		// the age of the keys is counted again from this inventory
+		log.NamespacedWarning(i.clusterInfo.Namespace, logger, "ignoring the invalid cephx key inventory in configmap %q. %v", cephxInventoryConfigMapName, err)
+		return nil, nil
+	}
+	return entities, nil
+}
+
+func (i *cephxInventory) saveInventory(entities []cephv1.CephxEntityStatus) error {
+	inventory, err := json.Marshal(entities)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the cephx key inventory")
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cephxInventoryConfigMapName,
+			Namespace: i.clusterInfo.Namespace,
+		},
+		Data: map[string]string{cephxInventoryConfigMapKey: string(inventory)},
+	}
+	if err := i.clusterInfo.OwnerInfo.SetControllerReference(cm); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference to configmap %q", cm.Name)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(i.clusterInfo.Context, i.context.Clientset, cm); err != nil {
+		return errors.Wrap(err, "failed to save the cephx key inventory")
+	}
+	return nil
+}
+
+// cephxEntityStatus returns the inventory of a key. The age of a key is counted from the first inventory of
+// its current generation, as Ceph does not report when a key was created.
+func cephxEntityStatus(owners *cephxKeyOwners, entity string, keyType cephv1.CephxKeyType, previous []cephv1.CephxEntityStatus, now metav1.Time, maxKeyAge time.Duration) cephv1.CephxEntityStatus {
+	status := cephv1.CephxEntityStatus{Entity: entity, KeyType: keyType}
+	owner := owners.entityOwner(entity)
+	if owner == nil {
+		return status
+	}
+	status.Owner = owner.kind + "/" + owner.name
+	status.Component = owner.component
+	status.KeyGeneration = owner.status.KeyGeneration
+	status.KeySince = &now
+
+	j := slices.IndexFunc(previous, func(p cephv1.CephxEntityStatus) bool { return p.Entity == entity })
+	if j >= 0 && previous[j].KeySince != nil && previous[j].Owner == status.Owner && previous[j].KeyGeneration == status.KeyGeneration {
+		status.KeySince = previous[j].KeySince
+	}
+	status.Overdue = maxKeyAge > 0 && now.Sub(status.KeySince.Time) > maxKeyAge
+	return status
+}
+
+func (i *cephxInventory) listKeyOwners() (*cephxKeyOwners, error) {
+	ctx := i.clusterInfo.Context
+	owners := &cephxKeyOwners{cephCluster: &cephv1.CephCluster{}}
+	if err := i.context.Client.Get(ctx, i.clusterInfo.NamespacedName(), owners.cephCluster); err != nil {
+		return nil, errors.Wrap(err, "failed to get the CephCluster to take the inventory of the cephx keys")
+	}
+
+	inNamespace := client.InNamespace(i.clusterInfo.Namespace)
+	lists := []client.ObjectList{&owners.filesystems, &owners.objectStores, &owners.rbdMirrors, &owners.filesystemMirrors, &owners.nfses, &owners.clients, &owners.nvmeofGateways, &owners.subVolumes}
+	for _, list := range lists {
+		if err := i.context.Client.List(ctx, list, inNamespace); err != nil {
+			return nil, errors.Wrapf(err, "failed to list the %T to find the owners of the cephx keys", list)
+		}
+	}
+	return owners, nil
+}
+
+// entityOwner returns the resource managing the key of the entity, or nil if the key is not managed by Rook
+func (o *cephxKeyOwners) entityOwner(entity string) *cephxKeyOwner {
+	clusterOwner := func(component string, status cephv1.CephxStatus) *cephxKeyOwner {
+		return &cephxKeyOwner{kind: "CephCluster", name: o.cephCluster.Name, component: component, status: status}
+	}
+	clusterStatus := o.cephCluster.Status.Cephx
+
+	daemonType, daemonID, _ := strings.Cut(entity, ".")
+	switch daemonType {
+	case "mon":
+		return clusterOwner(keyring.CephxComponentMon, clusterStatus.Mon)
+	case "mgr":
+		return clusterOwner(keyring.CephxComponentMgr, clusterStatus.Mgr)
+	case "osd":
+		// the cluster status holds the oldest key generation of the OSDs
+		return clusterOwner(keyring.CephxComponentOSD, clusterStatus.OSD)
+	case "mds":
+		// the mds daemons are named "<filesystem>-<letter>"
+		j := opcontroller.LongestPrefixMatch(daemonID, len(o.filesystems.Items), func(j int) string { return o.filesystems.Items[j].Name + "-" })
+		if j >= 0 && o.filesystems.Items[j].Status != nil {
+			fs := &o.filesystems.Items[j]
+			return &cephxKeyOwner{kind: "CephFilesystem", name: fs.Name, status: fs.Status.Cephx.Daemon}
+		}
+		return nil
+	}
+	if daemonType != "client" {
+		return nil
+	}
+
+	switch {
+	case daemonID == "admin":
+		return clusterOwner(keyring.CephxComponentAdmin, clusterStatus.Admin)
+	case strings.HasPrefix(daemonID, "csi-"):
+		return clusterOwner(keyring.CephxComponentCSI, clusterStatus.CSI.CephxStatus)
+	case daemonID == "rbd-mirror-peer":
+		return clusterOwner(keyring.CephxComponentRBDMirrorPeer, clusterStatus.RBDMirrorPeer)
+	case daemonID == "crash":
+		return clusterOwner(keyring.CephxComponentCrashCollector, clusterStatus.CrashCollector)
+	case daemonID == "ceph-exporter":
+		return clusterOwner(keyring.CephxComponentCephExporter, clusterStatus.CephExporter)
+
+	case strings.HasPrefix(daemonID, "rgw."):
+		// the rgw users are named "client.rgw.<store>.<letter>" with the dashes replaced by dots
+		rgwID := strings.TrimPrefix(daemonID, "rgw.")
+		j := opcontroller.LongestPrefixMatch(rgwID, len(o.objectStores.Items), func(j int) string { return strings.ReplaceAll(o.objectStores.Items[j].Name, "-", ".") + "." })
+		if j >= 0 && o.objectStores.Items[j].Status != nil {
+			store := &o.objectStores.Items[j]
+			return &cephxKeyOwner{kind: "CephObjectStore", name: store.Name, status: store.Status.Cephx.Daemon}
+		}
+
+	case strings.HasPrefix(daemonID, "rbd-mirror."):
+		// a single rbd mirror resource is supported per cluster
+		if len(o.rbdMirrors.Items) > 0 && o.rbdMirrors.Items[0].Status != nil {
+			mirror := &o.rbdMirrors.Items[0]
+			return &cephxKeyOwner{kind: "CephRBDMirror", name: mirror.Name, status: mirror.Status.Cephx.Daemon}
+		}
+
+	case daemonID == "fs-mirror":
+		if len(o.filesystemMirrors.Items) > 0 && o.filesystemMirrors.Items[0].Status != nil {
+			mirror := &o.filesystemMirrors.Items[0]
+			return &cephxKeyOwner{kind: "CephFilesystemMirror", name: mirror.Name, status: mirror.Status.Cephx.Daemon}
+		}
+
+	case strings.HasPrefix(daemonID, "nfs-ganesha."):
+		// the nfs users are named "client.nfs-ganesha.<nfs>.<letter>"
+		nfsID := strings.TrimPrefix(daemonID, "nfs-ganesha.")
+		j := opcontroller.LongestPrefixMatch(nfsID, len(o.nfses.Items), func(j int) string { return o.nfses.Items[j].Name + "." })
+		if j >= 0 && o.nfses.Items[j].Status != nil {
+			nfs := &o.nfses.Items[j]
+			return &cephxKeyOwner{kind: "CephNFS", name: nfs.Name, status: nfs.Status.Cephx.Daemon}
+		}
+
+	case strings.HasPrefix(daemonID, "nvmeof."):
+		// the nvmeof users are named "client.nvmeof.<gateway>-<id>" after the gateway daemons
+		nvmeofID := strings.TrimPrefix(daemonID, "nvmeof.")
+		j := opcontroller.LongestPrefixMatch(nvmeofID, len(o.nvmeofGateways.Items), func(j int) string { return o.nvmeofGateways.Items[j].Name + "-" })
+		if j >= 0 && o.nvmeofGateways.Items[j].Status != nil {
+			gateway := &o.nvmeofGateways.Items[j]
+			return &cephxKeyOwner{kind: "CephNVMeOFGateway", name: gateway.Name, status: gateway.Status.Cephx.Daemon}
+		}
+
+	case strings.HasPrefix(daemonID, "fs-subvolume-"):
+		// the subvolume users are named "client.fs-subvolume-<subvolume>". Their key is never rotated,
+		// so the key generation is always 0.
+		subVolumeName := strings.TrimPrefix(daemonID, "fs-subvolume-")
+		j := slices.IndexFunc(o.subVolumes.Items, func(s cephv1.CephFilesystemSubVolume) bool { return s.Name == subVolumeName })
+		if j >= 0 {
+			return &cephxKeyOwner{kind: "CephFilesystemSubVolume", name: subVolumeName}
+		}
+
+	default:
+		j := slices.IndexFunc(o.clients.Items, func(c cephv1.CephClient) bool { return c.Name == daemonID })
+		if j >= 0 && o.clients.Items[j].Status != nil {
+			cephClient := &o.clients.Items[j]
+			return &cephxKeyOwner{kind: "CephClient", name: cephClient.Name, status: cephClient.Status.Cephx}
+		}
+	}
+	return nil
+}
+
+func (i *cephxInventory) updateInventoryStatus(inventory *cephv1.CephxInventoryStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := i.context.Client.Get(i.clusterInfo.Context, i.clusterInfo.NamespacedName(), cephCluster); err != nil {
+			return errors.Wrap(err, "failed to get the CephCluster to update the cephx key inventory")
+		}
+		cephCluster.Status.Cephx.Inventory = inventory
+		return reporting.UpdateStatus(i.context.Client, cephCluster)
+	})
+	return errors.Wrap(err, "failed to update the cephx key inventory in the CephCluster status")
+}
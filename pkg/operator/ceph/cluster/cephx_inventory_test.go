@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
+	"github.com/rook/rook/pkg/operator/ceph/version"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCephxInventory(t *testing.T) {
+	namespace := "rook-ceph"
+	dumpKeys := `{"data":{"secrets":[
+		{"entity":{"type_str":"osd","id":"0"},"auth":{"key":{"type_str":"aes256k"}}},
+		{"entity":{"type_str":"mgr","id":"a"},"auth":{"key":{"type_str":"aes"}}},
+		{"entity":{"type_str":"mds","id":"my-fs-a"},"auth":{"key":{"type_str":"aes256k"}}},
+		{"entity":{"type_str":"client","id":"admin"},"auth":{"key":{"type_str":"aes"}}},
+		{"entity":{"type_str":"client","id":"csi-rbd-node.2"},"auth":{"key":{"type_str":"aes256k"}}},
+		{"entity":{"type_str":"client","id":"rgw.my.store.a"},"auth":{"key":{"type_str":"aes256k"}}},
+		{"entity":{"type_str":"client","id":"app"},"auth":{"key":{"type_str":"aes"}}},
+		{"entity":{"type_str":"client","id":"nvmeof.my-gw-0"},"auth":{"key":{"type_str":"aes256k"}}},
+		{"entity":{"type_str":"client","id":"fs-subvolume-my-subvol"},"auth":{"key":{"type_str":"aes256k"}}},
+		{"entity":{"type_str":"client","id":"legacy-app"},"auth":{"key":{"type_str":"aes"}}}
+	]}}`
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "auth" && args[1] == "dump-keys" {
+				return dumpKeys, nil
+			}
+			return "", errors.Errorf("unexpected ceph command %v", args)
+		},
+	}
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: namespace}}
+	cephCluster.Spec.Security.CephX.Inventory = &cephv1.CephxInventorySpec{MaxKeyAgeDays: 30}
+	cephCluster.Status.Cephx = cephv1.ClusterCephxStatus{
+		Admin: cephv1.CephxStatus{KeyGeneration: 1},
+		Mon:   cephv1.CephxStatus{KeyGeneration: 1, KeyType: cephv1.CephxKeyTypeAes},
+		Mgr:   cephv1.CephxStatus{KeyGeneration: 2},
+		OSD:   cephv1.CephxStatus{KeyGeneration: 1},
+		CSI:   cephv1.CephxStatusWithKeyCount{CephxStatus: cephv1.CephxStatus{KeyGeneration: 2}},
+	}
+	filesystem := &cephv1.CephFilesystem{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-fs", Namespace: namespace},
+		Status:     &cephv1.CephFilesystemStatus{Cephx: cephv1.LocalCephxStatus{Daemon: cephv1.CephxStatus{KeyGeneration: 3}}},
+	}
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: namespace},
+		Status:     &cephv1.ObjectStoreStatus{Cephx: cephv1.LocalCephxStatus{Daemon: cephv1.CephxStatus{KeyGeneration: 1}}},
+	}
+	cephClient := &cephv1.CephClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace},
+		Status:     &cephv1.CephClientStatus{Cephx: cephv1.CephxStatus{KeyGeneration: 1}},
+	}
+	gateway := &cephv1.CephNVMeOFGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-gw", Namespace: namespace},
+		Status:     &cephv1.NVMeOFGatewayStatus{Cephx: cephv1.LocalCephxStatus{Daemon: cephv1.CephxStatus{KeyGeneration: 2}}},
+	}
+	subVolume := &cephv1.CephFilesystemSubVolume{ObjectMeta: metav1.ObjectMeta{Name: "my-subvol", Namespace: namespace}}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cephCluster, filesystem, store, cephClient, gateway, subVolume).WithStatusSubresource(cephCluster).Build()
+
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	clusterInfo.Context = context.TODO()
+	clusterInfo.SetName(cephCluster.Name)
+	clusterInfo.OwnerInfo = k8sutil.NewOwnerInfo(cephCluster, scheme.Scheme)
+	clientset := k8sfake.NewSimpleClientset()
+	inventory := newCephxInventory(&clusterd.Context{Client: cl, Clientset: clientset, Executor: executor}, clusterInfo, cephCluster.Spec.Security.CephX.Inventory)
+	assert.Equal(t, defaultCephxInventoryInterval, inventory.interval)
+	defer keyring.SetOverdueCephxKeysForCluster(namespace, nil)
+
+	getInventory := func() *cephv1.CephxInventoryStatus {
+		c := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(context.TODO(), clusterInfo.NamespacedName(), c))
+		return c.Status.Cephx.Inventory
+	}
+	getEntities := func() []cephv1.CephxEntityStatus {
+		cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), cephxInventoryConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		entities := []cephv1.CephxEntityStatus{}
+		require.NoError(t, json.Unmarshal([]byte(cm.Data[cephxInventoryConfigMapKey]), &entities))
+		return entities
+	}
+	entity := func(entities []cephv1.CephxEntityStatus, name string) cephv1.CephxEntityStatus {
+		for _, e := range entities {
+			if e.Entity == name {
+				return e
+			}
+		}
+		t.Fatalf("entity %q not found in the inventory", name)
+		return cephv1.CephxEntityStatus{}
+	}
+
+	t.Run("first inventory", func(t *testing.T) {
+		require.NoError(t, inventory.checkKeys())
+		status := getInventory()
+		require.NotNil(t, status)
+		assert.NotNil(t, status.LastChecked)
+		assert.Equal(t, cephxInventoryConfigMapName, status.ConfigMap)
+		assert.Equal(t, 11, status.Keys)
+		assert.Zero(t, status.OverdueKeys)
+		assert.Empty(t, status.Overdue)
+
+		cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), cephxInventoryConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Len(t, cm.OwnerReferences, 1)
+		assert.Equal(t, cephCluster.Name, cm.OwnerReferences[0].Name)
+
+		entities := getEntities()
+		names := []string{}
+		for _, e := range entities {
+			names = append(names, e.Entity)
+		}
+		assert.Equal(t, []string{"client.admin", "client.app", "client.csi-rbd-node.2", "client.fs-subvolume-my-subvol", "client.legacy-app", "client.nvmeof.my-gw-0", "client.rgw.my.store.a", "mds.my-fs-a", "mgr.a", "mon.", "osd.0"}, names)
+
+		mon := entity(entities, "mon.")
+		assert.Equal(t, "CephCluster/my-cluster", mon.Owner)
+		assert.Equal(t, "mon", mon.Component)
+		assert.Equal(t, cephv1.CephxKeyTypeAes, mon.KeyType)
+
+		mds := entity(entities, "mds.my-fs-a")
+		assert.Equal(t, "CephFilesystem/my-fs", mds.Owner)
+		assert.Empty(t, mds.Component)
+		assert.Equal(t, uint32(3), mds.KeyGeneration)
+		assert.NotNil(t, mds.KeySince)
+
+		assert.Equal(t, "CephObjectStore/my-store", entity(entities, "client.rgw.my.store.a").Owner)
+		assert.Equal(t, "csi", entity(entities, "client.csi-rbd-node.2").Component)
+		assert.Equal(t, "CephClient/app", entity(entities, "client.app").Owner)
+
+		nvmeof := entity(entities, "client.nvmeof.my-gw-0")
+		assert.Equal(t, "CephNVMeOFGateway/my-gw", nvmeof.Owner)
+		assert.Equal(t, uint32(2), nvmeof.KeyGeneration)
+		subVolume := entity(entities, "client.fs-subvolume-my-subvol")
+		assert.Equal(t, "CephFilesystemSubVolume/my-subvol", subVolume.Owner)
+		assert.NotNil(t, subVolume.KeySince)
+
+		unmanaged := entity(entities, "client.legacy-app")
+		assert.Empty(t, unmanaged.Owner)
+		assert.Nil(t, unmanaged.KeySince)
+		assert.Equal(t, cephv1.CephxKeyTypeAes, unmanaged.KeyType)
+	})
+
+	t.Run("keys older than the maximum age are rotated", func(t *testing.T) {
+		// the mgr and mds keys were first inventoried 31 days ago
+		old := metav1.NewTime(time.Now().Add(-31 * 24 * time.Hour))
+		entities := getEntities()
+		for j := range entities {
+			e := &entities[j]
+			if e.Entity == "mgr.a" || e.Entity == "mds.my-fs-a" || e.Entity == "client.legacy-app" || e.Entity == "client.fs-subvolume-my-subvol" {
+				e.KeySince = &old
+			}
+		}
+		require.NoError(t, inventory.saveInventory(entities))
+
+		require.NoError(t, inventory.checkKeys())
+		status := getInventory()
+		assert.Equal(t, 3, status.OverdueKeys)
+		overdue := []string{}
+		for _, e := range status.Overdue {
+			overdue = append(overdue, e.Entity)
+		}
+		assert.Equal(t, []string{"client.fs-subvolume-my-subvol", "mds.my-fs-a", "mgr.a"}, overdue)
+		entities = getEntities()
+		assert.True(t, entity(entities, "mgr.a").Overdue)
+		assert.True(t, entity(entities, "mds.my-fs-a").Overdue)
+		assert.True(t, entity(entities, "client.fs-subvolume-my-subvol").Overdue)
+		assert.False(t, entity(entities, "client.legacy-app").Overdue)
+		assert.False(t, entity(entities, "osd.0").Overdue)
+
+		running := version.CephVersion{Major: 20, Minor: 2, Extra: 0}
+		owner := keyring.CephxKeyOwner("CephCluster", "my-cluster", keyring.CephxComponentMgr)
+		keyring.SetAllowCephxKeyRotationForCluster(namespace, true)
+		rotate, err := keyring.ShouldRotateCephxKeys(cephv1.CephxConfig{}, running, running, cephv1.CephxStatus{KeyGeneration: 2}, true, namespace, owner)
+		require.NoError(t, err)
+		assert.True(t, rotate)
+		// the key was rotated since the inventory
+		rotate, err = keyring.ShouldRotateCephxKeys(cephv1.CephxConfig{}, running, running, cephv1.CephxStatus{KeyGeneration: 3}, true, namespace, owner)
+		require.NoError(t, err)
+		assert.False(t, rotate)
+		// the subvolume keys are only reported
+		owner = keyring.CephxKeyOwner("CephFilesystemSubVolume", "my-subvol", "")
+		rotate, err = keyring.ShouldRotateCephxKeys(cephv1.CephxConfig{}, running, running, cephv1.CephxStatus{}, true, namespace, owner)
+		require.NoError(t, err)
+		assert.False(t, rotate)
+	})
+
+	t.Run("the age of a rotated key is reset", func(t *testing.T) {
+		c := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(context.TODO(), clusterInfo.NamespacedName(), c))
+		c.Status.Cephx.Mgr.KeyGeneration = 3
+		require.NoError(t, cl.Status().Update(context.TODO(), c))
+
+		require.NoError(t, inventory.checkKeys())
+		status := getInventory()
+		assert.Equal(t, 2, status.OverdueKeys)
+		mgr := entity(getEntities(), "mgr.a")
+		assert.False(t, mgr.Overdue)
+		assert.Equal(t, uint32(3), mgr.KeyGeneration)
+		assert.WithinDuration(t, time.Now(), mgr.KeySince.Time, time.Minute)
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -47,6 +48,7 @@ import (
 	cephver "github.com/rook/rook/pkg/operator/ceph/version"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	"github.com/rook/rook/pkg/util/log"
+	"github.com/rook/rook/pkg/util/tracing"
 	rookversion "github.com/rook/rook/pkg/version"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,6 +79,14 @@ type cluster struct {
 	isUpgrade          bool
 	monitoringRoutines sync.Map
 	observedGeneration int64
+	// canaryUpgradeRequeue is the time to wait before checking the soak period of a canary upgrade again
+	canaryUpgradeRequeue time.Duration
+	// networkMigrationRequeue is the time to wait before checking the daemons migrated to a new network provider again
+	networkMigrationRequeue time.Duration
+	// readdressRequeue is the time to wait before continuing the restart of the daemons on the new mon addresses
+	readdressRequeue time.Duration
+	// connectionBundleRequeue is the time to wait before syncing the connection bundle of the provider cluster again
+	connectionBundleRequeue time.Duration
 }
 
 func newCluster(ctx context.Context, c *cephv1.CephCluster, context *clusterd.Context, ownerInfo *k8sutil.OwnerInfo, rookImage string) *cluster {
@@ -99,7 +109,9 @@ func newCluster(ctx context.Context, c *cephv1.CephCluster, context *clusterd.Co
 	}
 }
 
-func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.CephVersion) error {
+// reconcileCephDaemons runs the orchestration of the daemons. The orchestration of the mons, mgrs and
+// OSDs are traced as child spans of the reconcile span in the given context.
+func (c *cluster) reconcileCephDaemons(ctx context.Context, rookImage string, cephVersion cephver.CephVersion) error {
 	// Create a configmap for overriding ceph config settings
 	// These settings should only be modified by a user after they are initialized
 	err := populateConfigOverrideConfigMap(c.context, c.Namespace, c.ownerInfo, c.clusterMetadata)
@@ -116,9 +128,23 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 		return errors.Wrap(err, "failed to execute actions before reconciling the ceph monitors")
 	}
 
+	// Start or continue a canary upgrade, which holds the current version of the daemons that are not canaries
+	if err := c.reconcileCanaryUpgrade(); err != nil {
+		return errors.Wrap(err, "failed to reconcile the canary upgrade")
+	}
+
+	// Start or continue a migration to a new network provider, where the mons are failed over first
+	if err := c.reconcileNetworkMigration(); err != nil {
+		return errors.Wrap(err, "failed to reconcile the network provider migration")
+	}
+
 	// Start the mon pods
 	controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph Mons")
-	clusterInfo, err := c.mons.Start(c.ClusterInfo, rookImage, cephVersion, *c.Spec)
+	var clusterInfo *client.ClusterInfo
+	err = traceOrchestration(ctx, "mons", func(ctx context.Context) (err error) {
+		clusterInfo, err = c.mons.Start(c.ClusterInfo.WithContext(ctx), rookImage, cephVersion, *c.Spec)
+		return err
+	})
 	if err != nil {
 		return errors.Wrap(err, "failed to start ceph monitors")
 	}
@@ -144,10 +170,27 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 		return errors.Wrap(err, "failed to execute post actions after all the ceph monitors started")
 	}
 
+	// The other daemons are restarted on the new mon addresses after the mons were re-addressed,
+	// before they are reconciled
+	c.readdressRequeue = c.mons.ReaddressRequeue
+	if c.readdressRequeue > 0 {
+		return nil
+	}
+
+	// Hold the other daemons on the previous network until all the mons are reachable on the new network
+	waitForMons, err := c.waitForMonNetworkMigration()
+	if err != nil {
+		return errors.Wrap(err, "failed to check the mons migrated to the new network provider")
+	}
+	if waitForMons {
+		return nil
+	}
+
 	// Start Ceph manager
 	controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph Mgr(s)")
-	mgrs := mgr.New(c.context, c.ClusterInfo, *c.Spec, rookImage)
-	err = mgrs.Start()
+	err = traceOrchestration(ctx, "mgrs", func(ctx context.Context) error {
+		return mgr.New(c.context, c.ClusterInfo.WithContext(ctx), *c.Spec, rookImage).Start()
+	})
 	if err != nil {
 		return errors.Wrap(err, "failed to start ceph mgr")
 	}
@@ -161,8 +204,9 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 
 	// Start the OSDs
 	controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring Ceph OSDs")
-	osds := osd.New(c.context, c.ClusterInfo, *c.Spec, rookImage)
-	err = osds.Start()
+	err = traceOrchestration(ctx, "osds", func(ctx context.Context) error {
+		return osd.New(c.context, c.ClusterInfo.WithContext(ctx), *c.Spec, rookImage).Start()
+	})
 	if err != nil {
 		return errors.Wrap(err, "failed to start ceph osds")
 	}
@@ -185,6 +229,18 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 		return errors.Wrap(err, "failed to set rotating service cephx key type after OSD update")
 	}
 
+	if err := c.completeCanaryStage(); err != nil {
+		return errors.Wrap(err, "failed to update the canary upgrade after the daemons were reconciled")
+	}
+
+	if err := c.completeNetworkMigration(); err != nil {
+		return errors.Wrap(err, "failed to update the network provider migration after the daemons were reconciled")
+	}
+
+	if err := c.reconcileConnectionBundle(); err != nil {
+		return errors.Wrap(err, "failed to publish the connection bundle")
+	}
+
 	log.NamespacedInfo(c.Namespace, logger, "done reconciling ceph cluster")
 
 	// We should be done updating by now
@@ -198,7 +254,17 @@ func (c *cluster) reconcileCephDaemons(rookImage string, cephVersion cephver.Cep
 	return nil
 }
 
-func (c *ClusterController) initializeCluster(cluster *cluster) error {
+// traceOrchestration records the orchestration of a type of daemons as a child span of the reconcile
+// span in the given context. The Ceph commands run with the context passed to orchestrate are
+// recorded as child spans of the orchestration.
+func traceOrchestration(ctx context.Context, daemons string, orchestrate func(ctx context.Context) error) error {
+	ctx, span := tracing.StartChildSpan(ctx, "orchestrate "+daemons, tracing.DaemonsKey.String(daemons))
+	err := orchestrate(ctx)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (c *ClusterController) initializeCluster(ctx context.Context, cluster *cluster) error {
 	// Check if the dataDirHostPath is located in the disallowed paths list
 	cleanDataDirHostPath := path.Clean(cluster.Spec.DataDirHostPath)
 	for _, b := range disallowedHostDirectories {
@@ -210,7 +276,7 @@ func (c *ClusterController) initializeCluster(cluster *cluster) error {
 
 	// Depending on the cluster type choose the correct orchestration
 	if cluster.Spec.External.Enable {
-		err := c.configureExternalCephCluster(cluster)
+		err := c.configureExternalCephCluster(ctx, cluster)
 		if err != nil {
 			controller.UpdateCondition(c.OpManagerCtx, c.context, cluster.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionFalse, cephv1.ClusterProgressingReason, err.Error())
 			return errors.Wrap(err, "failed to configure external ceph cluster")
@@ -254,7 +320,7 @@ func (c *ClusterController) initializeCluster(cluster *cluster) error {
 			c.configureCephMonitoring(cluster, clusterInfo)
 		}
 
-		err = c.configureLocalCephCluster(cluster)
+		err = c.configureLocalCephCluster(ctx, cluster)
 		if err != nil {
 			controller.UpdateCondition(c.OpManagerCtx, c.context, cluster.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionFalse, cephv1.ClusterProgressingReason, err.Error())
 			return errors.Wrap(err, "failed to configure local ceph cluster")
@@ -274,7 +340,7 @@ func (c *ClusterController) initializeCluster(cluster *cluster) error {
 	return nil
 }
 
-func (c *ClusterController) configureLocalCephCluster(cluster *cluster) error {
+func (c *ClusterController) configureLocalCephCluster(ctx context.Context, cluster *cluster) error {
 	cluster.ClusterInfo.Context = c.OpManagerCtx
 	// Cluster Spec validation
 	err := preClusterStartValidation(cluster)
@@ -284,7 +350,7 @@ func (c *ClusterController) configureLocalCephCluster(cluster *cluster) error {
 
 	// Run image validation job
 	controller.UpdateCondition(c.OpManagerCtx, c.context, cluster.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Detecting Ceph version")
-	cephVersion, isUpgrade, err := c.detectAndValidateCephVersion(cluster)
+	cephVersion, isUpgrade, err := c.detectAndValidateCephVersion(ctx, cluster)
 	if err != nil {
 		return errors.Wrap(err, "failed the ceph version check")
 	}
@@ -300,10 +366,15 @@ func (c *ClusterController) configureLocalCephCluster(cluster *cluster) error {
 
 	controller.UpdateCondition(c.OpManagerCtx, c.context, cluster.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Configuring the Ceph cluster")
 	// Run the orchestration
-	err = cluster.reconcileCephDaemons(c.rookImage, *cephVersion)
+	err = cluster.reconcileCephDaemons(ctx, c.rookImage, *cephVersion)
 	if err != nil {
 		return errors.Wrap(err, "failed to create cluster")
 	}
+	if cluster.networkMigrationRequeue > 0 || cluster.readdressRequeue > 0 {
+		// the cluster is not ready until the network migration of the mons, mgrs and OSDs, or the
+		// restart of the daemons on the new mon addresses completes
+		return nil
+	}
 
 	// Set the condition to the cluster object
 	controller.UpdateCondition(c.OpManagerCtx, c.context, cluster.namespacedName, cluster.observedGeneration, cephv1.ConditionReady, v1.ConditionTrue, cephv1.ClusterCreatedReason, "Cluster created successfully")
@@ -40,7 +40,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-func (c *ClusterController) configureExternalCephCluster(cluster *cluster) error {
+func (c *ClusterController) configureExternalCephCluster(ctx context.Context, cluster *cluster) error {
 	// Make sure the spec contains all the information we need
 	err := validateExternalClusterSpec(cluster)
 	if err != nil {
@@ -53,6 +53,11 @@ func (c *ClusterController) configureExternalCephCluster(cluster *cluster) error
 	// rotated. This doesn't apply to external clusters, so always allow rotation for those.
 	keyring.SetAllowCephxKeyRotationForCluster(cluster.Namespace, true)
 
+	// the connection info is synced from the bundle published by the provider cluster
+	if err := cluster.syncConnectionBundle(c.OpManagerCtx); err != nil {
+		return errors.Wrap(err, "failed to sync the connection bundle of the provider cluster")
+	}
+
 	// loop until we find the secret necessary to connect to the external cluster
 	// then populate clusterInfo
 	cluster.ClusterInfo, err = opcontroller.PopulateExternalClusterInfo(cluster.Spec, c.context, c.OpManagerCtx, cluster.namespacedName.Namespace, cluster.ownerInfo)
@@ -77,7 +82,7 @@ func (c *ClusterController) configureExternalCephCluster(cluster *cluster) error
 	// Validate versions (local and external)
 	// If no image is specified we don't perform any checks
 	if cluster.Spec.CephVersion.Image != "" {
-		_, _, err = c.detectAndValidateCephVersion(cluster)
+		_, _, err = c.detectAndValidateCephVersion(ctx, cluster)
 		if err != nil {
 			return errors.Wrap(err, "failed to detect and validate ceph version")
 		}
@@ -212,6 +217,23 @@ func validateExternalClusterSpec(cluster *cluster) error {
 		}
 	}
 
+	if bundle := cluster.Spec.External.ConnectionBundle; bundle != nil {
+		sources := 0
+		for _, set := range []bool{bundle.Secret != nil, bundle.URL != "", bundle.Path != ""} {
+			if set {
+				sources++
+			}
+		}
+		if sources != 1 {
+			return errors.New("exactly one of the secret, url and path of the connection bundle must be specified")
+		}
+		if bundle.URL != "" {
+			if err := validateConnectionBundleURL(bundle.URL); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Validate external services port
 	if cluster.Spec.Monitoring.Enabled {
 		if cluster.Spec.Monitoring.ExternalMgrPrometheusPort == 0 {
@@ -0,0 +1,340 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	connectionBundleDownloadTimeout = 30 * time.Second
+	// maxConnectionBundleSize bounds the size of a downloaded bundle
+	maxConnectionBundleSize = 1024 * 1024
+)
+
+// syncConnectionBundle applies the connection bundle published by the provider cluster to the
+// secrets and configmap the external cluster connects with. The bundle is synced again after the
+// sync interval. If the bundle cannot be loaded, the external cluster keeps connecting with the
+// bundle applied last.
+func (c *cluster) syncConnectionBundle(ctx context.Context) error {
+	c.connectionBundleRequeue = 0
+	spec := c.Spec.External.ConnectionBundle
+	if spec == nil {
+		return nil
+	}
+	c.connectionBundleRequeue = defaultConnectionBundleInterval
+	if spec.Interval != nil && spec.Interval.Duration > 0 {
+		c.connectionBundleRequeue = spec.Interval.Duration
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(ctx, c.namespacedName, cephCluster); err != nil {
+		return errors.Wrap(err, "failed to get the ceph cluster")
+	}
+	previous := cephCluster.Status.ConnectionBundle
+
+	bundle, digest, err := c.loadConnectionBundle(ctx, spec)
+	if err != nil {
+		if previous != nil && previous.Digest != "" {
+			log.NamespacedError(c.Namespace, logger, "failed to sync the connection bundle, connecting with the bundle applied last. %v", err)
+			return nil
+		}
+		return err
+	}
+
+	if err := c.applyConnectionBundle(ctx, bundle); err != nil {
+		return err
+	}
+	c.applyConnectionBundleMonitoring(bundle)
+
+	if previous != nil && previous.Digest == digest && previous.PublicKey == spec.PublicKey {
+		return nil
+	}
+	log.NamespacedInfo(c.Namespace, logger, "applied the connection bundle with digest %q from the provider cluster", digest)
+	now := metav1.Now()
+	status := connectionBundleStatus(bundle, digest, &now)
+	status.PublicKey = spec.PublicKey
+	return updateConnectionBundleStatus(ctx, c.context, c.namespacedName, status)
+}
+
+// loadConnectionBundle reads the bundle from its source and verifies its signature
+func (c *cluster) loadConnectionBundle(ctx context.Context, spec *cephv1.ExternalConnectionBundleSpec) (*connectionBundle, string, error) {
+	var document []byte
+	switch {
+	case spec.Secret != nil:
+		secret, err := c.context.Clientset.CoreV1().Secrets(c.Namespace).Get(ctx, spec.Secret.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "failed to get the connection bundle secret %q", spec.Secret.Name)
+		}
+		var ok bool
+		if document, ok = secret.Data[spec.Secret.Key]; !ok {
+			return nil, "", errors.Errorf("key %q not found in the connection bundle secret %q", spec.Secret.Key, spec.Secret.Name)
+		}
+
+	case spec.URL != "":
+		var caBundle []byte
+		if spec.CABundle != nil {
+			secret, err := c.context.Clientset.CoreV1().Secrets(c.Namespace).Get(ctx, spec.CABundle.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, "", errors.Wrapf(err, "failed to get the connection bundle ca secret %q", spec.CABundle.Name)
+			}
+			var ok bool
+			if caBundle, ok = secret.Data[spec.CABundle.Key]; !ok {
+				return nil, "", errors.Errorf("key %q not found in the connection bundle ca secret %q", spec.CABundle.Key, spec.CABundle.Name)
+			}
+		}
+		var err error
+		if document, err = downloadConnectionBundle(ctx, spec.URL, caBundle); err != nil {
+			return nil, "", err
+		}
+
+	case spec.Path != "":
+		var err error
+		if document, err = os.ReadFile(spec.Path); err != nil {
+			return nil, "", errors.Wrapf(err, "failed to read the connection bundle file %q", spec.Path)
+		}
+
+	default:
+		return nil, "", errors.New("no source of the connection bundle")
+	}
+
+	bundle, digest, err := verifyConnectionBundle(bytes.TrimSpace(document), spec.PublicKey)
+	if err != nil {
+		return nil, "", err
+	}
+	if bundle.FSID == "" || len(bundle.MonEndpoints) == 0 || bundle.CephUser.ID == "" || bundle.CephUser.Key == "" {
+		return nil, "", errors.New("the connection bundle is missing the fsid, mon endpoints or ceph user")
+	}
+	return bundle, digest, nil
+}
+
+// downloadConnectionBundle downloads the bundle over https, verifying the server with the given CA
+// certificates or with the system CAs
+func downloadConnectionBundle(ctx context.Context, bundleURL string, caBundle []byte) ([]byte, error) {
+	if err := validateConnectionBundleURL(bundleURL); err != nil {
+		return nil, err
+	}
+	httpClient, err := connectionBundleHTTPClient(caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectionBundleDownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bundleURL, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the request for the connection bundle %q", bundleURL)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download the connection bundle %q", bundleURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to download the connection bundle %q. status %q", bundleURL, resp.Status)
+	}
+	document, err := io.ReadAll(io.LimitReader(resp.Body, maxConnectionBundleSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the connection bundle %q", bundleURL)
+	}
+	return document, nil
+}
+
+// validateConnectionBundleURL checks that the bundle is downloaded over https, since the bundle
+// holds the CephX keys of the consumers
+func validateConnectionBundleURL(bundleURL string) error {
+	u, err := url.Parse(bundleURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the connection bundle url %q", bundleURL)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.Errorf("invalid connection bundle url %q. the url must be an https url", bundleURL)
+	}
+	return nil
+}
+
+// connectionBundleHTTPClient returns the client the bundle is downloaded with. The CA certificates
+// are added to the system CAs, and the redirects to other than https URLs are refused.
+func connectionBundleHTTPClient(caBundle []byte) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caBundle) > 0 {
+		caCertPool, err := x509.SystemCertPool()
+		if err != nil {
+			logger.Warningf("failed to load the system cert pool, verifying the connection bundle url with its ca bundle only. %v", err)
+			caCertPool = x509.NewCertPool()
+		}
+		if !caCertPool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("failed to parse the connection bundle ca certificates")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if err := validateConnectionBundleURL(req.URL.String()); err != nil {
+				return err
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}, nil
+}
+
+// applyConnectionBundle updates the mon secret, the mon endpoints and the CSI secrets the external
+// cluster connects with, as imported by the import-external-cluster.sh script
+func (c *cluster) applyConnectionBundle(ctx context.Context, bundle *connectionBundle) error {
+	err := c.updateConnectionBundleSecret(ctx, opcontroller.AppName, map[string][]byte{
+		"cluster-name":                  []byte(c.Namespace),
+		"fsid":                          []byte(bundle.FSID),
+		opcontroller.AdminSecretNameKey: []byte(opcontroller.AdminSecretNameKey),
+		opcontroller.MonSecretNameKey:   []byte(opcontroller.MonSecretNameKey),
+		opcontroller.CephUsernameKey:    []byte(bundle.CephUser.ID),
+		opcontroller.CephUserSecretKey:  []byte(bundle.CephUser.Key),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(bundle.CSIUsers)) {
+		user := bundle.CSIUsers[name]
+		err := c.updateConnectionBundleSecret(ctx, name, map[string][]byte{
+			"userID":  []byte(user.ID),
+			"userKey": []byte(user.Key),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	endpoints := []string{}
+	for _, name := range slices.Sorted(maps.Keys(bundle.MonEndpoints)) {
+		endpoints = append(endpoints, fmt.Sprintf("%s=%s", name, bundle.MonEndpoints[name]))
+	}
+	data := strings.Join(endpoints, ",")
+	cm, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Get(ctx, opcontroller.EndpointConfigMapName, metav1.GetOptions{})
+	if err == nil && cm.Data[opcontroller.EndpointDataKey] == data {
+		return nil
+	}
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to get the mon endpoints configmap %q", opcontroller.EndpointConfigMapName)
+	}
+	log.NamespacedInfo(c.Namespace, logger, "updating the mon endpoints of the external cluster to %q", data)
+	cm = &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opcontroller.EndpointConfigMapName,
+			Namespace: c.Namespace,
+		},
+		Data: map[string]string{
+			opcontroller.EndpointDataKey: data,
+			opcontroller.MappingKey:      "{}",
+			opcontroller.MaxMonIDKey:     "0",
+		},
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(ctx, c.context.Clientset, cm); err != nil {
+		return errors.Wrap(err, "failed to update the mon endpoints of the external cluster")
+	}
+	return nil
+}
+
+// updateConnectionBundleSecret sets the keys of a secret, keeping the other keys and the metadata
+// of an existing secret
+func (c *cluster) updateConnectionBundleSecret(ctx context.Context, name string, data map[string][]byte) error {
+	secrets := c.context.Clientset.CoreV1().Secrets(c.Namespace)
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get secret %q", name)
+		}
+		secret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: c.Namespace},
+			Data:       data,
+			Type:       k8sutil.RookType,
+		}
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to create secret %q", name)
+		}
+		return nil
+	}
+
+	changed := false
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for key, value := range data {
+		if !bytes.Equal(secret.Data[key], value) {
+			secret.Data[key] = value
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	log.NamespacedInfo(c.Namespace, logger, "updating secret %q from the connection bundle", name)
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to update secret %q", name)
+	}
+	return nil
+}
+
+// applyConnectionBundleMonitoring scrapes the prometheus exporter of the provider mgr, unless the
+// external mgr endpoints are set in the spec
+func (c *cluster) applyConnectionBundleMonitoring(bundle *connectionBundle) {
+	if bundle == nil || bundle.PrometheusURL == "" || len(c.Spec.Monitoring.ExternalMgrEndpoints) > 0 {
+		return
+	}
+	u, err := url.Parse(bundle.PrometheusURL)
+	if err != nil {
+		log.NamespacedWarning(c.Namespace, logger, "failed to parse the prometheus url %q of the connection bundle. %v", bundle.PrometheusURL, err)
+		return
+	}
+	// the external mgr endpoints are IP addresses
+	if net.ParseIP(u.Hostname()) == nil {
+		log.NamespacedDebug(c.Namespace, logger, "not scraping the prometheus exporter %q of the connection bundle since its host is not an ip address", bundle.PrometheusURL)
+		return
+	}
+	c.Spec.Monitoring.ExternalMgrEndpoints = []v1.EndpointAddress{{IP: u.Hostname()}}
+	if port, err := strconv.ParseUint(u.Port(), 10, 16); err == nil {
+		c.Spec.Monitoring.ExternalMgrPrometheusPort = uint16(port)
+	}
+}
@@ -43,3 +43,22 @@ func TestValidateExternalClusterSpec(t *testing.T) {
 	assert.NoError(t, err, err)
 	assert.Equal(t, uint16(9283), c.Spec.Monitoring.ExternalMgrPrometheusPort)
 }
+
+func TestValidateExternalConnectionBundle(t *testing.T) {
+	c := &cluster{Spec: &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}}, mons: &mon.Cluster{}}
+	c.Spec.External.ConnectionBundle = &cephv1.ExternalConnectionBundleSpec{PublicKey: "key"}
+	assert.Error(t, validateExternalClusterSpec(c))
+
+	c.Spec.External.ConnectionBundle.URL = "https://provider.example.com/bundle"
+	assert.NoError(t, validateExternalClusterSpec(c))
+
+	c.Spec.External.ConnectionBundle.Path = "/etc/rook/bundle"
+	assert.Error(t, validateExternalClusterSpec(c))
+
+	// the bundle holds cephx keys and is only downloaded over https
+	c.Spec.External.ConnectionBundle.Path = ""
+	for _, url := range []string{"http://provider.example.com/bundle", "https:///bundle", "provider.example.com/bundle"} {
+		c.Spec.External.ConnectionBundle.URL = url
+		assert.ErrorContains(t, validateExternalClusterSpec(c), "must be an https url", url)
+	}
+}
@@ -0,0 +1,758 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/csi"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//nolint:gosec // since this is not leaking any hardcoded credentials, it's just the secret names
+const (
+	// ConnectionBundleSecretName is the name of the secret holding the connection bundle published by the cluster
+	ConnectionBundleSecretName = "rook-ceph-connection-bundle"
+	// ConnectionBundleSecretKey is the key of the signed bundle in the connection bundle secret
+	ConnectionBundleSecretKey = "bundle"
+	// ConnectionBundlePublicKeySecretKey is the key of the public key of the bundle in the connection bundle secret
+	ConnectionBundlePublicKeySecretKey = "publicKey"
+	// connectionBundleSigningKeySecretName is the name of the secret holding the key the bundle is signed with
+	connectionBundleSigningKeySecretName = "rook-ceph-connection-bundle-key"
+	connectionBundleSigningKeySecretKey  = "privateKey"
+	// connectionBundleUsername is the CephX user the consumer clusters check the health of the cluster with
+	connectionBundleUsername = "client.healthchecker"
+	// connectionBundleCapsAnnotation records the caps of the health checker user on the connection bundle secret
+	connectionBundleCapsAnnotation = "ceph.rook.io/healthchecker-caps"
+	// connectionBundleConsumerLabel is set to the name of the consumer on the secret of the bundle of a consumer
+	connectionBundleConsumerLabel = "ceph.rook.io/connection-bundle-consumer"
+	// connectionBundleCSIUsersAnnotation records the CSI users of a consumer on the secret of its bundle
+	connectionBundleCSIUsersAnnotation = "ceph.rook.io/csi-users"
+)
+
+// defaultConnectionBundleInterval is the default interval of the updates and syncs of the connection bundle
+var defaultConnectionBundleInterval = 5 * time.Minute
+
+// connectionBundleUserCaps returns the caps of the health checker user of the consumer clusters, as
+// created by the create-external-cluster-resources.py script, with access to the rgw pools of the
+// object stores of the cluster
+func connectionBundleUserCaps(objectStores []cephv1.CephObjectStore, zones []cephv1.CephObjectZone) []string {
+	// the pools of a multisite object store belong to its zone
+	sharedPools := map[string]cephv1.ObjectSharedPoolsSpec{}
+	for _, store := range objectStores {
+		if store.Spec.IsExternal() || store.Spec.IsMultisite() {
+			continue
+		}
+		sharedPools[store.Name] = store.Spec.SharedPools
+	}
+	for _, zone := range zones {
+		if slices.ContainsFunc(objectStores, func(store cephv1.CephObjectStore) bool { return store.Spec.Zone.Name == zone.Name }) {
+			sharedPools[zone.Name] = zone.Spec.SharedPools
+		}
+	}
+
+	osdCaps := []string{"profile rbd-read-only"}
+	for _, name := range slices.Sorted(maps.Keys(sharedPools)) {
+		// the rgw pools of a zone are rados namespaces of the shared metadata pool, or pools prefixed with the zone name
+		if pool := sharedPools[name].MetadataPoolName; pool != "" {
+			osdCaps = append(osdCaps,
+				fmt.Sprintf("allow rwx pool=%s namespace=%s.meta*", pool, name),
+				fmt.Sprintf("allow rw pool=%s namespace=%s.control", pool, name),
+				fmt.Sprintf("allow rx pool=%s namespace=%s.log", pool, name),
+				fmt.Sprintf("allow x pool=%s namespace=%s.buckets.index", pool, name))
+			continue
+		}
+		osdCaps = append(osdCaps,
+			fmt.Sprintf("allow rwx pool=%s.rgw.meta", name),
+			fmt.Sprintf("allow rw pool=%s.rgw.control", name),
+			fmt.Sprintf("allow rx pool=%s.rgw.log", name),
+			fmt.Sprintf("allow x pool=%s.rgw.buckets.index", name))
+	}
+	if len(sharedPools) > 0 {
+		osdCaps = append(osdCaps, "allow r pool=.rgw.root")
+	}
+
+	return []string{
+		"mon", "allow r, allow command quorum_status, allow command version",
+		"mgr", "allow command config",
+		"osd", strings.Join(osdCaps, ", "),
+		"mds", "allow *",
+	}
+}
+
+// connectionBundleCSIUser is a CephX user of the CSI drivers dedicated to a consumer cluster
+type connectionBundleCSIUser struct {
+	ID   string   `json:"id"`
+	Caps []string `json:"caps"`
+}
+
+// connectionBundleConsumerCSIUsers returns the CSI users dedicated to a consumer by name of their
+// CSI secret, with the caps restricted to the pool and filesystem of the consumer like the users
+// created by the create-external-cluster-resources.py script with --restricted-auth-permission
+func connectionBundleConsumerCSIUsers(consumer cephv1.ConnectionBundleConsumerSpec) map[string]connectionBundleCSIUser {
+	users := map[string]connectionBundleCSIUser{}
+	userID := func(name string) string {
+		// the users are not prefixed with csi- like the CSI users of the cluster itself
+		return fmt.Sprintf("client.bundle-%s-%s", consumer.Name, name)
+	}
+
+	if consumer.RBDPool != "" {
+		osdCaps := "profile rbd pool=" + consumer.RBDPool
+		if consumer.RadosNamespace != "" {
+			osdCaps += " namespace=" + consumer.RadosNamespace
+		}
+		users[csi.CsiRBDNodeSecret] = connectionBundleCSIUser{
+			ID:   userID("csi-rbd-node"),
+			Caps: []string{"mon", "profile rbd, allow command 'osd blocklist'", "osd", osdCaps},
+		}
+		users[csi.CsiRBDProvisionerSecret] = connectionBundleCSIUser{
+			ID:   userID("csi-rbd-provisioner"),
+			Caps: []string{"mon", "profile rbd, allow command 'osd blocklist'", "mgr", "allow rw", "osd", osdCaps},
+		}
+	}
+
+	if consumer.Filesystem != "" {
+		users[csi.CsiCephFSNodeSecret] = connectionBundleCSIUser{
+			ID: userID("csi-cephfs-node"),
+			Caps: []string{
+				"mon", "allow r, allow command 'osd blocklist'",
+				"mgr", "allow rw",
+				"osd", fmt.Sprintf("allow rw tag cephfs *=%s", consumer.Filesystem),
+				"mds", "allow rw",
+			},
+		}
+		users[csi.CsiCephFSProvisionerSecret] = connectionBundleCSIUser{
+			ID: userID("csi-cephfs-provisioner"),
+			Caps: []string{
+				"mon", "allow r, allow command 'osd blocklist'",
+				"mgr", "allow rw",
+				"osd", fmt.Sprintf("allow rw tag cephfs metadata=%s", consumer.Filesystem),
+				"mds", "allow *",
+			},
+		}
+	}
+	return users
+}
+
+// connectionBundleConsumerSecretName returns the name of the secret of the bundle of a consumer
+func connectionBundleConsumerSecretName(consumer string) string {
+	return fmt.Sprintf("%s-%s", ConnectionBundleSecretName, consumer)
+}
+
+// connectionBundle is the information a consumer cluster in external mode connects to the provider
+// cluster with
+type connectionBundle struct {
+	FSID string `json:"fsid"`
+	// MonEndpoints are the endpoints of the mons by mon name
+	MonEndpoints map[string]string `json:"monEndpoints"`
+	// CephUser is the user the consumer cluster checks the health of the provider with
+	CephUser connectionBundleUser `json:"cephUser"`
+	// CSIUsers are the users of the CSI drivers by name of their secret, only set in the bundle of a
+	// consumer
+	CSIUsers map[string]connectionBundleUser `json:"csiUsers,omitempty"`
+	// RGWEndpoints are the endpoints of the object stores by name of the CephObjectStore
+	RGWEndpoints  map[string][]string `json:"rgwEndpoints,omitempty"`
+	DashboardURL  string              `json:"dashboardURL,omitempty"`
+	PrometheusURL string              `json:"prometheusURL,omitempty"`
+}
+
+type connectionBundleUser struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// signedConnectionBundle is the document published by the provider cluster
+type signedConnectionBundle struct {
+	Bundle    json.RawMessage `json:"bundle"`
+	Signature []byte          `json:"signature"`
+}
+
+// connectionBundlePublisher publishes the connection bundle of a provider cluster in a secret
+type connectionBundlePublisher struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	interval    time.Duration
+}
+
+func newConnectionBundlePublisher(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, spec *cephv1.ConnectionBundleSpec) *connectionBundlePublisher {
+	p := &connectionBundlePublisher{
+		context:     context,
+		clusterInfo: clusterInfo,
+		interval:    defaultConnectionBundleInterval,
+	}
+	if spec != nil && spec.Interval != nil && spec.Interval.Duration > 0 {
+		p.interval = spec.Interval.Duration
+	}
+	return p
+}
+
+// reconcileConnectionBundle publishes the connection bundle if requested by the spec, or removes it
+func (c *cluster) reconcileConnectionBundle() error {
+	publisher := newConnectionBundlePublisher(c.context, c.ClusterInfo, c.Spec.ConnectionBundle)
+	if c.Spec.ConnectionBundle != nil {
+		return publisher.publish()
+	}
+
+	if err := publisher.removeConsumerBundles(nil); err != nil {
+		return err
+	}
+	err := c.context.Clientset.CoreV1().Secrets(c.Namespace).Delete(c.ClusterInfo.Context, ConnectionBundleSecretName, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to delete the connection bundle secret")
+	}
+	return updateConnectionBundleStatus(c.ClusterInfo.Context, c.context, c.namespacedName, nil)
+}
+
+// Start updates the connection bundle at set intervals, since the mon endpoints and keys may change
+// without a reconcile of the cluster
+func (p *connectionBundlePublisher) Start(monitoringRoutines *sync.Map, daemon string) {
+	for {
+		// We must perform this check otherwise the case will check an index that does not exist anymore and
+		// we will get an invalid pointer error and the go routine will panic
+		v, ok := monitoringRoutines.Load(daemon)
+		if !ok {
+			log.NamespacedInfo(p.clusterInfo.Namespace, logger, "ceph cluster %q has been deleted. stopping the updates of the connection bundle", p.clusterInfo.Namespace)
+			return
+		}
+		health := v.(*opcontroller.ClusterHealth)
+		select {
+		case <-time.After(p.interval):
+			log.NamespacedDebug(p.clusterInfo.Namespace, logger, "updating the connection bundle")
+			if err := p.publish(); err != nil {
+				log.NamespacedError(p.clusterInfo.Namespace, logger, "failed to update the connection bundle. %v", err)
+			}
+
+		case <-health.InternalCtx.Done():
+			log.NamespacedInfo(p.clusterInfo.Namespace, logger, "stopping the updates of the connection bundle in namespace %q", p.clusterInfo.Namespace)
+			monitoringRoutines.Delete(daemon)
+			return
+		}
+	}
+}
+
+// publish signs the current connection bundle and updates the bundle secret and the CephCluster
+// status if the bundle changed
+func (p *connectionBundlePublisher) publish() error {
+	cephCluster := &cephv1.CephCluster{}
+	if err := p.context.Client.Get(p.clusterInfo.Context, p.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamespacedDebug(p.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrap(err, "failed to get the ceph cluster")
+	}
+	spec := cephCluster.Spec.ConnectionBundle
+	if spec == nil {
+		return nil
+	}
+
+	signingKey, err := p.getOrCreateSigningKey()
+	if err != nil {
+		return err
+	}
+	publicKey := base64.StdEncoding.EncodeToString(signingKey.Public().(ed25519.PublicKey))
+	existing, err := p.context.Clientset.CoreV1().Secrets(p.clusterInfo.Namespace).Get(p.clusterInfo.Context, ConnectionBundleSecretName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get the connection bundle secret %q", ConnectionBundleSecretName)
+		}
+		existing = nil
+	}
+
+	bundle, caps, err := p.buildBundle(spec)
+	if err != nil {
+		return err
+	}
+	if err := p.setHealthCheckerKey(bundle, caps, existing, publicKey); err != nil {
+		return err
+	}
+	document, digest, err := signConnectionBundle(bundle, signingKey)
+	if err != nil {
+		return err
+	}
+
+	if err := p.updateBundleSecret(existing, document, publicKey, caps); err != nil {
+		return err
+	}
+
+	// the CSI users of the consumers are revoked with the consumers
+	if err := p.removeConsumerBundles(spec.Consumers); err != nil {
+		return err
+	}
+	var consumers []cephv1.ConnectionBundleConsumerStatus
+	for _, consumer := range spec.Consumers {
+		consumerDigest, err := p.publishConsumerBundle(consumer, bundle, signingKey, publicKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to publish the connection bundle of consumer %q", consumer.Name)
+		}
+		consumers = append(consumers, cephv1.ConnectionBundleConsumerStatus{Name: consumer.Name, Digest: consumerDigest})
+	}
+
+	previous := cephCluster.Status.ConnectionBundle
+	if previous != nil && previous.Digest == digest && previous.PublicKey == publicKey && slices.Equal(previous.Consumers, consumers) {
+		return nil
+	}
+	log.NamespacedInfo(p.clusterInfo.Namespace, logger, "published connection bundle with digest %q", digest)
+	now := metav1.Now()
+	status := connectionBundleStatus(bundle, digest, &now)
+	status.PublicKey = publicKey
+	status.Consumers = consumers
+	return updateConnectionBundleStatus(p.clusterInfo.Context, p.context, p.clusterInfo.NamespacedName(), status)
+}
+
+// getOrCreateSigningKey returns the key the bundle is signed with. The key is generated once and is
+// kept for the lifetime of the cluster, so the consumers do not need to trust a new public key.
+func (p *connectionBundlePublisher) getOrCreateSigningKey() (ed25519.PrivateKey, error) {
+	secret, err := p.context.Clientset.CoreV1().Secrets(p.clusterInfo.Namespace).Get(p.clusterInfo.Context, connectionBundleSigningKeySecretName, metav1.GetOptions{})
+	if err == nil {
+		seed := secret.Data[connectionBundleSigningKeySecretKey]
+		if len(seed) != ed25519.SeedSize {
+			return nil, errors.Errorf("invalid connection bundle signing key in secret %q", connectionBundleSigningKeySecretName)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to get the connection bundle signing key secret %q", connectionBundleSigningKeySecretName)
+	}
+
+	log.NamespacedInfo(p.clusterInfo.Namespace, logger, "generating the connection bundle signing key")
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the connection bundle signing key")
+	}
+	secret = &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      connectionBundleSigningKeySecretName,
+			Namespace: p.clusterInfo.Namespace,
+		},
+		Data: map[string][]byte{connectionBundleSigningKeySecretKey: key.Seed()},
+		Type: k8sutil.RookType,
+	}
+	if err := p.clusterInfo.OwnerInfo.SetControllerReference(secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to set owner reference to secret %q", secret.Name)
+	}
+	if _, err := p.context.Clientset.CoreV1().Secrets(p.clusterInfo.Namespace).Create(p.clusterInfo.Context, secret, metav1.CreateOptions{}); err != nil {
+		return nil, errors.Wrapf(err, "failed to create the connection bundle signing key secret %q", secret.Name)
+	}
+	return key, nil
+}
+
+// buildBundle collects the connection information of the cluster, except the key of the health
+// checker user, and returns the caps of the health checker user
+func (p *connectionBundlePublisher) buildBundle(spec *cephv1.ConnectionBundleSpec) (*connectionBundle, []string, error) {
+	bundle := &connectionBundle{
+		FSID:         p.clusterInfo.FSID,
+		MonEndpoints: map[string]string{},
+		RGWEndpoints: map[string][]string{},
+		DashboardURL: spec.DashboardURL,
+	}
+	for name, m := range p.clusterInfo.InternalMonitors {
+		bundle.MonEndpoints[name] = m.Endpoint
+	}
+
+	objectStores := cephv1.CephObjectStoreList{}
+	if err := p.context.Client.List(p.clusterInfo.Context, &objectStores, client.InNamespace(p.clusterInfo.Namespace)); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list the object stores")
+	}
+	zones := cephv1.CephObjectZoneList{}
+	if err := p.context.Client.List(p.clusterInfo.Context, &zones, client.InNamespace(p.clusterInfo.Namespace)); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list the object zones")
+	}
+	for _, store := range objectStores.Items {
+		if store.Status == nil {
+			continue
+		}
+		endpoints := slices.Concat(store.Status.Endpoints.Insecure, store.Status.Endpoints.Secure)
+		if len(endpoints) > 0 {
+			bundle.RGWEndpoints[store.Name] = endpoints
+		}
+	}
+
+	services, err := cephclient.CephMgrServices(p.context, p.clusterInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bundle.DashboardURL == "" {
+		bundle.DashboardURL = services["dashboard"]
+	}
+	bundle.PrometheusURL = services["prometheus"]
+
+	return bundle, connectionBundleUserCaps(objectStores.Items, zones.Items), nil
+}
+
+// setHealthCheckerKey sets the key of the health checker user in the bundle. The user is only
+// created or updated, and its key only read, when the bundle or the caps of the user changed since
+// the bundle was published.
+func (p *connectionBundlePublisher) setHealthCheckerKey(bundle *connectionBundle, caps []string, existing *v1.Secret, publicKey string) error {
+	bundle.CephUser = connectionBundleUser{ID: connectionBundleUsername}
+	encodedCaps, err := json.Marshal(caps)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the caps of the health checker user")
+	}
+
+	if existing != nil && existing.Annotations[connectionBundleCapsAnnotation] == string(encodedCaps) {
+		published, _, err := verifyConnectionBundle(existing.Data[ConnectionBundleSecretKey], publicKey)
+		if err == nil && published.CephUser.ID == connectionBundleUsername && published.CephUser.Key != "" {
+			key := published.CephUser.Key
+			published.CephUser.Key = ""
+			unchanged, err := connectionBundlesEqual(bundle, published)
+			if err != nil {
+				return err
+			}
+			if unchanged {
+				bundle.CephUser.Key = key
+				return nil
+			}
+		}
+	}
+
+	// the user exists with other caps when the object stores changed
+	key, err := getOrUpdateUserKey(p.context, p.clusterInfo, connectionBundleUsername, caps)
+	if err != nil {
+		return err
+	}
+	bundle.CephUser.Key = key
+	return nil
+}
+
+// connectionBundlesEqual returns whether two bundles publish the same information
+func connectionBundlesEqual(a, b *connectionBundle) (bool, error) {
+	encodedA, err := json.Marshal(a)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal the connection bundle")
+	}
+	encodedB, err := json.Marshal(b)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal the connection bundle")
+	}
+	return bytes.Equal(encodedA, encodedB), nil
+}
+
+// updateBundleSecret updates the secret the consumer clusters sync the bundle from, if the bundle
+// or the caps of the health checker user changed
+func (p *connectionBundlePublisher) updateBundleSecret(existing *v1.Secret, document []byte, publicKey string, caps []string) error {
+	encodedCaps, err := json.Marshal(caps)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the caps of the health checker user")
+	}
+	if existing != nil && bytes.Equal(existing.Data[ConnectionBundleSecretKey], document) && string(existing.Data[ConnectionBundlePublicKeySecretKey]) == publicKey &&
+		existing.Annotations[connectionBundleCapsAnnotation] == string(encodedCaps) {
+		return nil
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ConnectionBundleSecretName,
+			Namespace:   p.clusterInfo.Namespace,
+			Annotations: map[string]string{connectionBundleCapsAnnotation: string(encodedCaps)},
+		},
+		Data: map[string][]byte{
+			ConnectionBundleSecretKey:          document,
+			ConnectionBundlePublicKeySecretKey: []byte(publicKey),
+		},
+		Type: k8sutil.RookType,
+	}
+	if err := p.clusterInfo.OwnerInfo.SetControllerReference(secret); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference to secret %q", secret.Name)
+	}
+	if _, err := k8sutil.CreateOrUpdateSecret(p.clusterInfo.Context, p.context.Clientset, secret); err != nil {
+		return errors.Wrap(err, "failed to update the connection bundle secret")
+	}
+	return nil
+}
+
+// publishConsumerBundle publishes the bundle of a consumer, with the keys of the CSI users dedicated
+// to the consumer, and returns its digest. The users are only created or updated, and their keys
+// only read, when the bundle or the caps of the users changed since the bundle was published.
+func (p *connectionBundlePublisher) publishConsumerBundle(consumer cephv1.ConnectionBundleConsumerSpec, base *connectionBundle, signingKey ed25519.PrivateKey, publicKey string) (string, error) {
+	name := connectionBundleConsumerSecretName(consumer.Name)
+	existing, err := p.context.Clientset.CoreV1().Secrets(p.clusterInfo.Namespace).Get(p.clusterInfo.Context, name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "failed to get the connection bundle secret %q", name)
+		}
+		existing = nil
+	}
+
+	users := connectionBundleConsumerCSIUsers(consumer)
+	encodedUsers, err := json.Marshal(users)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the csi users")
+	}
+	bundle := *base
+	bundle.CSIUsers = map[string]connectionBundleUser{}
+	for secretName, user := range users {
+		// the CSI secrets hold the user name without the client prefix
+		bundle.CSIUsers[secretName] = connectionBundleUser{ID: strings.TrimPrefix(user.ID, "client.")}
+	}
+
+	var previousUsers map[string]connectionBundleCSIUser
+	if existing != nil {
+		if err := json.Unmarshal([]byte(existing.Annotations[connectionBundleCSIUsersAnnotation]), &previousUsers); err != nil {
+			log.NamespacedWarning(p.clusterInfo.Namespace, logger, "failed to read the csi users of the connection bundle secret %q. %v", name, err)
+		}
+	}
+	keys, err := p.publishedCSIKeys(&bundle, existing, string(encodedUsers), publicKey)
+	if err != nil {
+		return "", err
+	}
+	if keys == nil {
+		// the users that are not part of the bundle anymore are revoked
+		if err := p.deleteCSIUsers(previousUsers, users); err != nil {
+			return "", err
+		}
+		keys = map[string]string{}
+		for secretName, user := range users {
+			key, err := getOrUpdateUserKey(p.context, p.clusterInfo, user.ID, user.Caps)
+			if err != nil {
+				return "", err
+			}
+			keys[secretName] = key
+		}
+	}
+	for secretName, key := range keys {
+		user := bundle.CSIUsers[secretName]
+		user.Key = key
+		bundle.CSIUsers[secretName] = user
+	}
+
+	document, digest, err := signConnectionBundle(&bundle, signingKey)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil && bytes.Equal(existing.Data[ConnectionBundleSecretKey], document) && string(existing.Data[ConnectionBundlePublicKeySecretKey]) == publicKey &&
+		existing.Annotations[connectionBundleCSIUsersAnnotation] == string(encodedUsers) {
+		return digest, nil
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   p.clusterInfo.Namespace,
+			Labels:      map[string]string{connectionBundleConsumerLabel: consumer.Name},
+			Annotations: map[string]string{connectionBundleCSIUsersAnnotation: string(encodedUsers)},
+		},
+		Data: map[string][]byte{
+			ConnectionBundleSecretKey:          document,
+			ConnectionBundlePublicKeySecretKey: []byte(publicKey),
+		},
+		Type: k8sutil.RookType,
+	}
+	if err := p.clusterInfo.OwnerInfo.SetControllerReference(secret); err != nil {
+		return "", errors.Wrapf(err, "failed to set owner reference to secret %q", secret.Name)
+	}
+	if _, err := k8sutil.CreateOrUpdateSecret(p.clusterInfo.Context, p.context.Clientset, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to update the connection bundle secret %q", name)
+	}
+	log.NamespacedInfo(p.clusterInfo.Namespace, logger, "published connection bundle of consumer %q with digest %q", consumer.Name, digest)
+	return digest, nil
+}
+
+// publishedCSIKeys returns the keys of the CSI users by name of their CSI secret from the published
+// bundle of a consumer, or nil if the bundle or the CSI users changed since it was published
+func (p *connectionBundlePublisher) publishedCSIKeys(bundle *connectionBundle, existing *v1.Secret, encodedUsers, publicKey string) (map[string]string, error) {
+	if existing == nil || existing.Annotations[connectionBundleCSIUsersAnnotation] != encodedUsers {
+		return nil, nil
+	}
+	published, _, err := verifyConnectionBundle(existing.Data[ConnectionBundleSecretKey], publicKey)
+	if err == nil {
+		keys := map[string]string{}
+		for secretName, user := range published.CSIUsers {
+			if user.Key == "" {
+				return nil, nil
+			}
+			keys[secretName] = user.Key
+			user.Key = ""
+			published.CSIUsers[secretName] = user
+		}
+		unchanged, err := connectionBundlesEqual(bundle, published)
+		if err != nil {
+			return nil, err
+		}
+		if unchanged {
+			return keys, nil
+		}
+	}
+	return nil, nil
+}
+
+// removeConsumerBundles deletes the bundles and the CSI users of the consumers that are not in the
+// given list
+func (p *connectionBundlePublisher) removeConsumerBundles(consumers []cephv1.ConnectionBundleConsumerSpec) error {
+	secrets, err := p.context.Clientset.CoreV1().Secrets(p.clusterInfo.Namespace).List(p.clusterInfo.Context, metav1.ListOptions{LabelSelector: connectionBundleConsumerLabel})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the connection bundle secrets of the consumers")
+	}
+	for _, secret := range secrets.Items {
+		consumer := secret.Labels[connectionBundleConsumerLabel]
+		if slices.ContainsFunc(consumers, func(c cephv1.ConnectionBundleConsumerSpec) bool { return c.Name == consumer }) {
+			continue
+		}
+
+		log.NamespacedInfo(p.clusterInfo.Namespace, logger, "removing the connection bundle and the csi users of consumer %q", consumer)
+		var users map[string]connectionBundleCSIUser
+		if err := json.Unmarshal([]byte(secret.Annotations[connectionBundleCSIUsersAnnotation]), &users); err != nil {
+			return errors.Wrapf(err, "failed to read the csi users of the connection bundle secret %q", secret.Name)
+		}
+		if err := p.deleteCSIUsers(users, nil); err != nil {
+			return err
+		}
+		err := p.context.Clientset.CoreV1().Secrets(p.clusterInfo.Namespace).Delete(p.clusterInfo.Context, secret.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the connection bundle secret %q", secret.Name)
+		}
+	}
+	return nil
+}
+
+// deleteCSIUsers deletes the CephX users of the previous CSI users that are not kept
+func (p *connectionBundlePublisher) deleteCSIUsers(previous, kept map[string]connectionBundleCSIUser) error {
+	keptIDs := map[string]bool{}
+	for _, user := range kept {
+		keptIDs[user.ID] = true
+	}
+	for _, user := range previous {
+		if keptIDs[user.ID] {
+			continue
+		}
+		if err := cephclient.AuthDelete(p.context, p.clusterInfo, user.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOrUpdateUserKey returns the key of a user, created with the given caps or updated to them
+func getOrUpdateUserKey(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, name string, caps []string) (string, error) {
+	key, err := cephclient.AuthGetOrCreateKey(context, clusterInfo, name, "", caps)
+	if err == nil {
+		return key, nil
+	}
+	// the user exists with other caps when the caps changed
+	log.NamespacedDebug(clusterInfo.Namespace, logger, "failed to get or create user %q, updating its caps. %v", name, err)
+	if err := cephclient.AuthUpdateCaps(context, clusterInfo, name, caps); err != nil {
+		return "", err
+	}
+	return cephclient.AuthGetKey(context, clusterInfo, name)
+}
+
+// signConnectionBundle returns the signed bundle document and the digest of the bundle
+func signConnectionBundle(bundle *connectionBundle, key ed25519.PrivateKey) ([]byte, string, error) {
+	payload, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal the connection bundle")
+	}
+	document, err := json.Marshal(signedConnectionBundle{Bundle: payload, Signature: ed25519.Sign(key, payload)})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal the signed connection bundle")
+	}
+	return document, connectionBundleDigest(payload), nil
+}
+
+// verifyConnectionBundle checks the signature of a bundle document with the base64-encoded public
+// key of the provider and returns the bundle and its digest
+func verifyConnectionBundle(document []byte, publicKey string) (*connectionBundle, string, error) {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to decode the connection bundle public key")
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, "", errors.Errorf("invalid connection bundle public key of %d bytes, expected %d", len(key), ed25519.PublicKeySize)
+	}
+
+	signed := signedConnectionBundle{}
+	if err := json.Unmarshal(document, &signed); err != nil {
+		return nil, "", errors.Wrap(err, "failed to unmarshal the signed connection bundle")
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), signed.Bundle, signed.Signature) {
+		return nil, "", errors.New("invalid signature of the connection bundle")
+	}
+
+	bundle := &connectionBundle{}
+	if err := json.Unmarshal(signed.Bundle, bundle); err != nil {
+		return nil, "", errors.Wrap(err, "failed to unmarshal the connection bundle")
+	}
+	return bundle, connectionBundleDigest(signed.Bundle), nil
+}
+
+func connectionBundleDigest(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// connectionBundleStatus returns the status reporting a bundle
+func connectionBundleStatus(bundle *connectionBundle, digest string, lastUpdated *metav1.Time) *cephv1.ConnectionBundleStatus {
+	status := &cephv1.ConnectionBundleStatus{
+		Digest:       digest,
+		LastUpdated:  lastUpdated,
+		DashboardURL: bundle.DashboardURL,
+	}
+	// the map keys are sorted to keep the status stable
+	for _, name := range slices.Sorted(maps.Keys(bundle.RGWEndpoints)) {
+		status.RGWEndpoints = append(status.RGWEndpoints, cephv1.ConnectionBundleObjectStore{Name: name, Endpoints: bundle.RGWEndpoints[name]})
+	}
+	return status
+}
+
+// updateConnectionBundleStatus sets the connection bundle in the CephCluster status
+func updateConnectionBundleStatus(ctx context.Context, clusterdContext *clusterd.Context, nsName types.NamespacedName, status *cephv1.ConnectionBundleStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := clusterdContext.Client.Get(ctx, nsName, cephCluster); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamespacedDebug(nsName.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return err
+		}
+		if cephCluster.Status.ConnectionBundle == nil && status == nil {
+			return nil
+		}
+		cephCluster.Status.ConnectionBundle = status
+		return reporting.UpdateStatus(clusterdContext.Client, cephCluster)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update the connection bundle in the ceph cluster status")
+	}
+	return nil
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/csi"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSignConnectionBundle(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	publicKey := base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	bundle := &connectionBundle{FSID: "fsid", MonEndpoints: map[string]string{"a": "10.0.0.1:3300"}}
+
+	document, digest, err := signConnectionBundle(bundle, key)
+	require.NoError(t, err)
+	verified, verifiedDigest, err := verifyConnectionBundle(document, publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, bundle, verified)
+	assert.Equal(t, digest, verifiedDigest)
+
+	t.Run("the signature is checked with the public key of the provider", func(t *testing.T) {
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		_, _, err = verifyConnectionBundle(document, base64.StdEncoding.EncodeToString(otherKey.Public().(ed25519.PublicKey)))
+		assert.ErrorContains(t, err, "invalid signature")
+
+		_, _, err = verifyConnectionBundle(document, base64.StdEncoding.EncodeToString([]byte("short")))
+		assert.ErrorContains(t, err, "invalid connection bundle public key")
+	})
+
+	t.Run("a tampered bundle is rejected", func(t *testing.T) {
+		tampered := []byte(string(document))
+		copy(tampered[len(`{"bundle":{"fsid":"`):], "FSID")
+		_, _, err := verifyConnectionBundle(tampered, publicKey)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+}
+
+func TestConnectionBundle(t *testing.T) {
+	ctx := context.TODO()
+	provider := types.NamespacedName{Namespace: "provider", Name: "provider"}
+	consumer := types.NamespacedName{Namespace: "consumer", Name: "consumer"}
+
+	services := `{"dashboard":"https://10.0.0.5:8443/","prometheus":"http://10.0.0.5:9283/"}`
+	authCalls := 0
+	deletedUsers := []string{}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "auth" && args[1] == "get-or-create-key" && args[2] == connectionBundleUsername:
+				authCalls++
+				assert.Contains(t, args[8], "allow rwx pool=store.rgw.meta")
+				return `{"key":"AQBhealthcheckerkey=="}`, nil
+			case args[0] == "auth" && args[1] == "get-or-create-key" && strings.HasPrefix(args[2], "client.bundle-east-csi-"):
+				authCalls++
+				return fmt.Sprintf(`{"key":"key-%s"}`, args[2]), nil
+			case args[0] == "auth" && args[1] == "del":
+				deletedUsers = append(deletedUsers, args[2])
+				return "", nil
+			case args[0] == "mgr" && args[1] == "services":
+				return services, nil
+			}
+			return "", errors.Errorf("unexpected ceph command %v", args)
+		},
+	}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		return executor.MockExecuteCommandWithTimeout(0, command, args...)
+	}
+
+	providerCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: provider.Name, Namespace: provider.Namespace},
+		Spec: cephv1.ClusterSpec{ConnectionBundle: &cephv1.ConnectionBundleSpec{
+			Consumers: []cephv1.ConnectionBundleConsumerSpec{{Name: "east", RBDPool: "replicapool", Filesystem: "myfs"}},
+		}},
+	}
+	consumerCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: consumer.Name, Namespace: consumer.Namespace},
+	}
+	objectStore := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: provider.Namespace},
+		Status:     &cephv1.ObjectStoreStatus{Endpoints: cephv1.ObjectEndpoints{Insecure: []string{"http://rgw.provider.svc:80"}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(providerCluster, consumerCluster, objectStore).WithStatusSubresource(providerCluster, consumerCluster).Build()
+	clientset := k8sfake.NewClientset()
+	clusterdContext := &clusterd.Context{Client: cl, Clientset: clientset, Executor: executor}
+
+	clusterInfo := clienttest.CreateTestClusterInfo(2)
+	clusterInfo.Namespace = provider.Namespace
+	clusterInfo.SetName(provider.Name)
+	publisher := newConnectionBundlePublisher(clusterdContext, clusterInfo, providerCluster.Spec.ConnectionBundle)
+
+	getStatus := func(nsName types.NamespacedName) *cephv1.ConnectionBundleStatus {
+		cephCluster := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(ctx, nsName, cephCluster))
+		return cephCluster.Status.ConnectionBundle
+	}
+
+	var published *cephv1.ConnectionBundleStatus
+	t.Run("the provider publishes the signed bundle", func(t *testing.T) {
+		require.NoError(t, publisher.publish())
+		published = getStatus(provider)
+		require.NotNil(t, published)
+		assert.NotEmpty(t, published.PublicKey)
+		assert.NotEmpty(t, published.Digest)
+		assert.Equal(t, "https://10.0.0.5:8443/", published.DashboardURL)
+		assert.Equal(t, []cephv1.ConnectionBundleObjectStore{{Name: "store", Endpoints: []string{"http://rgw.provider.svc:80"}}}, published.RGWEndpoints)
+
+		secret, err := clientset.CoreV1().Secrets(provider.Namespace).Get(ctx, ConnectionBundleSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, published.PublicKey, string(secret.Data[ConnectionBundlePublicKeySecretKey]))
+		bundle, digest, err := verifyConnectionBundle(secret.Data[ConnectionBundleSecretKey], published.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, published.Digest, digest)
+		assert.Equal(t, map[string]string{"a": "1.2.3.1:3300", "b": "1.2.3.2:3300"}, bundle.MonEndpoints)
+		assert.Equal(t, connectionBundleUser{ID: connectionBundleUsername, Key: "AQBhealthcheckerkey=="}, bundle.CephUser)
+		// the CSI users of the cluster are not published
+		assert.Empty(t, bundle.CSIUsers)
+	})
+
+	t.Run("each consumer gets a bundle with its own csi users", func(t *testing.T) {
+		require.Len(t, published.Consumers, 1)
+		assert.Equal(t, "east", published.Consumers[0].Name)
+
+		secret, err := clientset.CoreV1().Secrets(provider.Namespace).Get(ctx, "rook-ceph-connection-bundle-east", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "east", secret.Labels[connectionBundleConsumerLabel])
+		bundle, digest, err := verifyConnectionBundle(secret.Data[ConnectionBundleSecretKey], published.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, published.Consumers[0].Digest, digest)
+		assert.Equal(t, connectionBundleUser{ID: connectionBundleUsername, Key: "AQBhealthcheckerkey=="}, bundle.CephUser)
+		assert.Equal(t, map[string]connectionBundleUser{
+			csi.CsiRBDNodeSecret:           {ID: "bundle-east-csi-rbd-node", Key: "key-client.bundle-east-csi-rbd-node"},
+			csi.CsiRBDProvisionerSecret:    {ID: "bundle-east-csi-rbd-provisioner", Key: "key-client.bundle-east-csi-rbd-provisioner"},
+			csi.CsiCephFSNodeSecret:        {ID: "bundle-east-csi-cephfs-node", Key: "key-client.bundle-east-csi-cephfs-node"},
+			csi.CsiCephFSProvisionerSecret: {ID: "bundle-east-csi-cephfs-provisioner", Key: "key-client.bundle-east-csi-cephfs-provisioner"},
+		}, bundle.CSIUsers)
+	})
+
+	t.Run("an unchanged bundle is not published again", func(t *testing.T) {
+		authCalls = 0
+		require.NoError(t, publisher.publish())
+		assert.Equal(t, published, getStatus(provider))
+		// the key of the health checker user is only read when the bundle changes
+		assert.Equal(t, 0, authCalls)
+	})
+
+	t.Run("the signing key is kept when the bundle changes", func(t *testing.T) {
+		clusterInfo.InternalMonitors["c"] = clusterInfo.InternalMonitors["b"]
+		require.NoError(t, publisher.publish())
+		// the keys of the health checker and of the csi users of the consumer are read again
+		assert.Equal(t, 5, authCalls)
+		status := getStatus(provider)
+		assert.Equal(t, published.PublicKey, status.PublicKey)
+		assert.NotEqual(t, published.Digest, status.Digest)
+		assert.NotEqual(t, published.Consumers[0].Digest, status.Consumers[0].Digest)
+		published = status
+	})
+
+	providerSecret, err := clientset.CoreV1().Secrets(provider.Namespace).Get(ctx, "rook-ceph-connection-bundle-east", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().Secrets(consumer.Namespace).Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider-bundle", Namespace: consumer.Namespace},
+		Data:       providerSecret.Data,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	newConsumer := func(spec *cephv1.ExternalConnectionBundleSpec) *cluster {
+		return &cluster{
+			context:        clusterdContext,
+			Namespace:      consumer.Namespace,
+			namespacedName: consumer,
+			Spec:           &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true, ConnectionBundle: spec}},
+		}
+	}
+
+	t.Run("a consumer without a valid bundle cannot connect", func(t *testing.T) {
+		c := newConsumer(&cephv1.ExternalConnectionBundleSpec{
+			Secret:    &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "provider-bundle"}, Key: ConnectionBundleSecretKey},
+			PublicKey: base64.StdEncoding.EncodeToString(make([]byte, ed25519.PublicKeySize)),
+		})
+		assert.ErrorContains(t, c.syncConnectionBundle(ctx), "invalid signature")
+		assert.Nil(t, getStatus(consumer))
+	})
+
+	t.Run("the consumer applies the bundle from a secret", func(t *testing.T) {
+		c := newConsumer(&cephv1.ExternalConnectionBundleSpec{
+			Secret:    &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "provider-bundle"}, Key: ConnectionBundleSecretKey},
+			PublicKey: published.PublicKey,
+			Interval:  &metav1.Duration{Duration: time.Minute},
+		})
+		require.NoError(t, c.syncConnectionBundle(ctx))
+		assert.Equal(t, time.Minute, c.connectionBundleRequeue)
+
+		status := getStatus(consumer)
+		require.NotNil(t, status)
+		assert.Equal(t, published.Consumers[0].Digest, status.Digest)
+		assert.Equal(t, published.RGWEndpoints, status.RGWEndpoints)
+		assert.Equal(t, published.DashboardURL, status.DashboardURL)
+
+		secret, err := clientset.CoreV1().Secrets(consumer.Namespace).Get(ctx, opcontroller.AppName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "12345", string(secret.Data["fsid"]))
+		assert.Equal(t, connectionBundleUsername, string(secret.Data[opcontroller.CephUsernameKey]))
+		assert.Equal(t, "AQBhealthcheckerkey==", string(secret.Data[opcontroller.CephUserSecretKey]))
+		cm, err := clientset.CoreV1().ConfigMaps(consumer.Namespace).Get(ctx, opcontroller.EndpointConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "a=1.2.3.1:3300,b=1.2.3.2:3300,c=1.2.3.2:3300", cm.Data[opcontroller.EndpointDataKey])
+		csiSecret, err := clientset.CoreV1().Secrets(consumer.Namespace).Get(ctx, csi.CsiRBDNodeSecret, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "bundle-east-csi-rbd-node", string(csiSecret.Data["userID"]))
+		assert.Equal(t, "key-client.bundle-east-csi-rbd-node", string(csiSecret.Data["userKey"]))
+
+		assert.Equal(t, []v1.EndpointAddress{{IP: "10.0.0.5"}}, c.Spec.Monitoring.ExternalMgrEndpoints)
+		assert.Equal(t, uint16(9283), c.Spec.Monitoring.ExternalMgrPrometheusPort)
+	})
+
+	t.Run("the consumer keeps the bundle applied last if the bundle is invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bundle")
+		require.NoError(t, os.WriteFile(path, []byte("not a bundle"), 0o600))
+		c := newConsumer(&cephv1.ExternalConnectionBundleSpec{Path: path, PublicKey: published.PublicKey})
+		require.NoError(t, c.syncConnectionBundle(ctx))
+		assert.Equal(t, defaultConnectionBundleInterval, c.connectionBundleRequeue)
+		assert.Equal(t, published.Consumers[0].Digest, getStatus(consumer).Digest)
+	})
+
+	t.Run("the consumer applies the bundle from a file", func(t *testing.T) {
+		clusterInfo.InternalMonitors["c"] = clienttest.CreateTestClusterInfo(3).InternalMonitors["c"]
+		require.NoError(t, publisher.publish())
+		secret, err := clientset.CoreV1().Secrets(provider.Namespace).Get(ctx, ConnectionBundleSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "bundle")
+		require.NoError(t, os.WriteFile(path, secret.Data[ConnectionBundleSecretKey], 0o600))
+
+		c := newConsumer(&cephv1.ExternalConnectionBundleSpec{Path: path, PublicKey: published.PublicKey})
+		require.NoError(t, c.syncConnectionBundle(ctx))
+		assert.Equal(t, getStatus(provider).Digest, getStatus(consumer).Digest)
+		// the bundle without csi users keeps the csi secrets applied last
+		csiSecret, err := clientset.CoreV1().Secrets(consumer.Namespace).Get(ctx, csi.CsiRBDNodeSecret, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "key-client.bundle-east-csi-rbd-node", string(csiSecret.Data["userKey"]))
+		cm, err := clientset.CoreV1().ConfigMaps(consumer.Namespace).Get(ctx, opcontroller.EndpointConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "a=1.2.3.1:3300,b=1.2.3.2:3300,c=1.2.3.3:3300", cm.Data[opcontroller.EndpointDataKey])
+	})
+
+	setConsumers := func(consumers []cephv1.ConnectionBundleConsumerSpec) {
+		cephCluster := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(ctx, provider, cephCluster))
+		cephCluster.Spec.ConnectionBundle.Consumers = consumers
+		require.NoError(t, cl.Update(ctx, cephCluster))
+	}
+
+	t.Run("the csi users the consumer does not need anymore are revoked", func(t *testing.T) {
+		setConsumers([]cephv1.ConnectionBundleConsumerSpec{{Name: "east", RBDPool: "replicapool", RadosNamespace: "east"}})
+		require.NoError(t, publisher.publish())
+		assert.ElementsMatch(t, []string{"client.bundle-east-csi-cephfs-node", "client.bundle-east-csi-cephfs-provisioner"}, deletedUsers)
+
+		secret, err := clientset.CoreV1().Secrets(provider.Namespace).Get(ctx, "rook-ceph-connection-bundle-east", metav1.GetOptions{})
+		require.NoError(t, err)
+		bundle, _, err := verifyConnectionBundle(secret.Data[ConnectionBundleSecretKey], published.PublicKey)
+		require.NoError(t, err)
+		assert.Len(t, bundle.CSIUsers, 2)
+		assert.Contains(t, secret.Annotations[connectionBundleCSIUsersAnnotation], "profile rbd pool=replicapool namespace=east")
+	})
+
+	t.Run("the bundle and the csi users of a removed consumer are deleted", func(t *testing.T) {
+		deletedUsers = []string{}
+		setConsumers(nil)
+		require.NoError(t, publisher.publish())
+		assert.ElementsMatch(t, []string{"client.bundle-east-csi-rbd-node", "client.bundle-east-csi-rbd-provisioner"}, deletedUsers)
+		_, err := clientset.CoreV1().Secrets(provider.Namespace).Get(ctx, "rook-ceph-connection-bundle-east", metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+		assert.Empty(t, getStatus(provider).Consumers)
+	})
+
+	t.Run("the bundle is removed when it is not published anymore", func(t *testing.T) {
+		c := &cluster{context: clusterdContext, ClusterInfo: clusterInfo, Namespace: provider.Namespace, namespacedName: provider, Spec: &cephv1.ClusterSpec{}}
+		require.NoError(t, c.reconcileConnectionBundle())
+		assert.Nil(t, getStatus(provider))
+		_, err := clientset.CoreV1().Secrets(provider.Namespace).Get(ctx, ConnectionBundleSecretName, metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+	})
+}
+
+func TestDownloadConnectionBundle(t *testing.T) {
+	ctx := context.TODO()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://provider.example.com/bundle", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("bundle"))
+	}))
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	t.Run("the server is verified with the ca bundle", func(t *testing.T) {
+		document, err := downloadConnectionBundle(ctx, server.URL+"/bundle", caBundle)
+		require.NoError(t, err)
+		assert.Equal(t, "bundle", string(document))
+	})
+
+	t.Run("an unknown server is rejected", func(t *testing.T) {
+		_, err := downloadConnectionBundle(ctx, server.URL+"/bundle", nil)
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("non-tls urls are rejected", func(t *testing.T) {
+		_, err := downloadConnectionBundle(ctx, strings.Replace(server.URL, "https://", "http://", 1), caBundle)
+		assert.ErrorContains(t, err, "must be an https url")
+
+		_, err = downloadConnectionBundle(ctx, server.URL+"/redirect", caBundle)
+		assert.ErrorContains(t, err, "must be an https url")
+	})
+
+	t.Run("an invalid ca bundle is rejected", func(t *testing.T) {
+		_, err := downloadConnectionBundle(ctx, server.URL+"/bundle", []byte("not a certificate"))
+		assert.ErrorContains(t, err, "failed to parse the connection bundle ca certificates")
+	})
+}
+
+func TestConnectionBundleUserCaps(t *testing.T) {
+	osdCaps := func(caps []string) string {
+		require.Len(t, caps, 8)
+		assert.Equal(t, "osd", caps[4])
+		return caps[5]
+	}
+
+	t.Run("without object stores", func(t *testing.T) {
+		assert.Equal(t, "profile rbd-read-only", osdCaps(connectionBundleUserCaps(nil, nil)))
+	})
+
+	t.Run("the rgw pools are derived from the object stores", func(t *testing.T) {
+		stores := []cephv1.CephObjectStore{
+			{ObjectMeta: metav1.ObjectMeta{Name: "store-b"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "store-a"}, Spec: cephv1.ObjectStoreSpec{SharedPools: cephv1.ObjectSharedPoolsSpec{MetadataPoolName: "rgw-meta", DataPoolName: "rgw-data"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "external"}, Spec: cephv1.ObjectStoreSpec{Gateway: cephv1.GatewaySpec{ExternalRgwEndpoints: []cephv1.EndpointAddress{{IP: "10.0.0.1"}}}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "multisite"}, Spec: cephv1.ObjectStoreSpec{Zone: cephv1.ZoneSpec{Name: "zone-a"}}},
+		}
+		zones := []cephv1.CephObjectZone{
+			{ObjectMeta: metav1.ObjectMeta{Name: "zone-a"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "unused-zone"}},
+		}
+		assert.Equal(t, "profile rbd-read-only, "+
+			"allow rwx pool=rgw-meta namespace=store-a.meta*, allow rw pool=rgw-meta namespace=store-a.control, "+
+			"allow rx pool=rgw-meta namespace=store-a.log, allow x pool=rgw-meta namespace=store-a.buckets.index, "+
+			"allow rwx pool=store-b.rgw.meta, allow rw pool=store-b.rgw.control, allow rx pool=store-b.rgw.log, allow x pool=store-b.rgw.buckets.index, "+
+			"allow rwx pool=zone-a.rgw.meta, allow rw pool=zone-a.rgw.control, allow rx pool=zone-a.rgw.log, allow x pool=zone-a.rgw.buckets.index, "+
+			"allow r pool=.rgw.root",
+			osdCaps(connectionBundleUserCaps(stores, zones)))
+	})
+}
+
+func TestConnectionBundleConsumerCSIUsers(t *testing.T) {
+	t.Run("no csi users without a pool and a filesystem", func(t *testing.T) {
+		assert.Empty(t, connectionBundleConsumerCSIUsers(cephv1.ConnectionBundleConsumerSpec{Name: "east"}))
+	})
+
+	t.Run("the caps are restricted to the pool and the filesystem of the consumer", func(t *testing.T) {
+		users := connectionBundleConsumerCSIUsers(cephv1.ConnectionBundleConsumerSpec{Name: "east", RBDPool: "replicapool", RadosNamespace: "east", Filesystem: "myfs"})
+		assert.Equal(t, connectionBundleCSIUser{
+			ID:   "client.bundle-east-csi-rbd-node",
+			Caps: []string{"mon", "profile rbd, allow command 'osd blocklist'", "osd", "profile rbd pool=replicapool namespace=east"},
+		}, users[csi.CsiRBDNodeSecret])
+		assert.Equal(t, connectionBundleCSIUser{
+			ID:   "client.bundle-east-csi-rbd-provisioner",
+			Caps: []string{"mon", "profile rbd, allow command 'osd blocklist'", "mgr", "allow rw", "osd", "profile rbd pool=replicapool namespace=east"},
+		}, users[csi.CsiRBDProvisionerSecret])
+		assert.Equal(t, connectionBundleCSIUser{
+			ID:   "client.bundle-east-csi-cephfs-node",
+			Caps: []string{"mon", "allow r, allow command 'osd blocklist'", "mgr", "allow rw", "osd", "allow rw tag cephfs *=myfs", "mds", "allow rw"},
+		}, users[csi.CsiCephFSNodeSecret])
+		assert.Equal(t, connectionBundleCSIUser{
+			ID:   "client.bundle-east-csi-cephfs-provisioner",
+			Caps: []string{"mon", "allow r, allow command 'osd blocklist'", "mgr", "allow rw", "osd", "allow rw tag cephfs metadata=myfs", "mds", "allow *"},
+		}, users[csi.CsiCephFSProvisionerSecret])
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"slices"
 	"strconv"
 	"sync"
+	"time"
 
 	csiopv1 "github.com/ceph/ceph-csi-operator/api/v1"
 	"github.com/coreos/pkg/capnslog"
@@ -150,6 +151,9 @@ func isSecretRefFromCluster(secretName string, clusterSpec cephv1.ClusterSpec) b
 			}
 		}
 	}
+	if bundle := clusterSpec.External.ConnectionBundle; bundle != nil && bundle.Secret != nil && bundle.Secret.Name == secretName {
+		return true
+	}
 	return false
 }
 
@@ -162,7 +166,7 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 	}
 
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: concurrentReconciles})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r), MaxConcurrentReconciles: concurrentReconciles})
 	if err != nil {
 		return err
 	}
@@ -191,6 +195,12 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 		return err
 	}
 
+	// Reconcile the clusters whose cephx keys are overdue for rotation in the cephx key inventory
+	err = c.Watch(opcontroller.ReconcileTriggerSource(ControllerTypeMeta.Kind))
+	if err != nil {
+		return err
+	}
+
 	// Watch all other resources of the Ceph Cluster
 	for _, t := range objectsToWatch {
 		err = watchOwnedCoreObject(c, mgr, t)
@@ -224,7 +234,8 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 		return err
 	}
 
-	// Watch for changes to secrets referenced in ClusterSpec.CephConfigFromSecret
+	// Watch for changes to secrets referenced in ClusterSpec.CephConfigFromSecret or the connection
+	// bundle of an external cluster
 	err = c.Watch(
 		source.Kind(
 			mgr.GetCache(),
@@ -312,13 +323,13 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 func (r *ReconcileCephCluster) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
 	defer opcontroller.RecoverAndLogException()
 	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
-	reconcileResponse, cephCluster, err := r.reconcile(request)
+	reconcileResponse, cephCluster, err := r.reconcile(context, request)
 
 	return reporting.ReportReconcileResult(logger, r.clusterController.recorder, request,
 		&cephCluster, reconcileResponse, err)
 }
 
-func (r *ReconcileCephCluster) reconcile(request reconcile.Request) (reconcile.Result, cephv1.CephCluster, error) {
+func (r *ReconcileCephCluster) reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, cephv1.CephCluster, error) {
 	if err := r.opManagerContext.Err(); err != nil {
 		log.NamespacedInfo(request.Namespace, logger, "context cancelled before entering reconcile, exiting reconcile")
 		emptyCephCluster := cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{
@@ -376,7 +387,7 @@ func (r *ReconcileCephCluster) reconcile(request reconcile.Request) (reconcile.R
 
 	// Do reconcile here!
 	ownerInfo := k8sutil.NewOwnerInfo(cephCluster, r.scheme)
-	if err := r.clusterController.reconcileCephCluster(cephCluster, ownerInfo); err != nil {
+	if err := r.clusterController.reconcileCephCluster(ctx, cephCluster, ownerInfo); err != nil {
 		// If the error has a context cancelled let's return a success result so that the controller can
 		// exit gracefully and the goroutine (the one the manager runs in) won't block retrying even if the parent context has been
 		// cancelled.
@@ -392,6 +403,22 @@ func (r *ReconcileCephCluster) reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, *cephCluster, errors.Wrapf(err, "failed to reconcile cluster %q", cephCluster.Name)
 	}
 
+	// Check again at the end of the soak period of a canary upgrade, while mons migrate to a new network
+	// provider, while the daemons restart on the new mon addresses, or to sync the connection bundle of
+	// the provider cluster
+	if rawCluster, ok := r.clusterController.clusterMap.Load(cephCluster.Namespace); ok {
+		c := rawCluster.(*cluster)
+		requeue := c.canaryUpgradeRequeue
+		for _, r := range []time.Duration{c.networkMigrationRequeue, c.readdressRequeue, c.connectionBundleRequeue} {
+			if r > 0 && (requeue == 0 || r < requeue) {
+				requeue = r
+			}
+		}
+		if requeue > 0 {
+			return reconcile.Result{RequeueAfter: requeue}, *cephCluster, nil
+		}
+	}
+
 	// Return and do not requeue
 	return reconcile.Result{}, *cephCluster, nil
 }
@@ -463,7 +490,7 @@ func NewClusterController(context *clusterd.Context, rookImage string) *ClusterC
 	}
 }
 
-func (c *ClusterController) reconcileCephCluster(clusterObj *cephv1.CephCluster, ownerInfo *k8sutil.OwnerInfo) error {
+func (c *ClusterController) reconcileCephCluster(ctx context.Context, clusterObj *cephv1.CephCluster, ownerInfo *k8sutil.OwnerInfo) error {
 	if clusterObj.Spec.CleanupPolicy.HasDataDirCleanPolicy() {
 		log.NamespacedInfo(clusterObj.Namespace, logger, "skipping orchestration for cluster object %q in namespace %q because its cleanup policy is set", clusterObj.Name, clusterObj.Namespace)
 		return nil
@@ -471,6 +498,12 @@ func (c *ClusterController) reconcileCephCluster(clusterObj *cephv1.CephCluster,
 	var clustr *cluster
 	if rawCluster, ok := c.clusterMap.Load(clusterObj.Namespace); ok {
 		clustr = rawCluster.(*cluster)
+		// the CRD validation rules also reject the update, unless they are not enforced by the API server
+		if clustr.Spec != nil {
+			if err := cephv1.ValidateNetworkSpecUpdate(clusterObj.Namespace, clustr.Spec.Network, clusterObj.Spec.Network); err != nil {
+				return errors.Wrap(err, "failed to validate the network spec update")
+			}
+		}
 	} else {
 		// It's a new cluster so let's populate the struct
 		clustr = newCluster(c.OpManagerCtx, clusterObj, c.context, ownerInfo, c.rookImage)
@@ -493,7 +526,7 @@ func (c *ClusterController) reconcileCephCluster(clusterObj *cephv1.CephCluster,
 	log.NamedInfo(clustr.namespacedName, logger, "reconciling ceph cluster")
 
 	// Start the main ceph cluster orchestration
-	return c.initializeCluster(clustr)
+	return c.initializeCluster(ctx, clustr)
 }
 
 func (c *ClusterController) requestClusterDelete(clusterObj *cephv1.CephCluster) (reconcile.Result, error) {
@@ -257,7 +257,7 @@ func TestReconcileSkipsWhenSkipReconcileLabelSet(t *testing.T) {
 		opManagerContext:  context.TODO(),
 	}
 
-	resp, _, err := reconcileCephCluster.reconcile(reconcile.Request{NamespacedName: nsName})
+	resp, _, err := reconcileCephCluster.reconcile(context.TODO(), reconcile.Request{NamespacedName: nsName})
 	assert.NoError(t, err)
 	assert.True(t, resp.IsZero())
 
@@ -266,3 +266,20 @@ func TestReconcileSkipsWhenSkipReconcileLabelSet(t *testing.T) {
 	assert.Contains(t, event, "ReconcileSkipped")
 	assert.Contains(t, event, cephv1.SkipReconcileLabelKey)
 }
+
+func TestReconcileCephClusterNetworkUpdate(t *testing.T) {
+	namespace := "rook-ceph"
+	controller := NewClusterController(&clusterd.Context{}, "")
+	running := &cluster{Namespace: namespace, Spec: &cephv1.ClusterSpec{Network: cephv1.NetworkSpec{Provider: cephv1.NetworkProviderHost}}}
+	controller.clusterMap.Store(namespace, running)
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: namespace}}
+	cephCluster.Spec.Network = cephv1.NetworkSpec{
+		Provider:  cephv1.NetworkProviderMultus,
+		Selectors: map[cephv1.CephNetworkType]string{cephv1.CephNetworkPublic: "public-net"},
+	}
+	err := controller.reconcileCephCluster(context.TODO(), cephCluster, nil)
+	assert.ErrorContains(t, err, "failed to validate the network spec update")
+	// the spec of the running cluster is kept
+	assert.Equal(t, cephv1.NetworkProviderHost, running.Spec.Network.Provider)
+}
@@ -295,7 +295,7 @@ func (c *Cluster) setLoginCredentials(password string) error {
 	// exists however it will not update the password. That is why we need to explicitly
 	// call the ac-user-set-password command to ensure the password is updated correctly
 	args = []string{"dashboard", "ac-user-create", dashboardUsername, "-i", file.Name(), "administrator"}
-	_, err = client.ExecuteCephCommandWithRetry(func() (string, []byte, error) {
+	_, err = client.ExecuteCephCommandWithRetry(c.clusterInfo.Context, func() (string, []byte, error) {
 		output, err := client.NewCephCommand(c.context, c.clusterInfo, args).RunWithTimeout(exec.CephCommandsTimeout)
 		return "create dashboard user", output, err
 	}, 5, dashboardInitWaitTime)
@@ -306,7 +306,7 @@ func (c *Cluster) setLoginCredentials(password string) error {
 	// Set dashboard user password
 	// > ceph dashboard ac-user-set-password <username> -i <path-to-password-file>
 	args = []string{"dashboard", "ac-user-set-password", dashboardUsername, "-i", file.Name()}
-	_, err = client.ExecuteCephCommandWithRetry(func() (string, []byte, error) {
+	_, err = client.ExecuteCephCommandWithRetry(c.clusterInfo.Context, func() (string, []byte, error) {
 		output, err := client.NewCephCommand(c.context, c.clusterInfo, args).RunWithTimeout(exec.CephCommandsTimeout)
 		return "set dashboard user password", output, err
 	}, 5, dashboardInitWaitTime)
@@ -124,6 +124,12 @@ func (c *Cluster) Start() error {
 		return errors.Wrap(err, "failed to check for mgrs to skip reconcile")
 	}
 
+	mgrsHeldByUpgrade, err := controller.GetDaemonsHeldByCanaryUpgrade(c.clusterInfo.Context, c.context, c.clusterInfo.Namespace, config.MgrType, AppName)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for mgrs held by the canary upgrade")
+	}
+	mgrsToSkipReconcile = mgrsToSkipReconcile.Union(mgrsHeldByUpgrade)
+
 	c.shouldRotateCephxKeys, err = shouldRotateMgrKeys(c.context, c.clusterInfo)
 	if err != nil {
 		return errors.Wrapf(err, "failed to check if cephx keys for mgr daemons in the namespace %q should be rotated", c.clusterInfo.Namespace)
@@ -624,7 +630,8 @@ func shouldRotateMgrKeys(c *clusterd.Context, clusterInfo *cephclient.ClusterInf
 
 	// daemon key type always takes the default from setDefaultCephxKeyType()
 	shouldRotateKeys, err := keyring.ShouldRotateCephxKeys(
-		clusterObj.Spec.Security.CephX.Daemon, runningCephVersion, desiredCephVersion, clusterObj.Status.Cephx.Mgr, true, clusterInfo.Namespace)
+		clusterObj.Spec.Security.CephX.Daemon, runningCephVersion, desiredCephVersion, clusterObj.Status.Cephx.Mgr, true, clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephCluster", clusterObj.Name, keyring.CephxComponentMgr))
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check if mgr daemon keys should be rotated or not")
 	}
@@ -44,7 +44,7 @@ func (c *Cluster) configureOrchestratorModules() error {
 
 func (c *Cluster) setRookOrchestratorBackend() error {
 	// retry a few times in the case that the mgr module is not ready to accept commands
-	_, err := client.ExecuteCephCommandWithRetry(func() (string, []byte, error) {
+	_, err := client.ExecuteCephCommandWithRetry(c.clusterInfo.Context, func() (string, []byte, error) {
 		args := []string{"orch", "set", "backend", "rook"}
 		output, err := client.NewCephCommand(c.context, c.clusterInfo, args).RunWithTimeout(exec.CephCommandsTimeout)
 		return "set rook backend", output, err
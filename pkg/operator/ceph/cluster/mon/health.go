@@ -376,6 +376,11 @@ func (c *Cluster) checkHealth(ctx context.Context) error {
 			needToCheckMonsOnSameNode = false
 			return c.evictMonIfMultipleOnSameNode()
 		}
+
+		// move one mon per health check if the mons are not spread across the failure domains
+		if moved, err := c.rebalanceMons(); err != nil || moved {
+			return err
+		}
 	}
 
 	// failover mon if `multiClusterService` is enabled but mon service is not exported
@@ -740,6 +745,12 @@ func (c *Cluster) updateMonDeploymentReplica(name string, enabled bool) error {
 }
 
 func (c *Cluster) failoverMon(name string) error {
+	return c.failoverMonToFailureDomain(name, "")
+}
+
+// failoverMonToFailureDomain replaces the mon with a new mon. If the failure domain is set, the new
+// mon is scheduled on a node in that failure domain.
+func (c *Cluster) failoverMonToFailureDomain(name, failureDomain string) error {
 	log.NamespacedInfo(c.Namespace, logger, "Failing over monitor %q", name)
 
 	// remove the failed mon from a local list of the existing mons for finding a stretch zone
@@ -752,6 +763,7 @@ func (c *Cluster) failoverMon(name string) error {
 
 	// Start a new monitor
 	m := c.newMonConfig(c.maxMonID+1, zone)
+	m.FailureDomain = failureDomain
 	log.NamespacedInfo(c.Namespace, logger, "starting new mon: %+v", m)
 
 	// Scale down the failed mon to allow a new one to start
@@ -54,6 +54,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 )
@@ -69,6 +70,8 @@ const (
 	EndpointDataKey = "data"
 	// EndpointExternalMonsKey key in EndpointConfigMapName configmap containing IDs of external mons
 	EndpointExternalMonsKey = "externalMons"
+	// lastMonRebalanceKey key in EndpointConfigMapName configmap containing the time a mon was last moved to another failure domain
+	lastMonRebalanceKey = "lastMonRebalance"
 	// AppName is the name of the secret storing cluster mon.admin key, fsid and name
 	AppName = "rook-ceph-mon"
 	// FloatingMonAppName is the app label used for floating mon deployments
@@ -142,6 +145,16 @@ type Cluster struct {
 	monsToFailover map[string]*monConfig
 	// reference to the secret that stores mon key
 	monKeySecretResourceVersion string
+	// whether the mons are re-addressed, in which case they are updated without quorum
+	readdressingMons bool
+	// the progress of the restart of the other daemons after the mons were re-addressed
+	readdressRestart *readdressRestart
+	// ReaddressRequeue is the time to wait before continuing the restart of the other daemons after
+	// the mons were re-addressed, or zero if no daemons are restarted
+	ReaddressRequeue time.Duration
+	// the time a mon was last moved to spread the mons across the failure domains
+	lastMonRebalance time.Time
+	recorder         events.EventRecorder
 }
 
 // monConfig for a single monitor
@@ -158,6 +171,8 @@ type monConfig struct {
 	Zone string
 	// The node where the mon is assigned
 	NodeName string
+	// The failure domain a mon is moved to when rebalancing the mons
+	FailureDomain string
 	// DataPathMap is the mapping relationship between mon data stored on the host and mon data
 	// stored in containers.
 	DataPathMap *config.DataPathMap
@@ -193,6 +208,11 @@ func New(ctx context.Context, clusterdContext *clusterd.Context, namespace strin
 	}
 }
 
+// SetEventRecorder sets the recorder of the events emitted by the mon health checker
+func (c *Cluster) SetEventRecorder(recorder events.EventRecorder) {
+	c.recorder = recorder
+}
+
 func (c *Cluster) MaxMonID() int {
 	return c.maxMonID
 }
@@ -247,17 +267,33 @@ func (c *Cluster) startMons(targetCount int) error {
 		return errors.Wrap(err, "failed to check for mons to skip reconcile")
 	}
 
+	monsHeldByUpgrade, err := controller.GetDaemonsHeldByCanaryUpgrade(c.ClusterInfo.Context, c.context, c.Namespace, config.MonType, AppName)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for mons held by the canary upgrade")
+	}
+	monsToSkipReconcile = monsToSkipReconcile.Union(monsHeldByUpgrade)
+
 	// Assign the mons to nodes
 	if err := c.assignMons(mons, monsToSkipReconcile); err != nil {
 		return errors.Wrap(err, "failed to assign pods to mons")
 	}
 
+	// Rewrite the monmap of the existing mons if their address changed
+	c.readdressingMons = false
+	c.ReaddressRequeue = 0
+	if existingCount > 0 {
+		c.readdressingMons, err = c.readdressMons(mons[:existingCount])
+		if err != nil {
+			return errors.Wrap(err, "failed to re-address the mons")
+		}
+	}
+
 	// The centralized mon config database can only be used if there is at least one mon
 	// operational. If we are starting mons, and one is already up, then there is a cluster already
 	// created, and we can immediately set values in the config database. The goal is to set configs
 	// only once and do it as early as possible in the mon orchestration.
-	setConfigsNeedsRetry := false
-	if existingCount > 0 {
+	setConfigsNeedsRetry := c.readdressingMons
+	if existingCount > 0 && !c.readdressingMons {
 		err := config.SetOrRemoveDefaultConfigs(c.context, c.ClusterInfo, c.spec)
 		if err != nil {
 			// If we fail here, it could be because the mons are not healthy, and this might be
@@ -311,6 +347,18 @@ func (c *Cluster) startMons(targetCount int) error {
 		}
 	}
 
+	if c.readdressingMons {
+		// the other daemons connect to the new mon addresses once the mons are in quorum
+		completed, err := c.completeReaddressMons()
+		if err != nil {
+			return errors.Wrap(err, "failed to restart the daemons after the mons were re-addressed")
+		}
+		if !completed {
+			c.ReaddressRequeue = readdressRequeueInterval
+		}
+		c.readdressingMons = false
+	}
+
 	// apply network settings after mons have been created b/c they are set in the mon k-v store
 	if err := controller.ApplyCephNetworkSettings(c.ClusterInfo.Context, c.rookImage, c.context, &c.spec, c.ClusterInfo); err != nil {
 		return errors.Wrap(err, "failed to apply ceph network settings")
@@ -754,6 +802,15 @@ func scheduleMonitor(c *Cluster, mon *monConfig) (*apps.Deployment, error) {
 	monContainer.StartupProbe = nil
 	monContainer.LivenessProbe = nil
 
+	// a mon moved by the rebalancing must be scheduled in its target failure domain
+	if mon.FailureDomain != "" {
+		nodeAffinity, err := k8sutil.GenerateNodeAffinity(fmt.Sprintf("%s=%s", monRebalanceFailureDomainLabel(c.spec), mon.FailureDomain))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate mon %q failure domain node affinity", mon.DaemonName)
+		}
+		d.Spec.Template.Spec.Affinity = &corev1.Affinity{NodeAffinity: nodeAffinity}
+	}
+
 	// setup affinity settings for pod scheduling
 	p := c.getMonPlacement(mon.Zone)
 	p.ApplyToPodSpec(&d.Spec.Template.Spec)
@@ -1414,6 +1471,10 @@ func (c *Cluster) persistExpectedMonDaemonsInConfigMap() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to save maxMonID")
 	}
+	lastMonRebalance, err := c.getLastMonRebalance()
+	if err != nil {
+		return errors.Wrap(err, "failed to save the time of the last mon rebalancing")
+	}
 
 	// preserve the mons detected out of quorum
 	var monsOutOfQuorum []string
@@ -1442,6 +1503,9 @@ func (c *Cluster) persistExpectedMonDaemonsInConfigMap() error {
 		controller.OutOfQuorumKey: strings.Join(monsOutOfQuorum, ","),
 		csi.ConfigKey:             csiConfigValue,
 	}
+	if !lastMonRebalance.IsZero() {
+		configMap.Data[lastMonRebalanceKey] = lastMonRebalance.Format(time.RFC3339)
+	}
 
 	if _, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Create(c.ClusterInfo.Context, configMap, metav1.CreateOptions{}); err != nil {
 		if !kerrors.IsAlreadyExists(err) {
@@ -1530,7 +1594,9 @@ func (c *Cluster) updateMon(m *monConfig, d *apps.Deployment) error {
 	log.NamespacedInfo(c.Namespace, logger, "deployment for mon %s already exists. updating if needed",
 		d.Name)
 
-	err := updateDeploymentAndWait(c.context, c.ClusterInfo, d, config.MonType, m.DaemonName, c.spec.SkipUpgradeChecks, false)
+	// the upgrade checks require the quorum that the re-addressed mons lost
+	skipUpgradeChecks := c.spec.SkipUpgradeChecks || c.readdressingMons
+	err := updateDeploymentAndWait(c.context, c.ClusterInfo, d, config.MonType, m.DaemonName, skipUpgradeChecks, false)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update mon deployment %s", m.ResourceName)
 	}
@@ -1682,6 +1748,12 @@ func (c *Cluster) configureDefaultMonStorage(m *monConfig, d *apps.Deployment, s
 			return nil
 		}
 
+		// skip update if mon fail over is required due to change in multus settings
+		if isMonIPUpdateRequiredForMultus(m.DaemonName, existingDeployment, &c.spec.Network) {
+			c.monsToFailover[m.DaemonName] = m
+			return nil
+		}
+
 		// the existing deployment may have a node selector. if the cluster
 		// isn't using host networking and the deployment is using pvc storage,
 		// then the node selector can be removed. this may happen after
@@ -1748,6 +1820,20 @@ func isMonIPUpdateRequiredForHostNetwork(mon string, isMonUsingHostNetwork bool,
 	return false
 }
 
+func isMonIPUpdateRequiredForMultus(mon string, d *apps.Deployment, network *cephv1.NetworkSpec) bool {
+	isMultusEnabledInSpec := network.IsMultus()
+	isMonUsingMultus := k8sutil.PodNetworkProvider(&d.Spec.Template) == cephv1.NetworkProviderMultus
+	if isMultusEnabledInSpec && !isMonUsingMultus {
+		logger.Infof("multus is enabled for the cluster but mon %q is not running on the multus public network", mon)
+		return true
+	} else if !isMultusEnabledInSpec && isMonUsingMultus {
+		logger.Infof("multus is disabled for the cluster but mon %q is still running on the multus public network", mon)
+		return true
+	}
+
+	return false
+}
+
 func hasMonPathChanged(d *apps.Deployment, claim *corev1.PersistentVolumeClaim) bool {
 	if d.Labels["pvc_name"] == "" && claim != nil {
 		log.NamespacedInfo(d.Namespace, logger, "skipping update for mon %q where path has changed from hostPath to pvc", d.Name)
@@ -1894,7 +1980,8 @@ func (c *Cluster) RotateMonCephxKeys(clusterObj *cephv1.CephCluster) (bool, erro
 
 	// daemon key type always takes the default from setDefaultCephxKeyType()
 	shouldRotateMonKeys, err := keyring.ShouldRotateCephxKeys(
-		clusterObj.Spec.Security.CephX.Daemon, runningCephVersion, desiredCephVersion, clusterObj.Status.Cephx.Mon, true, c.ClusterInfo.Namespace)
+		clusterObj.Spec.Security.CephX.Daemon, runningCephVersion, desiredCephVersion, clusterObj.Status.Cephx.Mon, true, c.ClusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephCluster", clusterObj.Name, keyring.CephxComponentMon))
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to check if mon daemon keys should be rotated in the namespace %q", c.ClusterInfo.Namespace)
 	}
@@ -1241,6 +1241,29 @@ func TestIsMonIPUpdateRequiredForHostNetwork(t *testing.T) {
 	})
 }
 
+func TestIsMonIPUpdateRequiredForMultus(t *testing.T) {
+	multusDeployment := &apps.Deployment{}
+	multusDeployment.Spec.Template.Annotations = map[string]string{"k8s.v1.cni.cncf.io/networks": `[{"name":"public-net"}]`}
+	podDeployment := &apps.Deployment{}
+	multus := &cephv1.NetworkSpec{Provider: cephv1.NetworkProviderMultus}
+
+	t.Run("both cluster and mon are set to use multus", func(t *testing.T) {
+		assert.False(t, isMonIPUpdateRequiredForMultus("a", multusDeployment, multus))
+	})
+
+	t.Run("both cluster and mon are on the pod network", func(t *testing.T) {
+		assert.False(t, isMonIPUpdateRequiredForMultus("a", podDeployment, &cephv1.NetworkSpec{}))
+	})
+
+	t.Run("cluster is set for multus but mon pod is not", func(t *testing.T) {
+		assert.True(t, isMonIPUpdateRequiredForMultus("a", podDeployment, multus))
+	})
+
+	t.Run("mon is using multus but cluster is updated to the pod network", func(t *testing.T) {
+		assert.True(t, isMonIPUpdateRequiredForMultus("a", multusDeployment, &cephv1.NetworkSpec{}))
+	})
+}
+
 func TestRotateMonCephxKeys(t *testing.T) {
 	ctx := context.TODO()
 	namespace := "default"
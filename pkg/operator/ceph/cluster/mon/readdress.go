@@ -0,0 +1,622 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	readdressJobAppName = "rook-ceph-mon-readdress"
+	// readdressJobNameFmt embeds the mon name so the monmap of each mon is rewritten by its own Job
+	readdressJobNameFmt    = "rook-ceph-mon-readdress-%s"
+	readdressContainerName = "readdress"
+	readdressMonmapPath    = "/tmp/monmap"
+
+	// the app name and the labels of the OSD pods, which are set by the osd package
+	osdAppName            = "rook-ceph-osd"
+	osdIDLabel            = "ceph-osd-id"
+	osdFailureDomainLabel = "failure-domain"
+
+	// readdressRequestedValue is the value of the re-addressing annotation requesting the re-addressing
+	// of the mons
+	readdressRequestedValue = "true"
+	// readdressRestartingValue is the value of the re-addressing annotation while the other daemons
+	// are restarted on the new mon addresses
+	readdressRestartingValue = "restarting-daemons"
+)
+
+var (
+	readdressJobBackoffLimit int32 = 3
+	// readdressJobActiveDeadlineSeconds bounds the Job lifetime so that it surfaces as Failed
+	// rather than keeping the mons down forever
+	readdressJobActiveDeadlineSeconds int64 = 600
+
+	// hooks for tests to override the time to wait for the mons to stop and the Jobs to complete
+	readdressRetries       = 120
+	readdressRetryInterval = 5 * time.Second
+	// readdressRestartTimeout is the time to wait for the daemons restarted by a step to run, or for
+	// the OSDs of a failure domain to be ok to stop, before continuing with the next daemons
+	readdressRestartTimeout = 10 * time.Minute
+	// readdressRequeueInterval is the time to wait before continuing the restart of the daemons
+	readdressRequeueInterval = 10 * time.Second
+
+	// readdressRestartOrder is the order the daemons are restarted in once the mons are in quorum on
+	// their new addresses. The mgrs and OSDs come first so that the cluster serves IO again as soon as
+	// possible.
+	readdressRestartOrder = []string{
+		"rook-ceph-mgr",
+		osdAppName,
+		"rook-ceph-mds",
+		"rook-ceph-rgw",
+		"rook-ceph-rbd-mirror",
+		"rook-ceph-fs-mirror",
+		"rook-ceph-nfs",
+		"rook-ceph-nvmeof",
+		"rook-ceph-crashcollector",
+		"rook-ceph-exporter",
+	}
+)
+
+// readdressMons runs before the existing mons are started. It re-addresses the mons whose address
+// changed, e.g. when the nodes of the mons on the host network were renumbered or the mon services
+// were recreated in a new service CIDR. The mons are only re-addressed when they lost quorum. The
+// annotation on the CephCluster also recreates the deleted mon services. The mons are stopped
+// and their monmap is rewritten by a Job, then the new addresses are saved in the mon endpoints,
+// the mon_host secret and the CSI config. Returns true while the mons are re-addressed, in which
+// case the mon deployments are updated without the upgrade checks since the mons have no quorum.
+func (c *Cluster) readdressMons(mons []*monConfig) (bool, error) {
+	state, err := c.readdressState()
+	if err != nil {
+		return false, err
+	}
+	if state == readdressRestartingValue || c.readdressRestart != nil {
+		// the mons run on their new addresses, the restart of the other daemons continues
+		return true, nil
+	}
+	requested := state == readdressRequestedValue
+	addresses, err := c.changedMonAddresses(mons, requested)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check the addresses of the mons")
+	}
+	if len(addresses) == 0 && !requested {
+		return false, nil
+	}
+	changed := slices.Sorted(maps.Keys(addresses))
+
+	// the monmap is only rewritten offline when the mons lost quorum. The mons in quorum are
+	// reachable, so they can be failed over to a new address instead of being stopped.
+	if _, err := cephclient.GetMonQuorumStatus(c.context, c.ClusterInfo); err == nil {
+		if requested {
+			log.NamespacedInfo(c.Namespace, logger, "re-addressing of the mons requested but the mons are in quorum, the monmap is not rewritten")
+			if len(addresses) > 0 {
+				log.NamespacedWarning(c.Namespace, logger, "the address of mons %v changed. fail over the mons to move them to their new address", changed)
+			}
+			return false, c.setReaddressAnnotation("")
+		}
+		log.NamespacedWarning(c.Namespace, logger, "the address of mons %v changed but the mons are in quorum. the mons are re-addressed if they lose quorum, or can be failed over to their new address", changed)
+		return false, nil
+	}
+	if !requested {
+		// the annotation marks the re-addressing in progress until the daemons are restarted, even
+		// if the operator restarts after the monmap was rewritten
+		log.NamespacedWarning(c.Namespace, logger, "mons %v lost quorum after their address changed. re-addressing the mons", changed)
+		if err := c.setReaddressAnnotation(readdressRequestedValue); err != nil {
+			return false, err
+		}
+	}
+
+	controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.ClusterInfo.NamespacedName(), k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, corev1.ConditionTrue, cephv1.ClusterProgressingReason, "Re-addressing the Ceph mons")
+	if len(addresses) == 0 {
+		// the monmap was already rewritten, the mons only need to be restarted
+		log.NamespacedInfo(c.Namespace, logger, "continuing the re-addressing of the mons")
+		return true, nil
+	}
+
+	log.NamespacedInfo(c.Namespace, logger, "re-addressing mons %v", changed)
+	if err := c.rewriteMonmaps(mons, addresses); err != nil {
+		return false, err
+	}
+
+	for _, m := range mons {
+		address, ok := addresses[m.DaemonName]
+		if !ok {
+			continue
+		}
+		log.NamespacedInfo(c.Namespace, logger, "mon %q address changed from %q to %q", m.DaemonName, m.PublicIP, address)
+		m.PublicIP = address
+		if schedule := c.mapping.Schedule[m.DaemonName]; m.UseHostNetwork && schedule != nil {
+			schedule.Address = address
+		}
+		c.ClusterInfo.InternalMonitors[m.DaemonName] = cephclient.NewMonInfo(m.DaemonName, address, m.Port)
+	}
+
+	// the endpoints, the mon_host secret and the CSI config must have the new addresses before the
+	// mons restart, so the daemons restarted after them connect to the new addresses
+	if err := c.saveMonConfig(); err != nil {
+		return false, errors.Wrap(err, "failed to save the new mon addresses")
+	}
+	return true, nil
+}
+
+// readdressRestart is the progress of the restart of the other daemons once the re-addressed mons
+// are in quorum. The daemons are restarted one step at a time over several reconciles.
+type readdressRestart struct {
+	// apps are the apps of the daemons not restarted yet, in the restart order
+	apps []string
+	// osdDomains are the OSD pods not restarted yet by failure domain, once the OSDs are restarted
+	osdDomains map[string][]corev1.Pod
+	// selector selects the pods restarted by the current step, empty between the steps
+	selector    string
+	description string
+	// since is the time the current step started, or the time the OSDs of the next failure domain
+	// started waiting to be ok to stop
+	since time.Time
+}
+
+// completeReaddressMons runs once the re-addressed mons are in quorum. The other daemons are
+// restarted in order to connect to the new mon addresses. Returns false while the daemons are
+// restarted, in which case it must be called again after readdressRequeueInterval. The progress is
+// not persisted, the restart starts over from the first daemons if the operator restarts.
+func (c *Cluster) completeReaddressMons() (bool, error) {
+	r := c.readdressRestart
+	if r == nil {
+		// the annotation marks the restart in progress, while the mons are in quorum again
+		if err := c.setReaddressAnnotation(readdressRestartingValue); err != nil {
+			return false, err
+		}
+		r = &readdressRestart{apps: slices.Clone(readdressRestartOrder), since: time.Now()}
+		c.readdressRestart = r
+	}
+
+	for {
+		if r.selector != "" {
+			running, err := k8sutil.PodsWithLabelAreAllRunning(c.ClusterInfo.Context, c.context.Clientset, c.Namespace, r.selector)
+			if err != nil || !running {
+				if time.Since(r.since) < readdressRestartTimeout {
+					log.NamespacedDebug(c.Namespace, logger, "waiting for the %s to run", r.description)
+					return false, nil
+				}
+				// the daemons that do not restart are reconciled by their controllers
+				log.NamespacedWarning(c.Namespace, logger, "timed out waiting for the %s to run, continuing with the next daemons", r.description)
+			}
+			r.selector = ""
+			r.since = time.Now()
+		}
+		if len(r.apps) == 0 {
+			break
+		}
+		waiting, err := c.restartNextDaemons(r)
+		if err != nil {
+			return false, err
+		}
+		if waiting {
+			return false, nil
+		}
+	}
+
+	c.readdressRestart = nil
+	log.NamespacedInfo(c.Namespace, logger, "re-addressing of the mons completed")
+	return true, c.setReaddressAnnotation("")
+}
+
+// restartNextDaemons restarts the pods of the next app, or the OSDs of the next failure domain. The
+// OSDs of a failure domain are only restarted once they are ok to stop, like the OSD updates.
+// Returns true while the restarted pods or the OSDs to restart are waited for.
+func (c *Cluster) restartNextDaemons(r *readdressRestart) (bool, error) {
+	app := r.apps[0]
+	selector := fmt.Sprintf("%s=%s", k8sutil.AppAttr, app)
+	if app != osdAppName {
+		r.apps = r.apps[1:]
+		pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to list the %q pods", app)
+		}
+		if len(pods.Items) == 0 {
+			return false, nil
+		}
+		log.NamespacedInfo(c.Namespace, logger, "restarting %d %q pods to connect to the new mon addresses", len(pods.Items), app)
+		return true, c.restartPods(r, pods.Items, selector, fmt.Sprintf("%q pods", app))
+	}
+
+	if r.osdDomains == nil {
+		pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to list the %q pods", app)
+		}
+		r.osdDomains = map[string][]corev1.Pod{}
+		for _, pod := range pods.Items {
+			domain := pod.Labels[osdFailureDomainLabel]
+			if domain == "" {
+				domain = pod.Spec.NodeName
+			}
+			r.osdDomains[domain] = append(r.osdDomains[domain], pod)
+		}
+		if len(pods.Items) > 0 {
+			log.NamespacedInfo(c.Namespace, logger, "restarting %d OSD pods one failure domain at a time to connect to the new mon addresses", len(pods.Items))
+		}
+	}
+	if len(r.osdDomains) == 0 {
+		r.apps = r.apps[1:]
+		r.osdDomains = nil
+		return false, nil
+	}
+
+	domain := slices.Sorted(maps.Keys(r.osdDomains))[0]
+	pods := r.osdDomains[domain]
+	osdIDs := []string{}
+	for _, pod := range pods {
+		osdIDs = append(osdIDs, pod.Labels[osdIDLabel])
+	}
+	checkOkToStop := !c.spec.SkipUpgradeChecks && cephclient.OSDUpdateShouldCheckOkToStop(c.context, c.ClusterInfo)
+	if checkOkToStop && !c.osdsOkToStop(osdIDs) {
+		if time.Since(r.since) < readdressRestartTimeout {
+			log.NamespacedInfo(c.Namespace, logger, "waiting for OSDs %v in failure domain %q to be ok to stop", osdIDs, domain)
+			return true, nil
+		}
+		if !c.spec.ContinueUpgradeAfterChecksEvenIfNotHealthy {
+			log.NamespacedWarning(c.Namespace, logger, "OSDs %v in failure domain %q are not ok to stop, not restarting them. restart their pods once the PGs are healthy to connect them to the new mon addresses", osdIDs, domain)
+			delete(r.osdDomains, domain)
+			r.since = time.Now()
+			return false, nil
+		}
+		log.NamespacedInfo(c.Namespace, logger, "OSDs %v are not ok to stop but 'continueUpgradeAfterChecksEvenIfNotHealthy' is true, so restarting them", osdIDs)
+	}
+
+	log.NamespacedInfo(c.Namespace, logger, "restarting OSDs %v in failure domain %q", osdIDs, domain)
+	delete(r.osdDomains, domain)
+	selector = fmt.Sprintf("%s=%s,%s in (%s)", k8sutil.AppAttr, osdAppName, osdIDLabel, strings.Join(osdIDs, ","))
+	return true, c.restartPods(r, pods, selector, fmt.Sprintf("OSD %v pods", osdIDs))
+}
+
+// osdsOkToStop returns true if all the OSDs can be stopped together
+func (c *Cluster) osdsOkToStop(osdIDs []string) bool {
+	ids := make([]int, 0, len(osdIDs))
+	for _, osdID := range osdIDs {
+		id, err := strconv.Atoi(osdID)
+		if err != nil {
+			log.NamespacedWarning(c.Namespace, logger, "invalid OSD ID %q in the labels of an OSD pod", osdID)
+			return false
+		}
+		ids = append(ids, id)
+	}
+
+	okToStop, err := cephclient.OSDOkToStop(c.context, c.ClusterInfo, ids[0], uint32(len(ids)))
+	if err != nil {
+		log.NamespacedDebug(c.Namespace, logger, "OSDs %v are not ok to stop. %v", osdIDs, err)
+		return false
+	}
+	return !slices.ContainsFunc(ids, func(id int) bool { return !slices.Contains(okToStop, id) })
+}
+
+// restartPods deletes the pods, whose replacements selected by the selector are waited for by the
+// next steps of the restart
+func (c *Cluster) restartPods(r *readdressRestart, pods []corev1.Pod, selector, description string) error {
+	for _, pod := range pods {
+		err := c.context.Clientset.CoreV1().Pods(c.Namespace).Delete(c.ClusterInfo.Context, pod.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete pod %q", pod.Name)
+		}
+	}
+	r.selector = selector
+	r.description = description
+	r.since = time.Now()
+	return nil
+}
+
+// changedMonAddresses returns the new address of the mons whose address changed
+func (c *Cluster) changedMonAddresses(mons []*monConfig, requested bool) (map[string]string, error) {
+	addresses := map[string]string{}
+	for _, m := range mons {
+		if isFloatingMon(c, m.DaemonName) {
+			continue
+		}
+
+		var address string
+		if m.UseHostNetwork {
+			schedule := c.mapping.Schedule[m.DaemonName]
+			if schedule == nil || schedule.Name == "" {
+				continue
+			}
+			node, err := c.context.Clientset.CoreV1().Nodes().Get(c.ClusterInfo.Context, schedule.Name, metav1.GetOptions{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					log.NamespacedWarning(c.Namespace, logger, "node %q of mon %q not found, the mon must be failed over", schedule.Name, m.DaemonName)
+					continue
+				}
+				return nil, errors.Wrapf(err, "failed to get node %q of mon %q", schedule.Name, m.DaemonName)
+			}
+			nodeInfo, err := getNodeInfoFromNode(*node)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get the address of node %q of mon %q", node.Name, m.DaemonName)
+			}
+			address = nodeInfo.Address
+		} else {
+			if c.spec.Network.MultiClusterService.Enabled {
+				// the exported IPs are not allocated from the service CIDR of the cluster
+				continue
+			}
+			service, err := c.context.Clientset.CoreV1().Services(c.Namespace).Get(c.ClusterInfo.Context, m.ResourceName, metav1.GetOptions{})
+			if err != nil {
+				if !kerrors.IsNotFound(err) {
+					return nil, errors.Wrapf(err, "failed to get service of mon %q", m.DaemonName)
+				}
+				if !requested {
+					// the service is recreated with the address of the mon when the mon is started
+					continue
+				}
+				// the previous address of the mon may not be valid anymore in the service CIDR
+				newMon := *m
+				newMon.PublicIP = ""
+				service, err = c.createService(&newMon)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to recreate service of mon %q", m.DaemonName)
+				}
+				if service == nil {
+					continue
+				}
+			}
+			address = service.Spec.ClusterIP
+		}
+
+		if address != "" && address != m.PublicIP {
+			addresses[m.DaemonName] = address
+		}
+	}
+	return addresses, nil
+}
+
+// rewriteMonmaps stops the mons and replaces the address of the changed mons in the monmap of
+// every mon
+func (c *Cluster) rewriteMonmaps(mons []*monConfig, addresses map[string]string) error {
+	deployments := map[string]*corev1.PodTemplateSpec{}
+	for _, m := range mons {
+		if isFloatingMon(c, m.DaemonName) {
+			continue
+		}
+		d, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).Get(c.ClusterInfo.Context, m.ResourceName, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamespacedWarning(c.Namespace, logger, "deployment of mon %q not found, skipping the rewrite of its monmap", m.DaemonName)
+				continue
+			}
+			return errors.Wrapf(err, "failed to get deployment of mon %q", m.DaemonName)
+		}
+		deployments[m.DaemonName] = &d.Spec.Template
+
+		if err := c.updateMonDeploymentReplica(m.DaemonName, false); err != nil {
+			return errors.Wrapf(err, "failed to stop mon %q", m.DaemonName)
+		}
+	}
+
+	for _, m := range mons {
+		template, ok := deployments[m.DaemonName]
+		if !ok {
+			continue
+		}
+		if err := c.waitForMonPodToStop(m.DaemonName); err != nil {
+			return err
+		}
+
+		job, err := c.makeReaddressJob(m, template, mons, addresses)
+		if err != nil {
+			return err
+		}
+		log.NamespacedInfo(c.Namespace, logger, "rewriting the monmap of mon %q", m.DaemonName)
+		if err := k8sutil.RunReplaceableJob(c.ClusterInfo.Context, c.context.Clientset, job, true); err != nil {
+			return errors.Wrapf(err, "failed to run the job to rewrite the monmap of mon %q", m.DaemonName)
+		}
+		if err := c.waitForReaddressJob(job.Name); err != nil {
+			return errors.Wrapf(err, "failed to rewrite the monmap of mon %q", m.DaemonName)
+		}
+		// the job must be gone before the mon mounts its data again
+		if err := k8sutil.DeleteBatchJob(c.ClusterInfo.Context, c.context.Clientset, c.Namespace, job.Name, true); err != nil {
+			return errors.Wrapf(err, "failed to delete the job that rewrote the monmap of mon %q", m.DaemonName)
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) waitForMonPodToStop(name string) error {
+	selector := fmt.Sprintf("%s=%s,%s=%s,mon_canary!=true", k8sutil.AppAttr, AppName, config.MonType, name)
+	for i := 0; i < readdressRetries; i++ {
+		pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list the pods of mon %q", name)
+		}
+		if len(pods.Items) == 0 {
+			return nil
+		}
+		log.NamespacedInfo(c.Namespace, logger, "waiting for mon %q to stop", name)
+		time.Sleep(readdressRetryInterval)
+	}
+	return errors.Errorf("timed out waiting for mon %q to stop", name)
+}
+
+func (c *Cluster) waitForReaddressJob(name string) error {
+	for i := 0; i < readdressRetries; i++ {
+		job, err := c.context.Clientset.BatchV1().Jobs(c.Namespace).Get(c.ClusterInfo.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get job %q", name)
+		}
+		if job.Status.Succeeded > 0 {
+			return nil
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batch.JobFailed && condition.Status == corev1.ConditionTrue {
+				return errors.Errorf("job %q failed. %s", name, condition.Message)
+			}
+		}
+		time.Sleep(readdressRetryInterval)
+	}
+	return errors.Errorf("timed out waiting for job %q to complete", name)
+}
+
+// makeReaddressJob makes the Job that rewrites the monmap of a mon. The Job runs with the pod spec
+// of the mon deployment so it mounts the same mon store, on the same node.
+func (c *Cluster) makeReaddressJob(m *monConfig, template *corev1.PodTemplateSpec, mons []*monConfig, addresses map[string]string) (*batch.Job, error) {
+	monContainer, err := k8sutil.GetContainerByName(template.Spec.Containers, monContainerName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the %q container of mon %q", monContainerName, m.DaemonName)
+	}
+
+	flags := append(
+		controller.DaemonFlags(c.ClusterInfo, &c.spec, m.DaemonName),
+		config.NewFlag("setuser-match-path", path.Join(m.DataPathMap.ContainerDataDir, "store.db")),
+	)
+	container := corev1.Container{
+		Name:            readdressContainerName,
+		Image:           monContainer.Image,
+		ImagePullPolicy: monContainer.ImagePullPolicy,
+		Command:         []string{"/bin/bash", "-c"},
+		Args:            []string{readdressMonmapScript(flags, mons, addresses)},
+		Env:             monContainer.Env,
+		EnvFrom:         monContainer.EnvFrom,
+		VolumeMounts:    monContainer.VolumeMounts,
+		SecurityContext: monContainer.SecurityContext,
+		Resources:       monContainer.Resources,
+	}
+
+	podSpec := *template.Spec.DeepCopy()
+	podSpec.InitContainers = nil
+	podSpec.Containers = []corev1.Container{container}
+	podSpec.RestartPolicy = corev1.RestartPolicyOnFailure
+
+	labels := controller.AppLabels(readdressJobAppName, c.Namespace)
+	labels[config.MonType] = m.DaemonName
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(readdressJobNameFmt, m.DaemonName),
+			Namespace: c.Namespace,
+			Labels:    labels,
+		},
+		Spec: batch.JobSpec{
+			BackoffLimit:          &readdressJobBackoffLimit,
+			ActiveDeadlineSeconds: &readdressJobActiveDeadlineSeconds,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+	k8sutil.AddRookVersionLabelToJob(job)
+	if err := c.ownerInfo.SetControllerReference(job); err != nil {
+		return nil, errors.Wrapf(err, "failed to set owner reference to the job of mon %q", m.DaemonName)
+	}
+	return job, nil
+}
+
+// readdressMonmapScript extracts the monmap from the mon store, replaces the address of the changed
+// mons and injects the monmap back in the mon store
+func readdressMonmapScript(flags []string, mons []*monConfig, addresses map[string]string) string {
+	quoted := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		quoted = append(quoted, "'"+strings.ReplaceAll(flag, "'", `'\''`)+"'")
+	}
+	monFlags := strings.Join(quoted, " ")
+
+	script := []string{
+		"set -xe",
+		fmt.Sprintf("ceph-mon %s --extract-monmap %s", monFlags, readdressMonmapPath),
+		fmt.Sprintf("monmaptool --print %s", readdressMonmapPath),
+	}
+	for _, m := range mons {
+		address, ok := addresses[m.DaemonName]
+		if !ok {
+			continue
+		}
+		script = append(script,
+			// the mon may be missing from the monmap if a previous run of the job failed
+			fmt.Sprintf("monmaptool --rm %s %s || true", m.DaemonName, readdressMonmapPath),
+			fmt.Sprintf("monmaptool --addv %s '%s' %s", m.DaemonName, monmapAddrVec(address, m.Port), readdressMonmapPath),
+		)
+	}
+	script = append(script,
+		fmt.Sprintf("monmaptool --print %s", readdressMonmapPath),
+		fmt.Sprintf("ceph-mon %s --inject-monmap %s", monFlags, readdressMonmapPath),
+	)
+	return strings.Join(script, "\n")
+}
+
+// monmapAddrVec returns the address vector of a mon in the monmap, with the msgr1 port only if the
+// mon listens on it
+func monmapAddrVec(ip string, port int32) string {
+	msgr2 := net.JoinHostPort(ip, strconv.Itoa(int(DefaultMsgr2Port)))
+	if port == DefaultMsgr2Port {
+		return fmt.Sprintf("[v2:%s]", msgr2)
+	}
+	return fmt.Sprintf("[v2:%s,v1:%s]", msgr2, net.JoinHostPort(ip, strconv.Itoa(int(port))))
+}
+
+// readdressState returns the value of the re-addressing annotation of the cluster
+func (c *Cluster) readdressState() (string, error) {
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "failed to get cluster to check if the mons must be re-addressed")
+	}
+	return cephCluster.Annotations[cephv1.ReaddressMonsAnnotationKey], nil
+}
+
+// setReaddressAnnotation sets the re-addressing annotation of the cluster to the value, or removes
+// it if the value is empty
+func (c *Cluster) setReaddressAnnotation(value string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+			return err
+		}
+		if cephCluster.Annotations[cephv1.ReaddressMonsAnnotationKey] == value {
+			return nil
+		}
+		if value != "" {
+			if cephCluster.Annotations == nil {
+				cephCluster.Annotations = map[string]string{}
+			}
+			cephCluster.Annotations[cephv1.ReaddressMonsAnnotationKey] = value
+		} else {
+			delete(cephCluster.Annotations, cephv1.ReaddressMonsAnnotationKey)
+		}
+		return c.context.Client.Update(c.ClusterInfo.Context, cephCluster)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the %q annotation of the cluster", cephv1.ReaddressMonsAnnotationKey)
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	csiopv1 "github.com/ceph/ceph-csi-operator/api/v1"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	cephclientfake "github.com/rook/rook/pkg/daemon/ceph/client/fake"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	cephutil "github.com/rook/rook/pkg/daemon/ceph/util"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batch "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReaddressMons(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "default"
+	defer func(retries int, interval, timeout time.Duration) {
+		readdressRetries, readdressRetryInterval, readdressRestartTimeout = retries, interval, timeout
+	}(readdressRetries, readdressRetryInterval, readdressRestartTimeout)
+	readdressRetries, readdressRetryInterval, readdressRestartTimeout = 1, 0, time.Hour
+
+	node := func(name, ip string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     v1.NodeStatus{Addresses: []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: ip}}},
+		}
+	}
+	// the nodes of mons a and c were renumbered
+	clientset := k8sfake.NewClientset(node("node-a", "10.0.0.1"), node("node-b", "1.2.3.2"), node("node-c", "10.0.0.3"))
+	jobs := []*batch.Job{}
+	clientset.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		job := action.(k8stesting.CreateAction).GetObject().(*batch.Job)
+		job.Status.Succeeded = 1
+		jobs = append(jobs, job)
+		return false, nil, nil
+	})
+
+	inQuorum := true
+	// the OSDs ok to stop, and the ok-to-stop checks and the pods deleted in order
+	okToStop := map[string][]int{}
+	restarts := []string{}
+	clientset.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		restarts = append(restarts, "delete "+action.(k8stesting.DeleteAction).GetName())
+		return false, nil, nil
+	})
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "quorum_status" {
+				if inQuorum {
+					return clienttest.MonInQuorumResponseMany(3), nil
+				}
+				return "", errors.New("timed out")
+			}
+			if args[0] == "osd" && args[1] == "ls" {
+				return "[0,1,2]", nil
+			}
+			if args[0] == "osd" && args[1] == "ok-to-stop" {
+				restarts = append(restarts, "ok-to-stop "+args[2])
+				queriedID, _ := strconv.Atoi(args[2])
+				if len(okToStop[args[2]]) == 0 {
+					return cephclientfake.OsdOkToStopOutput(queriedID, nil), errors.New("EBUSY")
+				}
+				return cephclientfake.OsdOkToStopOutput(queriedID, okToStop[args[2]]), nil
+			}
+			return "", errors.Errorf("unexpected ceph command %v", args)
+		},
+	}
+	executor.MockExecuteCommandWithOutput = func(command string, args ...string) (string, error) {
+		return executor.MockExecuteCommandWithTimeout(0, command, args...)
+	}
+
+	s := scheme.Scheme
+	require.NoError(t, csiopv1.AddToScheme(s))
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: namespace, Namespace: namespace}}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	clusterdContext := &clusterd.Context{Clientset: clientset, Client: cl, Executor: executor, ConfigDir: t.TempDir()}
+
+	c := New(ctx, clusterdContext, namespace, cephv1.ClusterSpec{}, cephclient.NewMinimumOwnerInfoWithOwnerRef())
+	c.spec.Network.Provider = cephv1.NetworkProviderHost
+	c.ClusterInfo = clienttest.CreateTestClusterInfo(3)
+	for _, name := range []string{"a", "b", "c"} {
+		c.mapping.Schedule[name] = &opcontroller.MonScheduleInfo{Name: "node-" + name, Address: cephutil.GetIPFromEndpoint(c.ClusterInfo.InternalMonitors[name].Endpoint)}
+	}
+	mons := c.clusterInfoToMonConfig()
+	require.Len(t, mons, 3)
+	// the mons are built from a map, so they are sorted for the assertions on mon a
+	slices.SortFunc(mons, func(a, b *monConfig) int { return strings.Compare(a.DaemonName, b.DaemonName) })
+	for _, m := range mons {
+		require.True(t, m.UseHostNetwork)
+		d, err := c.makeDeployment(m, false)
+		require.NoError(t, err)
+		_, err = clientset.AppsV1().Deployments(namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	annotation := func() string {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(ctx, c.ClusterInfo.NamespacedName(), cluster))
+		return cluster.Annotations[cephv1.ReaddressMonsAnnotationKey]
+	}
+	annotated := func() bool { return annotation() != "" }
+
+	t.Run("mons in quorum are not re-addressed", func(t *testing.T) {
+		readdressing, err := c.readdressMons(mons)
+		require.NoError(t, err)
+		assert.False(t, readdressing)
+		assert.False(t, annotated())
+		assert.Empty(t, jobs)
+		assert.Equal(t, "1.2.3.1", mons[0].PublicIP)
+	})
+
+	t.Run("requested re-addressing of mons in quorum does not rewrite the monmap", func(t *testing.T) {
+		require.NoError(t, c.setReaddressAnnotation(readdressRequestedValue))
+		readdressing, err := c.readdressMons(mons)
+		require.NoError(t, err)
+		assert.False(t, readdressing)
+		assert.False(t, annotated())
+		assert.Empty(t, jobs)
+		assert.Equal(t, "1.2.3.1", mons[0].PublicIP)
+	})
+
+	t.Run("mons that lost quorum are re-addressed", func(t *testing.T) {
+		inQuorum = false
+		readdressing, err := c.readdressMons(mons)
+		require.NoError(t, err)
+		assert.True(t, readdressing)
+		assert.True(t, annotated())
+
+		// the monmap of every mon is rewritten with the new addresses
+		require.Len(t, jobs, 3)
+		for _, job := range jobs {
+			script := job.Spec.Template.Spec.Containers[0].Args[0]
+			assert.Contains(t, script, "monmaptool --addv a '[v2:10.0.0.1:3300]' /tmp/monmap")
+			assert.Contains(t, script, "monmaptool --addv c '[v2:10.0.0.3:3300]' /tmp/monmap")
+			assert.NotContains(t, script, "--addv b")
+			assert.Equal(t, v1.RestartPolicyOnFailure, job.Spec.Template.Spec.RestartPolicy)
+			assert.True(t, job.Spec.Template.Spec.HostNetwork)
+		}
+		list, err := clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+
+		// the mons are stopped until they are started with the new address
+		for _, m := range mons {
+			d, err := clientset.AppsV1().Deployments(namespace).Get(ctx, m.ResourceName, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, int32(0), *d.Spec.Replicas)
+		}
+
+		assert.Equal(t, "10.0.0.1", mons[0].PublicIP)
+		assert.Equal(t, "10.0.0.1:3300", c.ClusterInfo.InternalMonitors["a"].Endpoint)
+		assert.Equal(t, "1.2.3.2:3300", c.ClusterInfo.InternalMonitors["b"].Endpoint)
+		assert.Equal(t, "10.0.0.3", c.mapping.Schedule["c"].Address)
+
+		cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, EndpointConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, cm.Data[EndpointDataKey], "a=10.0.0.1:3300")
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, config.StoreName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, secret.StringData["mon_host"], "[v2:10.0.0.3:3300]")
+	})
+
+	t.Run("re-addressing continues after the monmap was rewritten", func(t *testing.T) {
+		jobs = jobs[:0]
+		readdressing, err := c.readdressMons(mons)
+		require.NoError(t, err)
+		assert.True(t, readdressing)
+		assert.Empty(t, jobs)
+	})
+
+	osdPod := func(osdID, host string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "osd-" + osdID,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": osdAppName, osdIDLabel: osdID, osdFailureDomainLabel: host},
+		}}
+	}
+
+	createPod := func(pod *v1.Pod, phase v1.PodPhase) {
+		pod.Status.Phase = phase
+		_, err := clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	mgrPod := func(name string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": "rook-ceph-mgr"}}}
+	}
+	deleteAllPods := func() {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		for _, pod := range pods.Items {
+			require.NoError(t, clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}))
+		}
+		restarts = restarts[:0]
+	}
+
+	t.Run("daemons are restarted once the mons are in quorum", func(t *testing.T) {
+		createPod(mgrPod("mgr-a"), v1.PodRunning)
+		for _, pod := range []*v1.Pod{osdPod("0", "host-b"), osdPod("1", "host-a"), osdPod("2", "host-b")} {
+			createPod(pod, v1.PodRunning)
+		}
+		okToStop["1"] = []int{1}
+		okToStop["0"] = []int{0, 2}
+		inQuorum = true
+
+		// each step restarts the next daemons and waits for them to run at the next reconcile
+		completed, err := c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.Equal(t, []string{"delete mgr-a"}, restarts)
+		assert.Equal(t, readdressRestartingValue, annotation())
+
+		// the re-addressing continues while the mons are in quorum
+		readdressing, err := c.readdressMons(mons)
+		require.NoError(t, err)
+		assert.True(t, readdressing)
+		assert.Equal(t, readdressRestartingValue, annotation())
+
+		completed, err = c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.Equal(t, []string{"delete mgr-a"}, restarts)
+
+		createPod(mgrPod("mgr-b"), v1.PodRunning)
+		completed, err = c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.False(t, completed)
+		// the OSDs are restarted one failure domain at a time once they are ok to stop
+		assert.Equal(t, []string{"delete mgr-a", "ok-to-stop 1", "delete osd-1"}, restarts)
+
+		createPod(osdPod("1", "host-a"), v1.PodRunning)
+		completed, err = c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.Equal(t, []string{"delete mgr-a", "ok-to-stop 1", "delete osd-1", "ok-to-stop 0", "delete osd-0", "delete osd-2"}, restarts)
+
+		createPod(osdPod("0", "host-b"), v1.PodRunning)
+		createPod(osdPod("2", "host-b"), v1.PodPending)
+		completed, err = c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.False(t, completed)
+
+		_, err = clientset.CoreV1().Pods(namespace).UpdateStatus(ctx, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "osd-2", Namespace: namespace, Labels: osdPod("2", "host-b").Labels}, Status: v1.PodStatus{Phase: v1.PodRunning}}, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		completed, err = c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.False(t, annotated())
+		assert.Nil(t, c.readdressRestart)
+		assert.Len(t, restarts, 6)
+		deleteAllPods()
+	})
+
+	t.Run("OSDs not ok to stop are not restarted", func(t *testing.T) {
+		createPod(osdPod("1", "host-a"), v1.PodRunning)
+		okToStop["1"] = nil
+
+		completed, err := c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.Equal(t, []string{"ok-to-stop 1"}, restarts)
+
+		// the OSDs are skipped once they are not ok to stop for too long
+		readdressRestartTimeout = 0
+		completed, err = c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, []string{"ok-to-stop 1", "ok-to-stop 1"}, restarts)
+		_, err = clientset.CoreV1().Pods(namespace).Get(ctx, "osd-1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		readdressRestartTimeout = time.Hour
+		deleteAllPods()
+	})
+
+	t.Run("daemons that do not restart are skipped after the timeout", func(t *testing.T) {
+		createPod(mgrPod("mgr-a"), v1.PodRunning)
+		completed, err := c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.False(t, completed)
+
+		readdressRestartTimeout = 0
+		completed, err = c.completeReaddressMons()
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, []string{"delete mgr-a"}, restarts)
+		readdressRestartTimeout = time.Hour
+		deleteAllPods()
+	})
+
+	t.Run("requested re-addressing of mons in quorum is a no-op", func(t *testing.T) {
+		require.NoError(t, c.setReaddressAnnotation(readdressRequestedValue))
+		inQuorum = true
+		readdressing, err := c.readdressMons(mons)
+		require.NoError(t, err)
+		assert.False(t, readdressing)
+		assert.False(t, annotated())
+	})
+}
+
+func TestReaddressMonmapScript(t *testing.T) {
+	mons := []*monConfig{
+		{DaemonName: "a", Port: DefaultMsgr2Port},
+		{DaemonName: "b", Port: DefaultMsgr1Port},
+		{DaemonName: "c", Port: DefaultMsgr2Port},
+	}
+	addresses := map[string]string{"a": "10.0.0.1", "b": "fd00::2"}
+	script := readdressMonmapScript([]string{"--id=a", "--default-log-stderr-prefix=debug "}, mons, addresses)
+
+	lines := strings.Split(script, "\n")
+	assert.Equal(t, []string{
+		"set -xe",
+		"ceph-mon '--id=a' '--default-log-stderr-prefix=debug ' --extract-monmap /tmp/monmap",
+		"monmaptool --print /tmp/monmap",
+		"monmaptool --rm a /tmp/monmap || true",
+		"monmaptool --addv a '[v2:10.0.0.1:3300]' /tmp/monmap",
+		"monmaptool --rm b /tmp/monmap || true",
+		"monmaptool --addv b '[v2:[fd00::2]:3300,v1:[fd00::2]:6789]' /tmp/monmap",
+		"monmaptool --print /tmp/monmap",
+		"ceph-mon '--id=a' '--default-log-stderr-prefix=debug ' --inject-monmap /tmp/monmap",
+	}, lines)
+}
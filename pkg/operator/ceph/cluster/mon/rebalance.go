@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// MonRebalanceEventReason is the reason of the events emitted when a mon is moved to another
+	// failure domain
+	MonRebalanceEventReason = "MonRebalance"
+	// defaultMonRebalanceInterval is the minimum time between moving two mons
+	defaultMonRebalanceInterval = time.Hour
+)
+
+// monMove is a mon to move from the most populated failure domain to the least populated one
+type monMove struct {
+	mon       string
+	from      string
+	fromCount int
+	to        string
+	toCount   int
+}
+
+func (m *monMove) String() string {
+	return fmt.Sprintf("mon %q is moved from failure domain %q with %d mons to failure domain %q with %d mons",
+		m.mon, m.from, m.fromCount, m.to, m.toCount)
+}
+
+// monRebalanceFailureDomainLabel returns the node label the mons are spread across
+func monRebalanceFailureDomainLabel(spec cephv1.ClusterSpec) string {
+	if spec.Mon.FailureDomainLabel != "" {
+		return spec.Mon.FailureDomainLabel
+	}
+	return corev1.LabelZoneFailureDomainStable
+}
+
+// rebalanceMons fails over one mon to an underrepresented failure domain if the mons are not spread
+// evenly across the failure domains of the nodes the mons can be placed on. It is only called when
+// all the mons are in quorum, and moves at most one mon per interval. Returns whether a mon was
+// moved.
+func (c *Cluster) rebalanceMons() (bool, error) {
+	spec := c.spec.Mon.Rebalance
+	if spec == nil {
+		return false, nil
+	}
+	// the zones of the mons are specified explicitly
+	if c.spec.ZonesRequired() {
+		log.NamespacedDebug(c.Namespace, logger, "skipping mon rebalancing since the mon zones are specified")
+		return false, nil
+	}
+	// mons on PVCs without host networking are placed by the scheduler instead of being assigned to a node
+	if c.spec.Mon.VolumeClaimTemplate != nil && !c.spec.Network.IsHost() {
+		log.NamespacedDebug(c.Namespace, logger, "skipping mon rebalancing since the mons are placed by the scheduler on their pvcs")
+		return false, nil
+	}
+	if len(c.monsToFailover) > 0 || c.readdressingMons {
+		return false, nil
+	}
+
+	interval := defaultMonRebalanceInterval
+	if spec.MinInterval != nil && spec.MinInterval.Duration > 0 {
+		interval = spec.MinInterval.Duration
+	}
+	lastMonRebalance, err := c.getLastMonRebalance()
+	if err != nil {
+		return false, err
+	}
+	if !lastMonRebalance.IsZero() && time.Since(lastMonRebalance) < interval {
+		log.NamespacedDebug(c.Namespace, logger, "skipping mon rebalancing since a mon was moved less than %s ago", interval)
+		return false, nil
+	}
+
+	move, err := c.findMonToRebalance()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check the spread of the mons across the failure domains")
+	}
+	if move == nil {
+		return false, nil
+	}
+
+	// the interval is also waited after a failed move so the failover is not retried in a loop, and
+	// after a restart of the operator
+	if err := c.commitLastMonRebalance(time.Now()); err != nil {
+		return false, err
+	}
+	log.NamespacedInfo(c.Namespace, logger, "mons are not spread evenly across the failure domains of label %q. %s", monRebalanceFailureDomainLabel(c.spec), move)
+	c.recordMonRebalanceEvent(corev1.EventTypeNormal, "mons are not spread evenly across the failure domains of label %q, %s",
+		monRebalanceFailureDomainLabel(c.spec), move)
+
+	if err := c.failoverMonToFailureDomain(move.mon, move.to); err != nil {
+		c.recordMonRebalanceEvent(corev1.EventTypeWarning, "failed to move mon %q to failure domain %q. %v", move.mon, move.to, err)
+		return false, errors.Wrapf(err, "failed to move mon %q to failure domain %q", move.mon, move.to)
+	}
+	return true, nil
+}
+
+// getLastMonRebalance returns the time a mon was last moved to another failure domain, as stored in
+// the mon endpoints configmap, or a zero time if no mon was moved
+func (c *Cluster) getLastMonRebalance() (time.Time, error) {
+	if !c.lastMonRebalance.IsZero() {
+		return c.lastMonRebalance, nil
+	}
+	configmap, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Get(c.ClusterInfo.Context, EndpointConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(err, "failed to get the mon endpoints configmap to load the time of the last mon rebalancing")
+	}
+	value, ok := configmap.Data[lastMonRebalanceKey]
+	if !ok || value == "" {
+		return time.Time{}, nil
+	}
+	lastMonRebalance, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.NamespacedWarning(c.Namespace, logger, "failed to parse the time of the last mon rebalancing %q. %v", value, err)
+		return time.Time{}, nil
+	}
+	c.lastMonRebalance = lastMonRebalance
+	return lastMonRebalance, nil
+}
+
+// commitLastMonRebalance stores the time a mon was last moved to another failure domain in the mon
+// endpoints configmap, so that the interval between two moves is kept across operator restarts
+func (c *Cluster) commitLastMonRebalance(lastMonRebalance time.Time) error {
+	configmap, err := c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Get(c.ClusterInfo.Context, EndpointConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to find existing mon endpoint config map")
+	}
+	if configmap.Data == nil {
+		configmap.Data = map[string]string{}
+	}
+	configmap.Data[lastMonRebalanceKey] = lastMonRebalance.Format(time.RFC3339)
+	if _, err = c.context.Clientset.CoreV1().ConfigMaps(c.Namespace).Update(c.ClusterInfo.Context, configmap, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update mon endpoint config map for the time of the last mon rebalancing")
+	}
+	c.lastMonRebalance = lastMonRebalance
+	return nil
+}
+
+// findMonToRebalance returns the mon to move to another failure domain, or nil if the mons are
+// spread evenly
+func (c *Cluster) findMonToRebalance() (*monMove, error) {
+	label := monRebalanceFailureDomainLabel(c.spec)
+
+	pods, err := c.context.Clientset.CoreV1().Pods(c.Namespace).List(c.ClusterInfo.Context, metav1.ListOptions{LabelSelector: fmt.Sprintf("app=%s", AppName)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list mon pods")
+	}
+	monNodes := map[string]string{}
+	nodesWithMons := sets.New[string]()
+	for _, pod := range pods.Items {
+		if _, ok := pod.Labels["mon_canary"]; ok {
+			continue
+		}
+		monNodes[pod.Labels["mon"]] = pod.Spec.NodeName
+		nodesWithMons.Insert(pod.Spec.NodeName)
+	}
+
+	nodes, err := c.context.Clientset.CoreV1().Nodes().List(c.ClusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list nodes")
+	}
+	nodeDomains := map[string]string{}
+	for _, node := range nodes.Items {
+		nodeDomains[node.Name] = node.Labels[label]
+	}
+
+	// count the mons in each failure domain
+	domainMons := map[string][]string{}
+	for _, name := range slices.Sorted(maps.Keys(c.ClusterInfo.InternalMonitors)) {
+		nodeName := monNodes[name]
+		if nodeName == "" {
+			log.NamespacedDebug(c.Namespace, logger, "skipping mon rebalancing since mon %q is not running on a node", name)
+			return nil, nil
+		}
+		domain := nodeDomains[nodeName]
+		if domain == "" {
+			log.NamespacedDebug(c.Namespace, logger, "skipping mon rebalancing since node %q of mon %q has no label %q", nodeName, name, label)
+			return nil, nil
+		}
+		domainMons[domain] = append(domainMons[domain], name)
+	}
+
+	// the mons can be moved to the failure domains with a node that is valid for the mon placement
+	// and not running a mon yet
+	placement := c.getMonPlacement("")
+	targetDomains := map[string]bool{}
+	for _, node := range nodes.Items {
+		domain := node.Labels[label]
+		if domain == "" || targetDomains[domain] {
+			continue
+		}
+		if err := k8sutil.ValidNode(node, placement, false); err != nil {
+			continue
+		}
+		if !c.spec.Mon.AllowMultiplePerNode && nodesWithMons.Has(node.Name) {
+			continue
+		}
+		targetDomains[domain] = true
+	}
+
+	var move *monMove
+	for _, from := range slices.Sorted(maps.Keys(domainMons)) {
+		if move == nil || len(domainMons[from]) > move.fromCount {
+			move = &monMove{mon: domainMons[from][0], from: from, fromCount: len(domainMons[from])}
+		}
+	}
+	if move == nil {
+		return nil, nil
+	}
+	to := ""
+	for _, domain := range slices.Sorted(maps.Keys(targetDomains)) {
+		if to == "" || len(domainMons[domain]) < len(domainMons[to]) {
+			to = domain
+		}
+	}
+	// moving a mon would not improve the spread
+	if to == "" || move.fromCount-len(domainMons[to]) <= 1 {
+		return nil, nil
+	}
+	move.to = to
+	move.toCount = len(domainMons[to])
+	return move, nil
+}
+
+// recordMonRebalanceEvent emits an event on the CephCluster about moving a mon
+func (c *Cluster) recordMonRebalanceEvent(eventType, messageFmt string, args ...any) {
+	if c.recorder == nil {
+		return
+	}
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.ClusterInfo.NamespacedName(), cephCluster); err != nil {
+		log.NamespacedWarning(c.Namespace, logger, "failed to get the ceph cluster to record the mon rebalancing event. %v", err)
+		return
+	}
+	c.recorder.Eventf(cephCluster, nil, eventType, MonRebalanceEventReason, "Failover", messageFmt, args...)
+}
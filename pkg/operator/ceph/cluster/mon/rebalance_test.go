@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	csiopv1 "github.com/ceph/ceph-csi-operator/api/v1"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRebalanceMons(t *testing.T) {
+	ctx := context.TODO()
+	clientset := test.New(t, 0)
+	for i, zone := range []string{"zone-a", "zone-a", "zone-b", "zone-c"} {
+		name := fmt.Sprintf("node%d", i+1)
+		test.AddReadyNode(t, clientset, name, fmt.Sprintf("10.0.0.%d", i+1))
+		node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		node.Labels[v1.LabelTopologyZone] = zone
+		_, err = clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			return "{\"key\":\"mysecurekey\"}", nil
+		},
+	}
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "testing", Namespace: "ns"}}
+	s := scheme.Scheme
+	require.NoError(t, csiopv1.AddToScheme(s))
+	client := fake.NewClientBuilder().WithScheme(s).WithObjects(cephCluster).Build()
+	context := &clusterd.Context{Clientset: clientset, Client: client, ConfigDir: t.TempDir(), Executor: executor}
+	c := New(ctx, context, "ns", cephv1.ClusterSpec{}, cephclient.NewMinimumOwnerInfoWithOwnerRef())
+	setCommonMonProperties(c, 3, cephv1.MonSpec{Count: 3}, "myversion")
+	c.ClusterInfo.Namespace = "ns"
+	c.ClusterInfo.SetName("testing")
+	c.maxMonID = 2
+	c.waitForStart = false
+	recorder := events.NewFakeRecorder(10)
+	c.SetEventRecorder(recorder)
+
+	// mons a and b are in zone-a, mon c is in zone-b and no mon is in zone-c
+	createTestMonPod(t, clientset, c, "a", "node1")
+	createTestMonPod(t, clientset, c, "b", "node2")
+	createTestMonPod(t, clientset, c, "c", "node3")
+	require.NoError(t, c.saveMonConfig())
+
+	t.Run("mons are moved from the most to the least populated failure domain", func(t *testing.T) {
+		move, err := c.findMonToRebalance()
+		require.NoError(t, err)
+		assert.Equal(t, &monMove{mon: "a", from: "zone-a", fromCount: 2, to: "zone-c", toCount: 0}, move)
+	})
+
+	t.Run("failure domains without an available node are not a target", func(t *testing.T) {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, "node4", metav1.GetOptions{})
+		require.NoError(t, err)
+		node.Spec.Unschedulable = true
+		_, err = clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		move, err := c.findMonToRebalance()
+		require.NoError(t, err)
+		assert.Nil(t, move)
+
+		node.Spec.Unschedulable = false
+		_, err = clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("mons are not moved unless requested", func(t *testing.T) {
+		moved, err := c.rebalanceMons()
+		require.NoError(t, err)
+		assert.False(t, moved)
+
+		c.spec.Mon.Rebalance = &cephv1.MonRebalanceSpec{}
+		c.spec.Mon.Zones = []cephv1.MonZoneSpec{{Name: "zone-a"}, {Name: "zone-b"}, {Name: "zone-c"}}
+		moved, err = c.rebalanceMons()
+		require.NoError(t, err)
+		assert.False(t, moved)
+		c.spec.Mon.Zones = nil
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("a mon is failed over to the target failure domain", func(t *testing.T) {
+		waitForMonitorScheduling = func(c *Cluster, d *apps.Deployment) (SchedulingResult, error) {
+			terms := d.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			require.Len(t, terms, 1)
+			assert.Equal(t, []v1.NodeSelectorRequirement{{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"zone-c"}}}, terms[0].MatchExpressions)
+			node, err := clientset.CoreV1().Nodes().Get(ctx, "node4", metav1.GetOptions{})
+			return SchedulingResult{Node: node}, err
+		}
+		defer func() { waitForMonitorScheduling = realWaitForMonitorScheduling }()
+
+		moved, err := c.rebalanceMons()
+		require.NoError(t, err)
+		assert.True(t, moved)
+		_, err = clientset.AppsV1().Deployments(c.Namespace).Get(ctx, "rook-ceph-mon-d", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, c.maxMonID)
+		assert.Equal(t, "node4", c.mapping.Schedule["d"].Name)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, `Normal MonRebalance mons are not spread evenly across the failure domains of label "topology.kubernetes.io/zone", mon "a" is moved from failure domain "zone-a" with 2 mons to failure domain "zone-c" with 0 mons`)
+	})
+
+	t.Run("mons are moved at most once per interval", func(t *testing.T) {
+		// the failover is reverted
+		delete(c.ClusterInfo.InternalMonitors, "d")
+		c.ClusterInfo.InternalMonitors["a"] = cephclient.NewMonInfo("a", "1.2.3.1", 3300)
+		moved, err := c.rebalanceMons()
+		require.NoError(t, err)
+		assert.False(t, moved)
+
+		// the time of the last move is kept across operator restarts
+		cm, err := clientset.CoreV1().ConfigMaps(c.Namespace).Get(ctx, EndpointConfigMapName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, cm.Data[lastMonRebalanceKey])
+		lastMonRebalance := c.lastMonRebalance
+		c.lastMonRebalance = time.Time{}
+		require.NoError(t, c.saveMonConfig())
+		c.lastMonRebalance = time.Time{}
+		moved, err = c.rebalanceMons()
+		require.NoError(t, err)
+		assert.False(t, moved)
+		assert.WithinDuration(t, lastMonRebalance, c.lastMonRebalance, time.Second)
+
+		// the mons are still not spread evenly
+		move, err := c.findMonToRebalance()
+		require.NoError(t, err)
+		assert.NotNil(t, move)
+
+		// after the interval, the mons are not moved while another mon is failed over
+		c.spec.Mon.Rebalance.MinInterval = &metav1.Duration{Duration: time.Minute}
+		c.lastMonRebalance = time.Now().Add(-2 * time.Minute)
+		c.monsToFailover["c"] = &monConfig{}
+		moved, err = c.rebalanceMons()
+		require.NoError(t, err)
+		assert.False(t, moved)
+	})
+}
@@ -19,54 +19,78 @@ package cluster
 
 import (
 	"context"
+	"reflect"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/nodedaemon"
 	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/util/log"
 )
 
-var monitorDaemonList = []string{"mon", "osd", "status"}
+var monitorDaemonList = []string{"mon", "osd", "status", "crash", "cephx", "crushweight", "connectionbundle"}
 
 func (c *ClusterController) configureCephMonitoring(cluster *cluster, clusterInfo *cephclient.ClusterInfo) {
 	var isEnabled bool
 	for _, daemon := range monitorDaemonList {
 		// Is the monitoring enabled for that daemon?
 		isEnabled = isMonitoringEnabled(daemon, cluster.Spec)
+		spec := monitoringSpec(daemon, cluster.Spec)
 		if health, ok := cluster.monitoringRoutines.Load(daemon); ok {
 			// If the context Err() is nil this means it hasn't been cancelled yet
 			if health.(*opcontroller.ClusterHealth).InternalCtx.Err() == nil {
 				log.NamespacedDebug(cluster.Namespace, logger, "monitoring routine for %q is already running", daemon)
 				if !isEnabled {
 					health.(*opcontroller.ClusterHealth).InternalCancel()
+				} else if !reflect.DeepEqual(health.(*opcontroller.ClusterHealth).Spec, spec) {
+					log.NamespacedInfo(cluster.Namespace, logger, "restarting monitoring routine for %q since its settings changed", daemon)
+					health.(*opcontroller.ClusterHealth).InternalCancel()
+					c.startMonitoringRoutine(cluster, clusterInfo, daemon, spec)
 				}
 			}
 		} else {
 			if isEnabled {
-				// Instantiate the monitoring goroutine context from the parent context
-				// They can individually be cancelled and will be cancelled when the parent context is cancelled
-				internalCtx, internalCancel := context.WithCancel(c.OpManagerCtx)
-
-				cluster.monitoringRoutines.Store(daemon, &opcontroller.ClusterHealth{
-					InternalCtx:    internalCtx,
-					InternalCancel: internalCancel,
-				})
-
-				// We can't use mon.isFloatingMon(cluster.mons, daemon) because the mon ID is not available.
-				if daemon == "mon" && cluster.Spec.Mon.FloatingMon.Name != "" {
-					log.NamespacedInfo(cluster.Namespace, logger, "skip mon health check since floating mon %q is configured", daemon)
-					continue
-				}
-
-				// Run the go routine
-				c.startMonitoringCheck(cluster, clusterInfo, daemon)
+				c.startMonitoringRoutine(cluster, clusterInfo, daemon, spec)
 			}
 		}
 	}
 }
 
+func (c *ClusterController) startMonitoringRoutine(cluster *cluster, clusterInfo *cephclient.ClusterInfo, daemon string, spec any) {
+	// Instantiate the monitoring goroutine context from the parent context
+	// They can individually be cancelled and will be cancelled when the parent context is cancelled
+	internalCtx, internalCancel := context.WithCancel(c.OpManagerCtx)
+
+	cluster.monitoringRoutines.Store(daemon, &opcontroller.ClusterHealth{
+		InternalCtx:    internalCtx,
+		InternalCancel: internalCancel,
+		Spec:           spec,
+	})
+
+	// We can't use mon.isFloatingMon(cluster.mons, daemon) because the mon ID is not available.
+	if daemon == "mon" && cluster.Spec.Mon.FloatingMon.Name != "" {
+		log.NamespacedInfo(cluster.Namespace, logger, "skip mon health check since floating mon %q is configured", daemon)
+		return
+	}
+
+	// Run the go routine
+	c.startMonitoringCheck(cluster, clusterInfo, daemon)
+}
+
+// monitoringSpec returns the settings a monitoring routine is started with. The routine is restarted
+// when they change, the routines without settings are never restarted.
+func monitoringSpec(daemon string, clusterSpec *cephv1.ClusterSpec) any {
+	switch daemon {
+	case "crash":
+		return clusterSpec.CrashCollector.Reporting.DeepCopy()
+	case "cephx":
+		return clusterSpec.Security.CephX.Inventory.DeepCopy()
+	}
+	return nil
+}
+
 func isMonitoringEnabled(daemon string, clusterSpec *cephv1.ClusterSpec) bool {
 	switch daemon {
 	case "mon":
@@ -77,6 +101,18 @@ func isMonitoringEnabled(daemon string, clusterSpec *cephv1.ClusterSpec) bool {
 
 	case "status":
 		return !clusterSpec.HealthCheck.DaemonHealth.Status.Disabled
+
+	case "crash":
+		return !clusterSpec.External.Enable && !clusterSpec.CrashCollector.Disable && clusterSpec.CrashCollector.Reporting != nil
+
+	case "cephx":
+		return !clusterSpec.External.Enable && clusterSpec.Security.CephX.Inventory != nil
+
+	case "crushweight":
+		return !clusterSpec.External.Enable && clusterSpec.Storage.CrushWeight != nil
+
+	case "connectionbundle":
+		return !clusterSpec.External.Enable && clusterSpec.ConnectionBundle != nil
 	}
 
 	return false
@@ -85,6 +121,7 @@ func isMonitoringEnabled(daemon string, clusterSpec *cephv1.ClusterSpec) bool {
 func (c *ClusterController) startMonitoringCheck(cluster *cluster, clusterInfo *cephclient.ClusterInfo, daemon string) {
 	switch daemon {
 	case "mon":
+		cluster.mons.SetEventRecorder(c.recorder)
 		healthChecker := mon.NewHealthChecker(cluster.mons)
 		log.NamespacedInfo(cluster.Namespace, logger, "enabling ceph %s monitoring goroutine", daemon)
 		go healthChecker.Check(&cluster.monitoringRoutines, daemon)
@@ -100,5 +137,25 @@ func (c *ClusterController) startMonitoringCheck(cluster *cluster, clusterInfo *
 		cephChecker := newCephStatusChecker(c.context, clusterInfo, cluster.Spec)
 		log.NamespacedInfo(cluster.Namespace, logger, "enabling ceph %s monitoring goroutine", daemon)
 		go cephChecker.checkCephStatus(&cluster.monitoringRoutines, daemon)
+
+	case "crash":
+		crashReporter := nodedaemon.NewCrashReporter(c.context, clusterInfo, cluster.Spec.CrashCollector.Reporting, c.recorder)
+		log.NamespacedInfo(cluster.Namespace, logger, "enabling ceph %s monitoring goroutine", daemon)
+		go crashReporter.Start(&cluster.monitoringRoutines, daemon)
+
+	case "cephx":
+		inventory := newCephxInventory(c.context, clusterInfo, cluster.Spec.Security.CephX.Inventory)
+		log.NamespacedInfo(cluster.Namespace, logger, "enabling ceph %s monitoring goroutine", daemon)
+		go inventory.Start(&cluster.monitoringRoutines, daemon)
+
+	case "crushweight":
+		crushWeightMonitor := osd.NewCrushWeightMonitor(c.context, clusterInfo, cluster.Spec.Storage.CrushWeight)
+		log.NamespacedInfo(cluster.Namespace, logger, "enabling ceph %s monitoring goroutine", daemon)
+		go crushWeightMonitor.Start(&cluster.monitoringRoutines, daemon)
+
+	case "connectionbundle":
+		publisher := newConnectionBundlePublisher(c.context, clusterInfo, cluster.Spec.ConnectionBundle)
+		log.NamespacedInfo(cluster.Namespace, logger, "enabling ceph %s monitoring goroutine", daemon)
+		go publisher.Start(&cluster.monitoringRoutines, daemon)
 	}
 }
@@ -18,9 +18,17 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
 )
 
 func TestIsMonitoringEnabled(t *testing.T) {
@@ -35,6 +43,18 @@ func TestIsMonitoringEnabled(t *testing.T) {
 	}{
 		{"isEnabled", args{"mon", &cephv1.ClusterSpec{}}, true},
 		{"isDisabled", args{"mon", &cephv1.ClusterSpec{HealthCheck: cephv1.CephClusterHealthCheckSpec{DaemonHealth: cephv1.DaemonHealthSpec{Monitor: cephv1.HealthCheckSpec{Disabled: true}}}}}, false},
+		{"crashReportsNotRequested", args{"crash", &cephv1.ClusterSpec{}}, false},
+		{"crashReportsEnabled", args{"crash", &cephv1.ClusterSpec{CrashCollector: cephv1.CrashCollectorSpec{Reporting: &cephv1.CrashReportingSpec{}}}}, true},
+		{"crashCollectorDisabled", args{"crash", &cephv1.ClusterSpec{CrashCollector: cephv1.CrashCollectorSpec{Disable: true, Reporting: &cephv1.CrashReportingSpec{}}}}, false},
+		{"cephxInventoryNotRequested", args{"cephx", &cephv1.ClusterSpec{}}, false},
+		{"cephxInventoryEnabled", args{"cephx", &cephv1.ClusterSpec{Security: cephv1.ClusterSecuritySpec{CephX: cephv1.ClusterCephxConfig{Inventory: &cephv1.CephxInventorySpec{}}}}}, true},
+		{"cephxInventoryExternalCluster", args{"cephx", &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}, Security: cephv1.ClusterSecuritySpec{CephX: cephv1.ClusterCephxConfig{Inventory: &cephv1.CephxInventorySpec{}}}}}, false},
+		{"crushWeightPolicyNotSet", args{"crushweight", &cephv1.ClusterSpec{}}, false},
+		{"crushWeightPolicySet", args{"crushweight", &cephv1.ClusterSpec{Storage: cephv1.StorageScopeSpec{CrushWeight: &cephv1.OSDCrushWeightSpec{}}}}, true},
+		{"crushWeightPolicyExternalCluster", args{"crushweight", &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true}, Storage: cephv1.StorageScopeSpec{CrushWeight: &cephv1.OSDCrushWeightSpec{}}}}, false},
+		{"connectionBundleNotPublished", args{"connectionbundle", &cephv1.ClusterSpec{}}, false},
+		{"connectionBundlePublished", args{"connectionbundle", &cephv1.ClusterSpec{ConnectionBundle: &cephv1.ConnectionBundleSpec{}}}, true},
+		{"connectionBundleExternalCluster", args{"connectionbundle", &cephv1.ClusterSpec{External: cephv1.ExternalSpec{Enable: true, ConnectionBundle: &cephv1.ExternalConnectionBundleSpec{}}}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -44,3 +64,48 @@ func TestIsMonitoringEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureCephMonitoring(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	c := &ClusterController{context: &clusterd.Context{}, recorder: events.NewFakeRecorder(1), OpManagerCtx: ctx}
+	spec := &cephv1.ClusterSpec{}
+	spec.HealthCheck.DaemonHealth.Monitor.Disabled = true
+	spec.HealthCheck.DaemonHealth.ObjectStorageDaemon.Disabled = true
+	spec.HealthCheck.DaemonHealth.Status.Disabled = true
+	spec.CrashCollector.Reporting = &cephv1.CrashReportingSpec{Interval: &metav1.Duration{Duration: time.Hour}}
+	cluster := &cluster{Namespace: "rook-ceph", Spec: spec}
+	clusterInfo := cephclient.AdminTestClusterInfo("rook-ceph")
+
+	running := func() *opcontroller.ClusterHealth {
+		health, ok := cluster.monitoringRoutines.Load("crash")
+		if !ok {
+			return nil
+		}
+		return health.(*opcontroller.ClusterHealth)
+	}
+
+	c.configureCephMonitoring(cluster, clusterInfo)
+	crashReports := running()
+	assert.NotNil(t, crashReports)
+	_, ok := cluster.monitoringRoutines.Load("mon")
+	assert.False(t, ok)
+
+	// the routine keeps running while the settings are the same
+	c.configureCephMonitoring(cluster, clusterInfo)
+	assert.Same(t, crashReports, running())
+
+	// the routine is restarted when the settings change
+	spec.CrashCollector.Reporting.Interval = &metav1.Duration{Duration: time.Minute}
+	c.configureCephMonitoring(cluster, clusterInfo)
+	assert.Error(t, crashReports.InternalCtx.Err())
+	assert.NotSame(t, crashReports, running())
+	assert.NoError(t, running().InternalCtx.Err())
+	assert.Equal(t, spec.CrashCollector.Reporting, running().Spec)
+
+	// the routine is stopped when it is disabled
+	crashReports = running()
+	spec.CrashCollector.Reporting = nil
+	c.configureCephMonitoring(cluster, clusterInfo)
+	assert.Error(t, crashReports.InternalCtx.Err())
+}
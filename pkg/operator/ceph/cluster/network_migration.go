@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mgr"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// networkMigrationCheckInterval is the interval to check if the mons are failed over to the new network
+var networkMigrationCheckInterval = time.Minute
+
+// reconcileNetworkMigration runs before the mons are reconciled. It starts a migration when the
+// network provider of the cluster changes. The mons whose network differs from the spec are then
+// failed over one at a time by the mon health checks.
+func (c *cluster) reconcileNetworkMigration() error {
+	c.networkMigrationRequeue = 0
+
+	status, err := c.getNetworkStatus()
+	if err != nil {
+		return err
+	}
+	target := c.Spec.Network.EffectiveProvider()
+	if status == nil {
+		// the daemons of a new cluster, or a cluster created before the network status was reported,
+		// are deployed with the provider of the spec
+		_, err = updateNetworkStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.NetworkStatus) {
+			s.Provider = target
+		})
+		return err
+	}
+
+	migration := status.Migration
+	inProgress := migration != nil && migration.Phase != cephv1.NetworkMigrationPhaseCompleted
+	if inProgress && migration.To == target {
+		return nil
+	}
+	if !inProgress && status.Provider == target {
+		return nil
+	}
+
+	from := status.Provider
+	if inProgress {
+		// the migration target changed, some mons may already run on the previous target
+		from = migration.To
+	}
+	log.NamespacedInfo(c.Namespace, logger, "migrating the cluster from network provider %q to %q", from, target)
+
+	if target == cephv1.NetworkProviderDefault {
+		// the networks of the previous provider would prevent the daemons from binding on the pod network
+		if err := controller.RemoveCephNetworkSettings(c.context, c.ClusterInfo); err != nil {
+			return errors.Wrap(err, "failed to remove the network settings of the previous network provider")
+		}
+	}
+
+	now := metav1.Now()
+	_, err = updateNetworkStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.NetworkStatus) {
+		s.Migration = &cephv1.NetworkMigrationStatus{
+			From:      from,
+			To:        target,
+			Phase:     cephv1.NetworkMigrationPhaseMons,
+			StartTime: &now,
+			Message:   "failing over the mons to the new network",
+		}
+	})
+	return err
+}
+
+// waitForMonNetworkMigration runs after the mons are reconciled. It returns true while mons are not
+// failed over to the new network yet, so the other daemons are restarted on the new network only once
+// the mons are reachable on it.
+func (c *cluster) waitForMonNetworkMigration() (bool, error) {
+	status, err := c.getNetworkStatus()
+	if err != nil {
+		return false, err
+	}
+	if status == nil || status.Migration == nil || status.Migration.Phase != cephv1.NetworkMigrationPhaseMons {
+		return false, nil
+	}
+
+	pending, err := c.monsPendingNetworkMigration(status.Migration.To)
+	if err != nil {
+		return false, err
+	}
+	if len(pending) > 0 {
+		message := fmt.Sprintf("failing over mons %v to the new network one at a time", pending)
+		if c.Spec.HealthCheck.DaemonHealth.Monitor.Disabled {
+			message += ". the mon health checks are disabled, enable them to fail over the mons"
+		}
+		log.NamespacedInfo(c.Namespace, logger, "network migration to provider %q: %s", status.Migration.To, message)
+		c.networkMigrationRequeue = networkMigrationCheckInterval
+		controller.UpdateCondition(c.ClusterInfo.Context, c.context, c.namespacedName, k8sutil.ObservedGenerationNotAvailable, cephv1.ConditionProgressing, v1.ConditionTrue, cephv1.ClusterProgressingReason, "Migrating the Ceph mons to the new network")
+		_, err = updateNetworkStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.NetworkStatus) {
+			s.Migration.PendingMons = pending
+			s.Migration.Message = message
+		})
+		return true, err
+	}
+
+	log.NamespacedInfo(c.Namespace, logger, "network migration to provider %q: mons migrated, restarting the other daemons on the new network", status.Migration.To)
+	_, err = updateNetworkStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.NetworkStatus) {
+		s.Migration.Phase = cephv1.NetworkMigrationPhaseDaemons
+		s.Migration.PendingMons = nil
+		s.Migration.Message = "mons migrated, restarting the other daemons on the new network"
+	})
+	return false, err
+}
+
+// completeNetworkMigration runs after the mgrs and OSDs are reconciled on the new network. The
+// migration completes once all of them run on the new network.
+func (c *cluster) completeNetworkMigration() error {
+	status, err := c.getNetworkStatus()
+	if err != nil {
+		return err
+	}
+	if status == nil || status.Migration == nil || status.Migration.Phase != cephv1.NetworkMigrationPhaseDaemons {
+		return nil
+	}
+
+	// the OSD updates may be paused, delayed by the ok-to-stop checks or fail, so the migration only
+	// completes once every mgr and OSD runs on the new network
+	pending, err := c.daemonsPendingNetworkMigration(status.Migration.To)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		message := fmt.Sprintf("waiting for %v to run on the new network", pending)
+		log.NamespacedInfo(c.Namespace, logger, "network migration to provider %q: %s", status.Migration.To, message)
+		c.networkMigrationRequeue = networkMigrationCheckInterval
+		_, err = updateNetworkStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.NetworkStatus) {
+			s.Migration.Message = message
+		})
+		return err
+	}
+
+	log.NamespacedInfo(c.Namespace, logger, "network migration to provider %q completed", status.Migration.To)
+	_, err = updateNetworkStatus(c.ClusterInfo.Context, c.context, c.namespacedName, func(s *cephv1.NetworkStatus) {
+		now := metav1.Now()
+		s.Provider = s.Migration.To
+		s.Migration.Phase = cephv1.NetworkMigrationPhaseCompleted
+		s.Migration.CompletionTime = &now
+		s.Migration.Message = "mons, mgrs and OSDs migrated, the other daemons are migrated by their controllers"
+	})
+	return err
+}
+
+// monsPendingNetworkMigration returns the mons whose deployment is not on the network of the provider
+func (c *cluster) monsPendingNetworkMigration(provider cephv1.NetworkProviderType) ([]string, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, mon.AppName)}
+	deployments, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).List(c.ClusterInfo.Context, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the mon deployments")
+	}
+	pending := []string{}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if k8sutil.PodNetworkProvider(&d.Spec.Template) != provider {
+			pending = append(pending, d.Labels[config.MonType])
+		}
+	}
+	slices.Sort(pending)
+	return pending, nil
+}
+
+// daemonsPendingNetworkMigration returns the mgr and OSD deployments that are not on the network of
+// the provider or not rolled out yet
+func (c *cluster) daemonsPendingNetworkMigration(provider cephv1.NetworkProviderType) ([]string, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s in (%s,%s)", k8sutil.AppAttr, mgr.AppName, osd.AppName)}
+	deployments, err := c.context.Clientset.AppsV1().Deployments(c.Namespace).List(c.ClusterInfo.Context, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the mgr and OSD deployments")
+	}
+	pending := []string{}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		rolledOut := d.Status.ObservedGeneration >= d.Generation && d.Status.Replicas == d.Status.UpdatedReplicas
+		if k8sutil.PodNetworkProvider(&d.Spec.Template) != provider || !rolledOut {
+			pending = append(pending, d.Name)
+		}
+	}
+	slices.Sort(pending)
+	return pending, nil
+}
+
+func (c *cluster) getNetworkStatus() (*cephv1.NetworkStatus, error) {
+	cephCluster := &cephv1.CephCluster{}
+	if err := c.context.Client.Get(c.ClusterInfo.Context, c.namespacedName, cephCluster); err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster to check the network migration")
+	}
+	return cephCluster.Status.Network, nil
+}
+
+func updateNetworkStatus(ctx context.Context, clusterdContext *clusterd.Context, nsName types.NamespacedName, update func(*cephv1.NetworkStatus)) (*cephv1.NetworkStatus, error) {
+	var network *cephv1.NetworkStatus
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := clusterdContext.Client.Get(ctx, nsName, cephCluster); err != nil {
+			return errors.Wrap(err, "failed to get cluster to update the network status")
+		}
+		if cephCluster.Status.Network == nil {
+			cephCluster.Status.Network = &cephv1.NetworkStatus{}
+		}
+		update(cephCluster.Status.Network)
+		network = cephCluster.Status.Network
+		return reporting.UpdateStatus(clusterdContext.Client, cephCluster)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update the network status")
+	}
+	return network, nil
+}
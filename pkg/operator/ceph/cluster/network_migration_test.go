@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileNetworkMigration(t *testing.T) {
+	ns := "rook-ceph"
+	nsName := types.NamespacedName{Namespace: ns, Name: "my-cluster"}
+	ctx := context.TODO()
+
+	newMonDeployment := func(id string, hostNetwork bool) *appsv1.Deployment {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "rook-ceph-mon-" + id,
+			Namespace: ns,
+			Labels:    map[string]string{k8sutil.AppAttr: "rook-ceph-mon", "mon": id},
+		}}
+		d.Spec.Template.Spec.HostNetwork = hostNetwork
+		return d
+	}
+
+	newTestCluster := func(t *testing.T, status *cephv1.NetworkStatus, commands *[]string) *cluster {
+		cephCluster := &cephv1.CephCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: ns},
+			Status:     cephv1.ClusterStatus{Network: status},
+		}
+		cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+		clientset := k8sfake.NewClientset(newMonDeployment("a", true), newMonDeployment("b", true))
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+				*commands = append(*commands, strings.Join(args[:4], " "))
+				return "", nil
+			},
+		}
+
+		clusterInfo := cephclient.AdminTestClusterInfo(ns)
+		clusterInfo.Context = ctx
+		return &cluster{
+			ClusterInfo:    clusterInfo,
+			Namespace:      ns,
+			Spec:           &cephCluster.Spec,
+			namespacedName: nsName,
+			context: &clusterd.Context{
+				Client:    cl,
+				Clientset: clientset,
+				Executor:  executor,
+			},
+		}
+	}
+
+	networkStatus := func(t *testing.T, c *cluster) *cephv1.NetworkStatus {
+		status, err := c.getNetworkStatus()
+		require.NoError(t, err)
+		return status
+	}
+
+	t.Run("provider of a new cluster is recorded", func(t *testing.T) {
+		commands := []string{}
+		c := newTestCluster(t, nil, &commands)
+		c.Spec.Network.HostNetwork = true
+
+		require.NoError(t, c.reconcileNetworkMigration())
+		status := networkStatus(t, c)
+		assert.Equal(t, cephv1.NetworkProviderHost, status.Provider)
+		assert.Nil(t, status.Migration)
+		assert.Empty(t, commands)
+	})
+
+	t.Run("migration from host to the pod network", func(t *testing.T) {
+		commands := []string{}
+		c := newTestCluster(t, &cephv1.NetworkStatus{Provider: cephv1.NetworkProviderHost}, &commands)
+
+		require.NoError(t, c.reconcileNetworkMigration())
+		migration := networkStatus(t, c).Migration
+		require.NotNil(t, migration)
+		assert.Equal(t, cephv1.NetworkProviderHost, migration.From)
+		assert.Equal(t, cephv1.NetworkProviderDefault, migration.To)
+		assert.Equal(t, cephv1.NetworkMigrationPhaseMons, migration.Phase)
+		assert.NotNil(t, migration.StartTime)
+		assert.Equal(t, []string{"config rm global public_network", "config rm global cluster_network"}, commands)
+
+		// the mons are still on the host network
+		wait, err := c.waitForMonNetworkMigration()
+		require.NoError(t, err)
+		assert.True(t, wait)
+		assert.Equal(t, networkMigrationCheckInterval, c.networkMigrationRequeue)
+		assert.Equal(t, []string{"a", "b"}, networkStatus(t, c).Migration.PendingMons)
+
+		// mon a is failed over
+		_, err = c.context.Clientset.AppsV1().Deployments(ns).Update(ctx, newMonDeployment("a", false), metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, c.reconcileNetworkMigration())
+		assert.Zero(t, c.networkMigrationRequeue)
+		wait, err = c.waitForMonNetworkMigration()
+		require.NoError(t, err)
+		assert.True(t, wait)
+		assert.Equal(t, []string{"b"}, networkStatus(t, c).Migration.PendingMons)
+
+		// the other daemons are not migrated before all the mons are
+		require.NoError(t, c.completeNetworkMigration())
+		assert.Equal(t, cephv1.NetworkMigrationPhaseMons, networkStatus(t, c).Migration.Phase)
+
+		// mon b is failed over
+		_, err = c.context.Clientset.AppsV1().Deployments(ns).Update(ctx, newMonDeployment("b", false), metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, c.reconcileNetworkMigration())
+		wait, err = c.waitForMonNetworkMigration()
+		require.NoError(t, err)
+		assert.False(t, wait)
+		migration = networkStatus(t, c).Migration
+		assert.Equal(t, cephv1.NetworkMigrationPhaseDaemons, migration.Phase)
+		assert.Empty(t, migration.PendingMons)
+
+		// an OSD is still on the host network
+		osd := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "rook-ceph-osd-0",
+			Namespace: ns,
+			Labels:    map[string]string{k8sutil.AppAttr: "rook-ceph-osd"},
+		}}
+		osd.Spec.Template.Spec.HostNetwork = true
+		_, err = c.context.Clientset.AppsV1().Deployments(ns).Create(ctx, osd, metav1.CreateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, c.completeNetworkMigration())
+		migration = networkStatus(t, c).Migration
+		assert.Equal(t, cephv1.NetworkMigrationPhaseDaemons, migration.Phase)
+		assert.Equal(t, "waiting for [rook-ceph-osd-0] to run on the new network", migration.Message)
+		assert.Equal(t, networkMigrationCheckInterval, c.networkMigrationRequeue)
+
+		// the OSD is updated but not rolled out yet
+		osd.Spec.Template.Spec.HostNetwork = false
+		osd.Status.Replicas = 2
+		osd.Status.UpdatedReplicas = 1
+		_, err = c.context.Clientset.AppsV1().Deployments(ns).Update(ctx, osd, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, c.completeNetworkMigration())
+		assert.Equal(t, cephv1.NetworkMigrationPhaseDaemons, networkStatus(t, c).Migration.Phase)
+
+		osd.Status.Replicas = 1
+		_, err = c.context.Clientset.AppsV1().Deployments(ns).Update(ctx, osd, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, c.completeNetworkMigration())
+		status := networkStatus(t, c)
+		assert.Equal(t, cephv1.NetworkProviderDefault, status.Provider)
+		assert.Equal(t, cephv1.NetworkMigrationPhaseCompleted, status.Migration.Phase)
+		assert.NotNil(t, status.Migration.CompletionTime)
+
+		// the migration is not started again
+		require.NoError(t, c.reconcileNetworkMigration())
+		assert.Equal(t, cephv1.NetworkMigrationPhaseCompleted, networkStatus(t, c).Migration.Phase)
+		assert.Len(t, commands, 2)
+	})
+
+	t.Run("migration target changed while the mons are migrated", func(t *testing.T) {
+		commands := []string{}
+		c := newTestCluster(t, &cephv1.NetworkStatus{
+			Provider:  cephv1.NetworkProviderMultus,
+			Migration: &cephv1.NetworkMigrationStatus{From: cephv1.NetworkProviderMultus, To: cephv1.NetworkProviderDefault, Phase: cephv1.NetworkMigrationPhaseMons},
+		}, &commands)
+		c.Spec.Network.Provider = cephv1.NetworkProviderHost
+
+		require.NoError(t, c.reconcileNetworkMigration())
+		migration := networkStatus(t, c).Migration
+		assert.Equal(t, cephv1.NetworkProviderDefault, migration.From)
+		assert.Equal(t, cephv1.NetworkProviderHost, migration.To)
+		assert.Empty(t, commands)
+
+		// the mons are already on the host network
+		wait, err := c.waitForMonNetworkMigration()
+		require.NoError(t, err)
+		assert.False(t, wait)
+	})
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedaemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CrashEventReason is the reason of the events emitted for the crashes of the Ceph daemons
+	CrashEventReason = "DaemonCrashed"
+	// the number of crashes listed in the CephCluster status
+	recentCrashesInStatus = 5
+	crashForwardTimeout   = 10 * time.Second
+	// the maximum number of crashes kept in the status to retry forwarding them
+	maxUnforwardedCrashes = 20
+)
+
+// defaultCrashReportInterval is the interval of the checks for new crashes
+var defaultCrashReportInterval = 60 * time.Second
+
+// CrashReporter reports the new crashes of the Ceph daemons with an event on the resource of the
+// crashed daemon and in the CephCluster status, forwards them to an external endpoint and archives them
+type CrashReporter struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	recorder    events.EventRecorder
+	interval    time.Duration
+	httpClient  *http.Client
+}
+
+// NewCrashReporter creates a new CrashReporter
+func NewCrashReporter(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, spec *cephv1.CrashReportingSpec, recorder events.EventRecorder) *CrashReporter {
+	r := &CrashReporter{
+		context:     context,
+		clusterInfo: clusterInfo,
+		recorder:    recorder,
+		interval:    defaultCrashReportInterval,
+		httpClient:  &http.Client{Timeout: crashForwardTimeout},
+	}
+	if spec != nil && spec.Interval != nil && spec.Interval.Duration > 0 {
+		r.interval = spec.Interval.Duration
+	}
+	return r
+}
+
+// Start checks for new crashes at set intervals, until the monitoring is cancelled or restarted with
+// other settings
+func (r *CrashReporter) Start(monitoringRoutines *sync.Map, daemon string) {
+	v, ok := monitoringRoutines.Load(daemon)
+	if !ok {
+		log.NamespacedInfo(r.clusterInfo.Namespace, logger, "ceph cluster %q has been deleted. stopping the crash reports", r.clusterInfo.Namespace)
+		return
+	}
+	health := v.(*opcontroller.ClusterHealth)
+	for {
+		select {
+		case <-time.After(r.interval):
+			log.NamespacedDebug(r.clusterInfo.Namespace, logger, "checking for new crashes")
+			r.checkCrashes()
+
+		case <-health.InternalCtx.Done():
+			log.NamespacedInfo(r.clusterInfo.Namespace, logger, "stopping the crash reports in namespace %q", r.clusterInfo.Namespace)
+			// the monitoring may already be restarted with other settings
+			monitoringRoutines.CompareAndDelete(daemon, health)
+			return
+		}
+	}
+}
+
+// checkCrashes reports the crashes since the last reported one and updates the CephCluster status
+func (r *CrashReporter) checkCrashes() {
+	cephCluster := &cephv1.CephCluster{}
+	err := r.context.Client.Get(r.clusterInfo.Context, r.clusterInfo.NamespacedName(), cephCluster)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamespacedDebug(r.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		log.NamespacedError(r.clusterInfo.Namespace, logger, "failed to get the CephCluster to report the crashes. %v", err)
+		return
+	}
+	spec := cephCluster.Spec.CrashCollector.Reporting
+	if spec == nil {
+		return
+	}
+
+	crashes, err := cephclient.GetCrashList(r.context, r.clusterInfo)
+	if err != nil {
+		log.NamespacedError(r.clusterInfo.Namespace, logger, "failed to get the crashes to report. %v", err)
+		return
+	}
+	// the crash IDs start with the crash timestamp
+	slices.SortStableFunc(crashes, func(a, b cephclient.CrashList) int { return strings.Compare(a.ID, b.ID) })
+
+	lastReported, unforwarded := "", []string{}
+	if cephCluster.Status.Crashes != nil {
+		lastReported = cephCluster.Status.Crashes.LastReported
+		unforwarded = cephCluster.Status.Crashes.Unforwarded
+	}
+	if lastReported == "" {
+		// when the reporting is enabled, the existing crashes are not reported in a burst, only the
+		// crashes after them
+		lastReported = reportingStart(crashes)
+		log.NamespacedInfo(r.clusterInfo.Namespace, logger, "reporting the crashes after %q", lastReported)
+		if err := r.updateStatus(crashesStatus(crashes, lastReported, nil)); err != nil {
+			log.NamespacedError(r.clusterInfo.Namespace, logger, "failed to update the crashes in the CephCluster status. %v", err)
+		}
+		return
+	}
+
+	// the new crashes are reported with an event and in the status first, the forwarding to the
+	// external endpoint is retried independently at the next checks if it fails
+	lastReportedTime, _ := cephclient.ParseCrashTimestamp(lastReported)
+	for i := range crashes {
+		crash := &crashes[i]
+		crashTime, ok := cephclient.ParseCrashTimestamp(crash.Timestamp)
+		if crash.Archived != "" || !ok || !crashTime.After(lastReportedTime) {
+			continue
+		}
+		r.reportCrash(cephCluster, crash)
+		lastReported = crash.Timestamp
+		if spec.Forward != nil {
+			unforwarded = append(unforwarded, crash.ID)
+		} else {
+			r.archiveCrash(spec, crash)
+		}
+	}
+	if spec.Forward == nil {
+		unforwarded = nil
+	}
+	if len(unforwarded) > maxUnforwardedCrashes {
+		log.NamespacedWarning(r.clusterInfo.Namespace, logger, "not forwarding the %d oldest crashes, more than %d crashes failed to be forwarded", len(unforwarded)-maxUnforwardedCrashes, maxUnforwardedCrashes)
+		unforwarded = unforwarded[len(unforwarded)-maxUnforwardedCrashes:]
+	}
+	if err := r.updateStatus(crashesStatus(crashes, lastReported, unforwarded)); err != nil {
+		log.NamespacedError(r.clusterInfo.Namespace, logger, "failed to update the crashes in the CephCluster status. %v", err)
+	}
+	if len(unforwarded) == 0 {
+		return
+	}
+
+	unforwarded = r.forwardCrashes(spec, crashes, unforwarded)
+	if err := r.updateStatus(crashesStatus(crashes, lastReported, unforwarded)); err != nil {
+		log.NamespacedError(r.clusterInfo.Namespace, logger, "failed to update the crashes in the CephCluster status. %v", err)
+	}
+}
+
+// forwardCrashes forwards the crashes not forwarded yet, archives them if requested, and returns the
+// crashes that failed to be forwarded and are retried at the next check
+func (r *CrashReporter) forwardCrashes(spec *cephv1.CrashReportingSpec, crashes []cephclient.CrashList, unforwarded []string) []string {
+	failed := []string{}
+	for _, crashID := range unforwarded {
+		i := slices.IndexFunc(crashes, func(crash cephclient.CrashList) bool { return crash.ID == crashID })
+		if i < 0 {
+			log.NamespacedWarning(r.clusterInfo.Namespace, logger, "not forwarding crash %q, the crash report was removed", crashID)
+			continue
+		}
+		if err := r.forwardCrash(spec.Forward, &crashes[i]); err != nil {
+			log.NamespacedError(r.clusterInfo.Namespace, logger, "failed to forward crash %q, retrying at the next check. %v", crashID, err)
+			failed = append(failed, crashID)
+			continue
+		}
+		r.archiveCrash(spec, &crashes[i])
+	}
+	return failed
+}
+
+// reportingStart returns the timestamp of the newest existing crash, or the current time if there is
+// no crash yet, after which the crashes are reported
+func reportingStart(crashes []cephclient.CrashList) string {
+	start, startTime := "", time.Time{}
+	for i := range crashes {
+		if crashTime, ok := cephclient.ParseCrashTimestamp(crashes[i].Timestamp); ok && crashTime.After(startTime) {
+			start, startTime = crashes[i].Timestamp, crashTime
+		}
+	}
+	if start == "" {
+		return time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	return start
+}
+
+// reportCrash emits an event on the resource of the crashed daemon
+func (r *CrashReporter) reportCrash(cephCluster *cephv1.CephCluster, crash *cephclient.CrashList) {
+	message := crashMessage(crash)
+	log.NamespacedWarning(r.clusterInfo.Namespace, logger, "%s", message)
+	r.recorder.Eventf(r.crashOwner(cephCluster, crash.Entity), nil, corev1.EventTypeWarning, CrashEventReason, "Crash", "%s", message)
+}
+
+// archiveCrash archives a reported crash if requested
+func (r *CrashReporter) archiveCrash(spec *cephv1.CrashReportingSpec, crash *cephclient.CrashList) {
+	if !spec.Archive || crash.Archived != "" {
+		return
+	}
+	if err := cephclient.ArchiveCrash(r.context, r.clusterInfo, crash.ID); err != nil {
+		// the crash is reported, it can still be archived manually
+		log.NamespacedWarning(r.clusterInfo.Namespace, logger, "failed to archive reported crash %q. %v", crash.ID, err)
+		return
+	}
+	crash.Archived = time.Now().UTC().Format(time.RFC3339)
+}
+
+func crashMessage(crash *cephclient.CrashList) string {
+	message := fmt.Sprintf("%s crashed on node %q at %s (crash %q)", crash.Entity, crash.UtsnameHostname, crash.Timestamp, crash.ID)
+	if crash.AssertFunc != "" {
+		message += fmt.Sprintf(". assert failed in %s: %s", crash.AssertFunc, crash.AssertCondition)
+	}
+	return message
+}
+
+// crashOwner returns the resource of the crashed daemon: the CephFilesystem of an MDS, the
+// CephObjectStore of an RGW, or the CephCluster for the other daemons
+func (r *CrashReporter) crashOwner(cephCluster *cephv1.CephCluster, entity string) client.Object {
+	daemonType, daemonID, _ := strings.Cut(entity, ".")
+	switch daemonType {
+	case "mds":
+		filesystems := &cephv1.CephFilesystemList{}
+		if err := r.context.Client.List(r.clusterInfo.Context, filesystems, client.InNamespace(cephCluster.Namespace)); err != nil {
+			log.NamespacedWarning(r.clusterInfo.Namespace, logger, "failed to list the filesystems to find the owner of %q. %v", entity, err)
+			break
+		}
+		// the mds daemons are named "<filesystem>-<letter>"
+		i := opcontroller.LongestPrefixMatch(daemonID, len(filesystems.Items), func(i int) string { return filesystems.Items[i].Name + "-" })
+		if i >= 0 {
+			return &filesystems.Items[i]
+		}
+
+	case "client":
+		rgwID, ok := strings.CutPrefix(daemonID, "rgw.")
+		if !ok {
+			break
+		}
+		stores := &cephv1.CephObjectStoreList{}
+		if err := r.context.Client.List(r.clusterInfo.Context, stores, client.InNamespace(cephCluster.Namespace)); err != nil {
+			log.NamespacedWarning(r.clusterInfo.Namespace, logger, "failed to list the object stores to find the owner of %q. %v", entity, err)
+			break
+		}
+		// the rgw users are named "client.rgw.<store>.<letter>" with the dashes replaced by dots
+		i := opcontroller.LongestPrefixMatch(rgwID, len(stores.Items), func(i int) string { return strings.ReplaceAll(stores.Items[i].Name, "-", ".") + "." })
+		if i >= 0 {
+			return &stores.Items[i]
+		}
+	}
+	return cephCluster
+}
+
+// forwardCrash sends the crash metadata to the webhook or the Sentry project
+func (r *CrashReporter) forwardCrash(spec *cephv1.CrashForwardSpec, crash *cephclient.CrashList) error {
+	endpoint := spec.URL
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	var payload any
+	switch spec.Type {
+	case cephv1.CrashForwardSentry:
+		storeURL, key, err := sentryStoreURL(spec.URL)
+		if err != nil {
+			return err
+		}
+		endpoint = storeURL
+		header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=rook, sentry_key=%s", key))
+		payload = sentryEvent(crash, r.clusterInfo.FSID)
+	default:
+		payload = map[string]any{
+			"cluster": r.clusterInfo.NamespacedName().String(),
+			"fsid":    r.clusterInfo.FSID,
+			"crash":   crash,
+		}
+	}
+
+	if spec.AuthorizationSecretRef != nil {
+		authorization, err := r.secretValue(spec.AuthorizationSecretRef)
+		if err != nil {
+			return err
+		}
+		header.Set("Authorization", authorization)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the crash")
+	}
+	ctx, cancel := context.WithTimeout(r.clusterInfo.Context, crashForwardTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the crash forward request")
+	}
+	req.Header = header
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send the crash")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("crash endpoint returned status %q", resp.Status)
+	}
+	return nil
+}
+
+func (r *CrashReporter) secretValue(selector *corev1.SecretKeySelector) (string, error) {
+	secret, err := r.context.Clientset.CoreV1().Secrets(r.clusterInfo.Namespace).Get(r.clusterInfo.Context, selector.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get secret %q", selector.Name)
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", errors.Errorf("secret %q is missing key %q", selector.Name, selector.Key)
+	}
+	return string(value), nil
+}
+
+// sentryStoreURL returns the URL of the store API and the public key of a Sentry DSN, formatted as
+// "https://<key>@<host>[/<path>]/<project>"
+func sentryStoreURL(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to parse the sentry DSN")
+	}
+	key := u.User.Username()
+	project := path.Base(u.Path)
+	if key == "" || project == "" || project == "/" || project == "." {
+		return "", "", errors.New("the sentry DSN must include the public key and the project ID")
+	}
+	prefix := strings.TrimSuffix(path.Dir(u.Path), "/")
+	return fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, prefix, project), key, nil
+}
+
+// sentryEvent converts a crash to a Sentry event. The event ID is derived from the crash ID so the
+// crashes forwarded again after a failure are deduplicated.
+func sentryEvent(crash *cephclient.CrashList, fsid string) map[string]any {
+	eventID := sha256.Sum256([]byte(crash.ID))
+	timestamp := crash.Timestamp
+	if t, ok := cephclient.ParseCrashTimestamp(crash.Timestamp); ok {
+		timestamp = t.UTC().Format(time.RFC3339Nano)
+	}
+	return map[string]any{
+		"event_id":    hex.EncodeToString(eventID[:16]),
+		"timestamp":   timestamp,
+		"level":       "fatal",
+		"logger":      "ceph-crash",
+		"platform":    "native",
+		"message":     crashMessage(crash),
+		"server_name": crash.UtsnameHostname,
+		"release":     crash.CephVersion,
+		"tags": map[string]string{
+			"entity":  crash.Entity,
+			"process": crash.ProcessName,
+			"fsid":    fsid,
+		},
+		"extra": crash,
+	}
+}
+
+// crashesStatus summarizes the crashes, sorted by crash ID, for the CephCluster status
+func crashesStatus(crashes []cephclient.CrashList, lastReported string, unforwarded []string) *cephv1.CrashesStatus {
+	status := &cephv1.CrashesStatus{Count: len(crashes), LastReported: lastReported}
+	if len(unforwarded) > 0 {
+		status.Unforwarded = unforwarded
+	}
+	for i := len(crashes) - 1; i >= 0; i-- {
+		if crashes[i].Archived == "" {
+			status.Unarchived++
+		}
+		if len(status.Recent) < recentCrashesInStatus {
+			status.Recent = append(status.Recent, cephv1.CrashSummary{
+				ID:        crashes[i].ID,
+				Entity:    crashes[i].Entity,
+				Timestamp: crashes[i].Timestamp,
+				Node:      crashes[i].UtsnameHostname,
+			})
+		}
+	}
+	return status
+}
+
+func (r *CrashReporter) updateStatus(status *cephv1.CrashesStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := r.context.Client.Get(r.clusterInfo.Context, r.clusterInfo.NamespacedName(), cephCluster); err != nil {
+			return errors.Wrap(err, "failed to get the CephCluster")
+		}
+		if reflect.DeepEqual(cephCluster.Status.Crashes, status) {
+			return nil
+		}
+		cephCluster.Status.Crashes = status
+		return reporting.UpdateStatus(r.context.Client, cephCluster)
+	})
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedaemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckCrashes(t *testing.T) {
+	namespace := "rook-ceph"
+	// the crashes before the reporting is enabled
+	crashList := `[
+		{"crash_id":"2026-01-01T10:00:00.000000Z_1","entity_name":"osd.1","timestamp":"2026-01-01T10:00:00.000000Z","archived":"2026-01-01 11:00:00.000000"}
+	]`
+	var archived []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "crash" && args[1] == "ls" {
+				return crashList, nil
+			}
+			if args[0] == "crash" && args[1] == "archive" {
+				archived = append(archived, args[2])
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %v", args)
+		},
+	}
+
+	var forwarded []string
+	// the endpoint fails to receive these crashes
+	failingCrashes := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer secret-token", req.Header.Get("Authorization"))
+		payload := struct {
+			Crash cephclient.CrashList `json:"crash"`
+		}{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		if failingCrashes[payload.Crash.ID] {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		forwarded = append(forwarded, payload.Crash.ID)
+	}))
+	defer server.Close()
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: namespace}}
+	cephCluster.Spec.CrashCollector.Reporting = &cephv1.CrashReportingSpec{
+		Archive: true,
+		Forward: &cephv1.CrashForwardSpec{
+			URL:                    server.URL,
+			AuthorizationSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "crash-webhook"}, Key: "authorization"},
+		},
+	}
+	filesystem := &cephv1.CephFilesystem{ObjectMeta: metav1.ObjectMeta{Name: "my-fs", Namespace: namespace}}
+	otherFilesystem := &cephv1.CephFilesystem{ObjectMeta: metav1.ObjectMeta{Name: "my", Namespace: namespace}}
+	store := &cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: namespace}}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cephCluster, filesystem, otherFilesystem, store).WithStatusSubresource(cephCluster).Build()
+	clientset := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "crash-webhook", Namespace: namespace},
+		Data:       map[string][]byte{"authorization": []byte("Bearer secret-token")},
+	})
+
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	clusterInfo.Context = context.TODO()
+	clusterInfo.SetName(cephCluster.Name)
+	recorder := events.NewFakeRecorder(10)
+	r := NewCrashReporter(&clusterd.Context{Client: client, Clientset: clientset, Executor: executor}, clusterInfo, cephCluster.Spec.CrashCollector.Reporting, recorder)
+
+	getStatus := func() *cephv1.CrashesStatus {
+		c := &cephv1.CephCluster{}
+		require.NoError(t, client.Get(context.TODO(), clusterInfo.NamespacedName(), c))
+		return c.Status.Crashes
+	}
+
+	t.Run("existing crashes are not reported", func(t *testing.T) {
+		r.checkCrashes()
+		assert.Empty(t, forwarded)
+		assert.Empty(t, recorder.Events)
+		assert.Empty(t, archived)
+		status := getStatus()
+		require.NotNil(t, status)
+		assert.Equal(t, 1, status.Count)
+		assert.Equal(t, "2026-01-01T10:00:00.000000Z", status.LastReported)
+	})
+
+	crashList = `[
+		{"crash_id":"2026-01-01T10:00:00.000000Z_1","entity_name":"osd.1","timestamp":"2026-01-01T10:00:00.000000Z","archived":"2026-01-01 11:00:00.000000"},
+		{"crash_id":"2026-01-02T10:00:00.000000Z_2","entity_name":"mds.my-fs-b","timestamp":"2026-01-02T10:00:00.000000Z","utsname_hostname":"node-a"},
+		{"crash_id":"2026-01-03T10:00:00.000000Z_3","entity_name":"client.rgw.my.store.a","timestamp":"2026-01-03T10:00:00.000000Z","utsname_hostname":"node-b"}
+	]`
+
+	t.Run("endpoint failure", func(t *testing.T) {
+		failingCrashes["2026-01-02T10:00:00.000000Z_2"] = true
+		r.checkCrashes()
+		// the events and the status do not wait for the forwarding
+		require.Len(t, recorder.Events, 2)
+		assert.Contains(t, <-recorder.Events, `Warning DaemonCrashed mds.my-fs-b crashed on node "node-a"`)
+		assert.Contains(t, <-recorder.Events, `Warning DaemonCrashed client.rgw.my.store.a crashed on node "node-b"`)
+		// the next crash is forwarded after the failure
+		assert.Equal(t, []string{"2026-01-03T10:00:00.000000Z_3"}, forwarded)
+		assert.Equal(t, forwarded, archived)
+		status := getStatus()
+		require.NotNil(t, status)
+		assert.Equal(t, 3, status.Count)
+		assert.Equal(t, 1, status.Unarchived)
+		assert.Equal(t, "2026-01-03T10:00:00.000000Z", status.LastReported)
+		assert.Equal(t, []string{"2026-01-02T10:00:00.000000Z_2"}, status.Unforwarded)
+		assert.Equal(t, "2026-01-03T10:00:00.000000Z_3", status.Recent[0].ID)
+		assert.Equal(t, "node-b", status.Recent[0].Node)
+	})
+
+	crashList = `[
+		{"crash_id":"2026-01-01T10:00:00.000000Z_1","entity_name":"osd.1","timestamp":"2026-01-01T10:00:00.000000Z","archived":"2026-01-01 11:00:00.000000"},
+		{"crash_id":"2026-01-02T10:00:00.000000Z_2","entity_name":"mds.my-fs-b","timestamp":"2026-01-02T10:00:00.000000Z","utsname_hostname":"node-a"},
+		{"crash_id":"2026-01-03T10:00:00.000000Z_3","entity_name":"client.rgw.my.store.a","timestamp":"2026-01-03T10:00:00.000000Z","utsname_hostname":"node-b","archived":"2026-01-03 11:00:00.000000"}
+	]`
+
+	t.Run("forwarding retried", func(t *testing.T) {
+		delete(failingCrashes, "2026-01-02T10:00:00.000000Z_2")
+		r.checkCrashes()
+		assert.Empty(t, recorder.Events)
+		assert.Equal(t, []string{"2026-01-03T10:00:00.000000Z_3", "2026-01-02T10:00:00.000000Z_2"}, forwarded)
+		assert.Equal(t, forwarded, archived)
+		status := getStatus()
+		assert.Equal(t, 0, status.Unarchived)
+		assert.Equal(t, "2026-01-03T10:00:00.000000Z", status.LastReported)
+		assert.Empty(t, status.Unforwarded)
+	})
+
+	t.Run("reported crashes are not reported again", func(t *testing.T) {
+		r.checkCrashes()
+		assert.Len(t, forwarded, 2)
+		assert.Empty(t, recorder.Events)
+	})
+}
+
+func TestReportingStart(t *testing.T) {
+	crashes := []cephclient.CrashList{
+		{ID: "2026-01-02T10:00:00.000000Z_2", Timestamp: "2026-01-02T10:00:00.000000Z"},
+		{ID: "2026-01-03T10:00:00.000000Z_3", Timestamp: "2026-01-03 10:00:00.000000Z"},
+		{ID: "2026-01-01T10:00:00.000000Z_1", Timestamp: "2026-01-01T10:00:00.000000Z"},
+	}
+	assert.Equal(t, "2026-01-03 10:00:00.000000Z", reportingStart(crashes))
+
+	start, ok := cephclient.ParseCrashTimestamp(reportingStart(nil))
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now(), start, time.Minute)
+}
+
+func TestCrashOwner(t *testing.T) {
+	namespace := "rook-ceph"
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: namespace}}
+	filesystem := &cephv1.CephFilesystem{ObjectMeta: metav1.ObjectMeta{Name: "my-fs", Namespace: namespace}}
+	store := &cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: namespace}}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cephCluster, filesystem, store).Build()
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	clusterInfo.Context = context.TODO()
+	r := NewCrashReporter(&clusterd.Context{Client: client}, clusterInfo, nil, events.NewFakeRecorder(1))
+
+	assert.Equal(t, "my-fs", r.crashOwner(cephCluster, "mds.my-fs-a").GetName())
+	assert.Equal(t, "my-store", r.crashOwner(cephCluster, "client.rgw.my.store.a").GetName())
+	assert.Equal(t, "my-cluster", r.crashOwner(cephCluster, "osd.3").GetName())
+	assert.Equal(t, "my-cluster", r.crashOwner(cephCluster, "mds.other-fs-a").GetName())
+	assert.Equal(t, "my-cluster", r.crashOwner(cephCluster, "client.admin").GetName())
+}
+
+func TestSentryStoreURL(t *testing.T) {
+	storeURL, key, err := sentryStoreURL("https://abc123@sentry.example.com/42")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://sentry.example.com/api/42/store/", storeURL)
+	assert.Equal(t, "abc123", key)
+
+	storeURL, _, err = sentryStoreURL("https://abc123@example.com/sentry/42")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/sentry/api/42/store/", storeURL)
+
+	_, _, err = sentryStoreURL("https://sentry.example.com/42")
+	assert.Error(t, err)
+
+	event := sentryEvent(&cephclient.CrashList{ID: "2026-01-01_1", Entity: "osd.1", Timestamp: "2026-01-01 10:00:00.000000Z"}, "fsid")
+	assert.Len(t, event["event_id"], 32)
+	assert.Equal(t, "2026-01-01T10:00:00Z", event["timestamp"])
+}
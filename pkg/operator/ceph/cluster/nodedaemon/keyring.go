@@ -93,6 +93,7 @@ func createCrashCollectorKeyring(s *keyring.SecretStore, context *clusterd.Conte
 		clusterObj.Status.Cephx.CrashCollector,
 		true, // daemon key type always takes the default from setDefaultCephxKeyType()
 		clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephCluster", clusterObj.Name, keyring.CephxComponentCrashCollector),
 	)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to check if cephx keys should be rotated for crash collector %q", crashCollectorKeyringUsername)
@@ -213,6 +214,7 @@ func createExporterKeyring(s *keyring.SecretStore, context *clusterd.Context, cl
 		clusterObj.Status.Cephx.CephExporter,
 		true, // daemon key type always takes the default from setDefaultCephxKeyType()
 		clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephCluster", clusterObj.Name, keyring.CephxComponentCephExporter),
 	)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to check if cephx keys should be rotated for ceph exporter %q", exporterKeyringUsername)
@@ -33,6 +33,7 @@ const (
 	DeviceClassKey     = "deviceClass"
 	InitialWeightKey   = "initialWeight"
 	PrimaryAffinityKey = "primaryAffinity"
+	TuningProfileKey   = "tuningProfile"
 )
 
 // StoreConfig represents the configuration of an OSD on a device.
@@ -45,6 +46,7 @@ type StoreConfig struct {
 	DeviceClass     string `json:"deviceClass,omitempty"`
 	InitialWeight   string `json:"initialWeight,omitempty"`
 	PrimaryAffinity string `json:"primaryAffinity,omitempty"`
+	TuningProfile   string `json:"tuningProfile,omitempty"`
 	StoreType       string `json:"storeType,omitempty"`
 }
 
@@ -91,6 +93,8 @@ func ToStoreConfig(config map[string]string) StoreConfig {
 			storeConfig.InitialWeight = v
 		case PrimaryAffinityKey:
 			storeConfig.PrimaryAffinity = v
+		case TuningProfileKey:
+			storeConfig.TuningProfile = v
 		}
 	}
 
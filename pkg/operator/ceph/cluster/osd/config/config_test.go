@@ -52,6 +52,7 @@ func TestToStoreConfig(t *testing.T) {
 			DeviceClassKey:     "ssd",
 			InitialWeightKey:   "1.5",
 			PrimaryAffinityKey: "0.5",
+			TuningProfileKey:   "hdd-archive",
 		})
 		assert.Equal(t, 512, cfg.WalSizeMB)
 		assert.Equal(t, 1024, cfg.DatabaseSizeMB)
@@ -61,6 +62,7 @@ func TestToStoreConfig(t *testing.T) {
 		assert.Equal(t, "ssd", cfg.DeviceClass)
 		assert.Equal(t, "1.5", cfg.InitialWeight)
 		assert.Equal(t, "0.5", cfg.PrimaryAffinity)
+		assert.Equal(t, "hdd-archive", cfg.TuningProfile)
 	})
 
 	t.Run("OSDsPerDevice invalid input becomes 1", func(t *testing.T) {
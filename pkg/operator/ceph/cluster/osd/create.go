@@ -116,6 +116,12 @@ func (c *createConfig) createNewOSDsFromStatus(
 		status.OSDs[i].CephxStatus = keyring.UpdatedCephxStatus(false, c.cluster.spec.Security.CephX.Daemon,
 			c.cluster.clusterInfo.CephVersion, keyring.UninitializedCephxStatus(), keyType)
 
+		// the new OSD is tracked before it starts so that its CRUSH weight is ramped up from zero
+		if err := c.cluster.trackNewOSDCrushWeight(osd.ID); err != nil {
+			errs.addError("%v", err)
+			continue
+		}
+
 		if status.PvcBackedOSD {
 			log.NamespacedInfo(c.cluster.clusterInfo.Namespace, logger, "creating OSD %d on PVC %q", osd.ID, nodeOrPVCName)
 			err := createDaemonOnPVCFunc(c.cluster, &status.OSDs[i], nodeOrPVCName, c.provisionConfig)
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd/topology"
+	"github.com/rook/rook/pkg/util/log"
+)
+
+// ensureCrushTopologyTypes adds the bucket types of the user-defined CRUSH hierarchy to the CRUSH
+// map before any OSD is created or started in it
+func (c *Cluster) ensureCrushTopologyTypes() error {
+	crushTopology := c.spec.Storage.CrushTopology
+	if crushTopology == nil {
+		return nil
+	}
+	// the levels start with the host, which always exists in the crush map
+	levels := topology.CRUSHMapLevels(crushTopology)[1:]
+	if err := cephclient.EnsureCrushTypes(c.context, c.clusterInfo, levels); err != nil {
+		return errors.Wrapf(err, "failed to add crush types %v to the crush map", levels)
+	}
+	return nil
+}
+
+// migrateCrushTopology moves the host buckets of the existing OSDs to their location in the
+// user-defined CRUSH hierarchy. The OSDs only update their own location in the host bucket when
+// they start, so the host buckets created with a previous hierarchy are not moved without this.
+func (c *Cluster) migrateCrushTopology() error {
+	crushTopology := c.spec.Storage.CrushTopology
+	if crushTopology == nil || !crushTopology.MigrateOSDs {
+		return nil
+	}
+
+	deployments, err := c.getOSDDeployments()
+	if err != nil {
+		return err
+	}
+	crushRoot := cephclient.GetCrushRootFromSpec(&c.spec)
+	checkedHosts := map[string]bool{}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		osdID, err := strconv.Atoi(d.Labels[OsdIdLabelKey])
+		if err != nil {
+			log.NamespacedWarning(c.clusterInfo.Namespace, logger, "failed to parse the osd id of deployment %q. %v", d.Name, err)
+			continue
+		}
+		location, _, err := getLocationFromPod(c.clusterInfo.Context, c.context.Clientset, d, crushRoot, crushTopology)
+		if err != nil || location == "" {
+			log.NamespacedWarning(c.clusterInfo.Namespace, logger, "failed to get the crush location of osd %d, not migrating its host. %v", osdID, err)
+			continue
+		}
+		desired := crushLocationToMap(location)
+		host := desired["host"]
+		if checkedHosts[host] {
+			continue
+		}
+
+		found, err := cephclient.FindOSDInCrushMap(c.context, c.clusterInfo, osdID)
+		if err != nil {
+			log.NamespacedWarning(c.clusterInfo.Namespace, logger, "failed to find osd %d in the crush map, not migrating its host. %v", osdID, err)
+			continue
+		}
+		if found.Location["host"] != host {
+			// the osd moves to its host bucket when it restarts
+			log.NamespacedInfo(c.clusterInfo.Namespace, logger, "osd %d is in crush host %q instead of %q, not migrating the host yet", osdID, found.Location["host"], host)
+			continue
+		}
+		checkedHosts[host] = true
+		if maps.Equal(found.Location, desired) {
+			continue
+		}
+
+		var args []string
+		for _, arg := range strings.Fields(location) {
+			if !strings.HasPrefix(arg, "host=") {
+				args = append(args, arg)
+			}
+		}
+		log.NamespacedInfo(c.clusterInfo.Namespace, logger, "moving crush host %q from %v to %v", host, found.Location, args)
+		if err := cephclient.MoveCrushBucket(c.context, c.clusterInfo, host, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// crushLocationToMap converts a crush location such as "root=default host=a" to a map
+func crushLocationToMap(location string) map[string]string {
+	result := map[string]string{}
+	for _, arg := range strings.Fields(location) {
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			result[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMigrateCrushTopology(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+	clientset := fake.NewClientset()
+
+	nodes := map[string]map[string]string{
+		"node-a": {"example.com/power-domain": "pd1", "topology.rook.io/rack": "rack1"},
+		"node-b": {"example.com/power-domain": "pd2", "topology.rook.io/rack": "rack1"},
+	}
+	for name, labels := range nodes {
+		labels[k8sutil.LabelHostname()] = name
+		_, err := clientset.CoreV1().Nodes().Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	for id, node := range []string{"node-a", "node-a", "node-b"} {
+		labels := map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprint(id)}
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("rook-ceph-osd-%d", id), Namespace: namespace, Labels: labels}}
+		_, err := clientset.AppsV1().Deployments(namespace).Create(ctx, d, metav1.CreateOptions{})
+		require.NoError(t, err)
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: d.Name, Namespace: namespace, Labels: labels}, Spec: corev1.PodSpec{NodeName: node}}
+		_, err = clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	var moved []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "find" {
+				if args[2] == "2" {
+					// node-b was already moved
+					return `{"crush_location":{"host":"node-b","power-domain":"pd2","rack":"rack1","root":"default"}}`, nil
+				}
+				return `{"crush_location":{"host":"node-a","rack":"rack1","root":"default"}}`, nil
+			}
+			if args[0] == "osd" && args[1] == "crush" && args[2] == "move" {
+				moved = append(moved, strings.Join(args[3:7], " "))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %v", args)
+		},
+	}
+
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	clusterInfo.Context = ctx
+	spec := cephv1.ClusterSpec{}
+	spec.Storage.CrushTopology = &cephv1.CrushTopologySpec{
+		Levels: []cephv1.CrushTopologyLevelSpec{
+			{Type: "power-domain", Label: "example.com/power-domain"},
+			{Type: "rack", Label: "topology.rook.io/rack"},
+		},
+	}
+	c := New(&clusterd.Context{Clientset: clientset, Executor: executor}, clusterInfo, spec, "rook/rook:master")
+
+	// the hosts are not moved unless requested
+	require.NoError(t, c.migrateCrushTopology())
+	assert.Empty(t, moved)
+
+	c.spec.Storage.CrushTopology.MigrateOSDs = true
+	require.NoError(t, c.migrateCrushTopology())
+	assert.Equal(t, []string{"node-a root=default power-domain=pd1 rack=rack1"}, moved)
+}
+
+func TestCrushLocationToMap(t *testing.T) {
+	assert.Equal(t, map[string]string{"root": "default", "host": "a", "rack": "r1"}, crushLocationToMap("root=default host=a rack=r1"))
+	assert.Empty(t, crushLocationToMap(""))
+}
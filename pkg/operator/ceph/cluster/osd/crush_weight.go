@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/util/log"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// defaultCrushWeightStep is the default fraction of the full weight a ramping OSD is raised by
+	defaultCrushWeightStep = 0.1
+	// crushWeightTolerance is the relative difference under which two CRUSH weights are equal,
+	// since Ceph stores the weights with a limited precision
+	crushWeightTolerance = 0.01
+	// newOSDTimeout is how long a new OSD created by Rook is tracked until it joins the CRUSH map
+	newOSDTimeout = 24 * time.Hour
+)
+
+// defaultCrushWeightInterval is the default interval of the checks of the CRUSH weights
+var defaultCrushWeightInterval = time.Minute
+
+// CrushWeightMonitor ramps the new OSDs created by Rook up to their full CRUSH weight and raises
+// the weight of the OSDs whose device grew
+type CrushWeightMonitor struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	interval    time.Duration
+}
+
+// NewCrushWeightMonitor instantiates the monitoring of the CRUSH weights of the OSDs
+func NewCrushWeightMonitor(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, spec *cephv1.OSDCrushWeightSpec) *CrushWeightMonitor {
+	m := &CrushWeightMonitor{
+		context:     context,
+		clusterInfo: clusterInfo,
+		interval:    defaultCrushWeightInterval,
+	}
+	if spec != nil && spec.Interval != nil && spec.Interval.Duration > 0 {
+		m.interval = spec.Interval.Duration
+	}
+	return m
+}
+
+// Start checks the CRUSH weights of the OSDs at set intervals
+func (m *CrushWeightMonitor) Start(monitoringRoutines *sync.Map, daemon string) {
+	for {
+		// We must perform this check otherwise the case will check an index that does not exist anymore and
+		// we will get an invalid pointer error and the go routine will panic
+		v, ok := monitoringRoutines.Load(daemon)
+		if !ok {
+			log.NamespacedInfo(m.clusterInfo.Namespace, logger, "ceph cluster %q has been deleted. stopping the monitoring of the osd crush weights", m.clusterInfo.Namespace)
+			return
+		}
+		health := v.(*opcontroller.ClusterHealth)
+		select {
+		case <-time.After(m.interval):
+			log.NamespacedDebug(m.clusterInfo.Namespace, logger, "checking the osd crush weights")
+			if err := m.checkCrushWeights(); err != nil {
+				log.NamespacedError(m.clusterInfo.Namespace, logger, "failed to check the osd crush weights. %v", err)
+			}
+
+		case <-health.InternalCtx.Done():
+			log.NamespacedInfo(m.clusterInfo.Namespace, logger, "stopping the monitoring of the osd crush weights in namespace %q", m.clusterInfo.Namespace)
+			monitoringRoutines.Delete(daemon)
+			return
+		}
+	}
+}
+
+// checkCrushWeights updates the CRUSH weights of the OSDs and reports the ramping OSDs in the
+// CephCluster status
+func (m *CrushWeightMonitor) checkCrushWeights() error {
+	cephCluster := &cephv1.CephCluster{}
+	if err := m.context.Client.Get(m.clusterInfo.Context, m.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamespacedDebug(m.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrap(err, "failed to get the ceph cluster")
+	}
+	spec := cephCluster.Spec.Storage.CrushWeight
+	if spec == nil {
+		return nil
+	}
+
+	var previous []cephv1.OSDCrushWeightStatus
+	if cephCluster.Status.CephStorage != nil {
+		previous = cephCluster.Status.CephStorage.OSD.CrushWeights
+	}
+	ramping, err := m.updateCrushWeights(spec, cephCluster.Spec.DisruptionManagement.PGHealthyRegex, previous)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(ramping, previous) {
+		return nil
+	}
+	return m.updateCrushWeightStatus(ramping, previous)
+}
+
+// updateCrushWeights raises the CRUSH weights of the OSDs according to the policy and returns the
+// OSDs still ramping up to their full weight
+func (m *CrushWeightMonitor) updateCrushWeights(spec *cephv1.OSDCrushWeightSpec, pgHealthyRegex string, previous []cephv1.OSDCrushWeightStatus) ([]cephv1.OSDCrushWeightStatus, error) {
+	usage, err := cephclient.GetOSDUsage(m.context, m.clusterInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get osd usage")
+	}
+	// the OSDs with a zero weight are only ramped up if Rook created them, since an admin may also
+	// drain an OSD by setting its weight to zero
+	tracked := map[int]cephv1.OSDCrushWeightStatus{}
+	for _, osd := range previous {
+		tracked[osd.ID] = osd
+	}
+	step := spec.Step
+	if step == 0 {
+		step = defaultCrushWeightStep
+	}
+
+	// the ramping OSDs take a step only once the data moved by the previous step is in place
+	msg, clean, err := cephclient.IsClusterClean(m.context, m.clusterInfo, pgHealthyRegex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check if the pgs are clean")
+	}
+	if !clean {
+		log.NamespacedDebug(m.clusterInfo.Namespace, logger, "not raising the crush weight of the ramping osds until the pgs are clean. %s", msg)
+	}
+
+	now := metav1.Now()
+	ramping := []cephv1.OSDCrushWeightStatus{}
+	for _, osd := range usage.OSDNodes {
+		weight, err := strconv.ParseFloat(osd.CrushWeight.String(), 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the crush weight %q of osd.%d", osd.CrushWeight.String(), osd.ID)
+		}
+		fullWeight, err := cephclient.OsdFullCrushWeight(osd)
+		if err != nil {
+			return nil, err
+		}
+		reweight, err := osd.Reweight.Float64()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the reweight %q of osd.%d", osd.Reweight.String(), osd.ID)
+		}
+		status, isRamping := tracked[osd.ID]
+		delete(tracked, osd.ID)
+		if fullWeight == 0 || reweight == 0 {
+			// the size of the OSD is unknown until it is up, and the OUT OSDs do not take data
+			if isRamping {
+				ramping = append(ramping, status)
+			}
+			continue
+		}
+
+		target := weight
+		switch {
+		case isRamping && status.FullWeight == 0 && weight == 0 && spec.InitialWeight != nil:
+			// the new OSD joined the CRUSH map with a zero weight
+			log.NamespacedInfo(m.clusterInfo.Namespace, logger, "ramping new osd.%d up to its full crush weight %f", osd.ID, fullWeight)
+			target = *spec.InitialWeight * fullWeight
+		case isRamping:
+			if clean {
+				target = min(weight+step*fullWeight, fullWeight)
+			}
+		case spec.ResizeOnGrowth && weight != 0 && !crushWeightReached(weight, fullWeight):
+			// the weight of a drained OSD is left at zero
+			log.NamespacedInfo(m.clusterInfo.Namespace, logger, "the device of osd.%d grew, raising its crush weight %f to %f", osd.ID, weight, fullWeight)
+			if spec.InitialWeight == nil {
+				target = fullWeight
+			} else {
+				isRamping = true
+			}
+		}
+		if !isRamping && target == weight {
+			continue
+		}
+
+		if target != weight {
+			if err := cephclient.SetOsdCrushWeight(m.context, m.clusterInfo, osd.ID, target); err != nil {
+				return nil, err
+			}
+			weight = target
+			status.LastUpdated = &now
+		}
+		if crushWeightReached(weight, fullWeight) {
+			if isRamping {
+				log.NamespacedInfo(m.clusterInfo.Namespace, logger, "osd.%d reached its full crush weight %f", osd.ID, fullWeight)
+			}
+			continue
+		}
+		status.ID = osd.ID
+		status.Weight = weight
+		status.FullWeight = fullWeight
+		ramping = append(ramping, status)
+	}
+
+	// the new OSDs are not in the CRUSH map until they start for the first time
+	for _, status := range previous {
+		if _, ok := tracked[status.ID]; !ok {
+			continue
+		}
+		if status.Created != nil && now.Sub(status.Created.Time) < newOSDTimeout {
+			ramping = append(ramping, status)
+			continue
+		}
+		log.NamespacedInfo(m.clusterInfo.Namespace, logger, "osd.%d is not in the crush map anymore, not ramping up its crush weight", status.ID)
+	}
+	if len(ramping) == 0 {
+		return nil, nil
+	}
+	return ramping, nil
+}
+
+// updateCrushWeightStatus reports the ramping OSDs in the CephCluster status, keeping the new OSDs
+// created since the previous status was read
+func (m *CrushWeightMonitor) updateCrushWeightStatus(ramping, previous []cephv1.OSDCrushWeightStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := m.context.Client.Get(m.clusterInfo.Context, m.clusterInfo.NamespacedName(), cephCluster); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamespacedDebug(m.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return err
+		}
+		if cephCluster.Status.CephStorage == nil {
+			cephCluster.Status.CephStorage = &cephv1.CephStorage{}
+		}
+		statuses := append([]cephv1.OSDCrushWeightStatus{}, ramping...)
+		for _, status := range cephCluster.Status.CephStorage.OSD.CrushWeights {
+			if !slices.ContainsFunc(previous, func(s cephv1.OSDCrushWeightStatus) bool { return s.ID == status.ID }) {
+				statuses = append(statuses, status)
+			}
+		}
+		if len(statuses) == 0 {
+			statuses = nil
+		}
+		cephCluster.Status.CephStorage.OSD.CrushWeights = statuses
+		return reporting.UpdateStatus(m.context.Client, cephCluster)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update the osd crush weights in the ceph cluster status")
+	}
+	return nil
+}
+
+// trackNewOSDCrushWeight records an OSD created by Rook in the CephCluster status so that its CRUSH
+// weight is ramped up from zero by the crush weight monitoring routine
+func (c *Cluster) trackNewOSDCrushWeight(osdID int) error {
+	if c.spec.Storage.CrushWeight == nil || c.spec.Storage.CrushWeight.InitialWeight == nil {
+		return nil
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := c.context.Client.Get(c.clusterInfo.Context, c.clusterInfo.NamespacedName(), cephCluster); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamespacedDebug(c.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return err
+		}
+		if cephCluster.Status.CephStorage == nil {
+			cephCluster.Status.CephStorage = &cephv1.CephStorage{}
+		}
+		now := metav1.Now()
+		// the ID of a purged OSD may be reused by a new OSD
+		statuses := slices.DeleteFunc(cephCluster.Status.CephStorage.OSD.CrushWeights, func(s cephv1.OSDCrushWeightStatus) bool { return s.ID == osdID })
+		cephCluster.Status.CephStorage.OSD.CrushWeights = append(statuses, cephv1.OSDCrushWeightStatus{ID: osdID, Created: &now})
+		return reporting.UpdateStatus(c.context.Client, cephCluster)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to track the crush weight of new osd.%d in the ceph cluster status", osdID)
+	}
+	return nil
+}
+
+// crushWeightReached returns whether the CRUSH weight of an OSD matches its full weight
+func crushWeightReached(weight, fullWeight float64) bool {
+	return fullWeight-weight <= fullWeight*crushWeightTolerance
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckCrushWeights(t *testing.T) {
+	namespace := "ns"
+	tib := 1024 * 1024 * 1024
+
+	// osd.0 is at its full weight, osd.1 is new, the device of osd.2 grew, osd.3 is out and osd.4
+	// was drained by the admin
+	weights := map[int]string{0: "1.000000", 1: "0", 2: "1.000000", 3: "0", 4: "0"}
+	sizes := map[int]int{0: tib, 1: tib, 2: 2 * tib, 3: tib, 4: tib}
+	reweighted := []string{}
+	clean := false
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "osd" && args[1] == "df":
+				usage := cephclient.OSDUsage{}
+				for id := range 5 {
+					reweight := "1"
+					if id == 3 {
+						reweight = "0"
+					}
+					usage.OSDNodes = append(usage.OSDNodes, cephclient.OSDNodeUsage{
+						ID:          id,
+						CrushWeight: json.Number(weights[id]),
+						Reweight:    json.Number(reweight),
+						KB:          json.Number(strconv.Itoa(sizes[id])),
+					})
+				}
+				output, err := json.Marshal(usage)
+				return string(output), err
+			case args[0] == "status":
+				if clean {
+					return `{"pgmap":{"num_pgs":100,"pgs_by_state":[{"state_name":"active+clean","count":100}]}}`, nil
+				}
+				return `{"pgmap":{"num_pgs":100,"pgs_by_state":[{"state_name":"active+clean","count":90},{"state_name":"active+remapped+backfilling","count":10}]}}`, nil
+			case args[0] == "osd" && args[1] == "crush" && args[2] == "reweight":
+				id, err := strconv.Atoi(strings.TrimPrefix(args[3], "osd."))
+				if err != nil {
+					return "", err
+				}
+				weights[id] = args[4]
+				reweighted = append(reweighted, fmt.Sprintf("%s=%s", args[3], args[4]))
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %v", args)
+		},
+	}
+
+	initialWeight := 0.2
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "testing", Namespace: namespace},
+		Spec: cephv1.ClusterSpec{
+			Storage: cephv1.StorageScopeSpec{
+				CrushWeight: &cephv1.OSDCrushWeightSpec{InitialWeight: &initialWeight, Step: 0.5, ResizeOnGrowth: true},
+			},
+		},
+	}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	clusterInfo := &cephclient.ClusterInfo{Namespace: namespace, Context: context.TODO()}
+	clusterInfo.SetName("testing")
+	clusterdContext := &clusterd.Context{Client: client, Executor: executor}
+	m := NewCrushWeightMonitor(clusterdContext, clusterInfo, cephCluster.Spec.Storage.CrushWeight)
+
+	// rook created osd.1
+	c := &Cluster{context: clusterdContext, clusterInfo: clusterInfo, spec: cephCluster.Spec}
+	require.NoError(t, c.trackNewOSDCrushWeight(1))
+
+	ramping := func() []cephv1.OSDCrushWeightStatus {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, client.Get(context.TODO(), clusterInfo.NamespacedName(), cluster))
+		if cluster.Status.CephStorage == nil {
+			return nil
+		}
+		statuses := cluster.Status.CephStorage.OSD.CrushWeights
+		for i := range statuses {
+			statuses[i].LastUpdated = nil
+			statuses[i].Created = nil
+		}
+		return statuses
+	}
+
+	t.Run("new osds are tracked", func(t *testing.T) {
+		assert.Equal(t, []cephv1.OSDCrushWeightStatus{{ID: 1}}, ramping())
+	})
+
+	t.Run("new osds start at their initial weight while the pgs are not clean", func(t *testing.T) {
+		require.NoError(t, m.checkCrushWeights())
+		assert.Equal(t, []string{"osd.1=0.200000"}, reweighted)
+		statuses := ramping()
+		require.Len(t, statuses, 2)
+		assert.Equal(t, cephv1.OSDCrushWeightStatus{ID: 1, Weight: 0.2, FullWeight: 1}, statuses[0])
+		// the grown osd is ramped up from its current weight
+		assert.Equal(t, cephv1.OSDCrushWeightStatus{ID: 2, Weight: 1, FullWeight: 2}, statuses[1])
+	})
+
+	t.Run("ramping osds take a step once the pgs are clean", func(t *testing.T) {
+		reweighted = reweighted[:0]
+		clean = true
+		require.NoError(t, m.checkCrushWeights())
+		assert.Equal(t, []string{"osd.1=0.700000", "osd.2=2.000000"}, reweighted)
+		assert.Equal(t, []cephv1.OSDCrushWeightStatus{{ID: 1, Weight: 0.7, FullWeight: 1}}, ramping())
+	})
+
+	t.Run("osds at their full weight are not reported", func(t *testing.T) {
+		reweighted = reweighted[:0]
+		require.NoError(t, m.checkCrushWeights())
+		assert.Equal(t, []string{"osd.1=1.000000"}, reweighted)
+		assert.Empty(t, ramping())
+
+		reweighted = reweighted[:0]
+		require.NoError(t, m.checkCrushWeights())
+		assert.Empty(t, reweighted)
+	})
+
+	t.Run("grown osds are resized at once without an initial weight", func(t *testing.T) {
+		sizes[0] = 3 * tib
+		statuses, err := m.updateCrushWeights(&cephv1.OSDCrushWeightSpec{ResizeOnGrowth: true}, "", nil)
+		require.NoError(t, err)
+		assert.Empty(t, statuses)
+		assert.Equal(t, []string{"osd.0=3.000000"}, reweighted)
+	})
+
+	t.Run("new osds are tracked until they join the crush map", func(t *testing.T) {
+		reweighted = reweighted[:0]
+		created := metav1.Now()
+		expired := metav1.NewTime(created.Add(-2 * newOSDTimeout))
+		previous := []cephv1.OSDCrushWeightStatus{{ID: 5, Created: &created}, {ID: 6, Created: &expired}}
+		statuses, err := m.updateCrushWeights(cephCluster.Spec.Storage.CrushWeight, "", previous)
+		require.NoError(t, err)
+		assert.Equal(t, previous[:1], statuses)
+		assert.Empty(t, reweighted)
+	})
+
+	t.Run("osds created while the status is updated are kept", func(t *testing.T) {
+		require.NoError(t, c.trackNewOSDCrushWeight(5))
+		require.NoError(t, m.updateCrushWeightStatus([]cephv1.OSDCrushWeightStatus{{ID: 2, Weight: 1, FullWeight: 2}}, nil))
+		assert.Equal(t, []cephv1.OSDCrushWeightStatus{{ID: 2, Weight: 1, FullWeight: 2}, {ID: 5}}, ramping())
+
+		require.NoError(t, m.updateCrushWeightStatus(nil, ramping()))
+		assert.Empty(t, ramping())
+	})
+
+	t.Run("osds are not tracked without an initial weight", func(t *testing.T) {
+		c := &Cluster{context: clusterdContext, clusterInfo: clusterInfo, spec: cephv1.ClusterSpec{}}
+		require.NoError(t, c.trackNewOSDCrushWeight(7))
+		assert.Empty(t, ramping())
+	})
+
+	t.Run("grown osds are not resized if not requested", func(t *testing.T) {
+		reweighted = reweighted[:0]
+		sizes[0] = 4 * tib
+		statuses, err := m.updateCrushWeights(&cephv1.OSDCrushWeightSpec{}, "", nil)
+		require.NoError(t, err)
+		assert.Empty(t, statuses)
+		assert.Empty(t, reweighted)
+	})
+}
@@ -67,6 +67,8 @@ type deviceSet struct {
 	TuneSlowDeviceClass bool
 	// TuneFastDeviceClass Tune the OSD when running on a fast Device Class
 	TuneFastDeviceClass bool
+	// TuningProfile is the name of the tuning profile of the OSDs
+	TuningProfile string
 	// Scheduler name for OSD pod placement
 	SchedulerName string
 	// Whether to encrypt the deviceSet
@@ -208,6 +210,7 @@ func (c *Cluster) createDeviceSetPVCsForIndex(newDeviceSet cephv1.StorageClassDe
 		Portable:             newDeviceSet.Portable,
 		TuneSlowDeviceClass:  newDeviceSet.TuneSlowDeviceClass,
 		TuneFastDeviceClass:  newDeviceSet.TuneFastDeviceClass,
+		TuningProfile:        newDeviceSet.TuningProfile,
 		SchedulerName:        newDeviceSet.SchedulerName,
 		CrushDeviceClass:     crushDeviceClass,
 		CrushInitialWeight:   crushInitialWeight,
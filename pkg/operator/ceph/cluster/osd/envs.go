@@ -17,8 +17,10 @@ limitations under the License.
 package osd
 
 import (
+	"encoding/json"
 	"strconv"
 
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	opmon "github.com/rook/rook/pkg/operator/ceph/cluster/mon"
 	"github.com/rook/rook/pkg/operator/k8sutil"
@@ -50,6 +52,7 @@ const (
 	OSDStoreTypeVarName                 = "ROOK_OSD_STORE_TYPE"
 	MigrateOSDIDVarName                 = "ROOK_MIGRATE_OSD"
 	CrushRootVarName                    = "ROOK_CRUSHMAP_ROOT"
+	CrushTopologyVarName                = "ROOK_CRUSH_TOPOLOGY"
 	tcmallocMaxTotalThreadCacheBytesEnv = "TCMALLOC_MAX_TOTAL_THREAD_CACHE_BYTES"
 	wipeDevicesFromOtherClustersVarName = "ROOK_WIPE_DEVICES_FROM_OTHER_CLUSTERS"
 )
@@ -82,6 +85,9 @@ func (c *Cluster) getConfigEnvVars(osdProps osdProperties, dataDir string, prepa
 		}...)
 
 		envVars = append(envVars, osdStoreTypeEnvVar(c.spec.Storage.GetOSDStore()))
+		if c.spec.Storage.CrushTopology != nil {
+			envVars = append(envVars, crushTopologyEnvVar(c.spec.Storage.CrushTopology))
+		}
 	}
 
 	// Give a hint to the prepare pod for what the host in the CRUSH map should be
@@ -114,6 +120,12 @@ func (c *Cluster) getConfigEnvVars(osdProps osdProperties, dataDir string, prepa
 	return envVars
 }
 
+func crushTopologyEnvVar(crushTopology *cephv1.CrushTopologySpec) v1.EnvVar {
+	// the spec only holds strings, so it always marshals
+	value, _ := json.Marshal(crushTopology)
+	return v1.EnvVar{Name: CrushTopologyVarName, Value: string(value)}
+}
+
 func nodeNameEnvVar(name string) v1.EnvVar {
 	return v1.EnvVar{Name: "ROOK_NODE_NAME", Value: name}
 }
@@ -115,6 +115,10 @@ func (m *OSDHealthMonitor) checkOSDHealth() {
 		log.NamespacedDebug(m.clusterInfo.Namespace, logger, "failed to check OSD Dump. %v", err)
 	}
 	m.checkRequireOSDRelease()
+
+	if err := m.checkTuningProfiles(); err != nil {
+		log.NamespacedWarning(m.clusterInfo.Namespace, logger, "failed to check the osd tuning profiles. %v", err)
+	}
 }
 
 // checkRequireOSDRelease checks if all OSDs report the same version and, if so,
@@ -34,6 +34,8 @@ const (
 	CephDeviceSetPVCIDLabelKey = "ceph.rook.io/DeviceSetPVCId"
 	// OSDOverPVCLabelKey is the Rook PVC label key
 	OSDOverPVCLabelKey = "ceph.rook.io/pvc"
+	// TuningProfileLabelKey is the tuning profile label added to OSD deployments
+	TuningProfileLabelKey = "ceph.rook.io/tuningProfile"
 	// TopologyLocationLabel is the crush location label added to OSD deployments
 	TopologyLocationLabel = "topology-location-%s"
 	// CephImageLabelKey is the ceph image version label added to PVC
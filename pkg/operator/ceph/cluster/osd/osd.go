@@ -56,7 +56,8 @@ var (
 	logger                   = capnslog.NewPackageLogger("github.com/rook/rook", "op-osd")
 	waitForHealthyPGInterval = 10 * time.Second
 	waitForHealthyPGTimeout  = 15 * time.Minute
-	topologyValidated        bool
+	// validatedTopologyLabels are the node labels of the CRUSH hierarchy that were last validated
+	validatedTopologyLabels string
 )
 
 const (
@@ -208,6 +209,12 @@ func (c *Cluster) validateOSDSettings() error {
 		}
 		deviceSetNames[deviceSet.Name] = true
 	}
+	if err := topology.ValidateCrushTopology(c.spec.Storage.CrushTopology); err != nil {
+		return errors.Wrap(err, "invalid crush topology")
+	}
+	if err := validateTuningProfiles(c.spec.Storage.TuningProfiles); err != nil {
+		return errors.Wrap(err, "invalid tuning profiles")
+	}
 	return nil
 }
 
@@ -217,7 +224,8 @@ func (c *Cluster) validateTopologyAcrossNodes() error {
 		log.NamespacedDebug(c.clusterInfo.Namespace, logger, "Skipping topology validation due to ROOK_SKIP_OSD_TOPOLOGY_CHECK=true")
 		return nil
 	}
-	if topologyValidated {
+	topologyLabels := strings.Join(topology.Labels(c.spec.Storage.CrushTopology), ",")
+	if validatedTopologyLabels == topologyLabels {
 		log.NamespacedDebug(c.clusterInfo.Namespace, logger, "Skipping topology validation because it was already validated")
 		return nil
 	}
@@ -231,7 +239,7 @@ func (c *Cluster) validateTopologyAcrossNodes() error {
 	}
 	log.NamespacedInfo(c.clusterInfo.Namespace, logger, "Fetched nodelist with %d nodes", len(nodelist.Items))
 
-	if err := topology.CheckTopologyConflicts(&nodelist.Items); err != nil {
+	if err := topology.CheckTopologyConflicts(&nodelist.Items, c.spec.Storage.CrushTopology); err != nil {
 		log.NamespacedInfo(c.clusterInfo.Namespace, logger, "Topology conflict detected: %v", err)
 		// Check if there are any existing OSDs
 		osdRunning, errPods := k8sutil.PodsRunningWithLabel(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace, "app=rook-ceph-osd")
@@ -251,7 +259,7 @@ func (c *Cluster) validateTopologyAcrossNodes() error {
 	}
 
 	log.NamespacedInfo(c.clusterInfo.Namespace, logger, "Node topology validation passed without conflicts")
-	topologyValidated = true
+	validatedTopologyLabels = topologyLabels
 	log.NamespacedInfo(c.clusterInfo.Namespace, logger, "validateTopologyAcrossNodes() completed successfully")
 	return nil
 }
@@ -268,6 +276,9 @@ func (c *Cluster) Start() error {
 	if err := c.validateOSDSettings(); err != nil {
 		return err
 	}
+	if err := c.ensureCrushTopologyTypes(); err != nil {
+		return err
+	}
 	if err := c.initializeNodeConfigmaps(); err != nil {
 		return err
 	}
@@ -297,6 +308,12 @@ func (c *Cluster) Start() error {
 		log.NamespacedWarning(c.clusterInfo.Namespace, logger, "failed to get osds to skip reconcile. %v", err)
 	}
 
+	osdsHeldByUpgrade, err := controller.GetDaemonsHeldByCanaryUpgrade(c.clusterInfo.Context, c.context, c.clusterInfo.Namespace, OsdIdLabelKey, AppName)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for osds held by the canary upgrade")
+	}
+	osdsToSkipReconcile = osdsToSkipReconcile.Union(osdsHeldByUpgrade)
+
 	migrationConfig, err := c.startOSDMigration(osdsToSkipReconcile)
 	if err != nil {
 		return errors.Wrapf(err, "failed to start OSD migration")
@@ -343,6 +360,10 @@ func (c *Cluster) Start() error {
 		return errors.Wrapf(err, "failed to update/create OSDs")
 	}
 
+	if !updateConfig.paused && errs.len() == 0 {
+		updateConfig.setUpdatesCondition(corev1.ConditionFalse, cephv1.OSDUpdatesCompleteReason, "all the OSDs are updated")
+	}
+
 	if errs.len() > 0 {
 		return errors.Errorf("%d failures encountered while running osds on nodes in namespace %q. %s",
 			errs.len(), namespace, errs.asMessages())
@@ -353,6 +374,10 @@ func (c *Cluster) Start() error {
 	c.deleteAllOrphanedPrepareJobs()
 	c.deleteAllStatusConfigMaps()
 
+	if err := c.migrateCrushTopology(); err != nil {
+		log.NamespacedWarning(c.clusterInfo.Namespace, logger, "failed to migrate osd hosts to the crush topology. %v", err)
+	}
+
 	// The following block is used to apply any command(s) required by an upgrade
 	c.applyUpgradeOSDFunctionality()
 
@@ -465,7 +490,8 @@ func (c *Cluster) postReconcileUpdateOSDProperties(desiredOSDs map[int]*OSDInfo)
 	}
 	log.NamespacedDebug(c.clusterInfo.Namespace, logger, "post processing osd properties with %d actual osds from ceph osd df and %d existing osds found during reconcile", len(osdUsage.OSDNodes), len(desiredOSDs))
 	for _, actualOSD := range osdUsage.OSDNodes {
-		if c.spec.Storage.AllowOsdCrushWeightUpdate {
+		// the crush weight policy raises the weights of the OSDs in its own monitoring routine
+		if c.spec.Storage.AllowOsdCrushWeightUpdate && c.spec.Storage.CrushWeight == nil {
 			_, err := cephclient.ResizeOsdCrushWeight(actualOSD, c.context, c.clusterInfo)
 			if err != nil {
 				// Log the error and allow other updates to continue
@@ -568,9 +594,11 @@ func deploymentOnPVC(c *Cluster, osd *OSDInfo, pvcName string, config *provision
 func setOSDProperties(c *Cluster, osdProps osdProperties, osd *OSDInfo) error {
 	// OSD's 'primary-affinity' has to be configured via command which goes through mons
 	if osdProps.storeConfig.PrimaryAffinity != "" {
-		return cephclient.SetPrimaryAffinity(c.context, c.clusterInfo, osd.ID, osdProps.storeConfig.PrimaryAffinity)
+		if err := cephclient.SetPrimaryAffinity(c.context, c.clusterInfo, osd.ID, osdProps.storeConfig.PrimaryAffinity); err != nil {
+			return err
+		}
 	}
-	return nil
+	return c.applyTuningProfile(osdProps, osd)
 }
 
 func (c *Cluster) resolveNode(nodeName, deviceClass string) *cephv1.Node {
@@ -587,12 +615,18 @@ func (c *Cluster) resolveNode(nodeName, deviceClass string) *cephv1.Node {
 // perDeviceClassForOSD returns the per-device deviceClass from the CR that
 // provisioned this OSD, or "" if no matching device spec is found.
 func perDeviceClassForOSD(osd *OSDInfo, devices []cephv1.Device) string {
+	return perDeviceConfigForOSD(osd, devices, osdconfig.DeviceClassKey)
+}
+
+// perDeviceConfigForOSD returns the value of a per-device config key from the CR that
+// provisioned this OSD, or "" if no matching device spec sets it.
+func perDeviceConfigForOSD(osd *OSDInfo, devices []cephv1.Device, key string) string {
 	if osd.BlockPath == "" {
 		return ""
 	}
 	short := strings.TrimPrefix(osd.BlockPath, "/dev/")
 	for _, device := range devices {
-		deviceConfig := device.Config[osdconfig.DeviceClassKey]
+		deviceConfig := device.Config[key]
 		if deviceConfig == "" {
 			continue
 		}
@@ -677,6 +711,7 @@ func (c *Cluster) getOSDPropsForPVC(pvcName string) (osdProperties, error) {
 			osdProps.storeConfig.InitialWeight = deviceSet.CrushInitialWeight
 			osdProps.storeConfig.PrimaryAffinity = deviceSet.CrushPrimaryAffinity
 			osdProps.storeConfig.DeviceClass = deviceSet.CrushDeviceClass
+			osdProps.storeConfig.TuningProfile = deviceSet.TuningProfile
 
 			// If OSD isn't portable, we're getting the host name either from the osd deployment that was already initialized
 			// or from the osd prepare job from initial creation.
@@ -824,7 +859,7 @@ func (c *Cluster) getOSDInfo(d *appsv1.Deployment) (OSDInfo, error) {
 
 	// if the ROOK_TOPOLOGY_AFFINITY env var was not found in the loop above, detect it from the node
 	if isPVC && osd.TopologyAffinity == "" {
-		osd.TopologyAffinity, err = getTopologyFromNode(c.clusterInfo.Context, c.context.Clientset, d, osd)
+		osd.TopologyAffinity, err = getTopologyFromNode(c.clusterInfo.Context, c.context.Clientset, d, osd, c.spec.Storage.CrushTopology)
 		if err != nil {
 			log.NamespacedError(c.clusterInfo.Namespace, logger, "failed to get topology affinity for osd %d. %v", osd.ID, err)
 		}
@@ -834,7 +869,7 @@ func (c *Cluster) getOSDInfo(d *appsv1.Deployment) (OSDInfo, error) {
 	osd.Location, locationFound = getOSDLocationFromArgs(container.Args)
 
 	if !locationFound {
-		location, _, err := getLocationFromPod(c.clusterInfo.Context, c.context.Clientset, d, cephclient.GetCrushRootFromSpec(&c.spec))
+		location, _, err := getLocationFromPod(c.clusterInfo.Context, c.context.Clientset, d, cephclient.GetCrushRootFromSpec(&c.spec), c.spec.Storage.CrushTopology)
 		if err != nil {
 			log.NamespacedError(c.clusterInfo.Namespace, logger, "failed to get location. %v", err)
 		} else {
@@ -926,7 +961,7 @@ func getBlockPathFromActivateInitContainer(d *appsv1.Deployment) (string, error)
 	return "", errors.Errorf("failed to find activate init container")
 }
 
-func getLocationFromPod(ctx context.Context, clientset kubernetes.Interface, d *appsv1.Deployment, crushRoot string) (string, string, error) {
+func getLocationFromPod(ctx context.Context, clientset kubernetes.Interface, d *appsv1.Deployment, crushRoot string, crushTopology *cephv1.CrushTopologySpec) (string, string, error) {
 	pods, err := clientset.CoreV1().Pods(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", OsdIdLabelKey, d.Labels[OsdIdLabelKey])})
 	if err != nil || len(pods.Items) == 0 {
 		return "", "", err
@@ -943,10 +978,10 @@ func getLocationFromPod(ctx context.Context, clientset kubernetes.Interface, d *
 			hostName = pvcName
 		}
 	}
-	return GetLocationWithNode(ctx, clientset, nodeName, crushRoot, hostName)
+	return GetLocationWithNode(ctx, clientset, nodeName, crushRoot, hostName, crushTopology)
 }
 
-func getTopologyFromNode(ctx context.Context, clientset kubernetes.Interface, d *appsv1.Deployment, osd OSDInfo) (string, error) {
+func getTopologyFromNode(ctx context.Context, clientset kubernetes.Interface, d *appsv1.Deployment, osd OSDInfo, crushTopology *cephv1.CrushTopologySpec) (string, error) {
 	portable, ok := d.GetLabels()[portableKey]
 	if !ok || portable != "true" {
 		// osd is not portable, no need to load the topology affinity
@@ -970,7 +1005,7 @@ func getTopologyFromNode(ctx context.Context, clientset kubernetes.Interface, d
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get the node for topology affinity")
 	}
-	_, topologyAffinity := topology.ExtractOSDTopologyFromLabels(node.Labels)
+	_, topologyAffinity := topology.ExtractOSDTopologyFromLabels(node.Labels, crushTopology)
 	log.NamespacedInfo(d.Namespace, logger, "found osd %d topology affinity at %q", osd.ID, topologyAffinity)
 	return topologyAffinity, nil
 }
@@ -980,7 +1015,7 @@ func getTopologyFromNode(ctx context.Context, clientset kubernetes.Interface, d
 //	 location: The CRUSH properties for the OSD to apply
 //	 topologyAffinity: The label to be applied to the OSD daemon to guarantee it will start in the same
 //			topology as the OSD prepare job.
-func GetLocationWithNode(ctx context.Context, clientset kubernetes.Interface, nodeName string, crushRoot, crushHostname string) (string, string, error) {
+func GetLocationWithNode(ctx context.Context, clientset kubernetes.Interface, nodeName string, crushRoot, crushHostname string, crushTopology *cephv1.CrushTopologySpec) (string, string, error) {
 	node, err := getNode(ctx, clientset, nodeName)
 	if err != nil {
 		return "", "", errors.Wrap(err, "could not get the node for topology labels")
@@ -1001,7 +1036,7 @@ func GetLocationWithNode(ctx context.Context, clientset kubernetes.Interface, no
 	locArgs := []string{fmt.Sprintf("root=%s", crushRoot), fmt.Sprintf("host=%s", hostName)}
 
 	nodeLabels := node.GetLabels()
-	topologyAffinity := updateLocationWithNodeLabels(&locArgs, nodeLabels)
+	topologyAffinity := updateLocationWithNodeLabels(&locArgs, nodeLabels, crushTopology)
 
 	loc := strings.Join(locArgs, " ")
 	logger.Infof("CRUSH location=%s", loc)
@@ -1055,8 +1090,8 @@ func (c *Cluster) resolveDeviceClass(crDeviceClass, nodeName string) (string, er
 	return "", nil
 }
 
-func updateLocationWithNodeLabels(location *[]string, nodeLabels map[string]string) string {
-	topology, topologyAffinity := topology.ExtractOSDTopologyFromLabels(nodeLabels)
+func updateLocationWithNodeLabels(location *[]string, nodeLabels map[string]string, crushTopology *cephv1.CrushTopologySpec) string {
+	topology, topologyAffinity := topology.ExtractOSDTopologyFromLabels(nodeLabels, crushTopology)
 
 	keys := make([]string, 0, len(topology))
 	for k := range topology {
@@ -1185,6 +1220,16 @@ func (c *Cluster) updateCephOsdStorageStatus() error {
 			return errors.Wrapf(err, "failed to retrieve ceph cluster %q to update ceph Storage", c.clusterInfo.NamespacedName().Name)
 		}
 
+		// the ramping OSDs are reported by the crush weight monitoring routine
+		if cephCluster.Status.CephStorage != nil && c.spec.Storage.CrushWeight != nil {
+			cephClusterStorage.OSD.CrushWeights = cephCluster.Status.CephStorage.OSD.CrushWeights
+		}
+		// the tuning profiles are reported by the osd health monitoring routine, and the initial crush
+		// weight is recorded with the ceph config options
+		if cephCluster.Status.CephStorage != nil {
+			cephClusterStorage.OSD.TuningProfiles = cephCluster.Status.CephStorage.OSD.TuningProfiles
+			cephClusterStorage.OSD.CrushInitialWeightSet = cephCluster.Status.CephStorage.OSD.CrushInitialWeightSet
+		}
 		cephCluster.Status.CephStorage = &cephClusterStorage
 
 		if cephx != nil {
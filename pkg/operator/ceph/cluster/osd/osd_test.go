@@ -465,6 +465,13 @@ func TestPostReconcileUpdateOSDProperties(t *testing.T) {
 		assert.Equal(t, []string([]string{"osd.3", "osd.4"}), osdID)
 		assert.Equal(t, []string([]string{"9.166024", "9.305722"}), crushWeight)
 	})
+	t.Run("test crush weight policy skips the resize", func(t *testing.T) {
+		osdID, crushWeight = nil, nil
+		c.spec.Storage = cephv1.StorageScopeSpec{AllowOsdCrushWeightUpdate: true, CrushWeight: &cephv1.OSDCrushWeightSpec{ResizeOnGrowth: true}}
+		err := c.postReconcileUpdateOSDProperties(desiredOSDs)
+		assert.Nil(t, err)
+		assert.Empty(t, osdID)
+	})
 }
 
 func TestAddNodeFailure(t *testing.T) {
@@ -988,7 +995,7 @@ func TestDetectCrushLocation(t *testing.T) {
 	nodeLabels := map[string]string{}
 
 	// no change to the location if there are no labels
-	updateLocationWithNodeLabels(&location, nodeLabels)
+	updateLocationWithNodeLabels(&location, nodeLabels, nil)
 	assert.Equal(t, 1, len(location))
 	assert.Equal(t, "host=foo", location[0])
 
@@ -998,7 +1005,7 @@ func TestDetectCrushLocation(t *testing.T) {
 		"invalid.topology.rook.io/rack": "r1",
 		"topology.rook.io/zone":         "z1",
 	}
-	updateLocationWithNodeLabels(&location, nodeLabels)
+	updateLocationWithNodeLabels(&location, nodeLabels, nil)
 	assert.Equal(t, 1, len(location))
 	assert.Equal(t, "host=foo", location[0])
 
@@ -1017,7 +1024,7 @@ func TestDetectCrushLocation(t *testing.T) {
 		"row=row1",
 		"zone=zone",
 	}
-	updateLocationWithNodeLabels(&location, nodeLabels)
+	updateLocationWithNodeLabels(&location, nodeLabels, nil)
 
 	assert.Equal(t, 5, len(location))
 	for i, locString := range location {
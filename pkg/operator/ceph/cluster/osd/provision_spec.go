@@ -238,6 +238,10 @@ func (c *Cluster) provisionOSDContainer(osdProps osdProperties, copyBinariesMoun
 	envVars = append(envVars, v1.EnvVar{Name: "ROOK_CEPH_VERSION", Value: c.clusterInfo.CephVersion.CephVersionFormatted()})
 	envVars = append(envVars, crushDeviceClassEnvVar(osdProps.storeConfig.DeviceClass))
 	envVars = append(envVars, crushInitialWeightEnvVar(osdProps.storeConfig.InitialWeight))
+	// the allocation size is only read when the OSDs are created
+	if allocSize := c.tuningProfileMinAllocSize(osdProps.storeConfig.TuningProfile); allocSize > 0 {
+		envVars = append(envVars, v1.EnvVar{Name: "CEPH_ARGS", Value: fmt.Sprintf("--bluestore-min-alloc-size=%d", allocSize)})
+	}
 
 	if osdProps.metadataDevice != "" {
 		envVars = append(envVars, metadataDeviceEnvVar(osdProps.metadataDevice))
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
 	"github.com/rook/rook/pkg/clusterd"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	exectest "github.com/rook/rook/pkg/util/exec/test"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 // replaceTestState is the durable Ceph state a fake cluster reports, plus a record of the mutating
@@ -96,7 +98,8 @@ func newReplaceHealthMonitor(t *testing.T, clientset *fake.Clientset, st *replac
 			return "", nil
 		},
 	}
-	ctx := &clusterd.Context{Executor: executor, Clientset: clientset}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	ctx := &clusterd.Context{Executor: executor, Clientset: clientset, Client: client}
 	return NewOSDHealthMonitor(ctx, clusterInfo, false, cephv1.CephClusterHealthCheckSpec{}, cephv1.ClusterSpec{}, "rook/ceph:test")
 }
 
@@ -603,6 +603,11 @@ func (c *Cluster) makeDeployment(osdProps osdProperties, osd *OSDInfo, provision
 			Replicas: &replicaCount,
 		},
 	}
+	// the tuning profile is only added to the deployment since its settings are applied without
+	// restarting the osd
+	if profile := tuningProfileForOSD(osdProps, osd); profile != "" {
+		k8sutil.AddLabelToDeployment(TuningProfileLabelKey, profile, deployment)
+	}
 	if osdProps.onPVC() {
 		k8sutil.AddLabelToDeployment(OSDOverPVCLabelKey, osdProps.pvc.ClaimName, deployment)
 		k8sutil.AddLabelToDeployment(CephDeviceSetLabelKey, osdProps.deviceSetName, deployment)
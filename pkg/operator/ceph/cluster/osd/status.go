@@ -285,6 +285,11 @@ func (c *Cluster) updateAndCreateOSDsLoop(
 				prevUpdatedCount = u
 				prevChangeTime = time.Now().UTC()
 			}
+			if updateConfig.waitingForPGs() {
+				// waiting for the PGs to recover between two failure domains is expected to take a while
+				// and is not a stall of the OSD updates
+				prevChangeTime = time.Now().UTC()
+			}
 
 			// If we've been waiting too long, abort and reconcile again from the beginning
 			if time.Since(prevChangeTime).Minutes() > maxTimeForProcessingOSDs {
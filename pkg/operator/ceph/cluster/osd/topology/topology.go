@@ -26,6 +26,8 @@ import (
 	"strings"
 
 	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	corev1 "k8s.io/api/core/v1"
@@ -51,74 +53,122 @@ const (
 	topologyLabelPrefix = "topology.rook.io/"
 )
 
-// ExtractOSDTopologyFromLabels extracts rook topology from labels and returns a map from topology type to value
-func ExtractOSDTopologyFromLabels(labels map[string]string) (map[string]string, string) {
-	topology, topologyAffinity := extractTopologyFromLabels(labels)
+// Levels returns the levels of the CRUSH hierarchy above the host, from the lowest to the highest.
+// Without a topology spec, the levels are the topology.rook.io labels followed by the zone and
+// region labels.
+func Levels(crushTopology *cephv1.CrushTopologySpec) []cephv1.CrushTopologyLevelSpec {
+	if crushTopology != nil && len(crushTopology.Levels) > 0 {
+		return crushTopology.Levels
+	}
+	levels := []cephv1.CrushTopologyLevelSpec{}
+	for _, crushType := range CRUSHTopologyLabels {
+		levels = append(levels, cephv1.CrushTopologyLevelSpec{Type: crushType, Label: topologyLabelPrefix + crushType})
+	}
+	return append(levels,
+		cephv1.CrushTopologyLevelSpec{Type: "zone", Label: corev1.LabelTopologyZone},
+		cephv1.CrushTopologyLevelSpec{Type: "region", Label: corev1.LabelTopologyRegion},
+	)
+}
 
-	// Ensure the topology names are normalized for CRUSH
-	for name, value := range topology {
-		topology[name] = client.NormalizeCrushName(value)
+// CRUSHMapLevels returns the failure domains of the CRUSH hierarchy, ordered from lowest to highest
+func CRUSHMapLevels(crushTopology *cephv1.CrushTopologySpec) []string {
+	levels := []string{"host"}
+	for _, level := range Levels(crushTopology) {
+		levels = append(levels, level.Type)
 	}
-	return topology, topologyAffinity
+	return levels
 }
 
-func rookTopologyLabelsOrdered() []string {
-	topologyLabelsOrdered := []string{}
-	for _, label := range slices.Backward(CRUSHTopologyLabels) {
-		topologyLabelsOrdered = append(topologyLabelsOrdered, topologyLabelPrefix+label)
+// Labels returns the node labels of the CRUSH hierarchy, starting with the host name label
+func Labels(crushTopology *cephv1.CrushTopologySpec) []string {
+	if crushTopology == nil || len(crushTopology.Levels) == 0 {
+		return strings.Split(GetDefaultTopologyLabels(), ",")
 	}
-	return topologyLabelsOrdered
+	labels := []string{k8sutil.LabelHostname()}
+	for _, level := range crushTopology.Levels {
+		labels = append(labels, level.Label)
+	}
+	return labels
 }
 
-func allKubernetesTopologyLabelsOrdered() []string {
-	return append(
-		append([]string{
-			corev1.LabelTopologyRegion,
-			corev1.LabelTopologyZone,
-		},
-			rookTopologyLabelsOrdered()...),
-		k8sutil.LabelHostname(), //  host is the lowest level in the crush map hierarchy
-	)
+// ValidateCrushTopology checks that the levels of a user-defined CRUSH hierarchy can be built
+func ValidateCrushTopology(crushTopology *cephv1.CrushTopologySpec) error {
+	if crushTopology == nil {
+		return nil
+	}
+	if len(crushTopology.Levels) == 0 {
+		return errors.New("the crush topology must have at least one level")
+	}
+	types := map[string]bool{}
+	labels := map[string]bool{}
+	for _, level := range crushTopology.Levels {
+		switch level.Type {
+		case "", "osd", "host", "root":
+			return errors.Errorf("invalid crush topology level type %q", level.Type)
+		}
+		if level.Label == "" || level.Label == k8sutil.LabelHostname() {
+			return errors.Errorf("invalid node label %q for crush topology level %q", level.Label, level.Type)
+		}
+		if types[level.Type] {
+			return errors.Errorf("duplicate crush topology level type %q", level.Type)
+		}
+		if labels[level.Label] {
+			return errors.Errorf("node label %q is used by more than one crush topology level", level.Label)
+		}
+		types[level.Type] = true
+		labels[level.Label] = true
+	}
+	return nil
 }
 
-func kubernetesTopologyLabelToCRUSHLabel(label string) string {
-	if label == k8sutil.LabelHostname() {
-		return "host"
+// ExtractOSDTopologyFromLabels extracts rook topology from labels and returns a map from topology type to value
+func ExtractOSDTopologyFromLabels(labels map[string]string, crushTopology *cephv1.CrushTopologySpec) (map[string]string, string) {
+	topology, topologyAffinity := extractTopologyFromLabels(labels, crushTopology)
+
+	// Ensure the topology names are normalized for CRUSH
+	for name, value := range topology {
+		topology[name] = client.NormalizeCrushName(value)
 	}
-	crushLabel := strings.Split(label, "/")
-	return crushLabel[len(crushLabel)-1]
+	return topology, topologyAffinity
+}
+
+// topologyLevelsOrdered returns the levels of the hierarchy from the highest to the host
+func topologyLevelsOrdered(crushTopology *cephv1.CrushTopologySpec) []cephv1.CrushTopologyLevelSpec {
+	levels := slices.Clone(Levels(crushTopology))
+	slices.Reverse(levels)
+	//  host is the lowest level in the crush map hierarchy
+	return append(levels, cephv1.CrushTopologyLevelSpec{Type: "host", Label: k8sutil.LabelHostname()})
 }
 
 // extractTopologyFromLabels extracts rook topology from labels and returns a map from topology type to value
-func extractTopologyFromLabels(labels map[string]string) (map[string]string, string) {
+func extractTopologyFromLabels(labels map[string]string, crushTopology *cephv1.CrushTopologySpec) (map[string]string, string) {
 	topology := make(map[string]string)
 
 	// The topology affinity for the osd is the lowest topology label found in the hierarchy,
 	// not including the host name
 	var topologyAffinity string
-	allKubernetesTopologyLabels := allKubernetesTopologyLabelsOrdered()
+	levels := topologyLevelsOrdered(crushTopology)
 
 	// get the labels for the CRUSH map hierarchy
 	// iterate in a way so the last topology found will be the lowest level in the hierarchy
 	// for the topology affinity
-	for _, label := range allKubernetesTopologyLabels {
-		topologyID := kubernetesTopologyLabelToCRUSHLabel(label)
-		if value, ok := labels[label]; ok && value != "" {
-			topology[topologyID] = value
-			if topologyID != "host" {
-				topologyAffinity = formatTopologyAffinity(label, value)
+	for _, level := range levels {
+		if value, ok := labels[level.Label]; ok && value != "" {
+			topology[level.Type] = value
+			if level.Type != "host" {
+				topologyAffinity = formatTopologyAffinity(level.Label, value)
 			}
 		}
 	}
 	// iterate in lowest to highest order as the lowest level should be sustained and higher level duplicate
 	// should be removed
 	duplicateTopology := make(map[string][]string)
-	for _, topologyLabel := range slices.Backward(allKubernetesTopologyLabels) {
-		if value, ok := labels[topologyLabel]; ok {
+	for _, level := range slices.Backward(levels) {
+		if value, ok := labels[level.Label]; ok {
 			if _, ok := duplicateTopology[value]; ok {
-				delete(topology, kubernetesTopologyLabelToCRUSHLabel(topologyLabel))
+				delete(topology, level.Type)
 			}
-			duplicateTopology[value] = append(duplicateTopology[value], topologyLabel)
+			duplicateTopology[value] = append(duplicateTopology[value], level.Label)
 		}
 	}
 
@@ -153,15 +203,16 @@ func GetDefaultTopologyLabels() string {
 // 1. No child domain (e.g. rack) has fewer distinct values than its immediate parent.
 // 2. No topology value is used under more than one label key.
 // 3. No label value lives under more than one parent label value.
-func CheckTopologyConflicts(nodes *[]corev1.Node) error {
+//
+// The labels of the user-defined CRUSH hierarchy are checked instead of the default labels if set.
+func CheckTopologyConflicts(nodes *[]corev1.Node, crushTopology *cephv1.CrushTopologySpec) error {
 	logger.Debugf("Starting CheckTopologyConflicts with %d nodes", len(*nodes))
 
 	// 1. Build our ordered list of topology labels (region -> zone -> datacenter -> …), dropping hostname.
-	allLabels := allKubernetesTopologyLabelsOrdered()
 	var hierarchy []string
-	for _, label := range allLabels {
-		if label != k8sutil.LabelHostname() {
-			hierarchy = append(hierarchy, label)
+	for _, level := range topologyLevelsOrdered(crushTopology) {
+		if level.Type != "host" {
+			hierarchy = append(hierarchy, level.Label)
 		}
 	}
 	logger.Debugf("Topology hierarchy: %v", hierarchy)
@@ -20,8 +20,10 @@ limitations under the License.
 package topology
 
 import (
+	"strings"
 	"testing"
 
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,7 +55,7 @@ func TestCleanTopologyLabels(t *testing.T) {
 		"topology.rook.io/chassis":    "test",
 		"topology.rook.io/pod":        "test",
 	}
-	topology, affinity := ExtractOSDTopologyFromLabels(nodeLabels)
+	topology, affinity := ExtractOSDTopologyFromLabels(nodeLabels, nil)
 	assert.Equal(t, 6, len(topology))
 	assert.Equal(t, "r-region", topology["region"])
 	assert.Equal(t, "host-name", topology["host"])
@@ -66,7 +68,7 @@ func TestCleanTopologyLabels(t *testing.T) {
 	assert.Equal(t, "", topology["room"])
 
 	t.Setenv("ROOK_CUSTOM_HOSTNAME_LABEL", "my_custom_hostname_label")
-	topology, affinity = ExtractOSDTopologyFromLabels(nodeLabels)
+	topology, affinity = ExtractOSDTopologyFromLabels(nodeLabels, nil)
 	assert.Equal(t, 6, len(topology))
 	assert.Equal(t, "r-region", topology["region"])
 	assert.Equal(t, "host-custom-name", topology["host"])
@@ -81,7 +83,7 @@ func TestCleanTopologyLabels(t *testing.T) {
 
 func TestTopologyLabels(t *testing.T) {
 	nodeLabels := map[string]string{}
-	topology, affinity := extractTopologyFromLabels(nodeLabels)
+	topology, affinity := extractTopologyFromLabels(nodeLabels, nil)
 	assert.Equal(t, 0, len(topology))
 	assert.Equal(t, "", affinity)
 
@@ -90,7 +92,7 @@ func TestTopologyLabels(t *testing.T) {
 		"region": "badregion",
 		"zone":   "badzone",
 	}
-	topology, affinity = extractTopologyFromLabels(nodeLabels)
+	topology, affinity = extractTopologyFromLabels(nodeLabels, nil)
 	assert.Equal(t, 0, len(topology))
 	assert.Equal(t, "", affinity)
 
@@ -99,7 +101,7 @@ func TestTopologyLabels(t *testing.T) {
 		"topology.rook.io/region": "r1",
 		"topology.rook.io/zone":   "z1",
 	}
-	topology, affinity = extractTopologyFromLabels(nodeLabels)
+	topology, affinity = extractTopologyFromLabels(nodeLabels, nil)
 	assert.Equal(t, 0, len(topology))
 	assert.Equal(t, "", affinity)
 
@@ -111,7 +113,7 @@ func TestTopologyLabels(t *testing.T) {
 		"topology.rook.io/row":        "row1",
 		"topology.rook.io/datacenter": "d1",
 	}
-	topology, affinity = extractTopologyFromLabels(nodeLabels)
+	topology, affinity = extractTopologyFromLabels(nodeLabels, nil)
 	assert.Equal(t, 5, len(topology))
 	assert.Equal(t, "r1", topology["region"])
 	assert.Equal(t, "myhost", topology["host"])
@@ -124,7 +126,7 @@ func TestTopologyLabels(t *testing.T) {
 	nodeLabels = map[string]string{
 		"topology.rook.io/row/bad": "r1",
 	}
-	topology, affinity = extractTopologyFromLabels(nodeLabels)
+	topology, affinity = extractTopologyFromLabels(nodeLabels, nil)
 	assert.Equal(t, 0, len(topology))
 	assert.Equal(t, "", affinity)
 }
@@ -173,7 +175,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-c", map[string]string{"topology.kubernetes.io/zone": "zone1", "topology.rook.io/rack": "rack3"}),
 			node("node-d", map[string]string{"topology.kubernetes.io/zone": "zone1", "topology.rook.io/rack": "rack3"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -183,7 +185,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-b", map[string]string{"topology.kubernetes.io/zone": "zone2", "topology.rook.io/rack": "rack1"}),
 			node("node-c", map[string]string{"topology.kubernetes.io/zone": "zone3", "topology.rook.io/rack": "rack3"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 	})
 
@@ -192,7 +194,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-a", map[string]string{"topology.rook.io/datacenter": "dc1", "topology.rook.io/row": "row1"}),
 			node("node-b", map[string]string{"topology.rook.io/datacenter": "dc2", "topology.rook.io/row": "row1"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 	})
 
@@ -201,7 +203,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-a", map[string]string{"topology.kubernetes.io/zone": "X", "topology.rook.io/row": "Y"}),
 			node("node-b", map[string]string{"topology.kubernetes.io/zone": "Y", "topology.rook.io/row": "Z"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 	})
 
@@ -211,7 +213,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-b", map[string]string{"topology.kubernetes.io/zone": "zone2"}),
 			node("node-c", map[string]string{"topology.kubernetes.io/zone": "zone3"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -221,7 +223,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-b", map[string]string{"topology.rook.io/rack": "rack2"}),
 			node("node-c", map[string]string{"topology.rook.io/rack": "rack3"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -230,7 +232,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-a", map[string]string{"topology.rook.io/rack": "shared"}),
 			node("node-b", map[string]string{"topology.rook.io/rack": "shared"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -239,7 +241,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-a", map[string]string{"topology.kubernetes.io/zone": "shared"}),
 			node("node-b", map[string]string{"topology.rook.io/rack": "shared"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 	})
 	t.Run("valid: region-zone-hostname topology", func(t *testing.T) {
@@ -275,7 +277,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.kubernetes.io/zone":   "us-south-3",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 	t.Run("invalid: rack reused under multiple zones", func(t *testing.T) {
@@ -300,7 +302,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			}),
 		}
 
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "topology.rook.io/rack")
 	})
@@ -318,7 +320,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			}),
 		}
 
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 	t.Run("invalid: duplicate values across zone and datacenter keys", func(t *testing.T) {
@@ -335,7 +337,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			}),
 		}
 
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "r1-dc1")
 		assert.Contains(t, err.Error(), "topology.kubernetes.io/zone")
@@ -367,7 +369,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/chassis":      "chassis1",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -376,7 +378,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-a", map[string]string{"topology.kubernetes.io/region": "region1"}),
 			node("node-b", map[string]string{"topology.kubernetes.io/region": "region2"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -391,7 +393,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/datacenter": "dc1",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "topology.rook.io/datacenter")
 	})
@@ -407,7 +409,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/chassis": "chassis2",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -422,7 +424,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/chassis": "chassis1",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "topology.rook.io/chassis")
 	})
@@ -432,7 +434,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-a", map[string]string{"topology.rook.io/pod": "shared"}),
 			node("node-b", map[string]string{"topology.rook.io/rack": "shared"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "topology.rook.io/pod")
 		assert.Contains(t, err.Error(), "topology.rook.io/rack")
@@ -443,7 +445,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("a", map[string]string{"topology.rook.io/pdu": "pduA"}),
 			node("b", map[string]string{"topology.rook.io/pdu": "pduB"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -457,7 +459,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			},
 			node("bar-1", map[string]string{"topology.kubernetes.io/zone": "bar-1"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -466,7 +468,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("a", map[string]string{"topology.kubernetes.io/region": "X"}),
 			node("b", map[string]string{"topology.rook.io/rack": "X"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "topology.kubernetes.io/region")
 		assert.Contains(t, err.Error(), "topology.rook.io/rack")
@@ -477,7 +479,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("a", map[string]string{"topology.kubernetes.io/zone": "Z1", "topology.rook.io/rack": "R1"}),
 			node("b", map[string]string{"topology.rook.io/datacenter": "DC1", "topology.rook.io/room": "RM1"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -492,7 +494,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/room":       "room2",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -507,7 +509,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				// missing pod label
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -516,7 +518,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("n1", map[string]string{"topology.rook.io/chassis": "c1"}),
 			node("n2", map[string]string{"topology.rook.io/chassis": "c2"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -525,7 +527,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("n1", map[string]string{}),
 			node("n2", map[string]string{}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -540,7 +542,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.kubernetes.io/zone":   "zone1",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), `"topology.kubernetes.io/zone"`)
 	})
@@ -550,7 +552,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("n1", map[string]string{"topology.rook.io/room": "r1"}),
 			node("n2", map[string]string{"topology.rook.io/room": "r2"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 
@@ -565,7 +567,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/datacenter": "dc2",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 	t.Run("valid: racks only under one zone out of many", func(t *testing.T) {
@@ -598,7 +600,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.kubernetes.io/zone":   "zone4",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 	t.Run("invalid: 3 regions but only 2 racks", func(t *testing.T) {
@@ -616,7 +618,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/rack":         "rack1", // reused rack label
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "topology.rook.io/rack")
 	})
@@ -635,7 +637,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/rack":       "rackC",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.NoError(t, err)
 	})
 	t.Run("invalid: node with empty zone label", func(t *testing.T) {
@@ -649,7 +651,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.kubernetes.io/zone":   "", // invalid: empty value
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "topology.kubernetes.io/zone")
 	})
@@ -659,7 +661,7 @@ func TestCheckTopologyConflicts(t *testing.T) {
 			node("node-a", map[string]string{"topology.kubernetes.io/region": "common"}),
 			node("node-b", map[string]string{"topology.rook.io/datacenter": "common"}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "value \"common\" appears under both")
 	})
@@ -676,10 +678,68 @@ func TestCheckTopologyConflicts(t *testing.T) {
 				"topology.rook.io/row":        "row1",
 			}),
 		}
-		err := CheckTopologyConflicts(&nodes)
+		err := CheckTopologyConflicts(&nodes, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), `"shared"`)
 		assert.Contains(t, err.Error(), "topology.kubernetes.io/zone")
 		assert.Contains(t, err.Error(), "topology.rook.io/rack")
 	})
 }
+
+func TestCustomCrushTopology(t *testing.T) {
+	crushTopology := &cephv1.CrushTopologySpec{
+		Levels: []cephv1.CrushTopologyLevelSpec{
+			{Type: "power-domain", Label: "example.com/power-domain"},
+			{Type: "rack", Label: "topology.rook.io/rack"},
+		},
+	}
+	assert.Equal(t, []string{"host", "power-domain", "rack"}, CRUSHMapLevels(crushTopology))
+	assert.Equal(t, CRUSHMapLevelsOrdered, CRUSHMapLevels(nil))
+	assert.Equal(t, []string{"kubernetes.io/hostname", "example.com/power-domain", "topology.rook.io/rack"}, Labels(crushTopology))
+	assert.Equal(t, GetDefaultTopologyLabels(), strings.Join(Labels(nil), ","))
+
+	nodeLabels := map[string]string{
+		corev1.LabelTopologyZone:   "zone1",
+		"kubernetes.io/hostname":   "node.a",
+		"example.com/power-domain": "pd.1",
+		"topology.rook.io/rack":    "rack1",
+		"topology.rook.io/row":     "row1",
+	}
+	topology, affinity := ExtractOSDTopologyFromLabels(nodeLabels, crushTopology)
+	assert.Equal(t, map[string]string{"host": "node-a", "power-domain": "pd-1", "rack": "rack1"}, topology)
+	assert.Equal(t, "example.com/power-domain=pd.1", affinity)
+}
+
+func TestValidateCrushTopology(t *testing.T) {
+	assert.NoError(t, ValidateCrushTopology(nil))
+
+	valid := func() *cephv1.CrushTopologySpec {
+		return &cephv1.CrushTopologySpec{
+			Levels: []cephv1.CrushTopologyLevelSpec{
+				{Type: "power-domain", Label: "example.com/power-domain"},
+				{Type: "rack", Label: "topology.rook.io/rack"},
+			},
+		}
+	}
+	assert.NoError(t, ValidateCrushTopology(valid()))
+
+	assert.Error(t, ValidateCrushTopology(&cephv1.CrushTopologySpec{}))
+
+	for _, crushType := range []string{"", "osd", "host", "root"} {
+		crushTopology := valid()
+		crushTopology.Levels[0].Type = crushType
+		assert.Error(t, ValidateCrushTopology(crushTopology), crushType)
+	}
+
+	crushTopology := valid()
+	crushTopology.Levels[0].Label = "kubernetes.io/hostname"
+	assert.Error(t, ValidateCrushTopology(crushTopology))
+
+	crushTopology = valid()
+	crushTopology.Levels[1].Type = "power-domain"
+	assert.ErrorContains(t, ValidateCrushTopology(crushTopology), "duplicate")
+
+	crushTopology = valid()
+	crushTopology.Levels[1].Label = "example.com/power-domain"
+	assert.ErrorContains(t, ValidateCrushTopology(crushTopology), "more than one")
+}
@@ -0,0 +1,418 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	osdconfig "github.com/rook/rook/pkg/operator/ceph/cluster/osd/config"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/util/log"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+)
+
+// builtinTuningProfiles are the tuning profiles that can be referenced without declaring them in
+// the CephCluster
+var builtinTuningProfiles = []cephv1.OSDTuningProfile{
+	{
+		// large sequential objects on spinning disks, favoring capacity over latency
+		Name: "hdd-archive",
+		BlueStore: &cephv1.OSDBlueStoreTuning{
+			MinAllocSize:         ptr.To(int64(65536)),
+			CompressionMode:      "aggressive",
+			CompressionAlgorithm: "zstd",
+		},
+		MClockProfile: "balanced",
+		Recovery: &cephv1.OSDRecoveryTuning{
+			MaxBackfills: ptr.To(int32(1)),
+		},
+	},
+	{
+		// small random IO on NVMe devices, favoring the client ops over recovery
+		Name: "nvme-latency",
+		BlueStore: &cephv1.OSDBlueStoreTuning{
+			MinAllocSize:      ptr.To(int64(4096)),
+			CacheMetaRatio:    ptr.To(0.5),
+			CacheKVRatio:      ptr.To(0.4),
+			CacheKVOnodeRatio: ptr.To(0.1),
+			CompressionMode:   "none",
+		},
+		MClockProfile: "high_client_ops",
+	},
+	{
+		// dense QLC flash with a large indirection unit and limited write endurance
+		Name: "qlc-capacity",
+		BlueStore: &cephv1.OSDBlueStoreTuning{
+			MinAllocSize:         ptr.To(int64(65536)),
+			CompressionMode:      "aggressive",
+			CompressionAlgorithm: "lz4",
+		},
+		MClockProfile: "balanced",
+		Recovery: &cephv1.OSDRecoveryTuning{
+			MaxBackfills: ptr.To(int32(2)),
+		},
+	},
+}
+
+// resolveTuningProfile returns the tuning profile of the given name. A profile declared in the
+// CephCluster replaces the built-in profile of the same name.
+func resolveTuningProfile(profiles []cephv1.OSDTuningProfile, name string) (*cephv1.OSDTuningProfile, bool) {
+	for _, list := range [][]cephv1.OSDTuningProfile{profiles, builtinTuningProfiles} {
+		for i := range list {
+			if list[i].Name == name {
+				return &list[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// cephOptionNameRegex matches the names of the Ceph options
+var cephOptionNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateTuningProfiles checks the settings of the tuning profiles declared in the CephCluster
+func validateTuningProfiles(profiles []cephv1.OSDTuningProfile) error {
+	for i := range profiles {
+		if _, err := tuningProfileSettings(&profiles[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tuningProfileSettings returns the Ceph settings of a tuning profile applied to the running OSDs,
+// with the option names as reported by Ceph. The allocation size is not part of them since it is
+// only read when an OSD is created. Returns an error if a key of the config of the profile is not
+// the name of a Ceph option.
+func tuningProfileSettings(profile *cephv1.OSDTuningProfile) (map[string]string, error) {
+	settings := map[string]string{}
+	if bluestore := profile.BlueStore; bluestore != nil {
+		setFloatOption(settings, "bluestore_cache_meta_ratio", bluestore.CacheMetaRatio)
+		setFloatOption(settings, "bluestore_cache_kv_ratio", bluestore.CacheKVRatio)
+		setFloatOption(settings, "bluestore_cache_kv_onode_ratio", bluestore.CacheKVOnodeRatio)
+		if bluestore.CompressionMode != "" {
+			settings["bluestore_compression_mode"] = bluestore.CompressionMode
+		}
+		if bluestore.CompressionAlgorithm != "" {
+			settings["bluestore_compression_algorithm"] = bluestore.CompressionAlgorithm
+		}
+	}
+	if profile.MClockProfile != "" {
+		settings["osd_mclock_profile"] = profile.MClockProfile
+	}
+	if recovery := profile.Recovery; recovery != nil {
+		if recovery.MaxBackfills != nil {
+			settings["osd_max_backfills"] = strconv.Itoa(int(*recovery.MaxBackfills))
+		}
+		if recovery.MaxActive != nil {
+			settings["osd_recovery_max_active"] = strconv.Itoa(int(*recovery.MaxActive))
+		}
+		// the mclock op queue ignores the recovery limits unless they are overridden explicitly
+		if recovery.MaxBackfills != nil || recovery.MaxActive != nil {
+			settings["osd_mclock_override_recovery_settings"] = "true"
+		}
+		setFloatOption(settings, "osd_recovery_sleep", recovery.Sleep)
+	}
+	configured := sets.New[string]()
+	for _, key := range slices.Sorted(maps.Keys(profile.Config)) {
+		option := strings.ReplaceAll(strings.ReplaceAll(key, " ", "_"), "-", "_")
+		if !cephOptionNameRegex.MatchString(option) {
+			return nil, errors.Errorf("invalid ceph option name %q in the config of tuning profile %q", key, profile.Name)
+		}
+		if configured.Has(option) {
+			return nil, errors.Errorf("ceph option %q is set more than once in the config of tuning profile %q", option, profile.Name)
+		}
+		configured.Insert(option)
+		settings[option] = profile.Config[key]
+	}
+	return settings, nil
+}
+
+func setFloatOption(settings map[string]string, option string, value *float64) {
+	if value != nil {
+		settings[option] = strconv.FormatFloat(*value, 'f', -1, 64)
+	}
+}
+
+// tuningProfileForOSD returns the name of the tuning profile of an OSD. The profile of the device
+// the OSD was created on takes precedence over the profile of the node or device set.
+func tuningProfileForOSD(osdProps osdProperties, osd *OSDInfo) string {
+	if name := perDeviceConfigForOSD(osd, osdProps.devices, osdconfig.TuningProfileKey); name != "" {
+		return name
+	}
+	return osdProps.storeConfig.TuningProfile
+}
+
+// tuningProfileMinAllocSize returns the allocation size of the new OSDs of a tuning profile, or 0
+// if the profile does not set it
+func (c *Cluster) tuningProfileMinAllocSize(name string) int64 {
+	if name == "" {
+		return 0
+	}
+	profile, ok := resolveTuningProfile(c.spec.Storage.TuningProfiles, name)
+	if !ok || profile.BlueStore == nil || profile.BlueStore.MinAllocSize == nil {
+		return 0
+	}
+	return *profile.BlueStore.MinAllocSize
+}
+
+// applyTuningProfile sets the settings of the tuning profile of an OSD in the mon configuration
+// database. The settings of a previous profile are removed by the OSD health monitoring.
+func (c *Cluster) applyTuningProfile(osdProps osdProperties, osd *OSDInfo) error {
+	name := tuningProfileForOSD(osdProps, osd)
+	if name == "" {
+		return nil
+	}
+	profile, ok := resolveTuningProfile(c.spec.Storage.TuningProfiles, name)
+	if !ok {
+		log.NamespacedWarning(c.clusterInfo.Namespace, logger, "tuning profile %q of osd.%d not found", name, osd.ID)
+		return nil
+	}
+	settings, err := tuningProfileSettings(profile)
+	if err != nil {
+		return err
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+	log.NamespacedDebug(c.clusterInfo.Namespace, logger, "applying tuning profile %q to osd.%d", name, osd.ID)
+	monStore := opconfig.GetMonStore(c.context, c.clusterInfo)
+	if err := monStore.SetAll(fmt.Sprintf("osd.%d", osd.ID), settings); err != nil {
+		return errors.Wrapf(err, "failed to apply tuning profile %q to osd.%d", name, osd.ID)
+	}
+	return nil
+}
+
+// checkTuningProfiles sets again the settings of the tuning profiles that were changed on the OSDs,
+// removes the settings of the profiles no longer applied to an OSD, and reports the OSDs of each
+// profile in the CephCluster status
+func (m *OSDHealthMonitor) checkTuningProfiles() error {
+	cephCluster := &cephv1.CephCluster{}
+	if err := m.context.Client.Get(m.clusterInfo.Context, m.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamespacedDebug(m.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+			return nil
+		}
+		return errors.Wrap(err, "failed to get the ceph cluster")
+	}
+	var previous []cephv1.OSDTuningProfileStatus
+	if cephCluster.Status.CephStorage != nil {
+		previous = cephCluster.Status.CephStorage.OSD.TuningProfiles
+	}
+
+	statuses, err := m.updateTuningProfiles(cephCluster.Spec.Storage.TuningProfiles, previous)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(statuses, previous) {
+		return nil
+	}
+	return m.updateTuningProfileStatus(statuses)
+}
+
+// updateTuningProfiles corrects the drift of the settings of the tuning profiles of the OSDs and
+// returns the OSDs of each profile
+func (m *OSDHealthMonitor) updateTuningProfiles(profiles []cephv1.OSDTuningProfile, previous []cephv1.OSDTuningProfileStatus) ([]cephv1.OSDTuningProfileStatus, error) {
+	deployments, err := m.cluster.getOSDDeployments()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list osd deployments")
+	}
+
+	// the options applied to each OSD are reported with the profiles, so that they can be removed
+	// once they are no longer desired even if the profile was changed or deleted from the spec
+	previousProfiles := map[int]string{}
+	previousOptions := map[int][]string{}
+	lastDriftCorrected := map[string]*metav1.Time{}
+	for _, status := range previous {
+		for _, id := range status.OSDs {
+			previousProfiles[id] = status.Name
+		}
+		for _, osd := range status.OSDOptions {
+			previousOptions[osd.ID] = osd.Options
+		}
+		lastDriftCorrected[status.Name] = status.LastDriftCorrected
+	}
+
+	osdIDs := map[int]string{}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		id, err := GetOSDID(d)
+		if err != nil {
+			log.NamespacedWarning(m.clusterInfo.Namespace, logger, "failed to check the tuning profile of osd deployment %q. %v", d.Name, err)
+			continue
+		}
+		if _, hadProfile := previousProfiles[id]; hadProfile || d.Labels[TuningProfileLabelKey] != "" {
+			osdIDs[id] = d.Labels[TuningProfileLabelKey]
+		}
+	}
+	for id := range previousProfiles {
+		if _, ok := osdIDs[id]; !ok {
+			log.NamespacedDebug(m.clusterInfo.Namespace, logger, "no longer reporting the tuning profile of the removed osd.%d", id)
+		}
+	}
+	if len(osdIDs) == 0 {
+		return nil, nil
+	}
+
+	// the settings of all the OSDs are compared with a single dump of the mon configuration database
+	monStore := opconfig.GetMonStore(m.context, m.clusterInfo)
+	options, err := monStore.Dump()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check the settings of the tuning profiles")
+	}
+	current := map[string]map[string]string{}
+	for _, option := range options {
+		if current[option.Who] == nil {
+			current[option.Who] = map[string]string{}
+		}
+		current[option.Who][option.Option] = option.Value
+	}
+
+	profileOSDs := map[string][]cephv1.OSDTuningProfileOptions{}
+	report := func(name string, id int, options []string) {
+		profileOSDs[name] = append(profileOSDs[name], cephv1.OSDTuningProfileOptions{ID: id, Options: options})
+	}
+	for _, id := range slices.Sorted(maps.Keys(osdIDs)) {
+		name := osdIDs[id]
+		previousName, hadProfile := previousProfiles[id]
+
+		var desired map[string]string
+		found := false
+		if name != "" {
+			var profile *cephv1.OSDTuningProfile
+			if profile, found = resolveTuningProfile(profiles, name); !found {
+				log.NamespacedWarning(m.clusterInfo.Namespace, logger, "tuning profile %q of osd.%d not found, removing the settings of its previous profile", name, id)
+			} else if desired, err = tuningProfileSettings(profile); err != nil {
+				log.NamespacedWarning(m.clusterInfo.Namespace, logger, "failed to check the settings of tuning profile %q of osd.%d. %v", name, id, err)
+				if hadProfile {
+					report(previousName, id, previousOptions[id])
+				}
+				continue
+			}
+		}
+
+		// the options applied before that the current profile does not set are removed
+		drifted, err := m.correctTuningProfileDrift(monStore, id, current[fmt.Sprintf("osd.%d", id)], desired, previousOptions[id])
+		if err != nil {
+			log.NamespacedWarning(m.clusterInfo.Namespace, logger, "failed to correct the settings of tuning profile %q of osd.%d. %v", name, id, err)
+			if hadProfile {
+				report(previousName, id, previousOptions[id])
+			}
+			continue
+		}
+		if !found {
+			if hadProfile {
+				log.NamespacedInfo(m.clusterInfo.Namespace, logger, "removed tuning profile %q from osd.%d", previousName, id)
+			}
+			continue
+		}
+		if drifted && hadProfile && previousName == name {
+			now := metav1.Now()
+			lastDriftCorrected[name] = &now
+		}
+		report(name, id, slices.Sorted(maps.Keys(desired)))
+	}
+
+	if len(profileOSDs) == 0 {
+		return nil, nil
+	}
+	statuses := []cephv1.OSDTuningProfileStatus{}
+	for _, name := range slices.Sorted(maps.Keys(profileOSDs)) {
+		status := cephv1.OSDTuningProfileStatus{Name: name, LastDriftCorrected: lastDriftCorrected[name]}
+		for _, osd := range profileOSDs[name] {
+			status.OSDs = append(status.OSDs, osd.ID)
+			if len(osd.Options) > 0 {
+				status.OSDOptions = append(status.OSDOptions, osd)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// correctTuningProfileDrift sets the desired settings of an OSD that differ from its current settings
+// in the mon configuration database and removes the previously applied options that are no longer
+// desired. Returns whether a desired setting was changed.
+func (m *OSDHealthMonitor) correctTuningProfileDrift(monStore *opconfig.MonStore, id int, current, desired map[string]string, applied []string) (bool, error) {
+	who := fmt.Sprintf("osd.%d", id)
+	for _, option := range slices.Sorted(slices.Values(applied)) {
+		if _, ok := desired[option]; ok {
+			continue
+		}
+		if _, ok := current[option]; !ok {
+			continue
+		}
+		if err := monStore.Delete(who, option); err != nil {
+			return false, err
+		}
+	}
+
+	changed := map[string]string{}
+	for _, option := range slices.Sorted(maps.Keys(desired)) {
+		value, ok := current[option]
+		if ok && value == desired[option] {
+			continue
+		}
+		if ok {
+			log.NamespacedInfo(m.clusterInfo.Namespace, logger, "option %q of %s was changed from %q to %q, setting it again", option, who, desired[option], value)
+		}
+		changed[option] = desired[option]
+	}
+	if len(changed) == 0 {
+		return false, nil
+	}
+	if err := monStore.SetAll(who, changed); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateTuningProfileStatus reports the OSDs of each tuning profile in the CephCluster status
+func (m *OSDHealthMonitor) updateTuningProfileStatus(statuses []cephv1.OSDTuningProfileStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephCluster := &cephv1.CephCluster{}
+		if err := m.context.Client.Get(m.clusterInfo.Context, m.clusterInfo.NamespacedName(), cephCluster); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamespacedDebug(m.clusterInfo.Namespace, logger, "CephCluster resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return err
+		}
+		if cephCluster.Status.CephStorage == nil {
+			cephCluster.Status.CephStorage = &cephv1.CephStorage{}
+		}
+		cephCluster.Status.CephStorage.OSD.TuningProfiles = statuses
+		return reporting.UpdateStatus(m.context.Client, cephCluster)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update the osd tuning profiles in the ceph cluster status")
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	osdconfig "github.com/rook/rook/pkg/operator/ceph/cluster/osd/config"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTuningProfileSettings(t *testing.T) {
+	tuningProfileSettings := func(profile *cephv1.OSDTuningProfile) map[string]string {
+		settings, err := tuningProfileSettings(profile)
+		require.NoError(t, err)
+		return settings
+	}
+
+	t.Run("built-in profiles", func(t *testing.T) {
+		profile, ok := resolveTuningProfile(nil, "hdd-archive")
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{
+			"bluestore_compression_mode":            "aggressive",
+			"bluestore_compression_algorithm":       "zstd",
+			"osd_mclock_profile":                    "balanced",
+			"osd_max_backfills":                     "1",
+			"osd_mclock_override_recovery_settings": "true",
+		}, tuningProfileSettings(profile))
+
+		profile, ok = resolveTuningProfile(nil, "nvme-latency")
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{
+			"bluestore_cache_meta_ratio":     "0.5",
+			"bluestore_cache_kv_ratio":       "0.4",
+			"bluestore_cache_kv_onode_ratio": "0.1",
+			"bluestore_compression_mode":     "none",
+			"osd_mclock_profile":             "high_client_ops",
+		}, tuningProfileSettings(profile))
+
+		_, ok = resolveTuningProfile(nil, "unknown")
+		assert.False(t, ok)
+	})
+
+	t.Run("declared profiles replace the built-in ones", func(t *testing.T) {
+		profiles := []cephv1.OSDTuningProfile{{
+			Name:     "hdd-archive",
+			Recovery: &cephv1.OSDRecoveryTuning{Sleep: ptr.To(0.25)},
+			Config:   map[string]string{"osd-op-num-shards": "4", "osd op num threads per shard": "2"},
+		}}
+		profile, ok := resolveTuningProfile(profiles, "hdd-archive")
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{
+			"osd_recovery_sleep":           "0.25",
+			"osd_op_num_shards":            "4",
+			"osd_op_num_threads_per_shard": "2",
+		}, tuningProfileSettings(profile))
+	})
+}
+
+func TestValidateTuningProfiles(t *testing.T) {
+	assert.NoError(t, validateTuningProfiles([]cephv1.OSDTuningProfile{{Name: "custom", Config: map[string]string{"osd op-num_shards": "4"}}}))
+
+	err := validateTuningProfiles([]cephv1.OSDTuningProfile{{Name: "custom", Config: map[string]string{"osd_op_num_shards=4": "4"}}})
+	assert.ErrorContains(t, err, `invalid ceph option name "osd_op_num_shards=4" in the config of tuning profile "custom"`)
+
+	err = validateTuningProfiles([]cephv1.OSDTuningProfile{{Name: "custom", Config: map[string]string{"OSD_OP_NUM_SHARDS": "4"}}})
+	assert.ErrorContains(t, err, "invalid ceph option name")
+
+	err = validateTuningProfiles([]cephv1.OSDTuningProfile{{Name: "custom", Config: map[string]string{"osd-op-num-shards": "4", "osd_op_num_shards": "8"}}})
+	assert.ErrorContains(t, err, `ceph option "osd_op_num_shards" is set more than once in the config of tuning profile "custom"`)
+}
+
+func TestTuningProfileForOSD(t *testing.T) {
+	osdProps := osdProperties{
+		devices: []cephv1.Device{
+			{Name: "sda", Config: map[string]string{osdconfig.TuningProfileKey: "nvme-latency"}},
+			{Name: "sdb"},
+		},
+		storeConfig: osdconfig.StoreConfig{TuningProfile: "hdd-archive"},
+	}
+	assert.Equal(t, "nvme-latency", tuningProfileForOSD(osdProps, &OSDInfo{BlockPath: "/dev/sda"}))
+	assert.Equal(t, "hdd-archive", tuningProfileForOSD(osdProps, &OSDInfo{BlockPath: "/dev/sdb"}))
+	assert.Equal(t, "hdd-archive", tuningProfileForOSD(osdProps, &OSDInfo{}))
+}
+
+func TestCheckTuningProfiles(t *testing.T) {
+	namespace := "rook-ceph"
+	// the options of the osds in the mon configuration database
+	store := map[string]map[string]string{}
+	dumps := 0
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "config" && args[1] == "dump":
+				dumps++
+				result := []map[string]any{{"section": "global", "name": "osd_pool_default_size", "value": "3", "mask": ""}}
+				for who, options := range store {
+					for option, value := range options {
+						result = append(result, map[string]any{"section": who, "name": option, "value": value, "mask": ""})
+					}
+				}
+				output, err := json.Marshal(result)
+				return string(output), err
+			case args[0] == "config" && args[1] == "rm":
+				delete(store[args[2]], args[3])
+				return "", nil
+			case args[0] == "config" && args[1] == "assimilate-conf":
+				content, err := os.ReadFile(args[3])
+				if err != nil {
+					return "", err
+				}
+				who := ""
+				for line := range strings.SplitSeq(string(content), "\n") {
+					line = strings.TrimSpace(line)
+					if strings.HasPrefix(line, "[") {
+						who = strings.Trim(line, "[]")
+						if store[who] == nil {
+							store[who] = map[string]string{}
+						}
+					} else if option, value, ok := strings.Cut(line, "="); ok {
+						store[who][strings.TrimSpace(option)] = strings.TrimSpace(value)
+					}
+				}
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %v", args)
+		},
+	}
+
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "testing", Namespace: namespace},
+		Spec: cephv1.ClusterSpec{
+			Storage: cephv1.StorageScopeSpec{
+				TuningProfiles: []cephv1.OSDTuningProfile{{Name: "custom", MClockProfile: "high_recovery_ops"}},
+			},
+		},
+	}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	clientset := fake.NewClientset(
+		osdDeployment(0, nil, map[string]string{TuningProfileLabelKey: "hdd-archive"}),
+		osdDeployment(1, nil, map[string]string{TuningProfileLabelKey: "custom"}),
+		osdDeployment(2, nil, nil),
+	)
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	clusterInfo.Context = context.TODO()
+	clusterInfo.SetName("testing")
+	ctx := &clusterd.Context{Executor: executor, Clientset: clientset, Client: client, ConfigDir: t.TempDir()}
+	m := NewOSDHealthMonitor(ctx, clusterInfo, false, cephv1.CephClusterHealthCheckSpec{}, cephv1.ClusterSpec{}, "rook/ceph:test")
+
+	statuses := func() []cephv1.OSDTuningProfileStatus {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, client.Get(context.TODO(), clusterInfo.NamespacedName(), cluster))
+		if cluster.Status.CephStorage == nil {
+			return nil
+		}
+		return cluster.Status.CephStorage.OSD.TuningProfiles
+	}
+
+	t.Run("the settings of the profiles are applied and reported", func(t *testing.T) {
+		require.NoError(t, m.checkTuningProfiles())
+		// the settings of all the osds are read at once
+		assert.Equal(t, 1, dumps)
+		assert.Equal(t, "zstd", store["osd.0"]["bluestore_compression_algorithm"])
+		assert.Equal(t, map[string]string{"osd_mclock_profile": "high_recovery_ops"}, store["osd.1"])
+		assert.Empty(t, store["osd.2"])
+		assert.Equal(t, []cephv1.OSDTuningProfileStatus{
+			{Name: "custom", OSDs: []int{1}, OSDOptions: []cephv1.OSDTuningProfileOptions{{ID: 1, Options: []string{"osd_mclock_profile"}}}},
+			{Name: "hdd-archive", OSDs: []int{0}, OSDOptions: []cephv1.OSDTuningProfileOptions{{ID: 0, Options: []string{
+				"bluestore_compression_algorithm",
+				"bluestore_compression_mode",
+				"osd_max_backfills",
+				"osd_mclock_override_recovery_settings",
+				"osd_mclock_profile",
+			}}}},
+		}, statuses())
+	})
+
+	t.Run("changed settings are set again", func(t *testing.T) {
+		store["osd.0"]["osd_max_backfills"] = "8"
+		delete(store["osd.1"], "osd_mclock_profile")
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, "1", store["osd.0"]["osd_max_backfills"])
+		assert.Equal(t, "high_recovery_ops", store["osd.1"]["osd_mclock_profile"])
+		for _, status := range statuses() {
+			assert.NotNil(t, status.LastDriftCorrected, status.Name)
+		}
+	})
+
+	t.Run("the settings of a removed profile are deleted", func(t *testing.T) {
+		d, err := clientset.AppsV1().Deployments(namespace).Get(context.TODO(), "rook-ceph-osd-0", metav1.GetOptions{})
+		require.NoError(t, err)
+		d.Labels[TuningProfileLabelKey] = "custom"
+		_, err = clientset.AppsV1().Deployments(namespace).Update(context.TODO(), d, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.NoError(t, clientset.AppsV1().Deployments(namespace).Delete(context.TODO(), "rook-ceph-osd-1", metav1.DeleteOptions{}))
+		// a setting of the user is kept
+		store["osd.0"]["osd_op_queue_cut_off"] = "high"
+
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, map[string]string{"osd_mclock_profile": "high_recovery_ops", "osd_op_queue_cut_off": "high"}, store["osd.0"])
+		reported := statuses()
+		require.Len(t, reported, 1)
+		assert.Equal(t, "custom", reported[0].Name)
+		assert.Equal(t, []int{0}, reported[0].OSDs)
+		assert.Equal(t, []cephv1.OSDTuningProfileOptions{{ID: 0, Options: []string{"osd_mclock_profile"}}}, reported[0].OSDOptions)
+	})
+
+	setProfiles := func(profiles []cephv1.OSDTuningProfile) {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, client.Get(context.TODO(), clusterInfo.NamespacedName(), cluster))
+		cluster.Spec.Storage.TuningProfiles = profiles
+		require.NoError(t, client.Update(context.TODO(), cluster))
+	}
+	setLabel := func(name string) {
+		d, err := clientset.AppsV1().Deployments(namespace).Get(context.TODO(), "rook-ceph-osd-0", metav1.GetOptions{})
+		require.NoError(t, err)
+		if name == "" {
+			delete(d.Labels, TuningProfileLabelKey)
+		} else {
+			d.Labels[TuningProfileLabelKey] = name
+		}
+		_, err = clientset.AppsV1().Deployments(namespace).Update(context.TODO(), d, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	t.Run("an option removed from a profile is deleted", func(t *testing.T) {
+		setProfiles([]cephv1.OSDTuningProfile{{Name: "custom", MClockProfile: "high_recovery_ops", Config: map[string]string{"osd_op_num_shards": "4"}}})
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, map[string]string{"osd_mclock_profile": "high_recovery_ops", "osd_op_num_shards": "4", "osd_op_queue_cut_off": "high"}, store["osd.0"])
+
+		setProfiles([]cephv1.OSDTuningProfile{{Name: "custom", Config: map[string]string{"osd_op_num_shards": "4"}}})
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, map[string]string{"osd_op_num_shards": "4", "osd_op_queue_cut_off": "high"}, store["osd.0"])
+		assert.Equal(t, []cephv1.OSDTuningProfileStatus{
+			{Name: "custom", OSDs: []int{0}, OSDOptions: []cephv1.OSDTuningProfileOptions{{ID: 0, Options: []string{"osd_op_num_shards"}}}, LastDriftCorrected: statuses()[0].LastDriftCorrected},
+		}, statuses())
+	})
+
+	t.Run("the settings of a profile deleted from the spec are deleted", func(t *testing.T) {
+		setProfiles(nil)
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, map[string]string{"osd_op_queue_cut_off": "high"}, store["osd.0"])
+		assert.Empty(t, statuses())
+	})
+
+	t.Run("the settings are deleted when the label is removed after the profile was deleted", func(t *testing.T) {
+		setProfiles([]cephv1.OSDTuningProfile{{Name: "custom", MClockProfile: "high_recovery_ops"}})
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, "high_recovery_ops", store["osd.0"]["osd_mclock_profile"])
+
+		setProfiles(nil)
+		setLabel("")
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, map[string]string{"osd_op_queue_cut_off": "high"}, store["osd.0"])
+		assert.Empty(t, statuses())
+	})
+
+	t.Run("the settings applied last are kept while the profile is invalid", func(t *testing.T) {
+		setProfiles([]cephv1.OSDTuningProfile{{Name: "custom", MClockProfile: "high_recovery_ops"}})
+		setLabel("custom")
+		require.NoError(t, m.checkTuningProfiles())
+		reported := statuses()
+
+		setProfiles([]cephv1.OSDTuningProfile{{Name: "custom", Config: map[string]string{"osd_op_num_shards=4": "4"}}})
+		require.NoError(t, m.checkTuningProfiles())
+		assert.Equal(t, map[string]string{"osd_mclock_profile": "high_recovery_ops", "osd_op_queue_cut_off": "high"}, store["osd.0"])
+		assert.Equal(t, reported, statuses())
+	})
+}
@@ -21,11 +21,13 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/operator/ceph/config/keyring"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	"github.com/rook/rook/pkg/util/log"
 	appsv1 "k8s.io/api/apps/v1"
@@ -43,16 +45,21 @@ var (
 	deploymentOnNodeFunc                        = deploymentOnNode
 	deploymentOnPVCFunc                         = deploymentOnPVC
 	shouldCheckOkToStopFunc                     = cephclient.OSDUpdateShouldCheckOkToStop
+	pauseCheckInterval                          = 15 * time.Second
 )
 
 type updateConfig struct {
 	cluster             *Cluster
 	provisionConfig     *provisionConfig
-	queue               *updateQueue     // these OSDs need updated
-	numUpdatesNeeded    int              // the number of OSDs that needed updating
-	deployments         *existenceList   // these OSDs have existing deployments
-	osdsToSkipReconcile sets.Set[string] // these OSDs should not be updated during reconcile
-	osdDesiredState     map[int]*OSDInfo // the desired state of the OSDs determined during the reconcile
+	queue               *updateQueue        // these OSDs need updated
+	numUpdatesNeeded    int                 // the number of OSDs that needed updating
+	deployments         *existenceList      // these OSDs have existing deployments
+	osdsToSkipReconcile sets.Set[string]    // these OSDs should not be updated during reconcile
+	osdDesiredState     map[int]*OSDInfo    // the desired state of the OSDs determined during the reconcile
+	order               *failureDomainOrder // the order of the updates by failure domain, if any
+	nextPauseCheck      time.Time           // the CephCluster is not read again for the pause annotation until then
+	paused              bool                // the updates were paused with the annotation
+	updatesCondition    *cephv1.Condition   // the last OSDUpdatesPending condition set on the CephCluster
 }
 
 func (c *Cluster) newUpdateConfig(
@@ -69,6 +76,10 @@ func (c *Cluster) newUpdateConfig(
 		deployments,
 		osdsToSkipReconcile,
 		map[int]*OSDInfo{},
+		newFailureDomainOrder(c),
+		time.Time{},
+		false,
+		nil,
 	}
 }
 
@@ -97,7 +108,27 @@ func (c *updateConfig) updateExistingOSDs(errs *provisionErrors) {
 		log.NamespacedInfo(c.cluster.clusterInfo.Namespace, logger, "PGs are healthy to proceed updating OSDs. %v", pgHealthMsg)
 	}
 
-	osdIDQuery, _ := c.queue.Pop()
+	if c.pauseRequested() {
+		paused := c.queue.Len()
+		c.queue.Remove(slices.Clone(c.queue.q))
+		c.numUpdatesNeeded -= paused
+		c.paused = true
+		message := fmt.Sprintf("OSD updates are paused with the %q annotation. %d OSDs will be updated when the annotation is removed", cephv1.PauseOSDUpdatesAnnotationKey, paused)
+		log.NamespacedInfo(c.cluster.clusterInfo.Namespace, logger, "%s", message)
+		c.setUpdatesCondition(v1.ConditionTrue, cephv1.OSDUpdatesPausedReason, message)
+		return
+	}
+
+	var osdIDQuery int
+	if c.order != nil {
+		var ok bool
+		osdIDQuery, ok = c.order.pop(c)
+		if !ok {
+			return
+		}
+	} else {
+		osdIDQuery, _ = c.queue.Pop()
+	}
 
 	var osdIDs []int
 	var err error
@@ -124,6 +155,11 @@ func (c *updateConfig) updateExistingOSDs(errs *provisionErrors) {
 		}
 	}
 
+	if c.order != nil {
+		// do not widen the update to the OSDs of other failure domains
+		osdIDs = c.order.filter(osdIDs)
+	}
+
 	log.NamespacedDebug(c.cluster.clusterInfo.Namespace, logger, "updating OSDs: %v", osdIDs)
 
 	updatedDeployments := make([]*appsv1.Deployment, 0, len(osdIDs))
@@ -232,6 +268,50 @@ func (c *updateConfig) updateExistingOSDs(errs *provisionErrors) {
 	c.queue.Remove(osdIDs)
 }
 
+// pauseRequested returns true if the user paused the OSD updates with an annotation on the CephCluster.
+// The CephCluster is read at most once per pauseCheckInterval while the OSDs are updated.
+func (c *updateConfig) pauseRequested() bool {
+	if time.Now().Before(c.nextPauseCheck) {
+		return false
+	}
+	c.nextPauseCheck = time.Now().Add(pauseCheckInterval)
+
+	cephCluster := cephv1.CephCluster{}
+	err := c.cluster.context.Client.Get(c.cluster.clusterInfo.Context, c.cluster.clusterInfo.NamespacedName(), &cephCluster)
+	if err != nil {
+		log.NamespacedWarning(c.cluster.clusterInfo.Namespace, logger, "failed to get the CephCluster to check if the OSD updates are paused. %v", err)
+		return false
+	}
+	return cephCluster.Annotations[cephv1.PauseOSDUpdatesAnnotationKey] == "true"
+}
+
+// waitingForPGs returns true if the updates are waiting for the PGs to be clean before updating the
+// OSDs of the next failure domain
+func (c *updateConfig) waitingForPGs() bool {
+	return c.order != nil && c.order.waitingForPGs
+}
+
+// setUpdatesCondition sets the OSDUpdatesPending condition on the CephCluster. The condition is
+// persisted across reconciles so that paused or partial updates remain visible in the status.
+func (c *updateConfig) setUpdatesCondition(status v1.ConditionStatus, reason cephv1.ConditionReason, message string) {
+	if c.updatesCondition != nil && c.updatesCondition.Status == status && c.updatesCondition.Reason == reason && c.updatesCondition.Message == message {
+		return
+	}
+	condition := cephv1.Condition{
+		Type:    cephv1.ConditionOSDUpdatesPending,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+	cephCluster := &cephv1.CephCluster{}
+	err := reporting.UpdateStatusConditionsWithRetry(c.cluster.clusterInfo.Context, c.cluster.context.Client, cephCluster, c.cluster.clusterInfo.NamespacedName(), "CephCluster", condition)
+	if err != nil {
+		log.NamespacedWarning(c.cluster.clusterInfo.Namespace, logger, "failed to set the %q condition. %v", cephv1.ConditionOSDUpdatesPending, err)
+		return
+	}
+	c.updatesCondition = &condition
+}
+
 // getOSDUpdateInfo returns an update queue of OSDs which need to be updated and an existence list of OSD
 // Deployments which already exist.
 func (c *Cluster) getOSDUpdateInfo(errs *provisionErrors) (*updateQueue, *existenceList, error) {
@@ -304,6 +384,19 @@ func (q *updateQueue) Pop() (osdID int, ok bool) {
 	return osdID, true
 }
 
+// PopMatching pops the first item matching the given function off the queue.
+// Returns -1 and ok=false if no item matches. Otherwise, returns an OSD ID and ok=true.
+func (q *updateQueue) PopMatching(match func(osdID int) bool) (osdID int, ok bool) {
+	idx := slices.IndexFunc(q.q, match)
+	if idx == -1 {
+		return -1, false
+	}
+
+	osdID = q.q[idx]
+	q.q = slices.Delete(q.q, idx, idx+1)
+	return osdID, true
+}
+
 // Exists returns true if the item exists in the queue.
 func (q *updateQueue) Exists(osdID int) bool {
 	return slices.Contains(q.q, osdID)
@@ -396,8 +489,10 @@ func (c *Cluster) rotateCephxKey(osdInfo OSDInfo) (cephv1.CephxStatus, error) {
 	// TODO: for rotation WithCephVersionUpdate fix this to have the right runningCephVersion and desiredCephVersion
 	runningCephVersion := c.clusterInfo.CephVersion
 	desiredCephVersion := c.clusterInfo.CephVersion
+	// daemon key type always takes the default from setDefaultCephxKeyType()
 	shouldRotate, err := keyring.ShouldRotateCephxKeys(c.spec.Security.CephX.Daemon,
-		runningCephVersion, desiredCephVersion, osdInfo.CephxStatus, true, c.clusterInfo.Namespace) // daemon key type always takes the default from setDefaultCephxKeyType()
+		runningCephVersion, desiredCephVersion, osdInfo.CephxStatus, true, c.clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephCluster", c.clusterInfo.NamespacedName().Name, keyring.CephxComponentOSD))
 	if err != nil {
 		return osdInfo.CephxStatus, errors.Wrapf(err, "failed to determine if cephx key for OSD %d needs rotated", osdInfo.ID)
 	}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+)
+
+// allow unit tests to override the interval of the PG health checks between two failure domains
+var failureDomainPGCheckInterval = 15 * time.Second
+
+// failureDomainOrder orders the OSD updates by CRUSH failure domain. All the queued OSDs of a failure
+// domain are updated before the OSDs of the next one, once the PGs are clean again.
+type failureDomainOrder struct {
+	strategy *cephv1.OSDUpdateStrategySpec
+	// the failure domain of each OSD, read from the topology location labels of the OSD deployments.
+	// OSDs without the label are in the failure domain "".
+	failureDomains map[int]string
+	current        string
+	started        bool
+	nextPGCheck    time.Time
+	// waitingForPGs is true while the PGs are not clean yet to update the next failure domain
+	waitingForPGs bool
+}
+
+func newFailureDomainOrder(c *Cluster) *failureDomainOrder {
+	strategy := c.spec.Storage.OSDUpdateStrategy
+	if strategy == nil || strategy.FailureDomain == "" {
+		return nil
+	}
+	return &failureDomainOrder{strategy: strategy}
+}
+
+// loadFailureDomains reads the failure domain of each OSD from its deployment
+func (o *failureDomainOrder) loadFailureDomains(c *Cluster) {
+	o.failureDomains = map[int]string{}
+	deployments, err := c.getOSDDeployments()
+	if err != nil {
+		log.NamespacedWarning(c.clusterInfo.Namespace, logger, "failed to get the failure domains of the OSDs, updating them in any order. %v", err)
+		return
+	}
+	label := fmt.Sprintf(TopologyLocationLabel, o.strategy.FailureDomain)
+	for i := range deployments.Items {
+		osdID, err := GetOSDID(&deployments.Items[i])
+		if err != nil {
+			continue
+		}
+		o.failureDomains[osdID] = deployments.Items[i].Labels[label]
+	}
+}
+
+// nextFailureDomain returns the failure domain to update after the current one: the failure domains
+// in the order of the strategy first, then the others by name, and the OSDs without failure domain last
+func (o *failureDomainOrder) nextFailureDomain(queue *updateQueue) string {
+	queued := []string{}
+	for _, osdID := range queue.q {
+		if domain := o.failureDomains[osdID]; !slices.Contains(queued, domain) {
+			queued = append(queued, domain)
+		}
+	}
+	for _, domain := range o.strategy.Order {
+		if slices.Contains(queued, domain) {
+			return domain
+		}
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		if queued[i] == "" || queued[j] == "" {
+			return queued[j] == ""
+		}
+		return queued[i] < queued[j]
+	})
+	return queued[0]
+}
+
+// pop returns the next OSD to update, or false if the PGs are not clean yet to start updating the
+// next failure domain
+func (o *failureDomainOrder) pop(c *updateConfig) (int, bool) {
+	if o.failureDomains == nil {
+		o.loadFailureDomains(c.cluster)
+	}
+	inCurrent := func(osdID int) bool { return o.failureDomains[osdID] == o.current }
+
+	if !o.started || !slices.ContainsFunc(c.queue.q, inCurrent) {
+		if o.started && !c.cluster.spec.SkipUpgradeChecks {
+			// let the PGs recover from the updates of the previous failure domain
+			if time.Now().Before(o.nextPGCheck) {
+				return -1, false
+			}
+			pgHealthMsg, pgClean, err := cephclient.IsClusterClean(c.cluster.context, c.cluster.clusterInfo, c.cluster.spec.DisruptionManagement.PGHealthyRegex)
+			if err != nil || !pgClean {
+				log.NamespacedInfo(c.cluster.clusterInfo.Namespace, logger, "waiting for PGs to be clean after updating the OSDs in %s %q. PGs status: %q. %v",
+					o.strategy.FailureDomain, o.current, pgHealthMsg, err)
+				o.nextPGCheck = time.Now().Add(failureDomainPGCheckInterval)
+				if !o.waitingForPGs {
+					o.waitingForPGs = true
+					c.setUpdatesCondition(v1.ConditionTrue, cephv1.OSDUpdatesWaitingForCleanPGsReason,
+						fmt.Sprintf("waiting for the PGs to be clean after updating the OSDs in %s %q", o.strategy.FailureDomain, o.current))
+				}
+				return -1, false
+			}
+		}
+		o.waitingForPGs = false
+		o.current = o.nextFailureDomain(c.queue)
+		o.started = true
+		log.NamespacedInfo(c.cluster.clusterInfo.Namespace, logger, "updating the OSDs in %s %q", o.strategy.FailureDomain, o.current)
+		c.setUpdatesCondition(v1.ConditionTrue, cephv1.OSDUpdatesInProgressReason, fmt.Sprintf("updating the OSDs in %s %q", o.strategy.FailureDomain, o.current))
+	}
+
+	return c.queue.PopMatching(inCurrent)
+}
+
+// filter removes the OSDs outside of the current failure domain from the OSDs that are ok to stop
+func (o *failureDomainOrder) filter(osdIDs []int) []int {
+	return slices.DeleteFunc(osdIDs, func(osdID int) bool { return o.failureDomains[osdID] != o.current })
+}
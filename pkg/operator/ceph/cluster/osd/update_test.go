@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/coreos/pkg/capnslog"
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
 	"github.com/rook/rook/pkg/clusterd"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	cephclientfake "github.com/rook/rook/pkg/daemon/ceph/client/fake"
@@ -42,6 +44,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_updateExistingOSDs(t *testing.T) {
@@ -100,6 +103,7 @@ func Test_updateExistingOSDs(t *testing.T) {
 		// set up intermediates
 		ctx = &clusterd.Context{
 			Clientset: clientset,
+			Client:    clientfake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
 			Executor:  executor,
 		}
 		clusterInfo := &cephclient.ClusterInfo{
@@ -575,6 +579,7 @@ func Test_updateExistingOSDs(t *testing.T) {
 
 		ctx = &clusterd.Context{
 			Clientset: clientset,
+			Client:    clientfake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
 			Executor:  executor,
 		}
 		clusterInfo := &cephclient.ClusterInfo{
@@ -621,6 +626,196 @@ func Test_updateExistingOSDs(t *testing.T) {
 	})
 }
 
+func Test_updateExistingOSDsByFailureDomain(t *testing.T) {
+	namespace := "my-namespace"
+
+	oldUpdateFunc := updateMultipleDeploymentsAndWaitFunc
+	oldConditionFunc := updateConditionFunc
+	oldShouldCheckFunc := shouldCheckOkToStopFunc
+	oldInterval := failureDomainPGCheckInterval
+	oldPauseInterval := pauseCheckInterval
+	defer func() {
+		updateMultipleDeploymentsAndWaitFunc = oldUpdateFunc
+		updateConditionFunc = oldConditionFunc
+		shouldCheckOkToStopFunc = oldShouldCheckFunc
+		failureDomainPGCheckInterval = oldInterval
+		pauseCheckInterval = oldPauseInterval
+	}()
+	failureDomainPGCheckInterval = 0
+	pauseCheckInterval = 0
+	updateConditionFunc = func(ctx context.Context, c *clusterd.Context, namespaceName types.NamespacedName, observedGeneration int64, conditionType cephv1.ConditionType, status corev1.ConditionStatus, reason cephv1.ConditionReason, message string) {
+	}
+	shouldCheckOkToStopFunc = func(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) bool { return true }
+	var deploymentsUpdated []string
+	updateMultipleDeploymentsAndWaitFunc = func(ctx context.Context, clientset kubernetes.Interface, deployments []*appsv1.Deployment, listFunc func() (*appsv1.DeploymentList, error)) k8sutil.Failures {
+		for _, d := range deployments {
+			deploymentsUpdated = append(deploymentsUpdated, d.Name)
+		}
+		return k8sutil.Failures{}
+	}
+
+	cephStatus := healthyCephStatus
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "ok-to-stop" {
+				// ok-to-stop widens the update to the OSDs of other racks
+				queriedID, _ := strconv.Atoi(args[2])
+				return cephclientfake.OsdOkToStopOutput(queriedID, []int{0, 1, 2, 3, 4}), nil
+			}
+			if args[0] == "osd" && args[1] == "crush" && args[2] == "get-device-class" {
+				return cephclientfake.OSDDeviceClassOutput(args[3]), nil
+			}
+			if args[0] == "status" {
+				return cephStatus, nil
+			}
+			return "", errors.Errorf("unexpected command %q with args %v", command, args)
+		},
+	}
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: namespace}}
+	clientset := fake.NewClientset()
+	ctx := &clusterd.Context{
+		Clientset: clientset,
+		Client:    clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(cephCluster).Build(),
+		Executor:  executor,
+	}
+	clusterInfo := &cephclient.ClusterInfo{Namespace: namespace, CephVersion: cephver.Squid, Context: context.TODO()}
+	clusterInfo.SetName("mycluster")
+	clusterInfo.OwnerInfo = cephclient.NewMinimumOwnerInfo(t)
+	spec := cephv1.ClusterSpec{}
+	spec.Storage.OSDUpdateStrategy = &cephv1.OSDUpdateStrategySpec{FailureDomain: "rack", Order: []string{"rack-b"}}
+	c := New(ctx, clusterInfo, spec, "rook/rook:master")
+
+	racks := map[int]string{0: "rack-b", 1: "rack-a", 2: "rack-b", 3: "rack-a", 4: ""}
+	for osdID := range 5 {
+		d := getDummyDeploymentOnNode(clientset, c, fmt.Sprintf("node%d", osdID), osdID)
+		if racks[osdID] != "" {
+			d.Labels["topology-location-rack"] = racks[osdID]
+		}
+		_, err := clientset.AppsV1().Deployments(namespace).Create(context.TODO(), d, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	updateConfig := c.newUpdateConfig(c.newProvisionConfig(), newUpdateQueueWithIDs(0, 1, 2, 3, 4), newExistenceListWithIDs(0, 1, 2, 3, 4), sets.New[string]())
+	errs := newProvisionErrors()
+
+	updatesCondition := func() *cephv1.Condition {
+		assert.NoError(t, ctx.Client.Get(context.TODO(), clusterInfo.NamespacedName(), cephCluster))
+		return cephv1.FindStatusCondition(cephCluster.Status.Conditions, cephv1.ConditionOSDUpdatesPending)
+	}
+
+	// the racks in the order are updated first
+	updateConfig.updateExistingOSDs(errs)
+	assert.ElementsMatch(t, []string{"rook-ceph-osd-0", "rook-ceph-osd-2"}, deploymentsUpdated)
+	assert.Equal(t, cephv1.OSDUpdatesInProgressReason, updatesCondition().Reason)
+	assert.False(t, updateConfig.waitingForPGs())
+
+	// the next rack waits for the PGs to be clean
+	deploymentsUpdated = nil
+	cephStatus = unHealthyCephStatus
+	updateConfig.updateExistingOSDs(errs)
+	assert.Empty(t, deploymentsUpdated)
+	assert.True(t, updateConfig.waitingForPGs())
+	condition := updatesCondition()
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, cephv1.OSDUpdatesWaitingForCleanPGsReason, condition.Reason)
+	assert.Equal(t, `waiting for the PGs to be clean after updating the OSDs in rack "rack-b"`, condition.Message)
+
+	cephStatus = healthyCephStatus
+	updateConfig.updateExistingOSDs(errs)
+	assert.ElementsMatch(t, []string{"rook-ceph-osd-1", "rook-ceph-osd-3"}, deploymentsUpdated)
+	assert.False(t, updateConfig.waitingForPGs())
+	assert.Equal(t, `updating the OSDs in rack "rack-a"`, updatesCondition().Message)
+
+	// the updates are paused with the annotation
+	cephCluster.Annotations = map[string]string{cephv1.PauseOSDUpdatesAnnotationKey: "true"}
+	assert.NoError(t, ctx.Client.Update(context.TODO(), cephCluster))
+	deploymentsUpdated = nil
+	updateConfig.updateExistingOSDs(errs)
+	assert.Empty(t, deploymentsUpdated)
+	assert.True(t, updateConfig.doneUpdating())
+	assert.True(t, updateConfig.paused)
+	assert.Equal(t, cephv1.OSDUpdatesPausedReason, updatesCondition().Reason)
+	completed, initial := updateConfig.progress()
+	assert.Equal(t, 4, completed)
+	assert.Equal(t, 4, initial)
+	assert.Zero(t, errs.len())
+}
+
+func Test_updateExistingOSDsPaused(t *testing.T) {
+	namespace := "my-namespace"
+
+	oldUpdateFunc := updateMultipleDeploymentsAndWaitFunc
+	oldConditionFunc := updateConditionFunc
+	oldShouldCheckFunc := shouldCheckOkToStopFunc
+	defer func() {
+		updateMultipleDeploymentsAndWaitFunc = oldUpdateFunc
+		updateConditionFunc = oldConditionFunc
+		shouldCheckOkToStopFunc = oldShouldCheckFunc
+	}()
+	updateConditionFunc = func(ctx context.Context, c *clusterd.Context, namespaceName types.NamespacedName, observedGeneration int64, conditionType cephv1.ConditionType, status corev1.ConditionStatus, reason cephv1.ConditionReason, message string) {
+	}
+	shouldCheckOkToStopFunc = func(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) bool { return false }
+	var deploymentsUpdated []string
+	updateMultipleDeploymentsAndWaitFunc = func(ctx context.Context, clientset kubernetes.Interface, deployments []*appsv1.Deployment, listFunc func() (*appsv1.DeploymentList, error)) k8sutil.Failures {
+		for _, d := range deployments {
+			deploymentsUpdated = append(deploymentsUpdated, d.Name)
+		}
+		return k8sutil.Failures{}
+	}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "crush" && args[2] == "get-device-class" {
+				return cephclientfake.OSDDeviceClassOutput(args[3]), nil
+			}
+			return "", errors.Errorf("unexpected command %q with args %v", command, args)
+		},
+	}
+
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: namespace}}
+	clientset := fake.NewClientset()
+	ctx := &clusterd.Context{
+		Clientset: clientset,
+		Client:    clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(cephCluster).Build(),
+		Executor:  executor,
+	}
+	clusterInfo := &cephclient.ClusterInfo{Namespace: namespace, CephVersion: cephver.Squid, Context: context.TODO()}
+	clusterInfo.SetName("mycluster")
+	clusterInfo.OwnerInfo = cephclient.NewMinimumOwnerInfo(t)
+	// the updates are not ordered by failure domain
+	c := New(ctx, clusterInfo, cephv1.ClusterSpec{}, "rook/rook:master")
+	for osdID := range 3 {
+		d := getDummyDeploymentOnNode(clientset, c, fmt.Sprintf("node%d", osdID), osdID)
+		_, err := clientset.AppsV1().Deployments(namespace).Create(context.TODO(), d, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	updateConfig := c.newUpdateConfig(c.newProvisionConfig(), newUpdateQueueWithIDs(0, 1, 2), newExistenceListWithIDs(0, 1, 2), sets.New[string]())
+	errs := newProvisionErrors()
+
+	updateConfig.updateExistingOSDs(errs)
+	assert.Equal(t, []string{"rook-ceph-osd-0"}, deploymentsUpdated)
+
+	// the annotation is not read again before the check interval
+	cephCluster.Annotations = map[string]string{cephv1.PauseOSDUpdatesAnnotationKey: "true"}
+	assert.NoError(t, ctx.Client.Update(context.TODO(), cephCluster))
+	updateConfig.updateExistingOSDs(errs)
+	assert.Equal(t, []string{"rook-ceph-osd-0", "rook-ceph-osd-1"}, deploymentsUpdated)
+
+	// the updates are paused once the annotation is read
+	updateConfig.nextPauseCheck = time.Time{}
+	updateConfig.updateExistingOSDs(errs)
+	assert.Equal(t, []string{"rook-ceph-osd-0", "rook-ceph-osd-1"}, deploymentsUpdated)
+	assert.True(t, updateConfig.doneUpdating())
+	assert.NoError(t, ctx.Client.Get(context.TODO(), clusterInfo.NamespacedName(), cephCluster))
+	condition := cephv1.FindStatusCondition(cephCluster.Status.Conditions, cephv1.ConditionOSDUpdatesPending)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, cephv1.OSDUpdatesPausedReason, condition.Reason)
+	assert.Contains(t, condition.Message, "1 OSDs will be updated when the annotation is removed")
+	completed, initial := updateConfig.progress()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 2, initial)
+	assert.Zero(t, errs.len())
+}
+
 func Test_getOSDUpdateInfo(t *testing.T) {
 	namespace := "rook-ceph"
 	cephImage := "quay.io/ceph/ceph:v15"
@@ -825,6 +1020,20 @@ func Test_updateQueue(t *testing.T) {
 	testPop(9)
 	testPop(10)
 	assertEmpty()
+
+	// test popping the first matching item
+	for _, i := range []int{11, 12, 13, 14} {
+		q.Push(i)
+	}
+	id, ok := q.PopMatching(func(osdID int) bool { return osdID%2 == 0 })
+	assert.True(t, ok)
+	assert.Equal(t, 12, id)
+	_, ok = q.PopMatching(func(osdID int) bool { return osdID > 20 })
+	assert.False(t, ok)
+	testPop(11)
+	testPop(13)
+	testPop(14)
+	assertEmpty()
 }
 
 func Test_existenceList(t *testing.T) {
@@ -884,6 +1093,7 @@ func TestCluster_rotateCephxKey(t *testing.T) {
 			Namespace:   "ns",
 			CephVersion: cephver.CephVersion{Major: 20, Minor: 2},
 		}
+		clusterInfo.SetName("my-cluster")
 		return &Cluster{
 			context:     &clusterd,
 			clusterInfo: &clusterInfo,
@@ -19,6 +19,7 @@ package cluster
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -72,13 +73,30 @@ func shouldReconcileChangedNode(objOld, objNew *corev1.Node) bool {
 // reconcile.
 var topologyLabels = strings.Split(topology.GetDefaultTopologyLabels(), ",")
 
-// nodeTopologyLabelsChanged returns true if the value of any of the OSD topology
-// labels Rook cares about differs between the old and new node. This detects a
+// clusterTopologyLabels returns the default topology labels and the node labels
+// of the custom CRUSH hierarchies declared by the CephClusters.
+func clusterTopologyLabels(ctx context.Context, c client.Client) []string {
+	labels := topologyLabels
+	clusterList := cephv1.CephClusterList{}
+	if err := c.List(ctx, &clusterList); err != nil {
+		logger.Debugf("failed to list ceph clusters for their crush topology labels. %v", err)
+		return labels
+	}
+	for _, cluster := range clusterList.Items {
+		if cluster.Spec.Storage.CrushTopology != nil {
+			labels = append(slices.Clone(labels), topology.Labels(cluster.Spec.Storage.CrushTopology)...)
+		}
+	}
+	return labels
+}
+
+// nodeTopologyLabelsChanged returns true if the value of any of the given OSD
+// topology labels differs between the old and new node. This detects a
 // topology label being added, removed, or changed.
-func nodeTopologyLabelsChanged(objOld, objNew *corev1.Node) bool {
+func nodeTopologyLabelsChanged(objOld, objNew *corev1.Node, labels []string) bool {
 	oldLabels := objOld.GetLabels()
 	newLabels := objNew.GetLabels()
-	for _, label := range topologyLabels {
+	for _, label := range labels {
 		if oldLabels[label] != newLabels[label] {
 			return true
 		}
@@ -108,7 +126,7 @@ func predicateForNodeWatcher[T *corev1.Node](ctx context.Context, client client.
 			// directly. Otherwise onK8sNode() would return false for a node that
 			// is already an OSD host and the relabel would never be propagated to
 			// the CRUSH map.
-			if nodeTopologyLabelsChanged(objOld, objNew) {
+			if nodeTopologyLabelsChanged(objOld, objNew, clusterTopologyLabels(ctx, client)) {
 				return true
 			}
 
@@ -309,6 +327,22 @@ func watchControllerPredicate[T *cephv1.CephCluster](ctx context.Context, c clie
 				return true
 			}
 
+			// Resume the OSD updates paused by the user when the annotation is removed
+			oldPaused := objOld.GetAnnotations()[cephv1.PauseOSDUpdatesAnnotationKey]
+			newPaused := objNew.GetAnnotations()[cephv1.PauseOSDUpdatesAnnotationKey]
+			if oldPaused != newPaused && newPaused != "true" {
+				log.NamespacedInfo(objNew.Namespace, logger, "OSD updates of CephCluster %q are resumed; triggering reconcile", objNew.Name)
+				return true
+			}
+
+			// Re-address the mons when requested by the user
+			oldReaddress := objOld.GetAnnotations()[cephv1.ReaddressMonsAnnotationKey]
+			newReaddress := objNew.GetAnnotations()[cephv1.ReaddressMonsAnnotationKey]
+			if oldReaddress != newReaddress && newReaddress == "true" {
+				log.NamespacedInfo(objNew.Namespace, logger, "re-addressing of the mons of CephCluster %q is requested; triggering reconcile", objNew.Name)
+				return true
+			}
+
 			return false
 		},
 		GenericFunc: func(e event.TypedGenericEvent[T]) bool {
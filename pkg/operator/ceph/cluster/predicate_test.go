@@ -115,7 +115,7 @@ func TestNodeTopologyLabelsChanged(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			//#nosec G601 -- since nothing is modifying the tests slice
-			assert.Equal(t, tt.changed, nodeTopologyLabelsChanged(&tt.oldobj, &tt.newobj))
+			assert.Equal(t, tt.changed, nodeTopologyLabelsChanged(&tt.oldobj, &tt.newobj, topologyLabels))
 		})
 	}
 }
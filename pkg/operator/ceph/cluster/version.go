@@ -18,6 +18,8 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	daemonclient "github.com/rook/rook/pkg/daemon/ceph/client"
@@ -27,9 +29,9 @@ import (
 	"github.com/rook/rook/pkg/util/log"
 )
 
-func (c *ClusterController) detectAndValidateCephVersion(cluster *cluster) (*cephver.CephVersion, bool, error) {
+func (c *ClusterController) detectAndValidateCephVersion(ctx context.Context, cluster *cluster) (*cephver.CephVersion, bool, error) {
 	version, err := controller.DetectCephVersion(
-		c.OpManagerCtx,
+		ctx,
 		c.rookImage,
 		cluster.Namespace,
 		detectVersionName,
@@ -29,6 +29,9 @@ import (
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
 )
 
 var logger = capnslog.NewPackageLogger("github.com/rook/rook", "op-config")
@@ -60,6 +63,9 @@ const (
 
 	// CrashType defines the crash collector DaemonType
 	CrashType = "crashcollector"
+
+	// osdCrushInitialWeight is the initial CRUSH weight of the new OSDs ramped up by the operator
+	osdCrushInitialWeight = "0"
 )
 
 var (
@@ -133,6 +139,11 @@ func SetOrRemoveDefaultConfigs(
 		}
 	}
 
+	// New OSDs join the CRUSH map with a zero weight when their weight is ramped up by the operator
+	if err := reconcileOSDCrushInitialWeight(context, clusterInfo, monStore, clusterSpec); err != nil {
+		return err
+	}
+
 	// This section will remove any previously configured option(s) from the mon centralized store
 	// This is useful for scenarios where options are not needed anymore and we just want to reset to the internal default
 	// On upgrade, the flag will be removed
@@ -143,6 +154,46 @@ func SetOrRemoveDefaultConfigs(
 	return nil
 }
 
+// reconcileOSDCrushInitialWeight sets the initial CRUSH weight of the new OSDs while their weight is
+// ramped up by the operator. The CephCluster status records that Rook set the option, which is only
+// removed when the ramping is disabled so that a weight set by the admin is kept.
+func reconcileOSDCrushInitialWeight(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, monStore *MonStore, clusterSpec cephv1.ClusterSpec) error {
+	enabled := clusterSpec.Storage.CrushWeight != nil && clusterSpec.Storage.CrushWeight.InitialWeight != nil
+	cephCluster := &cephv1.CephCluster{}
+	if err := context.Client.Get(clusterInfo.Context, clusterInfo.NamespacedName(), cephCluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to get the CephCluster to check the initial crush weight of the osds")
+	}
+	set := cephCluster.Status.CephStorage != nil && cephCluster.Status.CephStorage.OSD.CrushInitialWeightSet
+
+	if enabled {
+		if err := monStore.Set("osd", "osd_crush_initial_weight", osdCrushInitialWeight); err != nil {
+			return errors.Wrap(err, "failed to set the initial crush weight of the osds")
+		}
+	} else if set {
+		if err := monStore.Delete("osd", "osd_crush_initial_weight"); err != nil {
+			return errors.Wrap(err, "failed to remove the initial crush weight of the osds")
+		}
+	}
+	if enabled == set {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := context.Client.Get(clusterInfo.Context, clusterInfo.NamespacedName(), cephCluster); err != nil {
+			return err
+		}
+		if cephCluster.Status.CephStorage == nil {
+			cephCluster.Status.CephStorage = &cephv1.CephStorage{}
+		}
+		cephCluster.Status.CephStorage.OSD.CrushInitialWeightSet = enabled
+		return reporting.UpdateStatus(context.Client, cephCluster)
+	})
+	return errors.Wrap(err, "failed to record the initial crush weight of the osds in the CephCluster status")
+}
+
 func DisableInsecureGlobalID(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) {
 	monStore := GetMonStore(context, clusterInfo)
 	if err := monStore.Set("mon", "auth_allow_insecure_global_id_reclaim", "false"); err != nil {
@@ -40,10 +40,26 @@ var CephAuthRotateSupportedVersion = version.CephVersion{Major: 19, Minor: 2, Ex
 //nolint:gosec // G101: this is not hardcoded credentials
 const CephxKeyIdentifierAnnotation = "cephx-key-identifier"
 
+// The components of the CephCluster owning CephX keys, named as in the CephCluster cephx status
+const (
+	CephxComponentAdmin          = "admin"
+	CephxComponentMon            = "mon"
+	CephxComponentMgr            = "mgr"
+	CephxComponentOSD            = "osd"
+	CephxComponentCSI            = "csi"
+	CephxComponentRBDMirrorPeer  = "rbdMirrorPeer"
+	CephxComponentCrashCollector = "crashCollector"
+	CephxComponentCephExporter   = "cephExporter"
+)
+
 var (
 	// Ensure multiple CephCluster reconciles don't run into threading issues with the map.
 	cephxKeyRotationAllowedMutex      = sync.Mutex{}
 	cephxKeyRotationAllowedForCluster = map[string]bool{}
+
+	// Ensure the CephX inventories and the reconciles rotating keys don't run into threading issues with the map.
+	cephxKeysOverdueMutex      = sync.Mutex{}
+	cephxKeysOverdueForCluster = map[string]map[string]uint32{}
 )
 
 // SetAllowCephxKeyRotationForCluster sets a global config indicating whether CephX key rotation is
@@ -82,6 +98,41 @@ func GetAllowCephxKeyRotationForCluster(namespace string) (rotationAllowed bool,
 	return allowed, true
 }
 
+// CephxKeyOwner identifies the keys of a resource, and of a component for the CephCluster, when
+// checking if they are older than the maximum key age of the cluster. E.g., "CephFilesystem/myfs"
+// or "CephCluster/my-cluster/mgr".
+func CephxKeyOwner(kind, name, component string) string {
+	owner := kind + "/" + name
+	if component != "" {
+		owner += "/" + component
+	}
+	return owner
+}
+
+// SetOverdueCephxKeysForCluster records the keys of a CephCluster that are older than the maximum
+// key age, as the newest overdue key generation of each owner from CephxKeyOwner(). The keys of an
+// owner are rotated by ShouldRotateCephxKeys() until the key generation in its status is newer.
+// A nil map disables the rotation of overdue keys for the cluster.
+func SetOverdueCephxKeysForCluster(namespace string, overdue map[string]uint32) {
+	cephxKeysOverdueMutex.Lock()
+	defer cephxKeysOverdueMutex.Unlock()
+	if overdue == nil {
+		delete(cephxKeysOverdueForCluster, namespace)
+		return
+	}
+	cephxKeysOverdueForCluster[namespace] = overdue
+}
+
+func isCephxKeyOverdue(namespace, owner string, status v1.CephxStatus) bool {
+	if owner == "" {
+		return false
+	}
+	cephxKeysOverdueMutex.Lock()
+	defer cephxKeysOverdueMutex.Unlock()
+	generation, ok := cephxKeysOverdueForCluster[namespace][owner]
+	return ok && status.KeyGeneration <= generation
+}
+
 // ShouldRotateCephxKeys determines whether CephX keys should be rotated based on the CephX key
 // rotation config, the version of Ceph present in the image being deployed (desiredCephVersion),
 // and the last-reconciled CephX key status.
@@ -89,7 +140,9 @@ func GetAllowCephxKeyRotationForCluster(namespace string) (rotationAllowed bool,
 // Intended to use running/desired ceph version from CurrentAndDesiredCephVersion().
 // ignoreKeyType can be used by callers to ignore the key type in rotation calculation - intended
 // for daemon keys that (except for admin and mon) don't allow type overrides.
-func ShouldRotateCephxKeys(cfg v1.CephxConfig, runningCephVersion, desiredCephVersion version.CephVersion, status v1.CephxStatus, ignoreKeyType bool, clusterNamespace string) (bool, error) {
+// owner is the CephxKeyOwner() of the keys, to rotate them regardless of the rotation policy if they
+// are older than the maximum key age of the cluster.
+func ShouldRotateCephxKeys(cfg v1.CephxConfig, runningCephVersion, desiredCephVersion version.CephVersion, status v1.CephxStatus, ignoreKeyType bool, clusterNamespace, owner string) (bool, error) {
 	// note: the default return at the end of the function is false. only return false during
 	// ShouldRotate checking if further true returns should be invalidated
 
@@ -125,6 +178,14 @@ func ShouldRotateCephxKeys(cfg v1.CephxConfig, runningCephVersion, desiredCephVe
 		return false, nil // no need to rotate key when key isn't yet initialized
 	}
 
+	// are the keys older than the maximum key age of the cluster?
+	if isCephxKeyOverdue(clusterNamespace, owner, status) {
+		if allowRotationUndefined {
+			return false, rotationUndefinedErr
+		}
+		return true, nil
+	}
+
 	// does rotation policy indicate rotation?
 	switch cfg.KeyRotationPolicy {
 	case v1.CephxKeyRotationPolicy(""), v1.DisabledCephxKeyRotationPolicy:
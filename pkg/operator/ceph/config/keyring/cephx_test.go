@@ -104,7 +104,7 @@ func TestShouldRotateCephxKeys(t *testing.T) {
 			// run all tests for case where ceph version does support rotation
 			t.Run(tt.name, func(t *testing.T) {
 				ignoreKeyType := false // for these tests, don't ignore key type
-				got, err := ShouldRotateCephxKeys(tt.cfg, SupportsKeyTypeVer, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs)
+				got, err := ShouldRotateCephxKeys(tt.cfg, SupportsKeyTypeVer, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs, "")
 				if (err != nil) != tt.wantErr {
 					t.Errorf("ShouldRotateCephxKeys() error = %v, wantErr %v", err, tt.wantErr)
 					return
@@ -121,7 +121,7 @@ func TestShouldRotateCephxKeys(t *testing.T) {
 			// run all tests for case where ceph version does support rotation
 			t.Run(tt.name, func(t *testing.T) {
 				ignoreKeyType := false // for these tests, don't ignore key type
-				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs)
+				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs, "")
 				if (err != nil) != tt.wantErr {
 					t.Errorf("ShouldRotateCephxKeys() error = %v, wantErr %v", err, tt.wantErr)
 					return
@@ -138,7 +138,7 @@ func TestShouldRotateCephxKeys(t *testing.T) {
 			// and run all tests for case where ceph version does not support rotation
 			t.Run(tt.name, func(t *testing.T) {
 				ignoreKeyType := false // for these tests, don't ignore key type
-				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 2}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs)
+				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 2}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs, "")
 				assert.NoError(t, err)
 				assert.False(t, got)
 			})
@@ -152,7 +152,7 @@ func TestShouldRotateCephxKeys(t *testing.T) {
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
 				ignoreKeyType := false // for these tests, don't ignore key type
-				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs)
+				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs, "")
 				assert.NoError(t, err)
 				assert.False(t, got)
 			})
@@ -166,7 +166,7 @@ func TestShouldRotateCephxKeys(t *testing.T) {
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
 				ignoreKeyType := false // for these tests, don't ignore key type
-				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, "undefined-ns")
+				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, "undefined-ns", "")
 				if tt.want == true && tt.wantErr == false {
 					assert.ErrorContains(t, err, "cephx key rotation is indicated but must wait for CephCluster in namespace")
 				} else if tt.wantErr {
@@ -182,7 +182,7 @@ func TestShouldRotateCephxKeys(t *testing.T) {
 		for _, tt := range keyTypeTests {
 			t.Run(tt.name, func(t *testing.T) {
 				ignoreKeyType := true // test that ignoring key type for keyType tests
-				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs)
+				got, err := ShouldRotateCephxKeys(tt.cfg, version.CephVersion{Major: 19, Minor: 2, Extra: 6}, tt.imageCephVersion, tt.status, ignoreKeyType, clusterNs, "")
 				assert.NoError(t, err)
 				assert.False(t, got) // should not rotate when ignoring keyType
 			})
@@ -196,10 +196,43 @@ func TestShouldRotateCephxKeys(t *testing.T) {
 			KeyType:           v1.CephxKeyTypeAes,
 		}
 		status := v1.CephxStatus{KeyGeneration: 1}
-		got, err := ShouldRotateCephxKeys(cfg, v20_2_0, v20_2_0, status, false, clusterNs)
+		got, err := ShouldRotateCephxKeys(cfg, v20_2_0, v20_2_0, status, false, clusterNs, "")
 		assert.True(t, got)
 		assert.NoError(t, err)
 	})
+
+	t.Run("overdue keys", func(t *testing.T) {
+		owner := CephxKeyOwner("CephFilesystem", "myfs", "")
+		assert.Equal(t, "CephFilesystem/myfs", owner)
+		assert.Equal(t, "CephCluster/my-cluster/mgr", CephxKeyOwner("CephCluster", "my-cluster", "mgr"))
+
+		SetOverdueCephxKeysForCluster(clusterNs, map[string]uint32{owner: 2})
+		defer SetOverdueCephxKeysForCluster(clusterNs, nil)
+
+		// the keys are rotated regardless of the policy while their generation is overdue
+		cfg := v1.CephxConfig{KeyRotationPolicy: v1.DisabledCephxKeyRotationPolicy}
+		got, err := ShouldRotateCephxKeys(cfg, v20_2_0, v20_2_0, v1.CephxStatus{KeyGeneration: 2, KeyCephVersion: "20.2.0-0"}, false, clusterNs, owner)
+		assert.NoError(t, err)
+		assert.True(t, got)
+
+		got, err = ShouldRotateCephxKeys(cfg, v20_2_0, v20_2_0, v1.CephxStatus{KeyGeneration: 3, KeyCephVersion: "20.2.0-0"}, false, clusterNs, owner)
+		assert.NoError(t, err)
+		assert.False(t, got)
+
+		got, err = ShouldRotateCephxKeys(cfg, v20_2_0, v20_2_0, v1.CephxStatus{KeyGeneration: 2, KeyCephVersion: "20.2.0-0"}, false, clusterNs, CephxKeyOwner("CephFilesystem", "other", ""))
+		assert.NoError(t, err)
+		assert.False(t, got)
+
+		// new keys are not rotated
+		got, err = ShouldRotateCephxKeys(cfg, v20_2_0, v20_2_0, UninitializedCephxStatus(), false, clusterNs, owner)
+		assert.NoError(t, err)
+		assert.False(t, got)
+
+		SetOverdueCephxKeysForCluster(clusterNs, nil)
+		got, err = ShouldRotateCephxKeys(cfg, v20_2_0, v20_2_0, v1.CephxStatus{KeyGeneration: 2, KeyCephVersion: "20.2.0-0"}, false, clusterNs, owner)
+		assert.NoError(t, err)
+		assert.False(t, got)
+	})
 }
 
 func Test_parseCephVersionFromStatusVersion(t *testing.T) {
@@ -161,6 +161,35 @@ func (m *MonStore) GetDaemon(who string) ([]Option, error) {
 	return daemonOptions, nil
 }
 
+// Dump retrieves all the configs in the centralized mon configuration database. The entity of an
+// option set with a mask is reported as "<section>/<mask>".
+func (m *MonStore) Dump() ([]Option, error) {
+	args := []string{"config", "dump"}
+	cephCmd := client.NewCephCommand(m.context, m.clusterInfo, args)
+	out, err := cephCmd.RunWithTimeout(exec.CephCommandsTimeout)
+	if err != nil {
+		return []Option{}, errors.Wrapf(err, "failed to dump the config. output: %s", string(out))
+	}
+	var result []struct {
+		Section string `json:"section"`
+		Mask    string `json:"mask"`
+		Name    string `json:"name"`
+		Value   string `json:"value"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return []Option{}, errors.Wrapf(err, "failed to parse json config dump. json: %s", string(out))
+	}
+	options := []Option{}
+	for _, entry := range result {
+		who := entry.Section
+		if entry.Mask != "" {
+			who = entry.Section + "/" + entry.Mask
+		}
+		options = append(options, Option{who, entry.Name, entry.Value})
+	}
+	return options, nil
+}
+
 // DeleteDaemon deletes all configs for a specific daemon in the centralized mon configuration database.
 func (m *MonStore) DeleteDaemon(who string) error {
 	configOptions, err := m.GetDaemon(who)
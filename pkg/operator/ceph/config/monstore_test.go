@@ -17,17 +17,24 @@ limitations under the License.
 package config
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	testop "github.com/rook/rook/pkg/operator/test"
 	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestMonStore_Set(t *testing.T) {
@@ -154,6 +161,43 @@ func TestMonStore_GetDaemon(t *testing.T) {
 	assert.Contains(t, execedCmd, " config get mon.* ")
 }
 
+func TestMonStore_Dump(t *testing.T) {
+	executor := &exectest.MockExecutor{}
+	ctx := &clusterd.Context{Executor: executor}
+
+	execedCmd := ""
+	execReturn := `[{"section":"global","name":"rbd_default_features","value":"3","level":"advanced","mask":""},` +
+		`{"section":"osd","name":"osd_memory_target","value":"4294967296","level":"basic","mask":"host:node-a"},` +
+		`{"section":"osd.0","name":"osd_max_backfills","value":"1","level":"advanced","mask":""}]`
+	execInjectErr := false
+	executor.MockExecuteCommandWithTimeout = func(timeout time.Duration, command string, args ...string) (string, error) {
+		execedCmd = command + " " + strings.Join(args, " ")
+		if execInjectErr {
+			return "output from cmd with error", errors.New("mocked error")
+		}
+		return execReturn, nil
+	}
+
+	monStore := GetMonStore(ctx, client.AdminTestClusterInfo("mycluster"))
+
+	options, err := monStore.Dump()
+	assert.NoError(t, err)
+	assert.Contains(t, execedCmd, "ceph config dump")
+	assert.Equal(t, []Option{
+		{"global", "rbd_default_features", "3"},
+		{"osd/host:node-a", "osd_memory_target", "4294967296"},
+		{"osd.0", "osd_max_backfills", "1"},
+	}, options)
+
+	execReturn = "bad json output"
+	_, err = monStore.Dump()
+	assert.ErrorContains(t, err, "failed to parse json config dump")
+
+	execInjectErr = true
+	_, err = monStore.Dump()
+	assert.Error(t, err)
+}
+
 func TestMonStore_DeleteDaemon(t *testing.T) {
 	executor := &exectest.MockExecutor{}
 	clientset := testop.New(t, 1)
@@ -256,3 +300,56 @@ func TestFilterSettingsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileOSDCrushInitialWeight(t *testing.T) {
+	cephCluster := &cephv1.CephCluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "mycluster"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cephCluster).WithStatusSubresource(cephCluster).Build()
+	executor := &exectest.MockExecutor{}
+	ctx := &clusterd.Context{
+		Clientset: testop.New(t, 1),
+		Client:    cl,
+		Executor:  executor,
+	}
+	commands := []string{}
+	executor.MockExecuteCommandWithTimeout = func(timeout time.Duration, command string, args ...string) (string, error) {
+		switch {
+		case args[0] == "config" && args[1] == "set":
+			assert.Equal(t, []string{"osd", "osd_crush_initial_weight", "0"}, args[2:5])
+		case args[0] == "config" && args[1] == "rm":
+			assert.Equal(t, []string{"osd", "osd_crush_initial_weight"}, args[2:4])
+		default:
+			return "", errors.Errorf("unexpected command %v", args)
+		}
+		commands = append(commands, args[1])
+		return "", nil
+	}
+	clusterInfo := client.AdminTestClusterInfo("mycluster")
+	clusterInfo.SetName(cephCluster.Name)
+	monStore := GetMonStore(ctx, clusterInfo)
+	recorded := func() bool {
+		c := &cephv1.CephCluster{}
+		require.NoError(t, cl.Get(context.TODO(), clusterInfo.NamespacedName(), c))
+		return c.Status.CephStorage != nil && c.Status.CephStorage.OSD.CrushInitialWeightSet
+	}
+	spec := cephv1.ClusterSpec{}
+
+	// a weight set by the admin is kept if the ramping was never enabled
+	require.NoError(t, reconcileOSDCrushInitialWeight(ctx, clusterInfo, monStore, spec))
+	assert.Empty(t, commands)
+	assert.False(t, recorded())
+
+	// the weight is set while the ramping is enabled
+	spec.Storage.CrushWeight = &cephv1.OSDCrushWeightSpec{InitialWeight: ptr.To(0.0)}
+	require.NoError(t, reconcileOSDCrushInitialWeight(ctx, clusterInfo, monStore, spec))
+	require.NoError(t, reconcileOSDCrushInitialWeight(ctx, clusterInfo, monStore, spec))
+	assert.Equal(t, []string{"set", "set"}, commands)
+	assert.True(t, recorded())
+
+	// the weight set by rook is removed once when the ramping is disabled
+	commands = commands[:0]
+	spec.Storage.CrushWeight = nil
+	require.NoError(t, reconcileOSDCrushInitialWeight(ctx, clusterInfo, monStore, spec))
+	require.NoError(t, reconcileOSDCrushInitialWeight(ctx, clusterInfo, monStore, spec))
+	assert.Equal(t, []string{"rm"}, commands)
+	assert.False(t, recorded())
+}
@@ -215,7 +215,7 @@ func CreateOrLoadClusterInfo(clusterdContext *clusterd.Context, context context.
 func loadCsiSettings(namespace string, cephClusterSpec *cephv1.ClusterSpec) cephv1.CSIDriverSpec {
 	settings := cephClusterSpec.CSI
 	if len(settings.ReadAffinity.CrushLocationLabels) == 0 {
-		settings.ReadAffinity.CrushLocationLabels = strings.Split(topology.GetDefaultTopologyLabels(), ",")
+		settings.ReadAffinity.CrushLocationLabels = topology.Labels(cephClusterSpec.Storage.CrushTopology)
 	}
 	// if the csi spec does not specify mount options, apply them from the operator env vars
 	if cephClusterSpec.CSI.CephFS.KernelMountOptions == "" {
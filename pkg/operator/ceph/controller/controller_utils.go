@@ -48,6 +48,9 @@ type OperatorConfig struct {
 type ClusterHealth struct {
 	InternalCtx    context.Context
 	InternalCancel context.CancelFunc
+	// Spec is the part of the cluster spec the monitoring was started with, if it must be restarted
+	// when the spec changes
+	Spec any
 }
 
 const (
@@ -57,7 +60,7 @@ const (
 	enforceHostNetworkDefaultValue string = "false"
 
 	obcAllowAdditionalConfigFieldsSettingName  string = "ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS"
-	obcAllowAdditionalConfigFieldsDefaultValue string = "maxObjects,maxSize"
+	obcAllowAdditionalConfigFieldsDefaultValue string = "maxObjects,maxSize,maxReadOps,maxWriteOps,maxReadBytes,maxWriteBytes,bucketMaxReadOps,bucketMaxWriteOps,bucketMaxReadBytes,bucketMaxWriteBytes"
 
 	revisionHistoryLimitSettingName string = "ROOK_REVISION_HISTORY_LIMIT"
 
@@ -294,3 +297,15 @@ func RecoverAndLogException() {
 func NsName(namespace, name string) types.NamespacedName {
 	return types.NamespacedName{Namespace: namespace, Name: name}
 }
+
+// LongestPrefixMatch returns the index of the longest prefix of the daemon ID, or -1 if none matches.
+// E.g., the prefixes "<filesystem>-" of the filesystems match the ID of their mds daemons.
+func LongestPrefixMatch(daemonID string, count int, prefix func(int) string) int {
+	match := -1
+	for i := range count {
+		if strings.HasPrefix(daemonID, prefix(i)) && (match < 0 || len(prefix(i)) > len(prefix(match))) {
+			match = i
+		}
+	}
+	return match
+}
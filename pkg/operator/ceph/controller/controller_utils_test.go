@@ -295,7 +295,7 @@ func TestObcAllowAdditionalConfigFields(t *testing.T) {
 	}{
 		{
 			"not set", "<notset>",
-			[]string{"maxObjects", "maxSize"}, // default allowlist is unlikely to need changing EVER
+			[]string{"maxObjects", "maxSize", "maxReadOps", "bucketMaxWriteBytes"}, // default allowlist is unlikely to need changing EVER
 			[]string{"bucketMaxObjects", "bucketMaxSize", "bucketPolicy", "bucketLifecycle", "bucketOwner", "random"},
 		},
 		{
@@ -18,15 +18,26 @@ package controller
 
 import (
 	"context"
+	"sync"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// the number of reconciles requested with TriggerReconcile that can wait for the controller of a kind
+const reconcileTriggerBuffer = 100
+
+// reconcileTriggers holds a channel of reconcile requests per kind of resource
+var reconcileTriggers sync.Map
+
 // ObjectToCRMapper returns the list of a given object type metadata
 // It is used to trigger a reconcile of object Kind A when watching object Kind B
 // So we reconcile Kind A instead of Kind B
@@ -57,3 +68,27 @@ func ObjectToCRMapper[List client.ObjectList, T runtime.Object](ctx context.Cont
 		return results
 	}, nil
 }
+
+func reconcileTrigger(kind string) chan event.GenericEvent {
+	trigger, _ := reconcileTriggers.LoadOrStore(kind, make(chan event.GenericEvent, reconcileTriggerBuffer))
+	return trigger.(chan event.GenericEvent)
+}
+
+// ReconcileTriggerSource returns the source of the reconciles of the resources of a kind requested
+// with TriggerReconcile. It is watched by the controller of the kind.
+func ReconcileTriggerSource(kind string) source.Source {
+	return source.Channel(reconcileTrigger(kind), &handler.EnqueueRequestForObject{})
+}
+
+// TriggerReconcile requests a reconcile of a resource from outside of its controller, e.g. from a
+// monitoring goroutine. It returns false if the request was dropped because too many requests are
+// waiting for the controller of the kind.
+func TriggerReconcile(kind string, nsName types.NamespacedName) bool {
+	obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: nsName.Namespace, Name: nsName.Name}}
+	select {
+	case reconcileTrigger(kind) <- event.GenericEvent{Object: obj}:
+		return true
+	default:
+		return false
+	}
+}
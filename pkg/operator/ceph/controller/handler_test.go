@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -66,3 +67,21 @@ func TestObjectToCRMapper(t *testing.T) {
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, fakeRequest, handlerFunc(context.TODO(), fs))
 }
+
+func TestTriggerReconcile(t *testing.T) {
+	kind := "CephTestTrigger"
+	defer reconcileTriggers.Delete(kind)
+
+	nsName := types.NamespacedName{Namespace: namespace, Name: name}
+	assert.True(t, TriggerReconcile(kind, nsName))
+	e := <-reconcileTrigger(kind)
+	assert.Equal(t, name, e.Object.GetName())
+	assert.Equal(t, namespace, e.Object.GetNamespace())
+
+	// the requests are dropped rather than blocking when the controller does not keep up
+	for range reconcileTriggerBuffer {
+		assert.True(t, TriggerReconcile(kind, nsName))
+	}
+	assert.False(t, TriggerReconcile(kind, nsName))
+	assert.Len(t, reconcileTrigger(kind), reconcileTriggerBuffer)
+}
@@ -103,8 +103,24 @@ func ApplyCephNetworkSettings(
 	return nil
 }
 
+// RemoveCephNetworkSettings removes the public and cluster networks from the ceph config. This is
+// needed when migrating a cluster from the host or multus networks to the k8s pod network, where the
+// daemons would otherwise not find an address in the networks of the previous provider.
+func RemoveCephNetworkSettings(clusterdContext *clusterd.Context, clusterInfo *cephclient.ClusterInfo) error {
+	s := getMonStoreFunc(clusterdContext, clusterInfo)
+	for _, cephNet := range []cephv1.CephNetworkType{cephv1.CephNetworkPublic, cephv1.CephNetworkCluster} {
+		settingKey := fmt.Sprintf("%s_network", string(cephNet))
+		log.NamespacedInfo(clusterInfo.Namespace, logger, "removing cluster %q network config", cephNet)
+		if err := s.Delete("global", settingKey); err != nil {
+			return errors.Wrapf(err, "failed to remove cluster %q network config", cephNet)
+		}
+	}
+	return nil
+}
+
 type monStoreInterface interface {
 	SetIfChanged(who string, option string, value string) (bool, error)
+	Delete(who string, option string) error
 }
 
 var getMonStoreFunc = func(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo) monStoreInterface {
@@ -378,6 +378,11 @@ func (m *mockMonStore) SetIfChanged(who string, option string, value string) (bo
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockMonStore) Delete(who string, option string) error {
+	args := m.Called(who, option)
+	return args.Error(0)
+}
+
 func mockGetMonStoreFunc(m *mockMonStore) func(context *clusterd.Context, clusterInfo *client.ClusterInfo) monStoreInterface {
 	return func(context *clusterd.Context, clusterInfo *client.ClusterInfo) monStoreInterface {
 		return m
@@ -895,3 +900,29 @@ func ipAddrOutput(iface string, ipsWithPrefixLens ...string) string {
 ]`
 	return out
 }
+
+func TestRemoveCephNetworkSettings(t *testing.T) {
+	oldGetMonStoreFunc := getMonStoreFunc
+	defer func() { getMonStoreFunc = oldGetMonStoreFunc }()
+
+	clusterdCtx, _, clusterInfo := newTestConfigsWithNetworkSpec(cephv1.NetworkSpec{})
+
+	t.Run("networks removed", func(t *testing.T) {
+		monStore := new(mockMonStore)
+		monStore.On("Delete", "global", "public_network").Return(nil)
+		monStore.On("Delete", "global", "cluster_network").Return(nil)
+		getMonStoreFunc = mockGetMonStoreFunc(monStore)
+
+		assert.NoError(t, RemoveCephNetworkSettings(clusterdCtx, clusterInfo))
+		monStore.AssertExpectations(t)
+	})
+
+	t.Run("failure to remove", func(t *testing.T) {
+		monStore := new(mockMonStore)
+		monStore.On("Delete", "global", "public_network").Return(errors.New("induced error"))
+		getMonStoreFunc = mockGetMonStoreFunc(monStore)
+
+		assert.Error(t, RemoveCephNetworkSettings(clusterdCtx, clusterInfo))
+		monStore.AssertNotCalled(t, "Delete", "global", "cluster_network")
+	})
+}
@@ -1058,3 +1058,36 @@ func GetDaemonsToSkipReconcile(ctx context.Context, clusterd *clusterd.Context,
 
 	return result, nil
 }
+
+// GetDaemonsHeldByCanaryUpgrade returns the daemons whose deployment keeps its current version until a
+// canary upgrade continues
+func GetDaemonsHeldByCanaryUpgrade(ctx context.Context, clusterd *clusterd.Context, namespace, daemonName, appName string) (sets.Set[string], error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s,%s", k8sutil.AppAttr, appName, cephv1.CanaryUpgradeHoldLabelKey)}
+
+	deployments, err := clusterd.Clientset.AppsV1().Deployments(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query %q held by the canary upgrade", daemonName)
+	}
+
+	result := sets.New[string]()
+	for _, deployment := range deployments.Items {
+		if daemonID, ok := deployment.Labels[daemonName]; ok {
+			logger.Infof("found %q %q pod held by the canary upgrade", daemonID, daemonName)
+			result.Insert(daemonID)
+		}
+	}
+
+	return result, nil
+}
+
+// IsCanaryUpgradeHoldingDaemons returns whether a canary upgrade holds the current version of daemons
+func IsCanaryUpgradeHoldingDaemons(ctx context.Context, clusterd *clusterd.Context, namespace string) (bool, error) {
+	listOpts := metav1.ListOptions{LabelSelector: cephv1.CanaryUpgradeHoldLabelKey, Limit: 1}
+
+	deployments, err := clusterd.Clientset.AppsV1().Deployments(namespace).List(ctx, listOpts)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to query deployments held by the canary upgrade")
+	}
+
+	return len(deployments.Items) > 0, nil
+}
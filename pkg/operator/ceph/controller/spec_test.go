@@ -785,3 +785,33 @@ func TestGetDaemonsToSkipReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDaemonsHeldByCanaryUpgrade(t *testing.T) {
+	namespace := "rook-ceph"
+	clientset := test.New(t, 1)
+	clusterdCtx := &clusterd.Context{
+		Clientset: clientset,
+	}
+
+	holding, err := IsCanaryUpgradeHoldingDaemons(context.TODO(), clusterdCtx, namespace)
+	assert.NoError(t, err)
+	assert.False(t, holding)
+
+	for _, id := range []string{"a", "b"} {
+		labels := map[string]string{k8sutil.AppAttr: "rook-ceph-mon", config.MonType: id}
+		if id == "b" {
+			labels[cephv1.CanaryUpgradeHoldLabelKey] = "true"
+		}
+		dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon-" + id, Namespace: namespace, Labels: labels}}
+		_, err := clientset.AppsV1().Deployments(namespace).Create(context.TODO(), dep, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	result, err := GetDaemonsHeldByCanaryUpgrade(context.TODO(), clusterdCtx, namespace, config.MonType, "rook-ceph-mon")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, result.UnsortedList())
+
+	holding, err = IsCanaryUpgradeHoldingDaemons(context.TODO(), clusterdCtx, namespace)
+	assert.NoError(t, err)
+	assert.True(t, holding)
+}
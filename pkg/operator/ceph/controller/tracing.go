@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/tracing"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	tracingEnabledSettingName      = "ROOK_TRACING_ENABLED"
+	tracingEndpointSettingName     = "ROOK_TRACING_OTLP_ENDPOINT"
+	tracingInsecureSettingName     = "ROOK_TRACING_OTLP_INSECURE"
+	tracingSampleRatioSettingName  = "ROOK_TRACING_SAMPLE_RATIO"
+	tracingSampleRatioDefaultValue = 1.0
+)
+
+// TracingConfig returns the tracing settings from the operator config and whether tracing is enabled
+func TracingConfig() (tracing.Config, bool) {
+	strEnabled := k8sutil.GetOperatorSetting(tracingEnabledSettingName, "false")
+	enabled, err := strconv.ParseBool(strEnabled)
+	if err != nil {
+		logger.Warningf("%s is set to an invalid value %q, tracing is disabled", tracingEnabledSettingName, strEnabled)
+		enabled = false
+	}
+
+	strInsecure := k8sutil.GetOperatorSetting(tracingInsecureSettingName, "false")
+	insecure, err := strconv.ParseBool(strInsecure)
+	if err != nil {
+		logger.Warningf("%s is set to an invalid value %q, set the default value false", tracingInsecureSettingName, strInsecure)
+		insecure = false
+	}
+
+	strRatio := k8sutil.GetOperatorSetting(tracingSampleRatioSettingName, strconv.FormatFloat(tracingSampleRatioDefaultValue, 'f', -1, 64))
+	ratio, err := strconv.ParseFloat(strRatio, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		logger.Warningf("%s is %q but it should be between 0 and 1, set the default value %v", tracingSampleRatioSettingName, strRatio, tracingSampleRatioDefaultValue)
+		ratio = tracingSampleRatioDefaultValue
+	}
+
+	return tracing.Config{
+		Endpoint:    k8sutil.GetOperatorSetting(tracingEndpointSettingName, ""),
+		Insecure:    insecure,
+		SampleRatio: ratio,
+	}, enabled
+}
+
+type tracingReconciler struct {
+	controllerName string
+	reconciler     reconcile.Reconciler
+}
+
+// WithTracing wraps a reconciler so that each reconcile is recorded as a span carrying the name of
+// the controller and of the CR. The span is available from the context passed to the reconciler.
+func WithTracing(controllerName string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &tracingReconciler{controllerName: controllerName, reconciler: r}
+}
+
+func (t *tracingReconciler) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, t.controllerName+" Reconcile",
+		tracing.ControllerKey.String(t.controllerName),
+		tracing.NamespaceKey.String(request.Namespace),
+		tracing.NameKey.String(request.Name))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return t.reconciler.Reconcile(ctx, request)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/util/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestTracingConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cfg, enabled := TracingConfig()
+		assert.False(t, enabled)
+		assert.Equal(t, tracing.Config{SampleRatio: 1}, cfg)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Setenv("ROOK_TRACING_ENABLED", "true")
+		t.Setenv("ROOK_TRACING_OTLP_ENDPOINT", "otel-collector.monitoring.svc:4318")
+		t.Setenv("ROOK_TRACING_OTLP_INSECURE", "true")
+		t.Setenv("ROOK_TRACING_SAMPLE_RATIO", "0.25")
+		cfg, enabled := TracingConfig()
+		assert.True(t, enabled)
+		assert.Equal(t, tracing.Config{Endpoint: "otel-collector.monitoring.svc:4318", Insecure: true, SampleRatio: 0.25}, cfg)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		t.Setenv("ROOK_TRACING_ENABLED", "foo")
+		t.Setenv("ROOK_TRACING_OTLP_INSECURE", "bar")
+		t.Setenv("ROOK_TRACING_SAMPLE_RATIO", "2")
+		cfg, enabled := TracingConfig()
+		assert.False(t, enabled)
+		assert.False(t, cfg.Insecure)
+		assert.Equal(t, float64(1), cfg.SampleRatio)
+	})
+}
+
+type fakeReconciler struct {
+	spanCtx trace.SpanContext
+	err     error
+}
+
+func (f *fakeReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	f.spanCtx = trace.SpanFromContext(ctx).SpanContext()
+	return reconcile.Result{}, f.err
+}
+
+func TestWithTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "rook-ceph", Name: "my-store"}}
+
+	t.Run("success", func(t *testing.T) {
+		exporter.Reset()
+		inner := &fakeReconciler{}
+		_, err := WithTracing("ceph-object-controller", inner).Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "ceph-object-controller Reconcile", spans[0].Name)
+		assert.ElementsMatch(t, spans[0].Attributes, []attribute.KeyValue{
+			tracing.ControllerKey.String("ceph-object-controller"),
+			tracing.NamespaceKey.String("rook-ceph"),
+			tracing.NameKey.String("my-store"),
+		})
+		// the wrapped reconciler sees the span so that it can create children
+		assert.Equal(t, spans[0].SpanContext.SpanID(), inner.spanCtx.SpanID())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		exporter.Reset()
+		inner := &fakeReconciler{err: errors.New("induced error")}
+		_, err := WithTracing("ceph-object-controller", inner).Reconcile(context.TODO(), request)
+		assert.Error(t, err)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+	})
+}
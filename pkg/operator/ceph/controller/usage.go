@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	usageIntervalSettingName  = "ROOK_USAGE_COLLECTION_INTERVAL"
+	usageIntervalDefaultValue = 5 * time.Minute
+	usageCollectorName        = "usage-collector"
+)
+
+var (
+	usageLabels = []string{"namespace", "kind", "name"}
+
+	tenantUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_used_bytes",
+		Help: "Bytes stored by a rados namespace, subvolume group, object store user or account",
+	}, usageLabels)
+	tenantQuotaBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_quota_bytes",
+		Help: "Quota of a subvolume group, object store user or account that has a quota",
+	}, usageLabels)
+	tenantProvisionedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_provisioned_bytes",
+		Help: "Total size of the RBD images in a rados namespace",
+	}, usageLabels)
+	tenantImages = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_images",
+		Help: "Number of RBD images in a rados namespace",
+	}, usageLabels)
+	tenantObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_objects",
+		Help: "Number of RADOS objects of an object store user or account",
+	}, usageLabels)
+	tenantSentBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_sent_bytes",
+		Help: "Bytes sent by the RGW to an object store user or account, from the RGW usage log",
+	}, usageLabels)
+	tenantReceivedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_received_bytes",
+		Help: "Bytes received by the RGW from an object store user or account, from the RGW usage log",
+	}, usageLabels)
+	tenantOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_tenant_operations",
+		Help: "Number of RGW operations of an object store user or account, from the RGW usage log",
+	}, usageLabels)
+
+	usageGauges = []*prometheus.GaugeVec{tenantUsedBytes, tenantQuotaBytes, tenantProvisionedBytes, tenantImages, tenantObjects, tenantSentBytes, tenantReceivedBytes, tenantOperations}
+)
+
+func init() {
+	for _, gauge := range usageGauges {
+		metrics.Registry.MustRegister(gauge)
+	}
+}
+
+// UsageCollectionInterval returns how often the usage of rados namespaces, subvolume groups, and
+// object store users and accounts is collected. Collection is disabled if the interval is 0.
+func UsageCollectionInterval() time.Duration {
+	strInterval := k8sutil.GetOperatorSetting(usageIntervalSettingName, usageIntervalDefaultValue.String())
+	interval, err := time.ParseDuration(strInterval)
+	if err != nil || interval < 0 {
+		logger.Warningf("%s is set to an invalid duration %q, set the default value %s", usageIntervalSettingName, strInterval, usageIntervalDefaultValue)
+		return usageIntervalDefaultValue
+	}
+	return interval
+}
+
+// ReportUsage exports the usage of a CR as metrics
+func ReportUsage(kind string, obj metav1.Object, usage *cephv1.UsageStatus) {
+	labels := prometheus.Labels{"namespace": obj.GetNamespace(), "kind": kind, "name": obj.GetName()}
+	tenantUsedBytes.With(labels).Set(float64(usage.UsedBytes))
+	if usage.QuotaBytes > 0 {
+		tenantQuotaBytes.With(labels).Set(float64(usage.QuotaBytes))
+	} else {
+		tenantQuotaBytes.Delete(labels)
+	}
+	switch kind {
+	case "CephBlockPoolRadosNamespace":
+		tenantProvisionedBytes.With(labels).Set(float64(usage.ProvisionedBytes))
+		tenantImages.With(labels).Set(float64(usage.Images))
+	case "CephObjectStoreUser", "CephObjectStoreAccount":
+		tenantObjects.With(labels).Set(float64(usage.Objects))
+		tenantSentBytes.With(labels).Set(float64(usage.BytesSent))
+		tenantReceivedBytes.With(labels).Set(float64(usage.BytesReceived))
+		tenantOperations.With(labels).Set(float64(usage.Operations))
+	}
+}
+
+// DeleteUsageMetrics removes the usage metrics of a deleted CR
+func DeleteUsageMetrics(kind string, obj metav1.Object) {
+	labels := prometheus.Labels{"namespace": obj.GetNamespace(), "kind": kind, "name": obj.GetName()}
+	for _, gauge := range usageGauges {
+		gauge.Delete(labels)
+	}
+}
+
+// UsageObject is a CR whose usage is collected
+type UsageObject interface {
+	client.Object
+	SetUsage(usage *cephv1.UsageStatus)
+}
+
+// UpdateUsageStatus sets the usage in the status of the latest version of a CR
+func UpdateUsageStatus(ctx context.Context, c client.Client, obj UsageObject, usage *cephv1.UsageStatus) error {
+	nsName := NsName(obj.GetNamespace(), obj.GetName())
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := c.Get(ctx, nsName, obj); err != nil {
+			return err
+		}
+		obj.SetUsage(usage)
+		return c.Status().Update(ctx, obj)
+	})
+}
+
+// UsageCollectFunc returns the usage of a CR from the Ceph cluster of the cluster info
+type UsageCollectFunc[T UsageObject] func(ctx context.Context, context *clusterd.Context, k8sClient client.Client, clusterInfo *cephclient.ClusterInfo, obj T) (*cephv1.UsageStatus, error)
+
+// UsageCollector collects the usage of all the CRs of a kind at the usage collection interval. It
+// is added to the manager of the controller of the CRs and runs separately from their reconcile.
+type UsageCollector[T UsageObject] struct {
+	Client  client.Client
+	Context *clusterd.Context
+	// Kind is the kind of the CRs
+	Kind string
+	// List is an empty list of the CRs, e.g. &cephv1.CephFilesystemSubVolumeGroupList{}
+	List client.ObjectList
+	// ClusterNamespace returns the namespace of the CephCluster of a CR if it can differ from the
+	// namespace of the CR
+	ClusterNamespace func(obj T) string
+	// Collect returns the usage of a CR
+	Collect UsageCollectFunc[T]
+}
+
+// Start collects the usage until the context is cancelled
+func (c *UsageCollector[T]) Start(ctx context.Context) error {
+	interval := UsageCollectionInterval()
+	if interval == 0 {
+		logger.Infof("usage collection of %s CRs is disabled", c.Kind)
+		return nil
+	}
+
+	logger.Infof("collecting the usage of %s CRs every %s", c.Kind, interval)
+	for {
+		c.collectAll(ctx)
+		select {
+		case <-ctx.Done():
+			logger.Infof("stopping usage collection of %s CRs", c.Kind)
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// collectAll collects the usage of every CR. Failures are only logged since the usage is collected
+// again at the next interval.
+func (c *UsageCollector[T]) collectAll(ctx context.Context) {
+	list := c.List.DeepCopyObject().(client.ObjectList)
+	if err := c.Client.List(ctx, list); err != nil {
+		logger.Warningf("failed to list %s CRs to collect their usage. %v", c.Kind, err)
+		return
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		logger.Warningf("failed to read the list of %s CRs to collect their usage. %v", c.Kind, err)
+		return
+	}
+
+	// the cluster info is loaded once for all the CRs of a cluster
+	clusterInfos := map[string]*cephclient.ClusterInfo{}
+	for _, item := range items {
+		obj, ok := item.(T)
+		if !ok || !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		clusterNamespace := obj.GetNamespace()
+		if c.ClusterNamespace != nil {
+			clusterNamespace = c.ClusterNamespace(obj)
+		}
+		clusterInfo, ok := clusterInfos[clusterNamespace]
+		if !ok {
+			clusterInfo = c.loadClusterInfo(ctx, clusterNamespace)
+			clusterInfos[clusterNamespace] = clusterInfo
+		}
+		if clusterInfo == nil {
+			continue
+		}
+
+		nsName := NsName(obj.GetNamespace(), obj.GetName())
+		usage, err := c.Collect(ctx, c.Context, c.Client, clusterInfo, obj)
+		if err != nil {
+			log.NamedWarning(nsName, logger, "failed to collect usage. %v", err)
+			continue
+		}
+		usage.LastUpdateTime = &metav1.Time{Time: time.Now()}
+		ReportUsage(c.Kind, obj, usage)
+		if err := UpdateUsageStatus(ctx, c.Client, obj, usage); err != nil {
+			log.NamedWarning(nsName, logger, "failed to update usage status. %v", err)
+		}
+	}
+}
+
+// loadClusterInfo returns the cluster info of the CephCluster in the namespace, or nil if the
+// cluster is not ready to run ceph commands
+func (c *UsageCollector[T]) loadClusterInfo(ctx context.Context, namespace string) *cephclient.ClusterInfo {
+	cephCluster, isReadyToReconcile, _, _ := IsReadyToReconcile(ctx, c.Client, types.NamespacedName{Namespace: namespace}, usageCollectorName)
+	if !isReadyToReconcile {
+		return nil
+	}
+	clusterInfo, _, _, err := LoadClusterInfo(c.Context, ctx, namespace, &cephCluster.Spec)
+	if err != nil {
+		logger.Warningf("failed to load cluster info of namespace %q to collect the usage of %s CRs. %v", namespace, c.Kind, err)
+		return nil
+	}
+	return clusterInfo
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUsageCollectionInterval(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, UsageCollectionInterval())
+
+	t.Setenv(usageIntervalSettingName, "1h")
+	assert.Equal(t, time.Hour, UsageCollectionInterval())
+
+	t.Setenv(usageIntervalSettingName, "0")
+	assert.Equal(t, time.Duration(0), UsageCollectionInterval())
+
+	t.Setenv(usageIntervalSettingName, "invalid")
+	assert.Equal(t, 5*time.Minute, UsageCollectionInterval())
+
+	t.Setenv(usageIntervalSettingName, "-1m")
+	assert.Equal(t, 5*time.Minute, UsageCollectionInterval())
+}
+
+func TestReportUsage(t *testing.T) {
+	obj := &metav1.ObjectMeta{Namespace: "rook-ceph", Name: "tenant-a"}
+
+	ReportUsage("CephBlockPoolRadosNamespace", obj, &cephv1.UsageStatus{UsedBytes: 100, ProvisionedBytes: 1000, Images: 0})
+	labels := []string{"rook-ceph", "CephBlockPoolRadosNamespace", "tenant-a"}
+	assert.Equal(t, float64(100), testutil.ToFloat64(tenantUsedBytes.WithLabelValues(labels...)))
+	assert.Equal(t, float64(1000), testutil.ToFloat64(tenantProvisionedBytes.WithLabelValues(labels...)))
+	assert.Equal(t, 1, testutil.CollectAndCount(tenantImages))
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantQuotaBytes))
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantObjects))
+
+	ReportUsage("CephObjectStoreUser", obj, &cephv1.UsageStatus{UsedBytes: 10, Objects: 2, QuotaBytes: 50})
+	labels = []string{"rook-ceph", "CephObjectStoreUser", "tenant-a"}
+	assert.Equal(t, float64(50), testutil.ToFloat64(tenantQuotaBytes.WithLabelValues(labels...)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(tenantObjects.WithLabelValues(labels...)))
+	assert.Equal(t, 1, testutil.CollectAndCount(tenantSentBytes))
+	assert.Equal(t, 2, testutil.CollectAndCount(tenantUsedBytes))
+
+	// removing the quota removes its metric
+	ReportUsage("CephObjectStoreUser", obj, &cephv1.UsageStatus{UsedBytes: 10, Objects: 2})
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantQuotaBytes))
+
+	DeleteUsageMetrics("CephObjectStoreUser", obj)
+	assert.Equal(t, 1, testutil.CollectAndCount(tenantUsedBytes))
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantObjects))
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantSentBytes))
+	DeleteUsageMetrics("CephBlockPoolRadosNamespace", obj)
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantUsedBytes))
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantProvisionedBytes))
+}
+
+func TestUsageCollector(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+	clientset := test.New(t, 1)
+	_, err := clientset.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon", Namespace: namespace},
+		Data: map[string][]byte{
+			"fsid":         []byte("fsid"),
+			"mon-secret":   []byte("monsecret"),
+			"admin-secret": []byte("adminsecret"),
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace, Namespace: namespace},
+		Status:     cephv1.ClusterStatus{CephStatus: &cephv1.CephStatus{Health: "HEALTH_OK"}},
+	}
+	newRadosNamespace := func(namespace, name string) *cephv1.CephBlockPoolRadosNamespace {
+		return &cephv1.CephBlockPoolRadosNamespace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     &cephv1.CephBlockPoolRadosNamespaceStatus{Phase: cephv1.ConditionReady},
+		}
+	}
+	deleted := newRadosNamespace(namespace, "deleted")
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deleted.Finalizers = []string{"cephblockpoolradosnamespace.ceph.rook.io"}
+	objects := []client.Object{
+		cephCluster,
+		newRadosNamespace(namespace, "tenant-a"),
+		newRadosNamespace(namespace, "failing"),
+		deleted,
+		newRadosNamespace("no-cluster", "tenant-b"),
+	}
+	cl := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).WithStatusSubresource(objects...).Build()
+
+	var collected []string
+	collector := &UsageCollector[*cephv1.CephBlockPoolRadosNamespace]{
+		Client:  cl,
+		Context: &clusterd.Context{Clientset: clientset},
+		Kind:    "CephBlockPoolRadosNamespace",
+		List:    &cephv1.CephBlockPoolRadosNamespaceList{},
+		Collect: func(ctx context.Context, context *clusterd.Context, k8sClient client.Client, clusterInfo *cephclient.ClusterInfo, radosNamespace *cephv1.CephBlockPoolRadosNamespace) (*cephv1.UsageStatus, error) {
+			assert.Equal(t, namespace, clusterInfo.Namespace)
+			collected = append(collected, radosNamespace.Name)
+			if radosNamespace.Name == "failing" {
+				return nil, errors.New("failed to run rbd du")
+			}
+			return &cephv1.UsageStatus{UsedBytes: 100, Images: 1}, nil
+		},
+	}
+	collector.collectAll(ctx)
+	assert.ElementsMatch(t, []string{"tenant-a", "failing"}, collected)
+
+	radosNamespace := &cephv1.CephBlockPoolRadosNamespace{}
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "tenant-a"}, radosNamespace))
+	assert.Equal(t, cephv1.ConditionReady, radosNamespace.Status.Phase)
+	require.NotNil(t, radosNamespace.Status.Usage)
+	assert.Equal(t, int64(100), radosNamespace.Status.Usage.UsedBytes)
+	assert.Equal(t, int64(1), radosNamespace.Status.Usage.Images)
+	assert.NotNil(t, radosNamespace.Status.Usage.LastUpdateTime)
+	assert.Equal(t, float64(100), testutil.ToFloat64(tenantUsedBytes.WithLabelValues(namespace, "CephBlockPoolRadosNamespace", "tenant-a")))
+
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "failing"}, radosNamespace))
+	assert.Nil(t, radosNamespace.Status.Usage)
+	DeleteUsageMetrics("CephBlockPoolRadosNamespace", &metav1.ObjectMeta{Namespace: namespace, Name: "tenant-a"})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Setenv(usageIntervalSettingName, "0")
+		collected = nil
+		assert.NoError(t, collector.Start(ctx))
+		assert.Empty(t, collected)
+	})
+}
@@ -18,6 +18,7 @@ package operator
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/rook/rook/pkg/clusterd"
@@ -29,11 +30,14 @@ import (
 	"github.com/rook/rook/pkg/operator/ceph/csi"
 	"github.com/rook/rook/pkg/operator/ceph/disruption/clusterdisruption"
 	"github.com/rook/rook/pkg/operator/ceph/disruption/controllerconfig"
+	"github.com/rook/rook/pkg/operator/ceph/disruption/nodemaintenance"
 	"github.com/rook/rook/pkg/operator/ceph/file"
 	"github.com/rook/rook/pkg/operator/ceph/file/mirror"
+	"github.com/rook/rook/pkg/operator/ceph/file/subvolume"
 	"github.com/rook/rook/pkg/operator/ceph/file/subvolumegroup"
 	"github.com/rook/rook/pkg/operator/ceph/nfs"
 	"github.com/rook/rook/pkg/operator/ceph/nvmeof"
+	nvmeofsubsystem "github.com/rook/rook/pkg/operator/ceph/nvmeof/subsystem"
 	"github.com/rook/rook/pkg/operator/ceph/object"
 	objectaccount "github.com/rook/rook/pkg/operator/ceph/object/account"
 	"github.com/rook/rook/pkg/operator/ceph/object/bucket"
@@ -47,6 +51,7 @@ import (
 	"github.com/rook/rook/pkg/operator/ceph/pool"
 	"github.com/rook/rook/pkg/operator/ceph/pool/radosnamespace"
 	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/tracing"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
@@ -58,6 +63,9 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
+// tracingFlushTimeout is how long to wait for pending spans to be exported when the operator stops
+const tracingFlushTimeout = 5 * time.Second
+
 var resourcesSchemeFuncs = []func(*runtime.Scheme) error{
 	clientgoscheme.AddToScheme,
 	cephv1.AddToScheme,
@@ -69,6 +77,7 @@ var EnableMachineDisruptionBudget bool
 // AddToManagerFuncsMaintenance is a list of functions to add all Controllers to the Manager (entrypoint for controller)
 var AddToManagerFuncsMaintenance = []func(manager.Manager, *controllerconfig.Context) error{
 	clusterdisruption.Add,
+	nodemaintenance.Add,
 }
 
 // AddToManagerFuncs is a list of functions to add all Controllers to the Manager (entrypoint for controller)
@@ -85,6 +94,7 @@ var AddToManagerFuncs = []func(manager.Manager, *clusterd.Context, context.Conte
 	rbd.Add,
 	client.Add,
 	nvmeof.Add,
+	nvmeofsubsystem.Add,
 	mirror.Add,
 	Add,
 	csi.Add,
@@ -92,6 +102,7 @@ var AddToManagerFuncs = []func(manager.Manager, *clusterd.Context, context.Conte
 	topic.Add,
 	notification.Add,
 	subvolumegroup.Add,
+	subvolume.Add,
 	radosnamespace.Add,
 	cosi.Add,
 	objectaccount.Add,
@@ -143,6 +154,8 @@ func (o *Operator) startCRDManager(context context.Context, mgrErrorCh chan erro
 		}
 	}
 
+	defer setupTracing(context)()
+
 	metricsBindAddress := k8sutil.GetOperatorSetting("ROOK_OPERATOR_METRICS_BIND_ADDRESS", "0")
 	skipNameValidation := true
 	// Set up a manager
@@ -192,3 +205,27 @@ func (o *Operator) startCRDManager(context context.Context, mgrErrorCh chan erro
 
 	logger.Info("successfully started the controller-runtime manager")
 }
+
+// setupTracing starts exporting spans if tracing is enabled in the operator settings. The returned
+// function flushes the pending spans when the manager stops.
+func setupTracing(ctx context.Context) func() {
+	tracingConfig, enabled := opcontroller.TracingConfig()
+	if !enabled {
+		return func() {}
+	}
+
+	shutdown, err := tracing.Setup(ctx, tracingConfig)
+	if err != nil {
+		logger.Errorf("failed to set up tracing, continuing without it. %v", err)
+		return func() {}
+	}
+
+	return func() {
+		// the operator context is cancelled by the time the manager stops, so flush with a separate deadline
+		flushCtx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+		if err := shutdown(flushCtx); err != nil {
+			logger.Errorf("failed to flush traces. %v", err)
+		}
+	}
+}
@@ -18,7 +18,6 @@ package csi
 
 import (
 	"os"
-	"strings"
 
 	"github.com/pkg/errors"
 
@@ -78,7 +77,7 @@ func generateCephConnSpec(c client.Client, clusterInfo *cephclient.ClusterInfo,
 		crushLabels := clusterSpec.CSI.ReadAffinity.CrushLocationLabels
 		if len(crushLabels) == 0 {
 			logger.Debug("using default crush topology labels")
-			crushLabels = strings.Split(topology.GetDefaultTopologyLabels(), ",")
+			crushLabels = topology.Labels(clusterSpec.Storage.CrushTopology)
 		}
 		csiClusterConnSpec.ReadAffinity = &csiopv1.ReadAffinitySpec{
 			CrushLocationLabels: crushLabels,
@@ -92,6 +92,7 @@ func createCSIKeyring(
 		interpretedCephxStatus.CephxStatus,
 		false, // do not ignore key type for non-daemon csi keys
 		clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephCluster", cephCluster.Name, keyring.CephxComponentCSI),
 	)
 	if err != nil {
 		return "", "", 0, shouldRotate, errors.Wrap(err, "failed to call `shouldRotateCephxKeys` during CSI key rotation")
@@ -389,7 +389,16 @@ func (r *ReconcileClusterDisruption) updateNoout(clusterInfo *cephclient.Cluster
 	if err != nil {
 		return errors.Wrapf(err, "failed to get osddump for reconciling maintenance noout in namespace %s", clusterInfo.Namespace)
 	}
+	hostsInMaintenance, err := r.getHostsInMaintenance(clusterInfo)
+	if err != nil {
+		return err
+	}
 	for _, failureDomainName := range allFailureDomains {
+		if hostsInMaintenance.Has(failureDomainName) {
+			// the noout flag is managed by the CephNodeMaintenance of the host
+			logger.Debugf("skipping noout update of failure domain %q in node maintenance", failureDomainName)
+			continue
+		}
 		drainingFailureDomainTimeStampKey := fmt.Sprintf("%s-noout-last-set-at", failureDomainName)
 		if pdbStateMap.Data[drainingFailureDomainKey] == failureDomainName {
 			if pdbStateMap.Data[setNoOut] == "true" {
@@ -434,6 +443,21 @@ func (r *ReconcileClusterDisruption) updateNoout(clusterInfo *cephclient.Cluster
 	return nil
 }
 
+// getHostsInMaintenance returns the CRUSH hosts of the active CephNodeMaintenances of the cluster
+func (r *ReconcileClusterDisruption) getHostsInMaintenance(clusterInfo *cephclient.ClusterInfo) (sets.Set[string], error) {
+	maintenances := &cephv1.CephNodeMaintenanceList{}
+	if err := r.client.List(clusterInfo.Context, maintenances, client.InNamespace(clusterInfo.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list node maintenances in namespace %q", clusterInfo.Namespace)
+	}
+	hosts := sets.New[string]()
+	for _, m := range maintenances.Items {
+		if m.Status != nil && m.Status.CrushHost != "" && m.Status.Phase != cephv1.NodeMaintenancePhaseExpired {
+			hosts.Insert(m.Status.CrushHost)
+		}
+	}
+	return hosts, nil
+}
+
 func (r *ReconcileClusterDisruption) getOSDFailureDomains(clusterInfo *cephclient.ClusterInfo, request reconcile.Request, poolFailureDomain string) ([]string, []string, []string, []int, error) {
 	osdDeploymentList := &appsv1.DeploymentList{}
 	namespaceListOpts := client.InNamespace(request.Namespace)
@@ -578,6 +578,27 @@ func TestHasNodeDrained(t *testing.T) {
 	assert.True(t, expected)
 }
 
+func TestGetHostsInMaintenance(t *testing.T) {
+	maintenance := func(name, host string, phase cephv1.NodeMaintenancePhase) *cephv1.CephNodeMaintenance {
+		return &cephv1.CephNodeMaintenance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     &cephv1.NodeMaintenanceStatus{CrushHost: host, Phase: phase},
+		}
+	}
+	r := getFakeReconciler(t,
+		maintenance("node-a", "host-a", cephv1.NodeMaintenancePhaseReady),
+		maintenance("node-b", "host-b", cephv1.NodeMaintenancePhaseBlocked),
+		maintenance("node-c", "host-c", cephv1.NodeMaintenancePhaseExpired),
+		maintenance("node-d", "", cephv1.NodeMaintenancePhaseReady),
+	)
+	clusterInfo := getFakeClusterInfo()
+	clusterInfo.Context = context.TODO()
+
+	hosts, err := r.getHostsInMaintenance(clusterInfo)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"host-a", "host-b"}, hosts.UnsortedList())
+}
+
 func TestSetPDBConfig(t *testing.T) {
 	testcases := []struct {
 		name                          string
@@ -32,7 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-func (r *ReconcileClusterDisruption) processPools(request reconcile.Request) (*cephv1.CephObjectStoreList, *cephv1.CephFilesystemList, string, int, error) {
+func (r *ReconcileClusterDisruption) processPools(request reconcile.Request, crushTopology *cephv1.CrushTopologySpec) (*cephv1.CephObjectStoreList, *cephv1.CephFilesystemList, string, int, error) {
 	namespaceListOpt := client.InNamespace(request.Namespace)
 	poolSpecs := make([]cephv1.PoolSpec, 0)
 	poolCount := 0
@@ -71,22 +71,23 @@ func (r *ReconcileClusterDisruption) processPools(request reconcile.Request) (*c
 		poolSpecs = append(poolSpecs, cephObjectStore.Spec.DataPool)
 
 	}
-	minFailureDomain := getMinimumFailureDomain(poolSpecs)
+	minFailureDomain := getMinimumFailureDomain(poolSpecs, crushTopology)
 
 	return cephObjectStoreList, cephFilesystemList, minFailureDomain, poolCount, nil
 }
 
-func getMinimumFailureDomain(poolList []cephv1.PoolSpec) string {
+func getMinimumFailureDomain(poolList []cephv1.PoolSpec, crushTopology *cephv1.CrushTopologySpec) string {
 	if len(poolList) == 0 {
 		return cephv1.DefaultFailureDomain
 	}
+	crushMapLevels := topology.CRUSHMapLevels(crushTopology)
 
 	// start with max as the min
-	minfailureDomainIndex := len(topology.CRUSHMapLevelsOrdered) - 1
+	minfailureDomainIndex := len(crushMapLevels) - 1
 	matched := false
 
 	for _, pool := range poolList {
-		for index, failureDomain := range topology.CRUSHMapLevelsOrdered {
+		for index, failureDomain := range crushMapLevels {
 			if index == minfailureDomainIndex {
 				// index is higher-than/equal-to the min
 				break
@@ -102,7 +103,7 @@ func getMinimumFailureDomain(poolList []cephv1.PoolSpec) string {
 		logger.Debugf("could not match failure domain. defaulting to %q", cephv1.DefaultFailureDomain)
 		return cephv1.DefaultFailureDomain
 	}
-	return topology.CRUSHMapLevelsOrdered[minfailureDomainIndex]
+	return crushMapLevels[minfailureDomainIndex]
 }
 
 // Setting naive minAvailable for RGW at: n - 1
@@ -168,7 +169,7 @@ func (r *ReconcileClusterDisruption) reconcileCephFilesystem(cephFilesystemList
 			MatchLabels: map[string]string{"rook_file_system": fsName},
 		}
 
-		activeCount := filesystem.Spec.MetadataServer.ActiveCount
+		activeCount := filesystem.ActiveMDSCount()
 		minAvailable := &intstr.IntOrString{IntVal: activeCount - 1}
 		if filesystem.Spec.MetadataServer.ActiveStandby {
 			minAvailable.IntVal++
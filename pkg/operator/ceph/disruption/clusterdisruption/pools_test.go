@@ -38,7 +38,7 @@ func TestGetMinimumFailureDomain(t *testing.T) {
 		{FailureDomain: "zone"},
 	}
 
-	assert.Equal(t, "zone", getMinimumFailureDomain(poolList))
+	assert.Equal(t, "zone", getMinimumFailureDomain(poolList, nil))
 
 	poolList = []cephv1.PoolSpec{
 		{FailureDomain: "region"},
@@ -46,7 +46,7 @@ func TestGetMinimumFailureDomain(t *testing.T) {
 		{FailureDomain: "host"},
 	}
 
-	assert.Equal(t, "host", getMinimumFailureDomain(poolList))
+	assert.Equal(t, "host", getMinimumFailureDomain(poolList, nil))
 
 	// test default
 	poolList = []cephv1.PoolSpec{
@@ -55,7 +55,21 @@ func TestGetMinimumFailureDomain(t *testing.T) {
 		{FailureDomain: "ccc"},
 	}
 
-	assert.Equal(t, "host", getMinimumFailureDomain(poolList))
+	assert.Equal(t, "host", getMinimumFailureDomain(poolList, nil))
+
+	// custom crush hierarchy
+	crushTopology := &cephv1.CrushTopologySpec{
+		Levels: []cephv1.CrushTopologyLevelSpec{
+			{Type: "power-domain", Label: "example.com/power-domain"},
+			{Type: "rack", Label: "topology.rook.io/rack"},
+		},
+	}
+	poolList = []cephv1.PoolSpec{
+		{FailureDomain: "rack"},
+		{FailureDomain: "power-domain"},
+	}
+	assert.Equal(t, "power-domain", getMinimumFailureDomain(poolList, crushTopology))
+	assert.Equal(t, "rack", getMinimumFailureDomain(poolList, nil))
 }
 
 func TestReconcileCephObjectStorePDB(t *testing.T) {
@@ -137,7 +137,7 @@ func (r *ReconcileClusterDisruption) reconcile(request reconcile.Request) (recon
 	}
 
 	//  reconcile the pools and get the failure domain
-	cephObjectStoreList, cephFilesystemList, poolFailureDomain, poolCount, err := r.processPools(request)
+	cephObjectStoreList, cephFilesystemList, poolFailureDomain, poolCount, err := r.processPools(request, cephCluster.Spec.Storage.CrushTopology)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
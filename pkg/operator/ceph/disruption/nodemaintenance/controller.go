@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemaintenance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/disruption/controllerconfig"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "ceph-node-maintenance-controller"
+
+	// DefaultMaintenanceTimeout is the duration after which the daemons of a node are restored if
+	// the CephNodeMaintenance does not specify a timeout
+	DefaultMaintenanceTimeout = 4 * time.Hour
+)
+
+var (
+	logger = capnslog.NewPackageLogger("github.com/rook/rook", "node-maintenance")
+
+	// Sets the type meta for the controller main object
+	controllerTypeMeta = metav1.TypeMeta{
+		Kind:       reflect.TypeFor[cephv1.CephNodeMaintenance]().Name(),
+		APIVersion: fmt.Sprintf("%s/%s", cephv1.CustomResourceGroup, cephv1.Version),
+	}
+
+	// the interval to check again the daemons while they are being stopped or the maintenance is blocked
+	waitForDaemonsInterval = 15 * time.Second
+	// the interval to re-assert the OSD flags while the maintenance is ready
+	maintenanceCheckInterval = time.Minute
+)
+
+// ReconcileNodeMaintenance reconciles a CephNodeMaintenance object
+type ReconcileNodeMaintenance struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	context  *controllerconfig.Context
+	recorder events.EventRecorder
+}
+
+// Add creates a new CephNodeMaintenance Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *controllerconfig.Context) error {
+	return add(mgr, newReconciler(mgr, context))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, context *controllerconfig.Context) reconcile.Reconciler {
+	return &ReconcileNodeMaintenance{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		context:  context,
+		recorder: mgr.GetEventRecorder("rook-" + controllerName),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the CephNodeMaintenance CRD object
+	return c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephNodeMaintenance{TypeMeta: controllerTypeMeta},
+			&handler.TypedEnqueueRequestForObject[*cephv1.CephNodeMaintenance]{},
+			opcontroller.WatchControllerPredicate[*cephv1.CephNodeMaintenance](mgr.GetScheme()),
+		),
+	)
+}
+
+// Reconcile reads the state of the cluster for a CephNodeMaintenance object and makes changes based on the state read
+// and what is in the CephNodeMaintenance.Spec
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileNodeMaintenance) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	defer opcontroller.RecoverAndLogException()
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, maintenance, err := r.reconcile(request)
+	return reporting.ReportReconcileResult(logger, r.recorder, request, &maintenance, reconcileResponse, err)
+}
+
+func (r *ReconcileNodeMaintenance) reconcile(request reconcile.Request) (reconcile.Result, cephv1.CephNodeMaintenance, error) {
+	ctx := r.context.OpManagerContext
+	// Fetch the CephNodeMaintenance instance
+	maintenance := &cephv1.CephNodeMaintenance{}
+	err := r.client.Get(ctx, request.NamespacedName, maintenance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamedDebug(request.NamespacedName, logger, "CephNodeMaintenance resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, *maintenance, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, *maintenance, errors.Wrap(err, "failed to get CephNodeMaintenance")
+	}
+
+	// Set a finalizer so we can restore the daemons before the object goes away
+	generationUpdated, err := opcontroller.AddFinalizerIfNotPresent(ctx, r.client, maintenance)
+	if err != nil {
+		return reconcile.Result{}, *maintenance, errors.Wrap(err, "failed to add finalizer")
+	}
+	if generationUpdated {
+		log.NamedInfo(request.NamespacedName, logger, "reconciling the node maintenance after adding finalizer")
+		return reconcile.Result{}, *maintenance, nil
+	}
+
+	// Make sure a CephCluster is present otherwise do nothing
+	cephCluster, isReadyToReconcile, cephClusterExists, reconcileResponse := opcontroller.IsReadyToReconcile(ctx, r.client, request.NamespacedName, controllerName)
+	if !isReadyToReconcile {
+		// Only remove the finalizer if the CephCluster is gone, there is nothing left to restore
+		if !maintenance.GetDeletionTimestamp().IsZero() && !cephClusterExists {
+			err = opcontroller.RemoveFinalizer(ctx, r.client, maintenance)
+			if err != nil {
+				return opcontroller.ImmediateRetryResult, *maintenance, errors.Wrap(err, "failed to remove finalizer")
+			}
+			return reconcile.Result{}, *maintenance, nil
+		}
+		return reconcileResponse, *maintenance, nil
+	}
+
+	// Populate clusterInfo during each reconcile
+	clusterInfo, _, _, err := opcontroller.LoadClusterInfo(r.context.ClusterdContext, ctx, request.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return reconcile.Result{}, *maintenance, errors.Wrap(err, "failed to populate cluster info")
+	}
+	clusterInfo.Context = ctx
+
+	status := &cephv1.NodeMaintenanceStatus{}
+	if maintenance.Status != nil {
+		status = maintenance.Status.DeepCopy()
+	}
+
+	// DELETE: the maintenance ended, restart the daemons of the node
+	if !maintenance.GetDeletionTimestamp().IsZero() {
+		log.NamedInfo(request.NamespacedName, logger, "ending maintenance of node %q", maintenance.Spec.NodeName)
+		if err := r.endMaintenance(clusterInfo, maintenance, status); err != nil {
+			return reconcile.Result{}, *maintenance, errors.Wrapf(err, "failed to end maintenance of node %q", maintenance.Spec.NodeName)
+		}
+		r.recorder.Eventf(maintenance, nil, v1.EventTypeNormal, string(cephv1.ReconcileSucceeded), string(cephv1.ReconcileSucceeded), "restored the daemons of node %q", maintenance.Spec.NodeName)
+
+		err = opcontroller.RemoveFinalizer(ctx, r.client, maintenance)
+		if err != nil {
+			return reconcile.Result{}, *maintenance, errors.Wrap(err, "failed to remove finalizer")
+		}
+		// Return and do not requeue. Successful deletion.
+		return reconcile.Result{}, *maintenance, nil
+	}
+
+	// The daemons were already restored after the timeout, the CR is only kept as a record
+	if status.Phase == cephv1.NodeMaintenancePhaseExpired {
+		return reconcile.Result{}, *maintenance, nil
+	}
+
+	if status.StartTime == nil {
+		status.StartTime = &metav1.Time{Time: time.Now()}
+	}
+	timeout := DefaultMaintenanceTimeout
+	if maintenance.Spec.Timeout != nil {
+		timeout = maintenance.Spec.Timeout.Duration
+	}
+	remaining := time.Until(status.StartTime.Add(timeout))
+	if remaining <= 0 {
+		log.NamedWarning(request.NamespacedName, logger, "maintenance of node %q timed out after %s, restoring the daemons", maintenance.Spec.NodeName, timeout.String())
+		if err := r.endMaintenance(clusterInfo, maintenance, status); err != nil {
+			return reconcile.Result{}, *maintenance, errors.Wrapf(err, "failed to end expired maintenance of node %q", maintenance.Spec.NodeName)
+		}
+		status.Phase = cephv1.NodeMaintenancePhaseExpired
+		status.Message = fmt.Sprintf("maintenance timed out after %s, the daemons of the node were restored", timeout.String())
+		r.recorder.Eventf(maintenance, nil, v1.EventTypeWarning, string(status.Phase), string(status.Phase), status.Message)
+		if err := r.updateStatus(request.NamespacedName, maintenance.Generation, status); err != nil {
+			return reconcile.Result{}, *maintenance, err
+		}
+		return reconcile.Result{}, *maintenance, nil
+	}
+
+	err = r.startMaintenance(clusterInfo, maintenance, status)
+	if statusErr := r.updateStatus(request.NamespacedName, maintenance.Generation, status); statusErr != nil {
+		return reconcile.Result{}, *maintenance, statusErr
+	}
+	if err != nil {
+		return reconcile.Result{}, *maintenance, errors.Wrapf(err, "failed to start maintenance of node %q", maintenance.Spec.NodeName)
+	}
+
+	if status.Phase != cephv1.NodeMaintenancePhaseReady {
+		log.NamedInfo(request.NamespacedName, logger, "maintenance of node %q is %s: %s", maintenance.Spec.NodeName, status.Phase, status.Message)
+		return reconcile.Result{Requeue: true, RequeueAfter: min(waitForDaemonsInterval, remaining)}, *maintenance, nil
+	}
+	// Check the maintenance again until it times out
+	return reconcile.Result{Requeue: true, RequeueAfter: min(maintenanceCheckInterval, remaining)}, *maintenance, nil
+}
+
+// updateStatus updates the status of the maintenance
+func (r *ReconcileNodeMaintenance) updateStatus(nsName types.NamespacedName, observedGeneration int64, status *cephv1.NodeMaintenanceStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		maintenance := &cephv1.CephNodeMaintenance{}
+		if err := r.client.Get(r.context.OpManagerContext, nsName, maintenance); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamedDebug(nsName, logger, "CephNodeMaintenance resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return err
+		}
+		maintenance.Status = status.DeepCopy()
+		maintenance.Status.ObservedGeneration = observedGeneration
+		return reporting.UpdateStatus(r.client, maintenance)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update status of node maintenance %q", nsName.String())
+	}
+	log.NamedDebug(nsName, logger, "node maintenance status updated to %q", status.Phase)
+	return nil
+}
+
+// hasOtherActiveMaintenance returns whether another maintenance of the cluster may rely on the
+// cluster-wide norebalance flag
+func (r *ReconcileNodeMaintenance) hasOtherActiveMaintenance(clusterInfo *cephclient.ClusterInfo, maintenance *cephv1.CephNodeMaintenance) (bool, error) {
+	maintenances := &cephv1.CephNodeMaintenanceList{}
+	if err := r.client.List(clusterInfo.Context, maintenances, client.InNamespace(maintenance.Namespace)); err != nil {
+		return false, errors.Wrap(err, "failed to list node maintenances")
+	}
+	for _, other := range maintenances.Items {
+		if other.Name == maintenance.Name || !other.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if other.Status != nil && other.Status.Phase != cephv1.NodeMaintenancePhaseExpired && other.Status.CrushHost != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,368 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemaintenance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/ceph/disruption/controllerconfig"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const namespace = "rook-ceph"
+
+// fakeCluster mocks the ceph commands run during a node maintenance
+type fakeCluster struct {
+	osdsUp       map[int]bool
+	notOkToStop  sets.Set[string]
+	quorum       string
+	crushFlags   map[string][]string
+	clusterFlags sets.Set[string]
+}
+
+func newFakeCluster() *fakeCluster {
+	return &fakeCluster{
+		osdsUp:       map[int]bool{0: true, 1: true, 2: true},
+		notOkToStop:  sets.New[string](),
+		quorum:       `[0,1,2]`,
+		crushFlags:   map[string][]string{},
+		clusterFlags: sets.New("sortbitwise"),
+	}
+}
+
+func (f *fakeCluster) executor() *exectest.MockExecutor {
+	return &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			return f.run(args...)
+		},
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			return f.run(args...)
+		},
+	}
+}
+
+func (f *fakeCluster) run(args ...string) (string, error) {
+	switch {
+	case args[0] == "osd" && args[1] == "dump":
+		var osds []string
+		for id, up := range f.osdsUp {
+			upValue := 0
+			if up {
+				upValue = 1
+			}
+			osds = append(osds, fmt.Sprintf(`{"osd":%d,"up":%d,"in":1}`, id, upValue))
+		}
+		crushFlags := []string{}
+		for unit, flags := range f.crushFlags {
+			crushFlags = append(crushFlags, fmt.Sprintf(`"%s":["%s"]`, unit, strings.Join(flags, `","`)))
+		}
+		return fmt.Sprintf(`{"osds":[%s],"flags":"%s","crush_node_flags":{%s}}`,
+			strings.Join(osds, ","), strings.Join(sets.List(f.clusterFlags), ","), strings.Join(crushFlags, ",")), nil
+	case args[0] == "osd" && args[1] == "ok-to-stop":
+		if f.notOkToStop.Has(args[2]) {
+			return "", errors.New("unsafe to stop osd(s) at this time")
+		}
+		return fmt.Sprintf(`{"ok_to_stop":true,"osds":[%s]}`, args[2]), nil
+	case args[0] == "osd" && args[1] == "set-group":
+		f.crushFlags[args[3]] = []string{args[2]}
+	case args[0] == "osd" && args[1] == "unset-group":
+		delete(f.crushFlags, args[3])
+	case args[0] == "osd" && args[1] == "set":
+		f.clusterFlags.Insert(args[2])
+	case args[0] == "osd" && args[1] == "unset":
+		f.clusterFlags.Delete(args[2])
+	case args[0] == "quorum_status":
+		return fmt.Sprintf(`{"quorum":%s,"monmap":{"mons":[{"name":"a","rank":0},{"name":"b","rank":1},{"name":"c","rank":2}]}}`, f.quorum), nil
+	}
+	return "", nil
+}
+
+func createDaemon(t *testing.T, clientset kubernetes.Interface, app, idLabel, id, nodeName string) {
+	labels := map[string]string{k8sutil.AppAttr: app, idLabel: id}
+	if app == osd.AppName {
+		labels[crushHostLabel] = strings.ReplaceAll(nodeName, "node", "host")
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s-pod", app, id), Namespace: namespace, Labels: labels},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+	_, err := clientset.CoreV1().Pods(namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", app, id), Namespace: namespace, Labels: labels},
+		Spec:       appsv1.DeploymentSpec{Replicas: new(int32(1))},
+	}
+	_, err = clientset.AppsV1().Deployments(namespace).Create(context.TODO(), d, metav1.CreateOptions{})
+	assert.NoError(t, err)
+}
+
+func getDeployment(t *testing.T, clientset kubernetes.Interface, name string) *appsv1.Deployment {
+	d, err := clientset.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	return d
+}
+
+func assertStopped(t *testing.T, clientset kubernetes.Interface, name string, stopped bool) {
+	d := getDeployment(t, clientset, name)
+	_, fenced := d.Labels[cephv1.SkipReconcileLabelKey]
+	assert.Equal(t, stopped, fenced, name)
+	if stopped {
+		assert.Equal(t, int32(0), *d.Spec.Replicas, name)
+		assert.Equal(t, "node-a", d.Annotations[cephv1.NodeMaintenanceAnnotationKey], name)
+	} else {
+		assert.Equal(t, int32(1), *d.Spec.Replicas, name)
+		assert.NotContains(t, d.Annotations, cephv1.NodeMaintenanceAnnotationKey, name)
+	}
+}
+
+func newTestReconciler(t *testing.T, executor *exectest.MockExecutor, objects ...runtime.Object) (*ReconcileNodeMaintenance, kubernetes.Interface) {
+	ctx := context.TODO()
+	clientset := test.New(t, 3)
+	createDaemon(t, clientset, osd.AppName, osd.OsdIdLabelKey, "0", "node-a")
+	createDaemon(t, clientset, osd.AppName, osd.OsdIdLabelKey, "1", "node-a")
+	createDaemon(t, clientset, osd.AppName, osd.OsdIdLabelKey, "2", "node-b")
+	createDaemon(t, clientset, mon.AppName, "mon", "a", "node-a")
+	createDaemon(t, clientset, mon.AppName, "mon", "b", "node-b")
+	createDaemon(t, clientset, mon.AppName, "mon", "c", "node-c")
+
+	s := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objects...).WithStatusSubresource(&cephv1.CephNodeMaintenance{}).Build()
+
+	return &ReconcileNodeMaintenance{
+		client: cl,
+		scheme: s,
+		context: &controllerconfig.Context{
+			ClusterdContext:  &clusterd.Context{Executor: executor, Clientset: clientset, Client: cl},
+			OpManagerContext: ctx,
+		},
+		recorder: events.NewFakeRecorder(50),
+	}, clientset
+}
+
+func TestNodeMaintenance(t *testing.T) {
+	cluster := newFakeCluster()
+	maintenance := &cephv1.CephNodeMaintenance{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a", Namespace: namespace},
+		Spec:       cephv1.NodeMaintenanceSpec{NodeName: "node-a"},
+	}
+	r, clientset := newTestReconciler(t, cluster.executor(), maintenance)
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	status := &cephv1.NodeMaintenanceStatus{}
+
+	t.Run("stop the first osd", func(t *testing.T) {
+		assert.NoError(t, r.startMaintenance(clusterInfo, maintenance, status))
+		assert.Equal(t, cephv1.NodeMaintenancePhaseInProgress, status.Phase)
+		assert.Equal(t, "waiting for osd.0 to be down", status.Message)
+		assert.Equal(t, "host-a", status.CrushHost)
+		assert.Equal(t, []int{0}, status.StoppedOSDs)
+		assert.Equal(t, []string{nooutFlag}, cluster.crushFlags["host-a"])
+		assert.True(t, cluster.clusterFlags.Has(norebalanceFlag))
+		assertStopped(t, clientset, "rook-ceph-osd-0", true)
+		assertStopped(t, clientset, "rook-ceph-osd-1", false)
+	})
+
+	t.Run("next osd is not ok to stop", func(t *testing.T) {
+		cluster.osdsUp[0] = false
+		cluster.notOkToStop.Insert("1")
+		assert.NoError(t, r.startMaintenance(clusterInfo, maintenance, status))
+		assert.Equal(t, cephv1.NodeMaintenancePhaseBlocked, status.Phase)
+		assert.Contains(t, status.Message, "osd.1 is not ok to stop")
+		assertStopped(t, clientset, "rook-ceph-osd-1", false)
+	})
+
+	t.Run("stop the second osd", func(t *testing.T) {
+		cluster.notOkToStop.Delete("1")
+		assert.NoError(t, r.startMaintenance(clusterInfo, maintenance, status))
+		assert.Equal(t, cephv1.NodeMaintenancePhaseInProgress, status.Phase)
+		assert.Equal(t, []int{0, 1}, status.StoppedOSDs)
+		assertStopped(t, clientset, "rook-ceph-osd-1", true)
+		assertStopped(t, clientset, "rook-ceph-mon-a", false)
+	})
+
+	t.Run("mon quorum would be lost", func(t *testing.T) {
+		cluster.osdsUp[1] = false
+		cluster.quorum = `[0,1]`
+		assert.NoError(t, r.startMaintenance(clusterInfo, maintenance, status))
+		assert.Equal(t, cephv1.NodeMaintenancePhaseBlocked, status.Phase)
+		assert.Equal(t, "stopping mons [a] would break the mon quorum, only 1 of 3 mons would remain in quorum", status.Message)
+		assertStopped(t, clientset, "rook-ceph-mon-a", false)
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		cluster.quorum = `[0,1,2]`
+		assert.NoError(t, r.startMaintenance(clusterInfo, maintenance, status))
+		assert.Equal(t, cephv1.NodeMaintenancePhaseReady, status.Phase)
+		assert.Equal(t, `2 OSDs and 1 mons of node "node-a" are stopped`, status.Message)
+		assert.Equal(t, []string{"a"}, status.StoppedMons)
+		assertStopped(t, clientset, "rook-ceph-mon-a", true)
+		assertStopped(t, clientset, "rook-ceph-osd-2", false)
+		assertStopped(t, clientset, "rook-ceph-mon-b", false)
+
+		// nothing changes while the maintenance is ready
+		assert.NoError(t, r.startMaintenance(clusterInfo, maintenance, status))
+		assert.Equal(t, cephv1.NodeMaintenancePhaseReady, status.Phase)
+		assert.Equal(t, []int{0, 1}, status.StoppedOSDs)
+	})
+
+	t.Run("end maintenance", func(t *testing.T) {
+		assert.NoError(t, r.endMaintenance(clusterInfo, maintenance, status))
+		assert.Empty(t, status.StoppedOSDs)
+		assert.Empty(t, status.StoppedMons)
+		assert.Empty(t, cluster.crushFlags)
+		assert.False(t, cluster.clusterFlags.Has(norebalanceFlag))
+		for _, name := range []string{"rook-ceph-osd-0", "rook-ceph-osd-1", "rook-ceph-mon-a"} {
+			assertStopped(t, clientset, name, false)
+		}
+	})
+}
+
+func TestNodeMaintenanceManualFence(t *testing.T) {
+	cluster := newFakeCluster()
+	maintenance := &cephv1.CephNodeMaintenance{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a", Namespace: namespace},
+		Spec:       cephv1.NodeMaintenanceSpec{NodeName: "node-a"},
+	}
+	other := &cephv1.CephNodeMaintenance{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-b", Namespace: namespace},
+		Spec:       cephv1.NodeMaintenanceSpec{NodeName: "node-b"},
+		Status:     &cephv1.NodeMaintenanceStatus{Phase: cephv1.NodeMaintenancePhaseReady, CrushHost: "host-b"},
+	}
+	r, clientset := newTestReconciler(t, cluster.executor(), maintenance, other)
+	clusterInfo := cephclient.AdminTestClusterInfo(namespace)
+	status := &cephv1.NodeMaintenanceStatus{}
+
+	// osd.0 is fenced by an admin, the maintenance must not touch it
+	d := getDeployment(t, clientset, "rook-ceph-osd-0")
+	k8sutil.AddLabelToDeployment(cephv1.SkipReconcileLabelKey, "true", d)
+	_, err := clientset.AppsV1().Deployments(namespace).Update(context.TODO(), d, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, r.startMaintenance(clusterInfo, maintenance, status))
+	assert.Equal(t, []int{1}, status.StoppedOSDs)
+	d = getDeployment(t, clientset, "rook-ceph-osd-0")
+	assert.Equal(t, int32(1), *d.Spec.Replicas)
+	assert.NotContains(t, d.Annotations, cephv1.NodeMaintenanceAnnotationKey)
+
+	assert.NoError(t, r.endMaintenance(clusterInfo, maintenance, status))
+	_, fenced := getDeployment(t, clientset, "rook-ceph-osd-0").Labels[cephv1.SkipReconcileLabelKey]
+	assert.True(t, fenced)
+	assertStopped(t, clientset, "rook-ceph-osd-1", false)
+	// the other maintenance still relies on the norebalance flag
+	assert.True(t, cluster.clusterFlags.Has(norebalanceFlag))
+}
+
+func TestNodeMaintenanceTimeout(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.osdsUp[0] = false
+	cluster.crushFlags["host-a"] = []string{nooutFlag}
+	cluster.clusterFlags.Insert(norebalanceFlag)
+	maintenance := &cephv1.CephNodeMaintenance{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a", Namespace: namespace, Finalizers: []string{"cephnodemaintenance.ceph.rook.io"}},
+		Spec:       cephv1.NodeMaintenanceSpec{NodeName: "node-a", Timeout: &metav1.Duration{Duration: time.Hour}},
+		Status: &cephv1.NodeMaintenanceStatus{
+			Phase:       cephv1.NodeMaintenancePhaseInProgress,
+			CrushHost:   "host-a",
+			StartTime:   &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+			StoppedOSDs: []int{0},
+		},
+	}
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace, Namespace: namespace},
+		Status:     cephv1.ClusterStatus{CephStatus: &cephv1.CephStatus{Health: "HEALTH_OK"}},
+	}
+	r, clientset := newTestReconciler(t, cluster.executor(), maintenance, cephCluster)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-mon", Namespace: namespace},
+		Data: map[string][]byte{
+			"fsid":         []byte(namespace),
+			"mon-secret":   []byte("monsecret"),
+			"admin-secret": []byte("adminsecret"),
+		},
+		Type: k8sutil.RookType,
+	}
+	_, err := clientset.CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	// osd.0 was stopped before the timeout
+	d := getDeployment(t, clientset, "rook-ceph-osd-0")
+	k8sutil.AddLabelToDeployment(cephv1.SkipReconcileLabelKey, "true", d)
+	k8sutil.AddAnnotationToDeployment(cephv1.NodeMaintenanceAnnotationKey, "node-a", d)
+	d.Spec.Replicas = new(int32(0))
+	_, err = clientset.AppsV1().Deployments(namespace).Update(context.TODO(), d, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+
+	nsName := types.NamespacedName{Namespace: namespace, Name: "node-a"}
+	res, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nsName})
+	assert.NoError(t, err)
+	assert.False(t, res.Requeue)
+
+	assert.NoError(t, r.client.Get(context.TODO(), nsName, maintenance))
+	assert.Equal(t, cephv1.NodeMaintenancePhaseExpired, maintenance.Status.Phase)
+	assert.Equal(t, "maintenance timed out after 1h0m0s, the daemons of the node were restored", maintenance.Status.Message)
+	assert.Empty(t, maintenance.Status.StoppedOSDs)
+	assertStopped(t, clientset, "rook-ceph-osd-0", false)
+	assert.Empty(t, cluster.crushFlags)
+	assert.False(t, cluster.clusterFlags.Has(norebalanceFlag))
+
+	// the expired maintenance is not started again
+	res, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nsName})
+	assert.NoError(t, err)
+	assert.False(t, res.Requeue)
+	assertStopped(t, clientset, "rook-ceph-osd-1", false)
+}
+
+func TestQuorumSurvives(t *testing.T) {
+	monStatus := cephclient.MonStatusResponse{Quorum: []int{0, 1, 2}}
+	monStatus.MonMap.Mons = []cephclient.MonMapEntry{{Name: "a", Rank: 0}, {Name: "b", Rank: 1}, {Name: "c", Rank: 2}}
+
+	ok, remaining := quorumSurvives(monStatus, sets.New("a"))
+	assert.True(t, ok)
+	assert.Equal(t, 2, remaining)
+
+	ok, remaining = quorumSurvives(monStatus, sets.New("a", "b"))
+	assert.False(t, ok)
+	assert.Equal(t, 1, remaining)
+
+	// a mon out of quorum does not count
+	monStatus.Quorum = []int{0, 2}
+	ok, _ = quorumSurvives(monStatus, sets.New("c"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package nodemaintenance implements the controller of the CephNodeMaintenance CR. A maintenance sets
+the noout flag on the CRUSH host of a node, stops the OSDs of the node one at a time as long as they
+are ok to stop, then stops the mons of the node if the mon quorum can be kept. The daemons are
+restarted and the flags are cleared when the CR is deleted or when the maintenance times out.
+*/
+package nodemaintenance
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemaintenance
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// nooutFlag is set on the CRUSH host of the node so that its stopped OSDs are not marked out
+	nooutFlag = "noout"
+	// norebalanceFlag is set cluster-wide since ceph only supports noup, nodown, noin and noout on a
+	// CRUSH unit
+	norebalanceFlag = "norebalance"
+)
+
+var crushHostLabel = fmt.Sprintf(osd.TopologyLocationLabel, "host")
+
+// startMaintenance sets the OSD flags and stops the next daemon of the node that can be stopped.
+// The status is updated with the phase of the maintenance and the daemons stopped so far.
+func (r *ReconcileNodeMaintenance) startMaintenance(clusterInfo *cephclient.ClusterInfo, maintenance *cephv1.CephNodeMaintenance, status *cephv1.NodeMaintenanceStatus) error {
+	nsName := types.NamespacedName{Namespace: maintenance.Namespace, Name: maintenance.Name}
+	nodeName := maintenance.Spec.NodeName
+
+	osdDeployments, err := r.getNodeDaemons(clusterInfo, maintenance, osd.AppName, osd.OsdIdLabelKey)
+	if err != nil {
+		return err
+	}
+	monDeployments, err := r.getNodeDaemons(clusterInfo, maintenance, mon.AppName, config.MonType)
+	if err != nil {
+		return err
+	}
+
+	if status.CrushHost == "" {
+		for _, d := range osdDeployments {
+			if host := d.Labels[crushHostLabel]; host != "" {
+				status.CrushHost = host
+				break
+			}
+		}
+	}
+
+	osdDump, err := cephclient.GetOSDDump(r.context.ClusterdContext, clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	if status.CrushHost != "" {
+		if _, err := osdDump.UpdateFlagOnCrushUnit(r.context.ClusterdContext, clusterInfo, true, status.CrushHost, nooutFlag); err != nil {
+			return errors.Wrapf(err, "failed to set %s flag on crush host %q", nooutFlag, status.CrushHost)
+		}
+		if _, err := osdDump.UpdateFlag(r.context.ClusterdContext, clusterInfo, true, norebalanceFlag); err != nil {
+			return errors.Wrapf(err, "failed to set %s flag", norebalanceFlag)
+		}
+	}
+
+	// Stop the OSDs one at a time, waiting for each OSD to be down before checking the next one
+	for i := range osdDeployments {
+		d := &osdDeployments[i]
+		osdID, err := strconv.Atoi(d.Labels[osd.OsdIdLabelKey])
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse the osd id of deployment %q", d.Name)
+		}
+
+		if d.Annotations[cephv1.NodeMaintenanceAnnotationKey] != maintenance.Name {
+			if _, ok := d.Labels[cephv1.SkipReconcileLabelKey]; ok {
+				log.NamedWarning(nsName, logger, "osd.%d is labeled with %q by another process, leaving it as is", osdID, cephv1.SkipReconcileLabelKey)
+				continue
+			}
+			if _, err := cephclient.OSDOkToStop(r.context.ClusterdContext, clusterInfo, osdID, 1); err != nil {
+				status.Phase = cephv1.NodeMaintenancePhaseBlocked
+				status.Message = fmt.Sprintf("osd.%d is not ok to stop. %v", osdID, err)
+				return nil
+			}
+			if err := r.stopDaemon(clusterInfo, maintenance, d); err != nil {
+				return err
+			}
+			log.NamedInfo(nsName, logger, "stopped osd.%d of node %q", osdID, nodeName)
+		}
+		if !slices.Contains(status.StoppedOSDs, osdID) {
+			status.StoppedOSDs = append(status.StoppedOSDs, osdID)
+		}
+
+		up, _, err := osdDump.StatusByID(int64(osdID))
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the status of osd.%d", osdID)
+		}
+		if up == 1 {
+			status.Phase = cephv1.NodeMaintenancePhaseInProgress
+			status.Message = fmt.Sprintf("waiting for osd.%d to be down", osdID)
+			return nil
+		}
+	}
+
+	// Stop the mons only if enough mons of other nodes are in quorum
+	var monsToStop []*appsv1.Deployment
+	nodeMons := sets.New[string]()
+	for i := range monDeployments {
+		d := &monDeployments[i]
+		monID := d.Labels[config.MonType]
+		if d.Annotations[cephv1.NodeMaintenanceAnnotationKey] != maintenance.Name {
+			if _, ok := d.Labels[cephv1.SkipReconcileLabelKey]; ok {
+				log.NamedWarning(nsName, logger, "mon %q is labeled with %q by another process, leaving it as is", monID, cephv1.SkipReconcileLabelKey)
+				continue
+			}
+			monsToStop = append(monsToStop, d)
+		}
+		nodeMons.Insert(monID)
+	}
+	if len(monsToStop) > 0 {
+		monStatus, err := cephclient.GetMonQuorumStatus(r.context.ClusterdContext, clusterInfo)
+		if err != nil {
+			return errors.Wrap(err, "failed to get mon quorum status")
+		}
+		if ok, remaining := quorumSurvives(monStatus, nodeMons); !ok {
+			status.Phase = cephv1.NodeMaintenancePhaseBlocked
+			status.Message = fmt.Sprintf("stopping mons %v would break the mon quorum, only %d of %d mons would remain in quorum",
+				sets.List(nodeMons), remaining, len(monStatus.MonMap.Mons))
+			return nil
+		}
+		for _, d := range monsToStop {
+			if err := r.stopDaemon(clusterInfo, maintenance, d); err != nil {
+				return err
+			}
+			log.NamedInfo(nsName, logger, "stopped mon %q of node %q", d.Labels[config.MonType], nodeName)
+		}
+	}
+	for _, monID := range sets.List(nodeMons) {
+		if !slices.Contains(status.StoppedMons, monID) {
+			status.StoppedMons = append(status.StoppedMons, monID)
+		}
+	}
+
+	if status.Phase != cephv1.NodeMaintenancePhaseReady {
+		r.recorder.Eventf(maintenance, nil, corev1.EventTypeNormal, string(cephv1.NodeMaintenancePhaseReady), string(cephv1.NodeMaintenancePhaseReady),
+			"node %q is ready for maintenance", nodeName)
+	}
+	status.Phase = cephv1.NodeMaintenancePhaseReady
+	status.Message = fmt.Sprintf("%d OSDs and %d mons of node %q are stopped", len(status.StoppedOSDs), len(status.StoppedMons), nodeName)
+	return nil
+}
+
+// quorumSurvives returns whether a majority of the mons stays in quorum without the given mons, and
+// the number of mons that would remain in quorum
+func quorumSurvives(monStatus cephclient.MonStatusResponse, stoppedMons sets.Set[string]) (bool, int) {
+	remaining := 0
+	for _, m := range monStatus.MonMap.Mons {
+		if slices.Contains(monStatus.Quorum, m.Rank) && !stoppedMons.Has(m.Name) {
+			remaining++
+		}
+	}
+	return remaining >= len(monStatus.MonMap.Mons)/2+1, remaining
+}
+
+// endMaintenance restarts the daemons stopped by the maintenance, mons first, and clears the OSD flags
+func (r *ReconcileNodeMaintenance) endMaintenance(clusterInfo *cephclient.ClusterInfo, maintenance *cephv1.CephNodeMaintenance, status *cephv1.NodeMaintenanceStatus) error {
+	nsName := types.NamespacedName{Namespace: maintenance.Namespace, Name: maintenance.Name}
+
+	for _, app := range []string{mon.AppName, osd.AppName} {
+		deployments, err := k8sutil.GetDeployments(clusterInfo.Context, r.context.ClusterdContext.Clientset, maintenance.Namespace, fmt.Sprintf("%s=%s", k8sutil.AppAttr, app))
+		if err != nil {
+			return errors.Wrapf(err, "failed to list %q deployments", app)
+		}
+		for i := range deployments.Items {
+			d := &deployments.Items[i]
+			if d.Annotations[cephv1.NodeMaintenanceAnnotationKey] != maintenance.Name {
+				continue
+			}
+			// Scale back up and drop both markers in one update, the reverse of how they were set
+			d.Spec.Replicas = new(int32(1))
+			delete(d.Annotations, cephv1.NodeMaintenanceAnnotationKey)
+			delete(d.Labels, cephv1.SkipReconcileLabelKey)
+			if _, err := r.context.ClusterdContext.Clientset.AppsV1().Deployments(d.Namespace).Update(clusterInfo.Context, d, metav1.UpdateOptions{}); err != nil {
+				return errors.Wrapf(err, "failed to restart deployment %q", d.Name)
+			}
+			log.NamedInfo(nsName, logger, "restarted deployment %q", d.Name)
+		}
+	}
+	status.StoppedOSDs = nil
+	status.StoppedMons = nil
+
+	if status.CrushHost == "" {
+		return nil
+	}
+	osdDump, err := cephclient.GetOSDDump(r.context.ClusterdContext, clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd dump")
+	}
+	if _, err := osdDump.UpdateFlagOnCrushUnit(r.context.ClusterdContext, clusterInfo, false, status.CrushHost, nooutFlag); err != nil {
+		return errors.Wrapf(err, "failed to unset %s flag on crush host %q", nooutFlag, status.CrushHost)
+	}
+	otherMaintenance, err := r.hasOtherActiveMaintenance(clusterInfo, maintenance)
+	if err != nil {
+		return err
+	}
+	if otherMaintenance {
+		log.NamedInfo(nsName, logger, "keeping the %s flag set for other node maintenances", norebalanceFlag)
+		return nil
+	}
+	if _, err := osdDump.UpdateFlag(r.context.ClusterdContext, clusterInfo, false, norebalanceFlag); err != nil {
+		return errors.Wrapf(err, "failed to unset %s flag", norebalanceFlag)
+	}
+	return nil
+}
+
+// getNodeDaemons returns the deployments of the given app with a pod running on the node of the
+// maintenance, and the deployments already stopped by the maintenance
+func (r *ReconcileNodeMaintenance) getNodeDaemons(clusterInfo *cephclient.ClusterInfo, maintenance *cephv1.CephNodeMaintenance, app, idLabel string) ([]appsv1.Deployment, error) {
+	clientset := r.context.ClusterdContext.Clientset
+	selector := fmt.Sprintf("%s=%s", k8sutil.AppAttr, app)
+
+	pods, err := clientset.CoreV1().Pods(maintenance.Namespace).List(clusterInfo.Context, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %q pods", app)
+	}
+	daemonIDs := sets.New[string]()
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == maintenance.Spec.NodeName {
+			daemonIDs.Insert(pod.Labels[idLabel])
+		}
+	}
+
+	deployments, err := k8sutil.GetDeployments(clusterInfo.Context, clientset, maintenance.Namespace, selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %q deployments", app)
+	}
+	var nodeDeployments []appsv1.Deployment
+	for _, d := range deployments.Items {
+		if daemonIDs.Has(d.Labels[idLabel]) || d.Annotations[cephv1.NodeMaintenanceAnnotationKey] == maintenance.Name {
+			nodeDeployments = append(nodeDeployments, d)
+		}
+	}
+	sort.Slice(nodeDeployments, func(i, j int) bool { return nodeDeployments[i].Name < nodeDeployments[j].Name })
+	return nodeDeployments, nil
+}
+
+// stopDaemon fences the deployment off the operator reconcile and scales it down
+func (r *ReconcileNodeMaintenance) stopDaemon(clusterInfo *cephclient.ClusterInfo, maintenance *cephv1.CephNodeMaintenance, d *appsv1.Deployment) error {
+	// Both markers are written in the same update, so the deployment is never fenced but unowned
+	k8sutil.AddLabelToDeployment(cephv1.SkipReconcileLabelKey, "true", d)
+	k8sutil.AddAnnotationToDeployment(cephv1.NodeMaintenanceAnnotationKey, maintenance.Name, d)
+	d.Spec.Replicas = new(int32(0))
+	updated, err := r.context.ClusterdContext.Clientset.AppsV1().Deployments(d.Namespace).Update(clusterInfo.Context, d, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to stop deployment %q", d.Name)
+	}
+	*d = *updated
+	return nil
+}
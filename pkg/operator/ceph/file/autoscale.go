@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	defaultScaleUpRequestRate   = 1000
+	defaultCacheUsagePercent    = 80
+	defaultMDSAutoscaleCooldown = 10 * time.Minute
+	// mdsAutoscaleInterval is how often the load of the active ranks is evaluated
+	mdsAutoscaleInterval = time.Minute
+	// mdsStopRankTimeout is how long to wait for a rank to stop when scaling down
+	mdsStopRankTimeout = 5 * time.Minute
+)
+
+var now = time.Now
+
+// mdsLoad is the load of the active mds ranks of a filesystem
+type mdsLoad struct {
+	// requestRate is the average client request rate per active rank
+	requestRate float64
+	// cacheUsagePercent is the highest cache usage of an active rank
+	cacheUsagePercent int32
+}
+
+func getMDSLoad(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, fsName string) (*mdsLoad, error) {
+	status, err := cephclient.GetFilesystemStatus(context, clusterInfo, fsName)
+	if err != nil {
+		return nil, err
+	}
+
+	load := &mdsLoad{}
+	active := 0
+	totalRate := 0.0
+	for _, mds := range status.MDSMap {
+		if mds.State != "active" {
+			continue
+		}
+		active++
+		totalRate += mds.Rate
+
+		used, limit, err := cephclient.GetMDSCacheUsage(context, clusterInfo, mds.Name)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 {
+			load.cacheUsagePercent = max(load.cacheUsagePercent, int32(used*100/limit))
+		}
+	}
+	if active == 0 {
+		return nil, errors.Errorf("filesystem %q has no active mds", fsName)
+	}
+	load.requestRate = totalRate / float64(active)
+	return load, nil
+}
+
+func autoscaleThresholds(policy *cephv1.MDSAutoscaleSpec) (scaleUpRate, scaleDownRate float64, cacheUsagePercent int32, cooldown time.Duration) {
+	scaleUpRate = defaultScaleUpRequestRate
+	if policy.ScaleUpRequestRate > 0 {
+		scaleUpRate = float64(policy.ScaleUpRequestRate)
+	}
+	scaleDownRate = scaleUpRate / 2
+	if policy.ScaleDownRequestRate != nil {
+		scaleDownRate = float64(*policy.ScaleDownRequestRate)
+	}
+	cacheUsagePercent = defaultCacheUsagePercent
+	if policy.CacheUsagePercent > 0 {
+		cacheUsagePercent = policy.CacheUsagePercent
+	}
+	cooldown = defaultMDSAutoscaleCooldown
+	if policy.CooldownPeriod != nil {
+		cooldown = policy.CooldownPeriod.Duration
+	}
+	return scaleUpRate, scaleDownRate, cacheUsagePercent, cooldown
+}
+
+// desiredActiveCount returns the number of active ranks for the given load, changing the current
+// count by at most one rank, and the reason for the change
+func desiredActiveCount(policy *cephv1.MDSAutoscaleSpec, current int32, load *mdsLoad, lastScaleTime *metav1.Time) (int32, string) {
+	scaleUpRate, scaleDownRate, cacheUsagePercent, cooldown := autoscaleThresholds(policy)
+	if lastScaleTime != nil && now().Before(lastScaleTime.Add(cooldown)) {
+		return current, ""
+	}
+
+	if current < policy.MaxActiveCount {
+		if load.requestRate > scaleUpRate {
+			return current + 1, fmt.Sprintf("request rate of %.0f/s per rank is above %.0f/s", load.requestRate, scaleUpRate)
+		}
+		if load.cacheUsagePercent > cacheUsagePercent {
+			return current + 1, fmt.Sprintf("cache usage of %d%% is above %d%%", load.cacheUsagePercent, cacheUsagePercent)
+		}
+	}
+	if current > policy.MinActiveCount && load.requestRate < scaleDownRate && load.cacheUsagePercent < cacheUsagePercent {
+		// do not remove a rank if the remaining ranks would have to be scaled up again right away
+		if load.requestRate*float64(current)/float64(current-1) <= scaleUpRate {
+			return current - 1, fmt.Sprintf("request rate of %.0f/s per rank is below %.0f/s", load.requestRate, scaleDownRate)
+		}
+	}
+	return current, ""
+}
+
+// mdsAutoscaler periodically evaluates the load of the active mds ranks of a filesystem and changes
+// the number of active ranks in the status of the filesystem. The filesystem is then reconciled to
+// deploy or remove the mds daemons of the ranks.
+type mdsAutoscaler struct {
+	context        *clusterd.Context
+	client         client.Client
+	recorder       events.EventRecorder
+	clusterInfo    *cephclient.ClusterInfo
+	namespacedName types.NamespacedName
+	interval       time.Duration
+	// reconcile requests a reconcile of the filesystem
+	reconcile chan<- event.TypedGenericEvent[*cephv1.CephFilesystem]
+}
+
+// startMDSAutoscaler starts the autoscaling of the active mds ranks of the filesystem, or stops it if
+// autoscaling is disabled
+func (r *ReconcileCephFilesystem) startMDSAutoscaler(fs *cephv1.CephFilesystem) {
+	nsName := types.NamespacedName{Namespace: fs.Namespace, Name: fs.Name}
+	fsContext := r.fsContexts[fsChannelKeyName(fs)]
+	if fs.Spec.MetadataServer.Autoscale == nil {
+		if fsContext.autoscaleCancel != nil {
+			log.NamedInfo(nsName, logger, "stopping the autoscaling of the active mds ranks")
+			fsContext.autoscaleCancel()
+			fsContext.autoscaleCancel = nil
+		}
+		return
+	}
+	if fsContext.autoscaleCancel != nil {
+		log.NamedDebug(nsName, logger, "mds autoscaling go routine already running")
+		return
+	}
+
+	internalCtx, internalCancel := context.WithCancel(fsContext.internalCtx)
+	fsContext.autoscaleCancel = internalCancel
+	autoscaler := &mdsAutoscaler{
+		context:        r.context,
+		client:         r.client,
+		recorder:       r.recorder,
+		clusterInfo:    r.clusterInfo,
+		namespacedName: nsName,
+		interval:       mdsAutoscaleInterval,
+		reconcile:      r.autoscaleEvents,
+	}
+	log.NamedInfo(nsName, logger, "starting the autoscaling of the active mds ranks every %s", mdsAutoscaleInterval.String())
+	go autoscaler.autoscaleMDS(internalCtx)
+}
+
+// autoscaleMDS periodically evaluates the load of the active mds ranks
+func (a *mdsAutoscaler) autoscaleMDS(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.NamedInfo(a.namespacedName, logger, "stopping the autoscaling of the active mds ranks")
+			return
+
+		case <-time.After(a.interval):
+			fs := &cephv1.CephFilesystem{}
+			if err := a.client.Get(ctx, a.namespacedName, fs); err != nil {
+				log.NamedDebug(a.namespacedName, logger, "failed to get the filesystem to autoscale its mds ranks. %v", err)
+				continue
+			}
+			scaled, err := a.autoscale(ctx, fs)
+			if err != nil {
+				log.NamedWarning(a.namespacedName, logger, "failed to autoscale the active mds ranks. %v", err)
+				continue
+			}
+			if scaled {
+				// the mds daemons of the new number of ranks are deployed by the reconcile
+				select {
+				case a.reconcile <- event.TypedGenericEvent[*cephv1.CephFilesystem]{Object: fs}:
+				case <-ctx.Done():
+				}
+			}
+		}
+	}
+}
+
+// autoscale evaluates the load of the active mds ranks and updates the number of active ranks the
+// filesystem should run in its status. Returns whether the number of active ranks changed.
+func (a *mdsAutoscaler) autoscale(ctx context.Context, fs *cephv1.CephFilesystem) (bool, error) {
+	policy := fs.Spec.MetadataServer.Autoscale
+	if policy == nil || !fs.GetDeletionTimestamp().IsZero() {
+		return false, nil
+	}
+	current := fs.ActiveMDSCount()
+
+	status := cephv1.MDSAutoscaleStatus{ActiveCount: current}
+	if fs.Status != nil && fs.Status.MDSAutoscale != nil {
+		status.LastScaleTime = fs.Status.MDSAutoscale.LastScaleTime
+		status.LastScaleReason = fs.Status.MDSAutoscale.LastScaleReason
+	}
+
+	details, err := cephclient.GetFilesystem(a.context, a.clusterInfo, fs.Name)
+	if err != nil {
+		// the filesystem does not exist yet and is created with the current count
+		return false, updateMDSAutoscaleStatus(ctx, a.client, a.namespacedName, status)
+	}
+	mdsMap := details.MDSMap
+	if mdsMap.MaxMDS != int(current) || len(mdsMap.Up) != mdsMap.MaxMDS || len(mdsMap.Failed) > 0 {
+		log.NamedDebug(a.namespacedName, logger, "not autoscaling mds ranks while the filesystem has %d of %d ranks up", len(mdsMap.Up), mdsMap.MaxMDS)
+		return false, updateMDSAutoscaleStatus(ctx, a.client, a.namespacedName, status)
+	}
+
+	load, err := getMDSLoad(a.context, a.clusterInfo, fs.Name)
+	if err != nil {
+		log.NamedWarning(a.namespacedName, logger, "failed to get the load of the active mds ranks, not autoscaling. %v", err)
+		return false, updateMDSAutoscaleStatus(ctx, a.client, a.namespacedName, status)
+	}
+	status.RequestRate = int64(load.requestRate)
+	status.CacheUsagePercent = load.cacheUsagePercent
+
+	desired, reason := desiredActiveCount(policy, current, load, status.LastScaleTime)
+	if desired == current {
+		return false, updateMDSAutoscaleStatus(ctx, a.client, a.namespacedName, status)
+	}
+
+	log.NamedInfo(a.namespacedName, logger, "scaling active mds ranks from %d to %d since the %s", current, desired, reason)
+	if desired < current {
+		// Stop the rank before its daemons are removed so that a standby does not take it over.
+		// When scaling up, the new daemons are started by the reconcile before max_mds is raised so
+		// the standbys of the existing ranks are not used for the new rank.
+		if err := cephclient.SetNumMDSRanks(a.context, a.clusterInfo, fs.Name, desired); err != nil {
+			return false, err
+		}
+		if err := cephclient.WaitForActiveRanks(a.context, a.clusterInfo, fs.Name, desired, false, mdsStopRankTimeout); err != nil {
+			return false, errors.Wrapf(err, "failed to stop mds rank %d", current-1)
+		}
+	}
+	status.ActiveCount = desired
+	status.LastScaleTime = &metav1.Time{Time: now()}
+	status.LastScaleReason = reason
+	if err := updateMDSAutoscaleStatus(ctx, a.client, a.namespacedName, status); err != nil {
+		return false, err
+	}
+	a.recorder.Eventf(fs, nil, corev1.EventTypeNormal, "MDSAutoscale", "Scale", "scaled active mds ranks from %d to %d since the %s", current, desired, reason)
+	return true, nil
+}
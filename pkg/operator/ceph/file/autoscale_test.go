@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDesiredActiveCount(t *testing.T) {
+	policy := &cephv1.MDSAutoscaleSpec{MinActiveCount: 1, MaxActiveCount: 3}
+	recently := &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	longAgo := &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name      string
+		policy    *cephv1.MDSAutoscaleSpec
+		current   int32
+		load      mdsLoad
+		lastScale *metav1.Time
+		want      int32
+	}{
+		{"steady load", policy, 2, mdsLoad{requestRate: 700, cacheUsagePercent: 50}, nil, 2},
+		{"high request rate", policy, 1, mdsLoad{requestRate: 1500}, nil, 2},
+		{"high cache usage", policy, 1, mdsLoad{requestRate: 100, cacheUsagePercent: 90}, longAgo, 2},
+		{"at max", policy, 3, mdsLoad{requestRate: 5000, cacheUsagePercent: 90}, nil, 3},
+		{"cooldown", policy, 1, mdsLoad{requestRate: 1500}, recently, 1},
+		{"low request rate", policy, 3, mdsLoad{requestRate: 100, cacheUsagePercent: 10}, nil, 2},
+		{"low request rate with high cache usage", policy, 3, mdsLoad{requestRate: 100, cacheUsagePercent: 85}, nil, 3},
+		{
+			"remaining ranks would be overloaded",
+			&cephv1.MDSAutoscaleSpec{MinActiveCount: 1, MaxActiveCount: 3, ScaleUpRequestRate: 100, ScaleDownRequestRate: ptr.To(int32(80))},
+			2, mdsLoad{requestRate: 70}, nil, 2,
+		},
+		{"at min", policy, 1, mdsLoad{}, nil, 1},
+		{
+			"custom thresholds",
+			&cephv1.MDSAutoscaleSpec{MinActiveCount: 1, MaxActiveCount: 3, ScaleUpRequestRate: 100, ScaleDownRequestRate: ptr.To(int32(10)), CacheUsagePercent: 95, CooldownPeriod: &metav1.Duration{Duration: time.Second}},
+			2, mdsLoad{requestRate: 200, cacheUsagePercent: 90}, recently, 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := desiredActiveCount(tt.policy, tt.current, &tt.load, tt.lastScale)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, got == tt.current, reason == "")
+		})
+	}
+}
+
+func TestReconcileMDSAutoscale(t *testing.T) {
+	ctx := context.TODO()
+	maxMDS := 1
+	up := `{"mds_0":4463}`
+	requestRate := 1500.0
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "fs" && args[1] == "get" {
+				return fmt.Sprintf(`{"mdsmap":{"fs_name":"myfs","max_mds":%d,"up":%s,"failed":[],"info":{}},"id":1}`, maxMDS, up), nil
+			}
+			if args[0] == "fs" && args[1] == "status" {
+				return fmt.Sprintf(`{"mdsmap":[{"name":"myfs-a","rank":0,"rate":%f,"state":"active"},{"name":"myfs-b","rank":0,"state":"standby-replay"}]}`, requestRate), nil
+			}
+			if args[0] == "tell" && args[2] == "cache" {
+				return `{"pool":{"items":10,"bytes":100}}`, nil
+			}
+			if args[0] == "tell" && args[2] == "config" {
+				return `{"mds_cache_memory_limit":"1000"}`, nil
+			}
+			if args[0] == "fs" && args[1] == "set" && args[3] == "max_mds" {
+				maxMDS = 1
+				up = `{"mds_0":4463}`
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+
+	fs := &cephv1.CephFilesystem{
+		ObjectMeta: metav1.ObjectMeta{Name: "myfs", Namespace: "rook-ceph"},
+		Spec: cephv1.FilesystemSpec{
+			MetadataServer: cephv1.MetadataServerSpec{
+				ActiveCount: 1,
+				Autoscale:   &cephv1.MDSAutoscaleSpec{MinActiveCount: 1, MaxActiveCount: 2},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(fs).WithStatusSubresource(fs).Build()
+	a := &mdsAutoscaler{
+		client:         cl,
+		recorder:       events.NewFakeRecorder(10),
+		context:        &clusterd.Context{Executor: executor},
+		clusterInfo:    cephclient.AdminTestClusterInfo("rook-ceph"),
+		namespacedName: types.NamespacedName{Name: "myfs", Namespace: "rook-ceph"},
+	}
+	getFS := func() *cephv1.CephFilesystem {
+		current := &cephv1.CephFilesystem{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: "myfs", Namespace: "rook-ceph"}, current))
+		return current
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		noAutoscale := fs.DeepCopy()
+		noAutoscale.Spec.MetadataServer.Autoscale = nil
+		scaled, err := a.autoscale(ctx, noAutoscale)
+		assert.NoError(t, err)
+		assert.False(t, scaled)
+		assert.Nil(t, getFS().Status)
+	})
+
+	t.Run("scale up", func(t *testing.T) {
+		scaled, err := a.autoscale(ctx, getFS())
+		assert.NoError(t, err)
+		assert.True(t, scaled)
+		status := getFS().Status.MDSAutoscale
+		assert.Equal(t, int32(2), status.ActiveCount)
+		assert.Equal(t, int64(1500), status.RequestRate)
+		assert.Equal(t, int32(10), status.CacheUsagePercent)
+		assert.NotNil(t, status.LastScaleTime)
+		assert.Contains(t, status.LastScaleReason, "above 1000/s")
+		assert.Equal(t, int32(2), getFS().ActiveMDSCount())
+	})
+
+	t.Run("wait for the ranks to settle", func(t *testing.T) {
+		scaled, err := a.autoscale(ctx, getFS())
+		assert.NoError(t, err)
+		assert.False(t, scaled)
+		assert.Equal(t, int32(2), getFS().ActiveMDSCount())
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		maxMDS = 2
+		up = `{"mds_0":4463,"mds_1":4464}`
+		requestRate = 10
+		scaled, err := a.autoscale(ctx, getFS())
+		assert.NoError(t, err)
+		assert.False(t, scaled)
+		assert.Equal(t, int64(10), getFS().Status.MDSAutoscale.RequestRate)
+		assert.Equal(t, int32(2), getFS().ActiveMDSCount())
+	})
+
+	t.Run("scale down after the cooldown", func(t *testing.T) {
+		now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { now = time.Now }()
+		scaled, err := a.autoscale(ctx, getFS())
+		assert.NoError(t, err)
+		assert.True(t, scaled)
+		assert.Equal(t, 1, maxMDS)
+		assert.Equal(t, int32(1), getFS().Status.MDSAutoscale.ActiveCount)
+	})
+}
+
+func TestStartMDSAutoscaler(t *testing.T) {
+	fs := &cephv1.CephFilesystem{
+		ObjectMeta: metav1.ObjectMeta{Name: "myfs", Namespace: "rook-ceph"},
+		Spec: cephv1.FilesystemSpec{
+			MetadataServer: cephv1.MetadataServerSpec{
+				ActiveCount: 1,
+				Autoscale:   &cephv1.MDSAutoscaleSpec{MinActiveCount: 1, MaxActiveCount: 2},
+			},
+		},
+	}
+	internalCtx, internalCancel := context.WithCancel(context.TODO())
+	defer internalCancel()
+	r := &ReconcileCephFilesystem{
+		fsContexts: map[string]*fsHealth{fsChannelKeyName(fs): {internalCtx: internalCtx, internalCancel: internalCancel}},
+	}
+
+	r.startMDSAutoscaler(fs)
+	assert.NotNil(t, r.fsContexts[fsChannelKeyName(fs)].autoscaleCancel)
+
+	// a running autoscaler is not started again
+	r.startMDSAutoscaler(fs)
+	assert.NotNil(t, r.fsContexts[fsChannelKeyName(fs)].autoscaleCancel)
+	fs.Spec.MetadataServer.Autoscale = nil
+	r.startMDSAutoscaler(fs)
+	assert.Nil(t, r.fsContexts[fsChannelKeyName(fs)].autoscaleCancel)
+}
@@ -44,6 +44,7 @@ import (
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -82,12 +83,16 @@ type ReconcileCephFilesystem struct {
 	opManagerContext      context.Context
 	opConfig              opcontroller.OperatorConfig
 	shouldRotateCephxKeys bool
+	// autoscaleEvents requests the reconcile of a filesystem whose number of active mds ranks was
+	// changed by its autoscaler
+	autoscaleEvents chan event.TypedGenericEvent[*cephv1.CephFilesystem]
 }
 
 type fsHealth struct {
-	internalCtx    context.Context
-	internalCancel context.CancelFunc
-	started        bool
+	internalCtx     context.Context
+	internalCancel  context.CancelFunc
+	started         bool
+	autoscaleCancel context.CancelFunc
 }
 
 // Add creates a new CephFilesystem Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -97,7 +102,7 @@ func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext contex
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) *ReconcileCephFilesystem {
 	return &ReconcileCephFilesystem{
 		client:           mgr.GetClient(),
 		recorder:         mgr.GetEventRecorder("rook-" + controllerName),
@@ -106,6 +111,7 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 		fsContexts:       make(map[string]*fsHealth),
 		opManagerContext: opManagerContext,
 		opConfig:         opConfig,
+		autoscaleEvents:  make(chan event.TypedGenericEvent[*cephv1.CephFilesystem]),
 	}
 }
 
@@ -124,9 +130,9 @@ func watchOwnedCoreObject[T client.Object](c controller.Controller, mgr manager.
 	)
 }
 
-func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
+func add(opManagerContext context.Context, mgr manager.Manager, r *ReconcileCephFilesystem) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -145,6 +151,18 @@ func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reco
 		return err
 	}
 
+	// Reconcile the filesystems whose cephx keys are overdue for rotation in the cephx key inventory
+	err = c.Watch(opcontroller.ReconcileTriggerSource(controllerTypeMeta.Kind))
+	if err != nil {
+		return err
+	}
+
+	// Reconcile the filesystems whose number of active mds ranks was changed by the autoscaler
+	err = c.Watch(source.Channel(r.autoscaleEvents, &handler.TypedEnqueueRequestForObject[*cephv1.CephFilesystem]{}))
+	if err != nil {
+		return err
+	}
+
 	// Watch all other resources
 	for _, t := range objectsToWatch {
 		err = watchOwnedCoreObject(c, mgr, t)
@@ -364,7 +382,8 @@ func (r *ReconcileCephFilesystem) reconcile(request reconcile.Request) (reconcil
 
 	// daemon key type always takes the default from setDefaultCephxKeyType()
 	r.shouldRotateCephxKeys, err = keyring.ShouldRotateCephxKeys(cephCluster.Spec.Security.CephX.Daemon, *runningCephVersion,
-		*desiredCephVersion, cephFilesystem.Status.Cephx.Daemon, true, r.clusterInfo.Namespace)
+		*desiredCephVersion, cephFilesystem.Status.Cephx.Daemon, true, r.clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephFilesystem", cephFilesystem.Name, ""))
 	if err != nil {
 		return reconcile.Result{}, *cephFilesystem, errors.Wrap(err, "failed to determine if cephx keys should be rotated")
 	}
@@ -476,6 +495,9 @@ func (r *ReconcileCephFilesystem) reconcile(request reconcile.Request) (reconcil
 		}
 	}
 
+	// Evaluate the load of the mds ranks periodically
+	r.startMDSAutoscaler(cephFilesystem)
+
 	return reconcile.Result{}, *cephFilesystem, nil
 }
 
@@ -498,6 +520,9 @@ func (r *ReconcileCephFilesystem) reconcileCreateFilesystem(cephFilesystem *ceph
 		}
 	}
 
+	// the number of active ranks of an autoscaled filesystem is set by its autoscaler
+	cephFilesystem.Spec.MetadataServer.ActiveCount = cephFilesystem.ActiveMDSCount()
+
 	ownerInfo := k8sutil.NewOwnerInfo(cephFilesystem, r.scheme)
 	err := createFilesystem(r.context, r.clusterInfo, *cephFilesystem, r.cephClusterSpec, ownerInfo, r.cephClusterSpec.DataDirHostPath, r.shouldRotateCephxKeys)
 	if err != nil {
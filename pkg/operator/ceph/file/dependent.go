@@ -99,6 +99,17 @@ func cephFilesystemDependents(clusterdCtx *clusterd.Context, clusterInfo *cephcl
 		log.NamedDebug(nsName, logger, "found CephFilesystemSubVolumeGroups %q that does not depend on CephFilesystem", subVolumeGroup.Name)
 	}
 
+	// CephFilesystemSubVolumes
+	subVolumes, err := clusterdCtx.RookClientset.CephV1().CephFilesystemSubVolumes(filesystem.Namespace).List(clusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return deps, errors.Wrapf(err, "%s. failed to list CephFilesystemSubVolumes for CephFilesystem %q", baseErrMsg, nsName)
+	}
+	for _, subVolume := range subVolumes.Items {
+		if subVolume.Spec.FilesystemName == filesystem.Name {
+			deps.Add("CephFilesystemSubVolumes", subVolume.Name)
+		}
+	}
+
 	return deps, nil
 }
 
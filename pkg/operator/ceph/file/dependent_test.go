@@ -141,6 +141,19 @@ func TestCephFilesystemDependents(t *testing.T) {
 		assert.ElementsMatch(t, deps.OfKind("CephFilesystemSubVolumeGroups"), []string{"subvolgroup1"})
 	})
 
+	t.Run("one CephFilesystemSubVolume", func(t *testing.T) {
+		client.ListSubvolumeGroups = noSubvolumeGroups
+		client.ListSubvolumesInGroup = noSubvolumes
+
+		c := newClusterdCtx()
+		_, err := c.RookClientset.CephV1().CephFilesystemSubVolumes(clusterInfo.Namespace).Create(ctx, &cephv1.CephFilesystemSubVolume{ObjectMeta: meta("subvol1"), Spec: cephv1.CephFilesystemSubVolumeSpec{FilesystemName: "myfs"}}, v1.CreateOptions{})
+		assert.NoError(t, err)
+		deps, err := CephFilesystemDependents(c, clusterInfo, fs)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, deps.PluralKinds(), []string{"CephFilesystemSubVolumes"})
+		assert.ElementsMatch(t, deps.OfKind("CephFilesystemSubVolumes"), []string{"subvol1"})
+	})
+
 	t.Run("one ceph subvolumegroup with no subvolumes", func(t *testing.T) {
 		subvolumeGroupsToReturn := client.SubvolumeGroupList{
 			client.SubvolumeGroup{Name: "csi"},
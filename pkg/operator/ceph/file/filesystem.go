@@ -97,7 +97,7 @@ func deleteFilesystem(
 	c := mds.NewCluster(clusterInfo, context, clusterSpec, fs, ownerInfo, dataDirHostPath, false)
 
 	// Delete mds CephX keys and configuration in centralized mon database
-	replicas := fs.Spec.MetadataServer.ActiveCount * 2
+	replicas := fs.MaxActiveMDSCount() * 2
 	for i := 0; i < int(replicas); i++ {
 		daemonLetterID := k8sutil.IndexToName(i)
 		daemonName := fmt.Sprintf("%s-%s", fs.Name, daemonLetterID)
@@ -143,6 +143,9 @@ func validateFilesystem(context *clusterd.Context, clusterInfo *cephclient.Clust
 	if f.Spec.MetadataServer.ActiveCount < 1 {
 		return errors.New("MetadataServer.ActiveCount must be at least 1")
 	}
+	if autoscale := f.Spec.MetadataServer.Autoscale; autoscale != nil && autoscale.MinActiveCount > autoscale.MaxActiveCount {
+		return errors.New("MetadataServer.Autoscale.MinActiveCount must not be greater than MaxActiveCount")
+	}
 	// No data pool means that we expect the fs to exist already
 	if len(f.Spec.DataPools) == 0 {
 		return nil
@@ -141,6 +141,12 @@ func (c *Cluster) Start() error {
 		return errors.Wrap(err, "failed to check for mds to skip reconcile")
 	}
 
+	mdsHeldByUpgrade, err := controller.GetDaemonsHeldByCanaryUpgrade(c.clusterInfo.Context, c.context, c.clusterInfo.Namespace, config.MdsType, AppName)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for mds held by the canary upgrade")
+	}
+	mdsToSkipReconcile = mdsToSkipReconcile.Union(mdsHeldByUpgrade)
+
 	// keep list of deployments we want so unwanted ones can be deleted later
 	desiredDeployments := map[string]bool{} // improvised set
 	// Create/update deployments
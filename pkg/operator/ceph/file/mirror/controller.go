@@ -117,7 +117,7 @@ func watchOwnedCoreObject[T client.Object](c controller.Controller, mgr manager.
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -136,6 +136,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Reconcile the filesystem mirrors whose cephx keys are overdue for rotation in the cephx key inventory
+	err = c.Watch(opcontroller.ReconcileTriggerSource(controllerTypeMeta.Kind))
+	if err != nil {
+		return err
+	}
+
 	// Watch all other resources
 	for _, t := range objectsToWatch {
 		err = watchOwnedCoreObject(c, mgr, t)
@@ -249,7 +255,8 @@ func (r *ReconcileFilesystemMirror) reconcile(request reconcile.Request) (reconc
 	// check if cephRBDMirror daemon keys should be rotated or not
 	// daemon key type always takes the default from setDefaultCephxKeyType()
 	r.shouldRotateCephxKeys, err = keyring.ShouldRotateCephxKeys(
-		cephCluster.Spec.Security.CephX.Daemon, *runningCephVersion, *runningCephVersion, filesystemMirror.Status.Cephx.Daemon, true, r.clusterInfo.Namespace)
+		cephCluster.Spec.Security.CephX.Daemon, *runningCephVersion, *runningCephVersion, filesystemMirror.Status.Cephx.Daemon, true, r.clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephFilesystemMirror", filesystemMirror.Name, ""))
 	if err != nil {
 		return reconcile.Result{}, *filesystemMirror, errors.Wrapf(err, "failed to determine if cephx keys should be rotated for the cephFileSystemMirror %q", request.NamespacedName)
 	}
@@ -18,6 +18,8 @@ limitations under the License.
 package file
 
 import (
+	"context"
+	"reflect"
 	"time"
 
 	"github.com/pkg/errors"
@@ -28,6 +30,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // updateStatus updates a fs CR with the given status
@@ -71,6 +74,29 @@ func (r *ReconcileCephFilesystem) updateStatus(observedGeneration int64, namespa
 	return fs, nil
 }
 
+// updateMDSAutoscaleStatus updates the mds autoscaler status of a fs CR
+func updateMDSAutoscaleStatus(ctx context.Context, c client.Client, namespacedName types.NamespacedName, status cephv1.MDSAutoscaleStatus) error {
+	fs := &cephv1.CephFilesystem{}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := c.Get(ctx, namespacedName, fs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to retrieve filesystem %q to update mds autoscale status", namespacedName.String())
+		}
+		if fs.Status == nil {
+			fs.Status = &cephv1.CephFilesystemStatus{}
+		}
+		if reflect.DeepEqual(fs.Status.MDSAutoscale, &status) {
+			return nil
+		}
+		fs.Status.MDSAutoscale = &status
+		return reporting.UpdateStatus(c, fs)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update mds autoscale status of filesystem %q", namespacedName.String())
+	}
+	return nil
+}
+
 // updateStatusMirroring updates an object with a given status
 func (c *mirrorChecker) updateStatusMirroring(mirrorStatus []cephv1.FilesystemMirroringInfo, snapSchedStatus []cephv1.FilesystemSnapshotSchedulesSpec, details string) {
 	fs := &cephv1.CephFilesystem{}
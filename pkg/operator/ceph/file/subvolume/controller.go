@@ -0,0 +1,506 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package subvolume to manage CephFS subvolumes
+package subvolume
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/exec"
+	"github.com/rook/rook/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "ceph-fs-subvolume-controller"
+	appName        = "rook-ceph-fs-subvolume"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+// Sets the type meta for the controller main object
+var controllerTypeMeta = metav1.TypeMeta{
+	Kind:       reflect.TypeFor[cephv1.CephFilesystemSubVolume]().Name(),
+	APIVersion: fmt.Sprintf("%s/%s", cephv1.CustomResourceGroup, cephv1.Version),
+}
+
+// ReconcileCephFilesystemSubVolume reconciles a CephFilesystemSubVolume object
+type ReconcileCephFilesystemSubVolume struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	context          *clusterd.Context
+	clusterInfo      *cephclient.ClusterInfo
+	opManagerContext context.Context
+}
+
+// Add creates a new CephFilesystemSubVolume Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return add(mgr, newReconciler(mgr, context, opManagerContext))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context) reconcile.Reconciler {
+	return &ReconcileCephFilesystemSubVolume{
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		context:          context,
+		opManagerContext: opManagerContext,
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the CephFilesystemSubVolume CRD object
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephFilesystemSubVolume{TypeMeta: controllerTypeMeta},
+			&handler.TypedEnqueueRequestForObject[*cephv1.CephFilesystemSubVolume]{},
+			opcontroller.WatchControllerPredicate[*cephv1.CephFilesystemSubVolume](mgr.GetScheme()),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch the credential secrets so they are recreated if deleted
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&corev1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: corev1.SchemeGroupVersion.String()}},
+			handler.TypedEnqueueRequestForOwner[*corev1.Secret](
+				mgr.GetScheme(),
+				mgr.GetRESTMapper(),
+				&cephv1.CephFilesystemSubVolume{},
+			),
+			opcontroller.WatchPredicateForNonCRDObject[*corev1.Secret](&cephv1.CephFilesystemSubVolume{TypeMeta: controllerTypeMeta}, mgr.GetScheme()),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reconcile reads the state of the cluster for a CephFilesystemSubVolume object and makes changes based on the state read
+// and what is in the CephFilesystemSubVolume.Spec
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileCephFilesystemSubVolume) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	defer opcontroller.RecoverAndLogException()
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, err := r.reconcile(request)
+	if err != nil {
+		log.NamedError(request.NamespacedName, logger, "failed to reconcile %q. %v", request.NamespacedName, err)
+	}
+
+	return reconcileResponse, err
+}
+
+func (r *ReconcileCephFilesystemSubVolume) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	namespacedName := request.NamespacedName
+	// Fetch the CephFilesystemSubVolume instance
+	cephFilesystemSubVolume := &cephv1.CephFilesystemSubVolume{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, cephFilesystemSubVolume)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamedDebug(namespacedName, logger, "cephFilesystemSubVolume resource %q not found. Ignoring since object must be deleted.", namespacedName)
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, errors.Wrap(err, "failed to get cephFilesystemSubVolume")
+	}
+	// update observedGeneration local variable with current generation value,
+	// because generation can be changed before reconcile got completed
+	// CR status will be updated at end of reconcile, so to reflect the reconcile has finished
+	observedGeneration := cephFilesystemSubVolume.ObjectMeta.Generation
+
+	// Set a finalizer so we can do cleanup before the object goes away
+	generationUpdated, err := opcontroller.AddFinalizerIfNotPresent(r.opManagerContext, r.client, cephFilesystemSubVolume)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to add finalizer")
+	}
+	if generationUpdated {
+		log.NamedInfo(namespacedName, logger, "reconciling the subvolume %q after adding finalizer", cephFilesystemSubVolume.Name)
+		return reconcile.Result{}, nil
+	}
+
+	// The CR was just created, initializing status fields
+	if cephFilesystemSubVolume.Status == nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, cephv1.ConditionProgressing, nil)
+	}
+
+	// Make sure a CephCluster is present otherwise do nothing
+	cephCluster, isReadyToReconcile, cephClusterExists, reconcileResponse := opcontroller.IsReadyToReconcile(r.opManagerContext, r.client, namespacedName, controllerName)
+	if !isReadyToReconcile {
+		// This handles the case where the Ceph Cluster is gone and we want to delete that CR
+		// We skip the deleteSubVolume() function since everything is gone already
+		//
+		// Also, only remove the finalizer if the CephCluster is gone
+		// If not, we should wait for it to be ready
+		// This handles the case where the operator is not ready to accept Ceph command but the cluster exists
+		if !cephFilesystemSubVolume.GetDeletionTimestamp().IsZero() && !cephClusterExists {
+			// Remove finalizer
+			err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephFilesystemSubVolume)
+			if err != nil {
+				return opcontroller.ImmediateRetryResult, errors.Wrap(err, "failed to remove finalizer")
+			}
+
+			// Return and do not requeue. Successful deletion.
+			return reconcile.Result{}, nil
+		}
+		return reconcileResponse, nil
+	}
+
+	// Populate clusterInfo during each reconcile
+	r.clusterInfo, _, _, err = opcontroller.LoadClusterInfo(r.context, r.opManagerContext, namespacedName.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to populate cluster info")
+	}
+	r.clusterInfo.Context = r.opManagerContext
+
+	// DELETE: the CR was deleted
+	if !cephFilesystemSubVolume.GetDeletionTimestamp().IsZero() {
+		log.NamedDebug(namespacedName, logger, "deleting subvolume %q", namespacedName)
+
+		if !cephCluster.Spec.External.Enable {
+			err = r.deleteSubVolume(cephFilesystemSubVolume)
+			if err != nil {
+				if strings.Contains(err.Error(), opcontroller.UninitializedCephConfigError) {
+					logger.Info(opcontroller.OperatorNotInitializedMessage)
+					return opcontroller.WaitForRequeueIfOperatorNotInitialized, nil
+				}
+				return reconcile.Result{}, errors.Wrapf(err, "failed to delete ceph filesystem subvolume %q", cephFilesystemSubVolume.Name)
+			}
+		}
+
+		// Remove finalizer
+		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephFilesystemSubVolume)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to remove finalizer")
+		}
+
+		// Return and do not requeue. Successful deletion.
+		return reconcile.Result{}, nil
+	}
+
+	if cephCluster.Spec.External.Enable {
+		log.NamedWarning(namespacedName, logger, "subvolumes are not managed for external clusters, create them manually")
+		r.updateStatus(observedGeneration, namespacedName, cephv1.ConditionFailure, nil)
+		return reconcile.Result{}, nil
+	}
+
+	// Detect running Ceph version
+	runningCephVersion, err := cephclient.LeastUptodateDaemonVersion(r.context, r.clusterInfo, config.OsdType)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to retrieve current ceph %q version", config.OsdType)
+	}
+	r.clusterInfo.CephVersion = runningCephVersion
+
+	// Build the NamespacedName to fetch the Filesystem and make sure it exists, if not we cannot
+	// create the subvolume
+	cephFilesystem := &cephv1.CephFilesystem{}
+	cephFilesystemNamespacedName := types.NamespacedName{Name: cephFilesystemSubVolume.Spec.FilesystemName, Namespace: request.Namespace}
+	err = r.client.Get(r.opManagerContext, cephFilesystemNamespacedName, cephFilesystem)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, errors.Wrapf(err, "failed to fetch ceph filesystem %q, cannot create subvolume %q", cephFilesystemSubVolume.Spec.FilesystemName, cephFilesystemSubVolume.Name)
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, errors.Wrap(err, "failed to get cephFilesystem")
+	}
+
+	// If the CephFilesystem is not ready to accept commands, we should wait for it to be ready
+	if cephFilesystem.Status == nil || cephFilesystem.Status.Phase != cephv1.ConditionReady {
+		// We know the CR is present so it should be a matter of seconds for it to become ready
+		log.NamedInfo(namespacedName, logger, "waiting for ceph filesystem %q to be ready before creating subvolume", cephFilesystemSubVolume.Spec.FilesystemName)
+		return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+	}
+
+	// Create or update ceph filesystem subvolume
+	info, err := r.createOrUpdateSubVolume(cephFilesystemSubVolume)
+	if err != nil {
+		if strings.Contains(err.Error(), opcontroller.UninitializedCephConfigError) {
+			logger.Info(opcontroller.OperatorNotInitializedMessage)
+			return opcontroller.WaitForRequeueIfOperatorNotInitialized, nil
+		}
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, cephv1.ConditionFailure, nil)
+		return reconcile.Result{}, errors.Wrapf(err, "failed to create or update ceph filesystem subvolume %q", cephFilesystemSubVolume.Name)
+	}
+
+	// Create the cephx user limited to the subvolume and store its credential in a secret
+	err = r.reconcileSubVolumeSecret(cephFilesystemSubVolume, info)
+	if err != nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, cephv1.ConditionFailure, nil)
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile credential of ceph filesystem subvolume %q", cephFilesystemSubVolume.Name)
+	}
+
+	r.updateStatus(observedGeneration, namespacedName, cephv1.ConditionReady, info)
+
+	// Return and do not requeue
+	log.NamedDebug(namespacedName, logger, "done reconciling cephFilesystemSubVolume %q", namespacedName)
+	return reconcile.Result{}, nil
+}
+
+func getSubVolumeName(cephFilesystemSubVolume *cephv1.CephFilesystemSubVolume) string {
+	if cephFilesystemSubVolume.Spec.Name != "" {
+		return cephFilesystemSubVolume.Spec.Name
+	}
+	return cephFilesystemSubVolume.Name
+}
+
+// Create the ceph filesystem subvolume, or update its quota, owner, mode and earmark if it exists
+func (r *ReconcileCephFilesystemSubVolume) createOrUpdateSubVolume(cephFilesystemSubVolume *cephv1.CephFilesystemSubVolume) (*cephclient.SubVolumeInfo, error) {
+	nsName := opcontroller.NsName(cephFilesystemSubVolume.Namespace, cephFilesystemSubVolume.Name)
+	spec := &cephFilesystemSubVolume.Spec
+	subVolName := getSubVolumeName(cephFilesystemSubVolume)
+
+	info, err := cephclient.GetCephFSSubVolumeInfo(r.context, r.clusterInfo, spec.FilesystemName, spec.SubVolumeGroupName, subVolName)
+	if err != nil {
+		code, ok := exec.ExitStatus(err)
+		if !ok || code != int(syscall.ENOENT) {
+			return nil, errors.Wrapf(err, "failed to get info of subvolume %q", subVolName)
+		}
+
+		log.NamedInfo(nsName, logger, "creating ceph filesystem subvolume")
+		if err := cephclient.CreateCephFSSubVolume(r.context, r.clusterInfo, spec.FilesystemName, subVolName, spec); err != nil {
+			return nil, err
+		}
+	} else {
+		mode, uid, gid := subVolumeAttrChanges(spec, info)
+		if mode != "" || uid != nil || gid != nil {
+			log.NamedInfo(nsName, logger, "updating the mode and owner of ceph filesystem subvolume")
+			if err := cephclient.SetCephFSSubVolumeAttrs(r.context, r.clusterInfo, spec.FilesystemName, spec.SubVolumeGroupName, subVolName, mode, uid, gid); err != nil {
+				return nil, err
+			}
+		}
+
+		var size int64
+		if spec.Size != nil {
+			size = spec.Size.Value()
+		}
+		if info.BytesQuota != size {
+			log.NamedInfo(nsName, logger, "resizing ceph filesystem subvolume from %d to %d bytes", info.BytesQuota, size)
+			if err := cephclient.ResizeCephFSSubVolume(r.context, r.clusterInfo, spec.FilesystemName, spec.SubVolumeGroupName, subVolName, size); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if info == nil || info.Earmark != spec.Earmark {
+		// a new subvolume only needs an earmark if one is requested
+		if info != nil || spec.Earmark != "" {
+			if err := cephclient.SetCephFSSubVolumeEarmark(r.context, r.clusterInfo, spec.FilesystemName, spec.SubVolumeGroupName, subVolName, spec.Earmark); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// get the info again for the path of a new subvolume and the current quota
+	info, err = cephclient.GetCephFSSubVolumeInfo(r.context, r.clusterInfo, spec.FilesystemName, spec.SubVolumeGroupName, subVolName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get info of subvolume %q", subVolName)
+	}
+	return info, nil
+}
+
+// subVolumeAttrChanges returns the mode and owner of the spec that differ from the subvolume info
+func subVolumeAttrChanges(spec *cephv1.CephFilesystemSubVolumeSpec, info *cephclient.SubVolumeInfo) (mode string, uid, gid *int64) {
+	if spec.Mode != "" {
+		desired, err := strconv.ParseUint(spec.Mode, 8, 32)
+		if err != nil || uint32(desired) != info.Mode&0o7777 {
+			mode = spec.Mode
+		}
+	}
+	if spec.UID != nil && *spec.UID != info.UID {
+		uid = spec.UID
+	}
+	if spec.GID != nil && *spec.GID != info.GID {
+		gid = spec.GID
+	}
+	return mode, uid, gid
+}
+
+// Delete the ceph filesystem subvolume and its cephx user
+func (r *ReconcileCephFilesystemSubVolume) deleteSubVolume(cephFilesystemSubVolume *cephv1.CephFilesystemSubVolume) error {
+	nsName := opcontroller.NsName(cephFilesystemSubVolume.Namespace, cephFilesystemSubVolume.Name)
+	spec := &cephFilesystemSubVolume.Spec
+
+	if err := cephclient.AuthDelete(r.context, r.clusterInfo, "client."+generateCephUserName(cephFilesystemSubVolume)); err != nil {
+		return errors.Wrapf(err, "failed to delete cephx user of subvolume %q", cephFilesystemSubVolume.Name)
+	}
+
+	if spec.PreserveSubVolumeOnDelete {
+		log.NamedInfo(nsName, logger, "preserving ceph filesystem subvolume %q on deletion", getSubVolumeName(cephFilesystemSubVolume))
+		return nil
+	}
+
+	log.NamedInfo(nsName, logger, "deleting ceph filesystem subvolume object")
+	if err := cephclient.DeleteCephFSSubVolume(r.context, r.clusterInfo, spec.FilesystemName, spec.SubVolumeGroupName, getSubVolumeName(cephFilesystemSubVolume)); err != nil {
+		code, ok := exec.ExitStatus(err)
+		// If the subvolume does not exist, we should not return an error
+		if ok && code == int(syscall.ENOENT) {
+			log.NamedDebug(nsName, logger, "ceph filesystem subvolume does not exist")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete ceph filesystem subvolume %q", cephFilesystemSubVolume.Name)
+	}
+
+	log.NamedInfo(nsName, logger, "deleted ceph filesystem subvolume")
+	return nil
+}
+
+// generateCephUserName returns the cephx user of the subvolume without the "client." prefix,
+// as expected in the userID of a CSI secret
+func generateCephUserName(cephFilesystemSubVolume *cephv1.CephFilesystemSubVolume) string {
+	return fmt.Sprintf("fs-subvolume-%s", cephFilesystemSubVolume.Name)
+}
+
+func generateSecretName(cephFilesystemSubVolume *cephv1.CephFilesystemSubVolume) string {
+	return fmt.Sprintf("rook-ceph-fs-subvolume-%s", cephFilesystemSubVolume.Name)
+}
+
+// generateCephUserCaps returns caps that only allow access to the path of the subvolume and its data
+func generateCephUserCaps(cephFilesystemSubVolume *cephv1.CephFilesystemSubVolume, info *cephclient.SubVolumeInfo) []string {
+	fsName := cephFilesystemSubVolume.Spec.FilesystemName
+	osdCaps := fmt.Sprintf("allow rw tag cephfs data=%s", fsName)
+	if info.PoolNamespace != "" {
+		osdCaps = fmt.Sprintf("allow rw pool=%s namespace=%s", info.DataPool, info.PoolNamespace)
+	}
+	return []string{
+		"mon", fmt.Sprintf("allow r fsname=%s", fsName),
+		"mds", fmt.Sprintf("allow rw fsname=%s path=%s", fsName, info.Path),
+		"osd", osdCaps,
+	}
+}
+
+func (r *ReconcileCephFilesystemSubVolume) reconcileSubVolumeSecret(cephFilesystemSubVolume *cephv1.CephFilesystemSubVolume, info *cephclient.SubVolumeInfo) error {
+	userName := generateCephUserName(cephFilesystemSubVolume)
+	clientEntity := "client." + userName
+	caps := generateCephUserCaps(cephFilesystemSubVolume, info)
+
+	key, err := cephclient.AuthGetKey(r.context, r.clusterInfo, clientEntity)
+	if err != nil {
+		key, err = cephclient.AuthGetOrCreateKey(r.context, r.clusterInfo, clientEntity, "", caps)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create cephx user %q", clientEntity)
+		}
+	} else {
+		err = cephclient.AuthUpdateCaps(r.context, r.clusterInfo, clientEntity, caps)
+		if err != nil {
+			return errors.Wrapf(err, "cephx user %q exists, failed to update caps", clientEntity)
+		}
+	}
+
+	// The secret can be referenced by a static CSI PV as its node stage secret
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateSecretName(cephFilesystemSubVolume),
+			Namespace: cephFilesystemSubVolume.Namespace,
+			Labels: map[string]string{
+				"app":             appName,
+				"subvolume":       cephFilesystemSubVolume.Name,
+				"rook_cluster":    cephFilesystemSubVolume.Namespace,
+				"rook_filesystem": cephFilesystemSubVolume.Spec.FilesystemName,
+			},
+		},
+		StringData: map[string]string{
+			"userID":  userName,
+			"userKey": key,
+			"fsName":  cephFilesystemSubVolume.Spec.FilesystemName,
+			"path":    info.Path,
+		},
+		Type: k8sutil.RookType,
+	}
+	if err := controllerutil.SetControllerReference(cephFilesystemSubVolume, secret, r.scheme); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference of subvolume secret %q", secret.Name)
+	}
+	if err := opcontroller.CreateOrUpdateObject(r.opManagerContext, r.client, secret); err != nil {
+		return errors.Wrapf(err, "failed to create or update subvolume secret %q", secret.Name)
+	}
+	return nil
+}
+
+// updateStatus updates an object with a given status
+func (r *ReconcileCephFilesystemSubVolume) updateStatus(observedGeneration int64, name types.NamespacedName, status cephv1.ConditionType, info *cephclient.SubVolumeInfo) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cephFilesystemSubVolume := &cephv1.CephFilesystemSubVolume{}
+		if err := r.client.Get(r.opManagerContext, name, cephFilesystemSubVolume); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamedDebug(name, logger, "CephFilesystemSubVolume not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve ceph filesystem subvolume %q to update status to %q", name, status)
+		}
+		if cephFilesystemSubVolume.Status == nil {
+			cephFilesystemSubVolume.Status = &cephv1.CephFilesystemSubVolumeStatus{}
+		}
+
+		cephFilesystemSubVolume.Status.Phase = status
+		if info != nil {
+			cephFilesystemSubVolume.Status.Path = info.Path
+			cephFilesystemSubVolume.Status.SecretName = generateSecretName(cephFilesystemSubVolume)
+		}
+		if observedGeneration != k8sutil.ObservedGenerationNotAvailable {
+			cephFilesystemSubVolume.Status.ObservedGeneration = observedGeneration
+		}
+		if err := reporting.UpdateStatus(r.client, cephFilesystemSubVolume); err != nil {
+			return errors.Wrapf(err, "failed to set ceph filesystem subvolume %q status to %q", name, status)
+		}
+		return nil
+	})
+	if err != nil {
+		log.NamedError(name, logger, "failed to update ceph filesystem subvolume status to %q after retries. %v", status, err)
+		return
+	}
+	log.NamedDebug(name, logger, "ceph filesystem subvolume status updated to %q", status)
+}
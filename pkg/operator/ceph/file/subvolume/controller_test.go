@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subvolume
+
+import (
+	"context"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// import TestMockExecHelperProcess
+func TestMockExecHelperProcess(t *testing.T) {
+	exectest.TestMockExecHelperProcess(t)
+}
+
+func TestCreateOrUpdateSubVolume(t *testing.T) {
+	exists := false
+	quota := `"infinite"`
+	earmark := ""
+	mode := "16877"
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] != "fs" || args[1] != "subvolume" {
+				return "", errors.Errorf("unexpected ceph command %q", args)
+			}
+			commands = append(commands, args[2])
+			switch args[2] {
+			case "info":
+				if !exists {
+					return "", exectest.MockExecCommandReturns(t, "", "", int(syscall.ENOENT))
+				}
+				return `{"path":"/volumes/_nogroup/vol1/abc","bytes_quota":` + quota + `,"data_pool":"myfs-replicated","pool_namespace":"","earmark":"` + earmark + `","mode":` + mode + `,"uid":0,"gid":0}`, nil
+			case "create":
+				exists = true
+				for _, arg := range args {
+					assert.NotContains(t, arg, "--size")
+					if strings.HasPrefix(arg, "--mode=") {
+						mode = "16872"
+					}
+				}
+				return "", nil
+			case "resize":
+				quota = args[5]
+				return "", nil
+			case "earmark":
+				earmark = ""
+				if args[3] == "set" {
+					earmark = args[7]
+				}
+				return "", nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	r := &ReconcileCephFilesystemSubVolume{
+		context:     &clusterd.Context{Executor: executor},
+		clusterInfo: cephclient.AdminTestClusterInfo("rook-ceph"),
+	}
+	subVolume := &cephv1.CephFilesystemSubVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "vol1", Namespace: "rook-ceph"},
+		Spec:       cephv1.CephFilesystemSubVolumeSpec{FilesystemName: "myfs"},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		info, err := r.createOrUpdateSubVolume(subVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, "/volumes/_nogroup/vol1/abc", info.Path)
+		assert.Equal(t, []string{"info", "create", "info"}, commands)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		commands = nil
+		_, err := r.createOrUpdateSubVolume(subVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"info", "info"}, commands)
+	})
+
+	t.Run("resize and earmark", func(t *testing.T) {
+		commands = nil
+		subVolume.Spec.Size = ptr.To(resource.MustParse("1Gi"))
+		subVolume.Spec.Earmark = "nfs"
+		info, err := r.createOrUpdateSubVolume(subVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"info", "resize", "earmark", "info"}, commands)
+		assert.Equal(t, int64(1073741824), info.BytesQuota)
+		assert.Equal(t, "nfs", info.Earmark)
+	})
+
+	t.Run("apply mode of an existing subvolume", func(t *testing.T) {
+		commands = nil
+		subVolume.Spec.Mode = "750"
+		_, err := r.createOrUpdateSubVolume(subVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"info", "create", "info"}, commands)
+
+		// the mode is not set again once applied
+		commands = nil
+		_, err = r.createOrUpdateSubVolume(subVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"info", "info"}, commands)
+	})
+}
+
+func TestDeleteSubVolume(t *testing.T) {
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			commands = append(commands, strings.Join(args[:3], " "))
+			if args[0] == "fs" && args[2] == "rm" {
+				return "", exectest.MockExecCommandReturns(t, "", "", int(syscall.ENOENT))
+			}
+			return "", nil
+		},
+	}
+	r := &ReconcileCephFilesystemSubVolume{
+		context:     &clusterd.Context{Executor: executor},
+		clusterInfo: cephclient.AdminTestClusterInfo("rook-ceph"),
+	}
+	subVolume := &cephv1.CephFilesystemSubVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "vol1", Namespace: "rook-ceph"},
+		Spec:       cephv1.CephFilesystemSubVolumeSpec{FilesystemName: "myfs", PreserveSubVolumeOnDelete: true},
+	}
+
+	t.Run("preserve the subvolume", func(t *testing.T) {
+		assert.NoError(t, r.deleteSubVolume(subVolume))
+		assert.Equal(t, []string{"auth del client.fs-subvolume-vol1"}, commands)
+	})
+
+	t.Run("subvolume already removed", func(t *testing.T) {
+		commands = nil
+		subVolume.Spec.PreserveSubVolumeOnDelete = false
+		assert.NoError(t, r.deleteSubVolume(subVolume))
+		assert.Equal(t, []string{"auth del client.fs-subvolume-vol1", "fs subvolume rm"}, commands)
+	})
+}
+
+func TestReconcileSubVolumeSecret(t *testing.T) {
+	ctx := context.TODO()
+	var caps []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "auth" && args[1] == "get-key" {
+				return "", errors.New("not found")
+			}
+			if args[0] == "auth" && args[1] == "get-or-create-key" {
+				caps = args[3:9]
+				return `{"key":"AQBsecret=="}`, nil
+			}
+			return "", errors.Errorf("unexpected ceph command %q", args)
+		},
+	}
+	subVolume := &cephv1.CephFilesystemSubVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "vol1", Namespace: "rook-ceph", UID: "c47cac40-9bee-4d52-823b-ccd803ba5bfe"},
+		Spec:       cephv1.CephFilesystemSubVolumeSpec{FilesystemName: "myfs", NamespaceIsolated: true},
+	}
+	s := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(s))
+	require.NoError(t, corev1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(subVolume).Build()
+	r := &ReconcileCephFilesystemSubVolume{
+		client:           cl,
+		scheme:           s,
+		context:          &clusterd.Context{Executor: executor},
+		clusterInfo:      cephclient.AdminTestClusterInfo("rook-ceph"),
+		opManagerContext: ctx,
+	}
+	info := &cephclient.SubVolumeInfo{Path: "/volumes/_nogroup/vol1/abc", DataPool: "myfs-replicated", PoolNamespace: "fsvolumens_vol1"}
+
+	err := r.reconcileSubVolumeSecret(subVolume, info)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"mon", "allow r fsname=myfs",
+		"mds", "allow rw fsname=myfs path=/volumes/_nogroup/vol1/abc",
+		"osd", "allow rw pool=myfs-replicated namespace=fsvolumens_vol1",
+	}, caps)
+
+	secret := &corev1.Secret{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "rook-ceph-fs-subvolume-vol1", Namespace: "rook-ceph"}, secret)
+	require.NoError(t, err)
+	assert.Equal(t, "fs-subvolume-vol1", secret.StringData["userID"])
+	assert.Equal(t, "AQBsecret==", secret.StringData["userKey"])
+	assert.Equal(t, "/volumes/_nogroup/vol1/abc", secret.StringData["path"])
+	assert.Equal(t, "vol1", secret.OwnerReferences[0].Name)
+}
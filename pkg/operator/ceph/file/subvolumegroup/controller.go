@@ -92,7 +92,18 @@ func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext contex
 	}); err != nil {
 		return fmt.Errorf("failed to index CephFilesystemSubVolumeGroup by %s: %v", cephSVGFileSystemNameIndex, err)
 	}
-	return add(opManagerContext, mgr, newReconciler(mgr, context, opManagerContext, opConfig))
+	if err := add(opManagerContext, mgr, newReconciler(mgr, context, opManagerContext, opConfig)); err != nil {
+		return err
+	}
+
+	// The usage is collected separately from the reconcile of the subvolume groups
+	return mgr.Add(&opcontroller.UsageCollector[*cephv1.CephFilesystemSubVolumeGroup]{
+		Client:  mgr.GetClient(),
+		Context: context,
+		Kind:    controllerTypeMeta.Kind,
+		List:    &cephv1.CephFilesystemSubVolumeGroupList{},
+		Collect: collectUsage,
+	})
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -108,7 +119,7 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 
 func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -315,6 +326,7 @@ func (r *ReconcileCephFilesystemSubVolumeGroup) reconcile(request reconcile.Requ
 		if err != nil {
 			return reconcile.Result{}, errors.Wrap(err, "failed to remove finalizer")
 		}
+		opcontroller.DeleteUsageMetrics(controllerTypeMeta.Kind, cephFilesystemSubVolumeGroup)
 
 		// Return and do not requeue. Successful deletion.
 		return reconcile.Result{}, nil
@@ -390,6 +402,16 @@ func (r *ReconcileCephFilesystemSubVolumeGroup) reconcile(request reconcile.Requ
 	return reconcile.Result{}, nil
 }
 
+// collectUsage returns the capacity used by the subvolumes of the group, which is the recursive size
+// (rbytes) of the group directory
+func collectUsage(ctx context.Context, context *clusterd.Context, k8sClient client.Client, clusterInfo *cephclient.ClusterInfo, cephFilesystemSubVolumeGroup *cephv1.CephFilesystemSubVolumeGroup) (*cephv1.UsageStatus, error) {
+	used, quota, err := cephclient.GetCephFSSubVolumeGroupUsage(context, clusterInfo, cephFilesystemSubVolumeGroup.Spec.FilesystemName, getSubvolumeGroupName(cephFilesystemSubVolumeGroup))
+	if err != nil {
+		return nil, err
+	}
+	return &cephv1.UsageStatus{UsedBytes: used, QuotaBytes: quota}, nil
+}
+
 func getSubvolumeGroupName(cephFilesystemSubVolumeGroup *cephv1.CephFilesystemSubVolumeGroup) string {
 	if cephFilesystemSubVolumeGroup.Spec.Name != "" {
 		return cephFilesystemSubVolumeGroup.Spec.Name
@@ -119,7 +119,7 @@ func watchOwnedCoreObject[T client.Object](c controller.Controller, mgr manager.
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -138,6 +138,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Reconcile the nfs servers whose cephx keys are overdue for rotation in the cephx key inventory
+	err = c.Watch(opcontroller.ReconcileTriggerSource(controllerTypeMeta.Kind))
+	if err != nil {
+		return err
+	}
+
 	// Watch all other resources
 	for _, t := range objectsToWatch {
 		err = watchOwnedCoreObject(c, mgr, t)
@@ -310,7 +316,8 @@ func (r *ReconcileCephNFS) reconcile(request reconcile.Request) (reconcile.Resul
 	// Determine if we should rotate CephX keys for NFS daemons
 	// daemon key type always takes the default from setDefaultCephxKeyType()
 	r.shouldRotateCephxKeys, err = keyring.ShouldRotateCephxKeys(cephCluster.Spec.Security.CephX.Daemon, *runningCephVersion,
-		*desiredCephVersion, cephNFS.Status.Cephx.Daemon, true, r.clusterInfo.Namespace)
+		*desiredCephVersion, cephNFS.Status.Cephx.Daemon, true, r.clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephNFS", cephNFS.Name, ""))
 	if err != nil {
 		return reconcile.Result{}, *cephNFS, errors.Wrap(err, "failed to determine if cephx keys should be rotated")
 	}
@@ -19,7 +19,9 @@ package nvmeof
 import (
 	"context"
 	"fmt"
+	"path"
 	"reflect"
+	"slices"
 	"strings"
 
 	csiopv1 "github.com/ceph/ceph-csi-operator/api/v1"
@@ -46,8 +48,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -85,6 +89,8 @@ type ReconcileCephNVMeOFGateway struct {
 	opConfig              opcontroller.OperatorConfig
 	recorder              events.EventRecorder
 	shouldRotateCephxKeys bool
+	// securityHash is the hash of the secrets mounted in the gateway pods for the security settings
+	securityHash string
 }
 
 // Add creates a new CephNVMeOFGateway Controller and adds it to the Manager.
@@ -119,7 +125,7 @@ func watchOwnedCoreObject[T client.Object](c controller.Controller, mgr manager.
 }
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return errors.Wrap(err, "failed to create controller")
 	}
@@ -147,6 +153,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return errors.Wrap(err, "failed to watch CephNVMeOFGateway CRD")
 	}
 
+	// Reconcile the gateways whose cephx keys are overdue for rotation in the cephx key inventory
+	err = c.Watch(opcontroller.ReconcileTriggerSource(controllerTypeMeta.Kind))
+	if err != nil {
+		return errors.Wrap(err, "failed to watch the cephx key rotation requests")
+	}
+
 	for _, t := range objectsToWatch {
 		err = watchOwnedCoreObject(c, mgr, t)
 		if err != nil {
@@ -154,9 +166,45 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		}
 	}
 
+	// Watch the secrets of the security settings so that rotated certificates and keys are rolled
+	// out to the gateway pods
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&v1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: v1.SchemeGroupVersion.String()}},
+			handler.TypedEnqueueRequestsFromMapFunc(gatewaysOfSecret(mgr.GetClient())),
+			predicate.TypedFuncs[*v1.Secret]{
+				UpdateFunc: func(e event.TypedUpdateEvent[*v1.Secret]) bool {
+					return !reflect.DeepEqual(e.ObjectOld.Data, e.ObjectNew.Data)
+				},
+			},
+		),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to watch secrets")
+	}
+
 	return nil
 }
 
+// gatewaysOfSecret maps a secret to the gateways that mount it for their security settings
+func gatewaysOfSecret(c client.Client) handler.TypedMapFunc[*v1.Secret, reconcile.Request] {
+	return func(ctx context.Context, secret *v1.Secret) []reconcile.Request {
+		gateways := &cephv1.CephNVMeOFGatewayList{}
+		if err := c.List(ctx, gateways, client.InNamespace(secret.Namespace)); err != nil {
+			logger.Errorf("failed to list nvmeof gateways. %v", err)
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for i := range gateways.Items {
+			if slices.Contains(SecretNames(&gateways.Items[i]), secret.Name) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: secret.Namespace, Name: gateways.Items[i].Name}})
+			}
+		}
+		return requests
+	}
+}
+
 // Reconcile reads the state of the cluster for a CephNVMeOFGateway object and makes changes based on the state read.
 func (r *ReconcileCephNVMeOFGateway) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
 	defer opcontroller.RecoverAndLogException()
@@ -259,7 +307,8 @@ func (r *ReconcileCephNVMeOFGateway) reconcile(request reconcile.Request) (recon
 
 	// daemon key type always takes the default from setDefaultCephxKeyType()
 	r.shouldRotateCephxKeys, err = keyring.ShouldRotateCephxKeys(cephCluster.Spec.Security.CephX.Daemon, *runningCephVersion,
-		*desiredCephVersion, cephNVMeOFGateway.Status.Cephx.Daemon, true, r.clusterInfo.Namespace)
+		*desiredCephVersion, cephNVMeOFGateway.Status.Cephx.Daemon, true, r.clusterInfo.Namespace,
+		keyring.CephxKeyOwner("CephNVMeOFGateway", cephNVMeOFGateway.Name, ""))
 	if err != nil {
 		return reconcile.Result{}, *cephNVMeOFGateway, errors.Wrap(err, "failed to determine if cephx keys should be rotated")
 	}
@@ -267,6 +316,11 @@ func (r *ReconcileCephNVMeOFGateway) reconcile(request reconcile.Request) (recon
 		logger.Infof("cephx keys will be rotated for %q", request.NamespacedName)
 	}
 
+	r.securityHash, err = r.reconcileSecurity(cephNVMeOFGateway)
+	if err != nil {
+		return reconcile.Result{}, *cephNVMeOFGateway, errors.Wrap(err, "failed to reconcile security settings")
+	}
+
 	_, err = r.reconcileCreateCephNVMeOFGateway(cephNVMeOFGateway)
 	if err != nil {
 		return reconcile.Result{}, *cephNVMeOFGateway, errors.Wrap(err, "failed to create deployments")
@@ -280,6 +334,10 @@ func (r *ReconcileCephNVMeOFGateway) reconcile(request reconcile.Request) (recon
 		return opcontroller.ImmediateRetryResult, *cephNVMeOFGateway, errors.Wrapf(err, "failed to update status")
 	}
 
+	// generated certificates must be renewed before they expire even if nothing else changes
+	if MTLSEnabled(cephNVMeOFGateway) && cephNVMeOFGateway.Spec.Security.MTLS.ServerSecretName == "" {
+		return reconcile.Result{RequeueAfter: certRenewalCheckInterval}, *cephNVMeOFGateway, nil
+	}
 	return reconcile.Result{}, *cephNVMeOFGateway, nil
 }
 
@@ -292,7 +350,7 @@ func (r *ReconcileCephNVMeOFGateway) reconcileCreateCephNVMeOFGateway(cephNVMeOF
 	}
 
 	listOps := metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("%s=%s,app.kubernetes.io/part-of=%s", k8sutil.AppAttr, AppName, cephNVMeOFGateway.Name),
+		LabelSelector: GatewayLabelSelector(cephNVMeOFGateway.Name),
 	}
 	deployments, err := r.context.Clientset.AppsV1().Deployments(cephNVMeOFGateway.Namespace).List(r.opManagerContext, listOps)
 	if err != nil && !kerrors.IsNotFound(err) {
@@ -384,7 +442,7 @@ func (r *ReconcileCephNVMeOFGateway) downCephNVMeOFGateway(cephNVMeOFGateway *ce
 // getNVMeOFGatewayConfig generates a complete nvmeof.conf configuration file
 // with all values filled in (no placeholders). User overrides from nvmeofConfig
 // are merged on top of the default configuration.
-func getNVMeOFGatewayConfig(poolName, podName, podIP, anaGroup string, userConfig map[string]map[string]string) (string, error) {
+func getNVMeOFGatewayConfig(poolName, podName, podIP, anaGroup string, security *cephv1.NVMeOFSecuritySpec, userConfig map[string]map[string]string) (string, error) {
 	cfg := ini.Empty()
 	// Set default [gateway] section
 	gatewaySection, err := cfg.NewSection("gateway")
@@ -434,6 +492,18 @@ func getNVMeOFGatewayConfig(poolName, podName, podIP, anaGroup string, userConfi
 	mtlsSection.Key("server_cert").SetValue("./server.crt")
 	mtlsSection.Key("client_cert").SetValue("./client.crt")
 
+	if security != nil {
+		gatewaySection.Key("encryption_key").SetValue(path.Join(encryptionKeyDir, encryptionKeyKey))
+		if security.MTLS != nil {
+			// the gateway verifies client certificates with the CA in the server secret
+			gatewaySection.Key("enable_auth").SetValue("True")
+			mtlsSection.Key("server_key").SetValue(path.Join(serverTLSDir, v1.TLSPrivateKeyKey))
+			mtlsSection.Key("server_cert").SetValue(path.Join(serverTLSDir, v1.TLSCertKey))
+			mtlsSection.Key("client_cert").SetValue(path.Join(serverTLSDir, caCertKey))
+			mtlsSection.Key("client_key").SetValue(path.Join(clientTLSDir, v1.TLSPrivateKeyKey))
+		}
+	}
+
 	// Set default [spdk] section
 	spdkSection, err := cfg.NewSection("spdk")
 	if err != nil {
@@ -485,7 +555,7 @@ func (r *ReconcileCephNVMeOFGateway) generateConfigMap(nvmeof *cephv1.CephNVMeOF
 	// The init container will replace @@POD_IP@@ with the actual pod IP
 	podIP := "@@POD_IP@@"
 
-	configContent, err := getNVMeOFGatewayConfig(nvmeofPoolName, podName, podIP, anaGroup, nvmeof.Spec.NVMeOFConfig)
+	configContent, err := getNVMeOFGatewayConfig(nvmeofPoolName, podName, podIP, anaGroup, nvmeof.Spec.Security, nvmeof.Spec.NVMeOFConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate nvmeof config")
 	}
@@ -647,7 +647,7 @@ func TestNVMeOFKeyRotation(t *testing.T) {
 
 func TestNVMeOFConfigGeneration(t *testing.T) {
 	t.Run("defaults", func(t *testing.T) {
-		config, err := getNVMeOFGatewayConfig("pool-a", "pod-a", "10.0.0.1", "ana-a", nil)
+		config, err := getNVMeOFGatewayConfig("pool-a", "pod-a", "10.0.0.1", "ana-a", nil, nil)
 		assert.NoError(t, err)
 
 		cfg, err := ini.Load([]byte(config))
@@ -677,7 +677,7 @@ func TestNVMeOFConfigGeneration(t *testing.T) {
 				"foo": "bar",
 			},
 		}
-		config, err := getNVMeOFGatewayConfig("pool-a", "pod-a", "10.0.0.1", "ana-a", userConfig)
+		config, err := getNVMeOFGatewayConfig("pool-a", "pod-a", "10.0.0.1", "ana-a", nil, userConfig)
 		assert.NoError(t, err)
 
 		cfg, err := ini.Load([]byte(config))
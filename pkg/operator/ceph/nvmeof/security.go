@@ -0,0 +1,405 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nvmeof
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	caCertKey        = "ca.crt"
+	caPrivateKeyKey  = "ca.key"
+	encryptionKeyKey = "encryption.key"
+
+	serverTLSDir     = "/etc/nvmeof/tls/server"
+	clientTLSDir     = "/etc/nvmeof/tls/client"
+	encryptionKeyDir = "/etc/nvmeof/encryption"
+
+	caValidity      = 10 * 365 * 24 * time.Hour
+	certValidity    = 365 * 24 * time.Hour
+	certRenewBefore = 30 * 24 * time.Hour
+
+	// certRenewalCheckInterval is how often the generated certificates are checked for renewal
+	certRenewalCheckInterval = 24 * time.Hour
+)
+
+// now is the current time, replaced in unit tests to check certificate renewal
+var now = time.Now
+
+// MTLSEnabled returns whether the gRPC control plane of a gateway requires mutual TLS
+func MTLSEnabled(nvmeof *cephv1.CephNVMeOFGateway) bool {
+	return nvmeof.Spec.Security != nil && nvmeof.Spec.Security.MTLS != nil
+}
+
+// MTLSClientFlags returns the flags of the nvmeof CLI to connect to a gateway with mutual TLS,
+// using the client certificate mounted in the gateway pods
+func MTLSClientFlags() []string {
+	return []string{
+		"--server-cert", path.Join(clientTLSDir, caCertKey),
+		"--client-key", path.Join(clientTLSDir, v1.TLSPrivateKeyKey),
+		"--client-cert", path.Join(clientTLSDir, v1.TLSCertKey),
+	}
+}
+
+// GatewayServiceAddress returns the DNS name of the service of the gateway instance running in a
+// pod. The server certificate of a gateway with mutual TLS is valid for this name.
+func GatewayServiceAddress(pod *v1.Pod) string {
+	return fmt.Sprintf("%s.%s.svc", GatewayInstanceName(pod), pod.Namespace)
+}
+
+func generatedSecretName(nvmeof *cephv1.CephNVMeOFGateway, suffix string) string {
+	return fmt.Sprintf("%s-%s-%s", AppName, nvmeof.Name, suffix)
+}
+
+func serverSecretName(nvmeof *cephv1.CephNVMeOFGateway) string {
+	if name := nvmeof.Spec.Security.MTLS.ServerSecretName; name != "" {
+		return name
+	}
+	return generatedSecretName(nvmeof, "server-tls")
+}
+
+func clientSecretName(nvmeof *cephv1.CephNVMeOFGateway) string {
+	if name := nvmeof.Spec.Security.MTLS.ClientSecretName; name != "" {
+		return name
+	}
+	return generatedSecretName(nvmeof, "client-tls")
+}
+
+func encryptionKeySecretName(nvmeof *cephv1.CephNVMeOFGateway) string {
+	if name := nvmeof.Spec.Security.EncryptionKeySecretName; name != "" {
+		return name
+	}
+	return generatedSecretName(nvmeof, "encryption-key")
+}
+
+// SecretNames returns the names of the secrets mounted in the pods of a gateway for its security
+// settings, generated or not
+func SecretNames(nvmeof *cephv1.CephNVMeOFGateway) []string {
+	if nvmeof.Spec.Security == nil {
+		return nil
+	}
+	names := []string{encryptionKeySecretName(nvmeof)}
+	if MTLSEnabled(nvmeof) {
+		names = append(names, serverSecretName(nvmeof), clientSecretName(nvmeof))
+	}
+	return names
+}
+
+// reconcileSecurity generates the secrets that are not provided by the user for the security
+// settings of the gateway and returns a hash of all the mounted secrets, so that the gateway pods are
+// restarted when a certificate or key is rotated
+func (r *ReconcileCephNVMeOFGateway) reconcileSecurity(nvmeof *cephv1.CephNVMeOFGateway) (string, error) {
+	if nvmeof.Spec.Security == nil {
+		return "", nil
+	}
+
+	if nvmeof.Spec.Security.EncryptionKeySecretName == "" {
+		if err := r.generateEncryptionKey(nvmeof); err != nil {
+			return "", errors.Wrap(err, "failed to generate encryption key")
+		}
+	}
+	if MTLSEnabled(nvmeof) && nvmeof.Spec.Security.MTLS.ServerSecretName == "" {
+		if err := r.generateCertificates(nvmeof); err != nil {
+			return "", errors.Wrap(err, "failed to generate mTLS certificates")
+		}
+	}
+
+	required := map[string][]string{
+		encryptionKeySecretName(nvmeof): {encryptionKeyKey},
+	}
+	if MTLSEnabled(nvmeof) {
+		tlsKeys := []string{v1.TLSCertKey, v1.TLSPrivateKeyKey, caCertKey}
+		required[serverSecretName(nvmeof)] = tlsKeys
+		required[clientSecretName(nvmeof)] = tlsKeys
+	}
+
+	names := make([]string, 0, len(required))
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var content strings.Builder
+	for _, name := range names {
+		secret, err := r.context.Clientset.CoreV1().Secrets(nvmeof.Namespace).Get(r.opManagerContext, name, metav1.GetOptions{})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get secret %q", name)
+		}
+		for _, key := range required[name] {
+			value, ok := secret.Data[key]
+			if !ok || len(value) == 0 {
+				return "", errors.Errorf("secret %q has no %q key", name, key)
+			}
+			fmt.Fprintf(&content, "%s/%s=%s\n", name, key, value)
+		}
+	}
+	return k8sutil.Hash(content.String()), nil
+}
+
+func (r *ReconcileCephNVMeOFGateway) getSecret(nvmeof *cephv1.CephNVMeOFGateway, name string) (*v1.Secret, error) {
+	secret, err := r.context.Clientset.CoreV1().Secrets(nvmeof.Namespace).Get(r.opManagerContext, name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get secret %q", name)
+	}
+	return secret, nil
+}
+
+func (r *ReconcileCephNVMeOFGateway) saveSecret(nvmeof *cephv1.CephNVMeOFGateway, name string, secretType v1.SecretType, data map[string][]byte) error {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: nvmeof.Namespace,
+			Labels:    map[string]string{k8sutil.AppAttr: AppName, k8sutil.PartOfLabelKey: nvmeof.Name},
+		},
+		Type: secretType,
+		Data: data,
+	}
+	if err := controllerutil.SetControllerReference(nvmeof, secret, r.scheme); err != nil {
+		return errors.Wrapf(err, "failed to set owner reference for secret %q", name)
+	}
+	if _, err := k8sutil.CreateOrUpdateSecret(r.opManagerContext, r.context.Clientset, secret); err != nil {
+		return errors.Wrapf(err, "failed to save secret %q", name)
+	}
+	return nil
+}
+
+// generateEncryptionKey generates the key used by the gateway to encrypt host keys. The key is
+// never rotated since the host keys stored in Ceph could not be decrypted anymore.
+func (r *ReconcileCephNVMeOFGateway) generateEncryptionKey(nvmeof *cephv1.CephNVMeOFGateway) error {
+	name := encryptionKeySecretName(nvmeof)
+	secret, err := r.getSecret(nvmeof, name)
+	if err != nil || secret != nil {
+		return err
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return errors.Wrap(err, "failed to generate random key")
+	}
+	logger.Infof("generating encryption key for nvmeof gateway %q", nvmeof.Name)
+	return r.saveSecret(nvmeof, name, v1.SecretTypeOpaque, map[string][]byte{
+		encryptionKeyKey: []byte(base64.URLEncoding.EncodeToString(key)),
+	})
+}
+
+// serverDNSNames returns the names that the server certificate of a gateway must be valid for
+func serverDNSNames(nvmeof *cephv1.CephNVMeOFGateway) []string {
+	names := []string{}
+	for i := 0; i < nvmeof.Spec.Instances; i++ {
+		name := instanceName(nvmeof, k8sutil.IndexToName(i))
+		names = append(names, name, fmt.Sprintf("%s.%s.svc", name, nvmeof.Namespace))
+	}
+	return names
+}
+
+// generateCertificates generates a CA and the server and client certificates signed by it. The
+// certificates are re-issued when they are close to expiring, and the server certificate when the
+// gateway is scaled to instances that it is not valid for.
+func (r *ReconcileCephNVMeOFGateway) generateCertificates(nvmeof *cephv1.CephNVMeOFGateway) error {
+	caName := generatedSecretName(nvmeof, "ca")
+	caSecret, err := r.getSecret(nvmeof, caName)
+	if err != nil {
+		return err
+	}
+	if caSecret == nil || needsRenewal(caSecret.Data[caCertKey], nil) {
+		logger.Infof("generating CA for nvmeof gateway %q", nvmeof.Name)
+		certPEM, keyPEM, err := generateCertificate(fmt.Sprintf("%s-%s-ca", AppName, nvmeof.Name), nil, caValidity, nil, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate CA")
+		}
+		data := map[string][]byte{caCertKey: certPEM, caPrivateKeyKey: keyPEM}
+		if err := r.saveSecret(nvmeof, caName, v1.SecretTypeOpaque, data); err != nil {
+			return err
+		}
+		caSecret = &v1.Secret{Data: data}
+	}
+
+	certs := []struct {
+		secretName string
+		commonName string
+		dnsNames   []string
+	}{
+		{serverSecretName(nvmeof), instanceName(nvmeof, "server"), serverDNSNames(nvmeof)},
+		{clientSecretName(nvmeof), instanceName(nvmeof, "client"), nil},
+	}
+	for _, c := range certs {
+		secret, err := r.getSecret(nvmeof, c.secretName)
+		if err != nil {
+			return err
+		}
+		if secret != nil && bytes.Equal(secret.Data[caCertKey], caSecret.Data[caCertKey]) && !needsRenewal(secret.Data[v1.TLSCertKey], c.dnsNames) {
+			continue
+		}
+		logger.Infof("issuing certificate %q for nvmeof gateway %q", c.secretName, nvmeof.Name)
+		certPEM, keyPEM, err := generateCertificate(c.commonName, c.dnsNames, certValidity, caSecret.Data[caCertKey], caSecret.Data[caPrivateKeyKey])
+		if err != nil {
+			return errors.Wrapf(err, "failed to issue certificate %q", c.secretName)
+		}
+		data := map[string][]byte{v1.TLSCertKey: certPEM, v1.TLSPrivateKeyKey: keyPEM, caCertKey: caSecret.Data[caCertKey]}
+		if err := r.saveSecret(nvmeof, c.secretName, v1.SecretTypeTLS, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// needsRenewal returns whether a PEM certificate is invalid, expires soon, or is not valid for all
+// the given DNS names
+func needsRenewal(certPEM []byte, dnsNames []string) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return true
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	if now().Add(certRenewBefore).After(cert.NotAfter) {
+		return true
+	}
+	for _, name := range dnsNames {
+		if !slices.Contains(cert.DNSNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateCertificate returns a PEM certificate and key. The certificate is a self-signed CA if
+// caCertPEM is nil, otherwise it is signed by the given CA.
+func generateCertificate(commonName string, dnsNames []string, validity time.Duration, caCertPEM, caKeyPEM []byte) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate private key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate serial number")
+	}
+
+	notBefore := now().Add(-time.Hour)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Rook"}},
+		DNSNames:     dnsNames,
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	parent := template
+	var signer any = key
+	if caCertPEM == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+		parent, signer, err = parseCA(caCertPEM, caKeyPEM)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create certificate")
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal private key")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+func parseCA(caCertPEM, caKeyPEM []byte) (*x509.Certificate, any, error) {
+	certBlock, _ := pem.Decode(caCertPEM)
+	keyBlock, _ := pem.Decode(caKeyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, errors.New("failed to decode CA")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse CA certificate")
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse CA key")
+	}
+	return cert, key, nil
+}
+
+// securityVolumesAndMounts returns the volumes and mounts of the secrets of the security settings
+func securityVolumesAndMounts(nvmeof *cephv1.CephNVMeOFGateway) ([]v1.Volume, []v1.VolumeMount) {
+	if nvmeof.Spec.Security == nil {
+		return nil, nil
+	}
+	mode := int32(0400)
+	secretVolume := func(volumeName, secretName, dir string) (v1.Volume, v1.VolumeMount) {
+		return v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{SecretName: secretName, DefaultMode: &mode},
+			},
+		}, v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: dir,
+			ReadOnly:  true,
+		}
+	}
+
+	volumes := []v1.Volume{}
+	mounts := []v1.VolumeMount{}
+	vol, mount := secretVolume("nvmeof-encryption-key", encryptionKeySecretName(nvmeof), encryptionKeyDir)
+	volumes = append(volumes, vol)
+	mounts = append(mounts, mount)
+	if MTLSEnabled(nvmeof) {
+		vol, mount = secretVolume("nvmeof-server-tls", serverSecretName(nvmeof), serverTLSDir)
+		volumes = append(volumes, vol)
+		mounts = append(mounts, mount)
+		vol, mount = secretVolume("nvmeof-client-tls", clientSecretName(nvmeof), clientTLSDir)
+		volumes = append(volumes, vol)
+		mounts = append(mounts, mount)
+	}
+	return volumes, mounts
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nvmeof
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func parseCert(t *testing.T, certPEM []byte) *x509.Certificate {
+	block, _ := pem.Decode(certPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestReconcileSecurity(t *testing.T) {
+	ctx := context.TODO()
+	r, _ := newDeploymentSpecTest(t)
+	r.opManagerContext = ctx
+	gw := &cephv1.CephNVMeOFGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-nvmeof", Namespace: namespace},
+		TypeMeta:   controllerTypeMeta,
+		Spec: cephv1.NVMeOFGatewaySpec{
+			Group:     "group-a",
+			Instances: 2,
+			Security:  &cephv1.NVMeOFSecuritySpec{MTLS: &cephv1.NVMeOFMTLSSpec{}},
+		},
+	}
+	getSecret := func(name string) *v1.Secret {
+		secret, err := r.context.Clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return secret
+	}
+
+	t.Run("no security settings", func(t *testing.T) {
+		hash, err := r.reconcileSecurity(&cephv1.CephNVMeOFGateway{})
+		assert.NoError(t, err)
+		assert.Empty(t, hash)
+	})
+
+	var hash string
+	t.Run("certificates and key are generated", func(t *testing.T) {
+		var err error
+		hash, err = r.reconcileSecurity(gw)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, hash)
+
+		assert.NotEmpty(t, getSecret("rook-ceph-nvmeof-my-nvmeof-encryption-key").Data[encryptionKeyKey])
+		ca := getSecret("rook-ceph-nvmeof-my-nvmeof-ca")
+		pool := x509.NewCertPool()
+		pool.AddCert(parseCert(t, ca.Data[caCertKey]))
+
+		server := getSecret("rook-ceph-nvmeof-my-nvmeof-server-tls")
+		assert.Equal(t, v1.SecretTypeTLS, server.Type)
+		assert.Equal(t, ca.Data[caCertKey], server.Data[caCertKey])
+		serverCert := parseCert(t, server.Data[v1.TLSCertKey])
+		assert.Equal(t, []string{
+			"rook-ceph-nvmeof-my-nvmeof-a", "rook-ceph-nvmeof-my-nvmeof-a.rook-ceph.svc",
+			"rook-ceph-nvmeof-my-nvmeof-b", "rook-ceph-nvmeof-my-nvmeof-b.rook-ceph.svc",
+		}, serverCert.DNSNames)
+		_, err = serverCert.Verify(x509.VerifyOptions{DNSName: "rook-ceph-nvmeof-my-nvmeof-b.rook-ceph.svc", Roots: pool})
+		assert.NoError(t, err)
+
+		client := getSecret("rook-ceph-nvmeof-my-nvmeof-client-tls")
+		_, err = parseCert(t, client.Data[v1.TLSCertKey]).Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("reconcile again keeps the certificates", func(t *testing.T) {
+		again, err := r.reconcileSecurity(gw)
+		assert.NoError(t, err)
+		assert.Equal(t, hash, again)
+	})
+
+	t.Run("server certificate is re-issued when scaling up", func(t *testing.T) {
+		client := getSecret("rook-ceph-nvmeof-my-nvmeof-client-tls")
+		gw.Spec.Instances = 3
+		again, err := r.reconcileSecurity(gw)
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, again)
+		assert.Contains(t, parseCert(t, getSecret("rook-ceph-nvmeof-my-nvmeof-server-tls").Data[v1.TLSCertKey]).DNSNames, "rook-ceph-nvmeof-my-nvmeof-c.rook-ceph.svc")
+		assert.Equal(t, client.Data, getSecret("rook-ceph-nvmeof-my-nvmeof-client-tls").Data)
+		hash = again
+	})
+
+	t.Run("certificates are renewed before they expire", func(t *testing.T) {
+		ca := getSecret("rook-ceph-nvmeof-my-nvmeof-ca")
+		key := getSecret("rook-ceph-nvmeof-my-nvmeof-encryption-key")
+		now = func() time.Time { return time.Now().Add(certValidity - certRenewBefore + time.Hour) }
+		defer func() { now = time.Now }()
+
+		again, err := r.reconcileSecurity(gw)
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, again)
+		assert.Equal(t, ca.Data, getSecret("rook-ceph-nvmeof-my-nvmeof-ca").Data)
+		assert.Equal(t, key.Data, getSecret("rook-ceph-nvmeof-my-nvmeof-encryption-key").Data)
+	})
+
+	t.Run("user secrets must have the expected keys", func(t *testing.T) {
+		userGW := gw.DeepCopy()
+		userGW.Spec.Security = &cephv1.NVMeOFSecuritySpec{
+			EncryptionKeySecretName: "my-key",
+			MTLS:                    &cephv1.NVMeOFMTLSSpec{ServerSecretName: "my-server", ClientSecretName: "my-client"},
+		}
+		_, err := r.reconcileSecurity(userGW)
+		assert.ErrorContains(t, err, `"my-client"`)
+
+		tlsData := map[string][]byte{v1.TLSCertKey: []byte("cert"), v1.TLSPrivateKeyKey: []byte("key"), caCertKey: []byte("ca")}
+		for name, data := range map[string]map[string][]byte{
+			"my-key":    {encryptionKeyKey: []byte("key")},
+			"my-server": tlsData,
+			"my-client": {v1.TLSCertKey: []byte("cert")},
+		} {
+			_, err := r.context.Clientset.CoreV1().Secrets(namespace).Create(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Data: data}, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		_, err = r.reconcileSecurity(userGW)
+		assert.ErrorContains(t, err, `secret "my-client" has no "tls.key" key`)
+
+		_, err = r.context.Clientset.CoreV1().Secrets(namespace).Update(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-client", Namespace: namespace}, Data: tlsData}, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		userHash, err := r.reconcileSecurity(userGW)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, userHash)
+		assert.ElementsMatch(t, []string{"my-key", "my-server", "my-client"}, SecretNames(userGW))
+	})
+}
+
+func TestSecurityConfigAndMounts(t *testing.T) {
+	security := &cephv1.NVMeOFSecuritySpec{MTLS: &cephv1.NVMeOFMTLSSpec{}}
+	config, err := getNVMeOFGatewayConfig("pool-a", "pod-a", "10.0.0.1", "ana-a", security, nil)
+	assert.NoError(t, err)
+	cfg, err := ini.Load([]byte(config))
+	assert.NoError(t, err)
+	assert.Equal(t, "True", cfg.Section("gateway").Key("enable_auth").String())
+	assert.Equal(t, "/etc/nvmeof/encryption/encryption.key", cfg.Section("gateway").Key("encryption_key").String())
+	assert.Equal(t, "/etc/nvmeof/tls/server/tls.key", cfg.Section("mtls").Key("server_key").String())
+	assert.Equal(t, "/etc/nvmeof/tls/server/tls.crt", cfg.Section("mtls").Key("server_cert").String())
+	assert.Equal(t, "/etc/nvmeof/tls/server/ca.crt", cfg.Section("mtls").Key("client_cert").String())
+
+	r, configHash := newDeploymentSpecTest(t)
+	r.securityHash = "abc"
+	gw := &cephv1.CephNVMeOFGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-nvmeof", Namespace: namespace},
+		Spec: cephv1.NVMeOFGatewaySpec{
+			Image:     "quay.io/ceph/nvmeof:1.5",
+			Group:     "group-a",
+			Instances: 1,
+			Security:  &cephv1.NVMeOFSecuritySpec{EncryptionKeySecretName: "my-key"},
+		},
+	}
+	d, err := r.makeDeployment(gw, "a", "config", configHash)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", d.Spec.Template.Annotations["security-hash"])
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, v1.Volume{
+		Name:         "nvmeof-encryption-key",
+		VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "my-key", DefaultMode: d.Spec.Template.Spec.Volumes[3].Secret.DefaultMode}},
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, v1.VolumeMount{Name: "nvmeof-encryption-key", MountPath: "/etc/nvmeof/encryption", ReadOnly: true})
+	assert.Len(t, d.Spec.Template.Spec.Volumes, 4)
+
+	gw.Spec.Security.MTLS = &cephv1.NVMeOFMTLSSpec{}
+	d, err = r.makeDeployment(gw, "a", "config", configHash)
+	assert.NoError(t, err)
+	assert.Len(t, d.Spec.Template.Spec.Volumes, 6)
+	assert.Len(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, 4)
+}
@@ -43,6 +43,9 @@ const (
 	nvmeofDiscoveryPort = 8009
 	configKey           = "config"
 	serviceAccountName  = "rook-ceph-nvmeof"
+
+	// GatewayContainerName is the name of the container running the gateway daemon
+	GatewayContainerName = "nvmeof-gateway"
 )
 
 // getPorts returns the configured ports with defaults
@@ -70,6 +73,12 @@ func getPorts(nvmeof *cephv1.CephNVMeOFGateway) (ioPort, gatewayPort, monitorPor
 	return ioPort, gatewayPort, monitorPort, discoveryPort
 }
 
+// GatewayPorts returns the IO port and the gRPC port of the instances of a gateway
+func GatewayPorts(nvmeof *cephv1.CephNVMeOFGateway) (ioPort, gatewayPort int32) {
+	ioPort, gatewayPort, _, _ = getPorts(nvmeof)
+	return ioPort, gatewayPort
+}
+
 //go:embed connectionconfig.sh
 var connectionConfigScript string
 
@@ -185,6 +194,8 @@ func (r *ReconcileCephNVMeOFGateway) makeDeployment(nvmeof *cephv1.CephNVMeOFGat
 	if err != nil {
 		return nil, err
 	}
+	securityVolumes, securityMounts := securityVolumesAndMounts(nvmeof)
+	daemonContainer.VolumeMounts = append(daemonContainer.VolumeMounts, securityMounts...)
 
 	gatewayName := instanceName(nvmeof, daemonID)
 	podSpec := v1.PodSpec{
@@ -195,11 +206,11 @@ func (r *ReconcileCephNVMeOFGateway) makeDeployment(nvmeof *cephv1.CephNVMeOFGat
 			daemonContainer,
 		},
 		RestartPolicy: v1.RestartPolicyAlways,
-		Volumes: []v1.Volume{
+		Volumes: append([]v1.Volume{
 			cephConfigVol,
 			adminKeyringVolume(),
 			gatewayConfigVol,
-		},
+		}, securityVolumes...),
 		HostNetwork:        hostNetwork,
 		PriorityClassName:  nvmeof.Spec.PriorityClassName,
 		SecurityContext:    &v1.PodSecurityContext{},
@@ -232,6 +243,10 @@ func (r *ReconcileCephNVMeOFGateway) makeDeployment(nvmeof *cephv1.CephNVMeOFGat
 		},
 		Spec: podSpec,
 	}
+	// restart the gateway when a certificate or key is rotated since they are only read on startup
+	if r.securityHash != "" {
+		podTemplateSpec.Annotations["security-hash"] = r.securityHash
+	}
 
 	if hostNetwork {
 		podSpec.DNSPolicy = v1.DNSClusterFirstWithHostNet
@@ -361,7 +376,7 @@ func (r *ReconcileCephNVMeOFGateway) daemonContainer(nvmeof *cephv1.CephNVMeOFGa
 
 	privileged := true
 	container := v1.Container{
-		Name: "nvmeof-gateway",
+		Name: GatewayContainerName,
 		Args: []string{
 			"-c",
 			"/etc/ceph/nvmeof.conf",
@@ -448,6 +463,17 @@ func instanceName(nvmeof *cephv1.CephNVMeOFGateway, daemonID string) string {
 	return fmt.Sprintf("%s-%s-%s", AppName, nvmeof.Name, daemonID)
 }
 
+// GatewayInstanceName returns the name of the gateway instance running in a pod of a gateway. This
+// is the name the instance is known by in the gateway group.
+func GatewayInstanceName(pod *v1.Pod) string {
+	return fmt.Sprintf("%s-%s", AppName, pod.Labels[controller.DaemonIDLabel])
+}
+
+// GatewayLabelSelector returns the label selector of the deployments and pods of a gateway
+func GatewayLabelSelector(gatewayName string) string {
+	return fmt.Sprintf("%s=%s,%s=%s", k8sutil.AppAttr, AppName, k8sutil.PartOfLabelKey, gatewayName)
+}
+
 // getNVMeOFImage returns the image to use for the NVMe-oF gateway.
 // Priority: CR spec.image > ceph config (mgr/cephadm/container_image_nvmeof).
 // If neither source provides an image, an error is returned to fail the reconcile.
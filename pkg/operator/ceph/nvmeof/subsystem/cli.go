@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subsystem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/operator/ceph/nvmeof"
+	"github.com/rook/rook/pkg/util/exec"
+	v1 "k8s.io/api/core/v1"
+)
+
+// anyHostNQN is the host NQN that allows any host to connect to a subsystem
+const anyHostNQN = "*"
+
+// secretFlag is a flag of the nvmeof CLI whose value is a key. Keys are written to the stdin of the
+// exec rather than passed in the command so that they are not logged by the operator.
+type secretFlag struct {
+	name  string
+	value string
+}
+
+// redactedReader hides the keys written to stdin from the debug logs of the executor
+type redactedReader struct{ io.Reader }
+
+func (redactedReader) String() string { return "<redacted>" }
+
+// runGatewayCLI runs the nvmeof CLI in the container of a gateway pod and returns its output. The
+// CLI is a client of the gRPC API of the gateway, it is run in the gateway pod since the gRPC port
+// is only reachable on the pod network.
+var runGatewayCLI = func(ctx context.Context, clusterdContext *clusterd.Context, pod *v1.Pod, secrets []secretFlag, args ...string) (string, error) {
+	timeout := strconv.Itoa(int(exec.CephCommandsTimeout.Seconds()))
+	command := append([]string{"timeout", timeout, "python3", "-m", "control.cli"}, args...)
+	var stdin io.Reader
+	if len(secrets) > 0 {
+		// a shell reads the keys from stdin, one per line, and appends them to the command
+		var script, input strings.Builder
+		for _, s := range secrets {
+			fmt.Fprintf(&script, `read -r v; set -- "$@" %s "$v"; `, s.name)
+			input.WriteString(s.value + "\n")
+		}
+		script.WriteString(`exec "$@"`)
+		command = append([]string{"sh", "-c", script.String(), "sh"}, command...)
+		stdin = redactedReader{strings.NewReader(input.String())}
+	}
+	stdout, stderr, err := clusterdContext.RemoteExecutor.ExecWithOptions(ctx, exec.ExecOptions{
+		Command:       command,
+		Namespace:     pod.Namespace,
+		PodName:       pod.Name,
+		ContainerName: nvmeof.GatewayContainerName,
+		Stdin:         stdin,
+		CaptureStdout: true,
+		CaptureStderr: true,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to run nvmeof CLI in pod %q. %s", pod.Name, stderr)
+	}
+	return stdout, nil
+}
+
+// gatewayCLI calls the gRPC API of a gateway instance through the nvmeof CLI
+type gatewayCLI struct {
+	ctx             context.Context
+	clusterdContext *clusterd.Context
+	gateway         gatewayInstance
+}
+
+// cliStatus is the result returned by every command of the CLI
+type cliStatus struct {
+	Status       int    `json:"status"`
+	ErrorMessage string `json:"error_message"`
+}
+
+type cliGatewayInfo struct {
+	cliStatus
+	Name               string `json:"name"`
+	Group              string `json:"group"`
+	LoadBalancingGroup int32  `json:"load_balancing_group"`
+}
+
+type cliSubsystem struct {
+	NQN           string `json:"nqn"`
+	SerialNumber  string `json:"serial_number"`
+	MaxNamespaces int32  `json:"max_namespaces"`
+}
+
+type cliSubsystems struct {
+	cliStatus
+	Subsystems []cliSubsystem `json:"subsystems"`
+}
+
+type cliNamespace struct {
+	NSID               int32  `json:"nsid"`
+	Pool               string `json:"rbd_pool_name"`
+	Image              string `json:"rbd_image_name"`
+	LoadBalancingGroup int32  `json:"load_balancing_group"`
+}
+
+type cliNamespaces struct {
+	cliStatus
+	Namespaces []cliNamespace `json:"namespaces"`
+}
+
+type cliListener struct {
+	HostName      string `json:"host_name"`
+	AddressFamily string `json:"adrfam"`
+	Address       string `json:"traddr"`
+	Port          int32  `json:"trsvcid"`
+	Secure        bool   `json:"secure"`
+}
+
+type cliListeners struct {
+	cliStatus
+	Listeners []cliListener `json:"listeners"`
+}
+
+type cliHost struct {
+	NQN       string `json:"nqn"`
+	UseDHCHAP bool   `json:"use_dhchap"`
+	UsePSK    bool   `json:"use_psk"`
+}
+
+type cliHosts struct {
+	cliStatus
+	AllowAnyHost bool      `json:"allow_any_host"`
+	Hosts        []cliHost `json:"hosts"`
+}
+
+func (c *gatewayCLI) run(result interface{ status() cliStatus }, args ...string) error {
+	return c.runWithSecrets(result, nil, args...)
+}
+
+func (c *gatewayCLI) runWithSecrets(result interface{ status() cliStatus }, secrets []secretFlag, args ...string) error {
+	cliArgs := []string{
+		"--server-address", c.gateway.grpcAddress,
+		"--server-port", strconv.Itoa(int(c.gateway.grpcPort)),
+		"--format", "json",
+		"--output", "stdio",
+	}
+	if c.gateway.mtls {
+		cliArgs = append(cliArgs, nvmeof.MTLSClientFlags()...)
+	}
+	cliArgs = append(cliArgs, args...)
+	output, err := runGatewayCLI(c.ctx, c.clusterdContext, c.gateway.pod, secrets, cliArgs...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(output), result); err != nil {
+		return errors.Wrapf(err, "failed to parse output of nvmeof CLI command %q. %s", strings.Join(args, " "), output)
+	}
+	if s := result.status(); s.Status != 0 {
+		return errors.Errorf("nvmeof CLI command %q failed with status %d. %s", strings.Join(args, " "), s.Status, s.ErrorMessage)
+	}
+	return nil
+}
+
+func (s *cliStatus) status() cliStatus { return *s }
+
+func (c *gatewayCLI) info() (cliGatewayInfo, error) {
+	info := cliGatewayInfo{}
+	err := c.run(&info, "gw", "info")
+	return info, err
+}
+
+func (c *gatewayCLI) listSubsystems() ([]cliSubsystem, error) {
+	subsystems := cliSubsystems{}
+	err := c.run(&subsystems, "subsystem", "list")
+	return subsystems.Subsystems, err
+}
+
+// keyFlags returns the flags of the given keys that are set
+func keyFlags(dhchapKey, psk string) []secretFlag {
+	secrets := []secretFlag{}
+	if dhchapKey != "" {
+		secrets = append(secrets, secretFlag{name: "--dhchap-key", value: dhchapKey})
+	}
+	if psk != "" {
+		secrets = append(secrets, secretFlag{name: "--psk", value: psk})
+	}
+	return secrets
+}
+
+func (c *gatewayCLI) addSubsystem(nqn, serialNumber string, maxNamespaces int32, dhchapKey string) error {
+	// the gateway group is not appended so that the NQN is the one requested in the CR
+	args := []string{"subsystem", "add", "--subsystem", nqn, "--no-group-append"}
+	if serialNumber != "" {
+		args = append(args, "--serial-number", serialNumber)
+	}
+	if maxNamespaces != 0 {
+		args = append(args, "--max-namespaces", strconv.Itoa(int(maxNamespaces)))
+	}
+	return c.runWithSecrets(&cliStatus{}, keyFlags(dhchapKey, ""), args...)
+}
+
+// changeSubsystemKey sets the DH-HMAC-CHAP key of the subsystem, or removes it if the key is empty
+func (c *gatewayCLI) changeSubsystemKey(nqn, dhchapKey string) error {
+	return c.runWithSecrets(&cliStatus{}, keyFlags(dhchapKey, ""), "subsystem", "change_key", "--subsystem", nqn)
+}
+
+func (c *gatewayCLI) deleteSubsystem(nqn string) error {
+	return c.run(&cliStatus{}, "subsystem", "del", "--subsystem", nqn, "--force")
+}
+
+func (c *gatewayCLI) listNamespaces(nqn string) ([]cliNamespace, error) {
+	namespaces := cliNamespaces{}
+	err := c.run(&namespaces, "namespace", "list", "--subsystem", nqn)
+	return namespaces.Namespaces, err
+}
+
+// addNamespace adds an RBD image to the subsystem. If sizeMiB is not zero, the image is created if
+// it does not exist.
+func (c *gatewayCLI) addNamespace(nqn, pool, image string, nsid int32, sizeMiB int64, loadBalancingGroup int32) error {
+	args := []string{"namespace", "add", "--subsystem", nqn, "--rbd-pool", pool, "--rbd-image", image}
+	if nsid != 0 {
+		args = append(args, "--nsid", strconv.Itoa(int(nsid)))
+	}
+	if sizeMiB != 0 {
+		args = append(args, "--rbd-create-image", "--size", fmt.Sprintf("%dMB", sizeMiB))
+	}
+	if loadBalancingGroup != 0 {
+		args = append(args, "--load-balancing-group", strconv.Itoa(int(loadBalancingGroup)))
+	}
+	return c.run(&cliStatus{}, args...)
+}
+
+func (c *gatewayCLI) deleteNamespace(nqn string, nsid int32) error {
+	return c.run(&cliStatus{}, "namespace", "del", "--subsystem", nqn, "--nsid", strconv.Itoa(int(nsid)))
+}
+
+func (c *gatewayCLI) changeLoadBalancingGroup(nqn string, nsid, loadBalancingGroup int32) error {
+	return c.run(&cliStatus{}, "namespace", "change_load_balancing_group", "--subsystem", nqn,
+		"--nsid", strconv.Itoa(int(nsid)), "--load-balancing-group", strconv.Itoa(int(loadBalancingGroup)))
+}
+
+func (c *gatewayCLI) listListeners(nqn string) ([]cliListener, error) {
+	listeners := cliListeners{}
+	err := c.run(&listeners, "listener", "list", "--subsystem", nqn)
+	return listeners.Listeners, err
+}
+
+func (c *gatewayCLI) addListener(nqn string, l cliListener) error {
+	args := []string{"listener", "add", "--subsystem", nqn, "--host-name", l.HostName,
+		"--traddr", l.Address, "--trsvcid", strconv.Itoa(int(l.Port)), "--adrfam", l.AddressFamily}
+	if l.Secure {
+		args = append(args, "--secure")
+	}
+	return c.run(&cliStatus{}, args...)
+}
+
+func (c *gatewayCLI) deleteListener(nqn string, l cliListener) error {
+	return c.run(&cliStatus{}, "listener", "del", "--subsystem", nqn, "--host-name", l.HostName,
+		"--traddr", l.Address, "--trsvcid", strconv.Itoa(int(l.Port)), "--adrfam", l.AddressFamily, "--force")
+}
+
+func (c *gatewayCLI) listHosts(nqn string) (cliHosts, error) {
+	hosts := cliHosts{}
+	err := c.run(&hosts, "host", "list", "--subsystem", nqn)
+	return hosts, err
+}
+
+func (c *gatewayCLI) addHost(nqn, hostNQN, dhchapKey, psk string) error {
+	return c.runWithSecrets(&cliStatus{}, keyFlags(dhchapKey, psk), "host", "add", "--subsystem", nqn, "--host-nqn", hostNQN)
+}
+
+// changeHostKey sets the DH-HMAC-CHAP key of a host, or removes it if the key is empty
+func (c *gatewayCLI) changeHostKey(nqn, hostNQN, dhchapKey string) error {
+	return c.runWithSecrets(&cliStatus{}, keyFlags(dhchapKey, ""), "host", "change_key", "--subsystem", nqn, "--host-nqn", hostNQN)
+}
+
+func (c *gatewayCLI) deleteHost(nqn, hostNQN string) error {
+	return c.run(&cliStatus{}, "host", "del", "--subsystem", nqn, "--host-nqn", hostNQN)
+}
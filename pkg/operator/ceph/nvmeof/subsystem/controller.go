@@ -0,0 +1,396 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package subsystem manages the NVMe-oF subsystems exported by the NVMe-oF gateways
+package subsystem
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/nvmeof"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName   = "ceph-nvmeof-subsystem-controller"
+	gatewayNameIndex = "spec.gatewayName"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+var (
+	// waitForGatewayResult is returned while no instance of the gateway is ready
+	waitForGatewayResult = reconcile.Result{Requeue: true, RequeueAfter: 15 * time.Second}
+	// refreshStatusResult periodically refreshes the status since the gateways move the ANA groups
+	// between instances on failover and failback
+	refreshStatusResult = reconcile.Result{RequeueAfter: 5 * time.Minute}
+)
+
+// Sets the type meta for the controller main object
+var controllerTypeMeta = metav1.TypeMeta{
+	Kind:       reflect.TypeFor[cephv1.CephNVMeOFSubsystem]().Name(),
+	APIVersion: fmt.Sprintf("%s/%s", cephv1.CustomResourceGroup, cephv1.Version),
+}
+
+// ReconcileCephNVMeOFSubsystem reconciles a CephNVMeOFSubsystem object
+type ReconcileCephNVMeOFSubsystem struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	context          *clusterd.Context
+	opManagerContext context.Context
+}
+
+// Add creates a new CephNVMeOFSubsystem Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	if err := mgr.GetFieldIndexer().IndexField(opManagerContext, &cephv1.CephNVMeOFSubsystem{}, gatewayNameIndex, func(obj client.Object) []string {
+		s, ok := obj.(*cephv1.CephNVMeOFSubsystem)
+		if !ok {
+			return nil
+		}
+		return []string{s.Spec.GatewayName}
+	}); err != nil {
+		return errors.Wrapf(err, "failed to index CephNVMeOFSubsystem by %s", gatewayNameIndex)
+	}
+	return add(mgr, newReconciler(mgr, context, opManagerContext))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context) reconcile.Reconciler {
+	return &ReconcileCephNVMeOFSubsystem{
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		context:          context,
+		opManagerContext: opManagerContext,
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the CephNVMeOFSubsystem CRD object
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephNVMeOFSubsystem{TypeMeta: controllerTypeMeta},
+			&handler.TypedEnqueueRequestForObject[*cephv1.CephNVMeOFSubsystem]{},
+			opcontroller.WatchControllerPredicate[*cephv1.CephNVMeOFSubsystem](mgr.GetScheme()),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch the deployments of the gateway instances so that the listeners follow the instances
+	// when they are added, removed or restarted with a new address
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&appsv1.Deployment{TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: appsv1.SchemeGroupVersion.String()}},
+			handler.TypedEnqueueRequestsFromMapFunc(subsystemsOfGateway(mgr.GetClient())),
+			predicate.TypedFuncs[*appsv1.Deployment]{
+				CreateFunc: func(e event.TypedCreateEvent[*appsv1.Deployment]) bool {
+					return isGatewayDeployment(e.Object)
+				},
+				UpdateFunc: func(e event.TypedUpdateEvent[*appsv1.Deployment]) bool {
+					return isGatewayDeployment(e.ObjectNew) && !reflect.DeepEqual(e.ObjectOld.Status, e.ObjectNew.Status)
+				},
+				DeleteFunc: func(e event.TypedDeleteEvent[*appsv1.Deployment]) bool {
+					return isGatewayDeployment(e.Object)
+				},
+				GenericFunc: func(e event.TypedGenericEvent[*appsv1.Deployment]) bool {
+					return false
+				},
+			},
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch the secrets of the keys so that rotated keys are set on the gateway
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&v1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: v1.SchemeGroupVersion.String()}},
+			handler.TypedEnqueueRequestsFromMapFunc(subsystemsOfSecret(mgr.GetClient())),
+			predicate.TypedFuncs[*v1.Secret]{
+				UpdateFunc: func(e event.TypedUpdateEvent[*v1.Secret]) bool {
+					return !reflect.DeepEqual(e.ObjectOld.Data, e.ObjectNew.Data)
+				},
+			},
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// subsystemsOfSecret maps a secret to the subsystems that reference it for their keys
+func subsystemsOfSecret(c client.Client) handler.TypedMapFunc[*v1.Secret, reconcile.Request] {
+	return func(ctx context.Context, secret *v1.Secret) []reconcile.Request {
+		subsystems := &cephv1.CephNVMeOFSubsystemList{}
+		if err := c.List(ctx, subsystems, client.InNamespace(secret.Namespace)); err != nil {
+			logger.Errorf("failed to list nvmeof subsystems. %v", err)
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for i := range subsystems.Items {
+			if slices.Contains(secretNames(&subsystems.Items[i]), secret.Name) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: secret.Namespace, Name: subsystems.Items[i].Name}})
+			}
+		}
+		return requests
+	}
+}
+
+func isGatewayDeployment(d *appsv1.Deployment) bool {
+	return d.GetLabels()[k8sutil.AppAttr] == nvmeof.AppName
+}
+
+// subsystemsOfGateway maps a gateway deployment to the subsystems exported by the gateway
+func subsystemsOfGateway(c client.Client) handler.TypedMapFunc[*appsv1.Deployment, reconcile.Request] {
+	return func(ctx context.Context, d *appsv1.Deployment) []reconcile.Request {
+		gatewayName := d.GetLabels()[k8sutil.PartOfLabelKey]
+		if gatewayName == "" {
+			return nil
+		}
+		subsystems := &cephv1.CephNVMeOFSubsystemList{}
+		err := c.List(ctx, subsystems, client.InNamespace(d.Namespace), client.MatchingFields{gatewayNameIndex: gatewayName})
+		if err != nil {
+			logger.Errorf("failed to list subsystems of gateway %q. %v", gatewayName, err)
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, s := range subsystems.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: s.Namespace, Name: s.Name}})
+		}
+		return requests
+	}
+}
+
+// Reconcile reads the state of the cluster for a CephNVMeOFSubsystem object and makes changes based on the state read
+// and what is in the CephNVMeOFSubsystem.Spec
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileCephNVMeOFSubsystem) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	defer opcontroller.RecoverAndLogException()
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, err := r.reconcile(request)
+	if err != nil {
+		log.NamedError(request.NamespacedName, logger, "failed to reconcile %q. %v", request.NamespacedName, err)
+	}
+
+	return reconcileResponse, err
+}
+
+func (r *ReconcileCephNVMeOFSubsystem) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	// Fetch the CephNVMeOFSubsystem instance
+	subsystem := &cephv1.CephNVMeOFSubsystem{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, subsystem)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			log.NamedDebug(request.NamespacedName, logger, "CephNVMeOFSubsystem resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, errors.Wrap(err, "failed to get CephNVMeOFSubsystem")
+	}
+	// update observedGeneration local variable with current generation value,
+	// because generation can be changed before reconcile got completed
+	// CR status will be updated at end of reconcile, so to reflect the reconcile has finished
+	observedGeneration := subsystem.ObjectMeta.Generation
+
+	// Set a finalizer so we can do cleanup before the object goes away
+	generationUpdated, err := opcontroller.AddFinalizerIfNotPresent(r.opManagerContext, r.client, subsystem)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to add finalizer")
+	}
+	if generationUpdated {
+		log.NamedInfo(request.NamespacedName, logger, "reconciling the nvmeof subsystem after adding finalizer")
+		return reconcile.Result{}, nil
+	}
+
+	// The CR was just created, initializing status fields
+	if subsystem.Status == nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, cephv1.ConditionProgressing, "", nil)
+	}
+
+	// Make sure a CephCluster is present otherwise do nothing
+	_, isReadyToReconcile, cephClusterExists, reconcileResponse := opcontroller.IsReadyToReconcile(r.opManagerContext, r.client, request.NamespacedName, controllerName)
+	if !isReadyToReconcile {
+		// This handles the case where the Ceph Cluster is gone and we want to delete that CR
+		if !subsystem.GetDeletionTimestamp().IsZero() && !cephClusterExists {
+			err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, subsystem)
+			if err != nil {
+				return opcontroller.ImmediateRetryResult, errors.Wrap(err, "failed to remove finalizer")
+			}
+			return reconcile.Result{}, nil
+		}
+		return reconcileResponse, nil
+	}
+
+	gateway := &cephv1.CephNVMeOFGateway{}
+	err = r.client.Get(r.opManagerContext, types.NamespacedName{Namespace: subsystem.Namespace, Name: subsystem.Spec.GatewayName}, gateway)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to get CephNVMeOFGateway %q", subsystem.Spec.GatewayName)
+	}
+	gatewayExists := err == nil
+
+	var instances []gatewayInstance
+	if gatewayExists {
+		instances, err = r.gatewayInstances(gateway)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// DELETE: the CR was deleted
+	if !subsystem.GetDeletionTimestamp().IsZero() {
+		log.NamedDebug(request.NamespacedName, logger, "deleting nvmeof subsystem")
+		// The subsystem is gone with the gateway, the subsystems of a gateway that is being deleted
+		// do not need to be cleaned up either
+		if gatewayExists && gateway.GetDeletionTimestamp().IsZero() {
+			if len(instances) == 0 {
+				log.NamedInfo(request.NamespacedName, logger, "waiting for an instance of gateway %q to be ready to delete the subsystem", gateway.Name)
+				return waitForGatewayResult, nil
+			}
+			if err := r.deleteSubsystem(subsystem, instances); err != nil {
+				return reconcile.Result{}, errors.Wrapf(err, "failed to delete nvmeof subsystem %q", getSubsystemNQN(subsystem))
+			}
+		}
+
+		// Remove finalizer
+		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, subsystem)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to remove finalizer")
+		}
+
+		// Return and do not requeue. Successful deletion.
+		return reconcile.Result{}, nil
+	}
+
+	if !gatewayExists {
+		msg := fmt.Sprintf("CephNVMeOFGateway %q not found", subsystem.Spec.GatewayName)
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, cephv1.ConditionProgressing, msg, nil)
+		log.NamedInfo(request.NamespacedName, logger, "%s, waiting for it to be created", msg)
+		return waitForGatewayResult, nil
+	}
+	if len(instances) == 0 {
+		msg := fmt.Sprintf("no instance of CephNVMeOFGateway %q is ready", gateway.Name)
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, cephv1.ConditionProgressing, msg, nil)
+		log.NamedInfo(request.NamespacedName, logger, "%s, waiting for it to be ready", msg)
+		return waitForGatewayResult, nil
+	}
+
+	keys, err := r.getKeys(subsystem)
+	if err == nil {
+		err = validateKeys(subsystem, gateway)
+	}
+	if err != nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, cephv1.ConditionFailure, err.Error(), nil)
+		return reconcile.Result{}, errors.Wrap(err, "invalid keys")
+	}
+
+	keyStatus, err := r.reconcileSubsystem(subsystem, keys, instances)
+	if err != nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, cephv1.ConditionFailure, err.Error(), nil)
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile nvmeof subsystem %q", getSubsystemNQN(subsystem))
+	}
+
+	status, err := r.buildStatus(subsystem, instances)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get the state of the subsystem")
+	}
+	status.Hosts = keyStatus.Hosts
+	status.DHCHAPKeyHash = keyStatus.DHCHAPKeyHash
+	r.updateStatus(observedGeneration, request.NamespacedName, cephv1.ConditionReady, "", status)
+
+	// Return and requeue to refresh the placement of the namespaces
+	log.NamedDebug(request.NamespacedName, logger, "done reconciling nvmeof subsystem")
+	return refreshStatusResult, nil
+}
+
+// updateStatus updates an object with a given status. The namespaces, listeners and gateways are
+// only updated when the state of the subsystem is passed.
+func (r *ReconcileCephNVMeOFSubsystem) updateStatus(observedGeneration int64, name types.NamespacedName, phase cephv1.ConditionType, message string, state *cephv1.NVMeOFSubsystemStatus) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		subsystem := &cephv1.CephNVMeOFSubsystem{}
+		if err := r.client.Get(r.opManagerContext, name, subsystem); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamedDebug(name, logger, "CephNVMeOFSubsystem not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve nvmeof subsystem %q to update status to %q", name, phase)
+		}
+		if subsystem.Status == nil {
+			subsystem.Status = &cephv1.NVMeOFSubsystemStatus{}
+		}
+		if state != nil {
+			state.ObservedGeneration = subsystem.Status.ObservedGeneration
+			subsystem.Status = state
+		}
+
+		subsystem.Status.Phase = phase
+		subsystem.Status.Message = message
+		subsystem.Status.NQN = getSubsystemNQN(subsystem)
+		if observedGeneration != k8sutil.ObservedGenerationNotAvailable {
+			subsystem.Status.ObservedGeneration = observedGeneration
+		}
+		if err := reporting.UpdateStatus(r.client, subsystem); err != nil {
+			return errors.Wrapf(err, "failed to set nvmeof subsystem %q status to %q", name, phase)
+		}
+		return nil
+	})
+	if err != nil {
+		log.NamedError(name, logger, "failed to update nvmeof subsystem status to %q after retries. %v", phase, err)
+		return
+	}
+	log.NamedDebug(name, logger, "nvmeof subsystem status updated to %q", phase)
+}
@@ -0,0 +1,536 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subsystem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	testop "github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	namespace   = "rook-ceph"
+	gatewayName = "nvmeof"
+	nqn         = "nqn.2016-06.io.rook:rook-ceph.my-subsystem"
+)
+
+// fakeGateway keeps the state of a gateway group and answers the nvmeof CLI commands
+type fakeGateway struct {
+	groups     map[string]int32
+	subsystems []string
+	namespaces []cliNamespace
+	hosts      []string
+	hostKeys   map[string]hostKeys
+	subsysKey  string
+	anyHost    bool
+	listeners  []cliListener
+	commands   []string
+	// addresses are the gRPC addresses the CLI was called on
+	addresses []string
+}
+
+func newFakeGateway() *fakeGateway {
+	return &fakeGateway{groups: map[string]int32{}, hostKeys: map[string]hostKeys{}}
+}
+
+func flag(args []string, name string) string {
+	i := slices.Index(args, name)
+	if i < 0 || i+1 >= len(args) {
+		return ""
+	}
+	return args[i+1]
+}
+
+func toJSON(v interface{}) string {
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+func (g *fakeGateway) run(ctx context.Context, clusterdContext *clusterd.Context, pod *v1.Pod, secrets []secretFlag, args ...string) (string, error) {
+	g.addresses = append(g.addresses, flag(args, "--server-address"))
+	// skip the connection flags
+	for strings.HasPrefix(args[0], "--") {
+		args = args[2:]
+	}
+	for _, s := range secrets {
+		args = append(args, s.name, s.value)
+	}
+	command := args[0] + " " + args[1]
+	if args[1] != "list" && args[1] != "info" {
+		g.commands = append(g.commands, command)
+	}
+	nsid := func() int32 {
+		n, _ := strconv.Atoi(flag(args, "--nsid"))
+		return int32(n)
+	}
+
+	switch command {
+	case "gw info":
+		return toJSON(cliGatewayInfo{Name: "rook-ceph-nvmeof-" + pod.Labels["ceph_daemon_id"], LoadBalancingGroup: g.groups[pod.Name]}), nil
+	case "subsystem list":
+		subsystems := cliSubsystems{}
+		for _, nqn := range g.subsystems {
+			subsystems.Subsystems = append(subsystems.Subsystems, cliSubsystem{NQN: nqn})
+		}
+		return toJSON(subsystems), nil
+	case "subsystem add":
+		g.subsystems = append(g.subsystems, flag(args, "--subsystem"))
+		g.subsysKey = flag(args, "--dhchap-key")
+	case "subsystem change_key":
+		g.subsysKey = flag(args, "--dhchap-key")
+	case "subsystem del":
+		g.subsystems = slices.DeleteFunc(g.subsystems, func(s string) bool { return s == flag(args, "--subsystem") })
+	case "namespace list":
+		return toJSON(cliNamespaces{Namespaces: g.namespaces}), nil
+	case "namespace add":
+		if slices.ContainsFunc(g.namespaces, func(n cliNamespace) bool { return n.Image == flag(args, "--rbd-image") }) {
+			return toJSON(cliStatus{Status: 17, ErrorMessage: "namespace already exists"}), nil
+		}
+		ns := cliNamespace{NSID: nsid(), Pool: flag(args, "--rbd-pool"), Image: flag(args, "--rbd-image"), LoadBalancingGroup: 1}
+		if ns.NSID == 0 {
+			ns.NSID = int32(len(g.namespaces) + 1)
+		}
+		if lb := flag(args, "--load-balancing-group"); lb != "" {
+			n, _ := strconv.Atoi(lb)
+			ns.LoadBalancingGroup = int32(n)
+		}
+		g.namespaces = append(g.namespaces, ns)
+	case "namespace del":
+		g.namespaces = slices.DeleteFunc(g.namespaces, func(n cliNamespace) bool { return n.NSID == nsid() })
+	case "namespace change_load_balancing_group":
+		for i := range g.namespaces {
+			if g.namespaces[i].NSID == nsid() {
+				n, _ := strconv.Atoi(flag(args, "--load-balancing-group"))
+				g.namespaces[i].LoadBalancingGroup = int32(n)
+			}
+		}
+	case "host list":
+		hosts := cliHosts{AllowAnyHost: g.anyHost}
+		for _, h := range g.hosts {
+			hosts.Hosts = append(hosts.Hosts, cliHost{NQN: h, UseDHCHAP: g.hostKeys[h].dhchapKey != "", UsePSK: g.hostKeys[h].psk != ""})
+		}
+		return toJSON(hosts), nil
+	case "host add":
+		if flag(args, "--host-nqn") == anyHostNQN {
+			g.anyHost = true
+		} else {
+			g.hosts = append(g.hosts, flag(args, "--host-nqn"))
+			g.hostKeys[flag(args, "--host-nqn")] = hostKeys{dhchapKey: flag(args, "--dhchap-key"), psk: flag(args, "--psk")}
+		}
+	case "host change_key":
+		g.hostKeys[flag(args, "--host-nqn")] = hostKeys{dhchapKey: flag(args, "--dhchap-key"), psk: g.hostKeys[flag(args, "--host-nqn")].psk}
+	case "host del":
+		if flag(args, "--host-nqn") == anyHostNQN {
+			g.anyHost = false
+		} else {
+			g.hosts = slices.DeleteFunc(g.hosts, func(h string) bool { return h == flag(args, "--host-nqn") })
+			delete(g.hostKeys, flag(args, "--host-nqn"))
+		}
+	case "listener list":
+		return toJSON(cliListeners{Listeners: g.listeners}), nil
+	case "listener add":
+		port, _ := strconv.Atoi(flag(args, "--trsvcid"))
+		g.listeners = append(g.listeners, cliListener{HostName: flag(args, "--host-name"), AddressFamily: flag(args, "--adrfam"), Address: flag(args, "--traddr"), Port: int32(port), Secure: slices.Contains(args, "--secure")})
+	case "listener del":
+		g.listeners = slices.DeleteFunc(g.listeners, func(l cliListener) bool { return l.Address == flag(args, "--traddr") })
+	default:
+		return "", fmt.Errorf("unexpected command %v", args)
+	}
+	return toJSON(cliStatus{}), nil
+}
+
+func gatewayPod(id, ip string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rook-ceph-nvmeof-nvmeof-" + id + "-5d8f",
+			Namespace: namespace,
+			Labels: map[string]string{
+				k8sutil.AppAttr:        "rook-ceph-nvmeof",
+				k8sutil.PartOfLabelKey: gatewayName,
+				"ceph_daemon_id":       gatewayName + "-" + id,
+			},
+		},
+		Status: v1.PodStatus{
+			PodIP:      ip,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func TestCephNVMeOFSubsystemController(t *testing.T) {
+	ctx := context.TODO()
+	s := scheme.Scheme
+
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace, Namespace: namespace},
+		Status: cephv1.ClusterStatus{
+			Phase:      cephv1.ConditionReady,
+			CephStatus: &cephv1.CephStatus{Health: "HEALTH_OK"},
+		},
+	}
+	gateway := &cephv1.CephNVMeOFGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: namespace},
+		Spec:       cephv1.NVMeOFGatewaySpec{Group: "group-a", Instances: 2},
+	}
+	size := resource.MustParse("10Gi")
+	subsystem := &cephv1.CephNVMeOFSubsystem{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-subsystem",
+			Namespace: namespace,
+		},
+		Spec: cephv1.NVMeOFSubsystemSpec{
+			GatewayName: gatewayName,
+			Namespaces: []cephv1.NVMeOFNamespaceSpec{
+				{Pool: "rbd", Image: "image-a"},
+				{Pool: "rbd", Image: "image-b", Size: &size, LoadBalancingGroup: 2},
+			},
+			Hosts: []cephv1.NVMeOFHostSpec{{NQN: "nqn.2014-08.org.nvmexpress:uuid:host-1"}},
+		},
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: subsystem.Name, Namespace: namespace}}
+
+	fakeGW := newFakeGateway()
+	originalRunGatewayCLI := runGatewayCLI
+	runGatewayCLI = fakeGW.run
+	defer func() { runGatewayCLI = originalRunGatewayCLI }()
+
+	clusterdContext := &clusterd.Context{Clientset: testop.New(t, 1)}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(cephCluster, subsystem).WithStatusSubresource(subsystem).Build()
+	r := &ReconcileCephNVMeOFSubsystem{client: cl, scheme: s, context: clusterdContext, opManagerContext: ctx}
+
+	getSubsystem := func() *cephv1.CephNVMeOFSubsystem {
+		current := &cephv1.CephNVMeOFSubsystem{}
+		require.NoError(t, cl.Get(ctx, req.NamespacedName, current))
+		return current
+	}
+
+	t.Run("gateway does not exist", func(t *testing.T) {
+		// the first reconcile adds the finalizer
+		_, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Len(t, getSubsystem().Finalizers, 1)
+
+		res, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, waitForGatewayResult, res)
+		current := getSubsystem()
+		assert.Equal(t, cephv1.ConditionProgressing, current.Status.Phase)
+		assert.Contains(t, current.Status.Message, "not found")
+	})
+
+	t.Run("no gateway instance is ready", func(t *testing.T) {
+		require.NoError(t, cl.Create(ctx, gateway))
+		pod := gatewayPod("a", "10.0.0.1")
+		pod.Status.Conditions[0].Status = v1.ConditionFalse
+		_, err := clusterdContext.Clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		res, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, waitForGatewayResult, res)
+		assert.Empty(t, fakeGW.commands)
+	})
+
+	t.Run("subsystem is created", func(t *testing.T) {
+		pod := gatewayPod("a", "10.0.0.1")
+		_, err := clusterdContext.Clientset.CoreV1().Pods(namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		_, err = clusterdContext.Clientset.CoreV1().Pods(namespace).Create(ctx, gatewayPod("b", "10.0.0.2"), metav1.CreateOptions{})
+		require.NoError(t, err)
+		fakeGW.groups = map[string]int32{"rook-ceph-nvmeof-nvmeof-a-5d8f": 1, "rook-ceph-nvmeof-nvmeof-b-5d8f": 2}
+
+		res, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, refreshStatusResult, res)
+		assert.Equal(t, []string{nqn}, fakeGW.subsystems)
+		assert.Equal(t, []string{"nqn.2014-08.org.nvmexpress:uuid:host-1"}, fakeGW.hosts)
+		assert.Equal(t, []cliListener{
+			{HostName: "rook-ceph-nvmeof-nvmeof-a", AddressFamily: "ipv4", Address: "10.0.0.1", Port: 4420},
+			{HostName: "rook-ceph-nvmeof-nvmeof-b", AddressFamily: "ipv4", Address: "10.0.0.2", Port: 4420},
+		}, fakeGW.listeners)
+
+		current := getSubsystem()
+		assert.Equal(t, cephv1.ConditionReady, current.Status.Phase)
+		assert.Equal(t, nqn, current.Status.NQN)
+		assert.Equal(t, []cephv1.NVMeOFNamespaceStatus{
+			{NSID: 1, Pool: "rbd", Image: "image-a", ANAGroup: 1, Gateway: "rook-ceph-nvmeof-nvmeof-a"},
+			{NSID: 2, Pool: "rbd", Image: "image-b", ANAGroup: 2, Gateway: "rook-ceph-nvmeof-nvmeof-b"},
+		}, current.Status.Namespaces)
+		assert.Equal(t, []cephv1.NVMeOFGatewayInstanceStatus{
+			{Name: "rook-ceph-nvmeof-nvmeof-a", ANAGroup: 1, Namespaces: 1},
+			{Name: "rook-ceph-nvmeof-nvmeof-b", ANAGroup: 2, Namespaces: 1},
+		}, current.Status.Gateways)
+		assert.Len(t, current.Status.Listeners, 2)
+	})
+
+	t.Run("reconcile again is a no-op", func(t *testing.T) {
+		fakeGW.commands = nil
+		_, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Empty(t, fakeGW.commands)
+	})
+
+	t.Run("no key is changed without a status", func(t *testing.T) {
+		current := getSubsystem()
+		current.Status = nil
+		require.NoError(t, cl.Status().Update(ctx, current))
+
+		fakeGW.commands = nil
+		_, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.NotContains(t, fakeGW.commands, "subsystem change_key")
+	})
+
+	t.Run("spec changes are applied", func(t *testing.T) {
+		current := getSubsystem()
+		current.Spec.Namespaces = []cephv1.NVMeOFNamespaceSpec{{Pool: "rbd", Image: "image-b", LoadBalancingGroup: 1}}
+		current.Spec.Hosts = nil
+		current.Spec.AllowAnyHost = true
+		require.NoError(t, cl.Update(ctx, current))
+
+		fakeGW.commands = nil
+		_, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"namespace del", "namespace change_load_balancing_group", "host add"}, fakeGW.commands)
+		assert.Equal(t, []cliNamespace{{NSID: 2, Pool: "rbd", Image: "image-b", LoadBalancingGroup: 1}}, fakeGW.namespaces)
+		assert.True(t, fakeGW.anyHost)
+
+		current = getSubsystem()
+		assert.Equal(t, []cephv1.NVMeOFGatewayInstanceStatus{
+			{Name: "rook-ceph-nvmeof-nvmeof-a", ANAGroup: 1, Namespaces: 1},
+			{Name: "rook-ceph-nvmeof-nvmeof-b", ANAGroup: 2, Namespaces: 0},
+		}, current.Status.Gateways)
+	})
+
+	t.Run("listeners follow the instances", func(t *testing.T) {
+		// instance b restarted with a new address
+		pod := gatewayPod("b", "10.0.0.3")
+		_, err := clusterdContext.Clientset.CoreV1().Pods(namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		// instance a is restarting, its listener is kept while its deployment exists
+		pod = gatewayPod("a", "")
+		_, err = clusterdContext.Clientset.CoreV1().Pods(namespace).Update(ctx, pod, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "rook-ceph-nvmeof-nvmeof-a", Namespace: namespace}}
+		_, err = clusterdContext.Clientset.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		_, err = r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []cliListener{
+			{HostName: "rook-ceph-nvmeof-nvmeof-a", AddressFamily: "ipv4", Address: "10.0.0.1", Port: 4420},
+			{HostName: "rook-ceph-nvmeof-nvmeof-b", AddressFamily: "ipv4", Address: "10.0.0.3", Port: 4420},
+		}, fakeGW.listeners)
+
+		// instance a was removed
+		err = clusterdContext.Clientset.AppsV1().Deployments(namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{})
+		require.NoError(t, err)
+		_, err = r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []cliListener{
+			{HostName: "rook-ceph-nvmeof-nvmeof-b", AddressFamily: "ipv4", Address: "10.0.0.3", Port: 4420},
+		}, fakeGW.listeners)
+	})
+
+	t.Run("CLI failure is reported", func(t *testing.T) {
+		current := getSubsystem()
+		current.Spec.Namespaces = append(current.Spec.Namespaces, cephv1.NVMeOFNamespaceSpec{Pool: "other", Image: "image-b"})
+		require.NoError(t, cl.Update(ctx, current))
+
+		_, err := r.Reconcile(ctx, req)
+		assert.ErrorContains(t, err, "namespace already exists")
+		current = getSubsystem()
+		assert.Equal(t, cephv1.ConditionFailure, current.Status.Phase)
+		assert.Contains(t, current.Status.Message, "namespace already exists")
+
+		current.Spec.Namespaces = current.Spec.Namespaces[:1]
+		require.NoError(t, cl.Update(ctx, current))
+	})
+
+	t.Run("subsystem is deleted", func(t *testing.T) {
+		require.NoError(t, cl.Delete(ctx, getSubsystem()))
+
+		_, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Empty(t, fakeGW.subsystems)
+		err = cl.Get(ctx, req.NamespacedName, &cephv1.CephNVMeOFSubsystem{})
+		assert.True(t, kerrors.IsNotFound(err))
+	})
+}
+
+func TestCephNVMeOFSubsystemKeys(t *testing.T) {
+	ctx := context.TODO()
+	s := scheme.Scheme
+
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace, Namespace: namespace},
+		Status: cephv1.ClusterStatus{
+			Phase:      cephv1.ConditionReady,
+			CephStatus: &cephv1.CephStatus{Health: "HEALTH_OK"},
+		},
+	}
+	gateway := &cephv1.CephNVMeOFGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: gatewayName, Namespace: namespace},
+		Spec:       cephv1.NVMeOFGatewaySpec{Group: "group-a", Instances: 1},
+	}
+	host := "nqn.2014-08.org.nvmexpress:uuid:host-1"
+	keyRef := func(key string) *v1.SecretKeySelector {
+		return &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "host-keys"}, Key: key}
+	}
+	subsystem := &cephv1.CephNVMeOFSubsystem{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-subsystem", Namespace: namespace},
+		Spec: cephv1.NVMeOFSubsystemSpec{
+			GatewayName: gatewayName,
+			Hosts:       []cephv1.NVMeOFHostSpec{{NQN: host, DHCHAPKey: keyRef("host-1")}},
+			DHCHAPKey:   keyRef("subsystem"),
+		},
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: subsystem.Name, Namespace: namespace}}
+
+	fakeGW := newFakeGateway()
+	originalRunGatewayCLI := runGatewayCLI
+	runGatewayCLI = fakeGW.run
+	defer func() { runGatewayCLI = originalRunGatewayCLI }()
+
+	clusterdContext := &clusterd.Context{Clientset: testop.New(t, 1)}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "host-keys", Namespace: namespace},
+		Data: map[string][]byte{
+			"host-1":    []byte("DHHC-1:00:host-key-1:\n"),
+			"subsystem": []byte("DHHC-1:00:subsystem-key:"),
+		},
+	}
+	_, err := clusterdContext.Clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clusterdContext.Clientset.CoreV1().Pods(namespace).Create(ctx, gatewayPod("a", "10.0.0.1"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(cephCluster, gateway, subsystem).WithStatusSubresource(subsystem).Build()
+	r := &ReconcileCephNVMeOFSubsystem{client: cl, scheme: s, context: clusterdContext, opManagerContext: ctx}
+
+	getSubsystem := func() *cephv1.CephNVMeOFSubsystem {
+		current := &cephv1.CephNVMeOFSubsystem{}
+		require.NoError(t, cl.Get(ctx, req.NamespacedName, current))
+		return current
+	}
+
+	t.Run("keys require gateway security settings", func(t *testing.T) {
+		_, err := r.Reconcile(ctx, req)
+		assert.ErrorContains(t, err, "must have security settings")
+		assert.Equal(t, cephv1.ConditionFailure, getSubsystem().Status.Phase)
+		assert.Empty(t, fakeGW.commands)
+	})
+
+	t.Run("keys are set with mTLS", func(t *testing.T) {
+		gw := &cephv1.CephNVMeOFGateway{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: gatewayName}, gw))
+		gw.Spec.Security = &cephv1.NVMeOFSecuritySpec{MTLS: &cephv1.NVMeOFMTLSSpec{}}
+		require.NoError(t, cl.Update(ctx, gw))
+
+		_, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, "DHHC-1:00:subsystem-key:", fakeGW.subsysKey)
+		assert.Equal(t, hostKeys{dhchapKey: "DHHC-1:00:host-key-1:"}, fakeGW.hostKeys[host])
+		assert.False(t, fakeGW.listeners[0].Secure)
+		assert.Equal(t, "rook-ceph-nvmeof-nvmeof-a.rook-ceph.svc", fakeGW.addresses[len(fakeGW.addresses)-1])
+
+		current := getSubsystem()
+		assert.Equal(t, cephv1.ConditionReady, current.Status.Phase)
+		assert.Equal(t, keyHash("DHHC-1:00:subsystem-key:"), current.Status.DHCHAPKeyHash)
+		assert.Equal(t, []cephv1.NVMeOFHostStatus{{NQN: host, DHCHAPKeyHash: keyHash("DHHC-1:00:host-key-1:")}}, current.Status.Hosts)
+
+		fakeGW.commands = nil
+		_, err = r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Empty(t, fakeGW.commands)
+	})
+
+	t.Run("rotated DH-HMAC-CHAP key is changed", func(t *testing.T) {
+		secret.Data["host-1"] = []byte("DHHC-1:00:host-key-2:")
+		_, err := clusterdContext.Clientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		fakeGW.commands = nil
+		_, err = r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"host change_key"}, fakeGW.commands)
+		assert.Equal(t, hostKeys{dhchapKey: "DHHC-1:00:host-key-2:"}, fakeGW.hostKeys[host])
+	})
+
+	t.Run("PSK re-adds the host and secures the listeners", func(t *testing.T) {
+		secret.Data["host-1-psk"] = []byte("NVMeTLSkey-1:01:psk:")
+		_, err := clusterdContext.Clientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		current := getSubsystem()
+		current.Spec.Hosts[0].PSK = keyRef("host-1-psk")
+		require.NoError(t, cl.Update(ctx, current))
+
+		fakeGW.commands = nil
+		_, err = r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"host del", "host add", "listener del", "listener add"}, fakeGW.commands)
+		assert.Equal(t, hostKeys{dhchapKey: "DHHC-1:00:host-key-2:", psk: "NVMeTLSkey-1:01:psk:"}, fakeGW.hostKeys[host])
+		assert.True(t, fakeGW.listeners[0].Secure)
+	})
+
+	t.Run("all hosts must have a PSK", func(t *testing.T) {
+		current := getSubsystem()
+		current.Spec.Hosts = append(current.Spec.Hosts, cephv1.NVMeOFHostSpec{NQN: "nqn.2014-08.org.nvmexpress:uuid:host-2"})
+		require.NoError(t, cl.Update(ctx, current))
+
+		_, err := r.Reconcile(ctx, req)
+		assert.ErrorContains(t, err, "all hosts must have a PSK")
+	})
+}
+
+func TestGetSubsystemNQN(t *testing.T) {
+	s := &cephv1.CephNVMeOFSubsystem{ObjectMeta: metav1.ObjectMeta{Name: "my-subsystem", Namespace: namespace}}
+	assert.Equal(t, nqn, getSubsystemNQN(s))
+	s.Spec.NQN = "nqn.2016-06.io.spdk:cnode1"
+	assert.Equal(t, "nqn.2016-06.io.spdk:cnode1", getSubsystemNQN(s))
+}
+
+func TestDesiredListeners(t *testing.T) {
+	listeners := desiredListeners([]gatewayInstance{
+		{name: "gw-a", address: "10.0.0.1", ioPort: 4420},
+		{name: "gw-b", address: "fd00::2", ioPort: 4421},
+	}, false)
+	assert.Equal(t, []cliListener{
+		{HostName: "gw-a", AddressFamily: "ipv4", Address: "10.0.0.1", Port: 4420},
+		{HostName: "gw-b", AddressFamily: "ipv6", Address: "fd00::2", Port: 4421},
+	}, listeners)
+}
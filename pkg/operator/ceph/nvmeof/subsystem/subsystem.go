@@ -0,0 +1,505 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subsystem
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/nvmeof"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// gatewayInstance is a running instance of a CephNVMeOFGateway
+type gatewayInstance struct {
+	name    string
+	pod     *v1.Pod
+	address string
+	ioPort  int32
+	// grpcAddress is the address the gRPC API is called on. With mutual TLS, this is the service
+	// name of the instance that the server certificate is valid for.
+	grpcAddress string
+	grpcPort    int32
+	mtls        bool
+}
+
+// subsystemKeys are the DH-HMAC-CHAP and PSK keys of a subsystem and its hosts
+type subsystemKeys struct {
+	dhchapKey string
+	hosts     map[string]hostKeys
+}
+
+type hostKeys struct {
+	dhchapKey string
+	psk       string
+}
+
+// keyHash returns the hash of a key recorded in the status, or an empty string if there is no key
+func keyHash(key string) string {
+	if key == "" {
+		return ""
+	}
+	return k8sutil.Hash(key)
+}
+
+// previousKeyHash returns the hash of the DH-HMAC-CHAP key last set on the subsystem. A subsystem
+// without a status had no key set by the operator.
+func previousKeyHash(s *cephv1.CephNVMeOFSubsystem) string {
+	if s.Status == nil {
+		return ""
+	}
+	return s.Status.DHCHAPKeyHash
+}
+
+// getSubsystemNQN returns the NQN of the subsystem
+func getSubsystemNQN(s *cephv1.CephNVMeOFSubsystem) string {
+	if s.Spec.NQN != "" {
+		return s.Spec.NQN
+	}
+	return fmt.Sprintf("nqn.2016-06.io.rook:%s.%s", s.Namespace, s.Name)
+}
+
+// gatewayInstances returns the ready instances of the gateway, sorted by name
+func (r *ReconcileCephNVMeOFSubsystem) gatewayInstances(gateway *cephv1.CephNVMeOFGateway) ([]gatewayInstance, error) {
+	pods, err := r.context.Clientset.CoreV1().Pods(gateway.Namespace).List(r.opManagerContext, metav1.ListOptions{LabelSelector: nvmeof.GatewayLabelSelector(gateway.Name)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods of gateway %q", gateway.Name)
+	}
+
+	ioPort, grpcPort := nvmeof.GatewayPorts(gateway)
+	mtls := nvmeof.MTLSEnabled(gateway)
+	instances := []gatewayInstance{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" || !isPodReady(pod) {
+			continue
+		}
+		instance := gatewayInstance{
+			name:        nvmeof.GatewayInstanceName(pod),
+			pod:         pod,
+			address:     pod.Status.PodIP,
+			ioPort:      ioPort,
+			grpcAddress: pod.Status.PodIP,
+			grpcPort:    grpcPort,
+			mtls:        mtls,
+		}
+		if mtls {
+			instance.grpcAddress = nvmeof.GatewayServiceAddress(pod)
+		}
+		instances = append(instances, instance)
+	}
+	slices.SortFunc(instances, func(a, b gatewayInstance) int { return strings.Compare(a.name, b.name) })
+	return instances, nil
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// validateKeys checks that the gateway can store the keys of the subsystem and that PSKs, which
+// require TLS on the listeners, are set for all the hosts
+func validateKeys(s *cephv1.CephNVMeOFSubsystem, gateway *cephv1.CephNVMeOFGateway) error {
+	usesKeys := s.Spec.DHCHAPKey != nil
+	hostsWithPSK := 0
+	for _, host := range s.Spec.Hosts {
+		if host.DHCHAPKey != nil || host.PSK != nil {
+			usesKeys = true
+		}
+		if host.PSK != nil {
+			hostsWithPSK++
+		}
+	}
+	if usesKeys && gateway.Spec.Security == nil {
+		return errors.Errorf("CephNVMeOFGateway %q must have security settings to use DH-HMAC-CHAP or PSK keys", gateway.Name)
+	}
+	if hostsWithPSK > 0 && (s.Spec.AllowAnyHost || hostsWithPSK != len(s.Spec.Hosts)) {
+		return errors.New("all hosts must have a PSK when any host has one, and allowAnyHost must not be set")
+	}
+	return nil
+}
+
+// getKeys reads the keys of the subsystem and its hosts from the referenced secrets
+func (r *ReconcileCephNVMeOFSubsystem) getKeys(s *cephv1.CephNVMeOFSubsystem) (*subsystemKeys, error) {
+	secrets := map[string]*v1.Secret{}
+	getKey := func(ref *v1.SecretKeySelector) (string, error) {
+		if ref == nil {
+			return "", nil
+		}
+		secret, ok := secrets[ref.Name]
+		if !ok {
+			var err error
+			secret, err = r.context.Clientset.CoreV1().Secrets(s.Namespace).Get(r.opManagerContext, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to get secret %q", ref.Name)
+			}
+			secrets[ref.Name] = secret
+		}
+		key := strings.TrimSpace(string(secret.Data[ref.Key]))
+		if key == "" {
+			return "", errors.Errorf("secret %q has no %q key", ref.Name, ref.Key)
+		}
+		return key, nil
+	}
+
+	keys := &subsystemKeys{hosts: map[string]hostKeys{}}
+	var err error
+	if keys.dhchapKey, err = getKey(s.Spec.DHCHAPKey); err != nil {
+		return nil, err
+	}
+	for _, host := range s.Spec.Hosts {
+		hk := hostKeys{}
+		if hk.dhchapKey, err = getKey(host.DHCHAPKey); err != nil {
+			return nil, errors.Wrapf(err, "failed to get DH-HMAC-CHAP key of host %q", host.NQN)
+		}
+		if hk.psk, err = getKey(host.PSK); err != nil {
+			return nil, errors.Wrapf(err, "failed to get PSK of host %q", host.NQN)
+		}
+		keys.hosts[host.NQN] = hk
+	}
+	return keys, nil
+}
+
+// secretNames returns the names of the secrets referenced by the keys of a subsystem
+func secretNames(s *cephv1.CephNVMeOFSubsystem) []string {
+	names := []string{}
+	if s.Spec.DHCHAPKey != nil {
+		names = append(names, s.Spec.DHCHAPKey.Name)
+	}
+	for _, host := range s.Spec.Hosts {
+		if host.DHCHAPKey != nil {
+			names = append(names, host.DHCHAPKey.Name)
+		}
+		if host.PSK != nil {
+			names = append(names, host.PSK.Name)
+		}
+	}
+	return names
+}
+
+func (r *ReconcileCephNVMeOFSubsystem) newGatewayCLI(instance gatewayInstance) *gatewayCLI {
+	return &gatewayCLI{ctx: r.opManagerContext, clusterdContext: r.context, gateway: instance}
+}
+
+// reconcileSubsystem creates the subsystem if needed and makes its keys, namespaces, hosts and
+// listeners match the spec. All the instances of a gateway group share their state, so the commands
+// are sent to the first instance and are applied by all of them. The returned status records the
+// hashes of the keys set on the gateway so that rotated keys are detected.
+func (r *ReconcileCephNVMeOFSubsystem) reconcileSubsystem(s *cephv1.CephNVMeOFSubsystem, keys *subsystemKeys, instances []gatewayInstance) (*cephv1.NVMeOFSubsystemStatus, error) {
+	nsName := opcontroller.NsName(s.Namespace, s.Name)
+	nqn := getSubsystemNQN(s)
+	cli := r.newGatewayCLI(instances[0])
+	status := &cephv1.NVMeOFSubsystemStatus{DHCHAPKeyHash: keyHash(keys.dhchapKey)}
+
+	subsystems, err := cli.listSubsystems()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list subsystems")
+	}
+	if !slices.ContainsFunc(subsystems, func(ss cliSubsystem) bool { return ss.NQN == nqn }) {
+		log.NamedInfo(nsName, logger, "creating nvmeof subsystem %q", nqn)
+		if err := cli.addSubsystem(nqn, s.Spec.SerialNumber, s.Spec.MaxNamespaces, keys.dhchapKey); err != nil {
+			return nil, errors.Wrapf(err, "failed to create subsystem %q", nqn)
+		}
+	} else if previousKeyHash(s) != status.DHCHAPKeyHash {
+		log.NamedInfo(nsName, logger, "updating the DH-HMAC-CHAP key of subsystem %q", nqn)
+		if err := cli.changeSubsystemKey(nqn, keys.dhchapKey); err != nil {
+			return nil, errors.Wrapf(err, "failed to change the key of subsystem %q", nqn)
+		}
+	}
+
+	if err := r.reconcileNamespaces(cli, s, nqn); err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile namespaces")
+	}
+	if status.Hosts, err = r.reconcileHosts(cli, s, keys, nqn); err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile hosts")
+	}
+	if err := r.reconcileListeners(cli, s, keys, nqn, instances); err != nil {
+		return nil, errors.Wrap(err, "failed to reconcile listeners")
+	}
+	return status, nil
+}
+
+func namespaceKey(pool, image string) string {
+	return pool + "/" + image
+}
+
+func (r *ReconcileCephNVMeOFSubsystem) reconcileNamespaces(cli *gatewayCLI, s *cephv1.CephNVMeOFSubsystem, nqn string) error {
+	nsName := opcontroller.NsName(s.Namespace, s.Name)
+	existing, err := cli.listNamespaces(nqn)
+	if err != nil {
+		return err
+	}
+
+	desired := map[string]cephv1.NVMeOFNamespaceSpec{}
+	for _, ns := range s.Spec.Namespaces {
+		desired[namespaceKey(ns.Pool, ns.Image)] = ns
+	}
+
+	found := map[string]bool{}
+	for _, ns := range existing {
+		key := namespaceKey(ns.Pool, ns.Image)
+		spec, ok := desired[key]
+		if !ok {
+			log.NamedInfo(nsName, logger, "removing namespace %d (%s) from subsystem %q", ns.NSID, key, nqn)
+			if err := cli.deleteNamespace(nqn, ns.NSID); err != nil {
+				return errors.Wrapf(err, "failed to remove namespace %d", ns.NSID)
+			}
+			continue
+		}
+		found[key] = true
+		if spec.NSID != 0 && spec.NSID != ns.NSID {
+			log.NamedWarning(nsName, logger, "namespace %q has nsid %d instead of %d, the nsid can only be set when the namespace is added", key, ns.NSID, spec.NSID)
+		}
+		if spec.LoadBalancingGroup != 0 && spec.LoadBalancingGroup != ns.LoadBalancingGroup {
+			log.NamedInfo(nsName, logger, "moving namespace %q from load balancing group %d to %d", key, ns.LoadBalancingGroup, spec.LoadBalancingGroup)
+			if err := cli.changeLoadBalancingGroup(nqn, ns.NSID, spec.LoadBalancingGroup); err != nil {
+				return errors.Wrapf(err, "failed to change load balancing group of namespace %q", key)
+			}
+		}
+	}
+
+	for _, ns := range s.Spec.Namespaces {
+		key := namespaceKey(ns.Pool, ns.Image)
+		if found[key] {
+			continue
+		}
+		var sizeMiB int64
+		if ns.Size != nil {
+			sizeMiB = ns.Size.Value() / (1024 * 1024)
+			if sizeMiB == 0 {
+				return errors.Errorf("size of namespace %q must be at least 1Mi", key)
+			}
+		}
+		log.NamedInfo(nsName, logger, "adding namespace %q to subsystem %q", key, nqn)
+		if err := cli.addNamespace(nqn, ns.Pool, ns.Image, ns.NSID, sizeMiB, ns.LoadBalancingGroup); err != nil {
+			return errors.Wrapf(err, "failed to add namespace %q", key)
+		}
+	}
+	return nil
+}
+
+// reconcileHosts adds and removes the hosts of the subsystem and updates the keys of the hosts
+// that changed since they were last set. A host is re-added when its PSK changes since the gateway
+// can only change the DH-HMAC-CHAP key of a host.
+func (r *ReconcileCephNVMeOFSubsystem) reconcileHosts(cli *gatewayCLI, s *cephv1.CephNVMeOFSubsystem, keys *subsystemKeys, nqn string) ([]cephv1.NVMeOFHostStatus, error) {
+	nsName := opcontroller.NsName(s.Namespace, s.Name)
+	existing, err := cli.listHosts(nqn)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Spec.AllowAnyHost {
+		if !existing.AllowAnyHost {
+			log.NamedInfo(nsName, logger, "allowing any host to connect to subsystem %q", nqn)
+			return nil, cli.addHost(nqn, anyHostNQN, "", "")
+		}
+		return nil, nil
+	}
+	if existing.AllowAnyHost {
+		log.NamedInfo(nsName, logger, "no longer allowing any host to connect to subsystem %q", nqn)
+		if err := cli.deleteHost(nqn, anyHostNQN); err != nil {
+			return nil, err
+		}
+	}
+
+	previous := map[string]cephv1.NVMeOFHostStatus{}
+	if s.Status != nil {
+		for _, h := range s.Status.Hosts {
+			previous[h.NQN] = h
+		}
+	}
+
+	for _, host := range existing.Hosts {
+		if !slices.ContainsFunc(s.Spec.Hosts, func(h cephv1.NVMeOFHostSpec) bool { return h.NQN == host.NQN }) {
+			log.NamedInfo(nsName, logger, "removing host %q from subsystem %q", host.NQN, nqn)
+			if err := cli.deleteHost(nqn, host.NQN); err != nil {
+				return nil, errors.Wrapf(err, "failed to remove host %q", host.NQN)
+			}
+		}
+	}
+
+	hosts := []cephv1.NVMeOFHostStatus{}
+	for _, host := range s.Spec.Hosts {
+		hk := keys.hosts[host.NQN]
+		hostStatus := cephv1.NVMeOFHostStatus{NQN: host.NQN, DHCHAPKeyHash: keyHash(hk.dhchapKey), PSKHash: keyHash(hk.psk)}
+		hosts = append(hosts, hostStatus)
+
+		i := slices.IndexFunc(existing.Hosts, func(h cliHost) bool { return h.NQN == host.NQN })
+		if i >= 0 {
+			prev, known := previous[host.NQN]
+			pskChanged := prev.PSKHash != hostStatus.PSKHash
+			dhchapChanged := prev.DHCHAPKeyHash != hostStatus.DHCHAPKeyHash
+			if !known {
+				// keys set before their hash was recorded cannot be compared, they are set again
+				pskChanged = existing.Hosts[i].UsePSK || hk.psk != ""
+				dhchapChanged = existing.Hosts[i].UseDHCHAP || hk.dhchapKey != ""
+			}
+			if !pskChanged {
+				if dhchapChanged {
+					log.NamedInfo(nsName, logger, "updating the DH-HMAC-CHAP key of host %q of subsystem %q", host.NQN, nqn)
+					if err := cli.changeHostKey(nqn, host.NQN, hk.dhchapKey); err != nil {
+						return nil, errors.Wrapf(err, "failed to change the key of host %q", host.NQN)
+					}
+				}
+				continue
+			}
+			log.NamedInfo(nsName, logger, "re-adding host %q to subsystem %q to update its PSK", host.NQN, nqn)
+			if err := cli.deleteHost(nqn, host.NQN); err != nil {
+				return nil, errors.Wrapf(err, "failed to remove host %q", host.NQN)
+			}
+		} else {
+			log.NamedInfo(nsName, logger, "adding host %q to subsystem %q", host.NQN, nqn)
+		}
+		if err := cli.addHost(nqn, host.NQN, hk.dhchapKey, hk.psk); err != nil {
+			return nil, errors.Wrapf(err, "failed to add host %q", host.NQN)
+		}
+	}
+	return hosts, nil
+}
+
+// desiredListeners returns a listener on the IO port of each gateway instance. The listeners require
+// TLS when the hosts connect with a PSK.
+func desiredListeners(instances []gatewayInstance, secure bool) []cliListener {
+	listeners := []cliListener{}
+	for _, instance := range instances {
+		family := "ipv4"
+		if strings.Contains(instance.address, ":") {
+			family = "ipv6"
+		}
+		listeners = append(listeners, cliListener{HostName: instance.name, AddressFamily: family, Address: instance.address, Port: instance.ioPort, Secure: secure})
+	}
+	return listeners
+}
+
+func sameListener(a, b cliListener) bool {
+	return a.HostName == b.HostName && a.Address == b.Address && a.Port == b.Port && a.Secure == b.Secure
+}
+
+// reconcileListeners adds a listener for each ready gateway instance and removes the listeners of
+// instances that moved to another address or no longer exist
+func (r *ReconcileCephNVMeOFSubsystem) reconcileListeners(cli *gatewayCLI, s *cephv1.CephNVMeOFSubsystem, keys *subsystemKeys, nqn string, instances []gatewayInstance) error {
+	nsName := opcontroller.NsName(s.Namespace, s.Name)
+	existing, err := cli.listListeners(nqn)
+	if err != nil {
+		return err
+	}
+	secure := slices.ContainsFunc(s.Spec.Hosts, func(h cephv1.NVMeOFHostSpec) bool { return keys.hosts[h.NQN].psk != "" })
+	desired := desiredListeners(instances, secure)
+
+	for _, l := range existing {
+		if slices.ContainsFunc(desired, func(d cliListener) bool { return sameListener(d, l) }) {
+			continue
+		}
+		// the instance may only be restarting, keep its listener until it has a new address
+		if !slices.ContainsFunc(desired, func(d cliListener) bool { return d.HostName == l.HostName }) && r.instanceExists(s.Namespace, l.HostName) {
+			continue
+		}
+		log.NamedInfo(nsName, logger, "removing listener %s:%d of gateway %q from subsystem %q", l.Address, l.Port, l.HostName, nqn)
+		if err := cli.deleteListener(nqn, l); err != nil {
+			return errors.Wrapf(err, "failed to remove listener %s:%d", l.Address, l.Port)
+		}
+	}
+	for _, l := range desired {
+		if slices.ContainsFunc(existing, func(e cliListener) bool { return sameListener(e, l) }) {
+			continue
+		}
+		log.NamedInfo(nsName, logger, "adding listener %s:%d of gateway %q to subsystem %q", l.Address, l.Port, l.HostName, nqn)
+		if err := cli.addListener(nqn, l); err != nil {
+			return errors.Wrapf(err, "failed to add listener %s:%d", l.Address, l.Port)
+		}
+	}
+	return nil
+}
+
+// instanceExists returns whether the deployment of a gateway instance still exists
+func (r *ReconcileCephNVMeOFSubsystem) instanceExists(namespace, instanceName string) bool {
+	_, err := r.context.Clientset.AppsV1().Deployments(namespace).Get(r.opManagerContext, instanceName, metav1.GetOptions{})
+	return err == nil
+}
+
+// buildStatus reports the namespaces and listeners of the subsystem, and the ANA group of each
+// gateway instance with the number of namespaces it serves
+func (r *ReconcileCephNVMeOFSubsystem) buildStatus(s *cephv1.CephNVMeOFSubsystem, instances []gatewayInstance) (*cephv1.NVMeOFSubsystemStatus, error) {
+	nsName := opcontroller.NsName(s.Namespace, s.Name)
+	nqn := getSubsystemNQN(s)
+	status := &cephv1.NVMeOFSubsystemStatus{NQN: nqn}
+
+	gatewayByGroup := map[int32]int{}
+	for _, instance := range instances {
+		gw := cephv1.NVMeOFGatewayInstanceStatus{Name: instance.name}
+		info, err := r.newGatewayCLI(instance).info()
+		if err != nil {
+			log.NamedWarning(nsName, logger, "failed to get the load balancing group of gateway %q. %v", instance.name, err)
+		} else {
+			gw.ANAGroup = info.LoadBalancingGroup
+			gatewayByGroup[info.LoadBalancingGroup] = len(status.Gateways)
+		}
+		status.Gateways = append(status.Gateways, gw)
+	}
+
+	cli := r.newGatewayCLI(instances[0])
+	namespaces, err := cli.listNamespaces(nqn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list namespaces")
+	}
+	for _, ns := range namespaces {
+		nsStatus := cephv1.NVMeOFNamespaceStatus{NSID: ns.NSID, Pool: ns.Pool, Image: ns.Image, ANAGroup: ns.LoadBalancingGroup}
+		if i, ok := gatewayByGroup[ns.LoadBalancingGroup]; ok {
+			nsStatus.Gateway = status.Gateways[i].Name
+			status.Gateways[i].Namespaces++
+		}
+		status.Namespaces = append(status.Namespaces, nsStatus)
+	}
+	slices.SortFunc(status.Namespaces, func(a, b cephv1.NVMeOFNamespaceStatus) int { return int(a.NSID - b.NSID) })
+
+	listeners, err := cli.listListeners(nqn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list listeners")
+	}
+	for _, l := range listeners {
+		status.Listeners = append(status.Listeners, cephv1.NVMeOFListenerStatus{Gateway: l.HostName, Address: l.Address, Port: l.Port})
+	}
+	return status, nil
+}
+
+// deleteSubsystem removes the subsystem with its namespaces, hosts and listeners from the gateway.
+// The RBD images are not deleted.
+func (r *ReconcileCephNVMeOFSubsystem) deleteSubsystem(s *cephv1.CephNVMeOFSubsystem, instances []gatewayInstance) error {
+	nqn := getSubsystemNQN(s)
+	cli := r.newGatewayCLI(instances[0])
+
+	subsystems, err := cli.listSubsystems()
+	if err != nil {
+		return errors.Wrap(err, "failed to list subsystems")
+	}
+	if !slices.ContainsFunc(subsystems, func(ss cliSubsystem) bool { return ss.NQN == nqn }) {
+		return nil
+	}
+	log.NamedInfo(opcontroller.NsName(s.Namespace, s.Name), logger, "deleting nvmeof subsystem %q", nqn)
+	return cli.deleteSubsystem(nqn)
+}
@@ -18,14 +18,17 @@ package object
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"syscall"
 
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/util/exec"
 	"github.com/rook/rook/pkg/util/log"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 // GetAccount retrieves account information from RGW using the admin ops API.
@@ -115,6 +118,52 @@ func ForceDeleteAccount(nsName types.NamespacedName, adminOpsContext *AdminOpsCo
 	return nil
 }
 
+// AccountStats is the storage consumption of an RGW account as reported by 'radosgw-admin account stats'
+type AccountStats struct {
+	Size       int64 `json:"size"`
+	SizeActual int64 `json:"size_actual"`
+	NumObjects int64 `json:"num_objects"`
+}
+
+// GetAccountStats returns the storage consumption of all the buckets owned by an RGW account
+func GetAccountStats(adminOpsContext *AdminOpsContext, accountID string) (*AccountStats, error) {
+	if accountID == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+
+	// The admin ops API does not report account stats yet
+	account := fmt.Sprintf("--account-id=%s", accountID)
+	output, err := runAdminCommand(&adminOpsContext.Context, true, "account", "stats", account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get stats of account %q. %s", accountID, output)
+	}
+
+	var result struct {
+		Stats AccountStats `json:"stats"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse stats of account %q", accountID)
+	}
+	return &result.Stats, nil
+}
+
+// SetUsageLogTotals sets the bytes sent and received and the operations recorded in the RGW usage
+// log for the owner of buckets, which is the ID of a user or of an account
+func SetUsageLogTotals(ctx context.Context, api *admin.API, owner string, usage *cephv1.UsageStatus) error {
+	usageLog, err := api.GetUsage(ctx, admin.Usage{UserID: owner, ShowEntries: ptr.To(false), ShowSummary: ptr.To(true)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get usage log of %q", owner)
+	}
+
+	usage.BytesSent, usage.BytesReceived, usage.Operations = 0, 0, 0
+	for _, summary := range usageLog.Summary {
+		usage.BytesSent += int64(summary.Total.BytesSent)
+		usage.BytesReceived += int64(summary.Total.BytesReceived)
+		usage.Operations += int64(summary.Total.Ops)
+	}
+	return nil
+}
+
 // CreateAccountRootUser creates a root user for the given RGW account using the admin ops API.
 func CreateAccountRootUser(ctx context.Context, adminOpsContext *AdminOpsContext, user admin.User) (admin.User, error) {
 	if user.ID == "" {
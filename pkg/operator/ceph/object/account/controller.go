@@ -85,7 +85,18 @@ type ReconcileObjectStoreAccount struct {
 // +kubebuilder:rbac:groups=ceph.rook.io,resources=cephobjectstoreaccounts/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ceph.rook.io,resources=cephobjectstoreaccounts/finalizers,verbs=update
 func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
-	return add(mgr, newReconciler(mgr, context, opManagerContext))
+	if err := add(mgr, newReconciler(mgr, context, opManagerContext)); err != nil {
+		return err
+	}
+
+	// The usage is collected separately from the reconcile of the accounts
+	return mgr.Add(&opcontroller.UsageCollector[*cephv1.CephObjectStoreAccount]{
+		Client:  mgr.GetClient(),
+		Context: context,
+		Kind:    controllerTypeMeta.Kind,
+		List:    &cephv1.CephObjectStoreAccountList{},
+		Collect: collectUsage,
+	})
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -101,7 +112,7 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -238,6 +249,7 @@ func (r *ReconcileObjectStoreAccount) reconcile(request reconcile.Request) (reco
 		if err != nil {
 			return reconcile.Result{}, *cephObjectStoreAccount, errors.Wrap(err, "failed to remove finalizer")
 		}
+		opcontroller.DeleteUsageMetrics(controllerTypeMeta.Kind, cephObjectStoreAccount)
 
 		// Return and do not requeue. Successful deletion.
 		return reconcile.Result{}, *cephObjectStoreAccount, nil
@@ -263,6 +275,41 @@ func (r *ReconcileObjectStoreAccount) reconcile(request reconcile.Request) (reco
 	return reconcile.Result{}, *cephObjectStoreAccount, nil
 }
 
+// collectUsage returns the capacity used by the buckets of the account and the traffic of the
+// account recorded in the RGW usage log
+func collectUsage(ctx context.Context, context *clusterd.Context, k8sClient client.Client, clusterInfo *cephclient.ClusterInfo, cephObjectStoreAccount *cephv1.CephObjectStoreAccount) (*cephv1.UsageStatus, error) {
+	accountID := getAccountID(cephObjectStoreAccount)
+	if accountID == "" {
+		return nil, errors.New("account is not created yet")
+	}
+	objContext, _, err := object.InitializeObjectStoreContext(context, clusterInfo, k8sClient, ctx, cephObjectStoreAccount.Spec.Store, newMultisiteAdminOpsCtxFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := object.GetAccountStats(objContext, accountID)
+	if err != nil {
+		return nil, err
+	}
+	usage := &cephv1.UsageStatus{
+		UsedBytes: stats.Size,
+		Objects:   stats.NumObjects,
+	}
+	account, err := object.GetAccount(ctx, objContext, accountID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get quota of account %q", accountID)
+	}
+	if account.Quota.Enabled != nil && *account.Quota.Enabled && account.Quota.MaxSize != nil && *account.Quota.MaxSize > 0 {
+		usage.QuotaBytes = *account.Quota.MaxSize
+	}
+
+	// the buckets created by the users of an account are owned by the account
+	if err := object.SetUsageLogTotals(ctx, objContext.AdminOpsClient, accountID, usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
 // getAccountName returns the effective account name from the CR spec,
 // falling back to the CR name if spec.Name is not set.
 func getAccountName(cephObjectStoreAccount *cephv1.CephObjectStoreAccount) string {
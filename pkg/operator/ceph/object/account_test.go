@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const accountStatsOutput = `{
+    "stats": {
+        "size": 2048,
+        "size_actual": 8192,
+        "size_kb": 2,
+        "size_kb_actual": 8,
+        "num_objects": 2
+    },
+    "last_stats_sync": "2026-01-01T00:00:00.000000Z",
+    "last_stats_update": "2026-01-01T00:00:00.000000Z"
+}`
+
+func TestGetAccountStats(t *testing.T) {
+	var statsErr error
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "account" && args[1] == "stats" {
+				assert.Equal(t, "--account-id=RGW11111111111111111", args[2])
+				return accountStatsOutput, statsErr
+			}
+			return "", errors.New("unexpected command")
+		},
+	}
+	objContext := NewContext(&clusterd.Context{Executor: executor}, &cephclient.ClusterInfo{Namespace: "my-cluster", Context: context.TODO()}, "")
+	adminOpsContext := &AdminOpsContext{Context: *objContext}
+
+	stats, err := GetAccountStats(adminOpsContext, "RGW11111111111111111")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2048), stats.Size)
+	assert.Equal(t, int64(8192), stats.SizeActual)
+	assert.Equal(t, int64(2), stats.NumObjects)
+
+	_, err = GetAccountStats(adminOpsContext, "")
+	assert.ErrorContains(t, err, "account ID cannot be empty")
+
+	statsErr = errors.New("failed to connect")
+	_, err = GetAccountStats(adminOpsContext, "RGW11111111111111111")
+	assert.ErrorContains(t, err, "failed to get stats of account")
+}
+
+func TestSetUsageLogTotals(t *testing.T) {
+	var query url.Values
+	statusCode := 200
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			body := `{"entries":[],"summary":[{"user":"bob","categories":[],` +
+				`"total":{"bytes_sent":1024,"bytes_received":4096,"ops":12,"successful_ops":10}}]}`
+			return &http.Response{StatusCode: statusCode, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+		},
+	}
+	api, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+
+	usage := &cephv1.UsageStatus{UsedBytes: 100, BytesSent: 1}
+	err = SetUsageLogTotals(context.TODO(), api, "bob", usage)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"format": {"json"}, "uid": {"bob"}, "show-entries": {"false"}, "show-summary": {"true"}}, query)
+	assert.Equal(t, cephv1.UsageStatus{UsedBytes: 100, BytesSent: 1024, BytesReceived: 4096, Operations: 12}, *usage)
+
+	statusCode = 500
+	err = SetUsageLogTotals(context.TODO(), api, "bob", usage)
+	assert.ErrorContains(t, err, "failed to get usage log of \"bob\"")
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/util/exec"
 	"github.com/rook/rook/pkg/util/log"
+	"github.com/rook/rook/pkg/util/tracing"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -108,13 +109,37 @@ func (c *debugHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+type tracingHTTPClient struct {
+	client admin.HTTPClient
+	store  string
+}
+
+// NewTracingHTTPClient records a span for each admin ops request made with the client
+func NewTracingHTTPClient(client admin.HTTPClient, store string) admin.HTTPClient {
+	return &tracingHTTPClient{client, store}
+}
+
+func (c *tracingHTTPClient) Do(req *http.Request) (resp *http.Response, err error) {
+	_, span := tracing.StartChildSpan(req.Context(), "rgw admin ops "+req.Method,
+		tracing.StoreKey.String(c.store),
+		tracing.MethodKey.String(req.Method),
+		tracing.PathKey.String(req.URL.Path))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	resp, err = c.client.Do(req)
+	if resp != nil {
+		span.SetAttributes(tracing.StatusKey.Int(resp.StatusCode))
+	}
+	return resp, err
+}
+
 const (
 	// RGWAdminOpsUserSecretName is the secret name of the admin ops user
 	//nolint:gosec // since this is not leaking any hardcoded credentials, it's just the secret name
 	RGWAdminOpsUserSecretName = "rgw-admin-ops-user"
 	rgwAdminOpsUserAccessKey  = "accessKey"
 	rgwAdminOpsUserSecretKey  = "secretKey"
-	rgwAdminOpsUserCaps       = "accounts=*;buckets=*;users=*;usage=read;metadata=read;zone=read"
+	rgwAdminOpsUserCaps       = "accounts=*;buckets=*;users=*;usage=read;metadata=read;zone=read;ratelimit=*"
 )
 
 var rgwAdminOpsUserDisplayName = "RGW Admin Ops User"
@@ -182,15 +207,17 @@ func NewMultisiteAdminOpsContext(
 		return nil, err
 	}
 
+	tracingClient := NewTracingHTTPClient(httpClient, objContext.Name)
+
 	// If DEBUG level is set we will mutate the HTTP client for printing request and response
 	var client *admin.API
 	if logger.LevelAt(capnslog.DEBUG) {
-		client, err = admin.New(objContext.Endpoint, accessKey, secretKey, NewDebugHTTPClient(httpClient, logger))
+		client, err = admin.New(objContext.Endpoint, accessKey, secretKey, NewDebugHTTPClient(tracingClient, logger))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to build admin ops API connection")
 		}
 	} else {
-		client, err = admin.New(objContext.Endpoint, accessKey, secretKey, httpClient)
+		client, err = admin.New(objContext.Endpoint, accessKey, secretKey, tracingClient)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to build admin ops API connection")
 		}
@@ -237,6 +264,18 @@ func RunAdminCommandNoMultisite(c *Context, expectJSON bool, args ...string) (st
 }
 
 func RunAdminCommandNoMultisiteWithTimeout(c *Context, expectJSON bool, timeout time.Duration, args ...string) (string, error) {
+	command := tracing.CommandName(args)
+	_, span := tracing.StartChildSpan(c.clusterInfo.Context, strings.TrimSpace("radosgw-admin "+command),
+		tracing.ToolKey.String("radosgw-admin"),
+		tracing.CommandKey.String(command),
+		tracing.NamespaceKey.String(c.clusterInfo.Namespace),
+		tracing.StoreKey.String(c.Name))
+	output, err := runAdminCommandNoMultisiteWithTimeout(c, expectJSON, timeout, args...)
+	tracing.EndSpan(span, err)
+	return output, err
+}
+
+func runAdminCommandNoMultisiteWithTimeout(c *Context, expectJSON bool, timeout time.Duration, args ...string) (string, error) {
 	var output, stderr string
 	var err error
 	nsName := controller.NsName(c.clusterInfo.Namespace, c.Name)
@@ -531,6 +570,13 @@ func GetAdminOPSUserCredentials(objContext *Context, spec *cephv1.ObjectStoreSpe
 			if err != nil {
 				return "", "", errors.Wrapf(err, "failed to get details from ceph object user %q for object store %q", userConfig.UserID, objContext.Name)
 			}
+			// the caps are only set when the user is created, add the caps required by newer
+			// versions of the operator to the existing user
+			if missing := missingUserCaps(user.Caps, rgwAdminOpsUserCaps); missing != "" {
+				if err := AddUserCaps(objContext, userConfig.UserID, missing); err != nil {
+					log.NamedWarning(objContext.NsName(), logger, "failed to add caps %q to user %q. %v", missing, userConfig.UserID, err)
+				}
+			}
 		} else {
 			return "", "", errors.Wrapf(err, "failed to create object user %q. error code %d for object store %q", userConfig.UserID, rgwerr, objContext.Name)
 		}
@@ -872,3 +872,50 @@ func TestGetAdminOpsEndpoint(t *testing.T) {
 		})
 	})
 }
+
+func TestGetAdminOPSUserCredentials(t *testing.T) {
+	userInfo := func(caps string) string {
+		return `{"user_id":"rgw-admin-ops-user","display_name":"RGW Admin Ops User","keys":[{"user":"rgw-admin-ops-user","access_key":"access","secret_key":"secret"}],"caps":` + caps + `}`
+	}
+	var info string
+	var addedCaps []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			switch {
+			case args[0] == "user" && args[1] == "create":
+				return "could not create user: unable to create user, user: rgw-admin-ops-user exists", errors.New("exit status 17")
+			case args[0] == "user" && args[1] == "info":
+				return info, nil
+			case args[0] == "caps" && args[1] == "add":
+				assert.Equal(t, []string{"--uid", "rgw-admin-ops-user"}, args[2:4])
+				addedCaps = append(addedCaps, args[5])
+				return info, nil
+			}
+			return "", errors.Errorf("unexpected command %v", args)
+		},
+	}
+	objContext := &Context{
+		Context:     &clusterd.Context{Executor: executor},
+		clusterInfo: client.AdminTestClusterInfo("mycluster"),
+		Name:        "my-store",
+	}
+
+	t.Run("existing user without the ratelimit cap", func(t *testing.T) {
+		info = userInfo(`[{"type":"accounts","perm":"*"},{"type":"buckets","perm":"*"},{"type":"users","perm":"*"},` +
+			`{"type":"usage","perm":"read"},{"type":"metadata","perm":"read"},{"type":"zone","perm":"read"}]`)
+		accessKey, secretKey, err := GetAdminOPSUserCredentials(objContext, &cephv1.ObjectStoreSpec{})
+		assert.NoError(t, err)
+		assert.Equal(t, "access", accessKey)
+		assert.Equal(t, "secret", secretKey)
+		assert.Equal(t, []string{"ratelimit=*"}, addedCaps)
+	})
+
+	t.Run("existing user with all caps", func(t *testing.T) {
+		addedCaps = nil
+		info = userInfo(`[{"type":"accounts","perm":"*"},{"type":"buckets","perm":"*"},{"type":"users","perm":"*"},` +
+			`{"type":"usage","perm":"*"},{"type":"metadata","perm":"read"},{"type":"zone","perm":"read"},{"type":"ratelimit","perm":"*"}]`)
+		_, _, err := GetAdminOPSUserCredentials(objContext, &cephv1.ObjectStoreSpec{})
+		assert.NoError(t, err)
+		assert.Empty(t, addedCaps)
+	})
+}
@@ -50,6 +50,8 @@ type ReconcileBucket struct {
 	clusterInfo      *cephclient.ClusterInfo
 	opConfig         opcontroller.OperatorConfig
 	opManagerContext context.Context
+	// stopKeyRotation stops the key rotation started by the previous reconcile
+	stopKeyRotation context.CancelFunc
 }
 
 // Add creates a new Ceph CSI Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -74,7 +76,7 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 
 func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -166,6 +168,18 @@ func (r *ReconcileBucket) reconcile(request reconcile.Request) (reconcile.Result
 	//   bucket library's `NewProvisioner` function
 	bucketController, _ := NewBucketController(r.context.KubeConfig, bucketProvisioner)
 
+	// Rotate the keys of the OBC users on their own schedule with the latest cluster info
+	if r.stopKeyRotation != nil {
+		r.stopKeyRotation()
+	}
+	rotator, err := newKeyRotator(bucketProvisioner)
+	if err != nil {
+		return opcontroller.ImmediateRetryResult, errors.Wrap(err, "failed to create OBC key rotator")
+	}
+	var rotationCtx context.Context
+	rotationCtx, r.stopKeyRotation = context.WithCancel(r.opManagerContext)
+	go rotator.run(rotationCtx)
+
 	// We must run this in a go routine since RunWithContext() blocks and waits for the context to
 	// be Done. However, since it has a context, the go routine will exit on reload with SIGHUP
 	errChan := make(chan error)
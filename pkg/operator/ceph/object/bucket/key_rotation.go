@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	bktclient "github.com/kube-object-storage/lib-bucket-provisioner/pkg/client/clientset/versioned"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephObject "github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/util/log"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// storage class parameters of the key rotation policy of the users created for OBCs
+	keyRotationIntervalParam    = "keyRotationInterval"
+	keyRotationGracePeriodParam = "keyRotationGracePeriod"
+
+	// OBC annotations recording the key rotation status, since the OBC status is owned by
+	// lib-bucket-provisioner
+	keyGenerationAnnotation         = "ceph.rook.io/key-generation"
+	lastKeyRotationAnnotation       = "ceph.rook.io/last-key-rotation"
+	previousAccessKeyAnnotation     = "ceph.rook.io/previous-access-key"
+	previousKeyRevokeTimeAnnotation = "ceph.rook.io/previous-key-revoke-time"
+
+	keyRotationCheckInterval = 5 * time.Minute
+)
+
+// keyRotator rotates the keys of the users created for OBCs whose storage class sets a key rotation
+// policy. lib-bucket-provisioner only calls the provisioner when an OBC changes, so the rotation
+// runs on its own schedule.
+type keyRotator struct {
+	provisioner     *Provisioner
+	bktclient       bktclient.Interface
+	provisionerName string
+}
+
+func newKeyRotator(p *Provisioner) (*keyRotator, error) {
+	provName, err := cephObject.GetObjectBucketProvisioner(p.clusterInfo.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get provisioner name")
+	}
+	client, err := bktclient.NewForConfig(p.context.KubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create object bucket client")
+	}
+	return &keyRotator{provisioner: p, bktclient: client, provisionerName: provName}, nil
+}
+
+// run checks the object buckets for due key rotations until the context is done
+func (k *keyRotator) run(ctx context.Context) {
+	wait.UntilWithContext(ctx, k.rotateAll, keyRotationCheckInterval)
+}
+
+func (k *keyRotator) rotateAll(ctx context.Context) {
+	obs, err := k.bktclient.ObjectbucketV1alpha1().ObjectBuckets().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Errorf("failed to list object buckets for key rotation. %v", err)
+		return
+	}
+	for i := range obs.Items {
+		ob := &obs.Items[i]
+		if ob.Spec.ClaimRef == nil || ob.Status.Phase != bktv1alpha1.ObjectBucketStatusPhaseBound {
+			continue
+		}
+		// the keys of an explicit bucket owner are not managed by the provisioner
+		if _, ok := ob.Spec.AdditionalState["bucketOwner"]; ok {
+			continue
+		}
+		if err := k.rotate(ctx, ob); err != nil {
+			logger.Errorf("failed to rotate key of OBC %q. %v", types.NamespacedName{Namespace: ob.Spec.ClaimRef.Namespace, Name: ob.Spec.ClaimRef.Name}, err)
+		}
+	}
+}
+
+// rotate performs the next step of the key rotation of the OBC bound to an object bucket
+func (k *keyRotator) rotate(ctx context.Context, ob *bktv1alpha1.ObjectBucket) error {
+	sc, err := k.provisioner.context.Clientset.StorageV1().StorageClasses().Get(ctx, ob.Spec.StorageClassName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get storage class %q", ob.Spec.StorageClassName)
+	}
+	if sc.Provisioner != k.provisionerName {
+		return nil
+	}
+	policy, err := keyRotationPolicyFromStorageClass(sc)
+	if err != nil {
+		return err
+	}
+
+	obc, err := k.bktclient.ObjectbucketV1alpha1().ObjectBucketClaims(ob.Spec.ClaimRef.Namespace).Get(ctx, ob.Spec.ClaimRef.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to get OBC")
+	}
+	if !obc.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	status := keyRotationStatusFromAnnotations(obc.Annotations)
+	if policy == nil && status.PreviousAccessKeyID == "" {
+		return nil
+	}
+
+	// work on a copy since the provisioner fields are set per object bucket
+	p := *k.provisioner
+	if err := p.initializeDeleteOrRevoke(ob); err != nil {
+		return err
+	}
+	return k.rotateOBCKeys(ctx, &p, obc, policy, status, ob.CreationTimestamp.Time, time.Now())
+}
+
+// rotateOBCKeys adds a new key pair to the user of an OBC when a rotation is due and revokes the
+// previous key pair when the grace period ends. The OBC secret always holds the active key pair.
+func (k *keyRotator) rotateOBCKeys(ctx context.Context, p *Provisioner, obc *bktv1alpha1.ObjectBucketClaim, policy *cephv1.ObjectKeyRotationSpec, status *cephv1.ObjectKeyRotationStatus, created, now time.Time) error {
+	nsName := types.NamespacedName{Namespace: obc.Namespace, Name: obc.Name}
+
+	user, err := p.adminOpsClient.GetUser(ctx, admin.User{ID: p.cephUserName})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get user %q", p.cephUserName)
+	}
+	secret, err := p.context.Clientset.CoreV1().Secrets(obc.Namespace).Get(ctx, obc.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get OBC secret")
+	}
+	active, ok := cephObject.ActiveUserKey(user.Keys, status.PreviousAccessKeyID, string(secret.Data[bktv1alpha1.AwsKeyField]))
+	if !ok {
+		return errors.Errorf("no keys set for user %q", p.cephUserName)
+	}
+
+	action, _ := cephObject.NextKeyRotation(policy, status, created, now)
+	switch action {
+	case cephObject.KeyRotationRotate:
+		newKey, err := cephObject.CreateUserKey(ctx, p.adminOpsClient, p.cephUserName, user.Keys)
+		if err != nil {
+			return err
+		}
+		status.KeyGeneration++
+		status.LastRotationTime = &metav1.Time{Time: now}
+		status.PreviousAccessKeyID = active.AccessKey
+		status.PreviousKeyRevokeTime = &metav1.Time{Time: now.Add(cephObject.KeyRotationGracePeriod(policy))}
+		// record the rotation before the key is published so that a failure to update the secret
+		// does not add yet another key
+		if err := k.updateKeyRotationAnnotations(ctx, obc, status); err != nil {
+			return err
+		}
+		log.NamedInfo(nsName, logger, "rotated key of user %q to generation %d, previous key %q is revoked at %s",
+			p.cephUserName, status.KeyGeneration, status.PreviousAccessKeyID, status.PreviousKeyRevokeTime.Format(time.RFC3339))
+		active = newKey
+
+	case cephObject.KeyRotationRevoke:
+		if err := cephObject.RemoveUserKey(ctx, p.adminOpsClient, p.cephUserName, status.PreviousAccessKeyID); err != nil {
+			return err
+		}
+		log.NamedInfo(nsName, logger, "revoked previous key %q of user %q", status.PreviousAccessKeyID, p.cephUserName)
+		status.PreviousAccessKeyID = ""
+		status.PreviousKeyRevokeTime = nil
+		if err := k.updateKeyRotationAnnotations(ctx, obc, status); err != nil {
+			return err
+		}
+	}
+
+	if string(secret.Data[bktv1alpha1.AwsKeyField]) == active.AccessKey && string(secret.Data[bktv1alpha1.AwsSecretField]) == active.SecretKey {
+		return nil
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[bktv1alpha1.AwsKeyField] = []byte(active.AccessKey)
+	secret.Data[bktv1alpha1.AwsSecretField] = []byte(active.SecretKey)
+	if _, err := p.context.Clientset.CoreV1().Secrets(obc.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to publish the active key to the OBC secret")
+	}
+	log.NamedInfo(nsName, logger, "published key %q to the OBC secret", active.AccessKey)
+	return nil
+}
+
+func (k *keyRotator) updateKeyRotationAnnotations(ctx context.Context, obc *bktv1alpha1.ObjectBucketClaim, status *cephv1.ObjectKeyRotationStatus) error {
+	setKeyRotationAnnotations(obc, status)
+	updated, err := k.bktclient.ObjectbucketV1alpha1().ObjectBucketClaims(obc.Namespace).Update(ctx, obc, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to record the key rotation on the OBC")
+	}
+	*obc = *updated
+	return nil
+}
+
+// keyRotationPolicyFromStorageClass returns the key rotation policy set in the storage class
+// parameters, or nil if the keys are not rotated
+func keyRotationPolicyFromStorageClass(sc *storagev1.StorageClass) (*cephv1.ObjectKeyRotationSpec, error) {
+	interval, ok := sc.Parameters[keyRotationIntervalParam]
+	if !ok {
+		return nil, nil
+	}
+	policy := &cephv1.ObjectKeyRotationSpec{}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		return nil, errors.Errorf("invalid %s %q in storage class %q", keyRotationIntervalParam, interval, sc.Name)
+	}
+	policy.Interval = &metav1.Duration{Duration: d}
+
+	if gracePeriod, ok := sc.Parameters[keyRotationGracePeriodParam]; ok {
+		d, err := time.ParseDuration(gracePeriod)
+		if err != nil || d < 0 {
+			return nil, errors.Errorf("invalid %s %q in storage class %q", keyRotationGracePeriodParam, gracePeriod, sc.Name)
+		}
+		policy.GracePeriod = &metav1.Duration{Duration: d}
+	}
+	return policy, nil
+}
+
+func keyRotationStatusFromAnnotations(annotations map[string]string) *cephv1.ObjectKeyRotationStatus {
+	status := &cephv1.ObjectKeyRotationStatus{
+		PreviousAccessKeyID: annotations[previousAccessKeyAnnotation],
+	}
+	if generation, err := strconv.ParseInt(annotations[keyGenerationAnnotation], 10, 64); err == nil {
+		status.KeyGeneration = generation
+	}
+	if t, err := time.Parse(time.RFC3339, annotations[lastKeyRotationAnnotation]); err == nil {
+		status.LastRotationTime = &metav1.Time{Time: t}
+	}
+	if t, err := time.Parse(time.RFC3339, annotations[previousKeyRevokeTimeAnnotation]); err == nil {
+		status.PreviousKeyRevokeTime = &metav1.Time{Time: t}
+	}
+	return status
+}
+
+func setKeyRotationAnnotations(obc *bktv1alpha1.ObjectBucketClaim, status *cephv1.ObjectKeyRotationStatus) {
+	if obc.Annotations == nil {
+		obc.Annotations = map[string]string{}
+	}
+	obc.Annotations[keyGenerationAnnotation] = strconv.FormatInt(status.KeyGeneration, 10)
+	if status.LastRotationTime != nil {
+		obc.Annotations[lastKeyRotationAnnotation] = status.LastRotationTime.UTC().Format(time.RFC3339)
+	}
+	if status.PreviousAccessKeyID != "" {
+		obc.Annotations[previousAccessKeyAnnotation] = status.PreviousAccessKeyID
+	} else {
+		delete(obc.Annotations, previousAccessKeyAnnotation)
+	}
+	if status.PreviousKeyRevokeTime != nil {
+		obc.Annotations[previousKeyRevokeTimeAnnotation] = status.PreviousKeyRevokeTime.UTC().Format(time.RFC3339)
+	} else {
+		delete(obc.Annotations, previousKeyRevokeTimeAnnotation)
+	}
+}
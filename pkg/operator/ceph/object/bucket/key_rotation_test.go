@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	bktfake "github.com/kube-object-storage/lib-bucket-provisioner/pkg/client/clientset/versioned/fake"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/client"
+	cephobject "github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeyRotationPolicyFromStorageClass(t *testing.T) {
+	sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "sc"}, Parameters: map[string]string{}}
+
+	policy, err := keyRotationPolicyFromStorageClass(sc)
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	sc.Parameters[keyRotationIntervalParam] = "720h"
+	policy, err = keyRotationPolicyFromStorageClass(sc)
+	assert.NoError(t, err)
+	assert.Equal(t, 720*time.Hour, policy.Interval.Duration)
+	assert.Nil(t, policy.GracePeriod)
+
+	sc.Parameters[keyRotationGracePeriodParam] = "1h"
+	policy, err = keyRotationPolicyFromStorageClass(sc)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, policy.GracePeriod.Duration)
+
+	sc.Parameters[keyRotationGracePeriodParam] = "soon"
+	_, err = keyRotationPolicyFromStorageClass(sc)
+	assert.Error(t, err)
+
+	sc.Parameters[keyRotationIntervalParam] = "0s"
+	_, err = keyRotationPolicyFromStorageClass(sc)
+	assert.Error(t, err)
+}
+
+func TestKeyRotationAnnotations(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	obc := &bktv1alpha1.ObjectBucketClaim{}
+
+	status := keyRotationStatusFromAnnotations(obc.Annotations)
+	assert.Equal(t, &cephv1.ObjectKeyRotationStatus{}, status)
+
+	status = &cephv1.ObjectKeyRotationStatus{
+		KeyGeneration:         2,
+		LastRotationTime:      &metav1.Time{Time: now},
+		PreviousAccessKeyID:   "old",
+		PreviousKeyRevokeTime: &metav1.Time{Time: now.Add(time.Hour)},
+	}
+	setKeyRotationAnnotations(obc, status)
+	assert.Equal(t, status, keyRotationStatusFromAnnotations(obc.Annotations))
+
+	status.PreviousAccessKeyID = ""
+	status.PreviousKeyRevokeTime = nil
+	setKeyRotationAnnotations(obc, status)
+	assert.NotContains(t, obc.Annotations, previousAccessKeyAnnotation)
+	assert.NotContains(t, obc.Annotations, previousKeyRevokeTimeAnnotation)
+	assert.Equal(t, status, keyRotationStatusFromAnnotations(obc.Annotations))
+}
+
+func TestRotateOBCKeys(t *testing.T) {
+	ctx := context.TODO()
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := &cephv1.ObjectKeyRotationSpec{
+		Interval:    &metav1.Duration{Duration: 24 * time.Hour},
+		GracePeriod: &metav1.Duration{Duration: time.Hour},
+	}
+
+	// the mock RGW keeps the keys of the user so that the rotation can be followed step by step
+	keys := []admin.UserKeySpec{{User: "obc-user", AccessKey: "old", SecretKey: "old-secret"}}
+	mockClient := &cephobject.MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "rgw.test/admin/user" {
+				return nil, fmt.Errorf("unexpected url path %q", req.URL.Path)
+			}
+			var body []byte
+			switch {
+			case req.Method == http.MethodGet:
+				body, _ = json.Marshal(admin.User{ID: "obc-user", Keys: keys})
+			case req.Method == http.MethodPut && req.URL.Query().Has("key"):
+				keys = append(keys, admin.UserKeySpec{User: "obc-user", AccessKey: "new", SecretKey: "new-secret"})
+				body, _ = json.Marshal(keys)
+			case req.Method == http.MethodDelete && req.URL.Query().Has("key"):
+				accessKey := req.URL.Query().Get("access-key")
+				keys = slices.DeleteFunc(keys, func(k admin.UserKeySpec) bool { return k.AccessKey == accessKey })
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q", req.URL.RawQuery, req.Method)
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(body))}, nil
+		},
+	}
+	adminClient, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+
+	obc := &bktv1alpha1.ObjectBucketClaim{ObjectMeta: metav1.ObjectMeta{Name: "obc", Namespace: "app"}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "obc", Namespace: "app"},
+		Data: map[string][]byte{
+			bktv1alpha1.AwsKeyField:    []byte("old"),
+			bktv1alpha1.AwsSecretField: []byte("old-secret"),
+		},
+	}
+	clientset := test.New(t, 1)
+	_, err = clientset.CoreV1().Secrets("app").Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	p := NewProvisioner(&clusterd.Context{Clientset: clientset}, client.AdminTestClusterInfo("rook-ceph"))
+	p.adminOpsClient = adminClient
+	p.cephUserName = "obc-user"
+	k := &keyRotator{provisioner: p, bktclient: bktfake.NewSimpleClientset(obc)}
+
+	publishedKey := func() string {
+		s, err := clientset.CoreV1().Secrets("app").Get(ctx, "obc", metav1.GetOptions{})
+		require.NoError(t, err)
+		return string(s.Data[bktv1alpha1.AwsKeyField])
+	}
+	rotate := func(now time.Time) *cephv1.ObjectKeyRotationStatus {
+		current, err := k.bktclient.ObjectbucketV1alpha1().ObjectBucketClaims("app").Get(ctx, "obc", metav1.GetOptions{})
+		require.NoError(t, err)
+		status := keyRotationStatusFromAnnotations(current.Annotations)
+		require.NoError(t, k.rotateOBCKeys(ctx, p, current, policy, status, created, now))
+		return status
+	}
+
+	t.Run("nothing due", func(t *testing.T) {
+		status := rotate(created.Add(time.Hour))
+		assert.Equal(t, int64(0), status.KeyGeneration)
+		assert.Len(t, keys, 1)
+		assert.Equal(t, "old", publishedKey())
+	})
+
+	t.Run("rotation adds and publishes a new key", func(t *testing.T) {
+		status := rotate(created.Add(24 * time.Hour))
+		assert.Equal(t, int64(1), status.KeyGeneration)
+		assert.Equal(t, "old", status.PreviousAccessKeyID)
+		assert.Len(t, keys, 2)
+		assert.Equal(t, "new", publishedKey())
+	})
+
+	t.Run("previous key stays valid during the grace period", func(t *testing.T) {
+		status := rotate(created.Add(24*time.Hour + 30*time.Minute))
+		assert.Equal(t, "old", status.PreviousAccessKeyID)
+		assert.Len(t, keys, 2)
+		assert.Equal(t, "new", publishedKey())
+	})
+
+	t.Run("previous key is revoked after the grace period", func(t *testing.T) {
+		status := rotate(created.Add(25 * time.Hour))
+		assert.Equal(t, int64(1), status.KeyGeneration)
+		assert.Empty(t, status.PreviousAccessKeyID)
+		assert.Equal(t, []admin.UserKeySpec{{User: "obc-user", AccessKey: "new", SecretKey: "new-secret"}}, keys)
+		assert.Equal(t, "new", publishedKey())
+	})
+}
@@ -65,13 +65,21 @@ type Provisioner struct {
 }
 
 type additionalConfigSpec struct {
-	maxObjects       *int64
-	maxSize          *int64
-	bucketMaxObjects *int64
-	bucketMaxSize    *int64
-	bucketPolicy     *string
-	bucketLifecycle  *string
-	bucketOwner      *string
+	maxObjects          *int64
+	maxSize             *int64
+	bucketMaxObjects    *int64
+	bucketMaxSize       *int64
+	maxReadOps          *int64
+	maxWriteOps         *int64
+	maxReadBytes        *int64
+	maxWriteBytes       *int64
+	bucketMaxReadOps    *int64
+	bucketMaxWriteOps   *int64
+	bucketMaxReadBytes  *int64
+	bucketMaxWriteBytes *int64
+	bucketPolicy        *string
+	bucketLifecycle     *string
+	bucketOwner         *string
 }
 
 var _ apibkt.Provisioner = &Provisioner{}
@@ -631,6 +639,16 @@ func (p *Provisioner) setAdditionalSettings(bucket *bucket) error {
 		return errors.Wrap(err, "failed to set bucket quota")
 	}
 
+	err = p.setUserRateLimit(bucket)
+	if err != nil {
+		return errors.Wrap(err, "failed to set user rate limit")
+	}
+
+	err = p.setBucketRateLimit(bucket)
+	if err != nil {
+		return errors.Wrap(err, "failed to set bucket rate limit")
+	}
+
 	err = p.setBucketPolicy(bucket)
 	if err != nil {
 		return errors.Wrap(err, "failed to set bucket policy")
@@ -760,6 +778,82 @@ func (p *Provisioner) setBucketQuota(bucket *bucket) error {
 	return nil
 }
 
+func (p *Provisioner) setUserRateLimit(bucket *bucket) error {
+	nsName := p.objectContext.NsName()
+	additionalConfig := bucket.additionalConfig
+
+	if additionalConfig.bucketOwner != nil {
+		// when an explicit bucket owner is set, we do not manage user rate limits
+		log.NamedDebug(nsName, logger, "Skipping user level rate limits for OBC %q as bucketOwner is set", bucket.options.ObjectBucketClaim.Name)
+		return nil
+	}
+
+	targetLimit := rateLimitFromConfig(additionalConfig.maxReadOps, additionalConfig.maxWriteOps, additionalConfig.maxReadBytes, additionalConfig.maxWriteBytes)
+	if !targetLimit.Enabled {
+		// the rate limit api is not called for the many OBCs without rate limits
+		return nil
+	}
+
+	currentLimit, err := object.GetUserRateLimit(p.clusterInfo.Context, p.adminOpsClient, p.cephUserName)
+	if err != nil {
+		return err
+	}
+	if currentLimit != targetLimit {
+		log.NamedDebug(nsName, logger, "Rate limit for user %q has changed from %+v to %+v", p.cephUserName, currentLimit, targetLimit)
+		err = object.SetUserRateLimit(p.clusterInfo.Context, p.adminOpsClient, p.cephUserName, targetLimit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Provisioner) setBucketRateLimit(bucket *bucket) error {
+	nsName := p.objectContext.NsName()
+	additionalConfig := bucket.additionalConfig
+
+	targetLimit := rateLimitFromConfig(additionalConfig.bucketMaxReadOps, additionalConfig.bucketMaxWriteOps, additionalConfig.bucketMaxReadBytes, additionalConfig.bucketMaxWriteBytes)
+	if !targetLimit.Enabled {
+		return nil
+	}
+
+	currentLimit, err := object.GetBucketRateLimit(p.clusterInfo.Context, p.adminOpsClient, p.bucketName)
+	if err != nil {
+		return err
+	}
+	if currentLimit != targetLimit {
+		log.NamedDebug(nsName, logger, "Rate limit for bucket %q has changed from %+v to %+v", p.bucketName, currentLimit, targetLimit)
+		err = object.SetBucketRateLimit(p.clusterInfo.Context, p.adminOpsClient, p.bucketName, targetLimit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rateLimitFromConfig returns the rate limit set by the OBC additionalConfig. The rate limit is
+// disabled when none of its keys are set, in which case the current rate limit is left alone.
+func rateLimitFromConfig(maxReadOps, maxWriteOps, maxReadBytes, maxWriteBytes *int64) object.RateLimit {
+	limit := object.RateLimit{}
+	for _, l := range []struct {
+		config *int64
+		limit  *int64
+	}{
+		{maxReadOps, &limit.MaxReadOps},
+		{maxWriteOps, &limit.MaxWriteOps},
+		{maxReadBytes, &limit.MaxReadBytes},
+		{maxWriteBytes, &limit.MaxWriteBytes},
+	} {
+		if l.config != nil {
+			limit.Enabled = true
+			*l.limit = *l.config
+		}
+	}
+	return limit
+}
+
 func (p *Provisioner) setBucketPolicy(bucket *bucket) error {
 	nsName := p.objectContext.NsName()
 	additionalConfig := bucket.additionalConfig
@@ -928,14 +1022,16 @@ func (p *Provisioner) setAdminOpsAPIClient() error {
 		return errors.Wrapf(err, "failed to retrieve admin ops endpoint")
 	}
 
+	tracingClient := object.NewTracingHTTPClient(httpClient, cephObjectStore.Name)
+
 	// If DEBUG level is set we will mutate the HTTP client for printing request and response
 	if logger.LevelAt(capnslog.DEBUG) {
-		p.adminOpsClient, err = admin.New(s3endpoint, accessKey, secretKey, object.NewDebugHTTPClient(httpClient, logger))
+		p.adminOpsClient, err = admin.New(s3endpoint, accessKey, secretKey, object.NewDebugHTTPClient(tracingClient, logger))
 		if err != nil {
 			return errors.Wrap(err, "failed to build admin ops API connection")
 		}
 	} else {
-		p.adminOpsClient, err = admin.New(s3endpoint, accessKey, secretKey, httpClient)
+		p.adminOpsClient, err = admin.New(s3endpoint, accessKey, secretKey, tracingClient)
 		if err != nil {
 			return errors.Wrap(err, "failed to build admin ops API connection")
 		}
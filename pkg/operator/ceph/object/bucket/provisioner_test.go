@@ -22,12 +22,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/ceph/go-ceph/rgw/admin"
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	apibkt "github.com/kube-object-storage/lib-bucket-provisioner/pkg/provisioner/api"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	rookclient "github.com/rook/rook/pkg/client/clientset/versioned/fake"
 	"github.com/rook/rook/pkg/clusterd"
@@ -42,8 +45,9 @@ import (
 )
 
 const (
-	userPath   = "rgw.test/admin/user"
-	bucketPath = "rgw.test/admin/bucket"
+	userPath      = "rgw.test/admin/user"
+	bucketPath    = "rgw.test/admin/bucket"
+	rateLimitPath = "rgw.test/admin/ratelimit"
 )
 
 func TestPopulateDomainAndPort(t *testing.T) {
@@ -510,6 +514,95 @@ func TestProvisioner_setBucketQuota(t *testing.T) {
 	})
 }
 
+func TestProvisioner_setRateLimits(t *testing.T) {
+	var sets []url.Values
+	gets := 0
+	userLimit := `{"user_ratelimit":{"max_read_ops":0,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":false}}`
+	bucketLimit := `{"bucket_ratelimit":{"max_read_ops":10,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":true}}`
+	mockClient := &object.MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != rateLimitPath {
+				return nil, fmt.Errorf("unexpected url path %q", req.URL.Path)
+			}
+			body := ""
+			switch {
+			case req.Method == http.MethodGet && req.URL.Query().Has("uid"):
+				gets++
+				body = userLimit
+			case req.Method == http.MethodGet:
+				gets++
+				body = bucketLimit
+			case req.Method == http.MethodPost:
+				sets = append(sets, req.URL.Query())
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+		},
+	}
+	adminClient, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	assert.NoError(t, err)
+
+	clusterInfo := &client.ClusterInfo{Context: context.Background()}
+	p := &Provisioner{
+		clusterInfo:    clusterInfo,
+		cephUserName:   "bob",
+		adminOpsClient: adminClient,
+		objectContext:  object.NewContext(&clusterd.Context{}, clusterInfo, "store"),
+	}
+	p.setBucketName("photos")
+
+	t.Run("rate limit api is not called without rate limits", func(t *testing.T) {
+		sets, gets = nil, 0
+		err := p.setUserRateLimit(&bucket{additionalConfig: &additionalConfigSpec{}})
+		assert.NoError(t, err)
+		err = p.setBucketRateLimit(&bucket{additionalConfig: &additionalConfigSpec{}})
+		assert.NoError(t, err)
+		assert.Empty(t, sets)
+		assert.Zero(t, gets)
+	})
+
+	t.Run("user rate limit is set", func(t *testing.T) {
+		sets = nil
+		var readOps int64 = 20
+		err := p.setUserRateLimit(&bucket{additionalConfig: &additionalConfigSpec{maxReadOps: &readOps}})
+		assert.NoError(t, err)
+		assert.Len(t, sets, 1)
+		assert.Equal(t, "bob", sets[0].Get("uid"))
+		assert.Equal(t, "true", sets[0].Get("enabled"))
+		assert.Equal(t, "20", sets[0].Get("max-read-ops"))
+	})
+
+	t.Run("user rate limit is skipped with a bucket owner", func(t *testing.T) {
+		sets = nil
+		var readOps int64 = 20
+		owner := "alice"
+		err := p.setUserRateLimit(&bucket{
+			options:          &apibkt.BucketOptions{ObjectBucketClaim: &bktv1alpha1.ObjectBucketClaim{}},
+			additionalConfig: &additionalConfigSpec{maxReadOps: &readOps, bucketOwner: &owner},
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, sets)
+	})
+
+	t.Run("bucket rate limit in sync", func(t *testing.T) {
+		sets = nil
+		var readOps int64 = 10
+		err := p.setBucketRateLimit(&bucket{additionalConfig: &additionalConfigSpec{bucketMaxReadOps: &readOps}})
+		assert.NoError(t, err)
+		assert.Empty(t, sets)
+	})
+
+	t.Run("bucket rate limit is lifted", func(t *testing.T) {
+		sets = nil
+		var unlimited int64
+		err := p.setBucketRateLimit(&bucket{additionalConfig: &additionalConfigSpec{bucketMaxReadOps: &unlimited}})
+		assert.NoError(t, err)
+		assert.Len(t, sets, 1)
+		assert.Equal(t, "photos", sets[0].Get("bucket"))
+		assert.Equal(t, "true", sets[0].Get("enabled"))
+		assert.Equal(t, "0", sets[0].Get("max-read-ops"))
+	})
+}
+
 func TestProvisioner_additionalConfigSpecFromMap(t *testing.T) {
 	t.Run("does not fail on empty map", func(t *testing.T) {
 		spec, err := additionalConfigSpecFromMap(map[string]string{})
@@ -590,10 +683,28 @@ func TestProvisioner_additionalConfigSpecFromMap(t *testing.T) {
 		assert.Equal(t, additionalConfigSpec{bucketOwner: &(&struct{ s string }{"foo"}).s}, *spec)
 	})
 
+	t.Run("rate limit fields should be set", func(t *testing.T) {
+		os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "maxReadOps,bucketMaxWriteBytes")
+		defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+		spec, err := additionalConfigSpecFromMap(map[string]string{"maxReadOps": "1k", "bucketMaxWriteBytes": "1Mi"})
+		assert.NoError(t, err)
+		assert.Equal(t, additionalConfigSpec{
+			maxReadOps:          &(&struct{ i int64 }{1000}).i,
+			bucketMaxWriteBytes: &(&struct{ i int64 }{1024 * 1024}).i,
+		}, *spec)
+
+		_, err = additionalConfigSpecFromMap(map[string]string{"maxReadOps": "-1"})
+		assert.Error(t, err)
+	})
+
 	t.Run("fields disallowed by default", func(t *testing.T) {
 		opcontroller.SetObcAllowAdditionalConfigFields()
 
-		for _, configKey := range []string{"bucketMaxObjects", "bucketMaxSize", "bucketPolicy", "bucketLifecycle", "bucketOwner"} {
+		for _, configKey := range []string{"bucketMaxObjects", "bucketMaxSize", "bucketPolicy", "bucketLifecycle", "bucketOwner",
+			"maxReadOps", "maxWriteOps", "maxReadBytes", "maxWriteBytes", "bucketMaxReadOps", "bucketMaxWriteOps", "bucketMaxReadBytes", "bucketMaxWriteBytes"} {
 			_, err := additionalConfigSpecFromMap(map[string]string{configKey: "foo"})
 			assert.Error(t, err)
 		}
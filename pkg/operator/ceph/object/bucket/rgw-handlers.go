@@ -8,6 +8,7 @@ import (
 	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
 	apibkt "github.com/kube-object-storage/lib-bucket-provisioner/pkg/provisioner/api"
 	"github.com/pkg/errors"
+	cephObject "github.com/rook/rook/pkg/operator/ceph/object"
 	"github.com/rook/rook/pkg/util/log"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -30,7 +31,9 @@ func (b *bucket) getUserCreds() (accessKeyID, secretAccessKey string, err error)
 
 	if b.additionalConfig.bucketOwner == nil {
 		// get or create user
-		accessKeyID, secretAccessKey, err = p.createCephUser(p.cephUserName)
+		// during a key rotation the previous key must not be published again
+		previousAccessKey := b.options.ObjectBucketClaim.Annotations[previousAccessKeyAnnotation]
+		accessKeyID, secretAccessKey, err = p.createCephUser(p.cephUserName, previousAccessKey)
 		if err != nil {
 			err = errors.Wrapf(err, "unable to create Ceph object user %q", p.cephUserName)
 			return
@@ -62,8 +65,9 @@ func (p *Provisioner) bucketExists(name string) (bool, string, error) {
 }
 
 // Create a Ceph user based on the passed-in name or a generated name. Return the
-// accessKeys and set user name and keys in receiver.
-func (p *Provisioner) createCephUser(username string) (accKey string, secKey string, err error) {
+// accessKeys and set user name and keys in receiver. The previous key of a pending
+// key rotation is never returned.
+func (p *Provisioner) createCephUser(username, previousAccessKey string) (accKey string, secKey string, err error) {
 	if len(username) == 0 {
 		return "", "", errors.Wrap(err, "no user name provided")
 	}
@@ -90,8 +94,13 @@ func (p *Provisioner) createCephUser(username string) (accKey string, secKey str
 		log.NamedInfo(nsName, logger, "Ceph object user %q already exists", username)
 	}
 
+	key, ok := cephObject.ActiveUserKey(u.Keys, previousAccessKey, "")
+	if !ok {
+		return "", "", errors.Errorf("no keys set for Ceph object user %q", username)
+	}
+
 	log.NamedInfo(nsName, logger, "successfully created Ceph object user %q with access keys", username)
-	return u.Keys[0].AccessKey, u.Keys[0].SecretKey, nil
+	return key.AccessKey, key.SecretKey, nil
 }
 
 func (p *Provisioner) genUserName(obc *bktv1alpha1.ObjectBucketClaim) string {
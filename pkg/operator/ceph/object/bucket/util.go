@@ -134,6 +134,33 @@ func additionalConfigSpecFromMap(config map[string]string) (*additionalConfigSpe
 		}
 	}
 
+	// rate limits are per minute. Both the ops and bytes are parsed as quantities so that e.g. "1k"
+	// ops or "100Mi" bytes may be used.
+	for key, field := range map[string]**int64{
+		"maxReadOps":          &spec.maxReadOps,
+		"maxWriteOps":         &spec.maxWriteOps,
+		"maxReadBytes":        &spec.maxReadBytes,
+		"maxWriteBytes":       &spec.maxWriteBytes,
+		"bucketMaxReadOps":    &spec.bucketMaxReadOps,
+		"bucketMaxWriteOps":   &spec.bucketMaxWriteOps,
+		"bucketMaxReadBytes":  &spec.bucketMaxReadBytes,
+		"bucketMaxWriteBytes": &spec.bucketMaxWriteBytes,
+	} {
+		if _, ok := config[key]; !ok {
+			continue
+		}
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed(key) {
+			return nil, errors.Errorf("OBC config %q is not allowed", key)
+		}
+		*field, err = quanityToInt64(config[key])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s rate limit", key)
+		}
+		if **field < 0 {
+			return nil, errors.Errorf("%s rate limit must not be negative", key)
+		}
+	}
+
 	if _, ok := config["bucketPolicy"]; ok {
 		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketPolicy") {
 			return nil, errors.Errorf("OBC config %q is not allowed", "bucketPolicy")
@@ -0,0 +1,334 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/util/log"
+)
+
+const (
+	defaultReshardObjectsPerShard int64 = 50000
+	// the default of rgw_max_dynamic_shards
+	defaultReshardMaxShards int64 = 1999
+	maxLargeBucketsInStatus       = 20
+
+	// 'bucket limit check' reads the stats of every bucket, which takes a while on large stores
+	bucketLimitCheckTimeout = 5 * time.Minute
+
+	bucketFillStatusOK      = "OK"
+	reshardStatusQueued     = "queued"
+	reshardStatusInProgress = "in-progress"
+)
+
+type bucketLimitCheckUser struct {
+	UserID  string                   `json:"user_id"`
+	Buckets []bucketLimitCheckBucket `json:"buckets"`
+}
+
+type bucketLimitCheckBucket struct {
+	Bucket          string `json:"bucket"`
+	Tenant          string `json:"tenant"`
+	NumObjects      int64  `json:"num_objects"`
+	NumShards       int64  `json:"num_shards"`
+	ObjectsPerShard int64  `json:"objects_per_shard"`
+	FillStatus      string `json:"fill_status"`
+}
+
+type reshardQueueEntry struct {
+	Time         string `json:"time"`
+	Tenant       string `json:"tenant"`
+	BucketName   string `json:"bucket_name"`
+	OldNumShards int64  `json:"old_num_shards"`
+	NewNumShards int64  `json:"tentative_new_num_shards"`
+}
+
+type reshardShardStatus struct {
+	ReshardStatus string `json:"reshard_status"`
+}
+
+// the versioning of a bucket is reported in different fields across Ceph versions
+type bucketVersioningStats struct {
+	Versioned         *bool   `json:"versioned"`
+	VersioningEnabled *bool   `json:"versioning_enabled"`
+	Versioning        *string `json:"versioning"`
+}
+
+// CheckBucketIndexes returns the buckets of the object store whose index is over the objects per
+// shard threshold and the reshard queue. The large buckets matching the reshard policy of the spec
+// are queued for a manual reshard. The number of reshards queued is returned with the status.
+func CheckBucketIndexes(objContext *Context, spec *cephv1.ObjectStoreBucketIndexSpec) (*cephv1.ObjectStoreBucketIndexStatus, int, error) {
+	largeBuckets, err := listLargeBuckets(objContext, spec.ObjectsPerShardThreshold)
+	if err != nil {
+		return nil, 0, err
+	}
+	queue, err := listReshardQueue(objContext)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	queued := 0
+	if spec.Reshard != nil {
+		inQueue := map[string]bool{}
+		for _, entry := range queue {
+			inQueue[bucketFullName(entry.Tenant, entry.BucketName)] = true
+		}
+		for _, bucket := range largeBuckets {
+			if inQueue[bucket.Bucket] {
+				continue
+			}
+			entry, err := queueReshard(objContext, spec.Reshard, bucket)
+			if err != nil {
+				return nil, queued, err
+			}
+			if entry != nil {
+				queue = append(queue, *entry)
+				queued++
+			}
+		}
+	}
+
+	status := &cephv1.ObjectStoreBucketIndexStatus{LargeBucketCount: len(largeBuckets)}
+	if len(largeBuckets) > maxLargeBucketsInStatus {
+		largeBuckets = largeBuckets[:maxLargeBucketsInStatus]
+	}
+	status.LargeBuckets = largeBuckets
+
+	for _, entry := range queue {
+		bucket := bucketFullName(entry.Tenant, entry.BucketName)
+		reshardStatus, err := getReshardStatus(objContext, bucket)
+		if err != nil {
+			return nil, queued, err
+		}
+		status.Reshards = append(status.Reshards, cephv1.BucketReshardStatus{
+			Bucket:       bucket,
+			OldNumShards: entry.OldNumShards,
+			NewNumShards: entry.NewNumShards,
+			Status:       reshardStatus,
+			QueuedTime:   entry.Time,
+		})
+	}
+
+	return status, queued, nil
+}
+
+// listLargeBuckets returns the buckets over the objects per shard threshold, with the most objects
+// per shard first. Without a threshold, the buckets over the RGW warning threshold are returned.
+func listLargeBuckets(objContext *Context, threshold *int64) ([]cephv1.BucketIndexStatus, error) {
+	args := []string{"bucket", "limit", "check"}
+	if threshold == nil {
+		args = append(args, "--warnings-only")
+	}
+	output, err := runAdminCommandWithTimeout(objContext, true, bucketLimitCheckTimeout, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check bucket index limits")
+	}
+	var users []bucketLimitCheckUser
+	if err := json.Unmarshal([]byte(output), &users); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse bucket index limits")
+	}
+
+	largeBuckets := []cephv1.BucketIndexStatus{}
+	for _, user := range users {
+		for _, b := range user.Buckets {
+			if threshold != nil && b.ObjectsPerShard <= *threshold {
+				continue
+			}
+			if threshold == nil && (b.FillStatus == "" || b.FillStatus == bucketFillStatusOK) {
+				continue
+			}
+			largeBuckets = append(largeBuckets, cephv1.BucketIndexStatus{
+				Bucket:          bucketFullName(b.Tenant, b.Bucket),
+				Owner:           user.UserID,
+				NumObjects:      b.NumObjects,
+				NumShards:       b.NumShards,
+				ObjectsPerShard: b.ObjectsPerShard,
+				FillStatus:      b.FillStatus,
+			})
+		}
+	}
+	sort.SliceStable(largeBuckets, func(i, j int) bool {
+		return largeBuckets[i].ObjectsPerShard > largeBuckets[j].ObjectsPerShard
+	})
+	return largeBuckets, nil
+}
+
+func listReshardQueue(objContext *Context) ([]reshardQueueEntry, error) {
+	output, err := runAdminCommand(objContext, true, "reshard", "list")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the reshard queue")
+	}
+	var queue []reshardQueueEntry
+	if err := json.Unmarshal([]byte(output), &queue); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the reshard queue")
+	}
+	return queue, nil
+}
+
+// getReshardStatus returns whether the reshard of a queued bucket is in progress
+func getReshardStatus(objContext *Context, bucket string) (string, error) {
+	output, err := runAdminCommand(objContext, true, "reshard", "status", "--bucket", bucket)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get reshard status of bucket %q", bucket)
+	}
+	var shards []reshardShardStatus
+	if err := json.Unmarshal([]byte(output), &shards); err != nil {
+		return "", errors.Wrapf(err, "failed to parse reshard status of bucket %q", bucket)
+	}
+	for _, shard := range shards {
+		if shard.ReshardStatus == reshardStatusInProgress {
+			return reshardStatusInProgress, nil
+		}
+	}
+	return reshardStatusQueued, nil
+}
+
+// queueReshard adds a large bucket to the reshard queue if it matches the reshard policy and its
+// number of shards would increase. The queue entry is returned if the bucket was queued.
+func queueReshard(objContext *Context, policy *cephv1.BucketReshardPolicySpec, bucket cephv1.BucketIndexStatus) (*reshardQueueEntry, error) {
+	match, err := bucketMatchesPatterns(bucket.Bucket, policy.Buckets)
+	if err != nil || !match {
+		return nil, err
+	}
+	if policy.VersionedOnly {
+		versioned, err := isBucketVersioned(objContext, bucket.Bucket)
+		if err != nil {
+			return nil, err
+		}
+		if !versioned {
+			return nil, nil
+		}
+	}
+
+	numShards := reshardNumShards(policy, bucket.NumObjects)
+	if numShards <= bucket.NumShards {
+		return nil, nil
+	}
+
+	log.NamedInfo(objContext.NsName(), logger, "queueing reshard of bucket %q from %d to %d shards with %d objects per shard",
+		bucket.Bucket, bucket.NumShards, numShards, bucket.ObjectsPerShard)
+	_, err = runAdminCommand(objContext, false, "reshard", "add", "--bucket", bucket.Bucket, "--num-shards", fmt.Sprintf("%d", numShards))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to queue reshard of bucket %q", bucket.Bucket)
+	}
+
+	tenant, name := splitBucketFullName(bucket.Bucket)
+	return &reshardQueueEntry{
+		Time:         time.Now().UTC().Format(time.RFC3339),
+		Tenant:       tenant,
+		BucketName:   name,
+		OldNumShards: bucket.NumShards,
+		NewNumShards: numShards,
+	}, nil
+}
+
+// reshardNumShards returns the number of shards to reshard a bucket to. Like dynamic resharding,
+// a prime number of shards is used for a better distribution of the objects.
+func reshardNumShards(policy *cephv1.BucketReshardPolicySpec, numObjects int64) int64 {
+	objectsPerShard := defaultReshardObjectsPerShard
+	if policy.ObjectsPerShard != nil {
+		objectsPerShard = *policy.ObjectsPerShard
+	}
+	maxShards := defaultReshardMaxShards
+	if policy.MaxShards != nil {
+		maxShards = *policy.MaxShards
+	}
+
+	numShards := nextPrime((numObjects + objectsPerShard - 1) / objectsPerShard)
+	if numShards > maxShards {
+		return maxShards
+	}
+	return numShards
+}
+
+func nextPrime(n int64) int64 {
+	if n <= 2 {
+		return 2
+	}
+	for ; ; n++ {
+		prime := true
+		for d := int64(2); d*d <= n; d++ {
+			if n%d == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			return n
+		}
+	}
+}
+
+func bucketMatchesPatterns(bucket string, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, pattern := range patterns {
+		match, err := path.Match(pattern, bucket)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid bucket pattern %q", pattern)
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isBucketVersioned(objContext *Context, bucket string) (bool, error) {
+	output, err := runAdminCommand(objContext, true, "bucket", "stats", "--bucket", bucket)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get stats of bucket %q", bucket)
+	}
+	stats := bucketVersioningStats{}
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		return false, errors.Wrapf(err, "failed to parse stats of bucket %q", bucket)
+	}
+	switch {
+	case stats.Versioning != nil:
+		// a suspended versioning still keeps the versions of the objects
+		return *stats.Versioning != "off", nil
+	case stats.Versioned != nil:
+		return *stats.Versioned, nil
+	case stats.VersioningEnabled != nil:
+		return *stats.VersioningEnabled, nil
+	}
+	return false, nil
+}
+
+// bucketFullName returns the name of a bucket as radosgw-admin expects it
+func bucketFullName(tenant, bucket string) string {
+	if tenant == "" {
+		return bucket
+	}
+	return tenant + "/" + bucket
+}
+
+func splitBucketFullName(fullName string) (string, string) {
+	if tenant, bucket, ok := strings.Cut(fullName, "/"); ok {
+		return tenant, bucket
+	}
+	return "", fullName
+}
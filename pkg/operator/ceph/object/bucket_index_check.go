@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/util/log"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var defaultBucketIndexCheckInterval = 6 * time.Hour
+
+// allow this to be overridden for unit tests
+var checkBucketIndexesFunc = CheckBucketIndexes
+
+var (
+	largeBucketIndexes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_object_store_large_bucket_indexes",
+		Help: "Number of buckets of an object store over the objects per index shard threshold",
+	}, []string{"namespace", "object_store"})
+	bucketIndexObjectsPerShard = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_object_store_bucket_index_objects_per_shard",
+		Help: "Objects per index shard of the large buckets listed in the object store status",
+	}, []string{"namespace", "object_store", "bucket"})
+	bucketReshards = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_object_store_bucket_reshards",
+		Help: "Number of buckets in the reshard queue of an object store",
+	}, []string{"namespace", "object_store", "status"})
+	bucketReshardsQueued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rook_ceph_object_store_bucket_reshards_queued_total",
+		Help: "Number of manual bucket reshards queued by Rook",
+	}, []string{"namespace", "object_store"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(largeBucketIndexes, bucketIndexObjectsPerShard, bucketReshards, bucketReshardsQueued)
+}
+
+type bucketIndexCheck struct {
+	interval       time.Duration
+	internalCancel context.CancelFunc
+}
+
+type bucketIndexChecker struct {
+	client         client.Client
+	objContext     *Context
+	namespacedName types.NamespacedName
+	interval       time.Duration
+}
+
+// checkBucketIndexes periodically checks the bucket indexes of the object store
+func (c *bucketIndexChecker) checkBucketIndexes(ctx context.Context) {
+	// check the bucket indexes immediately before starting the loop
+	c.checkBucketIndexesOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.NamedInfo(c.namespacedName, logger, "stopping monitoring of bucket indexes")
+			return
+
+		case <-time.After(c.interval):
+			log.NamedDebug(c.namespacedName, logger, "checking bucket indexes")
+			c.checkBucketIndexesOnce(ctx)
+		}
+	}
+}
+
+func (c *bucketIndexChecker) checkBucketIndexesOnce(ctx context.Context) {
+	// the latest spec is used so that a change of the threshold or of the reshard policy does not
+	// need the check to be restarted
+	store := &cephv1.CephObjectStore{}
+	if err := c.client.Get(ctx, c.namespacedName, store); err != nil {
+		if !kerrors.IsNotFound(err) {
+			log.NamedWarning(c.namespacedName, logger, "failed to get object store to check bucket indexes. %v", err)
+		}
+		return
+	}
+	if store.Spec.BucketIndex == nil {
+		return
+	}
+
+	status, queued, err := checkBucketIndexesFunc(c.objContext, store.Spec.BucketIndex)
+	if err != nil {
+		log.NamedWarning(c.namespacedName, logger, "failed to check bucket indexes. %v", err)
+		// keep the last known status so that a transient failure does not hide the large buckets
+		status = &cephv1.ObjectStoreBucketIndexStatus{}
+		if store.Status != nil && store.Status.BucketIndex != nil {
+			status = store.Status.BucketIndex.DeepCopy()
+		}
+		status.Details = err.Error()
+	}
+	if queued > 0 {
+		bucketReshardsQueued.WithLabelValues(c.namespacedName.Namespace, c.namespacedName.Name).Add(float64(queued))
+	}
+	status.LastChecked = time.Now().UTC().Format(time.RFC3339)
+	if err == nil {
+		reportBucketIndexMetrics(c.namespacedName, status)
+	}
+
+	if err := updateBucketIndexStatus(ctx, c.client, c.namespacedName, status); err != nil {
+		log.NamedError(c.namespacedName, logger, "failed to update bucket index status of the object store. %v", err)
+	}
+}
+
+func reportBucketIndexMetrics(nsName types.NamespacedName, status *cephv1.ObjectStoreBucketIndexStatus) {
+	storeLabels := prometheus.Labels{"namespace": nsName.Namespace, "object_store": nsName.Name}
+	largeBucketIndexes.With(storeLabels).Set(float64(status.LargeBucketCount))
+
+	// the buckets no longer large and the reshards that are done must not be reported anymore
+	bucketIndexObjectsPerShard.DeletePartialMatch(storeLabels)
+	for _, bucket := range status.LargeBuckets {
+		bucketIndexObjectsPerShard.WithLabelValues(nsName.Namespace, nsName.Name, bucket.Bucket).Set(float64(bucket.ObjectsPerShard))
+	}
+	reshards := map[string]int{reshardStatusQueued: 0, reshardStatusInProgress: 0}
+	for _, reshard := range status.Reshards {
+		reshards[reshard.Status]++
+	}
+	for reshardStatus, count := range reshards {
+		bucketReshards.WithLabelValues(nsName.Namespace, nsName.Name, reshardStatus).Set(float64(count))
+	}
+}
+
+func deleteBucketIndexMetrics(nsName types.NamespacedName) {
+	storeLabels := prometheus.Labels{"namespace": nsName.Namespace, "object_store": nsName.Name}
+	largeBucketIndexes.DeletePartialMatch(storeLabels)
+	bucketIndexObjectsPerShard.DeletePartialMatch(storeLabels)
+	bucketReshards.DeletePartialMatch(storeLabels)
+	bucketReshardsQueued.DeletePartialMatch(storeLabels)
+}
+
+// startBucketIndexCheck starts the periodic check of the bucket indexes of the object store, or
+// stops it if the check is not configured or disabled. The check is restarted if its interval changed.
+func (r *ReconcileCephObjectStore) startBucketIndexCheck(objContext *Context, store *cephv1.CephObjectStore) {
+	nsName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+	if store.Spec.BucketIndex == nil || store.Spec.BucketIndex.Check.Disabled {
+		r.stopBucketIndexCheck(nsName)
+		if store.Status != nil && store.Status.BucketIndex != nil {
+			if err := updateBucketIndexStatus(r.opManagerContext, r.client, nsName, nil); err != nil {
+				log.NamedWarning(nsName, logger, "failed to reset bucket index status. %v", err)
+			}
+		}
+		return
+	}
+
+	interval := defaultBucketIndexCheckInterval
+	if store.Spec.BucketIndex.Check.Interval != nil {
+		interval = store.Spec.BucketIndex.Check.Interval.Duration
+	}
+
+	if r.bucketIndexChecks == nil {
+		r.bucketIndexChecks = map[string]*bucketIndexCheck{}
+	}
+	key := nsName.String()
+	if check, ok := r.bucketIndexChecks[key]; ok {
+		if check.interval == interval {
+			log.NamedDebug(nsName, logger, "bucket index monitoring go routine already running")
+			return
+		}
+		check.internalCancel()
+	}
+
+	internalCtx, internalCancel := context.WithCancel(r.opManagerContext)
+	r.bucketIndexChecks[key] = &bucketIndexCheck{interval: interval, internalCancel: internalCancel}
+	// the checks are not part of the reconcile that started them
+	checkContext := *objContext
+	checkContext.clusterInfo = objContext.clusterInfo.WithContext(r.opManagerContext)
+	checker := &bucketIndexChecker{
+		client:         r.client,
+		objContext:     &checkContext,
+		namespacedName: nsName,
+		interval:       interval,
+	}
+	log.NamedInfo(nsName, logger, "starting monitoring of bucket indexes every %s", interval.String())
+	go checker.checkBucketIndexes(internalCtx)
+}
+
+// stopBucketIndexCheck stops the bucket index monitoring. This is a noop if monitoring is not running.
+func (r *ReconcileCephObjectStore) stopBucketIndexCheck(nsName types.NamespacedName) {
+	if check, ok := r.bucketIndexChecks[nsName.String()]; ok {
+		check.internalCancel()
+		delete(r.bucketIndexChecks, nsName.String())
+	}
+	deleteBucketIndexMetrics(nsName)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const bucketLimitCheckOutput = `[
+  {
+    "user_id": "alice",
+    "buckets": [
+      {"bucket": "small", "tenant": "", "num_objects": 10, "num_shards": 11, "objects_per_shard": 0, "fill_status": "OK"},
+      {"bucket": "logs", "tenant": "", "num_objects": 1200000, "num_shards": 11, "objects_per_shard": 109090, "fill_status": "OVER 100.000000%"}
+    ]
+  },
+  {
+    "user_id": "team$bob",
+    "buckets": [
+      {"bucket": "archive", "tenant": "team", "num_objects": 950000, "num_shards": 11, "objects_per_shard": 86363, "fill_status": "OK"},
+      {"bucket": "photos", "tenant": "team", "num_objects": 1000000, "num_shards": 11, "objects_per_shard": 90909, "fill_status": "WARN 90.909090%"}
+    ]
+  }
+]`
+
+func TestCheckBucketIndexes(t *testing.T) {
+	var commands []string
+	reshardQueue := `[]`
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			commands = append(commands, strings.Join(args, " "))
+			switch {
+			case slices.Equal(args[:3], []string{"bucket", "limit", "check"}):
+				return bucketLimitCheckOutput, nil
+			case slices.Equal(args[:2], []string{"reshard", "list"}):
+				return reshardQueue, nil
+			case slices.Equal(args[:2], []string{"reshard", "status"}):
+				if args[3] == "team/photos" {
+					return `[{"reshard_status": "in-progress"}, {"reshard_status": "not-resharding"}]`, nil
+				}
+				return `[{"reshard_status": "not-resharding"}]`, nil
+			case slices.Equal(args[:2], []string{"bucket", "stats"}):
+				if args[3] == "logs" {
+					return `{"bucket": "logs", "versioning": "off"}`, nil
+				}
+				return `{"bucket": "photos", "versioned": true}`, nil
+			}
+			return "", nil
+		},
+	}
+	objContext := NewContext(&clusterd.Context{Executor: executor}, &cephclient.ClusterInfo{Namespace: "ns", Context: context.TODO()}, "my-store")
+
+	t.Run("report buckets over the rgw warning threshold", func(t *testing.T) {
+		commands = nil
+		status, queued, err := CheckBucketIndexes(objContext, &cephv1.ObjectStoreBucketIndexSpec{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, queued)
+		assert.Equal(t, 2, status.LargeBucketCount)
+		assert.Equal(t, cephv1.BucketIndexStatus{
+			Bucket: "logs", Owner: "alice", NumObjects: 1200000, NumShards: 11, ObjectsPerShard: 109090, FillStatus: "OVER 100.000000%",
+		}, status.LargeBuckets[0])
+		assert.Equal(t, "team/photos", status.LargeBuckets[1].Bucket)
+		assert.Empty(t, status.Reshards)
+		assert.Contains(t, commands[0], "--warnings-only")
+	})
+
+	t.Run("report buckets over a custom threshold", func(t *testing.T) {
+		threshold := int64(80000)
+		status, _, err := CheckBucketIndexes(objContext, &cephv1.ObjectStoreBucketIndexSpec{ObjectsPerShardThreshold: &threshold})
+		require.NoError(t, err)
+		assert.Equal(t, 3, status.LargeBucketCount)
+		assert.Equal(t, []string{"logs", "team/photos", "team/archive"}, []string{status.LargeBuckets[0].Bucket, status.LargeBuckets[1].Bucket, status.LargeBuckets[2].Bucket})
+	})
+
+	t.Run("reshard versioned buckets", func(t *testing.T) {
+		commands = nil
+		spec := &cephv1.ObjectStoreBucketIndexSpec{Reshard: &cephv1.BucketReshardPolicySpec{VersionedOnly: true}}
+		status, queued, err := CheckBucketIndexes(objContext, spec)
+		require.NoError(t, err)
+		assert.Equal(t, 1, queued)
+		assert.True(t, slices.ContainsFunc(commands, func(command string) bool {
+			return strings.HasPrefix(command, "reshard add --bucket team/photos --num-shards 23 ")
+		}))
+		assert.Len(t, status.Reshards, 1)
+		assert.Equal(t, "team/photos", status.Reshards[0].Bucket)
+		assert.Equal(t, int64(23), status.Reshards[0].NewNumShards)
+		assert.Equal(t, "in-progress", status.Reshards[0].Status)
+	})
+
+	t.Run("buckets already queued are not queued again", func(t *testing.T) {
+		commands = nil
+		reshardQueue = `[{"time": "2026-01-01T00:00:00Z", "tenant": "", "bucket_name": "logs", "old_num_shards": 11, "tentative_new_num_shards": 23}]`
+		defer func() { reshardQueue = `[]` }()
+		spec := &cephv1.ObjectStoreBucketIndexSpec{Reshard: &cephv1.BucketReshardPolicySpec{Buckets: []string{"logs", "other-*"}}}
+		status, queued, err := CheckBucketIndexes(objContext, spec)
+		require.NoError(t, err)
+		assert.Equal(t, 0, queued)
+		for _, command := range commands {
+			assert.NotContains(t, command, "reshard add")
+		}
+		assert.Equal(t, []cephv1.BucketReshardStatus{{Bucket: "logs", OldNumShards: 11, NewNumShards: 23, Status: "queued", QueuedTime: "2026-01-01T00:00:00Z"}}, status.Reshards)
+	})
+}
+
+func TestReshardNumShards(t *testing.T) {
+	policy := &cephv1.BucketReshardPolicySpec{}
+	assert.Equal(t, int64(2), reshardNumShards(policy, 10))
+	assert.Equal(t, int64(23), reshardNumShards(policy, 1000000))
+
+	objectsPerShard := int64(100000)
+	maxShards := int64(7)
+	policy = &cephv1.BucketReshardPolicySpec{ObjectsPerShard: &objectsPerShard, MaxShards: &maxShards}
+	assert.Equal(t, int64(5), reshardNumShards(policy, 500000))
+	assert.Equal(t, int64(7), reshardNumShards(policy, 1000000))
+}
+
+func TestBucketMatchesPatterns(t *testing.T) {
+	match, err := bucketMatchesPatterns("logs", nil)
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = bucketMatchesPatterns("team/photos", []string{"team/*"})
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = bucketMatchesPatterns("team/photos", []string{"*"})
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	_, err = bucketMatchesPatterns("logs", []string{"[logs"})
+	assert.Error(t, err)
+}
+
+func TestStartBucketIndexCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	r := &ReconcileCephObjectStore{
+		opManagerContext: ctx,
+		client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+	}
+	objContext := &Context{clusterInfo: cephclient.AdminTestClusterInfo("ns")}
+	store := &cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: "ns"}}
+
+	t.Run("not configured", func(t *testing.T) {
+		r.startBucketIndexCheck(objContext, store)
+		assert.Empty(t, r.bucketIndexChecks)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		store.Spec.BucketIndex = &cephv1.ObjectStoreBucketIndexSpec{}
+		r.startBucketIndexCheck(objContext, store)
+		require.Contains(t, r.bucketIndexChecks, "ns/store")
+		assert.Equal(t, 6*time.Hour, r.bucketIndexChecks["ns/store"].interval)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		store.Spec.BucketIndex.Check.Disabled = true
+		r.startBucketIndexCheck(objContext, store)
+		assert.Empty(t, r.bucketIndexChecks)
+	})
+}
@@ -85,15 +85,17 @@ var cephObjectStoreDependents = CephObjectStoreDependents
 
 // ReconcileCephObjectStore reconciles a cephObjectStore object
 type ReconcileCephObjectStore struct {
-	client           client.Client
-	bktclient        bktclient.Interface
-	scheme           *runtime.Scheme
-	context          *clusterd.Context
-	clusterSpec      *cephv1.ClusterSpec
-	clusterInfo      *cephclient.ClusterInfo
-	recorder         events.EventRecorder
-	opManagerContext context.Context
-	opConfig         opcontroller.OperatorConfig
+	client             client.Client
+	bktclient          bktclient.Interface
+	scheme             *runtime.Scheme
+	context            *clusterd.Context
+	clusterSpec        *cephv1.ClusterSpec
+	clusterInfo        *cephclient.ClusterInfo
+	recorder           events.EventRecorder
+	opManagerContext   context.Context
+	opConfig           opcontroller.OperatorConfig
+	bucketIndexChecks  map[string]*bucketIndexCheck
+	opsLogStatusChecks map[string]context.CancelFunc
 }
 
 // Add creates a new cephObjectStore Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -106,13 +108,14 @@ func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext contex
 func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) reconcile.Reconciler {
 	context.Client = mgr.GetClient()
 	return &ReconcileCephObjectStore{
-		client:           mgr.GetClient(),
-		scheme:           mgr.GetScheme(),
-		context:          context,
-		bktclient:        bktclient.NewForConfigOrDie(context.KubeConfig),
-		recorder:         mgr.GetEventRecorder("rook-" + controllerName),
-		opManagerContext: opManagerContext,
-		opConfig:         opConfig,
+		client:            mgr.GetClient(),
+		scheme:            mgr.GetScheme(),
+		context:           context,
+		bktclient:         bktclient.NewForConfigOrDie(context.KubeConfig),
+		recorder:          mgr.GetEventRecorder("rook-" + controllerName),
+		opManagerContext:  opManagerContext,
+		opConfig:          opConfig,
+		bucketIndexChecks: make(map[string]*bucketIndexCheck),
 	}
 }
 
@@ -133,7 +136,7 @@ func watchOwnedCoreObject[T client.Object](c controller.Controller, mgr manager.
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -152,6 +155,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Reconcile the object stores whose cephx keys are overdue for rotation in the cephx key inventory
+	err = c.Watch(opcontroller.ReconcileTriggerSource(controllerTypeMeta.Kind))
+	if err != nil {
+		return err
+	}
+
 	// Watch all other resources
 	for _, t := range objectsToWatch {
 		err = watchOwnedCoreObject(c, mgr, t)
@@ -173,6 +182,18 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch configmaps holding the lua scripts of the object store
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: corev1.SchemeGroupVersion.String()}},
+			handler.TypedEnqueueRequestsFromMapFunc(mapLuaScriptConfigMapToCR(mgr.GetClient())),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -263,6 +284,43 @@ func isObjStoreSpecContainsSecret(spec *cephv1.ObjectStoreSpec, secret *corev1.S
 	return false
 }
 
+// Maps configmap referenced by object store lua scripts to the object store CR
+func mapLuaScriptConfigMapToCR(k8sClient client.Client) handler.TypedMapFunc[*corev1.ConfigMap, reconcile.Request] {
+	return func(ctx context.Context, cm *corev1.ConfigMap) []reconcile.Request {
+		objStores := cephv1.CephObjectStoreList{}
+		err := k8sClient.List(ctx, &objStores, client.InNamespace(cm.Namespace))
+		if err != nil {
+			logger.Errorf("failed to list cephObjectStore resources for referenced configmap %q. %v", cm.Name, err)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, objStore := range objStores.Items {
+			if isObjStoreSpecContainsLuaConfigMap(&objStore.Spec, cm.Name) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      objStore.Name,
+						Namespace: objStore.Namespace,
+					},
+				})
+			}
+		}
+		return requests
+	}
+}
+
+func isObjStoreSpecContainsLuaConfigMap(spec *cephv1.ObjectStoreSpec, configMapName string) bool {
+	if spec.LuaScripts == nil {
+		return false
+	}
+	for _, script := range spec.LuaScripts.Scripts {
+		if script.ConfigMapKeyRef.Name == configMapName {
+			return true
+		}
+	}
+	return false
+}
+
 // Reconcile reads the state of the cluster for a cephObjectStore object and makes changes based on the state read
 // and what is in the cephObjectStore.Spec
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
@@ -270,13 +328,13 @@ func isObjStoreSpecContainsSecret(spec *cephv1.ObjectStoreSpec, secret *corev1.S
 func (r *ReconcileCephObjectStore) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
 	defer opcontroller.RecoverAndLogException()
 	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
-	reconcileResponse, objectStore, err := r.reconcile(request)
+	reconcileResponse, objectStore, err := r.reconcile(context, request)
 
 	return reporting.ReportReconcileResult(logger, r.recorder, request,
 		&objectStore, reconcileResponse, err)
 }
 
-func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconcile.Result, cephv1.CephObjectStore, error) {
+func (r *ReconcileCephObjectStore) reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, cephv1.CephObjectStore, error) {
 	// Fetch the cephObjectStore instance
 	cephObjectStore := &cephv1.CephObjectStore{}
 	err := r.client.Get(r.opManagerContext, request.NamespacedName, cephObjectStore)
@@ -348,8 +406,9 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 	}
 	r.clusterSpec = &cephCluster.Spec
 
-	// Populate clusterInfo during each reconcile
-	r.clusterInfo, _, _, err = opcontroller.LoadClusterInfo(r.context, r.opManagerContext, request.NamespacedName.Namespace, r.clusterSpec)
+	// Populate clusterInfo during each reconcile. The Ceph commands and admin ops requests made with
+	// its context are recorded as part of the reconcile.
+	r.clusterInfo, _, _, err = opcontroller.LoadClusterInfo(r.context, ctx, request.NamespacedName.Namespace, r.clusterSpec)
 	if err != nil {
 		return reconcile.Result{}, *cephObjectStore, errors.Wrap(err, "failed to populate cluster info")
 	}
@@ -367,7 +426,7 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 			return reconcile.Result{}, *cephObjectStore, errors.Wrapf(err, "failed to retrieve current ceph %q version", config.MonType)
 		}
 		r.clusterInfo.CephVersion = runningCephVersion
-		r.clusterInfo.Context = r.opManagerContext
+		r.clusterInfo.Context = ctx
 
 		// get the latest version of the object to check dependencies
 		err = r.client.Get(r.opManagerContext, request.NamespacedName, cephObjectStore)
@@ -402,6 +461,9 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 			}
 		}
 
+		r.stopBucketIndexCheck(request.NamespacedName)
+		r.stopOpsLogStatusCheck(request.NamespacedName)
+
 		cfg := clusterConfig{
 			context:     r.context,
 			store:       cephObjectStore,
@@ -431,7 +493,7 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 	} else {
 		// Detect desired CephCluster version
 		runningCephVersion, desiredCephVersion, err := currentAndDesiredCephVersion(
-			r.opManagerContext,
+			ctx,
 			r.opConfig.Image,
 			cephObjectStore.Namespace,
 			controllerName,
@@ -453,16 +515,25 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 		// then versions should match. Obviously using the cmd reporter job adds up to the deployment time
 		// Skip waiting for upgrades to finish in case of external cluster.
 		if !cephCluster.Spec.External.Enable && !reflect.DeepEqual(*runningCephVersion, *desiredCephVersion) {
-			// Upgrade is in progress, let's wait for the mons to be done
-			return opcontroller.WaitForRequeueIfCephClusterIsUpgrading,
-				*cephObjectStore,
-				opcontroller.ErrorCephUpgradingRequeue(desiredCephVersion, runningCephVersion)
+			// The canary RGWs of a canary upgrade are upgraded before all the mons are upgraded
+			isCanary, err := r.isCanaryUpgradeRGW(cephObjectStore)
+			if err != nil {
+				return reconcile.Result{}, *cephObjectStore, errors.Wrap(err, "failed to check if the rgw is a canary of the cluster upgrade")
+			}
+			if !isCanary {
+				// Upgrade is in progress, let's wait for the mons to be done
+				return opcontroller.WaitForRequeueIfCephClusterIsUpgrading,
+					*cephObjectStore,
+					opcontroller.ErrorCephUpgradingRequeue(desiredCephVersion, runningCephVersion)
+			}
+			log.NamedInfo(request.NamespacedName, logger, "upgrading the rgw daemons as canary of the cluster upgrade")
 		}
 		r.clusterInfo.CephVersion = *runningCephVersion
 
 		// daemon key type always takes the default from setDefaultCephxKeyType()
 		shouldRotateCephxKeys, err = keyring.ShouldRotateCephxKeys(
-			cephCluster.Spec.Security.CephX.Daemon, *runningCephVersion, *desiredCephVersion, cephObjectStore.Status.Cephx.Daemon, true, r.clusterInfo.Namespace)
+			cephCluster.Spec.Security.CephX.Daemon, *runningCephVersion, *desiredCephVersion, cephObjectStore.Status.Cephx.Daemon, true, r.clusterInfo.Namespace,
+			keyring.CephxKeyOwner("CephObjectStore", cephObjectStore.Name, ""))
 		if err != nil {
 			return reconcile.Result{}, *cephObjectStore, errors.Wrap(err, "failed to determine if cephx keys should be rotated")
 		}
@@ -482,6 +553,7 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 		clusterInfo:           r.clusterInfo,
 		store:                 cephObjectStore,
 		rookVersion:           r.clusterSpec.CephVersion.Image,
+		rookImage:             r.opConfig.Image,
 		clusterSpec:           r.clusterSpec,
 		DataPathMap:           config.NewStatelessDaemonDataPathMap(config.RgwType, cephObjectStore.Name, cephObjectStore.Namespace, r.clusterSpec.DataDirHostPath),
 		client:                r.client,
@@ -658,11 +730,51 @@ func (r *ReconcileCephObjectStore) reconcileCreateObjectStore(cephObjectStore *c
 				return reconcile.Result{}, errors.Wrapf(err, "failed to set realm %q as default", realmName)
 			}
 		}
+
+		// Reconcile the lua scripts. The installed scripts are recorded even on failure so that
+		// the ones already updated are not installed again.
+		luaScripts, luaErr := reconcileLuaScripts(objContext, cephObjectStore)
+		var installedLuaScripts *cephv1.ObjectStoreLuaScriptsStatus
+		if cephObjectStore.Status != nil {
+			installedLuaScripts = cephObjectStore.Status.LuaScripts
+		}
+		if !reflect.DeepEqual(luaScripts, installedLuaScripts) {
+			if err := updateLuaScriptsStatus(r.opManagerContext, r.client, namespacedName, luaScripts); err != nil {
+				return reconcile.Result{}, errors.Wrap(err, "failed to update lua scripts status")
+			}
+		}
+		if luaErr != nil {
+			return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "failed to reconcile lua scripts", luaErr)
+		}
+
+		// The global rate limits are set through the admin ops API, which is only available once
+		// the RGWs are running, so wait for them rather than failing the reconcile.
+		if err := reconcileGlobalRateLimits(objContext, cephObjectStore); err != nil {
+			return waitForRequeueIfObjectStoreNotReady, errors.Wrap(err, "failed to reconcile global rate limits")
+		}
+
+		r.startBucketIndexCheck(objContext, cephObjectStore)
+		r.startOpsLogStatusCheck(cephObjectStore)
 	}
 
 	return reconcile.Result{}, nil
 }
 
+// isCanaryUpgradeRGW returns whether the rgw of the store is upgraded in the canary stage of a canary
+// upgrade of the cluster, in which case it is not held back by the upgrade while the other daemons are
+func (r *ReconcileCephObjectStore) isCanaryUpgradeRGW(store *cephv1.CephObjectStore) (bool, error) {
+	holding, err := opcontroller.IsCanaryUpgradeHoldingDaemons(r.opManagerContext, r.context, store.Namespace)
+	if err != nil || !holding {
+		return false, err
+	}
+
+	rgwsHeldByUpgrade, err := opcontroller.GetDaemonsHeldByCanaryUpgrade(r.opManagerContext, r.context, store.Namespace, config.RgwType, AppName)
+	if err != nil {
+		return false, err
+	}
+	return !rgwsHeldByUpgrade.Has(store.Name), nil
+}
+
 func (r *ReconcileCephObjectStore) retrieveMultisiteZone(store *cephv1.CephObjectStore, zoneGroupName string, realmName string) (reconcile.Result, error) {
 	nsName := opcontroller.NsName(store.Namespace, store.Name)
 	realmArg := fmt.Sprintf("--rgw-realm=%s", realmName)
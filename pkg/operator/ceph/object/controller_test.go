@@ -610,8 +610,8 @@ func TestCephObjectStoreControllerMultisite(t *testing.T) {
 			MetadataPool: metadataPool,
 			DataPool:     dataPool,
 		},
-		Status: &cephv1.Status{
-			Phase: k8sutil.ReadyStatus,
+		Status: &cephv1.ObjectZoneStatus{
+			Status: cephv1.Status{Phase: k8sutil.ReadyStatus},
 		},
 	}
 
@@ -918,12 +918,12 @@ func TestCephObjectStoreControllerZoneNotReady(t *testing.T) {
 
 	zoneNotReadyPhases := []struct {
 		name   string
-		status *cephv1.Status
+		status *cephv1.ObjectZoneStatus
 	}{
 		{"zone status is nil", nil},
-		{"zone is reconciling", &cephv1.Status{Phase: k8sutil.ReconcilingStatus}},
-		{"zone reconcile failed", &cephv1.Status{Phase: k8sutil.ReconcileFailedStatus}},
-		{"zone status is empty", &cephv1.Status{Phase: k8sutil.EmptyStatus}},
+		{"zone is reconciling", &cephv1.ObjectZoneStatus{Status: cephv1.Status{Phase: k8sutil.ReconcilingStatus}}},
+		{"zone reconcile failed", &cephv1.ObjectZoneStatus{Status: cephv1.Status{Phase: k8sutil.ReconcileFailedStatus}}},
+		{"zone status is empty", &cephv1.ObjectZoneStatus{Status: cephv1.Status{Phase: k8sutil.EmptyStatus}}},
 	}
 
 	for _, tc := range zoneNotReadyPhases {
@@ -1478,8 +1478,8 @@ func TestKeyRotation(t *testing.T) {
 			MetadataPool: metadataPool,
 			DataPool:     dataPool,
 		},
-		Status: &cephv1.Status{
-			Phase: k8sutil.ReadyStatus,
+		Status: &cephv1.ObjectZoneStatus{
+			Status: cephv1.Status{Phase: k8sutil.ReadyStatus},
 		},
 	}
 
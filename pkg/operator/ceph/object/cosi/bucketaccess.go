@@ -0,0 +1,561 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	cosiapi "sigs.k8s.io/container-object-storage-interface/client/apis"
+	cosiv1alpha1 "sigs.k8s.io/container-object-storage-interface/client/apis/objectstorage/v1alpha1"
+	cosiclient "sigs.k8s.io/container-object-storage-interface/client/clientset/versioned"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CephCOSIAccountDriverName is the driver name of the BucketAccessClasses whose BucketAccesses
+	// are granted by the operator to users of a CephObjectStoreAccount
+	CephCOSIAccountDriverName = CephCOSIDriverPrefix + ".ceph-account.objectstorage.k8s.io"
+
+	// BucketAccessClass parameters of the account driver
+	accountNameParam      = "objectStoreAccountName"
+	accountNamespaceParam = "objectStoreAccountNamespace"
+	accessPolicyParam     = "accessPolicy"
+	prefixParam           = "prefix"
+
+	bucketAccessFinalizer = "ceph.rook.io/cosi-bucket-access"
+	// annotations recording where the access was granted so that it can be revoked even if the
+	// BucketAccessClass or the bucket claim is gone
+	accountAnnotation = "ceph.rook.io/cosi-account"
+	bucketAnnotation  = "ceph.rook.io/cosi-bucket"
+
+	bucketAccessUserPrefix    = "ba-"
+	bucketAccessPolicyName    = "cosi-bucket-access"
+	bucketAccessCheckInterval = 30 * time.Second
+	bucketInfoSecretKey       = "BucketInfo"
+)
+
+// accessPolicy is the set of operations a BucketAccess grants on the bucket
+type accessPolicy string
+
+const (
+	accessPolicyReadWrite accessPolicy = "ReadWrite"
+	accessPolicyReadOnly  accessPolicy = "ReadOnly"
+	accessPolicyWriteOnly accessPolicy = "WriteOnly"
+)
+
+// bucketAccessParams are the settings of a BucketAccessClass of the account driver
+type bucketAccessParams struct {
+	account types.NamespacedName
+	policy  accessPolicy
+	prefix  string
+}
+
+// bucketAccessReconciler grants and revokes the BucketAccesses of the account driver. The COSI
+// CRDs are optional, so the BucketAccesses are listed periodically instead of being watched.
+type bucketAccessReconciler struct {
+	context    *clusterd.Context
+	client     client.Client
+	cosiClient cosiclient.Interface
+}
+
+func newBucketAccessReconciler(context *clusterd.Context, k8sClient client.Client, cosiClient cosiclient.Interface) *bucketAccessReconciler {
+	return &bucketAccessReconciler{context: context, client: k8sClient, cosiClient: cosiClient}
+}
+
+// run reconciles the BucketAccesses until the context is done
+func (b *bucketAccessReconciler) run(ctx context.Context) {
+	wait.UntilWithContext(ctx, b.reconcileAll, bucketAccessCheckInterval)
+}
+
+func (b *bucketAccessReconciler) reconcileAll(ctx context.Context) {
+	accesses, err := b.cosiClient.ObjectstorageV1alpha1().BucketAccesses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		// the COSI controller may not be installed
+		logger.Debugf("failed to list bucket accesses. %v", err)
+		return
+	}
+	for i := range accesses.Items {
+		ba := &accesses.Items[i]
+		if err := b.reconcileBucketAccess(ctx, ba); err != nil {
+			logger.Errorf("failed to reconcile bucket access %q. %v", types.NamespacedName{Namespace: ba.Namespace, Name: ba.Name}, err)
+		}
+	}
+}
+
+func (b *bucketAccessReconciler) reconcileBucketAccess(ctx context.Context, ba *cosiv1alpha1.BucketAccess) error {
+	if !ba.DeletionTimestamp.IsZero() {
+		if !slices.Contains(ba.Finalizers, bucketAccessFinalizer) {
+			return nil
+		}
+		return b.revoke(ctx, ba)
+	}
+
+	bac, err := b.cosiClient.ObjectstorageV1alpha1().BucketAccessClasses().Get(ctx, ba.Spec.BucketAccessClassName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get bucket access class %q", ba.Spec.BucketAccessClassName)
+	}
+	if bac.DriverName != CephCOSIAccountDriverName {
+		return nil
+	}
+	if ba.Status.AccessGranted && slices.Contains(ba.Finalizers, bucketAccessFinalizer) {
+		return nil
+	}
+	return b.grant(ctx, ba, bac)
+}
+
+// grant creates the account user of a BucketAccess, attaches its policies and publishes its
+// credentials. Every step is idempotent so that a failed grant is completed at the next check.
+func (b *bucketAccessReconciler) grant(ctx context.Context, ba *cosiv1alpha1.BucketAccess, bac *cosiv1alpha1.BucketAccessClass) error {
+	nsName := types.NamespacedName{Namespace: ba.Namespace, Name: ba.Name}
+	params, err := parseBucketAccessParams(bac)
+	if err != nil {
+		return err
+	}
+	if ba.Spec.CredentialsSecretName == "" {
+		return errors.New("credentialsSecretName is not set")
+	}
+
+	bucketName, err := b.bucketName(ctx, ba)
+	if err != nil {
+		return err
+	}
+	if bucketName == "" {
+		logger.Debugf("bucket of bucket access %q is not ready yet", nsName)
+		return nil
+	}
+
+	account, err := b.getAccount(ctx, params.account)
+	if err != nil {
+		return err
+	}
+
+	// record the account and bucket before anything is created in RGW
+	ba, err = b.setFinalizer(ctx, ba, params.account, bucketName)
+	if err != nil {
+		return err
+	}
+
+	opsCtx, store, err := b.objectStoreContext(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	// the account can only be granted access to the buckets of its own object store
+	if err := checkBucketInStore(ctx, opsCtx, bucketName); err != nil {
+		return err
+	}
+
+	userName := bucketAccessUserName(ba)
+	user, err := opsCtx.AdminOpsClient.GetUser(ctx, admin.User{ID: userName})
+	if err != nil {
+		if !errors.Is(err, admin.ErrNoSuchUser) {
+			return errors.Wrapf(err, "failed to get user %q", userName)
+		}
+		user, err = opsCtx.AdminOpsClient.CreateUser(ctx, admin.User{
+			ID:          userName,
+			DisplayName: userName,
+			AccountID:   account.Status.AccountID,
+			GenerateKey: ptr.To(true),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create user %q in account %q", userName, account.Status.AccountID)
+		}
+		logger.Infof("created user %q in account %q for bucket access %q", userName, account.Status.AccountID, nsName)
+	}
+	if len(user.Keys) == 0 {
+		return errors.Errorf("no keys set for user %q", userName)
+	}
+
+	statements := accessPolicyStatements(userName, bucketName, params.prefix, params.policy)
+
+	// account users have no permissions by default, the identity policy grants them the access
+	iamAgent, err := b.accountIAMAgent(ctx, account, opsCtx, store)
+	if err != nil {
+		return err
+	}
+	if err := iamAgent.PutUserPolicy(ctx, userName, bucketAccessPolicyName, *object.NewBucketPolicy(statements...)); err != nil {
+		return errors.Wrapf(err, "failed to attach policy to user %q", userName)
+	}
+
+	// the bucket is owned outside of the account, so the bucket policy must allow the access as well
+	for i := range statements {
+		statements[i].ForAccountPrincipals(account.Status.AccountID, userName)
+	}
+	if err := b.updateBucketPolicy(ctx, opsCtx, store, bucketName, statementIDs(userName), statements); err != nil {
+		return err
+	}
+
+	if err := b.publishCredentials(ctx, ba, bucketName, opsCtx.Endpoint, user.Keys[0]); err != nil {
+		return err
+	}
+
+	ba.Status.AccessGranted = true
+	ba.Status.AccountID = userName
+	if _, err := b.cosiClient.ObjectstorageV1alpha1().BucketAccesses(ba.Namespace).UpdateStatus(ctx, ba, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update bucket access status")
+	}
+	logger.Infof("granted %s access to bucket %q for bucket access %q", params.policy, bucketName, nsName)
+	return nil
+}
+
+// revoke removes the account user of a BucketAccess and its bucket policy statements
+func (b *bucketAccessReconciler) revoke(ctx context.Context, ba *cosiv1alpha1.BucketAccess) error {
+	nsName := types.NamespacedName{Namespace: ba.Namespace, Name: ba.Name}
+	userName := bucketAccessUserName(ba)
+	accountName, bucketName := parseNamespacedName(ba.Annotations[accountAnnotation]), ba.Annotations[bucketAnnotation]
+
+	account, err := b.getAccount(ctx, accountName)
+	if err != nil {
+		if !kerrors.IsNotFound(errors.Cause(err)) {
+			return err
+		}
+		// the users of the account were deleted with it
+		logger.Warningf("account %q of bucket access %q not found, skipping revocation", accountName, nsName)
+		return b.removeFinalizer(ctx, ba)
+	}
+
+	opsCtx, store, err := b.objectStoreContext(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if bucketName != "" {
+		if err := b.updateBucketPolicy(ctx, opsCtx, store, bucketName, statementIDs(userName), nil); err != nil {
+			return err
+		}
+	}
+
+	iamAgent, err := b.accountIAMAgent(ctx, account, opsCtx, store)
+	if err != nil {
+		// the identity policy of the user is removed with it
+		logger.Warningf("failed to get iam client of account %q to remove the policy of user %q. %v", accountName, userName, err)
+	} else if err := iamAgent.DeleteUserPolicy(ctx, userName, bucketAccessPolicyName); err != nil {
+		return errors.Wrapf(err, "failed to remove policy of user %q", userName)
+	}
+
+	err = opsCtx.AdminOpsClient.RemoveUser(ctx, admin.User{ID: userName})
+	if err != nil && !errors.Is(err, admin.ErrNoSuchUser) {
+		return errors.Wrapf(err, "failed to remove user %q", userName)
+	}
+	logger.Infof("revoked access to bucket %q for bucket access %q", bucketName, nsName)
+
+	return b.removeFinalizer(ctx, ba)
+}
+
+// bucketName returns the name of the RGW bucket of a BucketAccess, or an empty name if the bucket
+// is not ready yet
+func (b *bucketAccessReconciler) bucketName(ctx context.Context, ba *cosiv1alpha1.BucketAccess) (string, error) {
+	claim, err := b.cosiClient.ObjectstorageV1alpha1().BucketClaims(ba.Namespace).Get(ctx, ba.Spec.BucketClaimName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get bucket claim %q", ba.Spec.BucketClaimName)
+	}
+	if !claim.Status.BucketReady || claim.Status.BucketName == "" {
+		return "", nil
+	}
+	bucket, err := b.cosiClient.ObjectstorageV1alpha1().Buckets().Get(ctx, claim.Status.BucketName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get bucket %q", claim.Status.BucketName)
+	}
+	if !bucket.Status.BucketReady {
+		return "", nil
+	}
+	return bucket.Status.BucketID, nil
+}
+
+// checkBucketInStore returns an error if the bucket is not in the object store of the admin ops
+// context
+func checkBucketInStore(ctx context.Context, opsCtx *object.AdminOpsContext, bucketName string) error {
+	_, err := opsCtx.AdminOpsClient.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
+	if err != nil {
+		if errors.Is(err, admin.ErrNoSuchBucket) {
+			return errors.Errorf("bucket %q is not in object store %q", bucketName, opsCtx.Name)
+		}
+		return errors.Wrapf(err, "failed to get bucket %q", bucketName)
+	}
+	return nil
+}
+
+func (b *bucketAccessReconciler) getAccount(ctx context.Context, name types.NamespacedName) (*cephv1.CephObjectStoreAccount, error) {
+	account := &cephv1.CephObjectStoreAccount{}
+	if err := b.client.Get(ctx, name, account); err != nil {
+		return nil, errors.Wrapf(err, "failed to get CephObjectStoreAccount %q", name)
+	}
+	if account.Status == nil || account.Status.AccountID == "" {
+		return nil, errors.Errorf("CephObjectStoreAccount %q is not ready", name)
+	}
+	return account, nil
+}
+
+func (b *bucketAccessReconciler) objectStoreContext(ctx context.Context, account *cephv1.CephObjectStoreAccount) (*object.AdminOpsContext, *cephv1.CephObjectStore, error) {
+	nsName := types.NamespacedName{Namespace: account.Namespace, Name: account.Name}
+	cephCluster, isReadyToReconcile, _, _ := opcontroller.IsReadyToReconcile(ctx, b.client, nsName, controllerName)
+	if !isReadyToReconcile {
+		return nil, nil, errors.Errorf("ceph cluster of account %q is not ready", nsName)
+	}
+	clusterInfo, _, _, err := opcontroller.LoadClusterInfo(b.context, ctx, account.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to populate cluster info")
+	}
+	opsCtx, store, err := object.InitializeObjectStoreContext(b.context, clusterInfo, b.client, ctx, account.Spec.Store, object.NewMultisiteAdminOpsContext)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to initialize object store %q", account.Spec.Store)
+	}
+	return opsCtx, store, nil
+}
+
+// accountIAMAgent returns an IAM client with the credentials of the account root user
+func (b *bucketAccessReconciler) accountIAMAgent(ctx context.Context, account *cephv1.CephObjectStoreAccount, opsCtx *object.AdminOpsContext, store *cephv1.CephObjectStore) (*object.IAMAgent, error) {
+	if account.Status.RootAccountSecretName == "" {
+		return nil, errors.Errorf("CephObjectStoreAccount %q has no root user to manage the user policies", account.Name)
+	}
+	secret, err := b.context.Clientset.CoreV1().Secrets(account.Namespace).Get(ctx, account.Status.RootAccountSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get root user secret of account %q", account.Name)
+	}
+	tlsCert, insecureTLS, err := storeTLS(opsCtx, store)
+	if err != nil {
+		return nil, err
+	}
+	return object.NewIAMAgent(string(secret.Data["AccessKey"]), string(secret.Data["SecretKey"]), opsCtx.Endpoint, tlsCert, insecureTLS, nil), nil
+}
+
+// updateBucketPolicy replaces the statements of a bucket access in the bucket policy with the
+// given statements, keeping the statements of the other consumers of the bucket
+func (b *bucketAccessReconciler) updateBucketPolicy(ctx context.Context, opsCtx *object.AdminOpsContext, store *cephv1.CephObjectStore, bucketName string, sids []string, statements []object.PolicyStatement) error {
+	bucket, err := opsCtx.AdminOpsClient.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
+	if err != nil {
+		if errors.Is(err, admin.ErrNoSuchBucket) && len(statements) == 0 {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get bucket %q", bucketName)
+	}
+	owner, err := opsCtx.AdminOpsClient.GetUser(ctx, admin.User{ID: bucket.Owner})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get owner %q of bucket %q", bucket.Owner, bucketName)
+	}
+	if len(owner.Keys) == 0 {
+		return errors.Errorf("no keys set for owner %q of bucket %q", bucket.Owner, bucketName)
+	}
+	tlsCert, insecureTLS, err := storeTLS(opsCtx, store)
+	if err != nil {
+		return err
+	}
+	s3Agent, err := object.NewS3Agent(owner.Keys[0].AccessKey, owner.Keys[0].SecretKey, opsCtx.Endpoint, logger.LevelAt(capnslog.DEBUG), tlsCert, insecureTLS, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create s3 client of the bucket owner")
+	}
+
+	policy, err := s3Agent.GetBucketPolicy(ctx, bucketName)
+	if err != nil {
+		var apiErr smithy.APIError
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "NoSuchBucketPolicy" {
+			return errors.Wrapf(err, "failed to get policy of bucket %q", bucketName)
+		}
+		policy = object.NewBucketPolicy()
+	}
+	policy.DropPolicyStatements(sids...)
+	policy.Statement = append(policy.Statement, statements...)
+
+	if len(policy.Statement) == 0 {
+		if _, err := s3Agent.Client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{Bucket: &bucketName}); err != nil {
+			return errors.Wrapf(err, "failed to delete policy of bucket %q", bucketName)
+		}
+		return nil
+	}
+	if _, err := s3Agent.PutBucketPolicy(ctx, bucketName, *policy); err != nil {
+		return errors.Wrapf(err, "failed to set policy of bucket %q", bucketName)
+	}
+	return nil
+}
+
+// publishCredentials writes the COSI BucketInfo of the access to the credentials secret
+func (b *bucketAccessReconciler) publishCredentials(ctx context.Context, ba *cosiv1alpha1.BucketAccess, bucketName, endpoint string, key admin.UserKeySpec) error {
+	bucketInfo := cosiapi.BucketInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("bc-%s", ba.UID)},
+		Spec: cosiapi.BucketInfoSpec{
+			BucketName:         bucketName,
+			AuthenticationType: cosiv1alpha1.AuthenticationTypeKey,
+			S3: &cosiapi.SecretS3{
+				Endpoint:        endpoint,
+				Region:          object.CephRegion,
+				AccessKeyID:     key.AccessKey,
+				AccessSecretKey: key.SecretKey,
+			},
+			Protocols: []cosiv1alpha1.Protocol{cosiv1alpha1.ProtocolS3},
+		},
+	}
+	data, err := json.Marshal(bucketInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize bucket info")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ba.Spec.CredentialsSecretName,
+			Namespace: ba.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: cosiv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "BucketAccess",
+				Name:       ba.Name,
+				UID:        ba.UID,
+			}},
+		},
+		StringData: map[string]string{bucketInfoSecretKey: string(data)},
+		Type:       corev1.SecretTypeOpaque,
+	}
+	if _, err := k8sutil.CreateOrUpdateSecret(ctx, b.context.Clientset, secret); err != nil {
+		return errors.Wrapf(err, "failed to publish credentials to secret %q", secret.Name)
+	}
+	return nil
+}
+
+func (b *bucketAccessReconciler) setFinalizer(ctx context.Context, ba *cosiv1alpha1.BucketAccess, account types.NamespacedName, bucketName string) (*cosiv1alpha1.BucketAccess, error) {
+	if slices.Contains(ba.Finalizers, bucketAccessFinalizer) && ba.Annotations[bucketAnnotation] == bucketName {
+		return ba, nil
+	}
+	ba = ba.DeepCopy()
+	if !slices.Contains(ba.Finalizers, bucketAccessFinalizer) {
+		ba.Finalizers = append(ba.Finalizers, bucketAccessFinalizer)
+	}
+	if ba.Annotations == nil {
+		ba.Annotations = map[string]string{}
+	}
+	ba.Annotations[accountAnnotation] = account.String()
+	ba.Annotations[bucketAnnotation] = bucketName
+	updated, err := b.cosiClient.ObjectstorageV1alpha1().BucketAccesses(ba.Namespace).Update(ctx, ba, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add finalizer to bucket access")
+	}
+	return updated, nil
+}
+
+func (b *bucketAccessReconciler) removeFinalizer(ctx context.Context, ba *cosiv1alpha1.BucketAccess) error {
+	ba = ba.DeepCopy()
+	ba.Finalizers = slices.DeleteFunc(ba.Finalizers, func(f string) bool { return f == bucketAccessFinalizer })
+	if _, err := b.cosiClient.ObjectstorageV1alpha1().BucketAccesses(ba.Namespace).Update(ctx, ba, metav1.UpdateOptions{}); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to remove finalizer from bucket access")
+	}
+	return nil
+}
+
+func parseBucketAccessParams(bac *cosiv1alpha1.BucketAccessClass) (bucketAccessParams, error) {
+	if bac.AuthenticationType != "" && !strings.EqualFold(string(bac.AuthenticationType), string(cosiv1alpha1.AuthenticationTypeKey)) {
+		return bucketAccessParams{}, errors.Errorf("authentication type %q of bucket access class %q is not supported", bac.AuthenticationType, bac.Name)
+	}
+	params := bucketAccessParams{
+		account: types.NamespacedName{Namespace: bac.Parameters[accountNamespaceParam], Name: bac.Parameters[accountNameParam]},
+		policy:  accessPolicy(bac.Parameters[accessPolicyParam]),
+		prefix:  strings.TrimPrefix(bac.Parameters[prefixParam], "/"),
+	}
+	if params.account.Name == "" || params.account.Namespace == "" {
+		return bucketAccessParams{}, errors.Errorf("bucket access class %q must set %q and %q", bac.Name, accountNameParam, accountNamespaceParam)
+	}
+	switch params.policy {
+	case "":
+		params.policy = accessPolicyReadWrite
+	case accessPolicyReadWrite, accessPolicyReadOnly, accessPolicyWriteOnly:
+	default:
+		return bucketAccessParams{}, errors.Errorf("invalid %s %q of bucket access class %q, must be one of %s, %s or %s",
+			accessPolicyParam, params.policy, bac.Name, accessPolicyReadWrite, accessPolicyReadOnly, accessPolicyWriteOnly)
+	}
+	return params, nil
+}
+
+// accessPolicyStatements returns the least-privilege statements of an access policy on a bucket,
+// restricted to the keys starting with prefix
+func accessPolicyStatements(userName, bucketName, prefix string, policy accessPolicy) []object.PolicyStatement {
+	sids := statementIDs(userName)
+	objects := fmt.Sprintf("%s/%s*", bucketName, prefix)
+
+	var statements []object.PolicyStatement
+	if policy != accessPolicyWriteOnly {
+		list := object.NewPolicyStatement().WithSID(sids[0]).Allows().ForResources(bucketName)
+		if policy == accessPolicyReadWrite {
+			list.Actions(object.ListBucket, object.ListBucketVersions, object.ListBucketMultiPartUploads)
+		} else {
+			list.Actions(object.ListBucket, object.ListBucketVersions)
+		}
+		if prefix != "" {
+			list.WithCondition("StringLike", "s3:prefix", prefix+"*")
+		}
+		statements = append(statements, *list)
+	}
+
+	objectStatement := object.NewPolicyStatement().WithSID(sids[1]).Allows().ForResources(objects)
+	switch policy {
+	case accessPolicyReadOnly:
+		objectStatement.Actions(object.GetObject, object.GetObjectVersion)
+	case accessPolicyWriteOnly:
+		objectStatement.Actions(object.PutObject, object.AbortMultipartUpload, object.ListMultipartUploadParts)
+	default:
+		objectStatement.Actions(object.GetObject, object.GetObjectVersion, object.PutObject, object.DeleteObject,
+			object.DeleteObjectVersion, object.AbortMultipartUpload, object.ListMultipartUploadParts)
+	}
+	return append(statements, *objectStatement)
+}
+
+// statementIDs returns the IDs of the bucket policy statements of a bucket access user
+func statementIDs(userName string) []string {
+	return []string{userName + "-list", userName + "-objects"}
+}
+
+func bucketAccessUserName(ba *cosiv1alpha1.BucketAccess) string {
+	return bucketAccessUserPrefix + string(ba.UID)
+}
+
+func parseNamespacedName(s string) types.NamespacedName {
+	namespace, name, found := strings.Cut(s, string(types.Separator))
+	if !found {
+		return types.NamespacedName{Name: s}
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+func storeTLS(opsCtx *object.AdminOpsContext, store *cephv1.CephObjectStore) ([]byte, bool, error) {
+	if !store.Spec.IsTLSEnabled() {
+		return nil, false, nil
+	}
+	tlsCert, insecureTLS, err := object.GetTlsCaCert(&opsCtx.Context, &store.Spec)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to fetch TLS certificate for the object store")
+	}
+	return tlsCert, insecureTLS, nil
+}
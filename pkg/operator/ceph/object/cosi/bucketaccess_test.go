@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cosiv1alpha1 "sigs.k8s.io/container-object-storage-interface/client/apis/objectstorage/v1alpha1"
+	cosifake "sigs.k8s.io/container-object-storage-interface/client/clientset/versioned/fake"
+)
+
+func TestParseBucketAccessParams(t *testing.T) {
+	bac := &cosiv1alpha1.BucketAccessClass{
+		ObjectMeta:         metav1.ObjectMeta{Name: "bac"},
+		DriverName:         CephCOSIAccountDriverName,
+		AuthenticationType: cosiv1alpha1.AuthenticationTypeKey,
+		Parameters: map[string]string{
+			accountNameParam:      "team-a",
+			accountNamespaceParam: "rook-ceph",
+		},
+	}
+
+	t.Run("defaults to read write", func(t *testing.T) {
+		params, err := parseBucketAccessParams(bac)
+		assert.NoError(t, err)
+		assert.Equal(t, types.NamespacedName{Namespace: "rook-ceph", Name: "team-a"}, params.account)
+		assert.Equal(t, accessPolicyReadWrite, params.policy)
+		assert.Empty(t, params.prefix)
+	})
+
+	t.Run("policy and prefix", func(t *testing.T) {
+		bac := bac.DeepCopy()
+		bac.Parameters[accessPolicyParam] = "ReadOnly"
+		bac.Parameters[prefixParam] = "/logs/"
+		params, err := parseBucketAccessParams(bac)
+		assert.NoError(t, err)
+		assert.Equal(t, accessPolicyReadOnly, params.policy)
+		assert.Equal(t, "logs/", params.prefix)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		bac := bac.DeepCopy()
+		bac.Parameters[accessPolicyParam] = "Admin"
+		_, err := parseBucketAccessParams(bac)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		bac := bac.DeepCopy()
+		delete(bac.Parameters, accountNamespaceParam)
+		_, err := parseBucketAccessParams(bac)
+		assert.Error(t, err)
+	})
+
+	t.Run("iam authentication is not supported", func(t *testing.T) {
+		bac := bac.DeepCopy()
+		bac.AuthenticationType = cosiv1alpha1.AuthenticationTypeIAM
+		_, err := parseBucketAccessParams(bac)
+		assert.Error(t, err)
+	})
+}
+
+func TestAccessPolicyStatements(t *testing.T) {
+	actions := func(ps object.PolicyStatement) []string {
+		var a []string
+		for _, action := range ps.Action {
+			a = append(a, string(action))
+		}
+		return a
+	}
+
+	t.Run("read only with prefix", func(t *testing.T) {
+		statements := accessPolicyStatements("ba-1", "bucket", "logs/", accessPolicyReadOnly)
+		assert.Len(t, statements, 2)
+		assert.Equal(t, "ba-1-list", statements[0].Sid)
+		assert.Equal(t, []string{"arn:aws:s3:::bucket"}, statements[0].Resource)
+		assert.Equal(t, []string{"s3:ListBucket", "s3:ListBucketVersions"}, actions(statements[0]))
+		assert.Equal(t, map[string]map[string][]string{"StringLike": {"s3:prefix": {"logs/*"}}}, statements[0].Condition)
+		assert.Equal(t, "ba-1-objects", statements[1].Sid)
+		assert.Equal(t, []string{"arn:aws:s3:::bucket/logs/*"}, statements[1].Resource)
+		assert.Equal(t, []string{"s3:GetObject", "s3:GetObjectVersion"}, actions(statements[1]))
+	})
+
+	t.Run("write only cannot list or read", func(t *testing.T) {
+		statements := accessPolicyStatements("ba-1", "bucket", "", accessPolicyWriteOnly)
+		assert.Len(t, statements, 1)
+		assert.Equal(t, []string{"arn:aws:s3:::bucket/*"}, statements[0].Resource)
+		assert.NotContains(t, actions(statements[0]), "s3:GetObject")
+		assert.Contains(t, actions(statements[0]), "s3:PutObject")
+		assert.NotContains(t, actions(statements[0]), "s3:DeleteObject")
+	})
+
+	t.Run("read write", func(t *testing.T) {
+		statements := accessPolicyStatements("ba-1", "bucket", "", accessPolicyReadWrite)
+		assert.Len(t, statements, 2)
+		assert.Nil(t, statements[0].Condition)
+		assert.Contains(t, actions(statements[1]), "s3:GetObject")
+		assert.Contains(t, actions(statements[1]), "s3:DeleteObject")
+	})
+
+	t.Run("no principal for identity policies", func(t *testing.T) {
+		for _, ps := range accessPolicyStatements("ba-1", "bucket", "", accessPolicyReadWrite) {
+			assert.Empty(t, ps.Principal)
+		}
+	})
+}
+
+func TestReconcileBucketAccessSkipsOtherDrivers(t *testing.T) {
+	ctx := context.TODO()
+	bac := &cosiv1alpha1.BucketAccessClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bac"},
+		DriverName: "rook-ceph.ceph.objectstorage.k8s.io",
+	}
+	ba := &cosiv1alpha1.BucketAccess{
+		ObjectMeta: metav1.ObjectMeta{Name: "ba", Namespace: "app", UID: "1234"},
+		Spec:       cosiv1alpha1.BucketAccessSpec{BucketAccessClassName: "bac", BucketClaimName: "bc", CredentialsSecretName: "creds"},
+	}
+	cosiClient := cosifake.NewSimpleClientset(bac, ba)
+	b := newBucketAccessReconciler(nil, nil, cosiClient)
+
+	// accesses of the other drivers are left untouched
+	b.reconcileAll(ctx)
+	current, err := cosiClient.ObjectstorageV1alpha1().BucketAccesses("app").Get(ctx, "ba", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, current.Finalizers)
+	assert.False(t, current.Status.AccessGranted)
+
+	// the access waits for its bucket
+	bac.DriverName = CephCOSIAccountDriverName
+	bac.Parameters = map[string]string{accountNameParam: "team-a", accountNamespaceParam: "rook-ceph"}
+	_, err = cosiClient.ObjectstorageV1alpha1().BucketAccessClasses().Update(ctx, bac, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	_, err = cosiClient.ObjectstorageV1alpha1().BucketClaims("app").Create(ctx, &cosiv1alpha1.BucketClaim{ObjectMeta: metav1.ObjectMeta{Name: "bc", Namespace: "app"}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, b.reconcileBucketAccess(ctx, current))
+	current, err = cosiClient.ObjectstorageV1alpha1().BucketAccesses("app").Get(ctx, "ba", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, current.Finalizers)
+}
+
+func TestCheckBucketInStore(t *testing.T) {
+	mockClient := &object.MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Query().Get("bucket") {
+			case "photos":
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"bucket":"photos","owner":"bob"}`)))}, nil
+			case "other-store":
+				return &http.Response{StatusCode: 404, Body: io.NopCloser(bytes.NewReader([]byte(`{"Code":"NoSuchBucket"}`)))}, nil
+			}
+			return &http.Response{StatusCode: 500, Body: io.NopCloser(bytes.NewReader([]byte(`{"Code":"UnknownError"}`)))}, nil
+		},
+	}
+	api, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+	opsCtx := &object.AdminOpsContext{Context: object.Context{Name: "my-store"}, AdminOpsClient: api}
+
+	assert.NoError(t, checkBucketInStore(context.TODO(), opsCtx, "photos"))
+	assert.ErrorContains(t, checkBucketInStore(context.TODO(), opsCtx, "other-store"), `bucket "other-store" is not in object store "my-store"`)
+	assert.ErrorContains(t, checkBucketInStore(context.TODO(), opsCtx, "broken"), `failed to get bucket "broken"`)
+}
+
+func TestParseNamespacedName(t *testing.T) {
+	assert.Equal(t, types.NamespacedName{Namespace: "rook-ceph", Name: "team-a"}, parseNamespacedName("rook-ceph/team-a"))
+	assert.Equal(t, types.NamespacedName{Name: "team-a"}, parseNamespacedName("team-a"))
+}
@@ -36,6 +36,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
+	cosiclient "sigs.k8s.io/container-object-storage-interface/client/clientset/versioned"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -66,6 +67,9 @@ type ReconcileCephCOSIDriver struct {
 	scheme           *runtime.Scheme
 	opManagerContext context.Context
 	recorder         events.EventRecorder
+	// bucketAccess grants the BucketAccesses of the account driver while the driver is enabled
+	bucketAccess     *bucketAccessReconciler
+	stopBucketAccess context.CancelFunc
 }
 
 // Add creates a new CephCOSIDriver Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -76,18 +80,25 @@ func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext contex
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context) reconcile.Reconciler {
-	return &ReconcileCephCOSIDriver{
+	r := &ReconcileCephCOSIDriver{
 		client:           mgr.GetClient(),
 		context:          context,
 		scheme:           mgr.GetScheme(),
 		opManagerContext: opManagerContext,
 		recorder:         mgr.GetEventRecorder(controllerName),
 	}
+	cosiClient, err := cosiclient.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		logger.Errorf("failed to create COSI client, bucket accesses of %q will not be granted. %v", CephCOSIAccountDriverName, err)
+	} else {
+		r.bucketAccess = newBucketAccessReconciler(context, mgr.GetClient(), cosiClient)
+	}
+	return r
 }
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	controller, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	controller, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return errors.Wrapf(err, "failed to create %s controller", controllerName)
 	}
@@ -157,6 +168,7 @@ func (r *ReconcileCephCOSIDriver) reconcile(request reconcile.Request) (reconcil
 
 	if cosiDeploymentStrategy == cephv1.COSIDeploymentStrategyNever {
 		log.NamedDebug(request.NamespacedName, logger, "Ceph COSI Driver is disabled, delete if exists")
+		r.stopBucketAccessReconciler()
 		cephCOSIDriverDeployment := &appsv1.Deployment{}
 		err = r.client.Get(r.opManagerContext, request.NamespacedName, cephCOSIDriverDeployment)
 		if kerrors.IsNotFound(err) {
@@ -202,9 +214,30 @@ func (r *ReconcileCephCOSIDriver) reconcile(request reconcile.Request) (reconcil
 		return reconcile.Result{}, *cephCOSIDriver, errors.Wrap(err, "failed to start Ceph COSI Driver")
 	}
 
+	// Grant the bucket accesses of the account driver
+	r.startBucketAccessReconciler()
+
 	return reconcile.Result{}, *cephCOSIDriver, nil
 }
 
+// startBucketAccessReconciler starts granting the BucketAccesses of the account driver if it is
+// not already running
+func (r *ReconcileCephCOSIDriver) startBucketAccessReconciler() {
+	if r.bucketAccess == nil || r.stopBucketAccess != nil {
+		return
+	}
+	var ctx context.Context
+	ctx, r.stopBucketAccess = context.WithCancel(r.opManagerContext)
+	go r.bucketAccess.run(ctx)
+}
+
+func (r *ReconcileCephCOSIDriver) stopBucketAccessReconciler() {
+	if r.stopBucketAccess != nil {
+		r.stopBucketAccess()
+		r.stopBucketAccess = nil
+	}
+}
+
 // Start the Ceph COSI Driver
 func (r *ReconcileCephCOSIDriver) startCephCOSIDriver(cephCOSIDriver *cephv1.CephCOSIDriver) error {
 	nsName := opcontroller.NsName(cephCOSIDriver.Namespace, cephCOSIDriver.Name)
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+const (
+	iamService    = "iam"
+	iamAPIVersion = "2010-05-08"
+)
+
+// ErrIAMNoSuchEntity is returned by the IAM API when the user or policy does not exist
+var ErrIAMNoSuchEntity = errors.New("NoSuchEntity")
+
+// IAMAgent calls the IAM API of RGW with the credentials of an account user. Only the calls needed
+// to manage the policies of account users are implemented.
+type IAMAgent struct {
+	endpoint    string
+	credentials aws.Credentials
+	httpClient  *http.Client
+	signer      *v4.Signer
+}
+
+type iamErrorResponse struct {
+	Error struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+}
+
+// NewIAMAgent returns a client of the IAM API of the RGW endpoint
+func NewIAMAgent(accessKey, secretKey, endpoint string, tlsCert []byte, insecure bool, httpClient *http.Client) *IAMAgent {
+	tlsEnabled := len(tlsCert) > 0 || insecure
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: HttpTimeOut,
+		}
+		if tlsEnabled {
+			httpClient.Transport = BuildTransportTLS(tlsCert, insecure)
+		}
+	}
+
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+	}
+	u, perr := url.Parse(endpoint)
+	if perr != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		u, _ = url.Parse(scheme + "://" + endpoint)
+	}
+
+	return &IAMAgent{
+		endpoint:    u.String(),
+		credentials: aws.Credentials{AccessKeyID: accessKey, SecretAccessKey: secretKey},
+		httpClient:  httpClient,
+		signer:      v4.NewSigner(),
+	}
+}
+
+// PutUserPolicy creates or replaces the inline policy of an account user
+func (a *IAMAgent) PutUserPolicy(ctx context.Context, userName, policyName string, policy BucketPolicy) error {
+	document, err := json.Marshal(policy)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize policy %q", policyName)
+	}
+	return a.do(ctx, url.Values{
+		"Action":         {"PutUserPolicy"},
+		"UserName":       {userName},
+		"PolicyName":     {policyName},
+		"PolicyDocument": {string(document)},
+	})
+}
+
+// DeleteUserPolicy removes the inline policy of an account user. A policy that does not exist is
+// not an error.
+func (a *IAMAgent) DeleteUserPolicy(ctx context.Context, userName, policyName string) error {
+	err := a.do(ctx, url.Values{
+		"Action":     {"DeleteUserPolicy"},
+		"UserName":   {userName},
+		"PolicyName": {policyName},
+	})
+	if errors.Is(err, ErrIAMNoSuchEntity) {
+		return nil
+	}
+	return err
+}
+
+func (a *IAMAgent) do(ctx context.Context, params url.Values) error {
+	params.Set("Version", iamAPIVersion)
+	body := params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, strings.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to build iam request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	payloadHash := sha256.Sum256([]byte(body))
+	err = a.signer.SignHTTP(ctx, a.credentials, req, hex.EncodeToString(payloadHash[:]), iamService, CephRegion, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to sign iam request")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call iam %s", params.Get("Action"))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(resp.Body)
+	iamErr := iamErrorResponse{}
+	if xml.Unmarshal(respBody, &iamErr) == nil && iamErr.Error.Code == ErrIAMNoSuchEntity.Error() {
+		return errors.Wrapf(ErrIAMNoSuchEntity, "iam %s failed. %s", params.Get("Action"), iamErr.Error.Message)
+	}
+	return errors.Errorf("iam %s failed with status %d. %s", params.Get("Action"), resp.StatusCode, string(respBody))
+}
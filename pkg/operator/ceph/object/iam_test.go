@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIAMAgent(t *testing.T) {
+	var form map[string][]string
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		authorization = r.Header.Get("Authorization")
+		if r.PostForm.Get("UserName") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<ErrorResponse><Error><Code>NoSuchEntity</Code><Message>no such user</Message></Error></ErrorResponse>`))
+			return
+		}
+		if r.PostForm.Get("UserName") == "denied" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<ErrorResponse><Error><Code>AccessDenied</Code></Error></ErrorResponse>`))
+			return
+		}
+	}))
+	defer server.Close()
+
+	agent := NewIAMAgent("access", "secret", server.URL, nil, false, nil)
+	ctx := context.TODO()
+
+	t.Run("put user policy", func(t *testing.T) {
+		policy := NewBucketPolicy(*NewPolicyStatement().WithSID("s1").Allows().Actions(GetObject).ForResources("bucket/*"))
+		err := agent.PutUserPolicy(ctx, "user", "policy", *policy)
+		assert.NoError(t, err)
+		assert.Equal(t, "PutUserPolicy", form["Action"][0])
+		assert.Equal(t, iamAPIVersion, form["Version"][0])
+		assert.Equal(t, "user", form["UserName"][0])
+		assert.Contains(t, form["PolicyDocument"][0], `"arn:aws:s3:::bucket/*"`)
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access/"))
+		assert.Contains(t, authorization, "/"+CephRegion+"/iam/aws4_request")
+	})
+
+	t.Run("delete missing user policy", func(t *testing.T) {
+		err := agent.DeleteUserPolicy(ctx, "missing", "policy")
+		assert.NoError(t, err)
+		assert.Equal(t, "DeleteUserPolicy", form["Action"][0])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := agent.DeleteUserPolicy(ctx, "denied", "policy")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "AccessDenied")
+	})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultKeyRotationGracePeriod is how long the previous key pair remains valid after a rotation
+// when the policy does not set a grace period
+const DefaultKeyRotationGracePeriod = 24 * time.Hour
+
+// KeyRotationAction is the next step of the rotation of an S3 key pair
+type KeyRotationAction int
+
+const (
+	// KeyRotationNone means nothing is due yet
+	KeyRotationNone KeyRotationAction = iota
+	// KeyRotationRotate means a new key pair must be added and published
+	KeyRotationRotate
+	// KeyRotationRevoke means the grace period ended and the previous key pair must be removed
+	KeyRotationRevoke
+)
+
+// KeyRotationGracePeriod returns the grace period of a rotation policy
+func KeyRotationGracePeriod(policy *cephv1.ObjectKeyRotationSpec) time.Duration {
+	if policy == nil || policy.GracePeriod == nil || policy.GracePeriod.Duration < 0 {
+		return DefaultKeyRotationGracePeriod
+	}
+	return policy.GracePeriod.Duration
+}
+
+// NextKeyRotation returns the next step of the rotation of a key pair and how long until it is
+// due. A pending revocation is completed even if the policy was removed. The wait is 0 when
+// nothing is scheduled.
+func NextKeyRotation(policy *cephv1.ObjectKeyRotationSpec, status *cephv1.ObjectKeyRotationStatus, created, now time.Time) (KeyRotationAction, time.Duration) {
+	if status != nil && status.PreviousAccessKeyID != "" {
+		if status.PreviousKeyRevokeTime == nil || !now.Before(status.PreviousKeyRevokeTime.Time) {
+			return KeyRotationRevoke, 0
+		}
+		return KeyRotationNone, status.PreviousKeyRevokeTime.Sub(now)
+	}
+
+	if policy == nil || policy.Interval == nil || policy.Interval.Duration <= 0 {
+		return KeyRotationNone, 0
+	}
+
+	last := created
+	if status != nil && status.LastRotationTime != nil {
+		last = status.LastRotationTime.Time
+	}
+	due := last.Add(policy.Interval.Duration)
+	if !now.Before(due) {
+		return KeyRotationRotate, 0
+	}
+	return KeyRotationNone, due.Sub(now)
+}
+
+// ActiveUserKey returns the key pair of a user that is published to applications. The previous key
+// pair of a pending rotation is never active. When several keys qualify, the key matching
+// preferredAccessKey is returned, otherwise the first one.
+func ActiveUserKey(keys []admin.UserKeySpec, previousAccessKey, preferredAccessKey string) (admin.UserKeySpec, bool) {
+	var active *admin.UserKeySpec
+	for i := range keys {
+		if keys[i].AccessKey == previousAccessKey && previousAccessKey != "" {
+			continue
+		}
+		if keys[i].AccessKey == preferredAccessKey && preferredAccessKey != "" {
+			return keys[i], true
+		}
+		if active == nil {
+			active = &keys[i]
+		}
+	}
+	if active == nil {
+		return admin.UserKeySpec{}, false
+	}
+	return *active, true
+}
+
+// CreateUserKey adds a new generated S3 key pair to a user with the admin ops API and returns it.
+// The existing keys of the user remain valid.
+func CreateUserKey(ctx context.Context, client *admin.API, userID string, existingKeys []admin.UserKeySpec) (admin.UserKeySpec, error) {
+	keys, err := client.CreateKey(ctx, admin.UserKeySpec{UID: userID, KeyType: "s3", GenerateKey: ptr.To(true)})
+	if err != nil {
+		return admin.UserKeySpec{}, errors.Wrapf(err, "failed to create key for user %q", userID)
+	}
+	for _, k := range *keys {
+		if !slices.ContainsFunc(existingKeys, func(e admin.UserKeySpec) bool { return e.AccessKey == k.AccessKey }) {
+			return k, nil
+		}
+	}
+	return admin.UserKeySpec{}, errors.Errorf("failed to find the key created for user %q", userID)
+}
+
+// RemoveUserKey revokes an S3 key pair of a user with the admin ops API. A key that no longer
+// exists is not an error.
+func RemoveUserKey(ctx context.Context, client *admin.API, userID, accessKey string) error {
+	err := client.RemoveKey(ctx, admin.UserKeySpec{UID: userID, AccessKey: accessKey, KeyType: "s3"})
+	if err != nil && !errors.Is(err, admin.ErrInvalidAccessKey) && !errors.Is(err, admin.ErrNoSuchKey) {
+		return errors.Wrapf(err, "failed to remove key %q from user %q", accessKey, userID)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNextKeyRotation(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := &cephv1.ObjectKeyRotationSpec{Interval: &metav1.Duration{Duration: 24 * time.Hour}}
+
+	t.Run("no policy", func(t *testing.T) {
+		action, wait := NextKeyRotation(nil, nil, created, created.Add(48*time.Hour))
+		assert.Equal(t, KeyRotationNone, action)
+		assert.Equal(t, time.Duration(0), wait)
+	})
+
+	t.Run("first rotation counts from creation", func(t *testing.T) {
+		action, wait := NextKeyRotation(policy, nil, created, created.Add(10*time.Hour))
+		assert.Equal(t, KeyRotationNone, action)
+		assert.Equal(t, 14*time.Hour, wait)
+
+		action, _ = NextKeyRotation(policy, nil, created, created.Add(24*time.Hour))
+		assert.Equal(t, KeyRotationRotate, action)
+	})
+
+	t.Run("next rotation counts from the last rotation", func(t *testing.T) {
+		status := &cephv1.ObjectKeyRotationStatus{KeyGeneration: 1, LastRotationTime: &metav1.Time{Time: created.Add(30 * time.Hour)}}
+		action, wait := NextKeyRotation(policy, status, created, created.Add(48*time.Hour))
+		assert.Equal(t, KeyRotationNone, action)
+		assert.Equal(t, 6*time.Hour, wait)
+	})
+
+	t.Run("pending revocation", func(t *testing.T) {
+		status := &cephv1.ObjectKeyRotationStatus{
+			LastRotationTime:      &metav1.Time{Time: created},
+			PreviousAccessKeyID:   "old",
+			PreviousKeyRevokeTime: &metav1.Time{Time: created.Add(time.Hour)},
+		}
+		action, wait := NextKeyRotation(policy, status, created, created.Add(30*time.Minute))
+		assert.Equal(t, KeyRotationNone, action)
+		assert.Equal(t, 30*time.Minute, wait)
+
+		action, _ = NextKeyRotation(policy, status, created, created.Add(time.Hour))
+		assert.Equal(t, KeyRotationRevoke, action)
+
+		// the previous key is revoked even when the policy was removed
+		action, _ = NextKeyRotation(nil, status, created, created.Add(2*time.Hour))
+		assert.Equal(t, KeyRotationRevoke, action)
+	})
+}
+
+func TestKeyRotationGracePeriod(t *testing.T) {
+	assert.Equal(t, DefaultKeyRotationGracePeriod, KeyRotationGracePeriod(nil))
+	assert.Equal(t, DefaultKeyRotationGracePeriod, KeyRotationGracePeriod(&cephv1.ObjectKeyRotationSpec{}))
+	assert.Equal(t, time.Duration(0), KeyRotationGracePeriod(&cephv1.ObjectKeyRotationSpec{GracePeriod: &metav1.Duration{}}))
+	assert.Equal(t, time.Hour, KeyRotationGracePeriod(&cephv1.ObjectKeyRotationSpec{GracePeriod: &metav1.Duration{Duration: time.Hour}}))
+}
+
+func TestActiveUserKey(t *testing.T) {
+	keys := []admin.UserKeySpec{{AccessKey: "old"}, {AccessKey: "new"}, {AccessKey: "other"}}
+
+	_, ok := ActiveUserKey(nil, "", "")
+	assert.False(t, ok)
+
+	key, ok := ActiveUserKey(keys, "", "")
+	assert.True(t, ok)
+	assert.Equal(t, "old", key.AccessKey)
+
+	key, _ = ActiveUserKey(keys, "old", "")
+	assert.Equal(t, "new", key.AccessKey)
+
+	key, _ = ActiveUserKey(keys, "old", "other")
+	assert.Equal(t, "other", key.AccessKey)
+
+	// the previous key is never active, even if it is still published
+	key, _ = ActiveUserKey(keys, "old", "old")
+	assert.Equal(t, "new", key.AccessKey)
+
+	_, ok = ActiveUserKey([]admin.UserKeySpec{{AccessKey: "old"}}, "old", "")
+	assert.False(t, ok)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/exec"
+	"github.com/rook/rook/pkg/util/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reconcileLuaScripts installs, updates and removes the RGW Lua scripts and allowed Lua packages of
+// the object store so that they match the spec. It returns the scripts and packages that are
+// installed once done, which is to be recorded in the object store status. On failure, the returned
+// status records the scripts that were installed or removed before the failure. The packages are
+// only recorded once they are all updated so that the RGWs are asked to reload them on the retry.
+func reconcileLuaScripts(objContext *Context, store *cephv1.CephObjectStore) (*cephv1.ObjectStoreLuaScriptsStatus, error) {
+	installed := &cephv1.ObjectStoreLuaScriptsStatus{}
+	if store.Status != nil && store.Status.LuaScripts != nil {
+		installed = store.Status.LuaScripts
+	}
+	progress := &cephv1.ObjectStoreLuaScriptsStatus{
+		Scripts:         slices.Clone(installed.Scripts),
+		AllowedPackages: installed.AllowedPackages,
+	}
+
+	if store.Spec.LuaScripts == nil {
+		if len(installed.Scripts) == 0 && len(installed.AllowedPackages) == 0 {
+			return nil, nil
+		}
+		// the luaScripts section was removed from the spec, remove everything Rook installed
+		log.NamedInfo(objContext.NsName(), logger, "removing lua scripts and packages from object store")
+		for _, script := range installed.Scripts {
+			if err := removeLuaScript(objContext, script.Context, script.Tenant); err != nil {
+				return progress, err
+			}
+			progress.Scripts = withoutLuaScript(progress.Scripts, script)
+		}
+		if err := updateLuaPackages(objContext, installed.AllowedPackages, []string{}); err != nil {
+			return progress, err
+		}
+		return nil, nil
+	}
+
+	status := &cephv1.ObjectStoreLuaScriptsStatus{}
+	for _, script := range store.Spec.LuaScripts.Scripts {
+		content, err := getLuaScriptContent(objContext, script)
+		if err != nil {
+			return progress, err
+		}
+		scriptStatus := cephv1.ObjectStoreLuaScriptStatus{
+			Context: script.Context,
+			Tenant:  script.Tenant,
+			Hash:    k8sutil.Hash(content),
+		}
+		if !slices.Contains(installed.Scripts, scriptStatus) {
+			if err := putLuaScript(objContext, script.Context, script.Tenant, content); err != nil {
+				return progress, err
+			}
+			progress.Scripts = append(withoutLuaScript(progress.Scripts, scriptStatus), scriptStatus)
+		}
+		status.Scripts = append(status.Scripts, scriptStatus)
+	}
+
+	for _, script := range installed.Scripts {
+		stillDesired := slices.ContainsFunc(status.Scripts, func(s cephv1.ObjectStoreLuaScriptStatus) bool {
+			return s.Context == script.Context && s.Tenant == script.Tenant
+		})
+		if stillDesired {
+			continue
+		}
+		if err := removeLuaScript(objContext, script.Context, script.Tenant); err != nil {
+			return progress, err
+		}
+		progress.Scripts = withoutLuaScript(progress.Scripts, script)
+	}
+
+	if err := updateLuaPackages(objContext, installed.AllowedPackages, store.Spec.LuaScripts.AllowedPackages); err != nil {
+		return progress, err
+	}
+	status.AllowedPackages = store.Spec.LuaScripts.AllowedPackages
+
+	return status, nil
+}
+
+// withoutLuaScript returns the scripts without the one installed in the same context for the same tenant
+func withoutLuaScript(scripts []cephv1.ObjectStoreLuaScriptStatus, script cephv1.ObjectStoreLuaScriptStatus) []cephv1.ObjectStoreLuaScriptStatus {
+	return slices.DeleteFunc(scripts, func(s cephv1.ObjectStoreLuaScriptStatus) bool {
+		return s.Context == script.Context && s.Tenant == script.Tenant
+	})
+}
+
+// getLuaScriptContent reads the Lua script from the ConfigMap referenced by the script spec
+func getLuaScriptContent(objContext *Context, script cephv1.ObjectStoreLuaScript) (string, error) {
+	ref := script.ConfigMapKeyRef
+	cm, err := objContext.Context.Clientset.CoreV1().ConfigMaps(objContext.clusterInfo.Namespace).Get(objContext.clusterInfo.Context, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get configmap %q for lua script in context %q", ref.Name, script.Context)
+	}
+	content, ok := cm.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("key %q not found in configmap %q for lua script in context %q", ref.Key, ref.Name, script.Context)
+	}
+	return content, nil
+}
+
+func luaScriptArgs(scriptContext cephv1.LuaScriptContext, tenant string) []string {
+	// radosgw-admin expects the context names in lower case
+	args := []string{fmt.Sprintf("--context=%s", strings.ToLower(string(scriptContext)))}
+	if tenant != "" {
+		args = append(args, fmt.Sprintf("--tenant=%s", tenant))
+	}
+	return args
+}
+
+func putLuaScript(objContext *Context, scriptContext cephv1.LuaScriptContext, tenant, content string) error {
+	scriptFilename := path.Join(objContext.Context.ConfigDir, fmt.Sprintf("%s.%s.lua", objContext.Name, k8sutil.Hash(string(scriptContext)+"/"+tenant)))
+	if err := os.WriteFile(scriptFilename, []byte(content), 0o600); err != nil {
+		return errors.Wrap(err, "failed to write lua script file")
+	}
+	defer os.Remove(scriptFilename)
+
+	args := append([]string{"script", "put", "--infile=" + scriptFilename}, luaScriptArgs(scriptContext, tenant)...)
+	output, err := runAdminCommand(objContext, false, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to put lua script in context %q for tenant %q. %s", scriptContext, tenant, output)
+	}
+	log.NamedInfo(objContext.NsName(), logger, "installed lua script in context %q for tenant %q", scriptContext, tenant)
+	return nil
+}
+
+func removeLuaScript(objContext *Context, scriptContext cephv1.LuaScriptContext, tenant string) error {
+	args := append([]string{"script", "rm"}, luaScriptArgs(scriptContext, tenant)...)
+	output, err := runAdminCommand(objContext, false, args...)
+	if err != nil {
+		// ENOENT means the script is already gone
+		if code, ok := exec.ExitStatus(err); ok && code == int(syscall.ENOENT) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to remove lua script in context %q for tenant %q. %s", scriptContext, tenant, output)
+	}
+	log.NamedInfo(objContext.NsName(), logger, "removed lua script in context %q for tenant %q", scriptContext, tenant)
+	return nil
+}
+
+// updateLuaPackages adds the desired Lua packages that are not yet allowed and removes the
+// previously allowed packages that are not desired anymore. The RGWs are asked to reload the
+// packages when the list changes.
+func updateLuaPackages(objContext *Context, installed, desired []string) error {
+	changed := false
+	for _, pkg := range desired {
+		if slices.Contains(installed, pkg) {
+			continue
+		}
+		output, err := runAdminCommand(objContext, false, "script-package", "add", fmt.Sprintf("--package=%s", pkg))
+		if err != nil {
+			return errors.Wrapf(err, "failed to add lua package %q. %s", pkg, output)
+		}
+		changed = true
+	}
+	for _, pkg := range installed {
+		if slices.Contains(desired, pkg) {
+			continue
+		}
+		output, err := runAdminCommand(objContext, false, "script-package", "rm", fmt.Sprintf("--package=%s", pkg))
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove lua package %q. %s", pkg, output)
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	output, err := runAdminCommand(objContext, false, "script-package", "reload")
+	if err != nil {
+		return errors.Wrapf(err, "failed to reload lua packages. %s", output)
+	}
+	log.NamedInfo(objContext.NsName(), logger, "updated allowed lua packages to %v", desired)
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileLuaScripts(t *testing.T) {
+	ns := "my-cluster"
+	clientset := test.New(t, 1)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "lua-scripts", Namespace: ns},
+		Data: map[string]string{
+			"pre.lua":  `RGWDebugLog("pre")`,
+			"post.lua": `RGWDebugLog("post")`,
+		},
+	}
+	_, err := clientset.CoreV1().ConfigMaps(ns).Create(context.TODO(), cm, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	var commands []string
+	failingTenant := ""
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			commands = append(commands, strings.Join(args[:2], " "))
+			if failingTenant != "" && slices.Contains(args, "--tenant="+failingTenant) {
+				return "", errors.New("failed to put script")
+			}
+			return "", nil
+		},
+	}
+	objContext := NewContext(&clusterd.Context{Executor: executor, Clientset: clientset, ConfigDir: t.TempDir()},
+		&cephclient.ClusterInfo{Namespace: ns, Context: context.TODO()}, "my-store")
+
+	scriptRef := func(key string) corev1.ConfigMapKeySelector {
+		return corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "lua-scripts"}, Key: key}
+	}
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: ns},
+		Spec: cephv1.ObjectStoreSpec{
+			LuaScripts: &cephv1.ObjectStoreLuaScriptsSpec{
+				Scripts: []cephv1.ObjectStoreLuaScript{
+					{Context: cephv1.LuaScriptContextPreRequest, ConfigMapKeyRef: scriptRef("pre.lua")},
+					{Context: cephv1.LuaScriptContextPostRequest, Tenant: "tenant1", ConfigMapKeyRef: scriptRef("post.lua")},
+				},
+				AllowedPackages: []string{"luasocket"},
+			},
+		},
+		Status: &cephv1.ObjectStoreStatus{},
+	}
+
+	t.Run("no lua scripts", func(t *testing.T) {
+		commands = nil
+		s := store.DeepCopy()
+		s.Spec.LuaScripts = nil
+		status, err := reconcileLuaScripts(objContext, s)
+		assert.NoError(t, err)
+		assert.Nil(t, status)
+		assert.Empty(t, commands)
+	})
+
+	t.Run("install scripts and packages", func(t *testing.T) {
+		commands = nil
+		status, err := reconcileLuaScripts(objContext, store)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"script put", "script put", "script-package add", "script-package reload"}, commands)
+		assert.Len(t, status.Scripts, 2)
+		assert.Equal(t, cephv1.LuaScriptContextPreRequest, status.Scripts[0].Context)
+		assert.Equal(t, k8sutil.Hash(`RGWDebugLog("pre")`), status.Scripts[0].Hash)
+		assert.Equal(t, "tenant1", status.Scripts[1].Tenant)
+		assert.Equal(t, []string{"luasocket"}, status.AllowedPackages)
+		store.Status.LuaScripts = status
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		commands = nil
+		status, err := reconcileLuaScripts(objContext, store)
+		assert.NoError(t, err)
+		assert.Empty(t, commands)
+		assert.Equal(t, store.Status.LuaScripts, status)
+	})
+
+	t.Run("script content changed", func(t *testing.T) {
+		commands = nil
+		cm.Data["pre.lua"] = `RGWDebugLog("updated")`
+		_, err := clientset.CoreV1().ConfigMaps(ns).Update(context.TODO(), cm, metav1.UpdateOptions{})
+		assert.NoError(t, err)
+		status, err := reconcileLuaScripts(objContext, store)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"script put"}, commands)
+		assert.Equal(t, k8sutil.Hash(`RGWDebugLog("updated")`), status.Scripts[0].Hash)
+		store.Status.LuaScripts = status
+	})
+
+	t.Run("script and package removed from spec", func(t *testing.T) {
+		commands = nil
+		s := store.DeepCopy()
+		s.Spec.LuaScripts.Scripts = s.Spec.LuaScripts.Scripts[:1]
+		s.Spec.LuaScripts.AllowedPackages = []string{"lua-cjson"}
+		status, err := reconcileLuaScripts(objContext, s)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"script rm", "script-package add", "script-package rm", "script-package reload"}, commands)
+		assert.Len(t, status.Scripts, 1)
+		assert.Equal(t, []string{"lua-cjson"}, status.AllowedPackages)
+	})
+
+	t.Run("lua scripts section removed", func(t *testing.T) {
+		commands = nil
+		s := store.DeepCopy()
+		s.Spec.LuaScripts = nil
+		status, err := reconcileLuaScripts(objContext, s)
+		assert.NoError(t, err)
+		assert.Nil(t, status)
+		assert.Equal(t, []string{"script rm", "script rm", "script-package rm", "script-package reload"}, commands)
+	})
+
+	t.Run("partial progress is recorded on failure", func(t *testing.T) {
+		commands = nil
+		failingTenant = "tenant1"
+		defer func() { failingTenant = "" }()
+		s := store.DeepCopy()
+		s.Status.LuaScripts = nil
+		status, err := reconcileLuaScripts(objContext, s)
+		assert.ErrorContains(t, err, "failed to put script")
+		assert.NotContains(t, commands, "script-package add")
+		assert.Equal(t, &cephv1.ObjectStoreLuaScriptsStatus{
+			Scripts: []cephv1.ObjectStoreLuaScriptStatus{
+				{Context: cephv1.LuaScriptContextPreRequest, Hash: k8sutil.Hash(`RGWDebugLog("updated")`)},
+			},
+		}, status)
+	})
+
+	t.Run("missing configmap key", func(t *testing.T) {
+		commands = nil
+		s := store.DeepCopy()
+		s.Spec.LuaScripts.Scripts[0].ConfigMapKeyRef.Key = "missing.lua"
+		status, err := reconcileLuaScripts(objContext, s)
+		assert.ErrorContains(t, err, "missing.lua")
+		assert.Equal(t, store.Status.LuaScripts, status)
+		assert.Empty(t, commands)
+	})
+}
+
+func TestLuaScriptArgs(t *testing.T) {
+	assert.Equal(t, []string{"--context=prerequest"}, luaScriptArgs(cephv1.LuaScriptContextPreRequest, ""))
+	assert.Equal(t, []string{"--context=getdata", "--tenant=tenant1"}, luaScriptArgs(cephv1.LuaScriptContextGetData, "tenant1"))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+)
+
+var (
+	syncCaughtUpRegex      = regexp.MustCompile(`^(metadata|data) is caught up with`)
+	syncBehindRegex        = regexp.MustCompile(`^(metadata|data) is behind on (\d+) shards`)
+	syncRecoveringRegex    = regexp.MustCompile(`^(\d+) shards are recovering`)
+	syncOldestChangeRegex  = regexp.MustCompile(`^oldest incremental change not applied: ([^\[]+)`)
+	syncSourceZoneRegex    = regexp.MustCompile(`^source: (\S+)(?: \((.*)\))?`)
+	bucketSourceZoneRegex  = regexp.MustCompile(`^source zone (\S+)(?: \((.*)\))?`)
+	bucketBehindRegex      = regexp.MustCompile(`^bucket is behind on (\d+) shards`)
+	syncDetailsLinesPrefix = []string{"full sync:", "incremental sync:", "behind shards:", "recovering shards:"}
+
+	// the format of the timestamps printed by radosgw-admin changed across Ceph versions
+	syncTimestampLayouts = []string{
+		"2006-01-02T15:04:05.999999999-0700",
+		"2006-01-02T15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999-0700",
+		"2006-01-02 15:04:05.999999999",
+	}
+)
+
+type syncErrorShard struct {
+	ShardID int               `json:"shard_id"`
+	Entries []json.RawMessage `json:"entries"`
+}
+
+// GetZoneSyncStatus returns the multisite replication status of the zone of the given context and
+// of the given buckets of the zone
+func GetZoneSyncStatus(objContext *Context, buckets []string) (*cephv1.ObjectZoneSyncStatus, error) {
+	realmArg := fmt.Sprintf("--rgw-realm=%s", objContext.Realm)
+	zoneGroupArg := fmt.Sprintf("--rgw-zonegroup=%s", objContext.ZoneGroup)
+	zoneArg := fmt.Sprintf("--rgw-zone=%s", objContext.Zone)
+
+	// 'sync status' only supports a human-readable output
+	output, err := RunAdminCommandNoMultisite(objContext, false, "sync", "status", realmArg, zoneGroupArg, zoneArg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sync status of zone %q. %s", objContext.Zone, output)
+	}
+	status := parseSyncStatus(output, time.Now())
+
+	output, err = RunAdminCommandNoMultisite(objContext, true, "sync", "error", "list", realmArg, zoneGroupArg, zoneArg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list sync errors of zone %q. %s", objContext.Zone, output)
+	}
+	var errorShards []syncErrorShard
+	if err := json.Unmarshal([]byte(output), &errorShards); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse sync errors of zone %q", objContext.Zone)
+	}
+	for _, shard := range errorShards {
+		status.ErrorCount += len(shard.Entries)
+	}
+
+	// a bucket that cannot be checked does not fail the check of the zone
+	for _, bucket := range buckets {
+		output, err = RunAdminCommandNoMultisite(objContext, false, "bucket", "sync", "status", fmt.Sprintf("--bucket=%s", bucket), realmArg, zoneGroupArg, zoneArg)
+		if err != nil {
+			status.Buckets = append(status.Buckets, cephv1.ObjectBucketSyncStatus{
+				Bucket:  bucket,
+				Details: fmt.Sprintf("failed to get bucket sync status. %v. %s", err, strings.TrimSpace(output)),
+			})
+			continue
+		}
+		status.Buckets = append(status.Buckets, parseBucketSyncStatus(bucket, output)...)
+	}
+
+	return status, nil
+}
+
+// parseBucketSyncStatus parses the output of 'radosgw-admin bucket sync status', which reports the
+// sync status of the bucket from each source zone
+func parseBucketSyncStatus(bucket, output string) []cephv1.ObjectBucketSyncStatus {
+	var statuses []cephv1.ObjectBucketSyncStatus
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if match := bucketSourceZoneRegex.FindStringSubmatch(line); match != nil {
+			sourceZone := match[1]
+			if match[2] != "" {
+				sourceZone = match[2]
+			}
+			statuses = append(statuses, cephv1.ObjectBucketSyncStatus{Bucket: bucket, SourceZone: sourceZone})
+			continue
+		}
+		if len(statuses) == 0 {
+			continue
+		}
+
+		current := &statuses[len(statuses)-1]
+		if strings.HasPrefix(line, "bucket is caught up with source") {
+			current.CaughtUp = true
+		} else if match := bucketBehindRegex.FindStringSubmatch(line); match != nil {
+			current.ShardsBehind, _ = strconv.Atoi(match[1])
+		}
+	}
+
+	if len(statuses) == 0 {
+		// e.g. the sync of the bucket is disabled
+		return []cephv1.ObjectBucketSyncStatus{{Bucket: bucket, Details: "the bucket is not synced from any zone"}}
+	}
+	return statuses
+}
+
+// parseSyncStatus parses the output of 'radosgw-admin sync status'. The lag of the oldest change
+// that is not applied is computed relative to the given time.
+func parseSyncStatus(output string, now time.Time) *cephv1.ObjectZoneSyncStatus {
+	status := &cephv1.ObjectZoneSyncStatus{}
+
+	// the section currently parsed, the lines following a section header are not prefixed
+	var current *cephv1.ObjectSyncStatus
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if state, ok := strings.CutPrefix(line, "metadata sync "); ok {
+			status.Metadata = &cephv1.ObjectSyncStatus{State: state}
+			current = status.Metadata
+			continue
+		}
+		line = strings.TrimPrefix(line, "data sync ")
+		if match := syncSourceZoneRegex.FindStringSubmatch(line); match != nil {
+			sourceZone := match[1]
+			if match[2] != "" {
+				sourceZone = match[2]
+			}
+			status.Data = append(status.Data, cephv1.ObjectDataSyncStatus{SourceZone: sourceZone})
+			current = &status.Data[len(status.Data)-1].ObjectSyncStatus
+			continue
+		}
+		if current == nil || line == "" {
+			continue
+		}
+
+		if syncCaughtUpRegex.MatchString(line) {
+			current.CaughtUp = true
+		} else if match := syncBehindRegex.FindStringSubmatch(line); match != nil {
+			current.ShardsBehind, _ = strconv.Atoi(match[2])
+		} else if match := syncRecoveringRegex.FindStringSubmatch(line); match != nil {
+			current.RecoveringShards, _ = strconv.Atoi(match[1])
+		} else if match := syncOldestChangeRegex.FindStringSubmatch(line); match != nil {
+			current.OldestChangeNotApplied = strings.TrimSpace(match[1])
+			if oldest, ok := parseSyncTimestamp(current.OldestChangeNotApplied); ok && now.After(oldest) {
+				current.LagSeconds = int64(now.Sub(oldest).Seconds())
+			}
+		} else if current.State == "" && !hasAnyPrefix(line, syncDetailsLinesPrefix) {
+			// the first line of a data source is its sync state
+			current.State = line
+		}
+	}
+
+	return status
+}
+
+func parseSyncTimestamp(timestamp string) (time.Time, bool) {
+	for _, layout := range syncTimestampLayouts {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	syncStatusBehindOutput = `          realm 2b7a3d57-5b0e-4b61-a6c7-2d2c4ce4e1f0 (realm-a)
+      zonegroup 1c2b8a8e-69de-4a7b-b0a4-5a8f1fb2f5d2 (zonegroup-a)
+           zone 9d8a7e0c-8c4c-4ad1-9d4c-1f6a1e4dd2a4 (zone-b)
+   current time 2024-02-15T10:21:18Z
+zonegroup features enabled: resharding
+                   disabled: compress-encrypted
+  metadata sync syncing
+                full sync: 0/64 shards
+                incremental sync: 64/64 shards
+                metadata is caught up with master
+      data sync source: 6cb39d2c-3005-49da-9be3-c1a92a97d28a (zone-a)
+                        syncing
+                        full sync: 0/128 shards
+                        incremental sync: 128/128 shards
+                        data is behind on 2 shards
+                        behind shards: [12,41]
+                        oldest incremental change not applied: 2024-02-15T10:11:18.000000+0000 [12]
+                        3 shards are recovering
+                        recovering shards: [1,2,3]
+                source: 7d0e5b0a-2f2c-4d7e-8b37-0b1b7b7e0a11 (zone-c)
+                        syncing
+                        full sync: 0/128 shards
+                        incremental sync: 128/128 shards
+                        data is caught up with source
+`
+	syncStatusMasterOutput = `          realm 2b7a3d57-5b0e-4b61-a6c7-2d2c4ce4e1f0 (realm-a)
+      zonegroup 1c2b8a8e-69de-4a7b-b0a4-5a8f1fb2f5d2 (zonegroup-a)
+           zone 6cb39d2c-3005-49da-9be3-c1a92a97d28a (zone-a)
+  metadata sync no sync (zone is master)
+      data sync source: 9d8a7e0c-8c4c-4ad1-9d4c-1f6a1e4dd2a4 (zone-b)
+                        not syncing from zone
+`
+	bucketSyncStatusOutput = `          realm 2b7a3d57-5b0e-4b61-a6c7-2d2c4ce4e1f0 (realm-a)
+      zonegroup 1c2b8a8e-69de-4a7b-b0a4-5a8f1fb2f5d2 (zonegroup-a)
+           zone 9d8a7e0c-8c4c-4ad1-9d4c-1f6a1e4dd2a4 (zone-b)
+         bucket :bucket1[6cb39d2c-3005-49da-9be3-c1a92a97d28a.4162.1])
+   current time 2024-02-15T10:21:18Z
+
+    source zone 6cb39d2c-3005-49da-9be3-c1a92a97d28a (zone-a)
+  source bucket :bucket1[6cb39d2c-3005-49da-9be3-c1a92a97d28a.4162.1])
+                incremental sync on 11 shards
+                bucket is behind on 2 shards
+                behind shards: [3,7]
+
+    source zone 7d0e5b0a-2f2c-4d7e-8b37-0b1b7b7e0a11 (zone-c)
+  source bucket :bucket1[6cb39d2c-3005-49da-9be3-c1a92a97d28a.4162.1])
+                incremental sync on 11 shards
+                bucket is caught up with source
+`
+	syncErrorListOutput = `[
+    {
+        "shard_id": 0,
+        "entries": [
+            {"id": "1_1707991878.234012_1.1", "section": "data", "name": "bucket1:6cb39d2c.4162.1", "info": {"error_code": 5, "message": "failed to sync bucket instance: (5) Input/output error"}}
+        ]
+    },
+    {
+        "shard_id": 1,
+        "entries": []
+    }
+]`
+)
+
+func TestParseSyncStatus(t *testing.T) {
+	now := time.Date(2024, 2, 15, 10, 21, 18, 0, time.UTC)
+
+	t.Run("data behind", func(t *testing.T) {
+		status := parseSyncStatus(syncStatusBehindOutput, now)
+		assert.Equal(t, &cephv1.ObjectSyncStatus{State: "syncing", CaughtUp: true}, status.Metadata)
+		assert.Len(t, status.Data, 2)
+		assert.Equal(t, cephv1.ObjectDataSyncStatus{
+			SourceZone: "zone-a",
+			ObjectSyncStatus: cephv1.ObjectSyncStatus{
+				State:                  "syncing",
+				ShardsBehind:           2,
+				RecoveringShards:       3,
+				OldestChangeNotApplied: "2024-02-15T10:11:18.000000+0000",
+				LagSeconds:             600,
+			},
+		}, status.Data[0])
+		assert.Equal(t, cephv1.ObjectDataSyncStatus{
+			SourceZone:       "zone-c",
+			ObjectSyncStatus: cephv1.ObjectSyncStatus{State: "syncing", CaughtUp: true},
+		}, status.Data[1])
+	})
+
+	t.Run("master zone", func(t *testing.T) {
+		status := parseSyncStatus(syncStatusMasterOutput, now)
+		assert.Equal(t, &cephv1.ObjectSyncStatus{State: "no sync (zone is master)"}, status.Metadata)
+		assert.Equal(t, []cephv1.ObjectDataSyncStatus{
+			{SourceZone: "zone-b", ObjectSyncStatus: cephv1.ObjectSyncStatus{State: "not syncing from zone"}},
+		}, status.Data)
+	})
+
+	t.Run("empty output", func(t *testing.T) {
+		status := parseSyncStatus("", now)
+		assert.Equal(t, &cephv1.ObjectZoneSyncStatus{}, status)
+	})
+}
+
+func TestParseBucketSyncStatus(t *testing.T) {
+	assert.Equal(t, []cephv1.ObjectBucketSyncStatus{
+		{Bucket: "bucket1", SourceZone: "zone-a", ShardsBehind: 2},
+		{Bucket: "bucket1", SourceZone: "zone-c", CaughtUp: true},
+	}, parseBucketSyncStatus("bucket1", bucketSyncStatusOutput))
+
+	assert.Equal(t, []cephv1.ObjectBucketSyncStatus{
+		{Bucket: "bucket1", Details: "the bucket is not synced from any zone"},
+	}, parseBucketSyncStatus("bucket1", "Sync is disabled for bucket bucket1\n"))
+}
+
+func TestParseSyncTimestamp(t *testing.T) {
+	expected := time.Date(2024, 2, 15, 10, 11, 18, 500000000, time.UTC)
+	for _, timestamp := range []string{
+		"2024-02-15T10:11:18.500000+0000",
+		"2024-02-15T10:11:18.5Z",
+		"2024-02-15 10:11:18.500000+0000",
+		"2024-02-15 10:11:18.5",
+	} {
+		parsed, ok := parseSyncTimestamp(timestamp)
+		assert.True(t, ok, timestamp)
+		assert.True(t, expected.Equal(parsed), timestamp)
+	}
+
+	_, ok := parseSyncTimestamp("not a timestamp")
+	assert.False(t, ok)
+}
+
+func TestGetZoneSyncStatus(t *testing.T) {
+	var syncErr error
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			assert.Contains(t, args, "--rgw-zone=zone-b")
+			if args[0] == "sync" && args[1] == "status" {
+				return syncStatusBehindOutput, syncErr
+			}
+			if args[0] == "sync" && args[1] == "error" {
+				return syncErrorListOutput, nil
+			}
+			if args[0] == "bucket" && args[3] == "--bucket=bucket1" {
+				return bucketSyncStatusOutput, nil
+			}
+			if args[0] == "bucket" {
+				return "ERROR: could not init bucket: (2) No such file or directory", errors.New("exit status 2")
+			}
+			return "", errors.New("unexpected command")
+		},
+	}
+	objContext := NewContext(&clusterd.Context{Executor: executor}, &cephclient.ClusterInfo{Namespace: "my-cluster", Context: context.TODO()}, "zone-b")
+	objContext.Realm = "realm-a"
+	objContext.ZoneGroup = "zonegroup-a"
+	objContext.Zone = "zone-b"
+
+	status, err := GetZoneSyncStatus(objContext, []string{"bucket1", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, status.ErrorCount)
+	assert.Equal(t, 2, status.Data[0].ShardsBehind)
+	assert.Len(t, status.Buckets, 3)
+	assert.Equal(t, 2, status.Buckets[0].ShardsBehind)
+	assert.Equal(t, "missing", status.Buckets[2].Bucket)
+	assert.Contains(t, status.Buckets[2].Details, "No such file or directory")
+
+	syncErr = errors.New("failed to connect")
+	_, err = GetZoneSyncStatus(objContext, nil)
+	assert.ErrorContains(t, err, "failed to get sync status")
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/rook/rook/pkg/daemon/ceph/opslog"
+	cephconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	opsLogContainerName  = "ops-log"
+	opsLogStatsPortName  = "ops-log-stats"
+	opsLogStatusInterval = time.Minute
+	opsLogStatsTimeout   = 10 * time.Second
+)
+
+var (
+	// allow these to be overridden for unit tests
+	readOpsLogStats = readOpsLogStatsFromPod
+	opsLogStatsPort = opslog.StatsPort
+
+	opsLogStatsClient = &http.Client{Timeout: opsLogStatsTimeout}
+)
+
+var (
+	opsLogDelivered = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_object_store_ops_log_delivered_records",
+		Help: "Number of ops log records delivered to a sink since the ops-log sidecars started",
+	}, []string{"namespace", "object_store", "sink"})
+	opsLogDropped = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_object_store_ops_log_dropped_records",
+		Help: "Number of ops log records dropped after their retries since the ops-log sidecars started",
+	}, []string{"namespace", "object_store", "sink"})
+	opsLogLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rook_ceph_object_store_ops_log_lag_seconds",
+		Help: "Age of the oldest ops log record not delivered to a sink",
+	}, []string{"namespace", "object_store", "sink"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(opsLogDelivered, opsLogDropped, opsLogLag)
+}
+
+// makeOpsLogShipperContainer returns the ops-log sidecar that ships the ops log to the sinks. The
+// secrets of the sinks are passed in env vars so that the operator never reads them.
+func (c *clusterConfig) makeOpsLogShipperContainer(opsLogSidecar *cephv1.OpsLogSidecar) (v1.Container, error) {
+	config, err := json.Marshal(opslog.Config{Sinks: opsLogSidecar.Sinks, Delivery: opsLogSidecar.Delivery})
+	if err != nil {
+		return v1.Container{}, errors.Wrap(err, "failed to marshal ops log config")
+	}
+
+	env := append([]v1.EnvVar{}, podNameEnvVars...)
+	env = append(env, v1.EnvVar{Name: opslog.ConfigEnvVar, Value: string(config)})
+	for i := range opsLogSidecar.Sinks {
+		sink := &opsLogSidecar.Sinks[i]
+		refs := opslog.SecretRefs(sink)
+		for _, key := range slices.Sorted(maps.Keys(refs)) {
+			env = append(env, v1.EnvVar{
+				Name:      opslog.SecretEnvVarName(sink.Name, key),
+				ValueFrom: &v1.EnvVarSource{SecretKeyRef: refs[key]},
+			})
+		}
+	}
+
+	return v1.Container{
+		Name:  opsLogContainerName,
+		Args:  []string{"ceph", "ops-log", "--log-file", opsLogAbsFilename, "--stats-port", strconv.Itoa(opslog.StatsPort)},
+		Image: c.rookImage,
+		Ports: []v1.ContainerPort{
+			{Name: opsLogStatsPortName, ContainerPort: opslog.StatsPort, Protocol: v1.ProtocolTCP},
+		},
+		ImagePullPolicy: controller.GetContainerImagePullPolicy(c.clusterSpec.CephVersion.ImagePullPolicy),
+		VolumeMounts:    controller.DaemonVolumeMounts(cephconfig.NewDatalessDaemonDataPathMap(c.clusterInfo.Namespace, c.clusterSpec.DataDirHostPath), "", c.clusterSpec.DataDirHostPath),
+		// the checkpoint is written next to the ops log, which is owned by ceph
+		SecurityContext: controller.CephSecurityContext(),
+		Resources:       opsLogSidecar.Resources,
+		Env:             env,
+	}, nil
+}
+
+func validateOpsLogSidecar(store *cephv1.CephObjectStore) error {
+	opsLogSidecar := store.Spec.Gateway.OpsLogSidecar
+	if opsLogSidecar == nil {
+		return nil
+	}
+	names := map[string]bool{}
+	for i := range opsLogSidecar.Sinks {
+		sink := &opsLogSidecar.Sinks[i]
+		if names[sink.Name] {
+			return errors.Errorf("duplicate ops log sink %q", sink.Name)
+		}
+		names[sink.Name] = true
+		if err := opslog.ValidateSink(sink); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasOpsLogSinks(store *cephv1.CephObjectStore) bool {
+	return store.Spec.Gateway.OpsLogSidecar != nil && len(store.Spec.Gateway.OpsLogSidecar.Sinks) > 0
+}
+
+// readOpsLogStatsFromPod reads the stats served by the ops-log sidecar of an RGW pod
+func readOpsLogStatsFromPod(ctx context.Context, pod *v1.Pod) (*opslog.Stats, error) {
+	if pod.Status.PodIP == "" {
+		return nil, errors.Errorf("failed to read ops log stats of pod %q: no pod ip", pod.Name)
+	}
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(opsLogStatsPort)), opslog.StatsPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create ops log stats request of pod %q", pod.Name)
+	}
+	resp, err := opsLogStatsClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read ops log stats of pod %q", pod.Name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to read ops log stats of pod %q: %s", pod.Name, resp.Status)
+	}
+	stats := &opslog.Stats{}
+	if err := json.NewDecoder(resp.Body).Decode(stats); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse ops log stats of pod %q", pod.Name)
+	}
+	return stats, nil
+}
+
+// collectOpsLogStatus sums the delivery stats of the ops-log sidecars of the running RGW pods
+func collectOpsLogStatus(ctx context.Context, context *clusterd.Context, store *cephv1.CephObjectStore) *cephv1.OpsLogStatus {
+	status := &cephv1.OpsLogStatus{LastChecked: time.Now().UTC().Format(time.RFC3339)}
+	sinks := map[string]*cephv1.OpsLogSinkStatus{}
+	for _, sink := range store.Spec.Gateway.OpsLogSidecar.Sinks {
+		status.Sinks = append(status.Sinks, cephv1.OpsLogSinkStatus{Name: sink.Name})
+	}
+	for i := range status.Sinks {
+		sinks[status.Sinks[i].Name] = &status.Sinks[i]
+	}
+
+	selector := fmt.Sprintf("%s=%s,%s=%s", k8sutil.AppAttr, AppName, "rook_object_store", store.Name)
+	pods, err := context.Clientset.CoreV1().Pods(store.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		status.Details = fmt.Sprintf("failed to list rgw pods. %v", err)
+		return status
+	}
+
+	var details []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		stats, err := readOpsLogStats(ctx, pod)
+		if err != nil {
+			details = append(details, err.Error())
+			continue
+		}
+		for _, sinkStats := range stats.Sinks {
+			sink, ok := sinks[sinkStats.Name]
+			if !ok {
+				// the pod was not restarted yet with the latest sinks
+				continue
+			}
+			sink.Delivered += sinkStats.Delivered
+			sink.Dropped += sinkStats.Dropped
+			sink.Buffered += sinkStats.Buffered
+			sink.LagSeconds = max(sink.LagSeconds, sinkStats.LagSeconds)
+			if sinkStats.LastError != "" {
+				sink.LastError = fmt.Sprintf("%s: %s", pod.Name, sinkStats.LastError)
+			}
+		}
+	}
+	status.Details = strings.Join(details, "; ")
+	return status
+}
+
+func reportOpsLogMetrics(nsName types.NamespacedName, status *cephv1.OpsLogStatus) {
+	deleteOpsLogMetrics(nsName)
+	for _, sink := range status.Sinks {
+		opsLogDelivered.WithLabelValues(nsName.Namespace, nsName.Name, sink.Name).Set(float64(sink.Delivered))
+		opsLogDropped.WithLabelValues(nsName.Namespace, nsName.Name, sink.Name).Set(float64(sink.Dropped))
+		opsLogLag.WithLabelValues(nsName.Namespace, nsName.Name, sink.Name).Set(float64(sink.LagSeconds))
+	}
+}
+
+func deleteOpsLogMetrics(nsName types.NamespacedName) {
+	storeLabels := prometheus.Labels{"namespace": nsName.Namespace, "object_store": nsName.Name}
+	opsLogDelivered.DeletePartialMatch(storeLabels)
+	opsLogDropped.DeletePartialMatch(storeLabels)
+	opsLogLag.DeletePartialMatch(storeLabels)
+}
+
+type opsLogStatusChecker struct {
+	client         client.Client
+	context        *clusterd.Context
+	namespacedName types.NamespacedName
+	interval       time.Duration
+}
+
+// checkOpsLogStatus periodically collects the ops log delivery status of the object store
+func (c *opsLogStatusChecker) checkOpsLogStatus(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.NamedInfo(c.namespacedName, logger, "stopping monitoring of ops log delivery")
+			return
+
+		case <-time.After(c.interval):
+			c.checkOpsLogStatusOnce(ctx)
+		}
+	}
+}
+
+func (c *opsLogStatusChecker) checkOpsLogStatusOnce(ctx context.Context) {
+	store := &cephv1.CephObjectStore{}
+	if err := c.client.Get(ctx, c.namespacedName, store); err != nil {
+		if !kerrors.IsNotFound(err) {
+			log.NamedWarning(c.namespacedName, logger, "failed to get object store to check ops log delivery. %v", err)
+		}
+		return
+	}
+	if !hasOpsLogSinks(store) {
+		return
+	}
+
+	status := collectOpsLogStatus(ctx, c.context, store)
+	if status.Details != "" {
+		log.NamedWarning(c.namespacedName, logger, "failed to collect ops log delivery status. %s", status.Details)
+	}
+	reportOpsLogMetrics(c.namespacedName, status)
+	if err := updateOpsLogStatus(ctx, c.client, c.namespacedName, status); err != nil {
+		log.NamedError(c.namespacedName, logger, "failed to update ops log status of the object store. %v", err)
+	}
+}
+
+// startOpsLogStatusCheck starts the periodic collection of the ops log delivery status if the
+// object store has ops log sinks, or stops it otherwise
+func (r *ReconcileCephObjectStore) startOpsLogStatusCheck(store *cephv1.CephObjectStore) {
+	nsName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+	if !hasOpsLogSinks(store) {
+		r.stopOpsLogStatusCheck(nsName)
+		if store.Status != nil && store.Status.OpsLog != nil {
+			if err := updateOpsLogStatus(r.opManagerContext, r.client, nsName, nil); err != nil {
+				log.NamedWarning(nsName, logger, "failed to reset ops log status. %v", err)
+			}
+		}
+		return
+	}
+
+	if r.opsLogStatusChecks == nil {
+		r.opsLogStatusChecks = map[string]context.CancelFunc{}
+	}
+	if _, ok := r.opsLogStatusChecks[nsName.String()]; ok {
+		log.NamedDebug(nsName, logger, "ops log monitoring go routine already running")
+		return
+	}
+
+	internalCtx, internalCancel := context.WithCancel(r.opManagerContext)
+	r.opsLogStatusChecks[nsName.String()] = internalCancel
+	checker := &opsLogStatusChecker{
+		client:         r.client,
+		context:        r.context,
+		namespacedName: nsName,
+		interval:       opsLogStatusInterval,
+	}
+	log.NamedInfo(nsName, logger, "starting monitoring of ops log delivery")
+	go checker.checkOpsLogStatus(internalCtx)
+}
+
+// stopOpsLogStatusCheck stops the ops log monitoring. This is a noop if monitoring is not running.
+func (r *ReconcileCephObjectStore) stopOpsLogStatusCheck(nsName types.NamespacedName) {
+	if cancel, ok := r.opsLogStatusChecks[nsName.String()]; ok {
+		cancel()
+		delete(r.opsLogStatusChecks, nsName.String())
+	}
+	deleteOpsLogMetrics(nsName)
+}
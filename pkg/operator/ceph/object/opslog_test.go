@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	"github.com/rook/rook/pkg/daemon/ceph/opslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func opsLogStore() *cephv1.CephObjectStore {
+	store := simpleStore()
+	store.Spec.Gateway.OpsLogSidecar = &cephv1.OpsLogSidecar{
+		Sinks: []cephv1.OpsLogSinkSpec{
+			{
+				Name: "audit-http",
+				HTTP: &cephv1.OpsLogHTTPSinkSpec{
+					HTTPEndpointSpec:       cephv1.HTTPEndpointSpec{URI: "https://logs.example.com"},
+					AuthorizationSecretRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "logs"}, Key: "token"},
+				},
+			},
+			{
+				Name: "archive",
+				S3: &cephv1.OpsLogS3SinkSpec{
+					Endpoint:           "https://s3.example.com",
+					Bucket:             "ops-log",
+					AccessKeySecretRef: v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "archive"}, Key: "access"},
+					SecretKeySecretRef: v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "archive"}, Key: "secret"},
+				},
+			},
+		},
+	}
+	return store
+}
+
+func TestMakeOpsLogShipperContainer(t *testing.T) {
+	store := opsLogStore()
+	c := &clusterConfig{
+		store:       store,
+		clusterInfo: clienttest.CreateTestClusterInfo(1),
+		rookImage:   "rook/ceph:master",
+		clusterSpec: &cephv1.ClusterSpec{DataDirHostPath: "/var/lib/rook"},
+	}
+
+	container, err := c.makeOpsLogShipperContainer(store.Spec.Gateway.OpsLogSidecar)
+	require.NoError(t, err)
+	assert.Equal(t, opsLogContainerName, container.Name)
+	assert.Equal(t, "rook/ceph:master", container.Image)
+	assert.Equal(t, []string{"ceph", "ops-log", "--log-file", opsLogAbsFilename, "--stats-port", "9286"}, container.Args)
+	assert.Equal(t, []v1.ContainerPort{{Name: "ops-log-stats", ContainerPort: 9286, Protocol: v1.ProtocolTCP}}, container.Ports)
+
+	env := map[string]v1.EnvVar{}
+	for _, e := range container.Env {
+		env[e.Name] = e
+	}
+	config := env[opslog.ConfigEnvVar].Value
+	assert.Contains(t, config, `"name":"audit-http"`)
+	assert.Equal(t, "token", env["ROOK_OPS_LOG_SINK_AUDIT_HTTP_AUTHORIZATION"].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "access", env["ROOK_OPS_LOG_SINK_ARCHIVE_ACCESS_KEY"].ValueFrom.SecretKeyRef.Key)
+	assert.Equal(t, "secret", env["ROOK_OPS_LOG_SINK_ARCHIVE_SECRET_KEY"].ValueFrom.SecretKeyRef.Key)
+}
+
+func TestValidateOpsLogSidecar(t *testing.T) {
+	store := opsLogStore()
+	assert.NoError(t, validateOpsLogSidecar(store))
+
+	store.Spec.Gateway.OpsLogSidecar.Sinks[1].Name = "audit-http"
+	assert.ErrorContains(t, validateOpsLogSidecar(store), "duplicate")
+
+	store = opsLogStore()
+	store.Spec.Gateway.OpsLogSidecar.Sinks[0].S3 = store.Spec.Gateway.OpsLogSidecar.Sinks[1].S3
+	assert.Error(t, validateOpsLogSidecar(store))
+
+	store.Spec.Gateway.OpsLogSidecar = nil
+	assert.NoError(t, validateOpsLogSidecar(store))
+}
+
+func TestCollectOpsLogStatus(t *testing.T) {
+	ctx := context.TODO()
+	store := opsLogStore()
+	clientset := fake.NewClientset()
+	for _, name := range []string{"rgw-a", "rgw-b", "rgw-c", "rgw-pending"} {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: store.Namespace,
+				Labels:    map[string]string{"app": AppName, "rook_object_store": store.Name},
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		}
+		if name == "rgw-pending" {
+			pod.Status.Phase = v1.PodPending
+		}
+		_, err := clientset.CoreV1().Pods(store.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	defer func() { readOpsLogStats = readOpsLogStatsFromPod }()
+	readOpsLogStats = func(ctx context.Context, pod *v1.Pod) (*opslog.Stats, error) {
+		switch pod.Name {
+		case "rgw-a":
+			return &opslog.Stats{Updated: time.Now(), Sinks: []opslog.SinkStats{
+				{Name: "audit-http", Delivered: 10, Buffered: 2, LagSeconds: 3},
+				{Name: "archive", Delivered: 5},
+			}}, nil
+		case "rgw-b":
+			return &opslog.Stats{Updated: time.Now(), Sinks: []opslog.SinkStats{
+				{Name: "audit-http", Delivered: 7, Dropped: 1, LagSeconds: 30, LastError: "503 Service Unavailable"},
+				{Name: "removed", Delivered: 100},
+			}}, nil
+		case "rgw-c":
+			return nil, errors.New("failed to read ops log stats of pod \"rgw-c\"")
+		}
+		t.Fatalf("unexpected pod %q", pod.Name)
+		return nil, nil
+	}
+
+	status := collectOpsLogStatus(ctx, &clusterd.Context{Clientset: clientset}, store)
+	assert.Equal(t, []cephv1.OpsLogSinkStatus{
+		{Name: "audit-http", Delivered: 17, Dropped: 1, Buffered: 2, LagSeconds: 30, LastError: "rgw-b: 503 Service Unavailable"},
+		{Name: "archive", Delivered: 5},
+	}, status.Sinks)
+	assert.Contains(t, status.Details, `"rgw-c"`)
+	assert.NotEmpty(t, status.LastChecked)
+}
+
+func TestReadOpsLogStatsFromPod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, opslog.StatsPath, r.URL.Path)
+		assert.NoError(t, json.NewEncoder(w).Encode(opslog.Stats{Sinks: []opslog.SinkStats{{Name: "audit-http", Delivered: 3}}}))
+	}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	defer func() { opsLogStatsPort = opslog.StatsPort }()
+	opsLogStatsPort, err = strconv.Atoi(port)
+	require.NoError(t, err)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "rgw-a"}, Status: v1.PodStatus{PodIP: host}}
+	stats, err := readOpsLogStatsFromPod(context.TODO(), pod)
+	assert.NoError(t, err)
+	assert.Equal(t, []opslog.SinkStats{{Name: "audit-http", Delivered: 3}}, stats.Sinks)
+
+	pod.Status.PodIP = ""
+	_, err = readOpsLogStatsFromPod(context.TODO(), pod)
+	assert.ErrorContains(t, err, "no pod ip")
+}
@@ -129,12 +129,15 @@ type PolicyStatement struct {
 	Effect effect `json:"Effect"`
 	// Principal is/are the Ceph user names affected by this PolicyStatement
 	// Must be in the format of 'arn:aws:iam:::user/<ceph-user>'
-	Principal map[string][]string `json:"Principal"`
+	// Identity policies attached to a user have no Principal.
+	Principal map[string][]string `json:"Principal,omitempty"`
 	// Action is a list of s3:* actions
 	Action []action `json:"Action"`
 	// Resource is the ARN identifier for the S3 resource (bucket)
 	// Must be in the format of 'arn:aws:s3:::<bucket>'
 	Resource []string `json:"Resource"`
+	// Condition (optional) restricts the requests the PolicyStatement applies to
+	Condition map[string]map[string][]string `json:"Condition,omitempty"`
 }
 
 // BucketPolicy represents set of policy statements for a single bucket.
@@ -232,9 +235,10 @@ func (ps *PolicyStatement) WithSID(sid string) *PolicyStatement {
 }
 
 const (
-	awsPrinciple       = "AWS"
-	arnPrefixPrinciple = "arn:aws:iam:::user/%s"
-	arnPrefixResource  = "arn:aws:s3:::%s"
+	awsPrinciple              = "AWS"
+	arnPrefixPrinciple        = "arn:aws:iam:::user/%s"
+	arnPrefixAccountPrinciple = "arn:aws:iam::%s:user/%s"
+	arnPrefixResource         = "arn:aws:s3:::%s"
 )
 
 // ForPrincipals adds users to the PolicyStatement
@@ -247,6 +251,16 @@ func (ps *PolicyStatement) ForPrincipals(users ...string) *PolicyStatement {
 	return ps
 }
 
+// ForAccountPrincipals adds users of an RGW account to the PolicyStatement
+func (ps *PolicyStatement) ForAccountPrincipals(accountID string, users ...string) *PolicyStatement {
+	principals := ps.Principal[awsPrinciple]
+	for _, u := range users {
+		principals = append(principals, fmt.Sprintf(arnPrefixAccountPrinciple, accountID, u))
+	}
+	ps.Principal[awsPrinciple] = principals
+	return ps
+}
+
 // ForResources adds resources (buckets) to the PolicyStatement with the appropriate ARN prefix
 func (ps *PolicyStatement) ForResources(resources ...string) *PolicyStatement {
 	for _, v := range resources {
@@ -289,6 +303,19 @@ func (ps *PolicyStatement) Actions(actions ...action) *PolicyStatement {
 	return ps
 }
 
+// WithCondition restricts the PolicyStatement to the requests where the condition key matches
+// one of the values with the given operator, e.g. "StringLike" "s3:prefix"
+func (ps *PolicyStatement) WithCondition(operator, key string, values ...string) *PolicyStatement {
+	if ps.Condition == nil {
+		ps.Condition = map[string]map[string][]string{}
+	}
+	if ps.Condition[operator] == nil {
+		ps.Condition[operator] = map[string][]string{}
+	}
+	ps.Condition[operator][key] = append(ps.Condition[operator][key], values...)
+	return ps
+}
+
 // //////////////
 // End Policy
 // //////////////
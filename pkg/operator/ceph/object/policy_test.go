@@ -17,6 +17,7 @@ limitations under the License.
 package object
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,3 +52,17 @@ func TestModifyBucketPolicy(t *testing.T) {
 		assert.Equal(t, "b", bp.Statement[1].Sid)
 	})
 }
+
+func TestPolicyStatementConditions(t *testing.T) {
+	ps := NewPolicyStatement().WithSID("list").Allows().Actions(ListBucket).
+		ForAccountPrincipals("RGW12345678901234567", "ba-user").
+		WithCondition("StringLike", "s3:prefix", "logs/*")
+	assert.Equal(t, []string{"arn:aws:iam::RGW12345678901234567:user/ba-user"}, ps.Principal[awsPrinciple])
+	assert.Equal(t, map[string]map[string][]string{"StringLike": {"s3:prefix": {"logs/*"}}}, ps.Condition)
+
+	// identity policies have no principal
+	serialized, err := json.Marshal(NewPolicyStatement().WithSID("s1").Allows().Actions(GetObject))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(serialized), "Principal")
+	assert.NotContains(t, string(serialized), "Condition")
+}
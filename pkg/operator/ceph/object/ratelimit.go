@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/util/log"
+)
+
+// The rate limit calls of the admin ops API are only available in go-ceph with the ceph_preview
+// build tag, and only for buckets, so the requests are signed here the same way go-ceph does.
+const (
+	rateLimitPath         = "/admin/ratelimit"
+	adminOpsService       = "s3"
+	adminOpsRegion        = "default"
+	unsignedPayloadSHA256 = "UNSIGNED-PAYLOAD"
+)
+
+// RateLimitScope is the scope an RGW rate limit applies to
+type RateLimitScope string
+
+const (
+	// RateLimitScopeUser limits the requests of a user
+	RateLimitScopeUser RateLimitScope = "user"
+	// RateLimitScopeBucket limits the requests to a bucket
+	RateLimitScopeBucket RateLimitScope = "bucket"
+	// RateLimitScopeAnonymous limits the unauthenticated requests. It only exists as a global limit.
+	RateLimitScopeAnonymous RateLimitScope = "anonymous"
+)
+
+// RateLimit is an RGW rate limit as reported by the admin ops API. The limits are per minute and
+// per RGW daemon, and a zero limit is unlimited.
+type RateLimit struct {
+	Enabled       bool  `json:"enabled"`
+	MaxReadOps    int64 `json:"max_read_ops"`
+	MaxWriteOps   int64 `json:"max_write_ops"`
+	MaxReadBytes  int64 `json:"max_read_bytes"`
+	MaxWriteBytes int64 `json:"max_write_bytes"`
+}
+
+// GlobalRateLimits are the rate limits of the realm applied to the users, buckets and anonymous
+// clients that do not have their own rate limit
+type GlobalRateLimits struct {
+	User      RateLimit `json:"user_ratelimit"`
+	Bucket    RateLimit `json:"bucket_ratelimit"`
+	Anonymous RateLimit `json:"anonymous_ratelimit"`
+}
+
+// RateLimitFromSpec returns the rate limit to apply for the given spec. A nil spec is a disabled
+// rate limit.
+func RateLimitFromSpec(spec *cephv1.RateLimitSpec) RateLimit {
+	if spec == nil {
+		return RateLimit{}
+	}
+	limit := RateLimit{Enabled: true}
+	if spec.MaxReadOps != nil {
+		limit.MaxReadOps = *spec.MaxReadOps
+	}
+	if spec.MaxWriteOps != nil {
+		limit.MaxWriteOps = *spec.MaxWriteOps
+	}
+	if spec.MaxReadBytes != nil {
+		limit.MaxReadBytes = spec.MaxReadBytes.Value()
+	}
+	if spec.MaxWriteBytes != nil {
+		limit.MaxWriteBytes = spec.MaxWriteBytes.Value()
+	}
+	return limit
+}
+
+// GetUserRateLimit returns the rate limit of a user
+func GetUserRateLimit(ctx context.Context, api *admin.API, uid string) (RateLimit, error) {
+	resp := struct {
+		RateLimit RateLimit `json:"user_ratelimit"`
+	}{}
+	err := callRateLimitAPI(ctx, api, http.MethodGet, url.Values{"ratelimit-scope": {string(RateLimitScopeUser)}, "uid": {uid}}, &resp)
+	if err != nil {
+		return RateLimit{}, errors.Wrapf(err, "failed to get rate limit of user %q", uid)
+	}
+	return resp.RateLimit, nil
+}
+
+// SetUserRateLimit sets the rate limit of a user
+func SetUserRateLimit(ctx context.Context, api *admin.API, uid string, limit RateLimit) error {
+	args := rateLimitArgs(limit)
+	args.Set("ratelimit-scope", string(RateLimitScopeUser))
+	args.Set("uid", uid)
+	if err := callRateLimitAPI(ctx, api, http.MethodPost, args, nil); err != nil {
+		return errors.Wrapf(err, "failed to set rate limit of user %q", uid)
+	}
+	return nil
+}
+
+// GetBucketRateLimit returns the rate limit of a bucket
+func GetBucketRateLimit(ctx context.Context, api *admin.API, bucket string) (RateLimit, error) {
+	resp := struct {
+		RateLimit RateLimit `json:"bucket_ratelimit"`
+	}{}
+	err := callRateLimitAPI(ctx, api, http.MethodGet, url.Values{"ratelimit-scope": {string(RateLimitScopeBucket)}, "bucket": {bucket}}, &resp)
+	if err != nil {
+		return RateLimit{}, errors.Wrapf(err, "failed to get rate limit of bucket %q", bucket)
+	}
+	return resp.RateLimit, nil
+}
+
+// SetBucketRateLimit sets the rate limit of a bucket
+func SetBucketRateLimit(ctx context.Context, api *admin.API, bucket string, limit RateLimit) error {
+	args := rateLimitArgs(limit)
+	args.Set("ratelimit-scope", string(RateLimitScopeBucket))
+	args.Set("bucket", bucket)
+	if err := callRateLimitAPI(ctx, api, http.MethodPost, args, nil); err != nil {
+		return errors.Wrapf(err, "failed to set rate limit of bucket %q", bucket)
+	}
+	return nil
+}
+
+// GetGlobalRateLimits returns the global rate limits of the realm
+func GetGlobalRateLimits(ctx context.Context, api *admin.API) (GlobalRateLimits, error) {
+	limits := GlobalRateLimits{}
+	if err := callRateLimitAPI(ctx, api, http.MethodGet, url.Values{"global": {"true"}}, &limits); err != nil {
+		return GlobalRateLimits{}, errors.Wrap(err, "failed to get global rate limits")
+	}
+	return limits, nil
+}
+
+// SetGlobalRateLimit sets the global rate limit of a scope. The RGWs only apply the change once the
+// period is committed.
+func SetGlobalRateLimit(ctx context.Context, api *admin.API, scope RateLimitScope, limit RateLimit) error {
+	args := rateLimitArgs(limit)
+	args.Set("ratelimit-scope", string(scope))
+	args.Set("global", "true")
+	if err := callRateLimitAPI(ctx, api, http.MethodPost, args, nil); err != nil {
+		return errors.Wrapf(err, "failed to set global %s rate limit", scope)
+	}
+	return nil
+}
+
+// reconcileGlobalRateLimits sets the global rate limits of the realm to the ones of the object store
+// spec and commits the period if they changed. The global rate limits are left alone if the spec
+// does not set them.
+func reconcileGlobalRateLimits(objContext *Context, store *cephv1.CephObjectStore) error {
+	if store.Spec.RateLimits == nil {
+		return nil
+	}
+
+	opsCtx, err := NewMultisiteAdminOpsContext(objContext, &store.Spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get admin ops client")
+	}
+	ctx := objContext.clusterInfo.Context
+	current, err := GetGlobalRateLimits(ctx, opsCtx.AdminOpsClient)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, scope := range []struct {
+		name    RateLimitScope
+		current RateLimit
+		spec    *cephv1.RateLimitSpec
+	}{
+		{RateLimitScopeUser, current.User, store.Spec.RateLimits.User},
+		{RateLimitScopeBucket, current.Bucket, store.Spec.RateLimits.Bucket},
+		{RateLimitScopeAnonymous, current.Anonymous, store.Spec.RateLimits.Anonymous},
+	} {
+		desired := RateLimitFromSpec(scope.spec)
+		if desired == scope.current {
+			continue
+		}
+		log.NamedInfo(objContext.NsName(), logger, "setting global %s rate limit to %+v", scope.name, desired)
+		if err := SetGlobalRateLimit(ctx, opsCtx.AdminOpsClient, scope.name, desired); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	if changed {
+		if err := CommitConfigChanges(objContext); err != nil {
+			return errors.Wrap(err, "failed to commit global rate limits")
+		}
+	}
+	return nil
+}
+
+func rateLimitArgs(limit RateLimit) url.Values {
+	return url.Values{
+		"enabled":         {strconv.FormatBool(limit.Enabled)},
+		"max-read-ops":    {strconv.FormatInt(limit.MaxReadOps, 10)},
+		"max-write-ops":   {strconv.FormatInt(limit.MaxWriteOps, 10)},
+		"max-read-bytes":  {strconv.FormatInt(limit.MaxReadBytes, 10)},
+		"max-write-bytes": {strconv.FormatInt(limit.MaxWriteBytes, 10)},
+	}
+}
+
+func callRateLimitAPI(ctx context.Context, api *admin.API, method string, args url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s?%s", api.Endpoint, rateLimitPath, args.Encode()), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to build rate limit request")
+	}
+
+	credentials := aws.Credentials{AccessKeyID: api.AccessKey, SecretAccessKey: api.SecretKey}
+	err = v4.NewSigner().SignHTTP(ctx, credentials, req, unsignedPayloadSHA256, adminOpsService, adminOpsRegion, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to sign rate limit request")
+	}
+
+	resp, err := api.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read rate limit response")
+	}
+	if resp.StatusCode >= 300 {
+		return errors.Errorf("rate limit request failed with status %d. %s", resp.StatusCode, string(body))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return errors.Wrapf(err, "failed to parse rate limit response %q", string(body))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestRateLimitFromSpec(t *testing.T) {
+	assert.Equal(t, RateLimit{}, RateLimitFromSpec(nil))
+	assert.Equal(t, RateLimit{Enabled: true}, RateLimitFromSpec(&cephv1.RateLimitSpec{}))
+
+	readOps := int64(100)
+	writeBytes := resource.MustParse("1Mi")
+	limit := RateLimitFromSpec(&cephv1.RateLimitSpec{MaxReadOps: &readOps, MaxWriteBytes: &writeBytes})
+	assert.Equal(t, RateLimit{Enabled: true, MaxReadOps: 100, MaxWriteBytes: 1024 * 1024}, limit)
+}
+
+func TestRateLimitAPI(t *testing.T) {
+	ctx := context.TODO()
+	var requests []*http.Request
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req)
+			body := ""
+			switch {
+			case req.Method == http.MethodGet && req.URL.Query().Get("global") == "true":
+				body = `{"bucket_ratelimit":{"max_read_ops":0,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":false},` +
+					`"user_ratelimit":{"max_read_ops":10,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":true},` +
+					`"anonymous_ratelimit":{"max_read_ops":0,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":false}}`
+			case req.Method == http.MethodGet && req.URL.Query().Get("uid") == "missing":
+				return &http.Response{StatusCode: 404, Body: io.NopCloser(strings.NewReader(`{"Code":"NoSuchUser"}`))}, nil
+			case req.Method == http.MethodGet:
+				body = `{"user_ratelimit":{"max_read_ops":0,"max_write_ops":20,"max_read_bytes":0,"max_write_bytes":4096,"enabled":true}}`
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+		},
+	}
+	api, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+
+	t.Run("get global", func(t *testing.T) {
+		limits, err := GetGlobalRateLimits(ctx, api)
+		assert.NoError(t, err)
+		assert.Equal(t, RateLimit{Enabled: true, MaxReadOps: 10}, limits.User)
+		assert.Equal(t, RateLimit{}, limits.Bucket)
+		req := requests[len(requests)-1]
+		assert.Equal(t, "rgw.test/admin/ratelimit", req.URL.Path)
+		assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=accesskey/"))
+	})
+
+	t.Run("get user", func(t *testing.T) {
+		limit, err := GetUserRateLimit(ctx, api, "bob")
+		assert.NoError(t, err)
+		assert.Equal(t, RateLimit{Enabled: true, MaxWriteOps: 20, MaxWriteBytes: 4096}, limit)
+		assert.Equal(t, url.Values{"ratelimit-scope": {"user"}, "uid": {"bob"}}, requests[len(requests)-1].URL.Query())
+
+		_, err = GetUserRateLimit(ctx, api, "missing")
+		assert.ErrorContains(t, err, "NoSuchUser")
+	})
+
+	t.Run("set global", func(t *testing.T) {
+		err := SetGlobalRateLimit(ctx, api, RateLimitScopeAnonymous, RateLimit{Enabled: true, MaxReadOps: 5})
+		assert.NoError(t, err)
+		req := requests[len(requests)-1]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, url.Values{
+			"ratelimit-scope": {"anonymous"},
+			"global":          {"true"},
+			"enabled":         {"true"},
+			"max-read-ops":    {"5"},
+			"max-write-ops":   {"0"},
+			"max-read-bytes":  {"0"},
+			"max-write-bytes": {"0"},
+		}, req.URL.Query())
+	})
+
+	t.Run("set bucket", func(t *testing.T) {
+		err := SetBucketRateLimit(ctx, api, "photos", RateLimit{})
+		assert.NoError(t, err)
+		query := requests[len(requests)-1].URL.Query()
+		assert.Equal(t, "bucket", query.Get("ratelimit-scope"))
+		assert.Equal(t, "photos", query.Get("bucket"))
+		assert.Equal(t, "false", query.Get("enabled"))
+	})
+}
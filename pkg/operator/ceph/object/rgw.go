@@ -51,6 +51,7 @@ type clusterConfig struct {
 	clusterInfo           *cephclient.ClusterInfo
 	store                 *cephv1.CephObjectStore
 	rookVersion           string
+	rookImage             string
 	clusterSpec           *cephv1.ClusterSpec
 	ownerInfo             *k8sutil.OwnerInfo
 	DataPathMap           *config.DataPathMap
@@ -120,6 +121,16 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 		return nil
 	}
 
+	rgwsHeldByUpgrade, err := controller.GetDaemonsHeldByCanaryUpgrade(c.clusterInfo.Context, c.context, c.clusterInfo.Namespace, config.RgwType, AppName)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for RGWs held by the canary upgrade")
+	}
+
+	if rgwsHeldByUpgrade.Has(c.store.Name) {
+		log.NamedInfo(nsName, logger, "skipping reconcile of rgw deployment until the canary upgrade of the cluster continues")
+		return nil
+	}
+
 	// start a new deployment and scale up
 	// We force a single deployment and later set the deployment replica to the "instances" value
 	desiredRgwInstances := 1
@@ -343,6 +354,10 @@ func (r *ReconcileCephObjectStore) validateStore(s *cephv1.CephObjectStore) erro
 		}
 	}
 
+	if err := validateOpsLogSidecar(s); err != nil {
+		return errors.Wrap(err, "invalid ops log sidecar spec")
+	}
+
 	return nil
 }
 
@@ -79,7 +79,7 @@ func TestStartRGW(t *testing.T) {
 
 	// start a basic cluster
 	ownerInfo := client.NewMinimumOwnerInfoWithOwnerRef()
-	c := &clusterConfig{context, info, store, version, &cephv1.ClusterSpec{}, ownerInfo, data, r.client, false}
+	c := &clusterConfig{context, info, store, version, "", &cephv1.ClusterSpec{}, ownerInfo, data, r.client, false}
 
 	t.Run("Deployment is created", func(t *testing.T) {
 		store.Spec.Gateway.Instances = 1
@@ -140,7 +140,7 @@ func TestCreateObjectStore(t *testing.T) {
 	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(object...).Build()
 	r := &ReconcileCephObjectStore{client: cl, scheme: s}
 	ownerInfo := client.NewMinimumOwnerInfoWithOwnerRef()
-	c := &clusterConfig{context, info, store, "1.2.3.4", &cephv1.ClusterSpec{}, ownerInfo, data, r.client, false}
+	c := &clusterConfig{context, info, store, "1.2.3.4", "", &cephv1.ClusterSpec{}, ownerInfo, data, r.client, false}
 	err := c.createOrUpdateStore(store.Name, store.Name, store.Name, nil)
 	assert.Nil(t, err)
 }
@@ -188,7 +188,7 @@ func TestCreateObjectStoreWithKeystoneAndS3(t *testing.T) {
 	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(object...).Build()
 	r := &ReconcileCephObjectStore{client: cl, scheme: s}
 	ownerInfo := client.NewMinimumOwnerInfoWithOwnerRef()
-	c := &clusterConfig{context, info, store, "1.2.3.4", &cephv1.ClusterSpec{}, ownerInfo, data, r.client, false}
+	c := &clusterConfig{context, info, store, "1.2.3.4", "", &cephv1.ClusterSpec{}, ownerInfo, data, r.client, false}
 	err := c.createOrUpdateStore(store.Name, store.Name, store.Name, nil)
 	assert.Nil(t, err)
 }
@@ -216,6 +216,7 @@ func TestGenerateSecretName(t *testing.T) {
 		&client.ClusterInfo{},
 		&cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "mycluster"}},
 		"v1.1.0",
+		"",
 		&cephv1.ClusterSpec{},
 		&k8sutil.OwnerInfo{},
 		&config.DataPathMap{},
@@ -207,10 +207,18 @@ func (c *clusterConfig) makeRGWPodSpec(rgwConfig *rgwConfig) (v1.PodTemplateSpec
 
 	if opsLogSidecar := c.store.Spec.Gateway.OpsLogSidecar; opsLogSidecar != nil {
 		// Add the side-car container named ops-log
-		podSpec.Containers = append(podSpec.Containers,
-			*controller.RgwOpsLogSidecarContainer(opsLogFilename,
-				c.clusterInfo.Namespace, *c.clusterSpec, podNameEnvVars,
-				opsLogSidecar.Resources))
+		if len(opsLogSidecar.Sinks) > 0 {
+			opsLogContainer, err := c.makeOpsLogShipperContainer(opsLogSidecar)
+			if err != nil {
+				return v1.PodTemplateSpec{}, err
+			}
+			podSpec.Containers = append(podSpec.Containers, opsLogContainer)
+		} else {
+			podSpec.Containers = append(podSpec.Containers,
+				*controller.RgwOpsLogSidecarContainer(opsLogFilename,
+					c.clusterInfo.Namespace, *c.clusterSpec, podNameEnvVars,
+					opsLogSidecar.Resources))
+		}
 	}
 
 	// If the log collector is enabled we add the side-car container
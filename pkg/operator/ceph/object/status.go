@@ -114,6 +114,72 @@ func updateStatus(ctx context.Context, observedGeneration int64, replicaCount in
 	return nil
 }
 
+// updateLuaScriptsStatus records the Lua scripts and packages installed on the object store
+func updateLuaScriptsStatus(ctx context.Context, client client.Client, namespacedName types.NamespacedName, luaScripts *cephv1.ObjectStoreLuaScriptsStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := client.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamedDebug(namespacedName, logger, "CephObjectStore resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update lua scripts status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.LuaScripts = luaScripts
+		if err := reporting.UpdateStatus(client, objectStore); err != nil {
+			return errors.Wrapf(err, "failed to set object store %q lua scripts status", namespacedName.String())
+		}
+		return nil
+	})
+}
+
+// updateBucketIndexStatus records the status of the bucket indexes of the object store. A nil status
+// removes it from the object store status.
+func updateBucketIndexStatus(ctx context.Context, client client.Client, namespacedName types.NamespacedName, bucketIndex *cephv1.ObjectStoreBucketIndexStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := client.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamedDebug(namespacedName, logger, "CephObjectStore resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update bucket index status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.BucketIndex = bucketIndex
+		if err := reporting.UpdateStatus(client, objectStore); err != nil {
+			return errors.Wrapf(err, "failed to set object store %q bucket index status", namespacedName.String())
+		}
+		return nil
+	})
+}
+
+func updateOpsLogStatus(ctx context.Context, client client.Client, namespacedName types.NamespacedName, opsLog *cephv1.OpsLogStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := client.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamedDebug(namespacedName, logger, "CephObjectStore resource not found. Ignoring since object must be deleted.")
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update ops log status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.OpsLog = opsLog
+		if err := reporting.UpdateStatus(client, objectStore); err != nil {
+			return errors.Wrapf(err, "failed to set object store %q ops log status", namespacedName.String())
+		}
+		return nil
+	})
+}
+
 func buildStatusInfo(cephObjectStore *cephv1.CephObjectStore) map[string]string {
 	nsName := controller.NsName(cephObjectStore.Namespace, cephObjectStore.Name)
 
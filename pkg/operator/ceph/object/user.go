@@ -18,6 +18,7 @@ package object
 
 import (
 	"encoding/json"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -179,6 +180,32 @@ func CreateUser(c *Context, user ObjectUser, force bool) (*ObjectUser, int, erro
 	return decodeUser(result)
 }
 
+// AddUserCaps adds caps to the user with the given ID
+func AddUserCaps(c *Context, id, caps string) error {
+	log.NamedInfo(c.NsName(), logger, "adding caps %q to s3 user %q", caps, id)
+	result, err := runAdminCommand(c, false, "caps", "add", "--uid", id, "--caps", caps)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add caps to s3 user %q. %s", id, result)
+	}
+	return nil
+}
+
+// missingUserCaps returns the caps in the "type=perm;..." format that are not granted by the
+// current caps of a user
+func missingUserCaps(current []admin.UserCapSpec, caps string) string {
+	var missing []string
+	for _, c := range strings.Split(caps, ";") {
+		capType, perm, _ := strings.Cut(c, "=")
+		granted := slices.ContainsFunc(current, func(cur admin.UserCapSpec) bool {
+			return cur.Type == capType && (cur.Perm == "*" || cur.Perm == perm)
+		})
+		if !granted {
+			missing = append(missing, c)
+		}
+	}
+	return strings.Join(missing, ";")
+}
+
 // CreateOrRecreateUserIfExists if the user doesn't exist, it is created, should it already exist it is deleted and re-created
 // It is called from the rgw dashboard setup logic.
 func CreateOrRecreateUserIfExists(c *Context, user ObjectUser, force bool) (*ObjectUser, int, error) {
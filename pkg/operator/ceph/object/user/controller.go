@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -100,7 +101,19 @@ type ReconcileObjectStoreUser struct {
 // Add creates a new CephObjectStoreUser Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
-	return add(mgr, newReconciler(mgr, context, opManagerContext))
+	if err := add(mgr, newReconciler(mgr, context, opManagerContext)); err != nil {
+		return err
+	}
+
+	// The usage is collected separately from the reconcile of the users
+	return mgr.Add(&opcontroller.UsageCollector[*cephv1.CephObjectStoreUser]{
+		Client:           mgr.GetClient(),
+		Context:          context,
+		Kind:             controllerTypeMeta.Kind,
+		List:             &cephv1.CephObjectStoreUserList{},
+		ClusterNamespace: clusterStoreNamespace,
+		Collect:          collectUsage,
+	})
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -116,7 +129,7 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -346,6 +359,7 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 			return reconcile.Result{}, *cephObjectStoreUser, errors.Wrap(err, "failed to remove finalizer")
 		}
 		r.recorder.Eventf(cephObjectStoreUser, nil, corev1.EventTypeNormal, string(cephv1.ReconcileSucceeded), string(cephv1.ReconcileSucceeded), "successfully removed finalizer")
+		opcontroller.DeleteUsageMetrics(controllerTypeMeta.Kind, cephObjectStoreUser)
 
 		// Return and do not requeue. Successful deletion.
 		return reconcile.Result{}, *cephObjectStoreUser, nil
@@ -413,9 +427,39 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus)
 
-	// Return and do not requeue
+	// Requeue for the next step of the key rotation
+	result := reconcile.Result{RequeueAfter: keyRotationRequeue(cephObjectStoreUser, 0)}
 	log.NamedDebug(request.NamespacedName, logger, "done reconciling")
-	return reconcile.Result{}, *cephObjectStoreUser, nil
+	return result, *cephObjectStoreUser, nil
+}
+
+// collectUsage returns the capacity used by the buckets of the user and the traffic of the user
+// recorded in the RGW usage log
+func collectUsage(ctx context.Context, context *clusterd.Context, k8sClient client.Client, clusterInfo *cephclient.ClusterInfo, u *cephv1.CephObjectStoreUser) (*cephv1.UsageStatus, error) {
+	objContext, _, err := object.InitializeObjectStoreContext(context, clusterInfo, k8sClient, ctx, u.Spec.Store, newMultisiteAdminOpsCtxFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	liveUser, err := objContext.AdminOpsClient.GetUser(ctx, admin.User{ID: u.Name, GenerateStat: ptr.To(true)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get stats of user %q", u.Name)
+	}
+	usage := &cephv1.UsageStatus{}
+	if liveUser.Stat.Size != nil {
+		usage.UsedBytes = int64(*liveUser.Stat.Size)
+	}
+	if liveUser.Stat.NumObjects != nil {
+		usage.Objects = int64(*liveUser.Stat.NumObjects)
+	}
+	if liveUser.UserQuota.Enabled != nil && *liveUser.UserQuota.Enabled && liveUser.UserQuota.MaxSize != nil && *liveUser.UserQuota.MaxSize > 0 {
+		usage.QuotaBytes = *liveUser.UserQuota.MaxSize
+	}
+
+	if err := object.SetUsageLogTotals(ctx, objContext.AdminOpsClient, u.Name, usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
 }
 
 func (r *ReconcileObjectStoreUser) reconcileCephUser(cephObjectStoreUser *cephv1.CephObjectStoreUser, userConfig *admin.User) (reconcile.Result, error) {
@@ -504,14 +548,11 @@ func (r *ReconcileObjectStoreUser) createOrUpdateCephUser(u *cephv1.CephObjectSt
 	}
 
 	if len(targetUser.Keys) == 0 {
-		// use the keys already set on the user & remove all but one key
-		if len(liveUser.Keys) == 0 {
-			// something is wrong, there should be at least one key
-			return errors.Errorf("no keys set for user %q", u.Name)
+		// use the keys already set on the user, rotating them if due, & remove all others
+		targetUser.Keys, err = r.managedUserKeys(u, liveUser.Keys)
+		if err != nil {
+			return errors.Wrapf(err, "failed to select keys for user %q", u.Name)
 		}
-
-		targetUser.Keys = []admin.UserKeySpec{liveUser.Keys[0]}
-		log.NamedDebug(nsName, logger, "reducing user %q keypairs to %v", u.Name, targetUser.Keys)
 	}
 
 	if err := r.reconcileUserKeys(nsName, targetUser.Keys); err != nil {
@@ -796,6 +837,14 @@ func (r *ReconcileObjectStoreUser) validateUser(u *cephv1.CephObjectStoreUser) e
 	if u.Spec.Store == "" {
 		return errors.New("missing store")
 	}
+	if u.Spec.KeyRotation != nil {
+		if len(u.Spec.Keys) > 0 {
+			return errors.New("keyRotation cannot be used together with keys")
+		}
+		if u.Spec.KeyRotation.Interval == nil || u.Spec.KeyRotation.Interval.Duration <= 0 {
+			return errors.New("keyRotation interval must be greater than 0")
+		}
+	}
 	// When accountRef is set, validate that displayName is IAM-compatible
 	if u.Spec.AccountRef.Name != "" {
 		displayName := u.Spec.DisplayName
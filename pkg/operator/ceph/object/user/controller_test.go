@@ -784,6 +784,64 @@ func TestValidateUser(t *testing.T) {
 		}
 		assert.NoError(t, r.validateUser(u))
 	})
+
+	t.Run("key rotation with an interval is valid", func(t *testing.T) {
+		u := &cephv1.CephObjectStoreUser{
+			ObjectMeta: metav1.ObjectMeta{Name: "user1", Namespace: namespace},
+			Spec: cephv1.ObjectStoreUserSpec{
+				Store:       store,
+				KeyRotation: &cephv1.ObjectKeyRotationSpec{Interval: &metav1.Duration{Duration: time.Hour}},
+			},
+		}
+		assert.NoError(t, r.validateUser(u))
+	})
+
+	t.Run("key rotation without an interval is invalid", func(t *testing.T) {
+		u := &cephv1.CephObjectStoreUser{
+			ObjectMeta: metav1.ObjectMeta{Name: "user1", Namespace: namespace},
+			Spec: cephv1.ObjectStoreUserSpec{
+				Store:       store,
+				KeyRotation: &cephv1.ObjectKeyRotationSpec{},
+			},
+		}
+		assert.Error(t, r.validateUser(u))
+	})
+
+	t.Run("key rotation with explicit keys is invalid", func(t *testing.T) {
+		u := &cephv1.CephObjectStoreUser{
+			ObjectMeta: metav1.ObjectMeta{Name: "user1", Namespace: namespace},
+			Spec: cephv1.ObjectStoreUserSpec{
+				Store:       store,
+				Keys:        []cephv1.ObjectUserKey{{}},
+				KeyRotation: &cephv1.ObjectKeyRotationSpec{Interval: &metav1.Duration{Duration: time.Hour}},
+			},
+		}
+		err := r.validateUser(u)
+		assert.ErrorContains(t, err, "keyRotation cannot be used together with keys")
+	})
+}
+
+func TestKeyRotationRequeue(t *testing.T) {
+	u := &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "user1", Namespace: namespace, CreationTimestamp: metav1.Now()},
+		Spec:       cephv1.ObjectStoreUserSpec{Store: store},
+	}
+	assert.Equal(t, 5*time.Minute, keyRotationRequeue(u, 5*time.Minute))
+
+	// the pending revocation of the previous key shortens the requeue
+	u.Status = &cephv1.ObjectStoreUserStatus{KeyRotation: &cephv1.ObjectKeyRotationStatus{
+		PreviousAccessKeyID:   "old",
+		PreviousKeyRevokeTime: &metav1.Time{Time: time.Now().Add(time.Minute)},
+	}}
+	requeue := keyRotationRequeue(u, 5*time.Minute)
+	assert.Greater(t, requeue, time.Duration(0))
+	assert.LessOrEqual(t, requeue, time.Minute)
+
+	// a later rotation does not delay the usage collection
+	u.Status = nil
+	u.Spec.KeyRotation = &cephv1.ObjectKeyRotationSpec{Interval: &metav1.Duration{Duration: 720 * time.Hour}}
+	assert.Equal(t, 5*time.Minute, keyRotationRequeue(u, 5*time.Minute))
+	assert.Greater(t, keyRotationRequeue(u, 0), 700*time.Hour)
 }
 
 func TestResolveAccountRef(t *testing.T) {
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// managedUserKeys returns the key pairs the user keeps when the operator generates its keys. The
+// first key is the active key published to the user secret. When a rotation is due, a new key pair
+// is added and becomes active while the previous key pair is kept until the grace period ends.
+func (r *ReconcileObjectStoreUser) managedUserKeys(u *cephv1.CephObjectStoreUser, liveKeys []admin.UserKeySpec) ([]admin.UserKeySpec, error) {
+	nsName := opcontroller.NsName(u.Namespace, u.Name)
+
+	status := &cephv1.ObjectKeyRotationStatus{}
+	if u.Status != nil && u.Status.KeyRotation != nil {
+		status = u.Status.KeyRotation.DeepCopy()
+	}
+
+	// with several keys, prefer the key already published to applications
+	preferredAccessKey := ""
+	if len(liveKeys) > 1 {
+		preferredAccessKey = r.publishedAccessKey(u)
+	}
+	active, ok := object.ActiveUserKey(liveKeys, status.PreviousAccessKeyID, preferredAccessKey)
+	if !ok {
+		// something is wrong, there should be at least one key
+		return nil, errors.Errorf("no keys set for user %q", u.Name)
+	}
+
+	now := time.Now()
+	action, _ := object.NextKeyRotation(u.Spec.KeyRotation, status, u.CreationTimestamp.Time, now)
+	switch action {
+	case object.KeyRotationRotate:
+		newKey, err := object.CreateUserKey(r.opManagerContext, r.objContext.AdminOpsClient, u.Name, liveKeys)
+		if err != nil {
+			return nil, err
+		}
+		status.KeyGeneration++
+		status.LastRotationTime = &metav1.Time{Time: now}
+		status.PreviousAccessKeyID = active.AccessKey
+		status.PreviousKeyRevokeTime = &metav1.Time{Time: now.Add(object.KeyRotationGracePeriod(u.Spec.KeyRotation))}
+		// record the rotation before the key is published so that a failure later in the
+		// reconcile does not add yet another key
+		if err := r.updateKeyRotationStatus(u, status); err != nil {
+			return nil, err
+		}
+		log.NamedInfo(nsName, logger, "rotated key of user %q to generation %d, previous key %q is revoked at %s",
+			u.Name, status.KeyGeneration, status.PreviousAccessKeyID, status.PreviousKeyRevokeTime.Format(time.RFC3339))
+		r.recorder.Eventf(u, nil, corev1.EventTypeNormal, "KeyRotated", "KeyRotated", "rotated key to generation %d", status.KeyGeneration)
+		return []admin.UserKeySpec{newKey, active}, nil
+
+	case object.KeyRotationRevoke:
+		if err := object.RemoveUserKey(r.opManagerContext, r.objContext.AdminOpsClient, u.Name, status.PreviousAccessKeyID); err != nil {
+			return nil, err
+		}
+		log.NamedInfo(nsName, logger, "revoked previous key %q of user %q", status.PreviousAccessKeyID, u.Name)
+		status.PreviousAccessKeyID = ""
+		status.PreviousKeyRevokeTime = nil
+		if err := r.updateKeyRotationStatus(u, status); err != nil {
+			return nil, err
+		}
+		return []admin.UserKeySpec{active}, nil
+	}
+
+	keys := []admin.UserKeySpec{active}
+	if status.PreviousAccessKeyID != "" {
+		for _, k := range liveKeys {
+			if k.AccessKey == status.PreviousAccessKeyID {
+				keys = append(keys, k)
+			}
+		}
+	}
+	if len(liveKeys) > len(keys) {
+		log.NamedDebug(nsName, logger, "reducing user %q keypairs to %d", u.Name, len(keys))
+	}
+	return keys, nil
+}
+
+// publishedAccessKey returns the access key in the user secret, if any
+func (r *ReconcileObjectStoreUser) publishedAccessKey(u *cephv1.CephObjectStoreUser) string {
+	secret := &corev1.Secret{}
+	err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: generateCephUserSecretName(u), Namespace: u.Namespace}, secret)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			log.NamedDebug(opcontroller.NsName(u.Namespace, u.Name), logger, "failed to get user secret. %v", err)
+		}
+		return ""
+	}
+	return string(secret.Data["AccessKey"])
+}
+
+// updateKeyRotationStatus updates `.status.keyRotation` and the copy of the user being reconciled
+func (r *ReconcileObjectStoreUser) updateKeyRotationStatus(u *cephv1.CephObjectStoreUser, status *cephv1.ObjectKeyRotationStatus) error {
+	nsName := opcontroller.NsName(u.Namespace, u.Name)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current := &cephv1.CephObjectStoreUser{}
+		if err := r.client.Get(r.opManagerContext, nsName, current); err != nil {
+			return err
+		}
+		if current.Status == nil {
+			current.Status = &cephv1.ObjectStoreUserStatus{}
+		}
+		current.Status.KeyRotation = status.DeepCopy()
+		return reporting.UpdateStatus(r.client, current)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update key rotation status of user %q", u.Name)
+	}
+
+	if u.Status == nil {
+		u.Status = &cephv1.ObjectStoreUserStatus{}
+	}
+	u.Status.KeyRotation = status.DeepCopy()
+	return nil
+}
+
+// keyRotationRequeue shortens the requeue of a reconcile so that the next step of the key
+// rotation happens on time
+func keyRotationRequeue(u *cephv1.CephObjectStoreUser, result time.Duration) time.Duration {
+	if len(u.Spec.Keys) > 0 {
+		return result
+	}
+	var status *cephv1.ObjectKeyRotationStatus
+	if u.Status != nil {
+		status = u.Status.KeyRotation
+	}
+	_, wait := object.NextKeyRotation(u.Spec.KeyRotation, status, u.CreationTimestamp.Time, time.Now())
+	if wait > 0 && (result == 0 || wait < result) {
+		return wait
+	}
+	return result
+}
@@ -85,6 +85,7 @@ type ReconcileObjectZone struct {
 	clusterSpec      *cephv1.ClusterSpec
 	opManagerContext context.Context
 	recorder         events.EventRecorder
+	zoneSyncContexts map[string]*zoneSyncHealth
 }
 
 // Add creates a new CephObjectZone Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -101,12 +102,13 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 		context:          context,
 		opManagerContext: opManagerContext,
 		recorder:         mgr.GetEventRecorder("rook-" + controllerName),
+		zoneSyncContexts: make(map[string]*zoneSyncHealth),
 	}
 }
 
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -221,6 +223,8 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 
 	// DELETE: the CR was deleted
 	if !cephObjectZone.GetDeletionTimestamp().IsZero() {
+		// Stop monitoring the multisite sync status of the zone
+		r.cancelSyncMonitoring(cephObjectZone)
 		res, err := r.deleteCephObjectZone(cephObjectZone, realmName)
 		return res, *cephObjectZone, err
 	}
@@ -235,11 +239,14 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 	}
 
 	// Create/Update Ceph Zone
-	_, err = r.createorUpdateCephZone(cephObjectZone, realmName)
+	objContext, err := r.createorUpdateCephZone(cephObjectZone, realmName)
 	if err != nil {
 		return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, cephObjectZone, request.NamespacedName, "failed to create ceph zone", err)
 	}
 
+	// Start (or stop if disabled) monitoring the multisite sync status of the zone
+	r.startSyncMonitoring(objContext, cephObjectZone)
+
 	// update ObservedGeneration in status at the end of reconcile
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus)
@@ -249,7 +256,7 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 	return reconcile.Result{}, *cephObjectZone, nil
 }
 
-func (r *ReconcileObjectZone) createorUpdateCephZone(zone *cephv1.CephObjectZone, realmName string) (reconcile.Result, error) {
+func (r *ReconcileObjectZone) createorUpdateCephZone(zone *cephv1.CephObjectZone, realmName string) (*object.Context, error) {
 	nsName := opcontroller.NsName(zone.Namespace, zone.Name)
 	log.NamedInfo(nsName, logger, "creating object zone in zonegroup %q in realm %q", zone.Spec.ZoneGroup, realmName)
 
@@ -260,10 +267,10 @@ func (r *ReconcileObjectZone) createorUpdateCephZone(zone *cephv1.CephObjectZone
 
 	err := r.createPoolsAndZone(objContext, zone)
 	if err != nil {
-		return reconcile.Result{}, err
+		return nil, err
 	}
 
-	return reconcile.Result{}, nil
+	return objContext, nil
 }
 
 func (r *ReconcileObjectZone) createPoolsAndZone(objContext *object.Context, zone *cephv1.CephObjectZone) error {
@@ -446,7 +453,7 @@ func (r *ReconcileObjectZone) updateStatus(observedGeneration int64, name types.
 		return
 	}
 	if objectZone.Status == nil {
-		objectZone.Status = &cephv1.Status{}
+		objectZone.Status = &cephv1.ObjectZoneStatus{}
 	}
 
 	objectZone.Status.Phase = status
@@ -364,7 +364,10 @@ func TestCephObjectZoneController(t *testing.T) {
 
 	cl = fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(object...).Build()
 
-	r = &ReconcileObjectZone{client: cl, scheme: s, context: c, clusterInfo: clusterInfo, recorder: events.NewFakeRecorder(50)}
+	r = &ReconcileObjectZone{client: cl, scheme: s, context: c, clusterInfo: clusterInfo, recorder: events.NewFakeRecorder(50), opManagerContext: ctx, zoneSyncContexts: make(map[string]*zoneSyncHealth)}
+
+	syncStatusChecked := make(chan struct{}, 1)
+	defer mockZoneSyncStatus(syncStatusChecked)()
 
 	err = r.client.Get(context.TODO(), types.NamespacedName{Name: zonegroup, Namespace: namespace}, objectZoneGroup)
 	assert.NoError(t, err, objectZoneGroup)
@@ -385,4 +388,10 @@ func TestCephObjectZoneController(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, createPoolsCalled)
 	assert.True(t, commitChangesCalled)
+
+	// the multisite sync status is monitored once the zone is ready
+	assert.Len(t, r.zoneSyncContexts, 1)
+	<-syncStatusChecked
+	r.cancelSyncMonitoring(objectZone)
+	assert.Empty(t, r.zoneSyncContexts)
 }
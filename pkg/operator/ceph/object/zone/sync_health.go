@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/util/log"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var defaultSyncStatusCheckInterval = 1 * time.Minute
+
+// allow this to be overridden for unit tests
+var getZoneSyncStatusFunc = object.GetZoneSyncStatus
+
+type zoneSyncHealth struct {
+	internalCtx    context.Context
+	internalCancel context.CancelFunc
+	// interval and buckets are the settings of the running checker
+	interval time.Duration
+	buckets  []string
+}
+
+type syncChecker struct {
+	ctx            context.Context
+	client         client.Client
+	objContext     *object.Context
+	namespacedName types.NamespacedName
+	interval       time.Duration
+	buckets        []string
+}
+
+// newSyncChecker creates a checker of the multisite replication status of a zone and of the given
+// buckets of the zone
+func newSyncChecker(ctx context.Context, client client.Client, objContext *object.Context, namespacedName types.NamespacedName, checkSpec cephv1.HealthCheckSpec, buckets []string) *syncChecker {
+	c := &syncChecker{
+		ctx:            ctx,
+		client:         client,
+		objContext:     objContext,
+		namespacedName: namespacedName,
+		interval:       defaultSyncStatusCheckInterval,
+		buckets:        buckets,
+	}
+
+	// allow overriding the check interval
+	if checkSpec.Interval != nil {
+		log.NamedInfo(namespacedName, logger, "multisite sync status check interval is %q", checkSpec.Interval.Duration.String())
+		c.interval = checkSpec.Interval.Duration
+	}
+
+	return c
+}
+
+// checkSync periodically checks the multisite replication status of the zone
+func (c *syncChecker) checkSync(ctx context.Context) {
+	// check the sync status immediately before starting the loop
+	c.checkSyncHealth()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.NamedInfo(c.namespacedName, logger, "stopping monitoring of multisite sync status")
+			return
+
+		case <-time.After(c.interval):
+			log.NamedDebug(c.namespacedName, logger, "checking multisite sync status")
+			c.checkSyncHealth()
+		}
+	}
+}
+
+func (c *syncChecker) checkSyncHealth() {
+	syncStatus, err := getZoneSyncStatusFunc(c.objContext, c.buckets)
+	if err != nil {
+		log.NamedDebug(c.namespacedName, logger, "failed to check multisite sync status. %v", err)
+		syncStatus = &cephv1.ObjectZoneSyncStatus{Details: err.Error()}
+	}
+	syncStatus.LastChecked = time.Now().UTC().Format(time.RFC3339)
+	condition := syncHealthCondition(syncStatus, err)
+
+	c.updateZoneSyncStatus(syncStatus, &condition)
+	c.updateObjectStoresSyncCondition(&condition)
+}
+
+// syncHealthCondition returns the MultisiteSyncHealthy condition matching the sync status
+func syncHealthCondition(syncStatus *cephv1.ObjectZoneSyncStatus, checkErr error) cephv1.Condition {
+	condition := cephv1.Condition{Type: cephv1.ConditionMultisiteSyncHealthy}
+	if checkErr != nil {
+		condition.Status = v1.ConditionUnknown
+		condition.Reason = cephv1.MultisiteSyncCheckFailedReason
+		condition.Message = checkErr.Error()
+		return condition
+	}
+
+	var issues []string
+	if m := syncStatus.Metadata; m != nil && m.ShardsBehind > 0 {
+		issues = append(issues, fmt.Sprintf("metadata is behind on %d shards with a lag of %ds", m.ShardsBehind, m.LagSeconds))
+	}
+	for _, d := range syncStatus.Data {
+		if strings.HasPrefix(d.State, "not syncing") {
+			issues = append(issues, fmt.Sprintf("data is not syncing from zone %q", d.SourceZone))
+		} else if d.ShardsBehind > 0 {
+			issues = append(issues, fmt.Sprintf("data is behind on %d shards from zone %q with a lag of %ds", d.ShardsBehind, d.SourceZone, d.LagSeconds))
+		}
+	}
+	for _, b := range syncStatus.Buckets {
+		if b.ShardsBehind > 0 {
+			issues = append(issues, fmt.Sprintf("bucket %q is behind on %d shards from zone %q", b.Bucket, b.ShardsBehind, b.SourceZone))
+		}
+	}
+	if len(issues) > 0 {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = cephv1.MultisiteSyncBehindReason
+		condition.Message = strings.Join(issues, "; ")
+	} else {
+		condition.Status = v1.ConditionTrue
+		condition.Reason = cephv1.MultisiteSyncCaughtUpReason
+		condition.Message = "metadata and data are caught up"
+	}
+	if syncStatus.ErrorCount > 0 {
+		condition.Message += fmt.Sprintf("; %d entries in the sync error log", syncStatus.ErrorCount)
+	}
+	return condition
+}
+
+// updateZoneSyncStatus sets the sync status and condition of the zone. A nil condition removes the
+// condition from the zone status.
+func (c *syncChecker) updateZoneSyncStatus(syncStatus *cephv1.ObjectZoneSyncStatus, condition *cephv1.Condition) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectZone := &cephv1.CephObjectZone{}
+		if err := c.client.Get(c.ctx, c.namespacedName, objectZone); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.NamedDebug(c.namespacedName, logger, "CephObjectZone resource not found for updating the sync status, ignoring.")
+				return nil
+			}
+			return err
+		}
+		if objectZone.Status == nil {
+			objectZone.Status = &cephv1.ObjectZoneStatus{}
+		}
+
+		objectZone.Status.SyncStatus = syncStatus
+		if condition != nil {
+			cephv1.SetStatusCondition(&objectZone.Status.Conditions, *condition)
+		} else {
+			removeStatusCondition(&objectZone.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy)
+		}
+		return reporting.UpdateStatus(c.client, objectZone)
+	})
+	if err != nil {
+		log.NamedError(c.namespacedName, logger, "failed to update multisite sync status of the object zone. %v", err)
+		return
+	}
+	log.NamedDebug(c.namespacedName, logger, "object zone multisite sync status updated")
+}
+
+// updateObjectStoresSyncCondition sets the sync condition on the object stores of the zone. A nil
+// condition removes the condition from the object stores status.
+func (c *syncChecker) updateObjectStoresSyncCondition(condition *cephv1.Condition) {
+	stores := &cephv1.CephObjectStoreList{}
+	if err := c.client.List(c.ctx, stores, client.InNamespace(c.namespacedName.Namespace)); err != nil {
+		log.NamedWarning(c.namespacedName, logger, "failed to list object stores to update the multisite sync condition. %v", err)
+		return
+	}
+
+	for i := range stores.Items {
+		store := &stores.Items[i]
+		if store.Spec.Zone.Name != c.namespacedName.Name || store.Status == nil {
+			continue
+		}
+		storeName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := c.client.Get(c.ctx, storeName, store); err != nil {
+				return err
+			}
+			if condition != nil {
+				cephv1.SetStatusCondition(&store.Status.Conditions, *condition)
+			} else {
+				removeStatusCondition(&store.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy)
+			}
+			return reporting.UpdateStatus(c.client, store)
+		})
+		if err != nil {
+			log.NamedWarning(c.namespacedName, logger, "failed to update multisite sync condition of object store %q. %v", store.Name, err)
+		}
+	}
+}
+
+func removeStatusCondition(conditions *[]cephv1.Condition, conditionType cephv1.ConditionType) {
+	filtered := (*conditions)[:0]
+	for _, condition := range *conditions {
+		if condition.Type != conditionType {
+			filtered = append(filtered, condition)
+		}
+	}
+	*conditions = filtered
+}
+
+func zoneSyncHealthKeyName(zone *cephv1.CephObjectZone) string {
+	return types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}.String()
+}
+
+// startSyncMonitoring starts the periodic check of the multisite sync status of the zone, or stops
+// it if the check is disabled. This is a noop if the monitoring is already running with the same
+// settings, the monitoring is restarted if the interval or the checked buckets changed.
+func (r *ReconcileObjectZone) startSyncMonitoring(objContext *object.Context, zone *cephv1.CephObjectZone) {
+	nsName := types.NamespacedName{Namespace: zone.Namespace, Name: zone.Name}
+	checker := newSyncChecker(r.opManagerContext, r.client, objContext, nsName, zone.Spec.SyncStatusCheck, zone.Spec.SyncStatusBuckets)
+
+	if zone.Spec.SyncStatusCheck.Disabled {
+		r.cancelSyncMonitoring(zone)
+		// Reset the sync status
+		if zone.Status != nil && (zone.Status.SyncStatus != nil || cephv1.FindStatusCondition(zone.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy) != nil) {
+			checker.updateZoneSyncStatus(nil, nil)
+			checker.updateObjectStoresSyncCondition(nil)
+		}
+		return
+	}
+
+	key := zoneSyncHealthKeyName(zone)
+	if health, ok := r.zoneSyncContexts[key]; ok {
+		if health.interval == checker.interval && slices.Equal(health.buckets, checker.buckets) {
+			log.NamedDebug(nsName, logger, "multisite sync monitoring go routine already running")
+			return
+		}
+		log.NamedInfo(nsName, logger, "restarting monitoring of multisite sync status since the check settings changed")
+		r.cancelSyncMonitoring(zone)
+	}
+	internalCtx, internalCancel := context.WithCancel(r.opManagerContext)
+	r.zoneSyncContexts[key] = &zoneSyncHealth{
+		internalCtx:    internalCtx,
+		internalCancel: internalCancel,
+		interval:       checker.interval,
+		buckets:        checker.buckets,
+	}
+	log.NamedInfo(nsName, logger, "starting monitoring of multisite sync status")
+	go checker.checkSync(internalCtx)
+}
+
+// cancelSyncMonitoring stops the multisite sync monitoring. This is a noop if monitoring is not running.
+func (r *ReconcileObjectZone) cancelSyncMonitoring(zone *cephv1.CephObjectZone) {
+	key := zoneSyncHealthKeyName(zone)
+	if health, ok := r.zoneSyncContexts[key]; ok {
+		// Cancel the context to stop the go routine
+		health.internalCancel()
+		delete(r.zoneSyncContexts, key)
+	}
+}
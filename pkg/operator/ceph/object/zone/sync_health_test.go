@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSyncHealthCondition(t *testing.T) {
+	t.Run("check failed", func(t *testing.T) {
+		condition := syncHealthCondition(&cephv1.ObjectZoneSyncStatus{}, errors.New("timeout"))
+		assert.Equal(t, v1.ConditionUnknown, condition.Status)
+		assert.Equal(t, cephv1.MultisiteSyncCheckFailedReason, condition.Reason)
+		assert.Equal(t, "timeout", condition.Message)
+	})
+
+	t.Run("caught up", func(t *testing.T) {
+		condition := syncHealthCondition(&cephv1.ObjectZoneSyncStatus{
+			Metadata: &cephv1.ObjectSyncStatus{State: "no sync (zone is master)"},
+			Data:     []cephv1.ObjectDataSyncStatus{{SourceZone: "zone-b", ObjectSyncStatus: cephv1.ObjectSyncStatus{State: "syncing", CaughtUp: true}}},
+		}, nil)
+		assert.Equal(t, cephv1.ConditionMultisiteSyncHealthy, condition.Type)
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, cephv1.MultisiteSyncCaughtUpReason, condition.Reason)
+	})
+
+	t.Run("behind with errors", func(t *testing.T) {
+		condition := syncHealthCondition(&cephv1.ObjectZoneSyncStatus{
+			Metadata: &cephv1.ObjectSyncStatus{State: "syncing", ShardsBehind: 1, LagSeconds: 30},
+			Data: []cephv1.ObjectDataSyncStatus{
+				{SourceZone: "zone-a", ObjectSyncStatus: cephv1.ObjectSyncStatus{State: "syncing", ShardsBehind: 2, LagSeconds: 600}},
+				{SourceZone: "zone-c", ObjectSyncStatus: cephv1.ObjectSyncStatus{State: "not syncing from zone"}},
+			},
+			Buckets:    []cephv1.ObjectBucketSyncStatus{{Bucket: "bucket1", SourceZone: "zone-a", ShardsBehind: 3}},
+			ErrorCount: 4,
+		}, nil)
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Equal(t, cephv1.MultisiteSyncBehindReason, condition.Reason)
+		assert.Equal(t, `metadata is behind on 1 shards with a lag of 30s; data is behind on 2 shards from zone "zone-a" with a lag of 600s; data is not syncing from zone "zone-c"; bucket "bucket1" is behind on 3 shards from zone "zone-a"; 4 entries in the sync error log`, condition.Message)
+	})
+}
+
+func TestSyncChecker(t *testing.T) {
+	ctx := context.TODO()
+	ns := "rook-ceph"
+	objectZone := &cephv1.CephObjectZone{
+		ObjectMeta: metav1.ObjectMeta{Name: "zone-b", Namespace: ns},
+		Spec:       cephv1.ObjectZoneSpec{ZoneGroup: "zonegroup-a"},
+		Status:     &cephv1.ObjectZoneStatus{Status: cephv1.Status{Phase: "Ready"}},
+	}
+	storeInZone := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store-b", Namespace: ns},
+		Spec:       cephv1.ObjectStoreSpec{Zone: cephv1.ZoneSpec{Name: "zone-b"}},
+		Status:     &cephv1.ObjectStoreStatus{Phase: "Ready"},
+	}
+	otherStore := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "store", Namespace: ns},
+		Status:     &cephv1.ObjectStoreStatus{Phase: "Ready"},
+	}
+
+	s := runtime.NewScheme()
+	assert.NoError(t, cephv1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objectZone, storeInZone, otherStore).
+		WithStatusSubresource(objectZone, storeInZone, otherStore).Build()
+
+	getZoneSyncStatusFunc = func(objContext *object.Context, buckets []string) (*cephv1.ObjectZoneSyncStatus, error) {
+		return &cephv1.ObjectZoneSyncStatus{
+			Metadata: &cephv1.ObjectSyncStatus{State: "syncing", CaughtUp: true},
+			Data:     []cephv1.ObjectDataSyncStatus{{SourceZone: "zone-a", ObjectSyncStatus: cephv1.ObjectSyncStatus{State: "syncing", ShardsBehind: 3}}},
+		}, nil
+	}
+	defer func() { getZoneSyncStatusFunc = object.GetZoneSyncStatus }()
+
+	nsName := types.NamespacedName{Namespace: ns, Name: "zone-b"}
+	interval := metav1.Duration{Duration: 10}
+	checker := newSyncChecker(ctx, cl, &object.Context{}, nsName, cephv1.HealthCheckSpec{Interval: &interval}, nil)
+	assert.Equal(t, interval.Duration, checker.interval)
+
+	checker.checkSyncHealth()
+
+	zone := &cephv1.CephObjectZone{}
+	assert.NoError(t, cl.Get(ctx, nsName, zone))
+	assert.Equal(t, "Ready", zone.Status.Phase)
+	assert.Equal(t, 3, zone.Status.SyncStatus.Data[0].ShardsBehind)
+	assert.NotEmpty(t, zone.Status.SyncStatus.LastChecked)
+	condition := cephv1.FindStatusCondition(zone.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy)
+	assert.Equal(t, v1.ConditionFalse, condition.Status)
+
+	store := &cephv1.CephObjectStore{}
+	assert.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: ns, Name: "store-b"}, store))
+	condition = cephv1.FindStatusCondition(store.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy)
+	assert.Equal(t, v1.ConditionFalse, condition.Status)
+	assert.Equal(t, cephv1.MultisiteSyncBehindReason, condition.Reason)
+
+	assert.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: ns, Name: "store"}, store))
+	assert.Nil(t, cephv1.FindStatusCondition(store.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy))
+
+	t.Run("check failure", func(t *testing.T) {
+		getZoneSyncStatusFunc = func(objContext *object.Context, buckets []string) (*cephv1.ObjectZoneSyncStatus, error) {
+			return nil, errors.New("failed to get sync status")
+		}
+		checker.checkSyncHealth()
+		assert.NoError(t, cl.Get(ctx, nsName, zone))
+		assert.Equal(t, "failed to get sync status", zone.Status.SyncStatus.Details)
+		condition := cephv1.FindStatusCondition(zone.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy)
+		assert.Equal(t, v1.ConditionUnknown, condition.Status)
+	})
+
+	t.Run("settings changed", func(t *testing.T) {
+		checked := make(chan struct{}, 1)
+		defer mockZoneSyncStatus(checked)()
+		r := &ReconcileObjectZone{client: cl, opManagerContext: ctx, zoneSyncContexts: make(map[string]*zoneSyncHealth)}
+		assert.NoError(t, cl.Get(ctx, nsName, zone))
+		zone.Spec.SyncStatusCheck.Interval = &metav1.Duration{Duration: time.Hour}
+
+		r.startSyncMonitoring(&object.Context{}, zone)
+		<-checked
+		running := r.zoneSyncContexts[nsName.String()]
+		assert.Equal(t, time.Hour, running.interval)
+
+		// the running checker is kept while the interval is the same
+		r.startSyncMonitoring(&object.Context{}, zone)
+		assert.Same(t, running, r.zoneSyncContexts[nsName.String()])
+
+		zone.Spec.SyncStatusCheck.Interval = &metav1.Duration{Duration: time.Minute}
+		r.startSyncMonitoring(&object.Context{}, zone)
+		<-checked
+		assert.Error(t, running.internalCtx.Err())
+		assert.Equal(t, time.Minute, r.zoneSyncContexts[nsName.String()].interval)
+
+		// the checker is also restarted when the checked buckets change
+		running = r.zoneSyncContexts[nsName.String()]
+		zone.Spec.SyncStatusBuckets = []string{"bucket1"}
+		r.startSyncMonitoring(&object.Context{}, zone)
+		<-checked
+		assert.Error(t, running.internalCtx.Err())
+		assert.Equal(t, []string{"bucket1"}, r.zoneSyncContexts[nsName.String()].buckets)
+		r.cancelSyncMonitoring(zone)
+	})
+
+	t.Run("monitoring disabled", func(t *testing.T) {
+		r := &ReconcileObjectZone{client: cl, opManagerContext: ctx, zoneSyncContexts: make(map[string]*zoneSyncHealth)}
+		internalCtx, internalCancel := context.WithCancel(ctx)
+		r.zoneSyncContexts[nsName.String()] = &zoneSyncHealth{internalCtx: internalCtx, internalCancel: internalCancel}
+
+		assert.NoError(t, cl.Get(ctx, nsName, zone))
+		zone.Spec.SyncStatusCheck.Disabled = true
+		r.startSyncMonitoring(&object.Context{}, zone)
+		assert.Empty(t, r.zoneSyncContexts)
+		assert.Error(t, internalCtx.Err())
+
+		assert.NoError(t, cl.Get(ctx, nsName, zone))
+		assert.Nil(t, zone.Status.SyncStatus)
+		assert.Nil(t, cephv1.FindStatusCondition(zone.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy))
+		assert.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: ns, Name: "store-b"}, store))
+		assert.Nil(t, cephv1.FindStatusCondition(store.Status.Conditions, cephv1.ConditionMultisiteSyncHealthy))
+	})
+}
+
+// mockZoneSyncStatus reports an empty sync status and notifies the channel on each check. The
+// returned function restores the original implementation.
+func mockZoneSyncStatus(checked chan struct{}) func() {
+	getZoneSyncStatusFunc = func(objContext *object.Context, buckets []string) (*cephv1.ObjectZoneSyncStatus, error) {
+		select {
+		case checked <- struct{}{}:
+		default:
+		}
+		return &cephv1.ObjectZoneSyncStatus{}, nil
+	}
+	return func() {
+		getZoneSyncStatusFunc = object.GetZoneSyncStatus
+	}
+}
@@ -100,7 +100,18 @@ func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext contex
 	}); err != nil {
 		return fmt.Errorf("failed to index CephRadosNamespaceName by %s: %v", cephRNSNameIndex, err)
 	}
-	return add(opManagerContext, mgr, newReconciler(mgr, context, opManagerContext, opConfig))
+	if err := add(opManagerContext, mgr, newReconciler(mgr, context, opManagerContext, opConfig)); err != nil {
+		return err
+	}
+
+	// The usage is collected separately from the reconcile of the rados namespaces
+	return mgr.Add(&opcontroller.UsageCollector[*cephv1.CephBlockPoolRadosNamespace]{
+		Client:  mgr.GetClient(),
+		Context: context,
+		Kind:    controllerTypeMeta.Kind,
+		List:    &cephv1.CephBlockPoolRadosNamespaceList{},
+		Collect: collectUsage,
+	})
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -118,7 +129,7 @@ func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerCont
 
 func add(opManagerContext context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: opcontroller.WithTracing(controllerName, r)})
 	if err != nil {
 		return err
 	}
@@ -340,6 +351,7 @@ func (r *ReconcileCephBlockPoolRadosNamespace) reconcile(request reconcile.Reque
 		if err != nil {
 			return reconcile.Result{}, radosNamespace, errors.Wrap(err, "failed to remove finalizer")
 		}
+		opcontroller.DeleteUsageMetrics(controllerTypeMeta.Kind, radosNamespace)
 
 		// Return and do not requeue. Successful deletion.
 		return reconcile.Result{}, radosNamespace, nil
@@ -417,6 +429,19 @@ func (r *ReconcileCephBlockPoolRadosNamespace) reconcile(request reconcile.Reque
 	return reconcile.Result{}, radosNamespace, nil
 }
 
+// collectUsage returns the capacity used by the images in the rados namespace
+func collectUsage(ctx context.Context, context *clusterd.Context, k8sClient client.Client, clusterInfo *cephclient.ClusterInfo, radosNamespace *cephv1.CephBlockPoolRadosNamespace) (*cephv1.UsageStatus, error) {
+	du, err := cephclient.GetRadosNamespaceUsage(context, clusterInfo, radosNamespace.Spec.BlockPoolName, cephv1.GetRadosNamespaceName(radosNamespace))
+	if err != nil {
+		return nil, err
+	}
+	return &cephv1.UsageStatus{
+		UsedBytes:        du.UsedBytes,
+		ProvisionedBytes: du.ProvisionedBytes,
+		Images:           du.Images,
+	}, nil
+}
+
 // Create the ceph blockpool rados namespace
 func (r *ReconcileCephBlockPoolRadosNamespace) createOrUpdateRadosNamespace(cephBlockPoolRadosNamespace *cephv1.CephBlockPoolRadosNamespace) error {
 	nsName := opcontroller.NsName(cephBlockPoolRadosNamespace.Namespace, cephBlockPoolRadosNamespace.Name)
@@ -28,6 +28,7 @@ import (
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/daemon/util"
 	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/util/tracing"
 	batch "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,6 +157,8 @@ func (cr *CmdReporter) Job() *batch.Job {
 func (cr *CmdReporter) Run(ctx context.Context, timeout time.Duration) (stdout, stderr string, retcode int, retErr error) {
 	jobName := cr.job.Name
 	namespace := cr.job.Namespace
+	ctx, span := tracing.StartChildSpan(ctx, "CmdReporter.Run", tracing.JobKey.String(jobName), tracing.NamespaceKey.String(namespace))
+	defer func() { tracing.EndSpan(span, retErr) }()
 	errMsg := fmt.Sprintf("failed to run CmdReporter %s successfully", jobName)
 
 	// the configmap MUST be deleted, because we will wait on its presence to determine when the
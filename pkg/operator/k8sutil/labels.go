@@ -55,7 +55,7 @@ func AddRecommendedLabels(labels map[string]string, appName, parentName, resourc
 	labels["app.kubernetes.io/name"] = appName
 	labels["app.kubernetes.io/instance"] = resourceInstance
 	labels["app.kubernetes.io/component"] = resourceKind
-	labels["app.kubernetes.io/part-of"] = parentName
+	labels[PartOfLabelKey] = parentName
 	labels["app.kubernetes.io/managed-by"] = "rook-ceph-operator"
 	labels["app.kubernetes.io/created-by"] = "rook-ceph-operator"
 	labels["rook.io/operator-namespace"] = os.Getenv(PodNamespaceEnvVar)
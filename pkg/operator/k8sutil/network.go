@@ -24,6 +24,7 @@ import (
 	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -70,6 +71,17 @@ func isClusterNetApp(app string) bool {
 	return app == "rook-ceph-osd"
 }
 
+// PodNetworkProvider returns the network provider a pod template was created with
+func PodNetworkProvider(template *corev1.PodTemplateSpec) cephv1.NetworkProviderType {
+	if template.Spec.HostNetwork {
+		return cephv1.NetworkProviderHost
+	}
+	if _, ok := template.Annotations[nadv1.NetworkAttachmentAnnot]; ok {
+		return cephv1.NetworkProviderMultus
+	}
+	return cephv1.NetworkProviderDefault
+}
+
 // ParseNetworkStatusAnnotation takes the annotation value from k8s.v1.cni.cncf.io/network-status
 // and returns the network status struct.
 func ParseNetworkStatusAnnotation(annotationValue string) ([]nadv1.NetworkStatus, error) {
@@ -22,6 +22,7 @@ import (
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -134,6 +135,18 @@ func TestApplyMultus(t *testing.T) {
 	}
 }
 
+func TestPodNetworkProvider(t *testing.T) {
+	template := &corev1.PodTemplateSpec{}
+	assert.Equal(t, cephv1.NetworkProviderDefault, PodNetworkProvider(template))
+
+	netSpec := &cephv1.NetworkSpec{Provider: cephv1.NetworkProviderMultus, Selectors: map[cephv1.CephNetworkType]string{cephv1.CephNetworkPublic: "public-net"}}
+	assert.NoError(t, ApplyMultus("rook-ceph", netSpec, &template.ObjectMeta))
+	assert.Equal(t, cephv1.NetworkProviderMultus, PodNetworkProvider(template))
+
+	template = &corev1.PodTemplateSpec{Spec: corev1.PodSpec{HostNetwork: true}}
+	assert.Equal(t, cephv1.NetworkProviderHost, PodNetworkProvider(template))
+}
+
 func TestParseLinuxIpAddrOutput(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -37,6 +37,8 @@ const (
 	AppAttr = "app"
 	// ClusterAttr cluster label
 	ClusterAttr = "rook_cluster"
+	// PartOfLabelKey is the recommended label with the name of the CR that owns a resource
+	PartOfLabelKey = "app.kubernetes.io/part-of"
 	// PublicIPEnvVar public IP env var
 	PublicIPEnvVar = "ROOK_PUBLIC_IP"
 	// PrivateIPEnvVar pod IP env var
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing configures OpenTelemetry tracing for the operator and provides helpers to record
+// spans around reconciles and calls out to Ceph.
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", "tracing")
+
+const (
+	tracerName  = "github.com/rook/rook"
+	serviceName = "rook-ceph-operator"
+
+	// maxCommandWords is the number of leading words of a Ceph CLI invocation recorded on a span.
+	// This is enough to identify the command (e.g. "osd pool create") without recording values
+	// that may be sensitive.
+	maxCommandWords = 3
+)
+
+// Attribute keys recorded on the spans created by Rook
+const (
+	ControllerKey = attribute.Key("rook.controller")
+	NamespaceKey  = attribute.Key("rook.namespace")
+	NameKey       = attribute.Key("rook.name")
+	ToolKey       = attribute.Key("ceph.tool")
+	CommandKey    = attribute.Key("ceph.command")
+	ActionKey     = attribute.Key("ceph.action")
+	AttemptsKey   = attribute.Key("ceph.attempts")
+	JobKey        = attribute.Key("rook.job")
+	StoreKey      = attribute.Key("rgw.store")
+	MethodKey     = attribute.Key("http.request.method")
+	PathKey       = attribute.Key("url.path")
+	StatusKey     = attribute.Key("http.response.status_code")
+	DaemonsKey    = attribute.Key("ceph.daemons")
+)
+
+// Config holds the settings used to export spans
+type Config struct {
+	// Endpoint is the host:port of the OTLP/HTTP collector. If empty, the OpenTelemetry default
+	// (localhost:4318 or the OTEL_EXPORTER_OTLP_ENDPOINT env var) is used.
+	Endpoint string
+	// Insecure disables TLS when connecting to the collector
+	Insecure bool
+	// SampleRatio is the fraction of new traces that are recorded, between 0 and 1
+	SampleRatio float64
+}
+
+// Setup installs a global tracer provider that exports spans to an OTLP collector over HTTP. The
+// returned function flushes any pending spans and must be called before the operator exits. Until
+// Setup is called, all spans are no-ops.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	opts := []otlptracehttp.Option{}
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build tracing resource")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	logger.Infof("tracing enabled, exporting spans to %q with sample ratio %v", endpointOrDefault(cfg.Endpoint), cfg.SampleRatio)
+
+	return provider.Shutdown, nil
+}
+
+func endpointOrDefault(endpoint string) string {
+	if endpoint == "" {
+		return "default OTLP endpoint"
+	}
+	return endpoint
+}
+
+// StartSpan starts a span as a child of any span found in ctx
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChildSpan starts a span as a child of the span found in ctx. If ctx carries no span, e.g. in
+// the background health checks of the operator, no span is recorded so that they don't start a new
+// trace for each call out to Ceph.
+func StartChildSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records the error, if any, on the span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// CommandName returns the leading words of a CLI invocation that identify the command, stopping at
+// the first flag. Later positional args are dropped since they may hold names or values that should
+// not be exported.
+func CommandName(args []string) string {
+	words := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") || len(words) == maxCommandWords {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestCommandName(t *testing.T) {
+	assert.Equal(t, "", CommandName(nil))
+	assert.Equal(t, "status", CommandName([]string{"status"}))
+	assert.Equal(t, "osd pool create", CommandName([]string{"osd", "pool", "create", "replicapool", "8"}))
+	assert.Equal(t, "config set", CommandName([]string{"config", "set", "--force", "global", "key", "value"}))
+	assert.Equal(t, "", CommandName([]string{"--pool", ".nfs", "add", "node"}))
+}
+
+func TestSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	t.Run("child of the reconcile span", func(t *testing.T) {
+		exporter.Reset()
+		reconcileCtx, parent := StartSpan(context.Background(), "parent")
+		_, child := StartChildSpan(reconcileCtx, "child", NameKey.String("my-store"))
+		EndSpan(child, nil)
+		EndSpan(parent, nil)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 2)
+		assert.Equal(t, "child", spans[0].Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		assert.Contains(t, spans[0].Attributes, NameKey.String("my-store"))
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("no child span without a parent", func(t *testing.T) {
+		exporter.Reset()
+		_, span := StartChildSpan(context.Background(), "health check")
+		EndSpan(span, errors.New("induced error"))
+		assert.False(t, span.IsRecording())
+		assert.Empty(t, exporter.GetSpans())
+	})
+
+	t.Run("error is recorded", func(t *testing.T) {
+		exporter.Reset()
+		_, span := StartSpan(context.Background(), "failing")
+		EndSpan(span, errors.New("induced error"))
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, "induced error", spans[0].Status.Description)
+	})
+}